@@ -82,6 +82,12 @@ type CatchpointCatchupService struct {
 	// the catchup due to an internal issue ( such as exceeding number of retries )
 	abortCtx     context.Context
 	abortCtxFunc context.CancelFunc
+	// ranker ranks the peers the blocks are downloaded from; nil if the peers are selected randomly.
+	ranker *peerRanker
+	// ledgerFetcher downloads the catchpoint file, and remembers which peers served it across the ledger download attempts
+	ledgerFetcher *ledgerFetcher
+	// ledgerVerificationAttempts is the number of times the catchpoint file was downloaded again after failing verification
+	ledgerVerificationAttempts int
 }
 
 // MakeResumedCatchpointCatchupService creates a catchpoint catchup service for a node that is already in catchpoint catchup mode
@@ -98,6 +104,9 @@ func MakeResumedCatchpointCatchupService(ctx context.Context, node CatchpointCat
 		ledger:         l,
 		config:         cfg,
 	}
+	if cfg.CatchupPeerSelection != catchupPeerSelectionRandom {
+		service.ranker = makePeerRanker()
+	}
 	service.lastBlockHeader, err = l.BlockHdr(l.Latest())
 	if err != nil {
 		return nil, err
@@ -129,6 +138,9 @@ func MakeNewCatchpointCatchupService(catchpoint string, node CatchpointCatchupNo
 		ledger:         l,
 		config:         cfg,
 	}
+	if cfg.CatchupPeerSelection != catchupPeerSelectionRandom {
+		service.ranker = makePeerRanker()
+	}
 	service.lastBlockHeader, err = l.BlockHdr(l.Latest())
 	if err != nil {
 		return nil, err
@@ -262,20 +274,53 @@ func (cs *CatchpointCatchupService) processStageLedgerDownload() (err error) {
 	}
 
 	// download balances file.
-	ledgerFetcher := makeLedgerFetcher(cs.net, cs.ledgerAccessor, cs.log, cs)
+	if cs.ledgerFetcher == nil {
+		cs.ledgerFetcher = makeLedgerFetcher(cs.net, cs.ledgerAccessor, cs.log, cs)
+	}
+	ledgerFetcher := cs.ledgerFetcher
 	attemptsCount := 0
 
+	parallelism := cs.config.CatchupLedgerDownloadParallelPeers
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
 	for {
 		attemptsCount++
 
-		err = cs.ledgerAccessor.ResetStagingBalances(cs.ctx, true)
-		if err != nil {
-			if cs.ctx.Err() != nil {
-				return cs.stopOrAbort()
+		// resume the download of the catchpoint file if a previous attempt, or a previous run of the node, got
+		// interrupted after processing some of it.
+		var progress ledger.CatchpointCatchupAccessorProgress
+		var processedSections []string
+		if !cs.newService || attemptsCount > 1 {
+			processedSections, err = cs.ledgerAccessor.ResumeStagingBalances(cs.ctx, &progress)
+			if err != nil {
+				cs.log.Warnf("processStageLedgerDownload unable to resume the ledger download, restarting it : %v", err)
+				progress = ledger.CatchpointCatchupAccessorProgress{}
+			}
+		}
+		if progress.SeenHeader {
+			cs.updateLedgerFetcherProgress(&progress)
+			err = ledgerFetcher.resumeLedgerDownload(cs.ctx, round, label, parallelism, &progress, processedSections)
+			if err == errLedgerSectionsUnsupported {
+				cs.log.Infof("processStageLedgerDownload unable to resume the ledger download, as no peer serves ranges of the catchpoint file")
+				progress.SeenHeader = false
+			}
+		}
+		if !progress.SeenHeader {
+			err = cs.ledgerAccessor.ResetStagingBalances(cs.ctx, true)
+			if err != nil {
+				if cs.ctx.Err() != nil {
+					return cs.stopOrAbort()
+				}
+				return cs.abort(fmt.Errorf("processStageLedgerDownload failed to reset staging balances : %v", err))
+			}
+			if parallelism > 1 {
+				err = ledgerFetcher.downloadLedgerParallel(cs.ctx, round, label, parallelism)
+			} else {
+				err = ledgerFetcher.downloadLedger(cs.ctx, round)
 			}
-			return cs.abort(fmt.Errorf("processStageLedgerDownload failed to reset staging balances : %v", err))
 		}
-		err = ledgerFetcher.downloadLedger(cs.ctx, round)
 		if err == nil {
 			break
 		}
@@ -308,6 +353,7 @@ func (cs *CatchpointCatchupService) processStageLastestBlockDownload() (err erro
 	}
 
 	fetcherFactory := MakeNetworkFetcherFactory(cs.net, 10, nil, &cs.config)
+	fetcherFactory.ranker = cs.ranker
 	attemptsCount := 0
 	var blk *bookkeeping.Block
 	var client FetcherClient
@@ -366,6 +412,15 @@ func (cs *CatchpointCatchupService) processStageLastestBlockDownload() (err erro
 			if cs.ctx.Err() != nil {
 				return cs.stopOrAbort()
 			}
+			if cs.ledgerFetcher != nil && cs.ledgerFetcher.hasSectionSources() && cs.ledgerVerificationAttempts < cs.config.CatchupLedgerDownloadRetryAttempts {
+				// the catchpoint file was downloaded from several peers, one of which may have served sections which
+				// don't belong to the catchpoint. Download it again, dropping the peers which served sections differing
+				// from the ones served this time.
+				cs.ledgerVerificationAttempts++
+				cs.log.Warnf("processStageLastestBlockDownload failed to verify the catchpoint, downloading the catchpoint file again : %v", err)
+				cs.ledgerFetcher.catchpointVerificationFailed()
+				return cs.restartLedgerDownload()
+			}
 			if attemptsCount <= cs.config.CatchupBlockDownloadRetryAttempts {
 				// try again.
 				blk = nil
@@ -410,6 +465,22 @@ func (cs *CatchpointCatchupService) processStageLastestBlockDownload() (err erro
 	return nil
 }
 
+// restartLedgerDownload discards the downloaded balances, and goes back to the ledger download stage.
+func (cs *CatchpointCatchupService) restartLedgerDownload() (err error) {
+	err = cs.ledgerAccessor.ResetStagingBalances(cs.ctx, true)
+	if err != nil {
+		if cs.ctx.Err() != nil {
+			return cs.stopOrAbort()
+		}
+		return cs.abort(fmt.Errorf("restartLedgerDownload failed to reset staging balances : %v", err))
+	}
+	err = cs.updateStage(ledger.CatchpointCatchupStateLedgerDownload)
+	if err != nil {
+		return cs.abort(fmt.Errorf("restartLedgerDownload failed to update stage to CatchpointCatchupStateLedgerDownload : %v", err))
+	}
+	return nil
+}
+
 // processStageBlocksDownload is the fourth catchpoint catchup stage. It downloads all the reminder of the blocks, verifying each one of them against it's predecessor.
 func (cs *CatchpointCatchupService) processStageBlocksDownload() (err error) {
 	topBlock, err := cs.ledgerAccessor.EnsureFirstBlock(cs.ctx)
@@ -436,44 +507,54 @@ func (cs *CatchpointCatchupService) processStageBlocksDownload() (err error) {
 
 	prevBlock := &topBlock
 	fetcherFactory := MakeNetworkFetcherFactory(cs.net, 10, nil, &cs.config)
-	blocksFetched := uint64(1) // we already got the first block in the previous step.
-	var blk *bookkeeping.Block
-	var client FetcherClient
-	for attemptsCount := uint64(1); blocksFetched <= lookback; attemptsCount++ {
-		if err := cs.ctx.Err(); err != nil {
-			return cs.stopOrAbort()
+	fetcherFactory.ranker = cs.ranker
+
+	// the blocks are downloaded concurrently, within a window of parallelRequests rounds below the last verified block,
+	// and verified one after the other in descending order, since each block authenticates its predecessor.
+	parallelRequests := cs.config.CatchupParallelBlocks
+	if parallelRequests == 0 {
+		parallelRequests = 1
+	}
+	ctx, cancel := context.WithCancel(cs.ctx)
+	defer cancel()
+	firstRound := topBlock.Round() - basics.Round(lookback)
+	nextRequest := topBlock.Round() - 1
+	pending := make([]<-chan catchpointBlockResult, 0, parallelRequests)
+	failedAttempts := 0
+	// retry requests the block at the head of the window again, unless we ran out of attempts.
+	retry := func(round basics.Round) bool {
+		failedAttempts++
+		if failedAttempts > cs.config.CatchupBlockDownloadRetryAttempts {
+			return false
+		}
+		pending[0] = cs.fetchCatchpointBlock(ctx, fetcherFactory, round)
+		return true
+	}
+	for blocksFetched := uint64(1); blocksFetched <= lookback; {
+		for uint64(len(pending)) < parallelRequests && nextRequest >= firstRound {
+			pending = append(pending, cs.fetchCatchpointBlock(ctx, fetcherFactory, nextRequest))
+			nextRequest--
 		}
 
-		blk = nil
-		// check to see if the current ledger might have this block. If so, we should try this first instead of downloading anything.
-		if ledgerBlock, err := cs.ledger.Block(topBlock.Round() - basics.Round(blocksFetched)); err == nil {
-			blk = &ledgerBlock
-		} else {
-			switch err.(type) {
-			case ledger.ErrNoEntry:
-				// this is expected, ignore this one.
-			default:
-				cs.log.Warnf("processStageBlocksDownload encountered the following error when attempting to retrieve the block for round %d : %v", topBlock.Round()-basics.Round(blocksFetched), err)
-			}
+		round := prevBlock.Round() - 1
+		var result catchpointBlockResult
+		select {
+		case result = <-pending[0]:
+		case <-cs.ctx.Done():
+			return cs.stopOrAbort()
 		}
 
-		if blk == nil {
-			fetcher := fetcherFactory.New()
-			blk, _, client, err = fetcher.FetchBlock(cs.ctx, topBlock.Round()-basics.Round(blocksFetched))
-			if err != nil {
-				if cs.ctx.Err() != nil {
-					return cs.stopOrAbort()
-				}
-				if attemptsCount <= uint64(cs.config.CatchupBlockDownloadRetryAttempts) {
-					// try again.
-					cs.log.Infof("Failed to download block %d on attempt %d out of %d. %v", topBlock.Round()-basics.Round(blocksFetched), attemptsCount, cs.config.CatchupBlockDownloadRetryAttempts, err)
-					continue
-				}
-				return cs.abort(fmt.Errorf("processStageBlocksDownload failed after multiple blocks download attempts"))
+		if result.err != nil {
+			if cs.ctx.Err() != nil {
+				return cs.stopOrAbort()
 			}
-			// success
-			client.Close()
+			if retry(round) {
+				cs.log.Infof("Failed to download block %d on attempt %d out of %d. %v", round, failedAttempts, cs.config.CatchupBlockDownloadRetryAttempts, result.err)
+				continue
+			}
+			return cs.abort(fmt.Errorf("processStageBlocksDownload failed after multiple blocks download attempts"))
 		}
+		blk := result.blk
 
 		cs.updateBlockRetrievalStatistics(1, 0)
 
@@ -482,8 +563,8 @@ func (cs *CatchpointCatchupService) processStageBlocksDownload() (err error) {
 			// not identical, retry download.
 			cs.log.Warnf("processStageBlocksDownload downloaded block(%d) did not match it's successor(%d) block hash %v != %v", blk.Round(), prevBlock.Round(), blk.Hash(), prevBlock.BlockHeader.Branch)
 			cs.updateBlockRetrievalStatistics(-1, 0)
-			if attemptsCount <= uint64(cs.config.CatchupBlockDownloadRetryAttempts) {
-				// try again.
+			cs.penalizePeer(result.client)
+			if retry(round) {
 				continue
 			}
 			return cs.abort(fmt.Errorf("processStageBlocksDownload downloaded block(%d) did not match it's successor(%d) block hash %v != %v", blk.Round(), prevBlock.Round(), blk.Hash(), prevBlock.BlockHeader.Branch))
@@ -493,8 +574,7 @@ func (cs *CatchpointCatchupService) processStageBlocksDownload() (err error) {
 		if _, ok := config.Consensus[blk.BlockHeader.CurrentProtocol]; !ok {
 			cs.log.Warnf("processStageBlocksDownload: unsupported protocol version detected: '%v'", blk.BlockHeader.CurrentProtocol)
 			cs.updateBlockRetrievalStatistics(-1, 0)
-			if attemptsCount <= uint64(cs.config.CatchupBlockDownloadRetryAttempts) {
-				// try again.
+			if retry(round) {
 				continue
 			}
 			return cs.abort(fmt.Errorf("processStageBlocksDownload: unsupported protocol version detected: '%v'", blk.BlockHeader.CurrentProtocol))
@@ -503,10 +583,9 @@ func (cs *CatchpointCatchupService) processStageBlocksDownload() (err error) {
 		// check to see that the block header and the block payset aligns
 		if !blk.ContentsMatchHeader() {
 			cs.log.Warnf("processStageBlocksDownload: downloaded block content does not match downloaded block header")
-			// try again.
 			cs.updateBlockRetrievalStatistics(-1, 0)
-			if attemptsCount <= uint64(cs.config.CatchupBlockDownloadRetryAttempts) {
-				// try again.
+			cs.penalizePeer(result.client)
+			if retry(round) {
 				continue
 			}
 			return cs.abort(fmt.Errorf("processStageBlocksDownload: downloaded block content does not match downloaded block header"))
@@ -519,13 +598,13 @@ func (cs *CatchpointCatchupService) processStageBlocksDownload() (err error) {
 		if err != nil {
 			cs.log.Warnf("processStageBlocksDownload failed to store downloaded staging block for round %d", blk.Round())
 			cs.updateBlockRetrievalStatistics(-1, -1)
-			if attemptsCount <= uint64(cs.config.CatchupBlockDownloadRetryAttempts) {
-				// try again.
+			if retry(round) {
 				continue
 			}
 			return cs.abort(fmt.Errorf("processStageBlocksDownload failed to store downloaded staging block for round %d", blk.Round()))
 		}
 		prevBlock = blk
+		pending = pending[1:]
 		blocksFetched++
 	}
 
@@ -536,6 +615,49 @@ func (cs *CatchpointCatchupService) processStageBlocksDownload() (err error) {
 	return nil
 }
 
+// catchpointBlockResult is the outcome of retrieving a block during the blocks download stage.
+type catchpointBlockResult struct {
+	blk *bookkeeping.Block
+	// client is the client the block was downloaded from, or nil if the block was found in the current ledger.
+	client FetcherClient
+	err    error
+}
+
+// fetchCatchpointBlock retrieves the block of the given round in the background, and delivers it on the returned channel.
+func (cs *CatchpointCatchupService) fetchCatchpointBlock(ctx context.Context, fetcherFactory FetcherFactory, round basics.Round) <-chan catchpointBlockResult {
+	out := make(chan catchpointBlockResult, 1)
+	go func() {
+		// check to see if the current ledger might have this block. If so, we should try this first instead of downloading anything.
+		ledgerBlock, err := cs.ledger.Block(round)
+		if err == nil {
+			out <- catchpointBlockResult{blk: &ledgerBlock}
+			return
+		}
+		switch err.(type) {
+		case ledger.ErrNoEntry:
+			// this is expected, ignore this one.
+		default:
+			cs.log.Warnf("processStageBlocksDownload encountered the following error when attempting to retrieve the block for round %d : %v", round, err)
+		}
+
+		fetcher := fetcherFactory.New()
+		defer fetcher.Close()
+		blk, _, client, err := fetcher.FetchBlock(ctx, round)
+		if err == nil {
+			client.Close()
+		}
+		out <- catchpointBlockResult{blk: blk, client: client, err: err}
+	}()
+	return out
+}
+
+// penalizePeer lowers the ranking of a peer which served a bad block.
+func (cs *CatchpointCatchupService) penalizePeer(client FetcherClient) {
+	if cs.ranker != nil && client != nil {
+		cs.ranker.penalize(client.Address())
+	}
+}
+
 // processStageLedgerDownload is the fifth catchpoint catchup stage. It completes the catchup process, swap the new tables and restart the node functionality.
 func (cs *CatchpointCatchupService) processStageSwitch() (err error) {
 	err = cs.ledgerAccessor.CompleteCatchup(cs.ctx)
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"time"
 
 	"github.com/algorand/go-deadlock"
 
@@ -64,6 +65,9 @@ type NetworkFetcherFactory struct {
 	fs        *rpcs.WsFetcherService
 	cfg       *config.Local
 
+	// ranker ranks the peers the fetchers select from, if not nil. Otherwise the peers are selected randomly.
+	ranker *peerRanker
+
 	log logging.Logger
 }
 
@@ -112,6 +116,7 @@ func (factory NetworkFetcherFactory) New() Fetcher {
 		roundUpperBound: make(map[FetcherClient]basics.Round),
 		activeFetches:   make(map[FetcherClient]int),
 		peers:           factory.BuildFetcherClients(),
+		ranker:          factory.ranker,
 		log:             logging.Base(),
 	}
 }
@@ -140,6 +145,7 @@ type NetworkFetcher struct {
 	roundUpperBound map[FetcherClient]basics.Round
 	activeFetches   map[FetcherClient]int
 	peers           []FetcherClient
+	ranker          *peerRanker
 	mu              deadlock.RWMutex
 	log             logging.Logger
 }
@@ -177,18 +183,39 @@ func (networkFetcher *NetworkFetcher) availablePeers(round basics.Round) []Fetch
 	return pool
 }
 
+// peersWithRound returns the clients which may have the block of the given round.
+func (networkFetcher *NetworkFetcher) peersWithRound(round basics.Round) []FetcherClient {
+	pool := make([]FetcherClient, 0, len(networkFetcher.peers))
+	for _, client := range networkFetcher.peers {
+		if roundUpperBound, exists := networkFetcher.roundUpperBound[client]; !exists || round < roundUpperBound {
+			pool = append(pool, client)
+		}
+	}
+	return pool
+}
+
 func (networkFetcher *NetworkFetcher) selectClient(r basics.Round) (FetcherClient, error) {
 	networkFetcher.mu.Lock()
 	defer networkFetcher.mu.Unlock()
 
-	availableClients := networkFetcher.availablePeers(r)
-	if len(availableClients) == 0 {
-		return nil, errors.New("no peers to ask")
-	}
+	var client FetcherClient
+	if networkFetcher.ranker != nil {
+		// select the best ranked peer, the ranker spreads the load according to the active fetches
+		availableClients := networkFetcher.peersWithRound(r)
+		if len(availableClients) == 0 {
+			return nil, errors.New("no peers to ask")
+		}
+		client = networkFetcher.ranker.selectClient(availableClients, networkFetcher.activeFetches)
+	} else {
+		availableClients := networkFetcher.availablePeers(r)
+		if len(availableClients) == 0 {
+			return nil, errors.New("no peers to ask")
+		}
 
-	// select one of the peers at random
-	i := rand.Uint64() % uint64(len(availableClients))
-	client := availableClients[i]
+		// select one of the peers at random
+		i := rand.Uint64() % uint64(len(availableClients))
+		client = availableClients[i]
+	}
 	networkFetcher.activeFetches[client] = networkFetcher.activeFetches[client] + 1
 	return client, nil
 }
@@ -218,17 +245,28 @@ func (networkFetcher *NetworkFetcher) FetchBlock(ctx context.Context, r basics.R
 	defer networkFetcher.releaseClient(client)
 	networkFetcher.log.Infof("networkFetcher.FetchBlock: asking client %v for block %v", client.Address(), r)
 
+	start := time.Now()
 	fetchedBuf, err := client.GetBlockBytes(ctx, r)
 	if err != nil {
 		networkFetcher.markPeerLastRound(client, r)
+		// neither a peer which doesn't have the block yet nor a request we canceled is the peer's fault
+		if networkFetcher.ranker != nil && err != errNoBlockForRound && ctx.Err() == nil {
+			networkFetcher.ranker.fetchFailed(client.Address())
+		}
 		err = fmt.Errorf("Peer %v: %v", client.Address(), err)
 		return
 	}
 	block, cert, err := processBlockBytes(fetchedBuf, r, client.Address())
 	if err != nil {
 		networkFetcher.markPeerLastRound(client, r)
+		if networkFetcher.ranker != nil {
+			networkFetcher.ranker.penalize(client.Address())
+		}
 		return
 	}
+	if networkFetcher.ranker != nil {
+		networkFetcher.ranker.fetchSucceeded(client.Address(), len(fetchedBuf), time.Since(start))
+	}
 	return block, cert, client, nil
 }
 
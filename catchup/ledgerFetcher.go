@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"path"
 	"strconv"
 	"time"
@@ -51,6 +52,7 @@ const (
 
 var errNoPeersAvailable = fmt.Errorf("downloadLedger : no peers are available")
 var errNonHTTPPeer = fmt.Errorf("downloadLedger : non-HTTPPeer encountered")
+var errLedgerSectionsUnsupported = fmt.Errorf("getPeerLedger : peer does not serve ranges of the catchpoint file")
 
 type ledgerFetcherReporter interface {
 	updateLedgerFetcherProgress(*ledger.CatchpointCatchupAccessorProgress)
@@ -62,14 +64,21 @@ type ledgerFetcher struct {
 	log      logging.Logger
 	peers    []network.Peer
 	reporter ledgerFetcherReporter
+	// sources are the peers which served the sections processed by the current parallel download
+	sources map[string]ledgerSectionSource
+	// failedSources are the peers which served the sections of the last download failing the catchpoint verification
+	failedSources map[string]ledgerSectionSource
+	// droppedPeers are the peers blamed for a failed catchpoint verification, which aren't downloaded from anymore
+	droppedPeers map[string]bool
 }
 
 func makeLedgerFetcher(net network.GossipNode, accessor ledger.CatchpointCatchupAccessor, log logging.Logger, reporter ledgerFetcherReporter) *ledgerFetcher {
 	return &ledgerFetcher{
-		net:      net,
-		accessor: accessor,
-		log:      log,
-		reporter: reporter,
+		net:          net,
+		accessor:     accessor,
+		log:          log,
+		reporter:     reporter,
+		droppedPeers: make(map[string]bool),
 	}
 }
 
@@ -89,12 +98,36 @@ func (lf *ledgerFetcher) downloadLedger(ctx context.Context, round basics.Round)
 }
 
 func (lf *ledgerFetcher) getPeerLedger(ctx context.Context, peer network.HTTPPeer, round basics.Round) error {
+	var downloadProgress ledger.CatchpointCatchupAccessorProgress
+	return lf.getPeerLedgerSections(ctx, peer, round, nil, func(section ledgerSection) error {
+		err := lf.processBalancesBlock(ctx, section.name, section.bytes, &downloadProgress)
+		if err != nil {
+			return err
+		}
+		if lf.reporter != nil {
+			lf.reporter.updateLedgerFetcherProgress(&downloadProgress)
+		}
+		return nil
+	})
+}
+
+// getPeerLedgerSections downloads the given range of sections of the catchpoint file from a peer, or the whole file if
+// sections is nil, and hands each of them to the handle function as it arrives.
+func (lf *ledgerFetcher) getPeerLedgerSections(ctx context.Context, peer network.HTTPPeer, round basics.Round, sections *ledgerSectionRange, handle func(ledgerSection) error) error {
 	parsedURL, err := network.ParseHostOrURL(peer.GetAddress())
 	if err != nil {
 		return err
 	}
 
 	parsedURL.Path = peer.PrepareURL(path.Join(parsedURL.Path, "/v1/{genesisID}/ledger/"+strconv.FormatUint(uint64(round), 36)))
+	if sections != nil {
+		query := url.Values{}
+		query.Set("from", strconv.FormatUint(sections.from, 10))
+		if sections.to != rpcs.LedgerSectionsEnd {
+			query.Set("to", strconv.FormatUint(sections.to, 10))
+		}
+		parsedURL.RawQuery = query.Encode()
+	}
 	ledgerURL := parsedURL.String()
 	lf.log.Debugf("ledger GET %#v peer %#v %T", ledgerURL, peer, peer)
 	request, err := http.NewRequest(http.MethodGet, ledgerURL, nil)
@@ -133,10 +166,12 @@ func (lf *ledgerFetcher) getPeerLedger(ctx context.Context, peer network.HTTPPee
 		err = fmt.Errorf("getPeerLedger : http ledger fetcher response has an invalid content type : %s", contentTypes[0])
 		return err
 	}
+	if sections != nil && response.Header.Get(rpcs.LedgerSectionsHeader) == "" {
+		return errLedgerSectionsUnsupported
+	}
 	watchdogReader := makeWatchdogStreamReader(response.Body, catchpointFileStreamReadSize, 2*maxCatchpointFileChunkSize, maxCatchpointFileChunkDownloadDuration)
 	defer watchdogReader.Close()
 	tarReader := tar.NewReader(watchdogReader)
-	var downloadProgress ledger.CatchpointCatchupAccessorProgress
 	for {
 		header, err := tarReader.Next()
 		if err != nil {
@@ -164,13 +199,10 @@ func (lf *ledgerFetcher) getPeerLedger(ctx context.Context, peer network.HTTPPee
 				return err
 			}
 		}
-		err = lf.processBalancesBlock(ctx, header.Name, balancesBlockBytes, &downloadProgress)
+		err = handle(ledgerSection{name: header.Name, bytes: balancesBlockBytes, peer: peer.GetAddress()})
 		if err != nil {
 			return err
 		}
-		if lf.reporter != nil {
-			lf.reporter.updateLedgerFetcherProgress(&downloadProgress)
-		}
 		if err = watchdogReader.Reset(); err != nil {
 			if err == io.EOF {
 				return nil
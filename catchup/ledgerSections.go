@@ -0,0 +1,474 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package catchup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/ledger"
+	"github.com/algorand/go-algorand/network"
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/algorand/go-algorand/rpcs"
+)
+
+// ledgerRangesPerPeer is the number of ranges of balances sections per downloading peer the catchpoint file is split
+// into, so that the faster peers end up serving more of the file.
+const ledgerRangesPerPeer = 4
+
+// ledgerSection is a section of the catchpoint file, i.e. one of the files of its tar archive.
+type ledgerSection struct {
+	name  string
+	bytes []byte
+	// peer is the address of the peer which served the section
+	peer string
+}
+
+// ledgerSectionSource is the peer which served a section of the catchpoint file, along with the digest of the section.
+type ledgerSectionSource struct {
+	peer   string
+	digest crypto.Digest
+}
+
+// ledgerSectionRange is the range of the sections of the catchpoint file whose index is in [from, to). The header is
+// the section at index 0, followed by the balances sections and then by the key/value sections.
+type ledgerSectionRange struct {
+	from uint64
+	to   uint64
+}
+
+// ledgerSectionQueue hands out the ranges of sections to download and the peers to download them from to the
+// download threads.
+type ledgerSectionQueue struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	ranges   []ledgerSectionRange
+	peers    []network.HTTPPeer
+	inFlight int
+	closed   bool
+}
+
+func makeLedgerSectionQueue(ranges []ledgerSectionRange, peers []network.HTTPPeer) *ledgerSectionQueue {
+	queue := &ledgerSectionQueue{
+		ranges: ranges,
+		peers:  peers,
+	}
+	queue.cond = sync.NewCond(&queue.mu)
+	return queue
+}
+
+// nextPeer returns a peer no range was downloaded from yet, if any.
+func (q *ledgerSectionQueue) nextPeer() (network.HTTPPeer, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.peers) == 0 {
+		return nil, false
+	}
+	peer := q.peers[0]
+	q.peers = q.peers[1:]
+	return peer, true
+}
+
+// nextRange returns the next range to download. If no range is left but some are being downloaded, it waits until
+// they either complete or get returned to the queue by a failing download thread.
+func (q *ledgerSectionQueue) nextRange() (ledgerSectionRange, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for len(q.ranges) == 0 && q.inFlight > 0 && !q.closed {
+		q.cond.Wait()
+	}
+	if len(q.ranges) == 0 || q.closed {
+		return ledgerSectionRange{}, false
+	}
+	r := q.ranges[0]
+	q.ranges = q.ranges[1:]
+	q.inFlight++
+	return r, true
+}
+
+// done reports the completion of a range returned by nextRange, returning the given remainder of the range to the
+// queue if it is not empty.
+func (q *ledgerSectionQueue) done(remainder ledgerSectionRange) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if remainder.from < remainder.to {
+		q.ranges = append(q.ranges, remainder)
+	}
+	q.inFlight--
+	q.cond.Broadcast()
+}
+
+// remaining returns the number of ranges which weren't downloaded.
+func (q *ledgerSectionQueue) remaining() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.ranges) + q.inFlight
+}
+
+// close wakes up the download threads waiting for a range, and lets them exit.
+func (q *ledgerSectionQueue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	q.cond.Broadcast()
+}
+
+// httpPeers returns the phonebook peers which the catchpoint file can be downloaded from.
+func (lf *ledgerFetcher) httpPeers() []network.HTTPPeer {
+	var peers []network.HTTPPeer
+	for _, peer := range lf.net.GetPeers(network.PeersPhonebook) {
+		if httpPeer, ok := peer.(network.HTTPPeer); ok && !lf.droppedPeers[httpPeer.GetAddress()] {
+			peers = append(peers, httpPeer)
+		}
+	}
+	return peers
+}
+
+// recordSectionSource records the peer which served a processed section. If the section differs from the one served for
+// a download which failed the catchpoint verification, the peer which served the latter gets dropped.
+func (lf *ledgerFetcher) recordSectionSource(section ledgerSection) {
+	source := ledgerSectionSource{peer: section.peer, digest: crypto.Hash(section.bytes)}
+	if failed, has := lf.failedSources[section.name]; has && failed.digest != source.digest && !lf.droppedPeers[failed.peer] {
+		lf.log.Warnf("recordSectionSource : section '%s' served by %s differs from the one served by %s for the catchpoint file which failed verification, dropping %s", section.name, source.peer, failed.peer, failed.peer)
+		lf.droppedPeers[failed.peer] = true
+	}
+	if lf.sources == nil {
+		lf.sources = make(map[string]ledgerSectionSource)
+	}
+	lf.sources[section.name] = source
+}
+
+// hasSectionSources returns whether the peers which served the sections of the catchpoint file were recorded, i.e.
+// whether the catchpoint file was downloaded by ranges from several peers.
+func (lf *ledgerFetcher) hasSectionSources() bool {
+	return len(lf.sources) > 0
+}
+
+// catchpointVerificationFailed reports the peers which served the sections of the downloaded catchpoint file, which
+// failed the catchpoint verification. These are kept, so that the peers which served sections differing from the ones
+// of the next download get dropped.
+func (lf *ledgerFetcher) catchpointVerificationFailed() {
+	peerSections := make(map[string]int)
+	for _, source := range lf.sources {
+		peerSections[source.peer]++
+	}
+	for peer, count := range peerSections {
+		lf.log.Infof("catchpointVerificationFailed : %d sections of the catchpoint file were served by %s", count, peer)
+	}
+	lf.failedSources = lf.sources
+	lf.sources = nil
+}
+
+// downloadLedgerParallel downloads the catchpoint file of the given round from up to parallelism peers concurrently.
+// The header of the file is downloaded first, and verified against the catchpoint label. The balances sections are
+// then split into ranges, which are downloaded from different peers and processed as they arrive, and the key/value
+// sections are downloaded last, since they can only be processed once all the balances were. A peer failing to serve a
+// range, or serving sections which don't belong to the catchpoint, is dropped and the rest of its range is downloaded
+// from another peer; each peer has its header verified as well before any range is downloaded from it. If the first
+// peer serving the header doesn't serve ranges of the catchpoint file, the whole file is downloaded from it. The peer
+// serving each section is recorded, so that the peers serving sections which don't match the catchpoint can be dropped
+// if the downloaded file fails the catchpoint verification.
+func (lf *ledgerFetcher) downloadLedgerParallel(ctx context.Context, round basics.Round, label string, parallelism int) error {
+	lf.sources = nil
+	peers := lf.httpPeers()
+	if len(peers) == 0 {
+		return errNoPeersAvailable
+	}
+
+	var progress ledger.CatchpointCatchupAccessorProgress
+	var err error
+	for _, peer := range peers {
+		err = lf.getPeerLedgerSections(ctx, peer, round, &ledgerSectionRange{from: 0, to: 1}, func(section ledgerSection) error {
+			err := verifyCatchpointHeader(section, round, label)
+			if err != nil {
+				return err
+			}
+			return lf.processSection(ctx, section, &progress)
+		})
+		if err == errLedgerSectionsUnsupported {
+			return lf.getPeerLedger(ctx, peer, round)
+		}
+		if err == nil && progress.SeenHeader {
+			break
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		lf.log.Infof("downloadLedgerParallel : unable to download the catchpoint file header from %s : %v", peer.GetAddress(), err)
+	}
+	if !progress.SeenHeader {
+		if err == nil {
+			err = fmt.Errorf("downloadLedgerParallel : no peer served the catchpoint file header")
+		}
+		return err
+	}
+
+	return lf.downloadRemainingSections(ctx, round, label, peers, parallelism, &progress, nil)
+}
+
+// resumeLedgerDownload downloads the sections of the catchpoint file of the given round which weren't processed by an
+// interrupted download, from up to parallelism peers concurrently. It returns errLedgerSectionsUnsupported if none of
+// the peers serves ranges of the catchpoint file, in which case the whole file needs to be downloaded again.
+func (lf *ledgerFetcher) resumeLedgerDownload(ctx context.Context, round basics.Round, label string, parallelism int, progress *ledger.CatchpointCatchupAccessorProgress, processedSections []string) error {
+	peers := lf.httpPeers()
+	if len(peers) == 0 {
+		return errNoPeersAvailable
+	}
+
+	err := errLedgerSectionsUnsupported
+	unsupported := 0
+	for _, peer := range peers {
+		err = lf.verifyPeerHeader(ctx, peer, round, label)
+		if err == nil {
+			break
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err == errLedgerSectionsUnsupported {
+			unsupported++
+		}
+	}
+	if unsupported == len(peers) {
+		return errLedgerSectionsUnsupported
+	}
+	if err != nil {
+		return err
+	}
+	return lf.downloadRemainingSections(ctx, round, label, peers, parallelism, progress, processedSections)
+}
+
+// downloadRemainingSections downloads the sections following the header of the catchpoint file which aren't in the given
+// processed sections. The balances sections are downloaded from up to parallelism peers concurrently, and the sections
+// following them are downloaded in order, once all the balances were processed.
+func (lf *ledgerFetcher) downloadRemainingSections(ctx context.Context, round basics.Round, label string, peers []network.HTTPPeer, parallelism int, progress *ledger.CatchpointCatchupAccessorProgress, processedSections []string) error {
+	totalChunks := progress.TotalChunks
+	rangeSize := totalChunks / uint64(parallelism*ledgerRangesPerPeer)
+	if rangeSize == 0 {
+		rangeSize = 1
+	}
+	balances, followingFrom := remainingSectionRanges(totalChunks, processedSections)
+	err := lf.downloadSectionRanges(ctx, round, label, peers, splitSectionRanges(balances, rangeSize), parallelism, totalChunks, progress)
+	if err != nil {
+		return err
+	}
+	return lf.downloadSectionRanges(ctx, round, label, peers, []ledgerSectionRange{{from: followingFrom, to: rpcs.LedgerSectionsEnd}}, 1, totalChunks, progress)
+}
+
+// remainingSectionRanges returns the ranges of balances sections of the catchpoint file which aren't in the given
+// processed sections, and the index of the first section following the balances which wasn't processed.
+func remainingSectionRanges(totalChunks uint64, processedSections []string) (balances []ledgerSectionRange, followingFrom uint64) {
+	processed := make(map[string]bool, len(processedSections))
+	following := uint64(0)
+	for _, name := range processedSections {
+		processed[name] = true
+		if name != "content.msgpack" && !strings.HasPrefix(name, "balances.") {
+			following++
+		}
+	}
+	for index := uint64(1); index <= totalChunks; index++ {
+		if processed[fmt.Sprintf("balances.%d.%d.msgpack", index, totalChunks)] {
+			continue
+		}
+		if len(balances) > 0 && balances[len(balances)-1].to == index {
+			balances[len(balances)-1].to++
+			continue
+		}
+		balances = append(balances, ledgerSectionRange{from: index, to: index + 1})
+	}
+	return balances, totalChunks + 1 + following
+}
+
+// splitSectionRanges splits the given ranges into ranges of at most rangeSize sections.
+func splitSectionRanges(ranges []ledgerSectionRange, rangeSize uint64) (split []ledgerSectionRange) {
+	for _, r := range ranges {
+		for from := r.from; from < r.to; from += rangeSize {
+			to := from + rangeSize
+			if to > r.to {
+				to = r.to
+			}
+			split = append(split, ledgerSectionRange{from: from, to: to})
+		}
+	}
+	return split
+}
+
+// verifyCatchpointHeader checks that the header section of a catchpoint file belongs to the catchpoint we're catching
+// up to.
+func verifyCatchpointHeader(section ledgerSection, round basics.Round, label string) error {
+	if section.name != "content.msgpack" {
+		return fmt.Errorf("verifyCatchpointHeader : unexpected section '%s' instead of the catchpoint file header", section.name)
+	}
+	var header ledger.CatchpointFileHeader
+	err := protocol.Decode(section.bytes, &header)
+	if err != nil {
+		return err
+	}
+	if header.Catchpoint != label || header.BlocksRound != round {
+		return fmt.Errorf("verifyCatchpointHeader : catchpoint file header is for catchpoint '%s' of round %d instead of '%s'", header.Catchpoint, header.BlocksRound, label)
+	}
+	return nil
+}
+
+// verifySectionName checks that a section at the given index of the catchpoint file is the expected one.
+func verifySectionName(section ledgerSection, index, totalChunks uint64) error {
+	if index == 0 || index > totalChunks {
+		// the sections following the balances are processed in order, and checked by the catchup accessor.
+		return nil
+	}
+	expected := fmt.Sprintf("balances.%d.%d.msgpack", index, totalChunks)
+	if section.name != expected {
+		return fmt.Errorf("verifySectionName : unexpected section '%s' instead of '%s'", section.name, expected)
+	}
+	return nil
+}
+
+// processSection processes a section of the catchpoint file, and reports the progress.
+func (lf *ledgerFetcher) processSection(ctx context.Context, section ledgerSection, progress *ledger.CatchpointCatchupAccessorProgress) error {
+	err := lf.processBalancesBlock(ctx, section.name, section.bytes, progress)
+	if err != nil {
+		return err
+	}
+	lf.recordSectionSource(section)
+	if lf.reporter != nil {
+		lf.reporter.updateLedgerFetcherProgress(progress)
+	}
+	return nil
+}
+
+// downloadSectionRanges downloads the given ranges of sections concurrently from up to parallelism peers, and processes
+// the sections on the calling goroutine as they arrive.
+func (lf *ledgerFetcher) downloadSectionRanges(ctx context.Context, round basics.Round, label string, peers []network.HTTPPeer, ranges []ledgerSectionRange, parallelism int, totalChunks uint64, progress *ledger.CatchpointCatchupAccessorProgress) error {
+	if len(ranges) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	queue := makeLedgerSectionQueue(ranges, append([]network.HTTPPeer(nil), peers...))
+	sections := make(chan ledgerSection, parallelism)
+	var wg sync.WaitGroup
+	for i := 0; i < parallelism && i < len(peers); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			lf.sectionsDownloadThread(ctx, round, label, queue, totalChunks, sections)
+		}()
+	}
+	go func() {
+		<-ctx.Done()
+		queue.close()
+	}()
+	go func() {
+		wg.Wait()
+		close(sections)
+	}()
+
+	for section := range sections {
+		err := lf.processSection(ctx, section, progress)
+		if err != nil {
+			cancel()
+			for range sections {
+			}
+			return err
+		}
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if remaining := queue.remaining(); remaining > 0 {
+		return fmt.Errorf("downloadSectionRanges : no peers left to download the remaining %d ranges of the catchpoint file", remaining)
+	}
+	return nil
+}
+
+// verifyPeerHeader downloads the header of the catchpoint file from a peer, and checks that it belongs to the catchpoint.
+func (lf *ledgerFetcher) verifyPeerHeader(ctx context.Context, peer network.HTTPPeer, round basics.Round, label string) error {
+	verified := false
+	err := lf.getPeerLedgerSections(ctx, peer, round, &ledgerSectionRange{from: 0, to: 1}, func(section ledgerSection) error {
+		err := verifyCatchpointHeader(section, round, label)
+		verified = err == nil
+		return err
+	})
+	if err == nil && !verified {
+		err = fmt.Errorf("verifyPeerHeader : no catchpoint file header received")
+	}
+	return err
+}
+
+// nextVerifiedPeer returns the next peer of the queue serving the catchpoint file of the given label, if any.
+func (lf *ledgerFetcher) nextVerifiedPeer(ctx context.Context, round basics.Round, label string, queue *ledgerSectionQueue) (network.HTTPPeer, bool) {
+	for {
+		peer, ok := queue.nextPeer()
+		if !ok {
+			return nil, false
+		}
+		err := lf.verifyPeerHeader(ctx, peer, round, label)
+		if err == nil {
+			return peer, true
+		}
+		if ctx.Err() != nil {
+			return nil, false
+		}
+		lf.log.Infof("nextVerifiedPeer : unable to verify the catchpoint file header of %s : %v", peer.GetAddress(), err)
+	}
+}
+
+// sectionsDownloadThread downloads ranges of sections from a peer, until no range is left or no peer is left to
+// replace the ones failing to serve their range.
+func (lf *ledgerFetcher) sectionsDownloadThread(ctx context.Context, round basics.Round, label string, queue *ledgerSectionQueue, totalChunks uint64, sections chan<- ledgerSection) {
+	peer, ok := lf.nextVerifiedPeer(ctx, round, label, queue)
+	for ok {
+		r, has := queue.nextRange()
+		if !has {
+			return
+		}
+		index := r.from
+		err := lf.getPeerLedgerSections(ctx, peer, round, &r, func(section ledgerSection) error {
+			if index >= r.to {
+				return fmt.Errorf("sectionsDownloadThread : received more sections than requested")
+			}
+			err := verifySectionName(section, index, totalChunks)
+			if err != nil {
+				return err
+			}
+			select {
+			case sections <- section:
+				index++
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		if err == nil && index < r.to && r.to != rpcs.LedgerSectionsEnd {
+			err = fmt.Errorf("sectionsDownloadThread : received %d sections out of %d", index-r.from, r.to-r.from)
+		}
+		if err == nil {
+			queue.done(ledgerSectionRange{})
+			continue
+		}
+		queue.done(ledgerSectionRange{from: index, to: r.to})
+		if ctx.Err() != nil {
+			return
+		}
+		lf.log.Infof("sectionsDownloadThread : unable to download sections %d-%d of the catchpoint file from %s : %v", index, r.to, peer.GetAddress(), err)
+		peer, ok = lf.nextVerifiedPeer(ctx, round, label, queue)
+	}
+}
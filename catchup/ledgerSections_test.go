@@ -0,0 +1,305 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package catchup
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-deadlock"
+
+	"github.com/algorand/go-algorand/components/mocks"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/ledger"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/algorand/go-algorand/rpcs"
+)
+
+// sectionsTestAccessor records the sections of the catchpoint file it is asked to process.
+type sectionsTestAccessor struct {
+	mocks.MockCatchpointCatchupAccessor
+	mu       deadlock.Mutex
+	sections map[string]int
+}
+
+func (a *sectionsTestAccessor) ProgressStagingBalances(ctx context.Context, sectionName string, bytes []byte, progress *ledger.CatchpointCatchupAccessorProgress) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if sectionName == "content.msgpack" {
+		var header ledger.CatchpointFileHeader
+		err := protocol.Decode(bytes, &header)
+		if err != nil {
+			return err
+		}
+		progress.SeenHeader = true
+		progress.TotalChunks = header.TotalChunks
+	}
+	a.sections[sectionName]++
+	return nil
+}
+
+// sectionsTestServer serves a catchpoint file, either by ranges of sections or as a whole.
+type sectionsTestServer struct {
+	sections []ledgerSection
+	// ranges is whether the server serves ranges of sections
+	ranges bool
+	// failAfter makes the server fail after serving the given number of sections of a range, if positive
+	failAfter int
+	listener  net.Listener
+	// rangeRequests is the number of requests for sections other than the header
+	rangeRequests int32
+}
+
+func makeTestCatchpointSections(label string, round basics.Round, totalChunks, kvChunks int) []ledgerSection {
+	header := ledger.CatchpointFileHeader{
+		BlocksRound: round,
+		TotalChunks: uint64(totalChunks),
+		Catchpoint:  label,
+	}
+	sections := []ledgerSection{{name: "content.msgpack", bytes: protocol.Encode(&header)}}
+	for i := 1; i <= totalChunks; i++ {
+		sections = append(sections, ledgerSection{name: fmt.Sprintf("balances.%d.%d.msgpack", i, totalChunks), bytes: []byte{byte(i)}})
+	}
+	for i := 1; i <= kvChunks; i++ {
+		sections = append(sections, ledgerSection{name: fmt.Sprintf("kvs.%d.msgpack", i), bytes: []byte{byte(i)}})
+	}
+	return sections
+}
+
+func startSectionsTestServer(t *testing.T, sections []ledgerSection, ranges bool, failAfter int) *sectionsTestServer {
+	s := &sectionsTestServer{sections: sections, ranges: ranges, failAfter: failAfter}
+	listener, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err)
+	s.listener = listener
+	go http.Serve(listener, s)
+	return s
+}
+
+func (s *sectionsTestServer) ServeHTTP(response http.ResponseWriter, request *http.Request) {
+	from, to := uint64(0), uint64(len(s.sections))
+	query := request.URL.Query()
+	if s.ranges && query.Get("from") != "" {
+		from, _ = strconv.ParseUint(query.Get("from"), 10, 64)
+		if query.Get("to") != "" {
+			to, _ = strconv.ParseUint(query.Get("to"), 10, 64)
+		}
+		response.Header().Set(rpcs.LedgerSectionsHeader, query.Get("from")+"-"+query.Get("to"))
+		if from > 0 {
+			atomic.AddInt32(&s.rangeRequests, 1)
+		}
+	}
+	response.Header().Set("Content-Type", rpcs.LedgerResponseContentType)
+	tarWriter := tar.NewWriter(response)
+	for i := from; i < to && i < uint64(len(s.sections)); i++ {
+		size := int64(len(s.sections[i].bytes))
+		if s.failAfter > 0 && i == from+uint64(s.failAfter) {
+			// announce more bytes than are sent, so that the download fails
+			tarWriter.WriteHeader(&tar.Header{Name: s.sections[i].name, Mode: 0600, Size: size + 100})
+			tarWriter.Write(s.sections[i].bytes)
+			return
+		}
+		tarWriter.WriteHeader(&tar.Header{Name: s.sections[i].name, Mode: 0600, Size: size})
+		tarWriter.Write(s.sections[i].bytes)
+	}
+	tarWriter.Close()
+}
+
+func (s *sectionsTestServer) address() string {
+	return s.listener.Addr().String()
+}
+
+func TestDownloadLedgerParallel(t *testing.T) {
+	const label = "5#AAAA"
+	round := basics.Round(5)
+	sections := makeTestCatchpointSections(label, round, 50, 3)
+
+	// a good peer, a peer which fails in the middle of each range, and a peer of another catchpoint
+	good := startSectionsTestServer(t, sections, true, 0)
+	defer good.listener.Close()
+	failing := startSectionsTestServer(t, sections, true, 2)
+	defer failing.listener.Close()
+	other := startSectionsTestServer(t, makeTestCatchpointSections("5#BBBB", round, 50, 3), true, 0)
+	defer other.listener.Close()
+
+	for _, peers := range [][]string{{other.address(), good.address(), failing.address()}, {failing.address(), good.address()}} {
+		peerSource := &httpTestPeerSource{}
+		for _, peer := range peers {
+			peerSource.addPeer(peer)
+		}
+		accessor := &sectionsTestAccessor{sections: make(map[string]int)}
+		lf := makeLedgerFetcher(peerSource, accessor, logging.TestingLog(t), &dummyLedgerFetcherReporter{})
+		err := lf.downloadLedgerParallel(context.Background(), round, label, 3)
+		require.NoError(t, err)
+
+		// every section was processed exactly once
+		require.Len(t, accessor.sections, len(sections))
+		for _, section := range sections {
+			require.Equal(t, 1, accessor.sections[section.name], section.name)
+		}
+	}
+	// the peer of another catchpoint was never asked for the sections of the catchpoint file
+	require.Equal(t, int32(0), atomic.LoadInt32(&other.rangeRequests))
+	require.NotZero(t, atomic.LoadInt32(&failing.rangeRequests))
+
+	// no peer serves the catchpoint
+	peerSource := &httpTestPeerSource{}
+	peerSource.addPeer(other.address())
+	lf := makeLedgerFetcher(peerSource, &sectionsTestAccessor{sections: make(map[string]int)}, logging.TestingLog(t), &dummyLedgerFetcherReporter{})
+	require.Error(t, lf.downloadLedgerParallel(context.Background(), round, label, 3))
+
+	// the remaining ranges cannot be downloaded once the good peers are gone
+	peerSource = &httpTestPeerSource{}
+	peerSource.addPeer(failing.address())
+	lf = makeLedgerFetcher(peerSource, &sectionsTestAccessor{sections: make(map[string]int)}, logging.TestingLog(t), &dummyLedgerFetcherReporter{})
+	require.Error(t, lf.downloadLedgerParallel(context.Background(), round, label, 3))
+}
+
+func TestDownloadLedgerParallelDropsPeers(t *testing.T) {
+	const label = "5#AAAA"
+	round := basics.Round(5)
+	sections := makeTestCatchpointSections(label, round, 20, 3)
+	// a peer serving the header of the catchpoint, but balances which don't belong to it
+	corruptSections := makeTestCatchpointSections(label, round, 20, 3)
+	for i := 1; i <= 20; i++ {
+		corruptSections[i].bytes = []byte{byte(i), 0xff}
+	}
+	good := startSectionsTestServer(t, sections, true, 0)
+	defer good.listener.Close()
+	corrupt := startSectionsTestServer(t, corruptSections, true, 0)
+	defer corrupt.listener.Close()
+
+	download := func(lf *ledgerFetcher, peers ...string) {
+		peerSource := &httpTestPeerSource{}
+		for _, peer := range peers {
+			peerSource.addPeer(peer)
+		}
+		lf.net = peerSource
+		require.NoError(t, lf.downloadLedgerParallel(context.Background(), round, label, 2))
+		require.True(t, lf.hasSectionSources())
+	}
+
+	// downloading the same sections again doesn't drop any peer
+	lf := makeLedgerFetcher(&httpTestPeerSource{}, &sectionsTestAccessor{sections: make(map[string]int)}, logging.TestingLog(t), &dummyLedgerFetcherReporter{})
+	download(lf, good.address())
+	lf.catchpointVerificationFailed()
+	require.False(t, lf.hasSectionSources())
+	download(lf, good.address())
+	require.Empty(t, lf.droppedPeers)
+
+	// the peer which served the balances of a catchpoint file failing verification is dropped once another peer
+	// serves different balances
+	lf = makeLedgerFetcher(&httpTestPeerSource{}, &sectionsTestAccessor{sections: make(map[string]int)}, logging.TestingLog(t), &dummyLedgerFetcherReporter{})
+	download(lf, corrupt.address())
+	lf.catchpointVerificationFailed()
+	download(lf, good.address())
+	require.Equal(t, map[string]bool{corrupt.address(): true}, lf.droppedPeers)
+
+	peerSource := &httpTestPeerSource{}
+	peerSource.addPeer(corrupt.address())
+	peerSource.addPeer(good.address())
+	lf.net = peerSource
+	peers := lf.httpPeers()
+	require.Len(t, peers, 1)
+	require.Equal(t, good.address(), peers[0].GetAddress())
+}
+
+func TestDownloadLedgerParallelUnsupported(t *testing.T) {
+	const label = "5#AAAA"
+	round := basics.Round(5)
+	sections := makeTestCatchpointSections(label, round, 10, 0)
+	server := startSectionsTestServer(t, sections, false, 0)
+	defer server.listener.Close()
+
+	// the whole file is downloaded from a peer which doesn't serve ranges
+	peerSource := &httpTestPeerSource{}
+	peerSource.addPeer(server.address())
+	accessor := &sectionsTestAccessor{sections: make(map[string]int)}
+	lf := makeLedgerFetcher(peerSource, accessor, logging.TestingLog(t), &dummyLedgerFetcherReporter{})
+	require.NoError(t, lf.downloadLedgerParallel(context.Background(), round, label, 3))
+	require.Len(t, accessor.sections, len(sections))
+}
+
+func TestResumeLedgerDownload(t *testing.T) {
+	const label = "5#AAAA"
+	round := basics.Round(5)
+	sections := makeTestCatchpointSections(label, round, 20, 3)
+	server := startSectionsTestServer(t, sections, true, 0)
+	defer server.listener.Close()
+
+	var processedSections []string
+	for _, i := range []int{0, 1, 2, 3, 4, 5, 9, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21} {
+		processedSections = append(processedSections, sections[i].name)
+	}
+	progress := ledger.CatchpointCatchupAccessorProgress{SeenHeader: true, TotalChunks: 20}
+
+	peerSource := &httpTestPeerSource{}
+	peerSource.addPeer(server.address())
+	accessor := &sectionsTestAccessor{sections: make(map[string]int)}
+	lf := makeLedgerFetcher(peerSource, accessor, logging.TestingLog(t), &dummyLedgerFetcherReporter{})
+	require.NoError(t, lf.resumeLedgerDownload(context.Background(), round, label, 2, &progress, processedSections))
+
+	// only the sections which weren't processed were downloaded
+	require.Equal(t, map[string]int{
+		"balances.6.20.msgpack":  1,
+		"balances.7.20.msgpack":  1,
+		"balances.8.20.msgpack":  1,
+		"balances.10.20.msgpack": 1,
+		"balances.11.20.msgpack": 1,
+		"kvs.2.msgpack":          1,
+		"kvs.3.msgpack":          1,
+	}, accessor.sections)
+
+	// the download can't be resumed from a peer which doesn't serve ranges
+	unsupported := startSectionsTestServer(t, sections, false, 0)
+	defer unsupported.listener.Close()
+	peerSource = &httpTestPeerSource{}
+	peerSource.addPeer(unsupported.address())
+	lf = makeLedgerFetcher(peerSource, &sectionsTestAccessor{sections: make(map[string]int)}, logging.TestingLog(t), &dummyLedgerFetcherReporter{})
+	require.Equal(t, errLedgerSectionsUnsupported, lf.resumeLedgerDownload(context.Background(), round, label, 2, &progress, processedSections))
+}
+
+func TestRemainingSectionRanges(t *testing.T) {
+	balances, followingFrom := remainingSectionRanges(10, nil)
+	require.Equal(t, []ledgerSectionRange{{from: 1, to: 11}}, balances)
+	require.Equal(t, uint64(11), followingFrom)
+
+	balances, followingFrom = remainingSectionRanges(10, []string{"content.msgpack", "balances.1.10.msgpack", "balances.2.10.msgpack", "balances.5.10.msgpack", "balances.10.10.msgpack"})
+	require.Equal(t, []ledgerSectionRange{{from: 3, to: 5}, {from: 6, to: 10}}, balances)
+	require.Equal(t, uint64(11), followingFrom)
+
+	balances, followingFrom = remainingSectionRanges(2, []string{"content.msgpack", "balances.1.2.msgpack", "balances.2.2.msgpack", "kvs.1.msgpack"})
+	require.Empty(t, balances)
+	require.Equal(t, uint64(4), followingFrom)
+
+	require.Equal(t, []ledgerSectionRange{{from: 3, to: 5}, {from: 5, to: 6}, {from: 8, to: 9}}, splitSectionRanges([]ledgerSectionRange{{from: 3, to: 6}, {from: 8, to: 9}}, 2))
+}
+
+func TestVerifySectionName(t *testing.T) {
+	require.NoError(t, verifySectionName(ledgerSection{name: "balances.3.10.msgpack"}, 3, 10))
+	require.Error(t, verifySectionName(ledgerSection{name: "balances.4.10.msgpack"}, 3, 10))
+	require.Error(t, verifySectionName(ledgerSection{name: "balances.3.11.msgpack"}, 3, 10))
+	require.NoError(t, verifySectionName(ledgerSection{name: "kvs.1.msgpack"}, 11, 10))
+}
@@ -0,0 +1,211 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package catchup
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/algorand/go-deadlock"
+
+	"github.com/algorand/go-algorand/util/metrics"
+)
+
+var catchupPeerScore = metrics.MakeGauge(metrics.CatchupPeerScore)
+var catchupPeerLatencySeconds = metrics.MakeGauge(metrics.CatchupPeerLatencySeconds)
+var catchupPeerThroughputBytes = metrics.MakeGauge(metrics.CatchupPeerThroughputBytes)
+var catchupPeerFailureRate = metrics.MakeGauge(metrics.CatchupPeerFailureRate)
+var catchupPeerPenaltiesTotal = metrics.MakeCounter(metrics.CatchupPeerPenaltiesTotal)
+
+const (
+	// catchupPeerSelectionRandom is the CatchupPeerSelection value which disables the peer ranking.
+	catchupPeerSelectionRandom = "random"
+
+	// peerRankingSmoothing is the weight of a new sample in the moving averages of the peer statistics.
+	peerRankingSmoothing = 0.2
+
+	// peerRankingPenaltyWeight is how much a penalty, i.e. a bad block or certificate, lowers the score of a peer: a
+	// single recent penalty divides the score by 1+peerRankingPenaltyWeight.
+	peerRankingPenaltyWeight = 4
+
+	// peerRankingPenaltyHalfLife is the time it takes for a penalty to lose half of its weight, so that a peer which
+	// served a bad block once may eventually be trusted again.
+	peerRankingPenaltyHalfLife = 10 * time.Minute
+
+	// peerRankingExplorationBonus is how much better than the best known peer the peers which weren't asked yet are
+	// assumed to be, so that each of them gets tried once it is idle.
+	peerRankingExplorationBonus = 1.1
+
+	// peerRankingDefaultLatency is the latency assumed for the peers when no block was fetched from any peer yet.
+	peerRankingDefaultLatency = time.Second
+)
+
+// peerStats are the statistics of the block requests sent to a peer.
+type peerStats struct {
+	// successes is the number of blocks successfully fetched from the peer.
+	successes uint64
+	// latency is the moving average of the time it took to fetch a block from the peer, in seconds.
+	latency float64
+	// throughput is the moving average of the rate at which the peer served block bytes, in bytes per second.
+	throughput float64
+	// failureRate is the moving average of the ratio of failed requests.
+	failureRate float64
+	// penalty is the decaying number of bad blocks or certificates served by the peer, as of penaltyUpdated.
+	penalty        float64
+	penaltyUpdated time.Time
+}
+
+// peerRanker ranks the peers blocks are fetched from during catchup by how fast and reliably they served blocks so far.
+// The ranker outlives the fetchers, so that the statistics gathered during a catchup are used by the next one.
+//
+// The score of a peer is the expected number of blocks per second it serves, i.e. its success rate divided by its
+// average latency, lowered by the bad blocks it returned. Peers which weren't asked yet are assumed to be slightly better
+// than the best known peer, so that they get a chance to prove themselves.
+type peerRanker struct {
+	mu    deadlock.Mutex
+	peers map[string]*peerStats
+}
+
+func makePeerRanker() *peerRanker {
+	return &peerRanker{
+		peers: make(map[string]*peerStats),
+	}
+}
+
+// stats returns the statistics of a peer, creating them if needed. Should be called with pr.mu held.
+func (pr *peerRanker) stats(address string) *peerStats {
+	stats, has := pr.peers[address]
+	if !has {
+		stats = &peerStats{}
+		pr.peers[address] = stats
+	}
+	return stats
+}
+
+// fetchSucceeded records a block of the given size fetched from a peer in the given time.
+func (pr *peerRanker) fetchSucceeded(address string, size int, duration time.Duration) {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	stats := pr.stats(address)
+	seconds := duration.Seconds()
+	if seconds <= 0 {
+		seconds = time.Microsecond.Seconds()
+	}
+	throughput := float64(size) / seconds
+	if stats.successes == 0 {
+		stats.latency = seconds
+		stats.throughput = throughput
+	} else {
+		stats.latency += peerRankingSmoothing * (seconds - stats.latency)
+		stats.throughput += peerRankingSmoothing * (throughput - stats.throughput)
+	}
+	stats.successes++
+	stats.failureRate -= peerRankingSmoothing * stats.failureRate
+	pr.updateMetrics(address, stats, time.Now())
+}
+
+// fetchFailed records a failed block request to a peer.
+func (pr *peerRanker) fetchFailed(address string) {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	stats := pr.stats(address)
+	stats.failureRate += peerRankingSmoothing * (1 - stats.failureRate)
+	pr.updateMetrics(address, stats, time.Now())
+}
+
+// penalize records a bad block or certificate served by a peer.
+func (pr *peerRanker) penalize(address string) {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	now := time.Now()
+	stats := pr.stats(address)
+	stats.penalty = stats.decayedPenalty(now) + 1
+	stats.penaltyUpdated = now
+	catchupPeerPenaltiesTotal.Inc(map[string]string{"peer": address})
+	pr.updateMetrics(address, stats, now)
+}
+
+// decayedPenalty returns the penalty of the peer at the given time.
+func (stats *peerStats) decayedPenalty(now time.Time) float64 {
+	if stats.penalty == 0 {
+		return 0
+	}
+	halfLives := float64(now.Sub(stats.penaltyUpdated)) / float64(peerRankingPenaltyHalfLife)
+	return stats.penalty * math.Pow(0.5, halfLives)
+}
+
+// score returns the score of a peer, given the latency assumed for the peers no block was fetched from yet.
+func (stats *peerStats) score(defaultLatency float64, now time.Time) float64 {
+	latency := defaultLatency
+	if stats.successes > 0 {
+		latency = stats.latency
+	}
+	return (1 - stats.failureRate) / latency / (1 + peerRankingPenaltyWeight*stats.decayedPenalty(now))
+}
+
+// defaultLatency returns the latency assumed for the peers no block was fetched from yet, which is the one of the best
+// known peer. Should be called with pr.mu held.
+func (pr *peerRanker) defaultLatency() float64 {
+	best := 0.0
+	for _, stats := range pr.peers {
+		if stats.successes > 0 && (best == 0 || stats.latency < best) {
+			best = stats.latency
+		}
+	}
+	if best == 0 {
+		return peerRankingDefaultLatency.Seconds()
+	}
+	return best
+}
+
+// updateMetrics exports the statistics of a peer. Should be called with pr.mu held.
+func (pr *peerRanker) updateMetrics(address string, stats *peerStats, now time.Time) {
+	labels := map[string]string{"peer": address}
+	catchupPeerScore.Set(stats.score(pr.defaultLatency(), now), labels)
+	catchupPeerFailureRate.Set(stats.failureRate, labels)
+	if stats.successes > 0 {
+		catchupPeerLatencySeconds.Set(stats.latency, labels)
+		catchupPeerThroughputBytes.Set(stats.throughput, labels)
+	}
+}
+
+// selectClient returns the best of the given clients, taking into account the number of requests each client is
+// currently serving so that the load is spread across the good peers. Ties are broken randomly.
+func (pr *peerRanker) selectClient(clients []FetcherClient, activeFetches map[FetcherClient]int) FetcherClient {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	now := time.Now()
+	defaultLatency := pr.defaultLatency()
+	var best FetcherClient
+	bestScore := -1.0
+	for _, i := range rand.Perm(len(clients)) {
+		client := clients[i]
+		var score float64
+		if stats, has := pr.peers[client.Address()]; has {
+			score = stats.score(defaultLatency, now)
+		} else {
+			score = peerRankingExplorationBonus / defaultLatency
+		}
+		score /= float64(1 + activeFetches[client])
+		if score > bestScore {
+			best = client
+			bestScore = score
+		}
+	}
+	return best
+}
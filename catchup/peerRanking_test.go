@@ -0,0 +1,132 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package catchup
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/logging"
+)
+
+// rankedTestFetcher is a dummyFetcher with its own address, so that the ranker tells the fetchers apart.
+type rankedTestFetcher struct {
+	dummyFetcher
+	address string
+}
+
+func (f *rankedTestFetcher) Address() string {
+	return f.address
+}
+
+func TestPeerRankerScore(t *testing.T) {
+	pr := makePeerRanker()
+	now := time.Now()
+
+	require.Equal(t, peerRankingDefaultLatency.Seconds(), pr.defaultLatency())
+	pr.fetchSucceeded("fast", 1000, 100*time.Millisecond)
+	pr.fetchSucceeded("slow", 1000, time.Second)
+	require.InDelta(t, 0.1, pr.defaultLatency(), 1e-9)
+	require.InDelta(t, 10, pr.peers["fast"].score(pr.defaultLatency(), now), 1e-9)
+	require.InDelta(t, 1, pr.peers["slow"].score(pr.defaultLatency(), now), 1e-9)
+	require.InDelta(t, 10000, pr.peers["fast"].throughput, 1e-6)
+
+	// failures lower the score, successes raise it back
+	pr.fetchFailed("fast")
+	failed := pr.peers["fast"].score(pr.defaultLatency(), now)
+	require.InDelta(t, 8, failed, 1e-9)
+	pr.fetchSucceeded("fast", 1000, 100*time.Millisecond)
+	require.True(t, pr.peers["fast"].score(pr.defaultLatency(), now) > failed)
+
+	// penalties lower the score, and decay over time
+	unpenalized := pr.peers["fast"].score(pr.defaultLatency(), now)
+	pr.penalize("fast")
+	require.InDelta(t, unpenalized/(1+peerRankingPenaltyWeight), pr.peers["fast"].score(pr.defaultLatency(), time.Now()), 1e-3)
+	require.InDelta(t, 0.5, pr.peers["fast"].decayedPenalty(time.Now().Add(peerRankingPenaltyHalfLife)), 1e-3)
+	require.InDelta(t, unpenalized, pr.peers["fast"].score(pr.defaultLatency(), time.Now().Add(20*peerRankingPenaltyHalfLife)), 1e-3)
+}
+
+func TestPeerRankerSelectClient(t *testing.T) {
+	pr := makePeerRanker()
+	fast := &rankedTestFetcher{address: "fast"}
+	slow := &rankedTestFetcher{address: "slow"}
+	unknown := &rankedTestFetcher{address: "unknown"}
+	clients := []FetcherClient{fast, slow, unknown}
+	activeFetches := make(map[FetcherClient]int)
+
+	pr.fetchSucceeded("fast", 1000, 100*time.Millisecond)
+	pr.fetchSucceeded("slow", 1000, time.Second)
+
+	// the fast peer and the peer which wasn't asked yet share the load, the slow one only gets requests once they are busy
+	for i := 0; i < 10; i++ {
+		selected := pr.selectClient(clients, activeFetches)
+		require.NotNil(t, selected)
+		activeFetches[selected]++
+	}
+	require.Equal(t, 5, activeFetches[fast])
+	require.Equal(t, 5, activeFetches[unknown])
+	require.Equal(t, 0, activeFetches[slow])
+
+	// a peer which served a bad block is avoided
+	pr.penalize("unknown")
+	for i := 0; i < 10; i++ {
+		activeFetches = make(map[FetcherClient]int)
+		require.Equal(t, fast, pr.selectClient(clients, activeFetches))
+	}
+}
+
+func TestFetchBlockRanked(t *testing.T) {
+	clients := make([]FetcherClient, 0)
+	for i := 0; i < 3; i++ {
+		clients = append(clients, &rankedTestFetcher{
+			dummyFetcher: dummyFetcher{fetchTimeout: time.Duration(i+1) * 20 * time.Millisecond},
+			address:      fmt.Sprintf("peer%d", i),
+		})
+	}
+	failing := &rankedTestFetcher{
+		dummyFetcher: dummyFetcher{failWithError: true},
+		address:      "failing",
+	}
+	clients = append(clients, failing)
+	fetcher := &NetworkFetcher{
+		roundUpperBound: make(map[FetcherClient]basics.Round),
+		activeFetches:   make(map[FetcherClient]int),
+		peers:           clients,
+		ranker:          makePeerRanker(),
+		log:             logging.TestingLog(t),
+	}
+
+	// every peer gets tried, then the fastest one is preferred; the failing one is no longer asked for the round
+	for i := 0; i < 10; i++ {
+		fetcher.FetchBlock(context.Background(), basics.Round(1))
+	}
+	for _, client := range clients {
+		require.Contains(t, fetcher.ranker.peers, client.Address())
+	}
+	require.True(t, fetcher.ranker.peers["failing"].failureRate > 0)
+	require.Equal(t, uint64(0), fetcher.ranker.peers["failing"].successes)
+	for i := 0; i < 5; i++ {
+		_, _, client, err := fetcher.FetchBlock(context.Background(), basics.Round(2))
+		require.NoError(t, err)
+		require.Equal(t, "peer0", client.Address())
+	}
+}
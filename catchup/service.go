@@ -74,6 +74,9 @@ type Service struct {
 	parallelBlocks  uint64
 	deadlineTimeout time.Duration
 
+	// ranker ranks the peers blocks are fetched from across syncs; nil if the peers are selected randomly.
+	ranker *peerRanker
+
 	// The channel gets closed when the initial sync is complete. This allows for other services to avoid
 	// the overhead of starting prematurely (before this node is caught-up and can validate messages for example).
 	InitialSyncDone              chan struct{}
@@ -103,12 +106,19 @@ func MakeService(log logging.Logger, config config.Local, net network.GossipNode
 	s = &Service{}
 
 	s.cfg = config
-	s.fetcherFactory = MakeNetworkFetcherFactory(net, catchupPeersForSync, wsf, &config)
+	if config.CatchupPeerSelection != catchupPeerSelectionRandom {
+		s.ranker = makePeerRanker()
+	}
+	fetcherFactory := MakeNetworkFetcherFactory(net, catchupPeersForSync, wsf, &config)
+	fetcherFactory.ranker = s.ranker
+	s.fetcherFactory = fetcherFactory
 	s.ledger = ledger
 	s.net = net
 	s.auth = auth
 	s.unmatchedPendingCertificates = unmatchedPendingCertificates
-	s.latestRoundFetcherFactory = MakeNetworkFetcherFactory(net, blockQueryPeerLimit, wsf, &config)
+	latestRoundFetcherFactory := MakeNetworkFetcherFactory(net, blockQueryPeerLimit, wsf, &config)
+	latestRoundFetcherFactory.ranker = s.ranker
+	s.latestRoundFetcherFactory = latestRoundFetcherFactory
 	s.log = log.With("Context", "sync")
 	s.parallelBlocks = config.CatchupParallelBlocks
 	s.deadlineTimeout = agreement.DeadlineTimeout()
@@ -225,6 +235,7 @@ func (s *Service) fetchAndWrite(fetcher Fetcher, r basics.Round, prevFetchComple
 			}
 
 			s.log.Warnf("fetchAndWrite(%v): block contents do not match header (attempt %d)", r, i)
+			s.penalizePeer(client)
 			client.Close()
 			continue // retry the fetch
 		}
@@ -246,6 +257,7 @@ func (s *Service) fetchAndWrite(fetcher Fetcher, r basics.Round, prevFetchComple
 		err = s.auth.Authenticate(block, cert)
 		if err != nil {
 			s.log.Warnf("fetchAndWrite(%v): cert did not authenticate block (attempt %d): %v", r, i, err)
+			s.penalizePeer(client)
 			client.Close()
 			continue // retry the fetch
 		}
@@ -284,6 +296,13 @@ func (s *Service) fetchAndWrite(fetcher Fetcher, r basics.Round, prevFetchComple
 	return false
 }
 
+// penalizePeer lowers the ranking of a peer which served a bad block or certificate.
+func (s *Service) penalizePeer(client FetcherClient) {
+	if s.ranker != nil {
+		s.ranker.penalize(client.Address())
+	}
+}
+
 type task func() basics.Round
 
 func (s *Service) pipelineCallback(fetcher Fetcher, r basics.Round, thisFetchComplete chan bool, prevFetchCompleteChan chan bool, lookbackChan chan bool) func() basics.Round {
@@ -555,6 +574,7 @@ func (s *Service) fetchRound(cert agreement.Certificate, verifier *agreement.Asy
 			return
 		}
 		// Otherwise, fetcher gave us the wrong block
+		s.penalizePeer(rpcc)
 		logging.Base().Warnf("fetcher gave us bad/wrong block (for round %d): fetched hash %v; want hash %v", cert.Round, block.Hash(), blockHash)
 
 		// As a failsafe, if the cert we fetched is valid but for the wrong block, panic as loudly as possible
@@ -1185,6 +1185,7 @@ var infoAppCmd = &cobra.Command{
 		lsch := params.LocalStateSchema
 
 		fmt.Printf("Application ID:        %d\n", appIdx)
+		fmt.Printf("Application account:   %v\n", meta.Address)
 		fmt.Printf("Creator:               %v\n", params.Creator)
 		fmt.Printf("Approval hash:         %v\n", basics.Address(logic.HashProgram(params.ApprovalProgram)))
 		fmt.Printf("Clear hash:            %v\n", basics.Address(logic.HashProgram(params.ClearStateProgram)))
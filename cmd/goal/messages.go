@@ -175,6 +175,26 @@ const (
 	errorFailedToReadResponse    = "Couldn't read response: %s"
 	errorFailedToReadPassword    = "Couldn't read password: %s"
 
+	// Wallet backup
+	infoChooseBackupPassphrasePrompt = "Please choose a passphrase for the wallet backup: "
+	infoBackupPassphrasePrompt       = "Please enter the passphrase of the wallet backup '%s': "
+	infoPolicyPasswordPrompt         = "Please enter the key policy password of the wallet: "
+	infoChooseRestoredPasswordPrompt = "Please choose a password for the restored wallet: "
+	infoWroteWalletBackup            = "Wrote wallet backup to '%s'"
+	infoRestoringWallet              = "Restoring wallet..."
+	infoRestoredWallet               = "Restored wallet '%s'"
+	errorCouldntBackupWallet         = "Couldn't back up wallet: %s"
+	errorCouldntRestoreWallet        = "Couldn't restore wallet: %s"
+
+	// Named API tokens
+	infoNodeWroteScopedToken = "Successfully added API token '%s' with scope '%s': %s"
+	infoNodeRevokedToken     = "Revoked API token '%s'"
+	infoNodeNoScopedTokens   = "No named API tokens"
+	errorNodeTokenScope      = "Invalid token scope '%s', must be one of %v"
+	errorNodeTokenWallet     = "Wallet scoped tokens need a wallet (-w), which can only be given for wallet scoped kmd tokens"
+	errorNodeFailListTokens  = "Cannot list API tokens: %s"
+	errorNodeFailRevokeToken = "Cannot revoke API token: %s"
+
 	// Commands
 	infoPasswordPrompt       = "Please enter the password for wallet '%s': "
 	infoSetWalletToDefault   = "Set wallet '%s' to be the default wallet"
@@ -55,6 +55,11 @@ var newNodeIndexer bool
 var newNodeRelay string
 var watchMillisecond uint64
 var abortCatchup bool
+var tokenName string
+var tokenScope string
+var tokenLifetime time.Duration
+var tokenForKmd bool
+var tokenWallet string
 
 func init() {
 	nodeCmd.AddCommand(startCmd)
@@ -101,6 +106,14 @@ func init() {
 
 	catchupCmd.Flags().BoolVarP(&abortCatchup, "abort", "x", false, "Aborts the current catchup process")
 
+	generateTokenCmd.AddCommand(listTokensCmd)
+	generateTokenCmd.AddCommand(revokeTokenCmd)
+	generateTokenCmd.PersistentFlags().BoolVar(&tokenForKmd, "kmd", false, "Manage the named API tokens of kmd instead of algod")
+	generateTokenCmd.Flags().StringVarP(&tokenName, "name", "n", "", "Add a named API token, stored hashed, instead of replacing the node's API token")
+	generateTokenCmd.Flags().StringVar(&tokenScope, "scope", "", "Scope of the named token: read, submit or admin for algod (default read), wallet or admin for kmd (default wallet)")
+	generateTokenCmd.Flags().DurationVar(&tokenLifetime, "expires", 0, "Lifetime of the named token, e.g. 720h (default never expires)")
+	generateTokenCmd.Flags().StringVarP(&tokenWallet, "wallet", "w", "", "The only wallet a wallet scoped kmd token may use")
+
 }
 
 var nodeCmd = &cobra.Command{
@@ -320,9 +333,15 @@ var restartCmd = &cobra.Command{
 var generateTokenCmd = &cobra.Command{
 	Use:   "generatetoken",
 	Short: "Generate and install a new API token",
+	Long:  "Generate and install a new API token. With --name, add a named API token with a scope and an optional expiry instead, which the running node (or kmd, with --kmd) accepts alongside its API token.",
 	Args:  validateNoPosArgsFn,
 	Run: func(cmd *cobra.Command, _ []string) {
 		onDataDirs(func(dataDir string) {
+			if tokenName != "" {
+				generateScopedToken(dataDir)
+				return
+			}
+
 			// Ensure the node is stopped -- HealthCheck should fail
 			clientConfig := libgoal.ClientConfig{
 				AlgodDataDir: dataDir,
@@ -349,6 +368,114 @@ var generateTokenCmd = &cobra.Command{
 	},
 }
 
+var listTokensCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the named API tokens",
+	Args:  validateNoPosArgsFn,
+	Run: func(cmd *cobra.Command, _ []string) {
+		onDataDirs(func(dataDir string) {
+			tokensDir, tokensFilename, _ := scopedTokensLocation(dataDir)
+			scopedTokens, err := tokens.LoadScopedTokens(tokensDir, tokensFilename)
+			if err != nil {
+				reportErrorf(errorNodeFailListTokens, err)
+			}
+			if len(scopedTokens) == 0 {
+				reportInfoln(infoNodeNoScopedTokens)
+				return
+			}
+
+			now := time.Now()
+			for _, token := range scopedTokens {
+				expires := "never expires"
+				if token.Expired(now) {
+					expires = "expired " + time.Unix(token.Expires, 0).Format(time.RFC3339)
+				} else if token.Expires != 0 {
+					expires = "expires " + time.Unix(token.Expires, 0).Format(time.RFC3339)
+				}
+				line := fmt.Sprintf("%s\t%s\t%s", token.Name, token.Scope, expires)
+				if token.Wallet != "" {
+					line += "\twallet " + token.Wallet
+				}
+				fmt.Println(line)
+			}
+		})
+	},
+}
+
+var revokeTokenCmd = &cobra.Command{
+	Use:   "revoke [name]",
+	Short: "Revoke a named API token",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		onDataDirs(func(dataDir string) {
+			tokensDir, tokensFilename, _ := scopedTokensLocation(dataDir)
+			err := tokens.RevokeScopedToken(tokensDir, tokensFilename, args[0])
+			if err != nil {
+				reportErrorf(errorNodeFailRevokeToken, err)
+			}
+			reportInfof(infoNodeRevokedToken, args[0])
+		})
+	},
+}
+
+// scopedTokensLocation returns where the named API tokens of algod, or of kmd
+// with --kmd, are stored, and the scopes they may have
+func scopedTokensLocation(dataDir string) (tokensDir string, tokensFilename string, scopes []tokens.Scope) {
+	if tokenForKmd {
+		return resolveKmdDataDir(dataDir), tokens.KmdScopedTokensFilename, tokens.KmdScopes
+	}
+	return dataDir, tokens.AlgodScopedTokensFilename, tokens.AlgodScopes
+}
+
+// generateScopedToken adds a named API token. Unlike the node's API token, it
+// is picked up by a running node.
+func generateScopedToken(dataDir string) {
+	tokensDir, tokensFilename, scopes := scopedTokensLocation(dataDir)
+
+	scope := tokens.Scope(tokenScope)
+	if scope == "" {
+		scope = scopes[0]
+	}
+	validScope := false
+	for _, s := range scopes {
+		if s == scope {
+			validScope = true
+			break
+		}
+	}
+	if !validScope {
+		reportErrorf(errorNodeTokenScope, scope, scopes)
+	}
+	if (scope == tokens.ScopeWallet) != (tokenWallet != "") {
+		reportErrorln(errorNodeTokenWallet)
+	}
+
+	// Wallet scoped tokens are bound to the wallet's ID, so that renaming
+	// the wallet doesn't change what the token may use
+	var walletID []byte
+	if tokenWallet != "" {
+		kmd := ensureKmdClient(dataDir)
+		var dup bool
+		var err error
+		walletID, dup, err = kmd.FindWalletIDByName([]byte(tokenWallet))
+		if err != nil {
+			reportErrorf(errFindingWallet, err)
+		}
+		if dup {
+			reportErrorf(errWalletNameAmbiguous, tokenWallet)
+		}
+		if len(walletID) == 0 {
+			reportErrorf(errWalletNotFound, tokenWallet)
+		}
+	}
+
+	apiToken, err := tokens.GenerateScopedToken(tokensDir, tokensFilename, tokenName, scope, string(walletID), tokenLifetime)
+	if err != nil {
+		reportErrorf(errorNodeFailGenToken, err)
+	}
+	reportInfof(infoNodeWroteScopedToken, tokenName, scope, apiToken)
+}
+
 var statusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Get the current node status",
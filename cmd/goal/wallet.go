@@ -33,17 +33,29 @@ import (
 var (
 	recoverWallet     bool
 	defaultWalletName string
+	backupFile        string
+	backupPolicies    bool
 )
 
 func init() {
 	walletCmd.AddCommand(newWalletCmd)
 	walletCmd.AddCommand(listWalletsCmd)
+	walletCmd.AddCommand(backupWalletCmd)
+	walletCmd.AddCommand(restoreWalletCmd)
 
 	// Default wallet to use when -w not specified
 	walletCmd.Flags().StringVarP(&defaultWalletName, "default", "f", "", "Set the wallet with this name to be the default wallet")
 
 	// Should we recover the wallet?
 	newWalletCmd.Flags().BoolVarP(&recoverWallet, "recover", "r", false, "Recover the wallet from the backup mnemonic provided at wallet creation (NOT the mnemonic provided by goal account export or by algokey). Regenerate accounts in the wallet with `goal account new`")
+
+	backupWalletCmd.Flags().StringVarP(&walletName, "wallet", "w", "", "Set the wallet to back up")
+	backupWalletCmd.Flags().StringVarP(&backupFile, "outfile", "o", "", "Filename for writing the wallet backup")
+	backupWalletCmd.Flags().BoolVar(&backupPolicies, "policy-password", false, "Prompt for the key policy password, needed to back up a wallet holding keys with signing policies")
+	backupWalletCmd.MarkFlagRequired("outfile")
+
+	restoreWalletCmd.Flags().StringVarP(&backupFile, "infile", "i", "", "Filename of the wallet backup to restore")
+	restoreWalletCmd.MarkFlagRequired("infile")
 }
 
 var walletCmd = &cobra.Command{
@@ -183,6 +195,100 @@ var newWalletCmd = &cobra.Command{
 	},
 }
 
+var backupWalletCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Back up a wallet to an encrypted file",
+	Long:  "Write the master derivation key, the generated and imported keys, and the multisig addresses of a wallet to a file encrypted with a passphrase. Unlike the backup phrase, the file can be used to recover imported keys and multisig addresses. Restore it with `goal wallet restore`.",
+	Args:  validateNoPosArgsFn,
+	Run: func(cmd *cobra.Command, args []string) {
+		dataDir := ensureSingleDataDir()
+		client := ensureKmdClient(dataDir)
+		wh, pw := ensureWalletHandleMaybePassword(dataDir, walletName, true)
+
+		var policyPw []byte
+		if backupPolicies {
+			fmt.Printf(infoPolicyPasswordPrompt)
+			policyPw = ensurePassword()
+		}
+
+		// Fetch a passphrase for the backup
+		fmt.Printf(infoChooseBackupPassphrasePrompt)
+		passphrase := ensurePassword()
+		fmt.Printf(infoPasswordConfirmation)
+		passphraseConfirmation := ensurePassword()
+		if !bytes.Equal(passphrase, passphraseConfirmation) {
+			reportErrorln(errorPasswordConfirmation)
+		}
+
+		backup, err := client.BackupWallet(wh, pw, policyPw, passphrase)
+		if err != nil {
+			reportErrorf(errorCouldntBackupWallet, err)
+		}
+
+		err = writeFile(backupFile, backup, 0600)
+		if err != nil {
+			reportErrorf(fileWriteError, backupFile, err)
+		}
+		reportInfof(infoWroteWalletBackup, backupFile)
+	},
+}
+
+var restoreWalletCmd = &cobra.Command{
+	Use:   "restore [wallet name]",
+	Short: "Restore a wallet from a file written by `goal wallet backup`",
+	Long:  "Create a new wallet holding the keys and multisig addresses of a wallet backup. The name of the backed up wallet is used if no wallet name is given.",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		dataDir := ensureSingleDataDir()
+		accountList := makeAccountsList(dataDir)
+		client := ensureKmdClient(dataDir)
+
+		var newWalletName []byte
+		if len(args) > 0 {
+			newWalletName = []byte(args[0])
+		}
+
+		backup, err := readFile(backupFile)
+		if err != nil {
+			reportErrorf(fileReadError, backupFile, err)
+		}
+
+		fmt.Printf(infoBackupPassphrasePrompt, backupFile)
+		passphrase := ensurePassword()
+
+		// Fetch a password for the restored wallet
+		fmt.Printf(infoChooseRestoredPasswordPrompt)
+		walletPassword := ensurePassword()
+		fmt.Printf(infoPasswordConfirmation)
+		passwordConfirmation := ensurePassword()
+		if !bytes.Equal(walletPassword, passwordConfirmation) {
+			reportErrorln(errorPasswordConfirmation)
+		}
+
+		reportInfoln(infoRestoringWallet)
+		walletID, err := client.RestoreWallet(newWalletName, walletPassword, backup, passphrase)
+		if err != nil {
+			reportErrorf(errorCouldntRestoreWallet, err)
+		}
+		name, _, err := client.FindWalletNameByID(walletID)
+		if err != nil {
+			reportErrorf(errorCouldntListWallets, err)
+		}
+		reportInfof(infoRestoredWallet, name)
+
+		// Check if we're the only wallet
+		wallets, err := client.ListWallets()
+		if err != nil {
+			reportErrorf(errorCouldntListWallets, err)
+		}
+
+		// We are the only wallet -- make us the default
+		if len(wallets) == 1 {
+			accountList.setDefaultWalletID(walletID)
+		}
+	},
+}
+
 var listWalletsCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List wallets managed by kmd",
@@ -166,12 +166,12 @@ func buildSyntaxHighlight() *tmLanguage {
 				Name:  "keyword.other.teal",
 				Match: fmt.Sprintf("^(%s)\\b", strings.Join(loading, "|")),
 			})
-		case "State Access":
+		case "State Access", "Inner Transactions", "Box Access":
 			keywords.Patterns = append(keywords.Patterns, pattern{
 				Name:  "keyword.other.unit.teal",
 				Match: fmt.Sprintf("^(%s)\\b", strings.Join(opgroup.Ops, "|")),
 			})
-		case "Arithmetic":
+		case "Arithmetic", "Byte Array Arithmetic":
 			escape := map[rune]bool{
 				'*': true,
 				'+': true,
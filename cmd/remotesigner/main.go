@@ -0,0 +1,153 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/daemon/kmd/remotesigner"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/util/tokens"
+)
+
+var (
+	listenAddress string
+	tokenFile     string
+	keyfiles      []string
+	tlsCertFile   string
+	tlsKeyFile    string
+)
+
+func init() {
+	signerCmd.Flags().StringVarP(&listenAddress, "listen", "l", "127.0.0.1:7834", "Address to serve the signing requests on.")
+	signerCmd.Flags().StringVarP(&tokenFile, "token-file", "t", "", "File holding the token the requests are authenticated with; generated if missing.")
+	signerCmd.Flags().StringSliceVarP(&keyfiles, "keyfile", "k", nil, "Private key file, as written by algokey generate. May be repeated.")
+	signerCmd.Flags().StringVar(&tlsCertFile, "tls-cert", "", "TLS certificate file; the requests are served over plain HTTP if omitted.")
+	signerCmd.Flags().StringVar(&tlsKeyFile, "tls-key", "", "TLS private key file.")
+	signerCmd.MarkFlagRequired("token-file")
+	signerCmd.MarkFlagRequired("keyfile")
+}
+
+var signerCmd = &cobra.Command{
+	Use:   "remotesigner",
+	Short: "Reference remote signer for kmd",
+	Long: `remotesigner is a reference implementation of the signing service kmd
+forwards the signing requests of its remote wallets to. It holds the keys of
+the given key files in memory, and signs every request authenticated with its
+token. This is a blocking command.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		os.Exit(runSigner())
+	},
+}
+
+func loadKeyfile(keyfile string) (*crypto.SignatureSecrets, error) {
+	seedbytes, err := ioutil.ReadFile(keyfile)
+	if err != nil {
+		return nil, err
+	}
+	var seed crypto.Seed
+	if len(seedbytes) != len(seed) {
+		return nil, fmt.Errorf("%s holds %d bytes instead of a %d bytes key seed", keyfile, len(seedbytes), len(seed))
+	}
+	copy(seed[:], seedbytes)
+	return crypto.GenerateSignatureSecrets(seed), nil
+}
+
+func runSigner() int {
+	log := logging.NewLogger()
+	log.SetLevel(logging.Info)
+
+	apiToken, wroteToken, err := tokens.ValidateOrGenerateAPIToken(filepath.Dir(tokenFile), filepath.Base(tokenFile))
+	if err != nil {
+		log.Errorf("invalid token file %s: %v", tokenFile, err)
+		return 1
+	}
+	if wroteToken {
+		log.Infof("generated a token in %s", tokenFile)
+	}
+
+	var secrets []*crypto.SignatureSecrets
+	for _, keyfile := range keyfiles {
+		secret, err := loadKeyfile(keyfile)
+		if err != nil {
+			log.Errorf("cannot load key: %v", err)
+			return 1
+		}
+		log.Infof("loaded key of %s from %s", basics.Address(secret.SignatureVerifier), keyfile)
+		secrets = append(secrets, secret)
+	}
+
+	signer, err := remotesigner.MakeSigner(secrets, apiToken, log)
+	if err != nil {
+		log.Errorf("cannot create signer: %v", err)
+		return 1
+	}
+	server := &http.Server{
+		Addr:    listenAddress,
+		Handler: signer,
+	}
+
+	kill := make(chan os.Signal, 1)
+	signal.Notify(kill, os.Interrupt, syscall.SIGTERM, syscall.SIGINT)
+	go func() {
+		<-kill
+		server.Shutdown(context.Background())
+	}()
+
+	log.Infof("serving signing requests on %s", listenAddress)
+	if tlsCertFile != "" {
+		err = server.ListenAndServeTLS(tlsCertFile, tlsKeyFile)
+	} else {
+		err = server.ListenAndServe()
+	}
+	if err != nil && err != http.ErrServerClosed {
+		log.Errorf("signer server failed: %v", err)
+		return 1
+	}
+	return 0
+}
+
+func main() {
+	// Hidden command to generate docs in a given directory
+	// remotesigner generate-docs [path]
+	if len(os.Args) == 3 && os.Args[1] == "generate-docs" {
+		err := doc.GenMarkdownTree(signerCmd, os.Args[2])
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	if err := signerCmd.Execute(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
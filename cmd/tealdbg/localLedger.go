@@ -314,6 +314,18 @@ func (ba *balancesAdapter) Put(basics.BalanceRecord) error {
 	return nil
 }
 
+func (ba *balancesAdapter) GetBox(basics.AppIndex, string) ([]byte, bool, error) {
+	return nil, false, nil
+}
+
+func (ba *balancesAdapter) PutBox(basics.AppIndex, string, []byte) error {
+	return nil
+}
+
+func (ba *balancesAdapter) DelBox(basics.AppIndex, string) error {
+	return nil
+}
+
 func (ba *balancesAdapter) Move(src, dst basics.Address, amount basics.MicroAlgos, srcRewards, dstRewards *basics.MicroAlgos) error {
 	return nil
 }
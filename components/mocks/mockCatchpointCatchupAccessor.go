@@ -57,6 +57,11 @@ func (m *MockCatchpointCatchupAccessor) ProgressStagingBalances(ctx context.Cont
 	return nil
 }
 
+// ResumeStagingBalances restores the progress of an interrupted processing of the catchpoint file
+func (m *MockCatchpointCatchupAccessor) ResumeStagingBalances(ctx context.Context, progress *ledger.CatchpointCatchupAccessorProgress) (processedSections []string, err error) {
+	return nil, nil
+}
+
 // GetCatchupBlockRound returns the latest block round matching the current catchpoint
 func (m *MockCatchpointCatchupAccessor) GetCatchupBlockRound(ctx context.Context) (round basics.Round, err error) {
 	return basics.Round(0), nil
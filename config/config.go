@@ -63,7 +63,7 @@ type Local struct {
 	// Version tracks the current version of the defaults so we can migrate old -> new
 	// This is specifically important whenever we decide to change the default value
 	// for an existing parameter. This field tag must be updated any time we add a new version.
-	Version uint32 `version[0]:"0" version[1]:"1" version[2]:"2" version[3]:"3" version[4]:"4" version[5]:"5" version[6]:"6" version[7]:"7" version[8]:"8" version[9]:"9" version[10]:"10" version[11]:"11" version[12]:"12"`
+	Version uint32 `version[0]:"0" version[1]:"1" version[2]:"2" version[3]:"3" version[4]:"4" version[5]:"5" version[6]:"6" version[7]:"7" version[8]:"8" version[9]:"9" version[10]:"10" version[11]:"11" version[12]:"12" version[13]:"13"`
 
 	// environmental (may be overridden)
 	// When enabled, stores blocks indefinitally, otherwise, only the most recents blocks
@@ -349,6 +349,73 @@ type Local struct {
 	// and is expected to happen only on either startup ( after enabling the catchpoint interval, or on certain database upgrades ) or during fast catchup. The values specified here
 	// and their meanings are identical to the ones in LedgerSynchronousMode.
 	AccountsRebuildSynchronousMode int `version[12]:"1"`
+
+	// StateDeltaExportRounds defines the number of most recent rounds for which the ledger retains an exported copy of the per-round
+	// state delta, so that it could be retrieved by external consumers via the REST API. A value of zero disables the state delta export.
+	StateDeltaExportRounds uint64 `version[13]:"0"`
+
+	// EnableAccountHistory enables the historical accounts store, which records the prior state of every account modified
+	// by each round written to the accounts database. This allows account lookups at any round committed since the history
+	// was enabled. The setting is effective only on Archival nodes.
+	EnableAccountHistory bool `version[13]:"false"`
+
+	// TLSCAFile is the certificate authority used for mutual TLS on the gossip network. When set, incoming gossip connections
+	// are required to present a client certificate signed by this authority, and outgoing connections verify the relay
+	// certificate against it while presenting the TLSCertFile/TLSKeyFile certificate as the client certificate.
+	// TLSCertFile and TLSKeyFile are required when it is set.
+	TLSCAFile string `version[13]:""`
+
+	// EnableGossipTLS makes the node contact relays given as host:port over TLS, i.e. using wss for the gossip connection
+	// and https for the other relay services. Relays given as a full URL are contacted using the URL scheme.
+	// TLSCertFile and TLSKeyFile are required when it is set.
+	EnableGossipTLS bool `version[13]:"false"`
+
+	// EnablePeerIdentity enables the ed25519 peer identity challenge/response performed when establishing gossip connections.
+	// The identity key is stored in the data directory, and is created on first use.
+	EnablePeerIdentity bool `version[13]:"false"`
+
+	// PeerIdentityAllowlist is a comma separated list of peer identity public keys, in address form, which are allowed to
+	// connect to this node. When non-empty, the peer identity is enabled and incoming connections from peers which cannot
+	// prove ownership of a listed key are rejected, as are relays which cannot do so.
+	PeerIdentityAllowlist string `version[13]:""`
+
+	// EnableGossipCompression allows the node to compress the payload of large gossip messages, such as proposal payloads and
+	// catchup block responses. The compression is only used on connections with peers that support it as well.
+	EnableGossipCompression bool `version[13]:"true"`
+
+	// EnablePeerExchange enables the gossip peer exchange: the node periodically asks the relays it is connected to for the
+	// addresses of the relays they are connected to, and adds them to its phonebook. Relays with this setting enabled answer
+	// these requests. This allows discovering relays on networks where DNS is unavailable.
+	EnablePeerExchange bool `version[13]:"false"`
+
+	// PeerBandwidthQuota is the number of bytes per second an incoming peer may send to this node. Peers exceeding their
+	// quota are throttled, and disconnected if they keep exceeding it. Zero disables the bandwidth quota.
+	PeerBandwidthQuota uint64 `version[13]:"0"`
+
+	// PeerMessageRateQuotas maps a message tag to the number of messages per second of that tag an incoming peer may send
+	// to this node, e.g. {"TX": 500}. Tags which are not listed are not rate limited.
+	PeerMessageRateQuotas map[string]uint64 `version[13]:""`
+
+	// PeerQuotaBurstSeconds is the number of seconds worth of quota an incoming peer may use in a burst.
+	PeerQuotaBurstSeconds int `version[13]:"5"`
+
+	// PeerQuotaThrottleLimitSeconds is the number of seconds per minute an incoming peer may spend being throttled before
+	// it gets disconnected.
+	PeerQuotaThrottleLimitSeconds int `version[13]:"30"`
+
+	// EnableTxSyncReconciliation enables syncing pending transactions over websockets by reconciling the transaction pools
+	// with invertible bloom lookup tables. Peers which don't support it are synced from using the http bloom filter protocol.
+	EnableTxSyncReconciliation bool `version[13]:"true"`
+
+	// CatchupPeerSelection is the strategy used to select the peer to fetch each block from during catchup. "ranked" prefers
+	// the peers which served blocks quickly and reliably so far, and avoids the ones which returned bad blocks; "random"
+	// picks any of the least busy peers.
+	CatchupPeerSelection string `version[13]:"ranked"`
+
+	// CatchupLedgerDownloadParallelPeers is the number of peers the catchpoint file is downloaded from concurrently during
+	// a catchpoint catchup, each of them serving a different range of the file. A value of one downloads the whole file
+	// from a single peer.
+	CatchupLedgerDownloadParallelPeers int `version[13]:"4"`
 }
 
 // Filenames of config files within the configdir (e.g. ~/.algorand)
@@ -371,6 +438,10 @@ const CrashFilename = "crash.sqlite"
 // built-in supported consensus protocols.
 const ConfigurableConsensusProtocolsFilename = "consensus.json"
 
+// PeerIdentityKeyFilename is the name of the file holding the seed of the ed25519 key
+// used to identify this node to its gossip peers.
+const PeerIdentityKeyFilename = "peer_identity.key"
+
 // LoadConfigFromDisk returns a Local config structure based on merging the defaults
 // with settings loaded from the config file from the custom dir.  If the custom file
 // cannot be loaded, the default config is returned (with the error from loading the
@@ -250,6 +250,10 @@ type ConsensusParams struct {
 	// maximum cost of application approval program or clear state program
 	MaxAppProgramCost int
 
+	// maximum number of inner transactions (payments, asset transfers and
+	// asset configurations) that a single application call may issue
+	MaxInnerTransactions int
+
 	// maximum length of a key used in an application's global or local
 	// key/value store
 	MaxAppKeyLen int
@@ -294,8 +298,25 @@ type ConsensusParams struct {
 	// GlobalStateSchema (and therefore allowed in GlobalState)
 	MaxGlobalSchemaEntries uint64
 
+	// maximum size, in bytes, of the value of a single application box.
+	// Zero disables box storage.
+	MaxBoxSize uint64
+
+	// maximum length of the name of an application box
+	MaxAppBoxNameLen int
+
+	// flat MinBalance requirement for each box held by an application
+	// account
+	BoxFlatMinBalance uint64
+
+	// MinBalance requirement per byte of box name and value held by an
+	// application account
+	BoxByteMinBalance uint64
+
 	// maximum total minimum balance requirement for an account, used
-	// to limit the maximum size of a single balance record
+	// to limit the maximum size of a single balance record. The minimum
+	// balance charged for boxes doesn't count, since they are stored
+	// outside of the balance record.
 	MaximumMinimumBalance uint64
 
 	// CompactCertRounds defines the frequency with with compact
@@ -811,6 +832,23 @@ func initConsensusProtocols() {
 	// FilterTimeout for period 0 should take a new optimized, configured value, need to revisit this later
 	vFuture.AgreementFilterTimeoutPeriod0 = 4 * time.Second
 
+	// Enable TEAL 3: subroutines (callsub, retsub) with a run-time cost
+	// budget, pushint and pushbytes, bit, byte and stack manipulation (getbit,
+	// setbit, getbyte, setbyte, dig, swap, select, shl, shr, sqrt), byte-array
+	// math (b+, b-, b*, b/, b%, b<, b==, b|, b&), application accounts
+	// (global CurrentApplicationAddress), inner transactions (itxn_begin,
+	// itxn_field, itxn_submit) and boxes (box_create, box_get, box_put, ...)
+	vFuture.LogicSigVersion = 3
+
+	// Applications may issue up to 16 inner transactions from their account
+	vFuture.MaxInnerTransactions = 16
+
+	// Applications may store named boxes of up to 32KB in their account
+	vFuture.MaxBoxSize = 32768
+	vFuture.MaxAppBoxNameLen = 64
+	vFuture.BoxFlatMinBalance = 2500
+	vFuture.BoxByteMinBalance = 400
+
 	Consensus[protocol.ConsensusFuture] = vFuture
 }
 
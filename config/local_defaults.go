@@ -20,7 +20,7 @@
 package config
 
 var defaultLocal = Local{
-	Version:                               12,
+	Version:                               13,
 	AccountsRebuildSynchronousMode:        1,
 	AnnounceParticipationKey:              true,
 	Archival:                              false,
@@ -34,29 +34,37 @@ var defaultLocal = Local{
 	CatchupFailurePeerRefreshRate:         10,
 	CatchupGossipBlockFetchTimeoutSec:     4,
 	CatchupHTTPBlockFetchTimeoutSec:       4,
+	CatchupLedgerDownloadParallelPeers:    4,
 	CatchupLedgerDownloadRetryAttempts:    50,
 	CatchupParallelBlocks:                 16,
+	CatchupPeerSelection:                  "ranked",
 	ConnectionsRateLimitingCount:          60,
 	ConnectionsRateLimitingWindowSeconds:  1,
 	DNSBootstrapID:                        "<network>.algorand.network",
 	DNSSecurityFlags:                      1,
 	DeadlockDetection:                     0,
 	DisableOutgoingConnectionThrottling:   false,
+	EnableAccountHistory:                  false,
 	EnableAgreementReporting:              false,
 	EnableAgreementTimeMetrics:            false,
 	EnableAssembleStats:                   false,
 	EnableBlockService:                    false,
 	EnableDeveloperAPI:                    false,
 	EnableGossipBlockService:              true,
+	EnableGossipCompression:               true,
+	EnableGossipTLS:                       false,
 	EnableIncomingMessageFilter:           false,
 	EnableLedgerService:                   false,
 	EnableMetricReporting:                 false,
 	EnableOutgoingNetworkMessageFiltering: true,
+	EnablePeerExchange:                    false,
+	EnablePeerIdentity:                    false,
 	EnablePingHandler:                     true,
 	EnableProcessBlockStats:               false,
 	EnableProfiler:                        false,
 	EnableRequestLogger:                   false,
 	EnableTopAccountsReporting:            false,
+	EnableTxSyncReconciliation:            true,
 	EndpointAddress:                       "127.0.0.1:0",
 	FallbackDNSResolverAddress:            "",
 	ForceRelayMessages:                    false,
@@ -77,8 +85,13 @@ var defaultLocal = Local{
 	OptimizeAccountsDatabaseOnStartup:     false,
 	OutgoingMessageFilterBucketCount:      3,
 	OutgoingMessageFilterBucketSize:       128,
+	PeerBandwidthQuota:                    0,
 	PeerConnectionsUpdateInterval:         3600,
+	PeerIdentityAllowlist:                 "",
+	PeerMessageRateQuotas:                 map[string]uint64{},
 	PeerPingPeriodSeconds:                 0,
+	PeerQuotaBurstSeconds:                 5,
+	PeerQuotaThrottleLimitSeconds:         30,
 	PriorityPeers:                         map[string]bool{},
 	PublicAddress:                         "",
 	ReconnectTime:                         60000000000,
@@ -86,8 +99,10 @@ var defaultLocal = Local{
 	RestReadTimeoutSeconds:                15,
 	RestWriteTimeoutSeconds:               120,
 	RunHosted:                             false,
+	StateDeltaExportRounds:                0,
 	SuggestedFeeBlockHistory:              3,
 	SuggestedFeeSlidingWindowSize:         50,
+	TLSCAFile:                             "",
 	TLSCertFile:                           "",
 	TLSKeyFile:                            "",
 	TelemetryToLog:                        true,
@@ -59,9 +59,16 @@ const (
 
 // rawRequestPaths is a set of paths where the body should not be urlencoded
 var rawRequestPaths = map[string]bool{
-	"/v1/transactions": true,
-	"/v2/teal/dryrun":  true,
-	"/v2/teal/compile": true,
+	"/v1/transactions":          true,
+	"/v2/teal/dryrun":           true,
+	"/v2/teal/compile":          true,
+	"/v2/transactions/simulate": true,
+}
+
+// rawRequestWithQuery is a raw request body sent along with url encoded query parameters
+type rawRequestWithQuery struct {
+	body  []byte
+	query interface{}
 }
 
 // RestClient manages the REST interface for a calling user.
@@ -118,6 +125,14 @@ func (client RestClient) submitForm(response interface{}, path string, request i
 
 	if request != nil {
 		if rawRequestPaths[path] {
+			if withQuery, ok := request.(rawRequestWithQuery); ok {
+				v, err := query.Values(withQuery.query)
+				if err != nil {
+					return err
+				}
+				queryURL.RawQuery = v.Encode()
+				request = withQuery.body
+			}
 			reqBytes, ok := request.([]byte)
 			if !ok {
 				return fmt.Errorf("couldn't decode raw request as bytes")
@@ -295,6 +310,24 @@ type appsParams struct {
 	Max    uint64 `url:"max"`
 }
 
+type pageParams struct {
+	Limit   uint64 `url:"limit,omitempty"`
+	Next    string `url:"next,omitempty"`
+	Creator string `url:"creator,omitempty"`
+}
+
+type boxParams struct {
+	Name string `url:"name"`
+}
+
+type peerAddressParams struct {
+	Address string `url:"address"`
+}
+
+type banParams struct {
+	Duration uint64 `url:"duration,omitempty"`
+}
+
 type rawblockParams struct {
 	Raw uint64 `url:"raw"`
 }
@@ -303,6 +336,14 @@ type rawAccountParams struct {
 	Format string `url:"format"`
 }
 
+type simulateParams struct {
+	SkipSignatureVerification bool `url:"skip-signature-verification,omitempty"`
+}
+
+type accountAtRoundParams struct {
+	Round uint64 `url:"round"`
+}
+
 // TransactionsByAddr returns all transactions for a PK [addr] in the [first,
 // last] rounds range.
 func (client RestClient) TransactionsByAddr(addr string, first, last, max uint64) (response v1.TransactionList, err error) {
@@ -335,6 +376,13 @@ func (client RestClient) ApplicationInformation(index uint64) (response generate
 	return
 }
 
+// ApplicationBox gets the named box of the passed application index
+func (client RestClient) ApplicationBox(index uint64, name []byte) (response generatedV2.Box, err error) {
+	params := boxParams{Name: base64.StdEncoding.EncodeToString(name)}
+	err = client.get(&response, fmt.Sprintf("/v2/applications/%d/box", index), params)
+	return
+}
+
 // AccountInformation also gets the AccountInformationResponse associated with the passed address
 func (client RestClient) AccountInformation(address string) (response v1.Account, err error) {
 	err = client.get(&response, fmt.Sprintf("/v1/account/%s", address), nil)
@@ -347,6 +395,38 @@ func (client RestClient) AccountInformationV2(address string) (response generate
 	return
 }
 
+// AccountInformationAtRoundV2 gets the AccountData associated with the passed address as of the given round
+func (client RestClient) AccountInformationAtRoundV2(address string, round uint64) (response generatedV2.Account, err error) {
+	err = client.get(&response, fmt.Sprintf("/v2/accounts/%s", address), accountAtRoundParams{round})
+	return
+}
+
+// AccountAssetsV2 gets a page of the asset holdings of the passed address.
+// An empty next token requests the first page.
+func (client RestClient) AccountAssetsV2(address string, limit uint64, next string) (response generatedV2.AccountAssetsResponse, err error) {
+	err = client.get(&response, fmt.Sprintf("/v2/accounts/%s/assets", address), pageParams{Limit: limit, Next: next})
+	return
+}
+
+// AccountApplicationsV2 gets a page of the application local states of the passed address.
+// An empty next token requests the first page.
+func (client RestClient) AccountApplicationsV2(address string, limit uint64, next string) (response generatedV2.AccountApplicationsResponse, err error) {
+	err = client.get(&response, fmt.Sprintf("/v2/accounts/%s/applications", address), pageParams{Limit: limit, Next: next})
+	return
+}
+
+// AssetsV2 gets a page of assets, optionally only those of the passed creator.
+func (client RestClient) AssetsV2(creator string, limit uint64, next string) (response generatedV2.AssetsResponse, err error) {
+	err = client.get(&response, "/v2/assets", pageParams{Limit: limit, Next: next, Creator: creator})
+	return
+}
+
+// ApplicationsV2 gets a page of applications, optionally only those of the passed creator.
+func (client RestClient) ApplicationsV2(creator string, limit uint64, next string) (response generatedV2.ApplicationsResponse, err error) {
+	err = client.get(&response, "/v2/applications", pageParams{Limit: limit, Next: next, Creator: creator})
+	return
+}
+
 // Blob represents arbitrary blob of data satisfying v1.RawResponse interface
 type Blob []byte
 
@@ -363,6 +443,15 @@ func (client RestClient) RawAccountInformationV2(address string) (response []byt
 	return
 }
 
+// RawStateDelta gets the msgpack encoded state delta of the given round. If the round is the next round,
+// the server waits for the round to be available before responding.
+func (client RestClient) RawStateDelta(round uint64) (response []byte, err error) {
+	var blob Blob
+	err = client.getRaw(&blob, fmt.Sprintf("/v2/deltas/%d", round), rawAccountParams{Format: "msgpack"})
+	response = blob
+	return
+}
+
 // TransactionInformation gets information about a specific transaction involving a specific account
 func (client RestClient) TransactionInformation(accountAddress, transactionID string) (response v1.Transaction, err error) {
 	transactionID = stripTransaction(transactionID)
@@ -416,6 +505,19 @@ func (client RestClient) SendRawTransactionGroup(txgroup []transactions.SignedTx
 	return client.post(&response, "/v1/transactions", enc)
 }
 
+// SimulateRawTransactionGroup evaluates a transaction group on top of the latest round without
+// broadcasting it. The signatures of the transactions are not verified if skipSignatureVerification is set.
+func (client RestClient) SimulateRawTransactionGroup(txgroup []transactions.SignedTxn, skipSignatureVerification bool) (response generatedV2.SimulateResponse, err error) {
+	var enc []byte
+	for _, tx := range txgroup {
+		enc = append(enc, protocol.Encode(&tx)...)
+	}
+
+	request := rawRequestWithQuery{body: enc, query: simulateParams{SkipSignatureVerification: skipSignatureVerification}}
+	err = client.submitForm(&response, "/v2/transactions/simulate", request, "POST", false /* encodeJSON */, true /* decodeJSON */)
+	return
+}
+
 // Block gets the block info for the given round
 func (client RestClient) Block(round uint64) (response v1.Block, err error) {
 	err = client.get(&response, fmt.Sprintf("/v1/block/%d", round), nil)
@@ -447,6 +549,33 @@ func (client RestClient) Catchup(catchpointLabel string) (response privateV2.Cat
 	return
 }
 
+// Peers lists the gossip network peers the node is connected to
+func (client RestClient) Peers() (response privateV2.PeersResponse, err error) {
+	err = client.get(&response, "/v2/peers", nil)
+	return
+}
+
+// DisconnectPeer disconnects the peers whose address or remote host matches the given address
+func (client RestClient) DisconnectPeer(address string) (err error) {
+	var response struct{}
+	err = client.submitForm(&response, "/v2/peers", peerAddressParams{Address: address}, "DELETE", false, true)
+	return
+}
+
+// AddPhonebookAddress adds a relay address to the phonebook of the node
+func (client RestClient) AddPhonebookAddress(address string) (err error) {
+	var response struct{}
+	err = client.post(&response, "/v2/phonebook", peerAddressParams{Address: address})
+	return
+}
+
+// BanHost bans an IP address for the given number of seconds, or for the default duration when zero
+func (client RestClient) BanHost(host string, durationSeconds uint64) (err error) {
+	var response struct{}
+	err = client.post(&response, fmt.Sprintf("/v2/bans/%s", host), banParams{Duration: durationSeconds})
+	return
+}
+
 // GetGoRoutines gets a dump of the goroutines from pprof
 // Not supported
 func (client RestClient) GetGoRoutines(ctx context.Context) (goRoutines string, err error) {
@@ -23,6 +23,8 @@ import (
 	"strings"
 
 	"github.com/labstack/echo/v4"
+
+	"github.com/algorand/go-algorand/util/tokens"
 )
 
 // TokenPathParam is the name of the path parameter used by URLAuthPrefix
@@ -35,6 +37,12 @@ const urlAuthFormatter = "/urlAuth/%s"
 // InvalidTokenMessage is the message set when an invalid / missing token is found.
 const InvalidTokenMessage = "Invalid API Token"
 
+// ForbiddenTokenMessage is the message set when a named token's scope doesn't allow the request.
+const ForbiddenTokenMessage = "API Token scope does not allow this request"
+
+// ScopeCheck decides whether a named token with the given scope may make the request.
+type ScopeCheck func(scope tokens.Scope, req *http.Request) bool
+
 // AuthMiddleware provides some data to the handler.
 type AuthMiddleware struct {
 	// Header is the token header which needs to be provided. For example 'X-Algod-API-Token'.
@@ -42,18 +50,30 @@ type AuthMiddleware struct {
 
 	// Tokens is the set of tokens which can be set to allow access.
 	tokens [][]byte
+
+	// ScopedTokens holds the named tokens which are accepted when scopeCheck allows their scope.
+	scopedTokens *tokens.ScopedTokenStore
+	scopeCheck   ScopeCheck
 }
 
 // MakeAuth constructs the auth middleware function
 func MakeAuth(header string, tokens []string) echo.MiddlewareFunc {
+	return MakeScopedAuth(header, tokens, nil, nil)
+}
+
+// MakeScopedAuth constructs an auth middleware function which, besides the
+// given tokens, accepts the named tokens of scopedTokens whose scope passes scopeCheck.
+func MakeScopedAuth(header string, apiTokens []string, scopedTokens *tokens.ScopedTokenStore, scopeCheck ScopeCheck) echo.MiddlewareFunc {
 	apiTokenBytes := make([][]byte, 0)
-	for _, token := range tokens {
+	for _, token := range apiTokens {
 		apiTokenBytes = append(apiTokenBytes, []byte(token))
 	}
 
 	auth := AuthMiddleware{
-		header: header,
-		tokens: apiTokenBytes,
+		header:       header,
+		tokens:       apiTokenBytes,
+		scopedTokens: scopedTokens,
+		scopeCheck:   scopeCheck,
 	}
 
 	return auth.handler
@@ -101,6 +121,16 @@ func (auth *AuthMiddleware) handler(next echo.HandlerFunc) echo.HandlerFunc {
 			}
 		}
 
+		// Check the named tokens, which are looked up by their hash
+		if auth.scopedTokens != nil && len(providedToken) != 0 {
+			if token, ok := auth.scopedTokens.Lookup(string(providedToken)); ok {
+				if auth.scopeCheck == nil || !auth.scopeCheck(token.Scope, ctx.Request()) {
+					return echo.NewHTTPError(http.StatusForbidden, ForbiddenTokenMessage)
+				}
+				return next(ctx)
+			}
+		}
+
 		return echo.NewHTTPError(http.StatusUnauthorized, InvalidTokenMessage)
 	}
 }
@@ -18,15 +18,20 @@ package middlewares
 
 import (
 	"errors"
+	"io/ioutil"
 	"net/http"
+	"os"
 	"testing"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/util/tokens"
 )
 
 var errSuccess = errors.New("unexpected success")
 var invalidTokenError = echo.NewHTTPError(http.StatusUnauthorized, InvalidTokenMessage)
+var forbiddenTokenError = echo.NewHTTPError(http.StatusForbidden, ForbiddenTokenMessage)
 var e = echo.New()
 var testAPIHeader = "API-Header-Whatever"
 
@@ -186,3 +191,54 @@ func TestAuth(t *testing.T) {
 		})
 	}
 }
+
+func TestScopedAuth(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "scoped-auth")
+	require.NoError(t, err)
+	defer os.RemoveAll(dataDir)
+
+	readToken, err := tokens.GenerateScopedToken(dataDir, tokens.AlgodScopedTokensFilename, "reader", tokens.ScopeRead, "", 0)
+	require.NoError(t, err)
+	adminToken, err := tokens.GenerateScopedToken(dataDir, tokens.AlgodScopedTokensFilename, "admin", tokens.ScopeAdmin, "", 0)
+	require.NoError(t, err)
+
+	// Only let read tokens make GET requests
+	scopeCheck := func(scope tokens.Scope, req *http.Request) bool {
+		return scope == tokens.ScopeAdmin || req.Method == http.MethodGet
+	}
+	store := tokens.MakeScopedTokenStore(dataDir, tokens.AlgodScopedTokensFilename)
+	handler := MakeScopedAuth(testAPIHeader, []string{"token1"}, store, scopeCheck)(success)
+
+	tests := []struct {
+		name           string
+		token          string
+		method         string
+		expectResponse error
+	}{
+		{"Static token", "token1", "POST", errSuccess},
+		{"Read token GET", readToken, "GET", errSuccess},
+		{"Read token POST", readToken, "POST", forbiddenTokenError},
+		{"Admin token POST", adminToken, "POST", errSuccess},
+		{"Invalid token", "invalid_token", "GET", invalidTokenError},
+		{"Missing token", "", "GET", invalidTokenError},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, _ := http.NewRequest(test.method, "N/A", nil)
+			req.Header.Set(testAPIHeader, test.token)
+			ctx := e.NewContext(req, nil)
+			ctx.SetPath("")
+
+			err := handler(ctx)
+			require.Equal(t, test.expectResponse, err, test.name)
+		})
+	}
+
+	// Revoked tokens are rejected without restarting
+	require.NoError(t, tokens.RevokeScopedToken(dataDir, tokens.AlgodScopedTokensFilename, "reader"))
+	req, _ := http.NewRequest("GET", "N/A", nil)
+	req.Header.Set(testAPIHeader, readToken)
+	ctx := e.NewContext(req, nil)
+	require.Equal(t, invalidTokenError, handler(ctx))
+}
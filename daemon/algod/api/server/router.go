@@ -102,16 +102,44 @@ func registerHandlers(router *echo.Echo, prefix string, routes lib.Routes, ctx l
 // TokenHeader is the header where we put the token.
 const TokenHeader = "X-Algo-API-Token"
 
+// submitTransactionPaths are the public API endpoints submitting transactions
+// to the network when posted to.
+var submitTransactionPaths = map[string]bool{
+	"/v1/transactions": true,
+	"/v2/transactions": true,
+}
+
+// publicAPIScope lets read tokens make GET requests to the public API, submit
+// tokens also post transactions to it, and admin tokens make any request to it.
+func publicAPIScope(scope tokens.Scope, req *http.Request) bool {
+	readRequest := req.Method == http.MethodGet || req.Method == http.MethodHead
+	switch scope {
+	case tokens.ScopeAdmin:
+		return true
+	case tokens.ScopeSubmit:
+		return readRequest || (req.Method == http.MethodPost && submitTransactionPaths[req.URL.Path])
+	case tokens.ScopeRead:
+		return readRequest
+	default:
+		return false
+	}
+}
+
+// adminAPIScope only lets admin tokens use the private API and the profiler.
+func adminAPIScope(scope tokens.Scope, req *http.Request) bool {
+	return scope == tokens.ScopeAdmin
+}
+
 // NewRouter builds and returns a new router with our REST handlers registered.
-func NewRouter(logger logging.Logger, node *node.AlgorandFullNode, shutdown <-chan struct{}, apiToken string, adminAPIToken string, listener net.Listener) *echo.Echo {
+func NewRouter(logger logging.Logger, node *node.AlgorandFullNode, shutdown <-chan struct{}, apiToken string, adminAPIToken string, scopedTokens *tokens.ScopedTokenStore, listener net.Listener) *echo.Echo {
 	if err := tokens.ValidateAPIToken(apiToken); err != nil {
 		logger.Errorf("Invalid apiToken was passed to NewRouter ('%s'): %v", apiToken, err)
 	}
 	if err := tokens.ValidateAPIToken(adminAPIToken); err != nil {
 		logger.Errorf("Invalid adminAPIToken was passed to NewRouter ('%s'): %v", adminAPIToken, err)
 	}
-	adminAuthenticator := middlewares.MakeAuth(TokenHeader, []string{adminAPIToken})
-	apiAuthenticator := middlewares.MakeAuth(TokenHeader, []string{adminAPIToken, apiToken})
+	adminAuthenticator := middlewares.MakeScopedAuth(TokenHeader, []string{adminAPIToken}, scopedTokens, adminAPIScope)
+	apiAuthenticator := middlewares.MakeScopedAuth(TokenHeader, []string{adminAPIToken, apiToken}, scopedTokens, publicAPIScope)
 
 	e := echo.New()
 
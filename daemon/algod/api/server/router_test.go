@@ -17,6 +17,7 @@ package server
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/labstack/echo/v4"
@@ -25,6 +26,7 @@ import (
 
 	"github.com/algorand/go-algorand/daemon/algod/api/server/lib"
 	"github.com/algorand/go-algorand/daemon/algod/api/server/v1/routes"
+	"github.com/algorand/go-algorand/util/tokens"
 )
 
 type TestSuite struct {
@@ -180,3 +182,26 @@ func (s *TestSuite) TestGetTransactionByID() {
 func TestTestSuite(t *testing.T) {
 	suite.Run(t, new(TestSuite))
 }
+
+func TestPublicAPIScope(t *testing.T) {
+	get := httptest.NewRequest(http.MethodGet, "/v2/status", nil)
+	submitV1 := httptest.NewRequest(http.MethodPost, "/v1/transactions", nil)
+	submitV2 := httptest.NewRequest(http.MethodPost, "/v2/transactions", nil)
+	compile := httptest.NewRequest(http.MethodPost, "/v2/teal/compile", nil)
+	dryrun := httptest.NewRequest(http.MethodPost, "/v2/teal/dryrun", nil)
+
+	for _, req := range []*http.Request{get, submitV1, submitV2, compile, dryrun} {
+		assert.True(t, publicAPIScope(tokens.ScopeAdmin, req), req.URL.Path)
+		assert.False(t, publicAPIScope(tokens.ScopeWallet, req), req.URL.Path)
+	}
+
+	assert.True(t, publicAPIScope(tokens.ScopeRead, get))
+	assert.False(t, publicAPIScope(tokens.ScopeRead, submitV2))
+
+	// submit tokens may only post transactions
+	assert.True(t, publicAPIScope(tokens.ScopeSubmit, get))
+	assert.True(t, publicAPIScope(tokens.ScopeSubmit, submitV1))
+	assert.True(t, publicAPIScope(tokens.ScopeSubmit, submitV2))
+	assert.False(t, publicAPIScope(tokens.ScopeSubmit, compile))
+	assert.False(t, publicAPIScope(tokens.ScopeSubmit, dryrun))
+}
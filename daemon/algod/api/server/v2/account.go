@@ -365,7 +365,8 @@ func ApplicationParamsToAppParams(gap *generated.ApplicationParams) (basics.AppP
 func AppParamsToApplication(creator string, appIdx basics.AppIndex, appParams *basics.AppParams) generated.Application {
 	globalState := convertTKVToGenerated(&appParams.GlobalState)
 	return generated.Application{
-		Id: uint64(appIdx),
+		Id:      uint64(appIdx),
+		Address: appIdx.Address().String(),
 		Params: generated.ApplicationParams{
 			Creator:           creator,
 			ApprovalProgram:   appParams.ApprovalProgram,
@@ -103,6 +103,7 @@ func TestAccount(t *testing.T) {
 		} else {
 			require.Fail(t, fmt.Sprintf("app idx %d not in [%d, %d]", app.Id, appIdx1, appIdx2))
 		}
+		require.Equal(t, basics.AppIndex(app.Id).Address().String(), app.Address)
 		require.Equal(t, params.ApprovalProgram, app.Params.ApprovalProgram)
 		require.Equal(t, params.GlobalStateSchema.NumUint, app.Params.GlobalStateSchema.NumUint)
 		require.Equal(t, params.GlobalStateSchema.NumByteSlice, app.Params.GlobalStateSchema.NumByteSlice)
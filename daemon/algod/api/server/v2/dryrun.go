@@ -227,10 +227,14 @@ type dryrunLedger struct {
 
 	// accounts that have been Put
 	accounts map[basics.Address]basics.BalanceRecord
+
+	// boxes that have been Put, by application
+	boxes map[basics.AppIndex]map[string][]byte
 }
 
 func (dl *dryrunLedger) init() error {
 	dl.accounts = make(map[basics.Address]basics.BalanceRecord)
+	dl.boxes = make(map[basics.AppIndex]map[string][]byte)
 	dl.accountsIn = make(map[basics.Address]int)
 	dl.accountApps = make(map[basics.Address]int)
 	for i, acct := range dl.dr.Accounts {
@@ -326,6 +330,28 @@ func (dl *dryrunLedger) PutWithCreatable(record basics.BalanceRecord, newCreatab
 	return nil
 }
 
+// GetBox returns a box previously written during this dryrun. Dryrun
+// requests do not carry box contents, so no other boxes exist.
+func (dl *dryrunLedger) GetBox(appIdx basics.AppIndex, name string) ([]byte, bool, error) {
+	value, ok := dl.boxes[appIdx][name]
+	return value, ok, nil
+}
+
+// PutBox records a box written by the dryrun program
+func (dl *dryrunLedger) PutBox(appIdx basics.AppIndex, name string, value []byte) error {
+	if dl.boxes[appIdx] == nil {
+		dl.boxes[appIdx] = make(map[string][]byte)
+	}
+	dl.boxes[appIdx][name] = value
+	return nil
+}
+
+// DelBox forgets a box written by the dryrun program
+func (dl *dryrunLedger) DelBox(appIdx basics.AppIndex, name string) error {
+	delete(dl.boxes[appIdx], name)
+	return nil
+}
+
 // GetCreator gets the address of the creator of an app or asset
 func (dl *dryrunLedger) GetCreator(cidx basics.CreatableIndex, ctype basics.CreatableType) (basics.Address, bool, error) {
 	switch ctype {
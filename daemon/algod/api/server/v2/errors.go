@@ -19,11 +19,15 @@ package v2
 var (
 	errAppDoesNotExist                         = "application does not exist"
 	errAssetDoesNotExist                       = "asset does not exist"
+	errBoxDoesNotExist                         = "box does not exist"
+	errFailedToParseBoxName                    = "failed to parse the box name"
 	errFailedLookingUpLedger                   = "failed to retrieve information from the ledger"
 	errFailedLookingUpTransactionPool          = "failed to retrieve information from the transaction pool"
 	errFailedRetrievingNodeStatus              = "failed retrieving node status"
 	errFailedRetrievingLatestBlockHeaderStatus = "failed retrieving latests block header"
 	errFailedParsingFormatOption               = "failed to parse the format option"
+	errFailedParsingLimit                      = "failed to parse the limit, must be between %d and %d"
+	errFailedToParseNext                       = "failed to parse the next token"
 	errFailedToParseAddress                    = "failed to parse the address"
 	errFailedToParseTransaction                = "failed to parse transaction"
 	errFailedToParseBlock                      = "failed to parse block"
@@ -38,4 +42,13 @@ var (
 	errFailedToAbortCatchup                    = "failed to abort catchup : %v"
 	errFailedToStartCatchup                    = "failed to start catchup : %v"
 	errOperationNotAvailableDuringCatchup      = "operation not available during catchup"
+	errStateDeltaExportDisabled                = "state delta export is disabled on this node"
+	errStateDeltaNotAvailable                  = "state delta is not available for the requested round"
+	errRoundAfterLatest                        = "requested round is after the latest round"
+	errAccountNotAvailableInRound              = "account state is not available for the requested round"
+	errFailedToSimulate                        = "failed to simulate the transaction group"
+	errFailedRetrievingPeers                   = "failed retrieving the connected peers"
+	errFailedToDisconnectPeer                  = "failed to disconnect the peer"
+	errPeerNotFound                            = "no connected peer matches the address"
+	errFailedParsingBanDuration                = "failed to parse the ban duration, must be between 1 and 2592000 seconds"
 )
@@ -17,12 +17,24 @@ import (
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Temporarily bans an IP address.
+	// (POST /v2/bans/{host})
+	BanHost(ctx echo.Context, host string, params BanHostParams) error
 	// Aborts a catchpoint catchup.
 	// (DELETE /v2/catchup/{catchpoint})
 	AbortCatchup(ctx echo.Context, catchpoint string) error
 	// Starts a catchpoint catchup.
 	// (POST /v2/catchup/{catchpoint})
 	StartCatchup(ctx echo.Context, catchpoint string) error
+	// Disconnects a peer.
+	// (DELETE /v2/peers)
+	DisconnectPeer(ctx echo.Context, params DisconnectPeerParams) error
+	// Gets the connected peers.
+	// (GET /v2/peers)
+	GetPeers(ctx echo.Context) error
+	// Adds an address to the phonebook.
+	// (POST /v2/phonebook)
+	AddPhonebookAddress(ctx echo.Context, params AddPhonebookAddressParams) error
 
 	// (POST /v2/register-participation-keys/{address})
 	RegisterParticipationKeys(ctx echo.Context, address string, params RegisterParticipationKeysParams) error
@@ -36,6 +48,49 @@ type ServerInterfaceWrapper struct {
 	Handler ServerInterface
 }
 
+// BanHost converts echo context to params.
+func (w *ServerInterfaceWrapper) BanHost(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty":   true,
+		"duration": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+	// ------------- Path parameter "host" -------------
+	var host string
+
+	err = runtime.BindStyledParameter("simple", false, "host", ctx.Param("host"), &host)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter host: %s", err))
+	}
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params BanHostParams
+	// ------------- Optional query parameter "duration" -------------
+	if paramValue := ctx.QueryParam("duration"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "duration", ctx.QueryParams(), &params.Duration)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter duration: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.BanHost(ctx, host, params)
+	return err
+}
+
 // AbortCatchup converts echo context to params.
 func (w *ServerInterfaceWrapper) AbortCatchup(ctx echo.Context) error {
 
@@ -96,6 +151,105 @@ func (w *ServerInterfaceWrapper) StartCatchup(ctx echo.Context) error {
 	return err
 }
 
+// DisconnectPeer converts echo context to params.
+func (w *ServerInterfaceWrapper) DisconnectPeer(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty":  true,
+		"address": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DisconnectPeerParams
+	// ------------- Required query parameter "address" -------------
+	if paramValue := ctx.QueryParam("address"); paramValue != "" {
+
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Query argument address is required, but not found"))
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "address", ctx.QueryParams(), &params.Address)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter address: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.DisconnectPeer(ctx, params)
+	return err
+}
+
+// GetPeers converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPeers(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetPeers(ctx)
+	return err
+}
+
+// AddPhonebookAddress converts echo context to params.
+func (w *ServerInterfaceWrapper) AddPhonebookAddress(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty":  true,
+		"address": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AddPhonebookAddressParams
+	// ------------- Required query parameter "address" -------------
+	if paramValue := ctx.QueryParam("address"); paramValue != "" {
+
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Query argument address is required, but not found"))
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "address", ctx.QueryParams(), &params.Address)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter address: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.AddPhonebookAddress(ctx, params)
+	return err
+}
+
 // RegisterParticipationKeys converts echo context to params.
 func (w *ServerInterfaceWrapper) RegisterParticipationKeys(ctx echo.Context) error {
 
@@ -225,8 +379,12 @@ func RegisterHandlers(router interface {
 		Handler: si,
 	}
 
+	router.POST("/v2/bans/:host", wrapper.BanHost, m...)
 	router.DELETE("/v2/catchup/:catchpoint", wrapper.AbortCatchup, m...)
 	router.POST("/v2/catchup/:catchpoint", wrapper.StartCatchup, m...)
+	router.DELETE("/v2/peers", wrapper.DisconnectPeer, m...)
+	router.GET("/v2/peers", wrapper.GetPeers, m...)
+	router.POST("/v2/phonebook", wrapper.AddPhonebookAddress, m...)
 	router.POST("/v2/register-participation-keys/:address", wrapper.RegisterParticipationKeys, m...)
 	router.POST("/v2/shutdown", wrapper.ShutdownNode, m...)
 
@@ -235,132 +393,157 @@ func RegisterHandlers(router interface {
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+x9e3PcNpL4V8Fvdqv8+A1n5Fd2rarUnmI7iS6O47KU3N1avgRD9swgIgGGACVNfPru",
-	"V90ASJAEZ0ayznep3b9sDYBGo9EvNBrNj5NUFaWSII2eHH6clLziBRio6C+epqqWJhEZ/pWBTitRGqHk",
-	"5NC3MW0qIVeT6UTgryU368l0InkBbR8cP51U8FstKsgmh6aqYTrR6RoKjoDNpsTeDaSrZKUSB+LIgjh+",
-	"Obne0sCzrAKth1j+IPMNEzLN6wyYqbjUPMUmzS6FWTOzFpq5wUxIpiQwtWRm3enMlgLyTM/8In+rodoE",
-	"q3STjy/pukUxqVQOQzxfqGIhJHisoEGq2RBmFMtgSZ3W3DCcAXH1HY1iGniVrtlSVTtQtUiE+IKsi8nh",
-	"+4kGmUFFu5WCuKD/LiuA3yExvFqBmXyYxha3NFAlRhSRpR076leg69xoRn1pjStxAZLhqBn7vtaGLYBx",
-	"yd59/YI9efLkOS6k4MZA5phsdFXt7OGa7PDJ4STjBnzzkNd4vlIVl1nS9H/39Qua/8QtcN9eXGuIC8sR",
-	"trDjl2ML8AMjLCSkgRXtQ4f7cUREKNqfF7BUFey5J7bznW5KOP//6q6k3KTrUglpIvvCqJXZ5qgOC4Zv",
-	"02ENAp3+JVKqQqDvD5LnHz4+mj46uP7T+6Pk7+7PZ0+u91z+iwbuDgpEO6Z1VYFMN8mqAk7SsuZySI93",
-	"jh/0WtV5xtb8gjafF6Tq3ViGY63qvOB5jXwi0kod5SulGXdslMGS17lhfmJWyxzVFEJz3M6EZmWlLkQG",
-	"2RS17+VapGuWcm1BUD92KfIcebDWkI3xWnx1W4TpOiQJ4nUretCC/u8So13XDkrAFWmDJM2VhsSoHebJ",
-	"WxwuMxYalNZW6ZsZK3a6BkaTY4M1tkQ7iTyd5xtmaF8zxjXjzJumKRNLtlE1u6TNycU5jXerQaoVDIlG",
-	"m9Oxoyi8Y+QbECNCvIVSOXBJxPNyNySZXIpVXYFml2swa2fzKtClkhqYWvwKqcFt/9eTH94wVbHvQWu+",
-	"grc8PWcgU5WN77GbNGbBf9UKN7zQq5Kn53FznYtCRFD+nl+Joi6YrIsFVLhf3j4YxSowdSXHELIQd/BZ",
-	"wa+Gk55WtUxpc9tpO44aspLQZc43M3a8ZAW/+vJg6tDRjOc5K0FmQq6YuZKjThrOvRu9pFK1zPbwYQxu",
-	"WGA1dQmpWArIWANlCyZuml34CHkzfFrPKkDHAxlFp5llBzoSriI8g6KLLazkKwhYZsZ+dJqLWo06B9ko",
-	"OLbYUFNZwYVQtW4GjeBIU293r6UykJQVLEWEx04cOVB72D5OvRbOwUmVNFxIyFDzEtLKgNVEozgFE24/",
-	"zAxN9IJr+OLpmAFvW/fc/aXq7/rWHd9rt6lTYkUyYhex1Qls3G3qjN/j8BfOrcUqsT8PNlKsTtGULEVO",
-	"ZuZX3D9PhlqTEugQwhseLVaSm7qCwzP5EP9iCTsxXGa8yvCXwv70fZ0bcSJW+FNuf3qtViI9EasRYja4",
-	"Rk9TNKyw/yC8uDo2V9FDw2ulzusyXFDaOZUuNuz45dgmW5g3Zcyj5igbnipOr/xJ46YjzFWzkSNIjtKu",
-	"5NjxHDYVILY8XdI/V0viJ76sfsd/yjKP0RQZ2BlaCgq4YME79xv+hCIP9kyAUETKkahzMp+HHwOE/lzB",
-	"cnI4+dO8jZTMbaueO7g44/V0ctTCufuZ2pF2fb2DTNvMhLS7Q12n9kx49/gg1Cgm5Kj2cPgqV+n5rXAo",
-	"K1VCZYTdxwXCGUoKgWdr4BlULOOGz9pDlfWzRvidBn5L4+iUBFXExP1A/+E5w2aUQm68+4auq9DoxKkg",
-	"0JShx2ftiJ0JO5AnqlhhnTyGztmNsHzRTm4VdKNR3zuyfOhDi+zOK+tXMhrhF4FLb0+NRwtV3Y5feowg",
-	"WXsWZhyhNt4vrry7s9S1LhNHn4g/bTv0ALXhx6FaDSnUBx+jVYcKJ4b/D1BBI9S7oEIX0F1TQRWlyOEO",
-	"5HXN9Xq4CHRwnjxmJ98ePXv0+OfHz75AC11WalXxgi02BjS77+wK02aTw4PhykjB17mJQ//iqT9BdeHu",
-	"pBAh3MDeR6JOATWDpRiz8QLE7mW1qWp5BySEqlJVxOcl1jEqVXlyAZUWKhK+eOt6MNcD9ZD1u3u/W2zZ",
-	"JdcM56bjWC0zqGYxyuM5i0y6gULvMhQW9OmVbGnjAPKq4pvBDtj1Rlbn5t1nT7rE9969ZiVUibmSLINF",
-	"vQptFFtWqmCcZTSQFOIblcGJ4abWd6AFWmAtMrgRIQp8oWrDOJMqQ4HGznH9MBLLpCAKxX5MqHLM2tqf",
-	"BaB3nPJ6tTYM3UoV29p2YMJTuykJ2Qo9cvRrzuy2l53OxsnyCni2YQsAydTCna/cyY8WySksY/yNi9NO",
-	"LVrNmaCDV1mpFLSGLHHXSztR81dVtMlmC5kIb8K3mYRpxZa8uiWuRhme78CT+gyx1a034c6kQ6z3m37b",
-	"/vUnD3eRV3jEtEyArgsKdw4Gxki4kyZ1OXId4azaqShQJJjkUmlIlcx0FFjOtUl2iQJ26phe3NaA+2Lc",
-	"T4BHDt2vuTb22CtkRm6YFWGah8bQFOMIj2pphPyTV9BD2CnqHqlr3WhrXZelqgxksTVIuNoy1xu4auZS",
-	"ywB2YxKMYrWGXZDHqBTAd8SyK7EE4sbFXZq40HBxFOJG3bqJkrKDREuIbYic+F4BdcOQ7Agi6LM3I4lx",
-	"hO5xThMHnk60UWWJOskktWzGjZHpxPY+Mj+2fYfMxU2rKzMFOLvxODnMLy1lbTB+zdFfIsis4Oeo78n7",
-	"sefzIc4ojIkWMoVkG+ejWJ5gr1AEdgjpiOPprvuC2XrC0ePfKNONMsGOXRhb8IgX/NZGlU/biMsdOAIv",
-	"wXCR68bYN6HrdhaKcvczENAzqyAFafIN8upSVIW9KCIbof1v1pXI3Cz2SqQVP5mxCi55lfkewxNIsJhE",
-	"yAyu4tqVd+INGVwxEUd62cwsDEv9NY4MAcyigu4uxrag4AINt5kch8antdc+lko6diFIDSgAhUgrxe09",
-	"Hy7GGknTXGVVUHDEjm6cnFEfn1PIVWKvFSPm0bb7a0cf7g15Jg7X88moZDescbkGuslAdd0jYshteEwD",
-	"DWMLWeVqwfMEnVdIMsjNzjASOsXwknqinVTpcHgX5bOz93l2dvaBvca+5CcDO4fNnG5fWbrmcgVtSDzk",
-	"U+sBwxWkdajSe2Tc61Dj4n5d7LvHmumkVCpPmuNbP4Q/UPN9up+L9BwyhnqCnE5nfe51dwgnYfeRxXVz",
-	"yXG53nh/tixBQvZgxtiRZFCUZuNiBT1Poze5vGe2zX9Fs2Y13bdyyWiRszMZP6bb29pPlCkPZrsk2fSl",
-	"T5zKAtk+kbmSI+LEL+myAcFF5XNrpO+ERgYmZ2BJA6ayWOxzHv6Gcnp4Z5dFRoeN1qroelEISuwJuk1R",
-	"c/q71uFpVZgZY6ekO/C0oOECKp5T1oL2QVChWSHw0KnrNAXIDs9k0sEkVYWb+H77X6uWzuqDgyfADh70",
-	"x2iDbqI7GFkZ6I/9kh1MbRORi33JziZnkwGkCgp1AZk9HIZ8bUftBPv/Grhn8oeBYmYF39hjpZdFpuvl",
-	"UqTCEj1XqNdXquftSUUtUCF6gIczzYSZkikjipKXbPelFcBJ1Gu5i/hFBCr6x2hKUdv5G7Yu72gGVzzF",
-	"VXJSMht2iYzS8NnQ+TCqTEIA0XDqlhldQFt39Pgt5W6oz+1pejt+p73zdIccAbvOdvvMA2JEMdhH/I9Y",
-	"qXDXhcul8QkXudBmgKQ72NNtRsOQEaMzY/+hapZykt+yNtCcqVRFBxU6wOIMZGP9nM5TaykEORRgwx3U",
-	"8vBhf+EPH7o9F5ot4dInoGHHPjkePrRCoLT5ZAnosebVccSBoiAzWtNI0vCa6/VsZ8CZ4O4VZw5AH7/0",
-	"E5IwaU0m5no6waNuvrkDgbeAWAXO39OdoI+2rWoZJru5/dMbbaAYRi7t0J9HPNF3/oQ2sLRK5kJCUigJ",
-	"m2h+t5DwPTVG7TSxyMhgEtaxsf0TbAf/HlrdefbZzU+lL+12wBJvm9S7O9j8Ptxe0DpM8yMvE/KScZbm",
-	"ggKCSmpT1ak5k5wCFD03qMcWPuwyHrJ64bvEY2SREJYDdSa5Rho2YYvoZcYSIgHJrwF85ErXqxXonlvE",
-	"lgBn0vUSktVSGJqLvMrEblgJFd06zWxP9ASWPKcI2+9QKbaoTVf1UjaS9WxsBB2nYWp5JrlhOXBt2PdC",
-	"nl4ROH/C8TwjwVyq6ryhwsgJDSRooZP4xdw3tvVbrtd++djRKxs32AaJEX6bsrQx0El3/s/7fzt8f5T8",
-	"nSe/HyTP///8w8en1w8eDn58fP3ll//V/enJ9ZcP/vbn2E553GO5Mg7z45fOLTl+SbanDZ4PcP9swd9C",
-	"yCTKZHhcKISklMseb7H7aEE9Az1ow/Bu18+kuZLISBc8FxkegW/DDn0VN5BFKx09rulsRC+W59f6IXbc",
-	"Wamk5Ok53WtPVsKs68UsVcXcu2PzlWpcs3nGoVCS2rI5L8Ucj7fzi0c7TOMn6CsWUVfX04nTOvrOM2gc",
-	"4NiC+nM2UXT/t1Hs3jevTtnc7ZS+ZxPnLOgg4yniQbvLsM5hDhdvH37YzEE8zLyEpZAC2w/PZMYNny+4",
-	"Fqme1xqqr3jOZQqzlWKHzIF8yQ2nGEAvojj2NoviMw6bsl7kImXnoSluRXMsMHZ29h4Z5Ozsw+Aia2g4",
-	"3VTxYCNNkFwKs1a1SVxUdjyO0MZaCLKNy22bdcocbMuRLurr4I8EQMtSJ0FELL78ssxx+QEbakaDKA+K",
-	"aaMqrwRRM7qYBu7vG+Wu8ip+6bPRazy3/1Lw8r2Q5gNL3Pn7qCwp3Ebxrl+crkGe3JSwf8ysRbEFFjtn",
-	"0cKtQ3Xj3DgCemJH+SCyjlMOm4h01Ae1QhsTvC2dENS3KsfNvTWZAhhR6tRmnaBMRVelkbVIHoI3hHyF",
-	"utDfveGxGZnPvWlZAEvXkJ5DRhcPFCOcdob7K29nWbzICm2fodgUOMqVpuPgAlhdZtzZXi43/aRVDcb4",
-	"TN13cA6bU9WmWt8kS/V6OnFB/QR5ZkxASqRHYATUsisu/mKgt/nuboUC72XJbGzbZhd6tjhs+MKPGRcg",
-	"a5nuQHhiTNGQYQu/l7yKEMIy/wgJbrFQhPdJrB+NpPPKiFSUdv37xebfdsYgkF1KParG1bKvrQfKNKq9",
-	"bedkwXVccQO24H6gDPWzS/xMNrJiL8kYPWV2jLvIIbhV0k6yeUXOjl+2fZs5hlqcS6CSrTX1aHQpEprt",
-	"tbuWFBftZSRdO+9j4HZeSiEX+XwB0Q0/C5w3hws+ehMw+obgOEgCCJ6mNS8EvGLrC8O0eS1iX4n7lwT+",
-	"+YB/MzCZ3ij/fzpxuV6x7VCSrHsGOay4C3xTFpljFIfaPR1sEOLxw3KZCwksieUTcK1VKuxdaKvL3RyA",
-	"zt9Dxmxghe0NIcbGAdoUMSTA7I0KZVOuboKkBEEhRu5hU6wx+Bt2R9za5/rOrdzp/g11RytE0/Y5jd3G",
-	"YfRnOomqpDHPvNOL2S4LGBxlYiyKqmkYDxlGXTTkQOY46WjW5DwWJUOvAogNT/ywwF1n98USjfyDIHBc",
-	"wQrP3u15FaXVB2A+b8zgQhlIlqLSJqGjcnR52OlrTc7g19g1rn46pGL2va/I4tqHpj2HTZKJvI7vtpv3",
-	"u5c47Zvm3KLrxTlsyMgAT9dsQe/T0Qp1psc+W6a2OTVbF/zaLvg1v7P17sdL2BUnrpQyvTn+IFzV0yfb",
-	"hCnCgDHmGO7aKEm3qJcgG2GoW4I8CJszQfkVs22n9YEw3TijY1TzWkjRtQSO7tZV2MQfm9sTPO8e5kyP",
-	"yAAvS5Fd9c7OFmqcx2mKmzjq1uMfUIF21wHbQYHgnBxLIazAn/XtlgY20z7UH6RZ7aZMP7krUAjhVEL7",
-	"MjNDQiFrUzbOLlqdAs+/g81P2JeWM7meTj7tyB+jtYO4g9Zvm+2N0pliyPYI2Imc3ZDkvCwrdcHzxD1L",
-	"GWPNSl041qTu/hXLZ1Z18eP36auj128d+pS9BrxySVvbVkX9yj/MqvBEHMvcOg0iI+St+rOzdcSCzW/e",
-	"BobBFJ9o1/HlUIs55rLi1Ri4UBRdcGUZv8raGSoJk/NuJZmd7L5PjcyFqX53KvIDCYtzaLvDO/RCONeW",
-	"wgKFrZ2hmZL9BAd04+iUSexS8A3uog3MDhWErIsERSDRuUjjoQO50ChFsi7oxcbGAKPOIw4hQqzFSPhc",
-	"1iKAhd30HjdFPSSDOaLEpLDOFtotlCt6VkvxWw1MZCANNlUu4akjLCgbPod3aNLi+cIOsEsZbsB/ip1H",
-	"UGMWnpDYbuTDKG8kS9wf+vxCm/A0/hAE525wSRPOODBLWy5YHH84brY33etutDasUTbUQcgYtp7F7gJp",
-	"PnSwtoiOzBEteDaqsY/GtTXlge+vp1u1TOiGCtnm5vFcqwiYWl5yaesX4ThLQzdagz2346hLVdGjJQ3R",
-	"G2qhk2Wlfof4aXKJGxXJwXKkJJeNRs8ij0H6SrSJjLSV6Tx9QzxGWXvMmwoaWfcSbUTCicuD8DUllfog",
-	"E5eWrW2tpc7VbVw4wnSLuYXfCofDeZCikvPLBY+VHUCnBnE6ai9KOuEwo5gf7HdBN7nUjveCO5emr7Av",
-	"fUqo2kTJ4UvNWzoofyyWzyAVBc/j0dGMqN9965mJlbAFq2oNQUUkB8hW+rNc5KpK2auoljTHS3YwDWqu",
-	"ud3IxIXQYpED9Xhkeyy4JqvVhDybIbg8kGatqfvjPbqva5lVkJm1toTVijVOpH3c4OPPCzCXAJIdUL9H",
-	"z9l9irxrcQEPkIrOF5kcPnpOKRn2j4OYsXOV6bbplYwUy785xRLnY7p6sDDQSDmos+irM1tOdFyFbZEm",
-	"O3QfWaKeTuvtlqWCS76C+I1qsQMnO5Z2kwJ3PbrIzNbC06ZSGyZMfH4wHPXTSFoWqj+LhsuVL1CAjGJa",
-	"FchPbbkjO6kHZwvruRIkHi/fSNccpX/z0Du0ft4grbXlsVXTZdQbXkCXrFPG7eNMerbhHvU6hTgbqRUB",
-	"1UV8kmpkg73ddGPZfalkUqDsZA/ahL+A/6KlEpTheXRa43VXP3NlO+h9XS2EkowStu4Qlgc66dYkrqv4",
-	"OnmNU/347rUzDIWqYnUPWm3ojEQFphJwEZXYfuJa45k05sJTPuagfFWLPPupTTftlRiquEzX0fjnAgf+",
-	"3NZEa8huqR59grfmUkIeBWdl+Wcv8xGt9Kvad55CyD379ksH2eX2Ftci3kXTI+UnRPIKk+MEIVW7+XdN",
-	"4ki+UhmjedpH1i0jDN9IBWVUfqtBm9h7LmqwuU50xkZ/xVbxYCAzsvYzZt8/IS6dFyxkZUVR5/Y1BGQr",
-	"qFwApi5zxbMpQzinr45eMzurdm9o6d0NVRFZ2bd0nVX0zlZB9YebPC4cS43aH872nBFctTb0JFsbXpSx",
-	"rFfsceo7UGrtBRe5Tz8g8xNSZ8ZeWsuvvV2xk7RvSFkzndM1xBP4H2N4uiaT2jFA4yy/f/kbz5U6KAPZ",
-	"VNRriirYZ5FG+Qo4tgDOlCn0ey6FtqVs4QK6ibZN1rlz6XzibXd5VS2l5ZS4fdryKuI2ZPfI2Ys9H5KK",
-	"YtYj/A3NjFZ1lcJNqwGd0KjoG6t+aaFB/UcJ2emVbOqv+RLlKZdKipReOAXFcxuUXVncfWKmezwG6x+X",
-	"vYg7CY0IV7SgUZM64Kg4WuLIK0JHuGHAKGjFTbXcYf80VH8VD4IrMNppNsimvmiVO8cJqcEVyaAKyYGe",
-	"xON4//4werXRPte/IRtR+t+Iu/I1tpGrIlzKzrmQ9HjVkc1lB9mTFlXtNHi8E4atFGi3nu7jLP0ex8xO",
-	"r+QxYvxh5qt8EgwbQsZl2zuLIagjf4Phbgyw7wvsyyhc3P7cSTW0kx6VpZs0pgl0s8OxslujBI5EwRMf",
-	"hgyI28APoW1ht61Xj2RPkdHggi4uoCQ7PGCMkSfwr/BQaznKvqS1V/7RpxlCRtB4LSS0NWgjBiKNmgTa",
-	"GJLXkXE6rbixLuBeOu0UeE43JTGFpo0LHX0qqN4GE0lojX6O8W1si6+NKI6mQ+u4cblpSt8idwfOxAuq",
-	"ue0IOSylRl6Vc6IySurqFVeLKQ5U3L4sYdcADMVg6BPZ4abiVnJuYonGktAzofE4UizySBrLy6YxKDBI",
-	"+XKLDf0be4A8vgJ3sXbrghk08Mb+5fbiFTnufaLF6pa70o6/w23pyUC4RzHuf4VqJXy3M3hLbhVP86yG",
-	"rvCVL/dKh4omMb3Ls6Toooe2tnLn9kPreA3OKanGkUSed+2LUW61r40NjqXzpKPZZ9y41FLD2bYqMrZw",
-	"ZgyCvYe0BTvtxy+igYGxu0d79YjNg9H7+Q0DL4xgbyWov9QeIvSdz1phJRcu8N2KyJCyLr9tmHG4T+ZL",
-	"u8H9RbisMQISW8ktk7z2kr0hlSKCHaYG7GDP8w5J7WuQniepKrhj0gYm9IakHSY97Ls8WgdxTK1huM69",
-	"N6BD2xHa70P4Vi8MiTsuzmaxjzjHk+pxOOkTSxD/7GOoTT6bNujU+3Xzxnb9p7HogT0hjwSqejStRZ7t",
-	"2txO2LF9zkyBtZ8XXzztRO8+54Pqn+2F/FDc3NvSmxj+/iYQYSJr7UweTBUEFPeIJbphkcgh1YJK60qY",
-	"DeXueE9T/BzNS/4GpKt67IrINzeg7gLOfr/EhaZXTe/2kxPfKFsGukD3l1xBQ0VSXl3xoszBycWX9xZ/",
-	"gSd/fZodPHn0l8VfD54dpPD02fODA/78KX/0/MkjePzXZ08P4NHyi+eLx9njp48XTx8//eLZ8/TJ00eL",
-	"p188/8s9/70Hi2j7LYV/p6oDydHb4+QUkW1pwkvxHWzsO2NkY/+CmackiVBwkU8O/U//4iVslqoi+ESd",
-	"+3XiIv2TtTGlPpzPLy8vZ+GQ+YrK9iVG1el67ucZ1qR5e9wEaO2FP+2ojb0hK9CmOlY4orZ3r05O2dHb",
-	"41nLMJPDycHsYPaICoWUIHkpJoeTJ/QTSc+a9n3umG1y+PF6Opmvgedm7f4owFQi9U36kq9WUM3cU278",
-	"6eLx3Md35h/dJff1trZuloF7OhIMCN4ezj926i1mIVx6mTf/6DMwgiZbpHf+kcJHwe+uyub8Y1v29try",
-	"eg6xc7wvC9Z2p3JfVGFf21+Rvf0todDd0sPNXh1nuEc46kVTAjj8kOj7f9DP7n3ofYXk8cHBP9j3FJ7e",
-	"cMVbvdPOaS5SNeErnjF/U0RzP/p8cx9LereB6olZ9Xs9nTz7nKs/lsjyPGfUM8jtGG79j/Jcqkvpe6Kt",
-	"rIuCVxsvxrqjFHxhb9LIfKWpDGElLvCI/oHqXMau5kaUC3244sbKhb7G8U/l8rmUyx/jMyWPbyjgf/wV",
-	"/1Od/tHU6YlVd/urU+fK2WSEuS0E13p4/g3k8GFg1zcd08nu4MLuU9RTwuUDl9BgwUYemTaXxyqzEQ5f",
-	"KMinSblZZwOd/c4B7bxn/g42epcCP10D+6X9dPwvlMxJVwlTpir2C8/z4Df6Aqh3wmcj36FvHh7u+xH6",
-	"6+tpDK0lgE8tpRRSV8sUDdk5+Ceqlgad68bhDX1bVm4Jo9+itdW3Qg3mWPDRwcFBLLWnj7OLxliMKZX3",
-	"UiU5XEA+3OoxJHovVbd9uXH0OxzDB8bhKTrCdf5Dx82b49EPWXZfzd4Eu5dK3jPskgtXUj2oUmO/dlII",
-	"47/xalN+XDpgYyPi3wVNEOT2zwZ/qvH+49Umvd6i7PS6Npm6lOOKi94K8dwl21L6axM8MIp5AI2mmjH/",
-	"0b584786yzglH6nadD8G7YtP9EowN+WRVkLSBCTlNIvNKudBzqb7EMZQCZ44zN7Y74b09F70m5gWx7jc",
-	"x4T+U3lp6Ghs3StfrKTz9xxZHt1V+7GhhCg0DFAY4PncpZ30frWXw8GP3TLLkV/nzUOtaGM/7BJrnX80",
-	"V0FkpY13hvFD2qkmcvj+AxKcMoLdJrbhsMP5nC5k10qb+QQVTjdUFjZ+aGj80e+8p/X1h+v/DgAA//8U",
-	"2bjJl4UAAA==",
+	"H4sIAAAAAAACA+09a3MbN5J/Bafdqtg+DinZsnetq9Se1nYSbxzHZWmzd2f5EpADkhMNZ7jz0CM+/ffr",
+	"BzDAzADkUNZ611X+kIrFARpAo7vR6Bc+7M3y1TrPVFaVe0cf9taykCtVqYL+krNZXmdVlMT4V6zKWZGs",
+	"qyTP9o7MN1FWRZIt9kZ7Cf66ltUS/p0BENsG+4/2CvX3OikUgKqKWo32ytlSrSQCrq7X2LqBdBUt8kiD",
+	"OGYQL5/v3Wz4IOO4UGXZn+WPWXotkmyW1rESVSGzUs7wUykuk2opqmVSCt0ZmglAhMjn8HOrsZgnKo3L",
+	"sVnk32tVXDur1IOHl3RjpxgVear683yWr6YJDK5npZpJNRsiqlzEak6NlrISOALO1TSEz6WSxWwp5nmx",
+	"Zao8CXe+KqtXe0fv9kqVxaqg3Zqp5IL+OS+U+k1FlSwWqtp7P/Itbg4zjKpk5VnaS419GLhOK0D3nFYD",
+	"a1zAAJnAXmPxQ11WYgrrzsTbb56JR48ePcWFrGRVqVgTWXBVdnR3TdwdvseyUuZzn9Zkushhr+OoaQ8T",
+	"oPFP9AKHtpJlqfzMcoxfBNBqYAGmo4eEkqxSC9qHFvVjDw9T2J+nCmaqBu4JN77TTXHH/6fuykxWs+U6",
+	"Bzx69kXQV8GfvTLM6b5JhjUTaLVfI6YKBPpuP3r6/sPB6GD/5nfvjqP/0X8+fnQzcPnPGrhbMOBtOKuL",
+	"QmWz62hRKEncspRZHx9vNT2Uy7xOY7GUF7T5ckWiXvcV2JdF54VMa6STZFbkxzAT4G5NRiCqJIASZmBR",
+	"ZymKKYSmqV0AgHWRXySxikcofS+XCezFTJYMgtqBRExTpMG6VHGI1vyr28BMNy5KcF63wgct6F8XGXZd",
+	"WzChrkgaRLM0L4El8y3HkzlxgOqEe6DYs6rc7bASp7BAGhw/8GFLuMuQplM4wSvaVxgOfhfmaAI0zcV1",
+	"XotL2pw0Oaf+ejWItZVApNHmtM5RZN4Q+nrI8CBvmsNyAa+IPMN3fZRl82RRw3IBBQomw2ce/A3qFqw0",
+	"n/6qZhVu+19Ofnwt8kL8AJiRC/VGzs4FbGAeh/dYD+o7wX8tc9zwVblYAyD/cZ0mq8Qz5R/kVbKqVwIg",
+	"TWG6sF/mfACcFaqqiyw0IYa4hc5W8qo/6GlRZzPaXDtsS1FDUkrKdSqvx+LlXACQr/dHejpADsAQa1Ba",
+	"YGmiusqCShqOvX16QMd1Fg/QYSrcMOfULNdqlgDlxqKBsmEmepht80my3eZjNStnOgZIcDrNKFumk6kr",
+	"D80g6+IXYLCFckhmLP6qJRd9rfJz0CqMgBPTa/q0LtRFktdl0ykwRxp6s3qd5aBNALx54qGxE40OlB7c",
+	"RovXlVZwZnlWSZBWMUpemjSAY0kUnJMz4ObLTP+InoJUf3IYOsDt14G7Dz07u75xxwftNjWKmCU95yJ+",
+	"1QzrV5ta/Qdc/tyxy2QR8c+9jUwWp3iUzJOUjplfcf8MGuqShEALEebgAZCZBImhjs6yB/iXiEA7ArTL",
+	"IsZfVvzTDwAogUHwp5R/epUvkhn8FEBmM1fvbYq6rfh/CM8vjqsr76XhVZ6f12t3QbPWrRSY6OXz0CYz",
+	"zF0J87i5yrq3itMrc9PYtQfMwmxkYJJB3K0lNjxX14XC2crZnP53NSd6kvPiN/zfep36cIoErA9aMgpo",
+	"Y8ExNE/gsMFz5a3+jp+R/RXfD6RtMqGjFH6zkwMBtlZFlTBUaFtGaT6TaVRWcITRMiu1oo+/B9EAU/rd",
+	"xBpYJgyonDjzeIXdT6j3TbMOWRSwei1zI5KdffIA+RoT64PkTTICNkJVI4Mj6Bw5QYKIQrUDeU8Bn2jp",
+	"y/oQC+TG3KBFuNaRxr07SVgY4QFAn2guLFZphCRjIkFSTVDEp+pCZpUDuyVvGgHxro9XM7jdadaeeKc7",
+	"VzqLW0EwBMEoSf0EfZspARdkiAI16zshBwI0nAiw+Xd5Gmv8ftl72ntG4k47TlejJWMyvM+32uGNG2iG",
+	"oDk5dHf3I9me20jewfxee1Z3JPEacLeRdv/adN4XQ3axgwixUYJbXXEbkETvniwQapgjuqTwz5Rzn9fG",
+	"8wJ33HLuBC3+DMfO+R3geYpw+qgh8GKpZAzLj2UlnQXpifpVNer4HfUjkyCM5HGY0D/g1MTPqHLC2alt",
+	"FWinQdkOItbxqsS8LYhnHgkbkNklFyu2aAi0ROw0y2d28N7uMFqGbM4LNqII6mEWQTuUX905MwJM3xzg",
+	"5y4jWgPt8TQvbicWOlSYCWt2FhKhNoYmxHubrqhpvY707nhMV9ygA8h6+jZzTxe8b6daWADt9x+AhRKh",
+	"3gUW2oDuGgtASEmq7kBaLGW57C8CbQmPHoqT744fHzz8+eHjJyiqoOMChCFcIFEvvqevcLCy61Td96p/",
+	"dMP2Q39yaIyVbbhbMUQTbmAP4edThXKJMSbYNI+ze15cF3V2ByhURZEXHvMSkU6Vz/I0ulBFmeSeA+uN",
+	"biF0C5SCbOLq/M6zFZcSxCiMTZbPGp2uXsUbTZqDT1oGfXqVWdy0z9zODvB6PavT4w7ZkzbyjSGtFGv0",
+	"wlxlIlbTetG6D8yLfCWkiKkjiePXQD14Ba7LO5ACFpidDG6EOwUQbDXISZFBW7oe1qVfPgTchuSvIDdL",
+	"5Yqcasmn31ShhjKT9WJZCbTg5L6ttR0jOeNNieikKgNW1sY8zq14OHZJpQUc69cwMJzE+VSbMrWRlRYp",
+	"yQNSmeAGLZ0817HWvAAjM5AMMDF9ndo6NRMVQptcbUATzZvm2wwiylzMZXHLuVZ5JdMt86Q2/dmWVpfR",
+	"5t/+rIcNv2n/uoO7u4jOLsOZqDghc6egrIZQuBUncPL4Pf/6VDuFj7jYDPToUgGjxKUXWCrLKtrGCtio",
+	"dfTitjrU56N+AhwwK7yCb2xhTrKYlEBmYRqH7Q04RHjCQSmNkH8yAroPe4ayJytBdBhpXdbrNShBKvat",
+	"gS4wwbFew1czFmy3hd0cCbDRdam2QQ5hyYGvkcUrYQQBURl7jL4A9RdH3mSUrddeVLYmYRGxaSInppWD",
+	"Xdf7GZgI3hiankQ48EubchqX6wjQlK/XKJOqqM6afiE0nXDr4+qvtm2fuNBHbWRlnCscvTJz0jO/ZMyy",
+	"3xuUF6HnYW6kpP2wKbw/Z2TGqAQxo6JNlI9seYKtXBbYwqQBxVNH1jijdZijQ79eogsSwZZdCC04oAW/",
+	"UTDAHShwa6UDFwepSzjqVh2JQQ7STjF4Ki/LZA3EUl3mxbmgzpawElJJMuhPUp50nzfsuz61fp070IGe",
+	"K1AB0rLRcxoHuR2FfOndOEdUSjG6Iqvgfj/DAIZixeEodDyW5jfWomI9CgdeWMkD/xXqUhaxadG/fDmL",
+	"iYAN1ZX/YJEtwyY0w4gP36TnzcgJyBcTLJK5AMZeGafDbzZMQZvSbjM4dvUPy8EljKXSF3ZEH5D3VxhN",
+	"JDmaCBfD+kHVBMwUQAA4O4pr0fpMeEwggoiDlzyaAX83wU3GqezSjB+uoZNoq5cAjoLCmOg6SHSpDW+o",
+	"Co6IwEIWaT41rqEoVmm11TZD/rXn1BJVBOtZst3bUz47e5fGZ2fvxSvrQRLn6npCMV5itpTZQlnHu0un",
+	"rPyrKzWr3dOsg8ZhllPezvbsu1bUNRwyUXNz7QYK9E64Lt7Pk9k5TBPlBOnb+uD9qr1DOIi4hyReNqEU",
+	"l8tro8rDQQAXjvtjIY4zoVbr6lqbSTpKVmfw7Ktq0/hXNGpcU1QX8BMtcnyW+S0UHBP2kTxlwGzmJA6S",
+	"/sihGMjmgeACHWAneUkhDQjOy58bTawn1NM5cvqnniUqnsWQA/BbihyWrV1OYrpn2VOlrKerhMKHnWYj",
+	"lJwmoqt/UU8qoKxTkh14USoV7BBagmTJ6qWOv1wleN8u69lMqfjoLItaMwH+0gPfs/9ksXRW7+8/UmL/",
+	"frdPWaGGrO+EzAPdvl+L/RF/InTB32d7Z3s9SCCm8wt0aeC92KVr7rUV7L81cM+yH3uCGVTQa75RG14E",
+	"NMznySxhpKc5yvVF3lF0s5y+kBdlpfBeCtivRnSUEUbpgsD7YhnQr7XchenGAxV1JjxKUdqZOJ427ZQg",
+	"a+FfsEpJQuZaXCKhNHTWVz5AcY1cAF5L8oYRtSehbMnxW/JdX56zIWHz/E47poQWOhxyHeBv7yHDO4OB",
+	"rrAcdz3REbsmrDNNyqo3SW3TIDdSQ5CeQ2cs/juvgdOJf9dwojbXSWAKvKPR3R1HoDPWjKk1NYshlQKF",
+	"s6WHvjx40F34gwd6zwHQXF2aMHds2EXHgwfMBHlZfTQHdEjz6qVHgSL7Op6mntQktKJv92gS3EGXGAf0",
+	"y+dmQGKmsqQjBrqcJKs6BTzfTUBBNAM8Bmxm+MlM4vTF8SvjYoBrSk0mJiXRxOHyJjdegBhd/4f4TRU5",
+	"a2mt1C8yjHCAedVS3kDZS7187R7KHYYNqJA4fdYbqYEelEPV2edhjaQ021HjR7GBjPriDb+pK20ecWGy",
+	"ZBl67L/QINr65BxuaHWhwn4w0jeULNF070T56FnrFU2xza90sx2L4ykq74YJO81A4VFrLZd3jzNqEiMc",
+	"yCD3FerlyPmgSYzV2LmNzvM0zS8NPpFqkaIyFVS4ApTYjNAmJUlHq4k0SAqBwS7Xz2Ul7XaiqchzbFGn",
+	"pvlYvCC92Y+0BrcffeT8DUY9fr7V7GH50hD4qIkbHuwbYjcUK4Auj/LiSpYijVBpqPIOxMpAntTCghb2",
+	"T2G/DtZ52ruENrRWYwMc0A6bXt+BSsaAgP70jbxseSRK/gpodJKe9AlbXpdAqH23Gnf9OcDpbw2N9Vgz",
+	"z9IkAzEF23HtzfOFrz/QRy9j0yEe6EzqVKhv17zamn9nWu1xhuzjx+KXdtth7zdNCtYdbH4Xbsej6qZ7",
+	"kR1ApWvg9FmakLcKBgeFbFadZZKs552LaocsjE8g7E95Zpr4HTge/4oGBROgqNDGpu49eubKc/Z9o5Rx",
+	"q5T1YgFnR+cqAL3OMt0KNqbOkorGont/xBsGy6SQiDG3xLvaHNOW4PZH+skUrritk4GyUvjuye5dHAag",
+	"wkIw6RA9VT8k6OdHcMYGZWimsT1rLARsaHBJLJMy8keNfMtfv4OPZvnYsFGu+DN7MBG+TV25pmhtm/b6",
+	"v/f+dITprjL6bT96+u+T9x8Ob+4/6P348Obrr/+v/dOjm6/v/+n3vp0yc/flTOiZg+rKF0f4B94OrGe3",
+	"N/dP5pnERCsvkeGpBB8p9a5DW+IeaqiGgO5bH7He9bMMYyyAkEA7STCh+lbk0BVxPV5k7uhQTWsjOo4m",
+	"s9b3vqNxkUcYEEjK5t4C9JF6Oobb3cQcmRNo0Pw7lgqkKX2LJ3KdTNAAObk42HJ5+Qh5JTziCobSUqe8",
+	"88BBDdi3oO6YjYvX/A07/9W3L07FRO9U+RUnUDFoJ/PFY+PQkRotcxsungsAcAYZmpueYx5vgt+PzjKM",
+	"N51MZZnMykldquLPMpXZTI0XuTgSGiSqs2Sl7Vz1QjU6yIKuZ7Oup4BGNLX7WDPkujg7e4cEggb7bpRF",
+	"/+A0mQFedxANEKFqntdVpP1mYUuvtYYTZPacbBp1JDRspkjtl9PwAy4qT5ZRf/lAfrh8N/Bcp7/glqGv",
+	"ujBCECWjtjrj/r7OdZwJGpV1VnKNltVfVnL9DibyXkTaQgq3FZuv9IuWNUiTMOnhXo2hyU+0cFaodk6T",
+	"IKAn3Mu4+Uo/5vAToY7aoFSwXpvb4slJ7bk1mrakB8m6WkbIU95VlUhaxA9OLRm5QFloAkPwTorEp2sb",
+	"YBbsUqEzhlzD5MUZtbqbeCx9shiWTUouR8DR4ZQzSwY7LFOwjqU+e2V23U1ehPVV5qb1VgHLn+Y25XaX",
+	"bEV0QrLbNUKaCTHIGvHhHAKdPI3GddvZfO39Jtfoei3Y+8iB94Ysjhq6MH3CDMQn0x0wj48oGjRsoHfA",
+	"gAcRTPwBFNxioQjvo0jf6+uUcJrMkjWvf5j39E2rDwLZJtS9YhzDptvSuidMvdKbG0cYKe3dDoVfcD+Q",
+	"h7qhj2Yktn2zrURQSStNuNNUOX5/17LpoIpr9ISm5qcS0N7taWqm0caIe2wvdeAIqA5NuAjFRA054P6B",
+	"yYWbcslfOhFqTomSJlPcCLYuM4yaqgFcLcxklJs0cpM7DtPZJQ8cI6ooENm3HXlGp3sMS11I7ZqkEGdN",
+	"KHpqX5XOBuE8fpzP0TwhIl+wG/B8Pks4WsXKcj2GQuXvgRBsWBGDIfjI2Jk2+XQIsAB58sYl0l0mmamE",
+	"nEDSwCZvkPO3GpDl1WSua7Vyq/rXlx2Wiax5VG9j3/rTZKK+6Yoxr2beaiW4yVT1rjI+EkXR1LeH9K0u",
+	"JSCLjuOoJVmjc5+VDLUKRWR4Yro56rq4l2De7fV9x7VXqAXeve19FbnVGGA+rc3gAqt1zJMC4x/xquxd",
+	"Hjb6piRl8Bts6hc/LVQJrvuUxH7pQ8MCdqI4SWv/butxv3+Ow75u7i1lPYV+dMiQj2tKdcrwFGoNj202",
+	"DM0BnxsX/IoX/Ere2XqH0RI2xYGLPK86Y3wmVNWRJ5uYyUOAPuLo71oQpRvEi+Ng6MsWx9np+A7Gm27r",
+	"PWbaOeYuKHlDDo9W4vrmVXBoJkdfOmW+yuH2h+P2/cbIUDTuFHma2kwaN5pUfEOx4L7bIt1+KEUWdAQO",
+	"+5linEOmOn7opCxrP/TdL0ABDgegSXzVsQwwzvwcTAjc5RrC95m9kf/KRR+7w9tNGm+gDaJ+PZ0tFOLY",
+	"EXzx/1iDzSkF4uoUXNCuFyjcppwk3h6e7AhMd6ikNOVY+6hG1qd40m3YxrzH79X1T9iWlrN3M9r7OJNI",
+	"B+tuqaAtuH7TEIgXz2Rj5ytyy7K4I8rhY5EDciId8BEibmikqYuam/iQT3wU+LkTA1be6OlT/LWShQ47",
+	"3rQqarf+bFaFFgNf7PGpYzkibd7YFlhUuqLUlBVwjU0mVLyl66KU18TF7NUoAO0gHjI+zf2uvq2S1A0v",
+	"vxVntuLTP9Zy6Qar3ynL9zjMT6F2h7fIBXesDQX4VlxjEmtIdEP0KJQLb+FELugmnSptuO4LCOgXIQtE",
+	"JUzAb1rJpnTyQEtKt4TGghoHFGaEWCcB90JWJw4sbFYO8KR1JumM4UUmmb024G6a64ImdZb8vYbDJcZw",
+	"S/hU6JDdFrMgb5gslP6R5s940YB10ksD/mM0BQSlz4zeqUOT2HzIu1ZwT56TuRSbhTYKmS0BtasTyx2x",
+	"dyxtcEBp+tDUzJEAy7Y1263l3ZdBSBhc93F7IXGjrOqqV4ExvIXBgxL7OCytKZNpuJy2Ypmm6wpkji6X",
+	"aZl7wNTZpcy4zi/2Yxzq3hxSyELjMi8o47hUXg9+UkbzIv9N+W/bc9woTxSxRiWpbNR77Mnk7ArRxnJk",
+	"K7gb/LrzCJJ2SJtyPoq2kzHA4UTljnmf0iKMEQ4aEUCuSdxybfuZw72mTBi+ZY5G4++E8KTycip9FYtQ",
+	"qcE5ORetlrkQE95151YZN5f2HJ9U0zbhNF2Ygw3175dZuKWC8nmRfAwksoIhvMiPCfvtQg1xski4sDNs",
+	"gVM5WAPiivhMRbr6MrvqLGpgQ/ZHTm1yvRtxcpGUyTRV1OKAW6CTg9bWXLJNF1weLHNZUvOHA5ovAaXA",
+	"ftCFEQtobZRITs8z9vmpqi6xFME+tTt4Ku6RZ6JMLtR9xKLWRfaODp5SyAr/se877HQF901yJSbB8jct",
+	"WPx0TK4ZhoGHlIY69qaM87MbYRG2gZu46xBeopZa6m3npZXM5EL5Pc6rLXPivrSbZNjs4CWLuWY8DJZf",
+	"Y8aXd3xVSZRPgbA1FH88DZ3thSkfVGs+XyE92bLAPKgBxwXodfUyMy/zkdxAa5O117m0flojNp/lvlWT",
+	"s+41fG6jdUSx57VOPNR2Ji0Qx4FCT6q48A9SBDbYnJu6L4asZdEKeSe+bwMiHfrz1jlCR6N32MrIrm5k",
+	"z2bQQ1UthBIFEVu3ECsdmXRrFNeFf52yxqH++vaVPhhWWFq+nwtppaE+JAoFoNWFl2O7gX2NZtIcFwbz",
+	"PgUFK9r1qw/mVwLR1VhcdQyc54YWQip+wLVONaiRaNcR8/BV359j7GZ9vwJ+MeDpjy788fYBuvc4SbU0",
+	"eFAvquokjX+ykcudQo5AfLOl15Q+xY4/2zLrzbyYQL359kuZZSr1gmOx97MRjx4B/ms+dBxg4YFtuwUa",
+	"ebmdxdmJt6dpJmUGRPQmFb6R1cJqO5SziUHCsFBB49hiMpZn+qkZTrk4qhvqS97mgqIk+8kcgaodVysD",
+	"CopJMRoLTnamvLRWPhAqJDo9LhapijG7lm1V9TrNJSiDCIdy2XjUUhfMoCRbqpa24MT51io611CnytUu",
+	"lQRCUXZ3VcqX06uo9AysebX2BVBji1PTgKK0L2SSmkgWOqld7IzFc1aSSnME6xyupmCEaIbTYploAv9R",
+	"VRLmzaVWRkNIfniZP0OVpfOyRFOkvykexTUQYN660h8X+hsJKl17mZT8Og6ms7eouklg0NqvieFuLw/o",
+	"KGNK2SWVrikVtSvazeTYR2ysd96ZdRC/44lc5nUxU7tWPTyhXt6E6m4Jxd6TEpghd5U1VW7Nq2dwwOZZ",
+	"MqN0Zt/RoV/aGWJeHpD53bUsGBbXHOphLm/hxiYKRWMxmK5nBKFGXN+25nzFTWXq4D8retIF78wLjBpk",
+	"yYaRX7o4p77ygrRWuhgYPbrkyEm0XHRd0V4vkK3NsyMZUSRpQLP7Br+RVpfo6K/zJKNKFRptOtCML6X0",
+	"EEiFN2FQ/hZYHIzX087ELt9hnzEQ0kuc8fuxeTiEYLC1HZfN7p0+qGPj7NHOFWz7DNsKsqzbn1tRqzwo",
+	"9NWD+iRB2eywr7xoEMG+lFBjsXWQ28B3oW0gt41e2ibPG9NtgSrUms7hHmEE6t284CRdpCgum8HRI94s",
+	"nyTzTOMVxro1CovngJh5jwTaGOLXQD9oj/E7g2Ua+pXIqeQTaMAsbGX7WFCdDSaU0BrNGOFttEVmA4Kj",
+	"aWAVNwwBN0yB1O0oE8/oGS+NyH7JWNKqtBIVU3xgp4isT3Cg4DZZ7e0DoM8GfZ2IuwN/z9SuJ1EonyFO",
+	"Sry5raapJyLqefPRKaRMoZdwp8T/+1K/wyvQPshbV8eijjvrl5srVaW49xEG5N5uV2z/O9yWbga2s0c+",
+	"6n+BYsVNAesVjmHB02RoUbRDborq06WiyXHo5KzLSvovbbYyw+ZrarjW+IhEYyAm7K1NPpYsfdmMGooM",
+	"mwUDGWWlo5RhlZtKxnGBcB8EdtlyYXJ+T9NrQwm5adlLi597vYfpDT0tjGBvRKjx//cn9L0J8BFrmWgf",
+	"gWWRPmZ1qGTY2LGJyu0GdxehAxCDxguqg+l5GtdTzHJDLcvB4Xan/ZQiBM2pJWj/gh/zulrknEAAmu1I",
+	"6KRWHRhqHyDNQOrMTHwdIMXpoh/44ygCsnb73UO8CAyrjOsiEG/Ydp3oUqyCqpua3HMNhKuRwFkFEy2X",
+	"KhAmi1lY6HEOqKenVK6cmxhjm7NSg7GAiZxFa2TSMbatxnRo8jdsWZrOIK5tyIxSqlBRaM8IpTbHb4Zt",
+	"9r4P9m9OJeEOyldw/2hVDvb7VNYUvE9PEwLGQjWybeYJNiL7gqFUSvReU9pcZzGjTqXesfhRV3QDJTTL",
+	"uZPTvqnxHYvrUJnQdZHkRVJdR5cKa9v5Z2oaCW7k8hRbPpvbOteqQE3qXHWjXRN+Qod9NfllFkoZWmE4",
+	"8jIvq1ChIGwglk7dJrtTXoINmlpOHftC7xUF8io1D/wEuCEYWupjeh/rdAndIc7+5rSx4xO0twzMHqTk",
+	"9I8jjwblhqtt0QPOW2cXZ3B2ruxwY7vjM8y5q+x4hvUD8YYuj9ZBRzM6wnvrHLwBLdwGcD8E8VYB6yM3",
+	"rDdV0yF6kz8RDruT4sYIMamafe7/ZGpX6wEZPa5v138KyQ42RQY8Ah2covNg6+NOrn/HliAhD8bPU1iB",
+	"6yb5lEVQfuYgsZBU3emG1d0EQoxnra3BnaEcz80Ap43u5nHRUIVdaAyyleJJzZU++dmbS4QlX/gZHf0m",
+	"mn3pjYNC+DU47S5dNK1tma9vc35XaIV2BjoiKyo9+eJK4hGt+eLrr6Z/UI/+eBjvPzr4w/SP+4/3Z+rw",
+	"8dP9ffn0UB48fXSgHv7x8eG+Opg/eTp9GD88fDg9fHj45PHT2aPDg+nhk6d/+Mq81csTte/g/hdVCoqO",
+	"37yMTuk9PLs16wSECtcGQTI2VUfgYkJ2sJVMUmimf/pPw2FYT8WCN7/uae/z3rKq1uXRZHJ5eTl2u0wW",
+	"VAw9qvJ6tpyYcfqVPt+8bDxhHIRGO8pODiQF2lRNCsf07e2Lk1MB/cZ7zom/tz/eHx+QzrdWGSwVfnpE",
+	"PxH3LGnfJ5rY4N/QcAKoS6ul/mOFvu+Z+VReygWImrEuv4I/XTycGEP65IM+/282fZt0Xrjc0NCk2Ieb",
+	"tKLodOqo08E/kvMrgHLr5Mc3A5tNpuS5N027E6W/oZMOWXSgTiVCQ/3lhrzYXjXvzzLj0kpvmovYnFNv",
+	"tSp15Kp9aM2iVxC0skYJ21ZHxHioOCmbxsZxoO9yVlHTJr/EvJXD9RAFYDxJdcxYhlX6QHoR+TXEiAVV",
+	"cc7f4WJ0rDEniB2986mczrJAEwaoY/8D50uGt/Vhc6uM+kYzODPKMoxHFwlziQi87u1orW7xNvLY7R09",
+	"erLvCWK7ed95FPvh/v5ORYy2FrP78Xvk5cMdwW7UalvmNt/zihJki3bl09gHn27slxnlaKJYEyy2ocnj",
+	"T7n6l+hwQLsitXTiFD0PqmbnGVzrTEs8Y2s48ICo8F09GLqQRYIpiz3uJmEuFyXVhS+SCzSjvr8xEoMe",
+	"sZp8oOuyI0n0KzSTD/ZZqBtdmFP5/D+mdrxtTjXh6QXKkn9FeWAC8ZKy/TRXm9vpWc1nzRNZG1n+mAEJ",
+	"A8nD6a2Rwvze6H+t9lYLfAc63fsPB6OD/ZvfoZan/3z86GagE9m+mClOGhVuYMOP5f3P/73RL2LpcxNL",
+	"x8z80vPwnV8mjQIaS0C40MOuOwsXeq32i3D5VMLl83jG9+GODP75r/iLOP3cxOkJi7vh4lSrchwK0Ffx",
+	"OLh1wjWq7c/NG3whVe95c90r/RfFJb5rppVPcv3xL47Hx7lBjsQK12AqD7Ckt4prW3bbocnnOeA26HFU",
+	"Nt7Ijq9DB8M7vg7fzc06IYbfHL9c3D4BSx/uH366GbzOO2TfomJpixF8joLGZXBpfXMedW3hy3d/lZRa",
+	"Mmx/TbMpCuXGIow6r3KQPw2AJjPDx0nhWpb6cuJbRQKCufQjGO+zf530yyn/uTHft8p/rG484tdLmO00",
+	"z8/DVt/jOOYqC9Y2Sgei6dnkKgApjcyTyGjBQcdLbGvTN5E9LgMK96jlF03tO2bNCNbWa+i1UKTjcphN",
+	"xwYUx29Mz+PmzN31vC9UKq8pxQ9P+SN6DTqnF+olhip9OeS/cPQ/3gyyhfM2crYpltivINh2iIUsJ9pb",
+	"Ku5RTGumLu/rdDUG66lG2aQGIY+yY0hr5TrSKKidv9VAW4VPvweQQxj3Fw0+SuJfqKoBBYqTrv6LTFPn",
+	"N4Gx5sZbF3Du3IaBvf4dfMND11igWgr6WUoUgFjekvGoXUCuu7Cff2XfnwGYIcHDz3R4XEIH+16XUG/O",
+	"OgSEZ0w1LS7zKFUXKu1vdWgSnZKWPYxtGP60/ZZK5725xnXvoTp6qnSqbHFS38w48q9VXnOX2T3P8cXi",
+	"S5lU3TcFqYQJJbXCHOY5eSgxoVPnxTeWHN+ksjxCkL652LIz7+9UE/0cnpm82SDVymVdxSAvw4KLimaB",
+	"6OWqE1QHoolYwHxHDaCRVGPxo47Rh8sEBkImMb67hKGfmHXfiB/sbKpUd17Tbd5RWKD/FgYgLqdRuLyK",
+	"7McQ94XgiZ7ZawDZl3s++tFz9PP9J/EDb94rU9W89fcESR6NyhF5ECPCUN/GVCmZTnRSYedXTv1xfmy/",
+	"mOv5ddJULPN+7MZn+L5OPlRXrYiJViOT+G0+2xgsN6aJNrKJZnr3HveDKmfoPbYhOkeTCWXjoO452UN5",
+	"1A7fcT++b7bAVF5otuLm/c3/A8p8DbPnqwAA",
 }
 
 // GetSwagger returns the Swagger specification corresponding to the generated code
@@ -104,6 +104,9 @@ type AccountStateDelta struct {
 // Application defines model for Application.
 type Application struct {
 
+	// Address of the account controlled by the application. Funds held by this account can only be moved by inner transactions issued by the application.
+	Address string `json:"address"`
+
 	// \[appidx\] application index.
 	Id uint64 `json:"id"`
 
@@ -227,6 +230,16 @@ type AssetParams struct {
 	Url *string `json:"url,omitempty"`
 }
 
+// Box defines model for Box.
+type Box struct {
+
+	// \[name\] box name, base64 encoded
+	Name []byte `json:"name"`
+
+	// \[value\] box value, base64 encoded.
+	Value []byte `json:"value"`
+}
+
 // BuildVersion defines model for BuildVersion.
 type BuildVersion struct {
 	Branch      string `json:"branch"`
@@ -321,6 +334,40 @@ type EvalDeltaKeyValue struct {
 	Value EvalDelta `json:"value"`
 }
 
+// Peer defines model for Peer.
+type Peer struct {
+
+	// The address of the peer: the URL of outgoing peers, or the public address announced by incoming peers, which may be empty.
+	Address string `json:"address"`
+
+	// The number of seconds since the connection was established.
+	ConnectionDuration uint64 `json:"connection-duration"`
+
+	// The instance name announced by the peer.
+	InstanceName *string `json:"instance-name,omitempty"`
+
+	// The number of messages received from the peer.
+	MessagesReceived uint64 `json:"messages-received"`
+
+	// The number of messages sent to the peer.
+	MessagesSent uint64 `json:"messages-sent"`
+
+	// Whether the connection was made by this node.
+	Outgoing bool `json:"outgoing"`
+
+	// The round trip time of the last ping sent to the peer, in nanoseconds. Omitted if no ping to the peer completed yet.
+	PingRoundTripTime *uint64 `json:"ping-round-trip-time,omitempty"`
+
+	// The priority weight of the peer, based on the online stake of the account it proved to own.
+	PriorityWeight uint64 `json:"priority-weight"`
+
+	// The remote host of the connection.
+	RemoteHost string `json:"remote-host"`
+
+	// The network protocol version used with the peer.
+	Version *string `json:"version,omitempty"`
+}
+
 // StateDelta defines model for StateDelta.
 type StateDelta []EvalDeltaKeyValue
 
@@ -520,6 +567,11 @@ type NodeStatusResponse struct {
 	TimeSinceLastRound uint64 `json:"time-since-last-round"`
 }
 
+// PeersResponse defines model for PeersResponse.
+type PeersResponse struct {
+	Peers []Peer `json:"peers"`
+}
+
 // PendingTransactionResponse defines model for PendingTransactionResponse.
 type PendingTransactionResponse struct {
 
@@ -574,6 +626,32 @@ type PostTransactionsResponse struct {
 	TxId string `json:"txId"`
 }
 
+// SimulateResponse defines model for SimulateResponse.
+type SimulateResponse struct {
+
+	// The cost of the TEAL programs run by each transaction of the group; zero for transactions which are not application calls.
+	AppCosts []uint64 `json:"app-costs"`
+
+	// The state delta which would result from the group, encoded using the versioned exported state delta format.
+	Delta map[string]interface{} `json:"delta"`
+
+	// The reason for which the group would be rejected. Absent if the group would be accepted.
+	FailureMessage *string `json:"failure-message,omitempty"`
+
+	// The round in which the group was evaluated, i.e. the round following the latest one.
+	Round uint64 `json:"round"`
+
+	// The evaluated transactions along with their ApplyData, encoded as signed transactions with ApplyData. Empty if the group would be rejected.
+	Txns []map[string]interface{} `json:"txns"`
+}
+
+// StateDeltaResponse defines model for StateDeltaResponse.
+type StateDeltaResponse struct {
+
+	// The state delta of the round, encoded using the versioned exported state delta format.
+	Delta map[string]interface{} `json:"delta"`
+}
+
 // SupplyResponse defines model for SupplyResponse.
 type SupplyResponse struct {
 
@@ -617,6 +695,27 @@ type TransactionParametersResponse struct {
 // VersionsResponse defines model for VersionsResponse.
 type VersionsResponse Version
 
+// BanHostParams defines parameters for BanHost.
+type BanHostParams struct {
+
+	// The duration of the ban, in seconds.
+	Duration *uint64 `json:"duration,omitempty"`
+}
+
+// DisconnectPeerParams defines parameters for DisconnectPeer.
+type DisconnectPeerParams struct {
+
+	// The address of the peer, or the remote host of its connection.
+	Address string `json:"address"`
+}
+
+// AddPhonebookAddressParams defines parameters for AddPhonebookAddress.
+type AddPhonebookAddressParams struct {
+
+	// The address of the relay, as host:port or as a URL.
+	Address string `json:"address"`
+}
+
 // RegisterParticipationKeysParams defines parameters for RegisterParticipationKeys.
 type RegisterParticipationKeysParams struct {
 
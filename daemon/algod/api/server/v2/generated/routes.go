@@ -20,18 +20,36 @@ type ServerInterface interface {
 	// Get account information.
 	// (GET /v2/accounts/{address})
 	AccountInformation(ctx echo.Context, address string, params AccountInformationParams) error
+	// Get the application local states of an account.
+	// (GET /v2/accounts/{address}/applications)
+	AccountApplicationsInformation(ctx echo.Context, address string, params AccountApplicationsInformationParams) error
+	// Get the asset holdings of an account.
+	// (GET /v2/accounts/{address}/assets)
+	AccountAssetsInformation(ctx echo.Context, address string, params AccountAssetsInformationParams) error
 	// Get a list of unconfirmed transactions currently in the transaction pool by address.
 	// (GET /v2/accounts/{address}/transactions/pending)
 	GetPendingTransactionsByAddress(ctx echo.Context, address string, params GetPendingTransactionsByAddressParams) error
+	// List applications.
+	// (GET /v2/applications)
+	GetApplications(ctx echo.Context, params GetApplicationsParams) error
 	// Get application information.
 	// (GET /v2/applications/{application-id})
 	GetApplicationByID(ctx echo.Context, applicationId uint64) error
+	// Get box information for a given application.
+	// (GET /v2/applications/{application-id}/box)
+	GetApplicationBoxByName(ctx echo.Context, applicationId uint64, params GetApplicationBoxByNameParams) error
+	// List assets.
+	// (GET /v2/assets)
+	GetAssets(ctx echo.Context, params GetAssetsParams) error
 	// Get asset information.
 	// (GET /v2/assets/{asset-id})
 	GetAssetByID(ctx echo.Context, assetId uint64) error
 	// Get the block for the given round.
 	// (GET /v2/blocks/{round})
 	GetBlock(ctx echo.Context, round uint64, params GetBlockParams) error
+	// Get the state delta for the given round.
+	// (GET /v2/deltas/{round})
+	GetStateDelta(ctx echo.Context, round uint64, params GetStateDeltaParams) error
 	// Get the current supply reported by the ledger.
 	// (GET /v2/ledger/supply)
 	GetSupply(ctx echo.Context) error
@@ -59,6 +77,9 @@ type ServerInterface interface {
 	// Get a specific pending transaction.
 	// (GET /v2/transactions/pending/{txid})
 	PendingTransactionInformation(ctx echo.Context, txid string, params PendingTransactionInformationParams) error
+	// Simulates a raw transaction group.
+	// (POST /v2/transactions/simulate)
+	SimulateTransaction(ctx echo.Context, params SimulateTransactionParams) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -71,6 +92,7 @@ func (w *ServerInterfaceWrapper) AccountInformation(ctx echo.Context) error {
 
 	validQueryParams := map[string]bool{
 		"pretty": true,
+		"round":  true,
 		"format": true,
 	}
 
@@ -94,6 +116,16 @@ func (w *ServerInterfaceWrapper) AccountInformation(ctx echo.Context) error {
 
 	// Parameter object where we will unmarshal all parameters from the context
 	var params AccountInformationParams
+	// ------------- Optional query parameter "round" -------------
+	if paramValue := ctx.QueryParam("round"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "round", ctx.QueryParams(), &params.Round)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter round: %s", err))
+	}
+
 	// ------------- Optional query parameter "format" -------------
 	if paramValue := ctx.QueryParam("format"); paramValue != "" {
 
@@ -109,6 +141,114 @@ func (w *ServerInterfaceWrapper) AccountInformation(ctx echo.Context) error {
 	return err
 }
 
+// AccountApplicationsInformation converts echo context to params.
+func (w *ServerInterfaceWrapper) AccountApplicationsInformation(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty": true,
+		"limit":  true,
+		"next":   true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+	// ------------- Path parameter "address" -------------
+	var address string
+
+	err = runtime.BindStyledParameter("simple", false, "address", ctx.Param("address"), &address)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter address: %s", err))
+	}
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AccountApplicationsInformationParams
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := ctx.QueryParam("limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "next" -------------
+	if paramValue := ctx.QueryParam("next"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "next", ctx.QueryParams(), &params.Next)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter next: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.AccountApplicationsInformation(ctx, address, params)
+	return err
+}
+
+// AccountAssetsInformation converts echo context to params.
+func (w *ServerInterfaceWrapper) AccountAssetsInformation(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty": true,
+		"limit":  true,
+		"next":   true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+	// ------------- Path parameter "address" -------------
+	var address string
+
+	err = runtime.BindStyledParameter("simple", false, "address", ctx.Param("address"), &address)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter address: %s", err))
+	}
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AccountAssetsInformationParams
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := ctx.QueryParam("limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "next" -------------
+	if paramValue := ctx.QueryParam("next"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "next", ctx.QueryParams(), &params.Next)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter next: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.AccountAssetsInformation(ctx, address, params)
+	return err
+}
+
 // GetPendingTransactionsByAddress converts echo context to params.
 func (w *ServerInterfaceWrapper) GetPendingTransactionsByAddress(ctx echo.Context) error {
 
@@ -163,6 +303,64 @@ func (w *ServerInterfaceWrapper) GetPendingTransactionsByAddress(ctx echo.Contex
 	return err
 }
 
+// GetApplications converts echo context to params.
+func (w *ServerInterfaceWrapper) GetApplications(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty":  true,
+		"limit":   true,
+		"next":    true,
+		"creator": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApplicationsParams
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := ctx.QueryParam("limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "next" -------------
+	if paramValue := ctx.QueryParam("next"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "next", ctx.QueryParams(), &params.Next)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter next: %s", err))
+	}
+
+	// ------------- Optional query parameter "creator" -------------
+	if paramValue := ctx.QueryParam("creator"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "creator", ctx.QueryParams(), &params.Creator)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter creator: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetApplications(ctx, params)
+	return err
+}
+
 // GetApplicationByID converts echo context to params.
 func (w *ServerInterfaceWrapper) GetApplicationByID(ctx echo.Context) error {
 
@@ -193,6 +391,109 @@ func (w *ServerInterfaceWrapper) GetApplicationByID(ctx echo.Context) error {
 	return err
 }
 
+// GetApplicationBoxByName converts echo context to params.
+func (w *ServerInterfaceWrapper) GetApplicationBoxByName(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty": true,
+		"name":   true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+	// ------------- Path parameter "application-id" -------------
+	var applicationId uint64
+
+	err = runtime.BindStyledParameter("simple", false, "application-id", ctx.Param("application-id"), &applicationId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter application-id: %s", err))
+	}
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApplicationBoxByNameParams
+	// ------------- Required query parameter "name" -------------
+	if paramValue := ctx.QueryParam("name"); paramValue != "" {
+
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Query argument name is required, but not found"))
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "name", ctx.QueryParams(), &params.Name)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetApplicationBoxByName(ctx, applicationId, params)
+	return err
+}
+
+// GetAssets converts echo context to params.
+func (w *ServerInterfaceWrapper) GetAssets(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty":  true,
+		"limit":   true,
+		"next":    true,
+		"creator": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAssetsParams
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := ctx.QueryParam("limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "next" -------------
+	if paramValue := ctx.QueryParam("next"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "next", ctx.QueryParams(), &params.Next)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter next: %s", err))
+	}
+
+	// ------------- Optional query parameter "creator" -------------
+	if paramValue := ctx.QueryParam("creator"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "creator", ctx.QueryParams(), &params.Creator)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter creator: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetAssets(ctx, params)
+	return err
+}
+
 // GetAssetByID converts echo context to params.
 func (w *ServerInterfaceWrapper) GetAssetByID(ctx echo.Context) error {
 
@@ -266,6 +567,49 @@ func (w *ServerInterfaceWrapper) GetBlock(ctx echo.Context) error {
 	return err
 }
 
+// GetStateDelta converts echo context to params.
+func (w *ServerInterfaceWrapper) GetStateDelta(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty": true,
+		"format": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+	// ------------- Path parameter "round" -------------
+	var round uint64
+
+	err = runtime.BindStyledParameter("simple", false, "round", ctx.Param("round"), &round)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter round: %s", err))
+	}
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetStateDeltaParams
+	// ------------- Optional query parameter "format" -------------
+	if paramValue := ctx.QueryParam("format"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "format", ctx.QueryParams(), &params.Format)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter format: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetStateDelta(ctx, round, params)
+	return err
+}
+
 // GetSupply converts echo context to params.
 func (w *ServerInterfaceWrapper) GetSupply(ctx echo.Context) error {
 
@@ -524,6 +868,53 @@ func (w *ServerInterfaceWrapper) PendingTransactionInformation(ctx echo.Context)
 	return err
 }
 
+// SimulateTransaction converts echo context to params.
+func (w *ServerInterfaceWrapper) SimulateTransaction(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty":                      true,
+		"skip-signature-verification": true,
+		"format":                      true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SimulateTransactionParams
+	// ------------- Optional query parameter "skip-signature-verification" -------------
+	if paramValue := ctx.QueryParam("skip-signature-verification"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "skip-signature-verification", ctx.QueryParams(), &params.SkipSignatureVerification)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter skip-signature-verification: %s", err))
+	}
+
+	// ------------- Optional query parameter "format" -------------
+	if paramValue := ctx.QueryParam("format"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "format", ctx.QueryParams(), &params.Format)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter format: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.SimulateTransaction(ctx, params)
+	return err
+}
+
 // RegisterHandlers adds each server route to the EchoRouter.
 func RegisterHandlers(router interface {
 	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
@@ -542,10 +933,16 @@ func RegisterHandlers(router interface {
 	}
 
 	router.GET("/v2/accounts/:address", wrapper.AccountInformation, m...)
+	router.GET("/v2/accounts/:address/applications", wrapper.AccountApplicationsInformation, m...)
+	router.GET("/v2/accounts/:address/assets", wrapper.AccountAssetsInformation, m...)
 	router.GET("/v2/accounts/:address/transactions/pending", wrapper.GetPendingTransactionsByAddress, m...)
+	router.GET("/v2/applications", wrapper.GetApplications, m...)
 	router.GET("/v2/applications/:application-id", wrapper.GetApplicationByID, m...)
+	router.GET("/v2/applications/:application-id/box", wrapper.GetApplicationBoxByName, m...)
+	router.GET("/v2/assets", wrapper.GetAssets, m...)
 	router.GET("/v2/assets/:asset-id", wrapper.GetAssetByID, m...)
 	router.GET("/v2/blocks/:round", wrapper.GetBlock, m...)
+	router.GET("/v2/deltas/:round", wrapper.GetStateDelta, m...)
 	router.GET("/v2/ledger/supply", wrapper.GetSupply, m...)
 	router.GET("/v2/status", wrapper.GetStatus, m...)
 	router.GET("/v2/status/wait-for-block-after/:round", wrapper.WaitForBlock, m...)
@@ -555,170 +952,221 @@ func RegisterHandlers(router interface {
 	router.GET("/v2/transactions/params", wrapper.TransactionParams, m...)
 	router.GET("/v2/transactions/pending", wrapper.GetPendingTransactions, m...)
 	router.GET("/v2/transactions/pending/:txid", wrapper.PendingTransactionInformation, m...)
+	router.POST("/v2/transactions/simulate", wrapper.SimulateTransaction, m...)
 
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+x9/XfbNrLov4Kre89p0itazld343N67nOTtPXbJM2J3b37bpzXhciRhDUJcAHQkprn",
-	"//0dDAASJEFJ/khad/VTYhEYDAbzhcFg8GmUiqIUHLhWo6NPo5JKWoAGiX/RNBUV1wnLzF8ZqFSyUjPB",
-	"R0f+G1FaMj4fjUfM/FpSvRiNR5wW0LQx/ccjCf+smIRsdKRlBeORShdQUANYr0vTuoa0SuYicSCOLYiT",
-	"l6OrDR9olklQqo/lTzxfE8bTvMqAaEm5oqn5pMiS6QXRC6aI60wYJ4IDETOiF63GZMYgz9SBn+Q/K5Dr",
-	"YJZu8OEpXTUoJlLk0MfzhSimjIPHCmqk6gUhWpAMZthoQTUxIxhcfUMtiAIq0wWZCbkFVYtEiC/wqhgd",
-	"fRgp4BlIXK0U2CX+dyYBfoVEUzkHPfo4jk1upkEmmhWRqZ046ktQVa4VwbY4xzm7BE5MrwPyplKaTIFQ",
-	"Tt5//4I8efLkuZlIQbWGzDHZ4Kya0cM52e6jo1FGNfjPfV6j+VxIyrOkbv/++xc4/qmb4K6tqFIQF5Zj",
-	"84WcvByagO8YYSHGNcxxHVrcb3pEhKL5eQozIWHHNbGN73RRwvF/01VJqU4XpWBcR9aF4FdiP0d1WNB9",
-	"kw6rEWi1Lw2lpAH64TB5/vHTo/Gjw6t//3Cc/I/789mTqx2n/6KGu4UC0YZpJSXwdJ3MJVCUlgXlfXq8",
-	"d/ygFqLKM7Kgl7j4tEBV7/oS09eqzkuaV4ZPWCrFcT4XilDHRhnMaJVr4gcmFc+NmjLQHLcTpkgpxSXL",
-	"IBsb7btcsHRBUqosCGxHlizPDQ9WCrIhXovPboMwXYUkMXjdiB44od8vMZp5baEErFAbJGkuFCRabDFP",
-	"3uJQnpHQoDS2Sl3PWJGzBRAc3HywxhZpxw1P5/maaFzXjFBFKPGmaUzYjKxFRZa4ODm7wP5uNoZqBTFE",
-	"w8Vp2VEjvEPk6xEjQrypEDlQjsTzctcnGZ+xeSVBkeUC9MLZPAmqFFwBEdN/QKrNsv/v05/eEiHJG1CK",
-	"zuEdTS8I8FRkw2vsBo1Z8H8oYRa8UPOSphdxc52zgkVQfkNXrKgKwqtiCtKsl7cPWhAJupJ8CCELcQuf",
-	"FXTVH/RMVjzFxW2GbTlqhpWYKnO6PiAnM1LQ1beHY4eOIjTPSQk8Y3xO9IoPOmlm7O3oJVJUPNvBh9Fm",
-	"wQKrqUpI2YxBRmooGzBxw2zDh/Hr4dN4VgE6HsggOvUoW9DhsIrwjBFd84WUdA4ByxyQn53mwq9aXACv",
-	"FRyZrvFTKeGSiUrVnQZwxKE3u9dcaEhKCTMW4bFTRw6jPWwbp14L5+CkgmvKOGRG8yLSQoPVRIM4BQNu",
-	"3sz0TfSUKvjm6ZABb77uuPoz0V31jSu+02pjo8SKZMQumq9OYONuU6v/Dpu/cGzF5on9ubeQbH5mTMmM",
-	"5Whm/mHWz5OhUqgEWoTwhkexOae6knB0zr82f5GEnGrKMyoz80thf3pT5Zqdsrn5Kbc/vRZzlp6y+QAx",
-	"a1yjuynsVth/DLy4Otar6KbhtRAXVRlOKG3tSqdrcvJyaJEtzOsy5nG9lQ13FWcrv9O4bg+9qhdyAMlB",
-	"2pXUNLyAtQSDLU1n+M9qhvxEZ/JX809Z5jGaGgZ2hhaDAi5Y8N79Zn4yIg92T2CgsJQaok7QfB59ChD6",
-	"Dwmz0dHo3ydNpGRiv6qJg2tGvBqPjhs4dz9S09POr7ORaT4Txu3qYNOx3RPePT4GahQTdFQ7OHyXi/Ti",
-	"RjiUUpQgNbPrODVw+pKC4MkCaAaSZFTTg2ZTZf2sAX7Hjj9iP9wlgYyYuJ/wPzQn5rORQqq9+2ZcV6aM",
-	"EyeCQFNmPD5rR+xIpgF6ooIU1skjxjm7FpYvmsGtgq416gdHlo9daJHVeWX9SoI9/CTM1Jtd4/FUyJvx",
-	"S4cROGn2woQaqLX3a2beXllsWpWJo0/En7YNOoCa8GNfrYYU6oKP0apFhVNNPwMVlIF6F1RoA7prKoii",
-	"ZDncgbwuqFr0J2EcnCePyemPx88ePf7l8bNvjIUupZhLWpDpWoMiD5xdIUqvc3jYnxkq+CrXcejfPPU7",
-	"qDbcrRRChGvYu0jUGRjNYClGbLzAYPdSrmXF74CEIKWQEZ8XWUeLVOTJJUjFRCR88c61IK6F0UPW7+78",
-	"brElS6qIGRu3YxXPQB7EKG/2WWjSNRRqm6GwoM9WvKGNA0ilpOveCtj5Rmbnxt1lTdrE9969IiXIRK84",
-	"yWBazUMbRWZSFISSDDuiQnwrMjjVVFfqDrRAA6xBxixEiAKdikoTSrjIjECbxnH9MBDLxCAKxn50qHL0",
-	"wtqfKRjvOKXVfKGJcStFbGmbjglN7aIkaCvUwNav3rPbVnY4GyfLJdBsTaYAnIip21+5nR9OkmJYRvsT",
-	"F6edGrTqPUELr1KKFJSCLHHHS1tR80dVuMh6A5kQb8S3HoQoQWZU3hBXLTTNt+CJbfrYqsabcHvSPta7",
-	"Db9p/bqDh6tIpdliWiYwrosR7hw0DJFwK02qcuA4wlm1M1YYkSCccqEgFTxTUWA5VTrZJgqmUcv0mmUN",
-	"uC/G/Qh4YNP9miptt72MZ+iGWRHGcbAPDjGM8KCWNpD/6hV0H3ZqdA9Xlaq1tarKUkgNWWwOHFYbxnoL",
-	"q3osMQtg1yZBC1Ip2AZ5iEoBfEcsOxNLIKpd3KWOC/UnhyFuo1vXUVK2kGgIsQmRU98qoG4Ykh1AxPjs",
-	"dU9kHKY6nFPHgccjpUVZGp2kk4rX/YbIdGpbH+ufm7Z95qK60ZWZADO69jg5zJeWsjYYv6DGX0LIpKAX",
-	"Rt+j92P3532cjTAmivEUkk2cb8Ty1LQKRWCLkA44nu64LxitIxwd/o0y3SATbFmFoQkPeMHvbFT5rIm4",
-	"3IEj8BI0ZbmqjX0dum5GwSh3NwPBeGYSUuA6XxtenTFZ2IMitBHK/2ZdicyNYo9EGvHjGZGwpDLzLfo7",
-	"kGAyCeMZrOLalbbiDRmsCIsjPatHZpqk/hiHhwAOooLuDsY2oOACDTcZ3HSND2uPfSyVVOxAED8YAShY",
-	"KgW153xmMtZI6vooS0JBDXZ44uSM+vCYjM8Te6wYMY/2uz929OHekGficD2fDEp2zRrLBeBJhlHXHSKG",
-	"3Ga2aaBgaCLzXExpnhjnFZIMcr01jGScYniJLY2dFGm/exvl8/MPeXZ+/pG8Nm3RTwZyAesJnr6SdEH5",
-	"HJqQeMin1gOGFaRVqNI7ZNxpU+Pifm3s29ua8agUIk/q7Vs3hN9T8126X7D0AjJi9AQ6nc76fNVeITMI",
-	"eWBYXNWHHMvF2vuzZQkcsocHhBxzAkWp1y5W0PE0OoPzr/Sm8Vc4albheSvlBCd5cM7j23R7WntLmfJg",
-	"NkuSTV+65VAWyOaB9IoPiBNd4mGDAReVz42RvlPsGZicniUNmMpisct++AfM6aGtVWYZbjYaq6KqacEw",
-	"sSdoNjaa05+19nerTB8Qcoa6w+wWFFyCpDlmLSgfBGWKFMxsOlWVpgDZ0TlPWpikonADP2j+a9XSeXV4",
-	"+ATI4cNuH6WNm+g2RlYGun2/JYdj+wnJRb4l56PzUQ+ShEJcQmY3hyFf215bwf5bDfec/9RTzKSga7ut",
-	"9LJIVDWbsZRZoufC6PW56Hh7XOAXkAY9MJszRZgeoylDiqKXbNelEcBR1Gu5i/hFBKrxj40pNdrOn7C1",
-	"eUcRWNHUzJKiklmTpWGUms/6zocWZRICiIZTN4zoAtqqpcdvKHd9fW5305vxO+vsp1vkCNj1YLvP3CNG",
-	"FINdxP+YlMKsOnO5ND7hImdK95B0G3s8zagZMmJ0Dsj/ERVJKcpvWWmo91RC4kYFN7BmBLSxfkznqTUU",
-	"ghwKsOEO/PL1192Jf/21W3OmyAyWPgHNNOyS4+uvrRAIpW8tAR3WXJ1EHCgMMhtrGkkaXlC1ONgacEa4",
-	"O8WZA9AnL/2AKExKoYm5Go/MVjdf34HAW0BEgvP3VCvoo+xXMQuT3dz6qbXSUPQjl7brLwOe6Hu/Q+tZ",
-	"WsFzxiEpBId1NL+bcXiDH6N2GllkoDMK61Df7g62hX8HrfY4u6zmbemLqx2wxLs69e4OFr8LtxO0DtP8",
-	"0MuEvCSUpDnDgKDgSssq1eecYoCi4wZ12MKHXYZDVi98k3iMLBLCcqDOOVWGhnXYInqYMYNIQPJ7AB+5",
-	"UtV8DqrjFpEZwDl3rRgnFWcax0KvMrELVoLEU6cD29J4AjOaY4TtV5CCTCvdVr2YjWQ9GxtBN8MQMTvn",
-	"VJMcqNLkDeNnKwTndzieZzjopZAXNRUGdmjAQTGVxA/mfrBff6Rq4advGnpl4zrbILGB36QsrTW00p3/",
-	"74P/OvpwnPwPTX49TJ7/5+Tjp6dXD7/u/fj46ttv/1/7pydX3z78r/+IrZTHPZYr4zA/eenckpOXaHua",
-	"4HkP9y8W/C0YT6JMZrYLBeOYctnhLfLAWFDPQA+bMLxb9XOuV9ww0iXNWWa2wDdhh66K68milY4O17QW",
-	"ohPL83P9GNvuzEVS0vQCz7VHc6YX1fQgFcXEu2OTuahds0lGoRAcv2UTWrKJ2d5OLh9tMY230Fckoq6u",
-	"xiOnddSdZ9A4wLEJdceso+j+by3IVz+8OiMTt1LqK5s4Z0EHGU8RD9odhrU2c2by9uKHzRw0m5mXMGOc",
-	"me9H5zyjmk6mVLFUTSoF8juaU57CwVyQI+JAvqSaYgygE1EcupuF8RmHTVlNc5aSi9AUN6I5FBg7P/9g",
-	"GOT8/GPvIKtvON1Q8WAjDpAsmV6ISicuKjscR2hiLQjZxuU2jTomDrblSBf1dfAHAqBlqZIgIhafflnm",
-	"ZvoBGyqCnTAPiigtpFeCRjO6mIZZ37fCHeVJuvTZ6JXZt/+9oOUHxvVHkrj993FZYrgN411/d7rG8OS6",
-	"hN1jZg2KDbDYPgsnbh2qa+fGIdBT28sHkVWccuYTkg7bGK3QxARvSicD6keRm8W9MZkCGFHqVHqRGJmK",
-	"zkoZ1kJ5CO4Q0rnRhf7szWybDfO5Oy1TIOkC0gvI8OABY4TjVnd/5O0sixdZpuw1FJsCh7nSuB2cAqnK",
-	"jDrbS/m6m7SqQGufqfseLmB9JppU6+tkqV6NRy6onxieGRKQ0tAjMAJi1hYXfzDQWXx3toKB97IkNrZt",
-	"sws9WxzVfOH7DAuQtUx3IDwxpqjJsIHfSyojhLDMP0CCG0zUwLsV60cj6VRqlrLSzn+32Py7Vh8DZJtS",
-	"j6pxMetq654yjWpv2ziZUhVX3GC+mPUwMtTNLvEj2ciKPSQjeJXZMe40h+BUSTnJphKdHT9tezdzCLU4",
-	"l4DkjTX1aLQpEprthTuWZJfNYSQeO+9i4LYeShku8vkCrB1+ZmbcHC7p4EnA4B2CkyAJILiaVt8Q8Iqt",
-	"Kwzj+raIvSXubxL46wP+zsBofK38//HI5XrFlkNwtO4Z5DCnLvCNWWSOURxqX6lggQweP81mOeNAklg+",
-	"AVVKpMyehTa63I0Bxvn7mhAbWCE7Q4ixcYA2RgwRMHkrQtnk8+sgyYFhiJF62BhrDP6G7RG35rq+cyu3",
-	"un993dEI0bi5TmOXsR/9GY+iKmnIM2+1IrbJFHpbmRiLGtXUj4f0oy4KckBznLQ0a3IRi5IZrwKQDU99",
-	"t8BdJw/YzBj5h0HgWMLc7L2b/aqRVh+A+bIxg0uhIZkxqXSCW+Xo9Eyj7xU6g9+bpnH10yIVsfd9WRbX",
-	"PjjsBayTjOVVfLXduH95aYZ9W+9bVDW9gDUaGaDpgkzxfrqxQq3hTZsNQ9ucmo0Tfm0n/Jre2Xx34yXT",
-	"1AwshdCdMe4JV3X0ySZhijBgjDn6qzZI0g3qJchG6OuWIA/C5kxgfsXBpt16T5iundExqHktpOhcAkd3",
-	"4yxs4o/N7Qmud/dzpgdkgJYly1advbOFGudxHOI6jrr1+HtUwNV1wLZQINgnx1IIJfi9vl3SwGbai/q9",
-	"NKvtlOkmdwUKIRyKKV9mpk8ow9qYjbONVmdA87/A+q+mLU5ndDUe3W7LH6O1g7iF1u/q5Y3SGWPIdgvY",
-	"ipxdk+S0LKW4pHnirqUMsaYUl441sbm/xfKFVV18+3326vj1O4c+Zq8BlS5pa9OssF15b2ZldsSxzK2z",
-	"IDKC3qrfO1tHLFj8+m5gGEzxiXYtX85oMcdcVrxqAxeKoguuzOJHWVtDJWFy3o0ks5Xdd9vIXJjqd6ci",
-	"35OwOIc2K7xFL4RjbSgsUNjaGYoI3k1wMG4c7jKRXQq6NqtoA7N9BcGrIjEikKicpfHQAZ8qI0W8KvDG",
-	"xloDwcYDDqGBWLGB8DmvWADLNFM7nBR1kAzGiBITwzobaDcVruhZxdk/KyAsA67NJ+kSnlrCYmTD5/D2",
-	"TVo8X9gBdinDNfjb2HkDasjCIxKbjXwY5Y1kiftNn59oHZ42PwTBuWsc0oQj9szShgMWxx+Om+1J96Id",
-	"rQ1rlPV1kGEMW89ie4E0HzpYWEQHxogWPBvU2MfD2hrzwHfX041aRnRDhWxz82iuRARMxZeU2/pFpp+l",
-	"oeutwO7bTa+lkHhpSUH0hJqpZCbFrxDfTc7MQkVysBwp0WXD3geRyyBdJVpHRprKdJ6+IR6DrD3kTQUf",
-	"SfsQbUDCkcuD8DUmlfogE+WWrW2tpdbRbVw4wnSLiYXfCIfDuZeiktPllMbKDhinxuB03ByUtMJhWhDf",
-	"2a+CqnOpHe8FZy51W2Zv+pQgm0TJ/k3NGzoo94vlM0hZQfN4dDRD6rfvemZszmzBqkpBUBHJAbKV/iwX",
-	"uapS9iiqIc3JjByOg5prbjUydskUm+aALR7ZFlOq0GrVIc+6i5kecL1Q2PzxDs0XFc8kZHqhLGGVILUT",
-	"aS83+PjzFPQSgJNDbPfoOXmAkXfFLuGhoaLzRUZHj55jSob94zBm7Fxluk16JUPF8t9OscT5GI8eLAxj",
-	"pBzUg+itM1tOdFiFbZAm23UXWcKWTuttl6WCcjqH+IlqsQUn2xdXEwN3HbrwzNbCU1qKNWE6Pj5oavTT",
-	"QFqWUX8WDZcrXxgB0oIoURh+asod2UE9OFtYz5Ug8Xj5j3jMUfo7D51N65cN0lpbHps1Hka9pQW0yTom",
-	"1F7OxGsb7lKvU4gHA7UiQF7GB5EDC+ztputLHnDBk8LITvawSfgL+C9aKkFomkeH1V53dTNXNoPe1dUy",
-	"UJJBwlYtwtJAJ92YxJWMz5NWZqif3792hqEQMlb3oNGGzkhI0JLBZVRiu4lrtWdSmwtP+ZiD8l3F8uyv",
-	"Tbppp8SQpDxdROOfU9Pxl6YmWk12S/XoFbwF5RzyKDgry794mY9opX+IXccpGN+xbbd0kJ1uZ3IN4m00",
-	"PVJ+QENepnMzQEjVdv5dnTiSz0VGcJzmknXDCP07UkEZlX9WoHTsPhd+sLlOuMc2/oqt4kGAZ2jtD4i9",
-	"/2Rwad1gQSvLiiq3tyEgm4N0AZiqzAXNxsTAOXt1/JrYUZW7Q4v3brCKyNzepWvNorO3Cqo/XOdy4VBq",
-	"1O5wNueMmFkrjVeylaZFGct6NS3OfANMrb2kLPfpB2h+QuockJfW8itvV+wgzR1SUg/ndA3yhPmP1jRd",
-	"oEltGaBhlt+9/I3nShWUgawr6tVFFey1SC18BRxbAGdMhPF7lkzZUrZwCe1E2zrr3Ll0PvG2PT1ZcW45",
-	"JW6fNtyKuAnZPXL2YM+HpKKYdQh/TTOjRCVTuG41oFPsFb1j1S0t1Kv/yCE7W/G6/povUZ5SLjhL8YZT",
-	"UDy3RtmVxd0lZrrDZbDudtmLuJPQiHBFCxrVqQOOioMljrwidITrB4yCr2ZRLXfYPzXWXzUbwTlo5TQb",
-	"ZGNftMrt4xhX4IpkYIXkQE+a7Xj3/DB6tNFc178mG2H634C78r35hq4Kcyk7F4zj5VVHNpcdZHdaWLVT",
-	"m+0d02QuQLn5tC9nqQ+mz8HZip8YjD8e+CqfCMOGkM207ZlFH9SxP8FwJwam7QvTlmC4uPm5lWpoBz0u",
-	"SzdoTBOoeoVjZbcGCRyJgic+DBkQt4YfQtvAbhuPHtGeGkaDSzy4gBLtcI8xBq7AvzKbWstR9iatPfKP",
-	"Xs1gPILGa8ahqUEbMRBp1CTgwqC8DvRTqaTauoA76bQzoDmelMQUmtIudHRbUJ0FRpLgHP0Yw8vYFF8b",
-	"UBx1g8Zxo3xdl7413B04Ey+w5rYjZL+UGnpVzonKMKmrU1wtpjiM4vZlCdsGoC8GfZ/IdteSWsm5jiUa",
-	"SkLPmDLbkWKaR9JYXtYfgwKDmC83XeO/sQvIwzNwB2s3LpiBHa/tX24uXpGbtU8Um99wVZr+d7gsHRkI",
-	"1yjG/a+MWgnv7fTuklvFU1+rwSN84cu94qaiTkxv8ywquuimrancuXnTOlyDc4yqcSCR531zY5Ra7Wtj",
-	"g0PpPOlg9hnVLrVUU7KpiowtnBmDYM8hbcFO+/hFNDAwdPZojx7N517v3fyGnheGsDcS1B9q9xH6i89a",
-	"ISVlLvDdiEifsi6/rZ9xuEvmS7PA3Um4rDEEEpvJDZO8dpK9PpUigh2mBmxhz4sWSe1tkI4nKSTcMWkD",
-	"E3pN0vaTHnadHs4DOaZS0J/nzgvQou0A7XchfKMX+sQdFmc93UWc40n1pjvqE0sQf+2jr02+mDZo1ft1",
-	"48ZW/a9D0QO7Qx4IVHVoWrE827a4rbBjc50ZA2u/TL952orefckL1b/YA/m+uLm7pdcx/N1FQMJE5toa",
-	"PBgqCCjuEEt03SKRQ6wFlVaS6TXm7nhPk/0SzUv+AbireuyKyNcnoO4Azr5f4kLT87p18+TED8KWgS6M",
-	"+4uuoMYiKa9WtChzcHLx7VfTP8GTPz/NDp88+tP0z4fPDlN4+uz54SF9/pQ+ev7kETz+87Onh/Bo9s3z",
-	"6ePs8dPH06ePn37z7Hn65Omj6dNvnv/pK//eg0W0eUvhb1h1IDl+d5KcGWQbmtCS/QXW9p6xYWN/g5mm",
-	"KIlQUJaPjvxP/8tL2EEqiuCJOvfryEX6RwutS3U0mSyXy4Owy2SOZfsSLap0MfHj9GvSvDupA7T2wB9X",
-	"1MbeDCvgojpWOMZv71+dnpHjdycHDcOMjkaHB4cHj7BQSAmclmx0NHqCP6H0LHDdJ47ZRkefrsajyQJo",
-	"rhfujwK0ZKn/pJZ0Pgd54K5ym58uH098fGfyyR1yXxmo81hWky+1VccX+zecxzZgYfYsdWmt4DKPcnd8",
-	"xmRq83eIq+7GM4wA2twMo9pqYp1kwYOYwcsL49Z7nh/u0RNVsbpPsavisUdH6yzz4Udngnf5/Ft8z/58",
-	"FTlo+th5SOTx4eFneDxk3ILi6XLDV0ie3iGK7R3UrRHtgutphTc0N3wD9cNyI5zQo3s7oROO9zmM2iJW",
-	"LV+NR8/u8QqdcCM4NCfYMkgh6avCn/kFF0vuWxqTXBUFlWs0uMFF8tC1uhpUue3kLXcjb1gPQ1CBLLjE",
-	"2wpsT9eez8ZE1YWeS8mEcRzwGcYMUgkUzbyQeB7U1DJzVxXBVrZ+c/w3jB6/Of6bLRIYfaIuGN4WzGwr",
-	"8R9AR2rtfbdunlnaqNF/KzU5/t2+6nd/bN5tTc2+YuO9rdi4g9Ler+6+Hue9rcd5v13SVZ14SwkXPOFY",
-	"1OASSBDW2vuov2sf9dnhk3s7m1OQlywFcgZFKSSVLF+Tn3mdEXQ7F7zWORUPcrQ26p9e9fnGiw7c96DA",
-	"0uRT61GJbHvwpHUjO2vVIKfxhy6D2jMuG3TcXDOlPLOZHP6sVo39dUuM1tl7zXY9xr3LmAcxJz04avlu",
-	"ffJyF7+8NafgBlrMN2/R63rP537WiMWNHyH9nBagh8d3NCM+ZfQz6+bdlOnTw6dfDoNwFd4KTb7HJLPP",
-	"rNI/a5wgzlaBssEiZpNP/rLaDgrGXQRtq5buy7UxpWIkdOxy9l2Z5vrxDaNPrCK0d3H7WsOMsKu+6N9V",
-	"jWmK5n7e70VHXOth4L1e2OuFG+uFLkM1GsE+Yzj5hAm2oTroiSS+rfwHOigJqudJUfjyLYLMQKcL9+xz",
-	"5yx76PX+jTpl07XCW+uX/aPft3n0e4cNyZ7AX+ZV9fsc+AisJUnIW3SHUMB9zvUfMezxOS3y557QW8GB",
-	"wIoprKppeXF/3Fi7C3gBH4ni30oIK97XroN7qnTyqXk7+KrJBLGXBCfW89/kV9gHXkZ3eqazf5TnHjzK",
-	"89vvKm4lIZ3ZSggfQAZ3SbaRFl+Us1+psp0s5ZqrRaUzsQxSq5rix4OS5J/Cv0NJ2r/Hv3+Pf/8e//49",
-	"/v17/Pv3+Pfv8d+P9/h/e8fquhupbrDuM+5u2q5q4LI0rpr9e7KkTCczIa0ZSrBCWiRQ2h79vynTri6f",
-	"20NpYZQCGEuMNdasQnFwgiopKsxZcY9X+AfPWRE5XDVDfS/kTnHZJtipBTETIxXXzGfV4yNH3m/7/QU5",
-	"9x7p3iPde6R7j3Tvke490r1Hej890t8meYEkiVfIPjM1lpdKRvfSa75HqZ9fMlezcaRrNxodb+P2Gjne",
-	"eKihgeYTV+8Lz3+FGsyOCmuHpWY4xkmZUyxovNL+jg7WMv7mqU9uqKvg2PIBRteYBk8ek9Mfj589evzL",
-	"42ff1G+Jt9s+8LVXlV7ntoBxe0dwBjR/4XC3SgOU/k5k6866GvQmiGl7RZtL9YxTGSkwFXlRuksDLbDI",
-	"nKuY1ts0XN1pwkO8CnCfnttIOVAJN8p9m5ZzawFWVxvUwd7lqMWsqScnccWpflONShAjx2aN9viXV583",
-	"UleejFExQiEcGw7LqhTw9S7HP6vENJoDT5yQJ1ORrf1TD65yXUul2ZJiwxrt1QrSykgGYuKY+oF66B5K",
-	"xNKIYawiWtI1qFAMCM/lTfW1lC1etVFJ3Xzx2qVwb3303gW36VV98kBIMpeiKh/amv98jZvQoqR87cMs",
-	"xm/CWrr4bCWmC92tWqzrCPaU2u6lYEPfHe9+dX+3ZCFLqnwd2MwWgo0Xw+mWK91O8aYY37biKXa+0cKh",
-	"A2VC+4voV9klKtahpRJkolc8Ur6vU6zvXz5H9z7q33dSXDKzJYyqMxvG1VHxPtiqhmWggFAPd+5fekXc",
-	"1o7v6TK8zbmrhlwlzme7tUO3APtSlndwIpdVjXGSgmYpVZhU6Oolf2ZnT69OIjtqRBOLDsx6l66Mtdxe",
-	"FB/h7uSKBaCbB5jwVrCy1ZV+W8esqQly7HI4W9TYa4k/yib3Oy98ilAi6bIrnEEN8x3UFF3qFY9qqUnz",
-	"wls05ygQiPpJqDs86emBbx/4BG8v2RMHyEtCSZozDKYLrrSsUn3OKQb3wjev+odBPmQ57Bi98E3i8eVI",
-	"+NeBOucUXympQ35RB2kGsYreAN7/UtV8Dkp3NPEM4Jy7Vow3L6IULJUisZl3JUjU6Ae2ZUHXZEZzjE7/",
-	"ClKQqXHZw4usGCpTmuW5O30ywxAxO+dUkxyM0n/DjHtmwPloSn2i6mrnh2+q90PPrszYwDM+P9ivP1K1",
-	"8NP3EREM3NjP9oDlyz/C43GPvc7nMD956YpMnLzEe8PNwVMP9y92cFIwnkSZzFh8d37b5S3ywD0JhQz0",
-	"sDnCcqt+zo1rrIV98bx5j/V67NANcPdk0UpHh2taC9GJg/u5foxdcJiLxGwAsfbwaM70oppiOT5/8WEy",
-	"F/UliElGoRAcv2UTWrKJKiGdXD7a4h/cQl+RiLraW+4/Tni6+2ZgvfDGie2t/YBdvoOaXr/vQl5bE1r2",
-	"ZbP2ZbP2hZX2ZbP2q7svm7UvKrUvKvWvWlTqYKOHOPmkV7uUeQmhssw+dSohtSPXCjxs1ioI0z8DZPqA",
-	"kDN8x5QaGwCXIGmOz1crfz2dKVKw+UITVaUpQHZ0zpMWJvZBTTPwg+a/dpt7Xh0ePgFy+JC0u9iwRaB4",
-	"+13RU8VP9lGbb8n56HzUBSShEJfgikNg66zCY1nbaSvUf3Ngz/lPsrdwBV3b0MqCliUYo6aq2YylzBI8",
-	"F2YrMBedvDUu8AtIgxwYfaoI07YOF1IT8/1c1gl1b/bEXO6+db9GLfTjDrPEU8YN212zYu5/7lIu91/F",
-	"vX4JmrJc1Znskd0U7mu6nLWkqhHcWqeMfQK08r+5w2c3Ss4uIMwtxYP+JZWZbxF9i6ypvObf2ou8r98q",
-	"SZXByjsEXaRn9ciseYi//zpMP67lCjttQMFVv7nJ4ANvSl+NR2kuFCSWSir2ygx+MJoIY7EUQ7HUvRLt",
-	"Hx81MIwwU4OdxKsiNmN9eEzG54l92CASorbf3cMHdSyuE/mOwPV8Mpi2WrOGfZoatU2XiCG3zYi7GT4Q",
-	"/rXvuNlkiBu/5tbp3nsoJ8/Ozz+S17ZoIb7ydAHriX1fJF1QPgdV0yjkU3u9w2awBPnKHTLe3Qtyxmok",
-	"A28/nvRzmLt0v2DpBWTE6An/MPmAC08e1BXY8HHf5WLtL2tYM/TwgJBjTqAo9dq/89uONHcG51/pTeOv",
-	"QsPZtkiRfLsU2CXIW8qUB7NZkhQYgbvlUBbI5oH0ig+IE11GNrS7luSJ7F87u8mAqSwWdxEW2FulvVXa",
-	"W6W9Vdpbpb1V+mxWqReCuf9Bim6fm0cpupDuLkzxmwcq/kBlAPcV/35nEwpTN1slfW8Ru60fLox5wS4q",
-	"2zwMGj60iVG1+onNDx+vPppv8tIH3Jp3I48mE/QqFkLpyehq/KnzpmT40ahSOrcQXECrlOwSC3Z+vPr/",
-	"AQAA//9ABOdBwOQAAA==",
+	"H4sIAAAAAAACA+19aXfbxpLoX8Fo5pzYGYKUt9xrz8mZp3hJPNd2fCwlmfcsPw8INElEIMCLRRKT5//+",
+	"auluNIBuEJToRQ4/5MQieq2urqqu9c+DMFuuslSkZXHw6M+DVZAHS1GKnP4KwjCr0tKPI/wrEkWYx6sy",
+	"ztKDR+qbV5R5nM4PRgcx/roKygX8O4VB6jbYf3SQi39WcS5gqDKvxOigCBdiGeDA5XqFrfVIl/488+UQ",
+	"RzzE8ycHH3o+BFGUi6LorvLnNFl7cRomVSS8Mg/SIgjxU+FdxOXCKxdx4cnO0MwDQHjZDH5uNPZmsUii",
+	"Yqw2+c9K5Gtjl3Jy95Y+1Ev08ywR3XU+zpbTGCaXqxJ6UfpAvDLzIjGjRoug9HAGXKtqCJ8LEeThwptl",
+	"+Yal8iLM9Yq0Wh48entQiDQSOZ1WKOJz+ucsF+IP4ZdBPhflwbuRbXMzWKFfxkvL1p5L6MPEVVICuGe0",
+	"G9jjHCZIPew19l5WRelNYd+p9+bZY+/evXsPcSPLoCxFJJHMuat6dnNP3B2+R0Ep1OcurgXJPIOzjnzd",
+	"HhZA8x/LDQ5tFRSFsF+WI/ziAa46NqA6WlAoTksxp3NoYD/2sFyK+uepgJWKgWfCjXd6KOb8n/VUwqAM",
+	"F6sM4Gg5F4++evzZSsOM7n00TC+g0X6FkMpx0LeH/sN3f94Z3Tn88K9vj/z/I/98cO/DwO0/1uNugIC1",
+	"YVjluUjDtT/PRUC3ZRGkXXi8kfhQLLIqibxFcE6HHyyJ1Mu+HvZl0nkeJBXiSRzm2RGsBG63RCMgVQEM",
+	"5amJvSpNkEzhaBLbPRhglWfncSSiEVLfi0UMZxEGBQ9B7YAiJgniYFWIyIVr9t31XKYPJkhwXVeCB23o",
+	"ywVGva8NkBCXRA38MMkKuJLZBvakOA5gnWcylJpXFdsxK+8ENkiT4wdmtgS7FHE6AQ5e0rnCdPC7p1gT",
+	"gGnmrbPKu6DDSeIz6i93g1Bbegg0OpwGH8XL6wJfBxgW4E0z2C7AFYGn7l0XZOksnlewXQCBgMUwz4O/",
+	"QdyCnWbT30VY4rH/1/HPr7ws914CZIK5eB2EZx4cYBa5z1hOauPgvxcZHviymK9gIDu7TuJlbFnyy+Ay",
+	"XlZLD0aawnLhvBR/AJjloqzy1LUgHnEDni2Dy+6kJ3mVhnS49bQNQQ1RKS5WSbAee89nHgzy/eFILgfQ",
+	"AS7ECoQW2JpXXqZOIQ3n3rw8wOMqjQbIMCUemME1i5UIY8DcyNOj9KxETrNpPXG63XpqycpYjhrEuRw9",
+	"y4blpOLSgjN4dfELXLC5MFBm7P0iKRd9LbMzkCoUgfOma/q0ysV5nFWF7uRYI03dL16nGUgTMN4stuDY",
+	"sQQHUg9uI8nrUgo4YZaWAVCrCCkvLRqGY0rkXJMxYf9jpsuip0DVv7vvYuD114GnDz1bp9574oNOmxr5",
+	"fCUtfBG/ygtrF5sa/Qc8/sy5i3ju88+dg4znJ8hKZnFCbOZ3PD8FhqogItAAhGI8MGQaAMUQj07Tb/Ev",
+	"zwfpCMAe5BH+suSfXsJAMUyCPyX804tsHofwkwOYeq3W1xR1W/L/cDw7OS4vrY+GF1l2Vq3MDYWNVylc",
+	"oudPXIfMY26LmEf6KWu+Kk4u1Utj2x6wCnWQjkU6YbcKsOGZWOcCVxuEM/rf5YzwKZjlf+D/VqvEBlNE",
+	"YMloSSkglQVH0DwGZoN85Y38jp/x+gt+HwR1kwmxUvitXhwQsJXIy5hHhbaFn2RhkPhFCSyMtlmKJX38",
+	"NyANsKR/ndQKlgkPVEyMdbzA7sfU+4PeR5DnsHtJc32inV30APoa0dUHyhunNNgIRY0UWNAZ3oQASBSK",
+	"HXj3BNwTSX1ZHmKCrNUNkoRLGWnceZO4iREyAPpEa2GySjPEKSMJomqMJD4R50FaGmM36I0mEG+7cFWT",
+	"1yfN0hOfdOtJV8PWozE8GqMg8RPkbcYE3JBCCpSsd4IONNBwJMDmP2VJJOG7P3s6ewbiVidOT6MFQ9J9",
+	"zlc64d4DVFPQmgy82/1Mdc9NKG9A/qC5qh1RPD3cVajdl43nXTJUb3YQImohuNEVjwFRdPdogaO6b0Qb",
+	"FT4nnbtZB88b3PLIuRO0+AHYztkO4DzFcbqgoeG9hQgi2H4UlIGxIblQu6hGHX+ifqQShJksBhP6B3BN",
+	"/IwiJ/BOqatAPQ3SdiCxhlUl4mNBOPNM2IDULpm3ZI2Gh5qIrVb5uJ68czoMliGH85SVKB71UJugE8ou",
+	"d34ZYUzbGuDn9kWsFbRH0yy/GlloYWHq1WpnL8BRtaIJ4d7EK2parXx5OhbVFTdoDVRb+vpvT3t420k1",
+	"oADS70eAQoGj7gIKzYF2DQVApDgRO6AWi6BYdDeBuoR7d73jn44e3Ln7/u6D75BUQcc5EEN4QKJcfEs+",
+	"4WBn60Tctop/9MK2j/7dfaWsbI67EUK0YD32kPt8IpAuMcQ8Vs3j6p7k67xKdwBCkedZblEvEeqUWZgl",
+	"/rnIizizMKzXsoUnWyAVZBVX63derXcRABmFuUnzWaHR1Sp4o0pzMKfloU8u0xo2TZ7bOgHer2V3ct4h",
+	"Z9IEvlKkFd4KrTCXqReJaTVvvAdmebb0Ai+ijkSOXwH24BO4KnZABerB6sXgQZhLAMJWAZ30UmhLz8Oq",
+	"sNMHh9mQ7BVkZilNklMumPtNBUooYVDNF6WHGpzMdrR1Rz8I+VB84lSFQ8uq1ePciqdjk1SSA1tfw8TA",
+	"ibOpVGVKJSttMiALSKmcGyR1sjzHGusCiIRAGWBh8jm1cWnKK4QOuewBE62b1qsn8YrMmwX5FddaZmWQ",
+	"bFgntemutqhlGan+7a562PR959ee3DxFNHapm4mCE17uBIRVFwg3wgQ4j93yL7naCXzEzaYgRxcCLkpU",
+	"WAdLgqL0N10FbNRgvXisBvbZsJ8GdqgVXsA31jDHaURCIF9hmof1DTiFe8FOKo0j/6oIdHfsEGlPWgDp",
+	"UNS6qFYrEIJEZNsDPWCcc72Cr2ouOO56bM0S4KCrQmwa2QUlY3wJLN4JAwiQSulj5AOouzmyJiNtXVtB",
+	"2VhEDYi+hRyrVgZ0TeunYyH4YtA9CXHglybmaJPrCMCUrVZIk0q/SnU/F5iOufVR+UvdtotcaKNWtDLK",
+	"BM5eqjXJlV8wZNnuDcKLJ9ehXqQk/bAqvLtmvIx+AWRG+H2Yj9fyGFuZV2DDJXUIntKzxpitdTla+GtF",
+	"OicSbDgF14YdUvBrARPsQIBbCem4OEhcwlk3ykg85CDpFJ2nsqKIV4As5UWWn3nUuUasmESSFPoTlSfZ",
+	"5zXbrk9qu84OZKAnAkSApNByjjaQ17OQLb3t54hCKXpXpCW870N0YMiX7I5C7LFQv7EUFclZ2PGipjzw",
+	"Xy4ugjxSLbqPL2MzPlxDcWlnLEFDsQnN0OPDtuiZnjkG+qKcRVJzgLGVxkn3m54lSFXaVSbHrvZp2bmE",
+	"oVTY3I7oA979JXoTBexNhJth+aDUDjM5IACujvxapDzjnhOQwGfnJYtkwN+Vc5MyKps4Yx9X4Ym/0UoA",
+	"rCBXKroWEE1swxeqABbh2Mg8yabKNORHIik36mbIvvaEWqKIUFuW6u7NJZ+evk2i09N33ovaguSdifWE",
+	"fLy8cBGkc1Eb3k08ZeFfXIqwMrlZC4zDNKd8nM3Vt7WoK2Ayvn65th0FOhyuDfezODyDZSKdIHlbMt5v",
+	"mieEk3i3EMUL7UpxsVgrUR4YATw4bo897yj1xHJVrqWapCVktSZPvyn75r+kWaOKvLrgPtEmx6epXUPB",
+	"PmHXvFNqmP6bxE7S15yKB+mfCB7QjusUXJBLAw5nvZ+9KtZj6mmwnC7Xq5GKVzGEAf5InsNB45TjiN5Z",
+	"NVcpqukyJvdho9kIKafy6Oo+1OMSMOuEaAc+lAoBJ4SaoKBg8VL6Xy5jfG8XVRgKET06Tf3GSuB+yYlv",
+	"1f9ksnRaHR7eE97h7XafokQJWb4J+Q60+37vHY74E4EL/j49OD3ojARkOjtHkwa+i0285l4bh/0XPe5p",
+	"+nOHMIMIuuYXtbqLAIbZLA5jBnqSIV2fZy1BN83oC1lRlgLfpQD9ckSsjCBKDwQ+l/oC2qWWXahuLKOi",
+	"zISsFKmd8uNp4k4BtBb+BbsMiMisvQtEFI1nXeEDBFffHMCqSe6ZUVoSigYdv+K969JzViT0r++kpUpo",
+	"gMNA1wH29g4wrCsYaArL8NRj6bGr3DqTuCg7i5Q6DTIjaYS0MJ2x97+zCm463d8VcFT9nIRLgW80ervj",
+	"DMRj1ZxSUqshJBLAcNb00Jdvv21v/Ntv5ZnDQDNxodzcsWEbHN9+y5cgK8pr34AWal4+twhQpF9HbmoJ",
+	"TUIt+maLJo076BFjDP38iZqQLlNREIuBLsfxskoAzrtxKPBDgKNDZ4af1CJOnh69UCYGeKZUpGISAao4",
+	"zLvJjedARlf/4f0h8oyltEboFylG2MG8bAhvIOwl1nttMuXWhXWIkLh8lhupgZyUXdXZ5lErSWm1I21H",
+	"qR0Z5cMbfhOXUj1ijsmUZSjbfyqHaMqTM3ihVblw28FI3hBBgap7w8tHrlruaIptfqeX7dg7mqLwri5h",
+	"qxkIPGIl6fL2fkY6MMIYGei+QLkcbz5IEmMxNl6jsyxJsgsFT8RaxKhUOAUuBybqGZqoFBBrVZ4Gce6h",
+	"s8v6SVAG9XGiqsjCtqiTbj72npLcbAeahu21Wc5vMOvRk41qj/peKgQfab/hwbYhNkOxAGjeUd5cwVRE",
+	"ExWNlTsgKwPvpCQWtLHPcv1aUOdlb+Pa0NhN7eCAethkvQORjAcC/JMv8qJhkSj4K4DRCHqSHLZYF4Co",
+	"XbMad33vuOlvFI51rmaWJnEKZAqOY22N84WvL+mj9WITE3d0JnHK1betXm2sv7Ws5jxDzvG68KXTNq73",
+	"ax2CtYPDb4/bsqia4V6kBxDJCm56mMRkrYLJQSALy9M0IO1566HaQgtlE3DbUx6rJnYDjsW+IoeCBZBX",
+	"qNapW1nPTFh43zMhlFmlqOZz4B2tpwD0Ok1lKziYKo1Lmove/T4fGGyTXCLG3BLfajMMW4LXH8knU3ji",
+	"NjgDRaXw25PNuzgNjAobwaBDtFS9jNHOj8MpHZTCGa17llBw6NDgkVjEhW/3GvmRv/4EH9X2saEWrvgz",
+	"WzBx/Dp0ZU3e2nXY6/+99Z+PMNw18P849B/+++Tdn/c/3P628+PdD99///+aP9378P3t//w320mptdti",
+	"JuTKQXTlhyP8A18HtWW3s/ZPZpnEQCsrkiFXgo8UetfCLe8WSqgKgW7XNmJ56qcp+lgAIoF0EmNA9ZXQ",
+	"oU3iOneRb0cLaxoH0TI0qb2+s7HGeeajQyAJmwdzkEeq6RhedxPFMifQQP87CgRQU/oWTYJVPEEF5OT8",
+	"zobHyzXolWchVzCVpDrFzh0H5cC2DbXn1CZe9Tec/Dc/Pj3xJvKkim84gIqHNiJfLDoO6anRULfh5jkB",
+	"AEeQobrpCcbxxvj90WmK/qaTaVDEYTGpCpH/ECRBGorxPPMeeXJIFGdJS9t66rlydJAGXa5mVU0BjKhq",
+	"t11Nl+ni9PQtIggq7NteFl3GqSIDrOYgmsBH0TyrSl/azdya3lobTiOz5aRv1pEnx2aMlHY5Ob7DRGWJ",
+	"MupuH9APt286nsvwFzwytFXniggiZZRaZzzfV5n0M0GlsoxKrlCz+j/LYPUWFvLO86WGFF4rdbzS/0ha",
+	"gzgJix5u1Rga/EQbZ4Fq6zAJGvSYeykzX2GHHH4i0FEbpAq11eaqcDJCe64Mpg3hQUFVLny8U9ZdFYha",
+	"dB+MXDLBHGmhcgzBNykin8xtgFGwC4HGGDINkxVn1Oiu/LEkZ1FXNi44HQF7h1PMLCnsME3BKgok7w3S",
+	"dTt4EfZXqpfWGwFX/iSrQ263iVZEIySbXX3EGdcFWSE8DCbQitPQptvW4UvrN5lGVyuPrY/seK/Q4pHG",
+	"C9XHfYGYM+3g8tiQQoOhB98BAhZAMPI7QHCFjeJ410J9q60zAG4Sxive/zDr6etGHxxkE1G3knF0m25S",
+	"6w4xtVJvbuyjp7T1OAR+wfPAO9R2fVQzse6bdSUepbSSiDtNhGH3NzWbBqg4R49raXYsAem95qZqGU2I",
+	"mGx7IR1HQHTQ7iLkEzWEwX3E4MK+WPLnhoeakaJER4orwta+DCOdNYCzhamIchVGrmLHYTnbxIGjRxU5",
+	"ItuOI0uJu0ew1XkgTZPk4iwRRS7tm8I4IFzHz7MZqic83+bsBnc+C2P2VqlpuZxDoPD3reexYsUbPIIN",
+	"jY1lk02HBvaAnrw2kXSbRaYiJiNQoMYma5DxtxgQ5aUj16VYuVH869KO+hLV6lF5jF3tj45Efd0mY1bJ",
+	"vNHK4yZT0XnK2FAUSVNXH9LVuhQALGLHfoOy+mc2LRlKFYLQ8Fh1M8R171aMcbfr24ZpLxdzfHvX71W8",
+	"rUoB82l1BueYrWMW5+j/iE9l6/aw0bOChMFn2NROfhqg8jjvUxzZqQ9NC9Dxozip7Kct5/3HE5z2lX63",
+	"FNUU+hGTIRvXlPKUIRdqTI9teqZmh8/eDb/gDb8IdrbfYbiETXHiPMvK1hw3BKta9KTvMlkQ0IYc3VNz",
+	"grSHvBgGhi5tMYydhu1g3Pda71ymrX3unJTXZfBoBK7374JdM9n70kjzVQzXPxw13zeKhqJyJ8+SpI6k",
+	"Mb1JvWfkC257LdLrh0JkQUZgt58p+jmkomWHjouiso++/QPIccNh0Di6bGkGGGb2G0wA3OYZwu+Zg5H9",
+	"yUUf29PXhzTuwQ3CfrmcDRhi6BFs/v+Yg81IBWLKFJzQruMo3MScONrsnmwQTHOquFDpWLugxqtP/qSb",
+	"oI1xj/8Q61+xLW3n4MPo4HoqkRbUzVRBG2D9WiOIFc6kY+cnckOzuCXI4WOeAXB86fDhQm5oJLGLmiv/",
+	"kE/MCuy3Ex1WXsvlk/+1CHLpdty3K2q3ujG7Qo2Bzff4xNAckTSvdAtMKk1SqtIKmMom5SrekHWRykvk",
+	"4uulBYCmEw8pn2Z2U99GSmq6l1/pZjb806+ruTSd1Xd65Ts3zI6h9QlvoAvmXD0J+JacYxJzSLRd9MiV",
+	"C1/hhC5oJp0KqbjuEgjo5+MV8AtYgF21kk6J80BLCreExh41dgjMOGIVO8wLaRUbY2GzYoAlrbVIYw4r",
+	"MEnt1QO7aSYTmlRp/M8KmEuE7pbwKZcuu43LgndDRaF0WZo94kUOLINe9PDXkRRwKMkzOlyHFtHP5E0t",
+	"uCXOST2K1Ua1QFangNrWiGXO2GFLPQYoiR8Sm9kTYNHUZpu5vLs0CBGD8z5uTiSuhFWZ9coxhzUxuJNi",
+	"H7mpNUUyDafTNVmm5ZoEmb3Lg6TILMNU6UWQcp5f7McwlL3ZpZCJxkWWU8RxIawW/LjwZ3n2h7C/tmd4",
+	"UBYvYglKEtmo99gSydkmolpzVGdwV/A11+FEbZc0ZXz0mkZGxw0nLDfU+xQWoZRw0IgG5JzEDdO2/XKY",
+	"z5QJj19fDi3xt1x4kuBiGtgyFqFQg2syHloNdSEGvMvOjTRuJu4ZNindNuYwXVhD7erfTbNwRQHlZqF8",
+	"BCiyhCmswI8I+s1EDVE8jzmxMxyBkTlYDsQZ8RmLZPZlNtXVoIEDORwZucnlaUTxeVzE00RQizvcAo0c",
+	"tDf9yFZdcHuwzUVBze8OaL4AkML1gy4MWACrFiI5PE/p56eivMBUBIfU7s5D7xZZJor4XNxGKEpZ5ODR",
+	"nYfkssJ/HNqYnczg3kdXIiIsv0nCYsdjMs3wGMik5Khja8g4l91wk7Ce28Rdh9wlaimp3ua7tAzSYC7s",
+	"FuflhjVxXzpNUmy24JJGnDMeJsvWGPFlnV+UAdInh9sakj9ehoz2wpAPyjWfLRGf6rTAPKkajhPQy+xl",
+	"al3qI5mBVipqr/Vo/bRKbObltl2Tse4VfG6CdUS+55UMPJR6JkkQx45ETyI/t0+SOw5Y8U3ZF13WUn+J",
+	"dye6XTtEGvhnzXOEhkbrtKWiXW3Pnv6hh4paOIrvBGzVAGxg0KQrg7jK7fsMKpzqlzcvJGNYYmr5bixk",
+	"TQ0lk8gFDC3OrTe27dinJRPNLhTkbQIKZrTrZh/MLj0El9a4Sh84ywvNBVT8gHudyqFGXjOPmOVede05",
+	"Sm/WtSvgFzU8/dEef7x5gvY7LqBcGjypFVRVnES/1p7LrUSOgHzhwqpKn2LH93Wadb0uRlBrvP0iSFOR",
+	"WIdjsvdekUcLAf89GzoPXOGBbdsJGnm7rc3VC28uUy1KTYjgjUuskdWAatOVU/sgoVuoR/PUyWTqO9MN",
+	"zTDSxVHeUFvwNicUJdpP6ggU7ThbGWBQRILR2ONgZ4pLa8QDoUAiw+MiLxERRteyrqpaJVkAwiCOQ7Fs",
+	"PGshE2ZQkC1lS5tz4HxjF61nqJHlaptMAi4vu12l8uXwKko9A3termwO1NjiRDUgL+3zIE6UJwtxahM6",
+	"Y+8JC0mFYsEyhksnjPD0dJIsE07gP8oygHVzqpXREJQfnuZPYWVhVJbQSfp18ijOgQDrlpn+ONHfyKPU",
+	"tRdxwdVxMJy9gdU6gEFKv8qHu7k9wKOUMWWbUDqdKmpbsKvFsY1Yae+sK2sBfkuOXGRVHoptsx4eUy9r",
+	"QHU7hWKnpARGyF2mOsutqnoGDDZL45DCmW2sQ1baGaJeHhD53dYsqCsub6jlclkTN2ovFAlFZ7ieIoQS",
+	"cF3dmvEVD5Wxg/8sqaQLvpnn6DXIlA09v2RyTvnkBWotZDIwKrpk0EnUXLRN0VYrUJ2bZ0s0Ik9Sh2T3",
+	"DL+RVBdL76+zOKVMFRJs0tGMH6VUCKTElzAIf3NMDsb7aUZiF2+xzxgQ6Tmu+N1YFQ6hMVjbjttm8053",
+	"qCNl7JHGFWz7GNt6pFmvf254rfKk0FdOaqMEhT5hW3pRJ4BtIaFKY2sAV49vjtaDbr1WWh3njeG2gBVi",
+	"RXy4gxiOfDdPOUgXMYrTZrD3iDXKJ04ty3iBvm5aYLEwiNDKEuhg6L46+kF79N8ZTNPQrkRGJRtBg8vC",
+	"WrbrDtU6YAIJ7VHN4T7GOsmsg3DoBrXghi7g6lIgdhvCxGMq4yUB2U0ZS1KVFKIi8g9sJZG1EQ4k3Cqq",
+	"vckAutegKxNxd7jfodiWE7niGaK4wJfbcppYPKKe6I9GImVyvYQ3Jf7fFvrt3oG0QV45OxZ13Fq+7M9U",
+	"leDZ++iQe7VTqfvv8FjaEdjGGdmw/ymSFTMErJM4hgmPjtAib4dMJdWnR4WOcWjFrAdlYH+01ZkZ+p+p",
+	"7lzjIyKNDp+wN3XwccDUl9WoLs+w0OnIGJTSSxl22ZcyjhOE20Zgky0nJud6mlYdistMy1Za/NzpPUxu",
+	"6EhhNHYvQJX9v7ugfygHH28VxNJGUF+RLmSlq6Rb2dGH5fUBtzchHRCdygvKg2kpjWtJZtmTy3Kwu91J",
+	"N6QIh+bQEtR/wY9ZVc4zDiAAyXbkyaBW6RhaFyBNgeqEyr8OgGJ0kQX+2IuAtN128xBvAt0qoyp3+Bs2",
+	"TScyFatH2U1V7LkchLORAK+ChRYL4XCTxSgstDg7xNMTSlfOTZSyzdipgphDRc6k1VfhGJt2ozro+I06",
+	"LU1rElM3pGYphCsptGWGQqrj+8dWZ98d9jcjk3AL5Et4fzQyB9ttKity3qfShAAxV47sOvIEG5F+QWEq",
+	"BXqvKGyutZlRK1Pv2PtZZnQDITTNuJPRXuf4jry1K03oKo+zPC7X/oXA3Hb2lapGHjcy7xRrPvVrnXNV",
+	"oCR1JtrerjGX0GFbTXaRukKGluiOvMiK0pUoCBt4CyNvU31SVoR1qlpODP1Cp4oCWZV0gR/HbXC6ltou",
+	"ve3qtBHdQM7u4TShYyO0V3TMHiTkdNmRRYIy3dU2yAFnDd7FEZytJzu82HbMw4y3ypY8rOuIN3R7tA9i",
+	"zWgI7+xz8AE0YOuA/RDA1wJYF7huuamcDpGb7IFw2J0ENwaICtXs3v5PJnY1CsjIeW2n/quLdrAq0mER",
+	"aMEUjQcbizuZ9p06BQlZMN5PYQemmeRTJkF5z05iLqq61QurfQgEGMteG5MbUxmWmwFGG9nNYqKhDLvQ",
+	"GGgr+ZOqJ3383hpLhClfuIyOrIlWV3pjpxCuBifNpXPduk7z9WPGdYWWqGcgFllS6smnlwGyaHkvvv9m",
+	"+jdx7+/3o8N7d/42/fvhg8NQ3H/w8PAweHg/uPPw3h1x9+8P7h+KO7PvHk7vRnfv353ev3v/uwcPw3v3",
+	"70zvf/fwb9+oWr280LoO7n9TpiD/6PVz/4Tq4dVHs4qBqHBuEERjlXUEHiakB1sGcQLN5E//S90wzKdS",
+	"D69+PZDW54NFWa6KR5PJxcXF2OwymVMydL/MqnAxUfN0M32+fq4tYeyERifKRg5EBTpUiQpH9O3N0+MT",
+	"D/qNDwyOf3A4PhzfIZlvJVLYKvx0j36i27Ogc59IZIN/Q8MJgC4pF/KPJdq+Q/WpuAjmQGrGMv0K/nR+",
+	"d6IU6ZM/Jf//gKPObZ62KoGxNuR0s5KMWMJE5ZBOWGyIUYWMy0XBi3xKPZkzO43I1ML+gkjaNLAw4aeK",
+	"3HpuFIqTbrEcy/TorS1FrS1niqUUdy33uKtw18QKCRBQn3d/Pvj7B5tI1WVeZLk0ZckA0wiTWC6DeAUr",
+	"141QPe8N19LIkkhlWuUiTf4ShKgchNk4jbIL8peiUKraXIX6wDxcxKghRklfZlJ8muJXCcifYmTrlGLi",
+	"OlXQLSnIZvG8yltVTbSyR2YFgV3+1/HPr/DV+pLlx9formjYkGwLkvzDVgtbWpqWxXzVVMvqY3nXKnh9",
+	"9/DwI9S5HTVGUcu5YsHc+ztcYlMtd+2FtofrUMCXQYLHhZ5kSpORM2Yf0M7u3NidPU8p8BRptce8iDZ0",
+	"/8Zu6MQgTDomj/L+aoqicqbJWrIqCTvu/MENRtLnaI5CrTO1NLxYLeV207MUHv2qJUpgFYhDQJ5QvjJy",
+	"/ZiS9Acnh52060Rb2e2LLDvDKqOpkb6iUbHdUl6DOMuCaieqpH4ZqgcjigefrhtP1OdPxt4vhagrT7Ek",
+	"KAsS1CnUZqKUGYXrbMFY1nfs4tNmVe2byLNfsuOzoaPjjNTk7MFijYtLJfEyLrdkmyd1DWaulSxns56O",
+	"LORcazpzcR5nVaE7ORaGQ9jWtTMW2bEwdrKy7Tb92ZdTHvujpujpehk04aomH1SCwLj8DUrCMTBB7QX3",
+	"MYWPbg3oAB5A0t/wI4sHw/j5g0+5+x2yoXatJfcZ93MnnR1tMF+SwYUyoKLJcWRI3sfiNbTYPZf5a3IZ",
+	"jak7yRn5F2UpDMStGEnjuu/Zx1fEPnpOtpdpNEJiZR44tyZRGJWJjNSRDR9Y5B08+sgrdO1bZVYke3Ik",
+	"MGaHFNXEcUZGjSNDtwb/fHn03+RoCv/n4mFKO0medZbpuZBek+XAsi01uH5YH2k+cTM4z4kGkqNGFoYa",
+	"clQrAW0ZXH7vAtll6mQG0G2vQNxXcrvpldwG6KH2p7uv03dj6/TdbEPDpU5nEHgYWZ1SKt1ztJVpx4yv",
+	"zuDwdandHxzeu7G7ORb5eRwK70RA3zzIYyAFv6TadnI9q4KmOUAP6nDOXvrTqUpdS9GG+D7I/IBzmy1H",
+	"3hLdFnWpYpUPhrK27lq7A/s3rQibROu/pC5lZKnytpbZ5cWg2g2uqetMCK7ZN6QJ3L01oYGwuwjG/nKU",
+	"PV0Vf73ZgRKLRMNG170e5gbqYTpU1061J38af/lxtNlpq5HfOGpUlG+mPjbUiLpOhaQHozrlKvptUaiu",
+	"Ljk8UqlHyUuQLQ8MvFEnMekmev/D+vmTIdqUxp6MbIw2jUoDXr2KlQ79/6jeQyaV6jfZGWezv9wf0+em",
+	"9xRewWvt2afwf/moDit2tBpIbCZTTn3UR3DSlqsJ0YA6pZFBfiijnZk2iX3kKamV/l3nrOukKuqlI9nl",
+	"D+tXHIb/pRCTUTeS0JXpySkZ8o42TvpJ3B8xDZaNYMCe9gTrcxEshP5XQaimTTRid3pZg7MRhqQJ1wbn",
+	"BZKvqM0nf88qO+v+JbvlS7a/+N5NecNewVfhZr1beYNbvli50/6temPfqnSAbfoLIqPM+D3gZSqz6Tff",
+	"pPxj/2uUZSZOfEo5RtcqniiM8SHKN5kLGtjJ8dCHZjfhv00qrJOcfymPSyYiLgeevXy2f1Du6kHZRqia",
+	"IkwDfEJi1P+HOuxwmmThGfxMPmcmjejc0x+w5aY7+uU6jox6PPgwkYh04VOyJMGlHZFtoTUqPM9NaPoS",
+	"tu9YtKFFW3Ih015k1DElEu8EM9tFMOr4EwcBYw4amKk7+s8qaRR+xnBUjF1SOSpVXYLMkCMjloTKOpd5",
+	"jDlXKEuJzGPh4SlutcrH9eTdCHECy9U8SvYAvg6AO5TuKd9web3kJm6684PBQj0f2EiqfB5UisZ9rOWX",
+	"taFXWBFbXILYjGIs4+I+irLhf8xAUZGNrOeR1WuVPBFiqtBqNfmT/kFZFgzBgjMzWgQLW1g+K5+NfEIq",
+	"KZMxsVmvHT8ts0gWyZBO0KO6pgH+Cowdi2zrmjWcwLzpSEEdFkE6FzI1V7d8RoHOwRMjB0+hi5gpJ2J6",
+	"+HSTCAiVQ+C43ldBmQLgcRXEKSW8AqqtgGxowbhnQUUbQiVSkcalzs309HKV5SXnJyDvYEtosCoJRCoE",
+	"CUXaM7oOXwRYF4EyClHdkMADUaWS1Qpj4hbozaVcaKyPNyNV1AbJsF/cah3/xxG2/kJuzq2UnfZcXif2",
+	"OyfxRG7PyD8jE6LAb4JwT0SN7rzhoULFUzlET/1lV9Xl7eW2rw0ATrmqsZu9dLWXrj6HK6aBg84cFgZj",
+	"3zvSfqmnx1QODxHzTVPKb3l8hXS3RfnC8LitduRx25YGe+VQrl4zYcVznwbrmFvsNIKIxwSRQadGNFPI",
+	"SWU48JWXcZhnmGNMhwwUa5DTlt3ylNz1fV9dFHs+Wsqa6i/hwC3Z536mry/po603RyU4OlN8iKtvu3hX",
+	"Y/2tZTXnGcLYrgvf8Zeh1L7WTWjtFmChozApXIXwv74PlFi6foitFrCDaZad1T/lYg5vX5H7WHg1DuMV",
+	"e9BgvcNG+jnZvFhUZQSLNH6h7HG9d41b7PSuvQIRh8dtJmzsVp8LOPl3oRbRumL6vWoXCRW863ZcRAaI",
+	"3VSQ4SuoMIMxPZusybp1Rz8I+Wr4rGjflIOaW/F0i+Acnl8JvGcjTA6Ofg9T+W6UJ0+bhCeiqp/LTzt6",
+	"ldtLstXrAoiEaJOLfLNIV9/S1GtXp912gYnWTevVk2Dx01mQX3GtTDP619muuqgTHWqdqCQL3VUPm77v",
+	"/NqTm6fI731GAqogLNNpu0C4ESaVKx34Y/6KRctaOb6tg2GKcH/TVaA84sbqCsEVYRX2WUvW4MAO9vUC",
+	"vsnSXmlEyuSizlfO6gGcwr1gZxpuHPlXnUi3M3aINCgtgHToqmdATYmOWkuYonOHc65X8FXNBcddj63z",
+	"f3O94k0ju6BkjP/GVOMwgIJS55uQmp3u5i7iJCEtlp3bNxZRA6JvIceqlQFdU2niWEhc1IBuJL635r0v",
+	"ymy1QppU+lWq+7nAdMytj8pf6rZd5JJqOaKVEZaywgeJbC9XfiH1baTZw6xtch3KW4dc6zkNQHfNeBl9",
+	"qrHg92E+XstjbGVegQ2XtC1amde/cc9al6OFv1akcyLBhlNwbdgmzN1Ij562Ku4jatqbwqwhstTCHP89",
+	"QV0tqo6YDflUB32jbv03reANpD4ftbxwtMiJqZI6ExQ5jlHgszBjqHkJSr2Np99VBeNUz7J8kI+AUbYi",
+	"IyU0PBvLWOUpppoVSm778gzue4l0L5HuJdK9RLqXSPcS6V4ivZkS6efxrvV8XxFkFURny5Pi7f3gP7IF",
+	"5VPmDqkFaS1Gk+CNYi/e417DRimCZCJLVZNN21rOjN33zbLXIRU+BGEnCVDEgEulcsa14hp1AVcuyESR",
+	"odDg3l3v+KejB3fuvr/74DukPgvKDdhoe0uG58C21om43X0RYB2px3LtTDTgDvyQRevWueLyJrTS5onW",
+	"ZYriNMgttZEtKfTbMGAPFlXsu/No+LBTJwuEU/d0uvDcBEprElJHDeO+49wYJ0QL1mMPMcbgmSpwerKu",
+	"8melqB6tSKJZTT32YURXIVcKjNZrRJdwhBgWVWj3Bcom8efSx0ZzAWycj8Wfwi2XJUNV0fUGSeNq2G6K",
+	"9vRShBXeDFqJROpbxW2kaQRRkOtMXQUV4p4jOe2+u8kjj8aTPvxdKsV1l3uJ1NUPjwfXATbXNd+3h+te",
+	"USPv1C1gLnPgKavb7PqYrukRulzBv5SaBeWmZZUwyJTrek0lZkFS7JhOUuFpa5k39VZxP3Net+trymdI",
+	"p+4mw4lqO/GBox9XGjlyPGPy1C3LdNeF5TfVp+P9WnYn5x1CeNWxS79ErWuCrfkwiKUUfavw/D6u8wYS",
+	"5NcyKNhO31ivW1rv+3gjXc4NikSEuZUgVFHmJrl8E1yY6UaHksxLXwpx15bw0DMdhBst8ViyqSK3yrMg",
+	"ClHJAn/I2rwfWforL59bnti0TMqKPetkBUT2uTmYm8YdJJsZQz9/oiaktLUFF7D8vJJaXYrsSLrCNqCx",
+	"pxJfy6v3B3X5sEpwHly0L2dZ18seQKaCC+BvVio1ISOT2wnJuBCvueVOTT+d4ZsWoNoCJk0QIlkBPMIk",
+	"Ju06LAJoUFiepgFp+4yNdRNMax2mWzB6rJrYFc4WfbAcChZA1QW0DtAqIM2ERbv/TAglfxXAnzjsxDxs",
+	"6HWaylYgulcpvhpgriX66vnsrAfbJIo+5pbLYO3NMDgFEOUPkQMpRxnezLRKurOiRG0ym6NwGhgVNlJ6",
+	"iUCi/zJG8QyHU+oVbWJt1Wm314+WlVx9+4v+R/76E6pF5PaVioQ0OfyZLS44/mept+zHkXPlwB04Czr8",
+	"AxPb1paozto/mSVlGae+FcmQ40uDbhu3vFuoN1cIdLu2aclTP01RNAZEIkIflFdDh7bGu3MX+Xa0sKZx",
+	"EC3FuNrrO1ucyDzz8UUYzPH3eVwuqilVPFbxIxNooP8dBWIJpIrKGk+CVTzBjBuT8zsb5INr0CvPQq72",
+	"nPvr0VebeIC3RR88JS1qn72DL++g6MyXXWlmo4fLvq7Lvq7LvvLHvq7L/nT3dV32VU/2wZp/1aon414J",
+	"cfJneTkkMaE5ahyp7K8yW6wm4GazRgrDrlEwLsceprUG+o/OkIWAtxxatoOCBaOUncKWMfrUFlUYChE9",
+	"Ok39xkrgvOTEt+p/8jP3tDo8vCe8w9vtPqy3MChvty+JqvSJDEfw9+nB6UFnpBxefudCJtOg5lFFllru",
+	"tXHYf9Hj/px3jg61MKRcWaC3OLK1oprN4jBmkCcZPgbmWcuVLc3oC2U1XQqkqAD7ciTTpQA8yQVQOqIA",
+	"yaSF2ITuLn/fpqxwC13sXuSIeFsWdfz3IRUd/yoC9hPMYIM5b6Rzu+U9pXPSmOeBBtk60bOiKiq1jyjU",
+	"b9IeLWdJ4jNhupuS7f8iyCPVoiu8NTLDp5G4tKuWmvn3oZkSCdqLnumZgbLUOYaaua9tmi2ZjLRnCTJj",
+	"41Umx672acMkK4TPUCpsaZfoA5Ii0sYGpIylzbBTOKnJcAy8zAGuLqfokTrXs31OQAI/WFL94a6Smr97",
+	"/F1r41q6b8u4Ck/8jcWYL4iocxblFhBNbEMXLAondyiAqUSKz/4ROndMH6c2k7iMDqjqSrt7c8mnp2+T",
+	"6PT0nfeirg1vZJpSSakUjEw85YgPdmoxXJhbYByW3JqPs7n69ksDuYavvTba4lTHrbkN97M4PMPEEZUM",
+	"spDe1hYh3rulswbPYqKgaxW/wWzoNrBteHOBsFMC4yfK1tI1tyZPvyn75r80GWeTI1lc8EIB8kh+zTul",
+	"hum/SYCa0bWn4kH6J0Ljmv06BReWJ+3Q3EaWF2zrPWkgFa9iF4qBPVfac6U9V9pzpT1X2nOlj8aVOkqY",
+	"vZriU6gpPrui4ivKo7hPmfiFbch03myUobiG9lZyrNAqBdv1sjIUoCfY7CkKApqvcdSaeSHJ/dgjR7+V",
+	"8ojCEYtG+uECKwleBGt7peyLrEqgIdY/QiqonXmJr5YeeSUQ1ZA/SMYi06BRucK84ymPLbDYx/pJwIle",
+	"RYDxyiYtZke5QucCMKNP4H1QUZi5vRvHUOmYCs5aONKJotFjQXps13HSDCraLEap5QL5i5QDg3Q99pBd",
+	"BUCDKO8sp94ngh6gsOUVZzGGxI7QW4ALnlWpzerJMXDqZC2VGo/lp6Zjd6+O9TfkPbKgAClO+VTVeguL",
+	"uzMnvkZLHG/maiu3aUUREL6e2zdhZfOO0HHSN055+3k97bu3HM5HE4yP61wPbX28mY5kF1tfWu2rifv4",
+	"D3Y0pTeNiX58UxXWmk8dzJ5uteqbImzruTIwAzVPykSBiUqd3YRW+1nSUo8OZkGc4O2S5UMc0vkWZG7s",
+	"HU3xqauUC61m8DwQK3npu4+eTe9tkFw7C4A3n5DMC2nsWIwN3U1dNdJgWABR5/PEgYl6hhbpI0FUFfSL",
+	"85oT1ceJOR4sRJA66eZj7ym9Mu1A07C9tsPJbzDr0ZONoW31vVQIPtL5mVyxbVvnbt9f/v3l31/+r+Py",
+	"d8QMjqFlXUlXwqhDk7+yKgb9wXB7p7G909gNdBpTD0lb3B/d5y3D/ZixUSZzUj6HFUYB0HM0WMXvzzBT",
+	"/dt3+DbCagTqpVrlCQy0KMvVo8mETB5Y8XJygM+9+lvR+oi0KpjzCHItqzw+p2J37z78fzry5Qt+NAEA",
 }
 
 // GetSwagger returns the Swagger specification corresponding to the generated code
@@ -104,6 +104,9 @@ type AccountStateDelta struct {
 // Application defines model for Application.
 type Application struct {
 
+	// Address of the account controlled by the application. Funds held by this account can only be moved by inner transactions issued by the application.
+	Address string `json:"address"`
+
 	// \[appidx\] application index.
 	Id uint64 `json:"id"`
 
@@ -227,6 +230,16 @@ type AssetParams struct {
 	Url *string `json:"url,omitempty"`
 }
 
+// Box defines model for Box.
+type Box struct {
+
+	// \[name\] box name, base64 encoded
+	Name []byte `json:"name"`
+
+	// \[value\] box value, base64 encoded.
+	Value []byte `json:"value"`
+}
+
 // BuildVersion defines model for BuildVersion.
 type BuildVersion struct {
 	Branch      string `json:"branch"`
@@ -321,6 +334,40 @@ type EvalDeltaKeyValue struct {
 	Value EvalDelta `json:"value"`
 }
 
+// Peer defines model for Peer.
+type Peer struct {
+
+	// The address of the peer: the URL of outgoing peers, or the public address announced by incoming peers, which may be empty.
+	Address string `json:"address"`
+
+	// The number of seconds since the connection was established.
+	ConnectionDuration uint64 `json:"connection-duration"`
+
+	// The instance name announced by the peer.
+	InstanceName *string `json:"instance-name,omitempty"`
+
+	// The number of messages received from the peer.
+	MessagesReceived uint64 `json:"messages-received"`
+
+	// The number of messages sent to the peer.
+	MessagesSent uint64 `json:"messages-sent"`
+
+	// Whether the connection was made by this node.
+	Outgoing bool `json:"outgoing"`
+
+	// The round trip time of the last ping sent to the peer, in nanoseconds. Omitted if no ping to the peer completed yet.
+	PingRoundTripTime *uint64 `json:"ping-round-trip-time,omitempty"`
+
+	// The priority weight of the peer, based on the online stake of the account it proved to own.
+	PriorityWeight uint64 `json:"priority-weight"`
+
+	// The remote host of the connection.
+	RemoteHost string `json:"remote-host"`
+
+	// The network protocol version used with the peer.
+	Version *string `json:"version,omitempty"`
+}
+
 // StateDelta defines model for StateDelta.
 type StateDelta []EvalDeltaKeyValue
 
@@ -422,15 +469,53 @@ type TxId string
 // TxType defines model for tx-type.
 type TxType string
 
+// AccountApplicationsResponse defines model for AccountApplicationsResponse.
+type AccountApplicationsResponse struct {
+	AppsLocalState []ApplicationLocalState `json:"apps-local-state"`
+
+	// Used for pagination, when making another request provide this token with the next parameter.
+	NextToken *string `json:"next-token,omitempty"`
+
+	// The round for which this information is relevant.
+	Round uint64 `json:"round"`
+}
+
+// AccountAssetsResponse defines model for AccountAssetsResponse.
+type AccountAssetsResponse struct {
+	Assets []AssetHolding `json:"assets"`
+
+	// Used for pagination, when making another request provide this token with the next parameter.
+	NextToken *string `json:"next-token,omitempty"`
+
+	// The round for which this information is relevant.
+	Round uint64 `json:"round"`
+}
+
 // AccountResponse defines model for AccountResponse.
 type AccountResponse Account
 
 // ApplicationResponse defines model for ApplicationResponse.
 type ApplicationResponse Application
 
+// ApplicationsResponse defines model for ApplicationsResponse.
+type ApplicationsResponse struct {
+	Applications []Application `json:"applications"`
+
+	// Used for pagination, when making another request provide this token with the next parameter.
+	NextToken *string `json:"next-token,omitempty"`
+}
+
 // AssetResponse defines model for AssetResponse.
 type AssetResponse Asset
 
+// AssetsResponse defines model for AssetsResponse.
+type AssetsResponse struct {
+	Assets []Asset `json:"assets"`
+
+	// Used for pagination, when making another request provide this token with the next parameter.
+	NextToken *string `json:"next-token,omitempty"`
+}
+
 // BlockResponse defines model for BlockResponse.
 type BlockResponse struct {
 
@@ -441,6 +526,9 @@ type BlockResponse struct {
 	Cert *map[string]interface{} `json:"cert,omitempty"`
 }
 
+// BoxResponse defines model for BoxResponse.
+type BoxResponse Box
+
 // CatchpointAbortResponse defines model for CatchpointAbortResponse.
 type CatchpointAbortResponse struct {
 
@@ -520,6 +608,11 @@ type NodeStatusResponse struct {
 	TimeSinceLastRound uint64 `json:"time-since-last-round"`
 }
 
+// PeersResponse defines model for PeersResponse.
+type PeersResponse struct {
+	Peers []Peer `json:"peers"`
+}
+
 // PendingTransactionResponse defines model for PendingTransactionResponse.
 type PendingTransactionResponse struct {
 
@@ -574,6 +667,32 @@ type PostTransactionsResponse struct {
 	TxId string `json:"txId"`
 }
 
+// SimulateResponse defines model for SimulateResponse.
+type SimulateResponse struct {
+
+	// The cost of the TEAL programs run by each transaction of the group; zero for transactions which are not application calls.
+	AppCosts []uint64 `json:"app-costs"`
+
+	// The state delta which would result from the group, encoded using the versioned exported state delta format.
+	Delta map[string]interface{} `json:"delta"`
+
+	// The reason for which the group would be rejected. Absent if the group would be accepted.
+	FailureMessage *string `json:"failure-message,omitempty"`
+
+	// The round in which the group was evaluated, i.e. the round following the latest one.
+	Round uint64 `json:"round"`
+
+	// The evaluated transactions along with their ApplyData, encoded as signed transactions with ApplyData. Empty if the group would be rejected.
+	Txns []map[string]interface{} `json:"txns"`
+}
+
+// StateDeltaResponse defines model for StateDeltaResponse.
+type StateDeltaResponse struct {
+
+	// The state delta of the round, encoded using the versioned exported state delta format.
+	Delta map[string]interface{} `json:"delta"`
+}
+
 // SupplyResponse defines model for SupplyResponse.
 type SupplyResponse struct {
 
@@ -620,10 +739,33 @@ type VersionsResponse Version
 // AccountInformationParams defines parameters for AccountInformation.
 type AccountInformationParams struct {
 
+	// Return the account as it was at the end of this round. Rounds older than the in-memory window are only available on archival nodes with EnableAccountHistory set.
+	Round *uint64 `json:"round,omitempty"`
+
 	// Configures whether the response object is JSON or MessagePack encoded.
 	Format *string `json:"format,omitempty"`
 }
 
+// AccountApplicationsInformationParams defines parameters for AccountApplicationsInformation.
+type AccountApplicationsInformationParams struct {
+
+	// Maximum number of results to return.
+	Limit *uint64 `json:"limit,omitempty"`
+
+	// The next page of results. Use the next token provided by the previous results.
+	Next *string `json:"next,omitempty"`
+}
+
+// AccountAssetsInformationParams defines parameters for AccountAssetsInformation.
+type AccountAssetsInformationParams struct {
+
+	// Maximum number of results to return.
+	Limit *uint64 `json:"limit,omitempty"`
+
+	// The next page of results. Use the next token provided by the previous results.
+	Next *string `json:"next,omitempty"`
+}
+
 // GetPendingTransactionsByAddressParams defines parameters for GetPendingTransactionsByAddress.
 type GetPendingTransactionsByAddressParams struct {
 
@@ -634,6 +776,39 @@ type GetPendingTransactionsByAddressParams struct {
 	Format *string `json:"format,omitempty"`
 }
 
+// GetApplicationsParams defines parameters for GetApplications.
+type GetApplicationsParams struct {
+
+	// Maximum number of results to return.
+	Limit *uint64 `json:"limit,omitempty"`
+
+	// The next page of results. Use the next token provided by the previous results.
+	Next *string `json:"next,omitempty"`
+
+	// Only include applications created by this account.
+	Creator *string `json:"creator,omitempty"`
+}
+
+// GetApplicationBoxByNameParams defines parameters for GetApplicationBoxByName.
+type GetApplicationBoxByNameParams struct {
+
+	// A box name, base64 encoded.
+	Name string `json:"name"`
+}
+
+// GetAssetsParams defines parameters for GetAssets.
+type GetAssetsParams struct {
+
+	// Maximum number of results to return.
+	Limit *uint64 `json:"limit,omitempty"`
+
+	// The next page of results. Use the next token provided by the previous results.
+	Next *string `json:"next,omitempty"`
+
+	// Only include assets created by this account.
+	Creator *string `json:"creator,omitempty"`
+}
+
 // GetBlockParams defines parameters for GetBlock.
 type GetBlockParams struct {
 
@@ -641,6 +816,13 @@ type GetBlockParams struct {
 	Format *string `json:"format,omitempty"`
 }
 
+// GetStateDeltaParams defines parameters for GetStateDelta.
+type GetStateDeltaParams struct {
+
+	// Configures whether the response object is JSON or MessagePack encoded.
+	Format *string `json:"format,omitempty"`
+}
+
 // TealDryrunJSONBody defines parameters for TealDryrun.
 type TealDryrunJSONBody DryrunRequest
 
@@ -661,5 +843,15 @@ type PendingTransactionInformationParams struct {
 	Format *string `json:"format,omitempty"`
 }
 
+// SimulateTransactionParams defines parameters for SimulateTransaction.
+type SimulateTransactionParams struct {
+
+	// When set to true, the signatures of the transactions are not verified, allowing unsigned transactions to be simulated.
+	SkipSignatureVerification *bool `json:"skip-signature-verification,omitempty"`
+
+	// Configures whether the response object is JSON or MessagePack encoded.
+	Format *string `json:"format,omitempty"`
+}
+
 // TealDryrunRequestBody defines body for TealDryrun for application/json ContentType.
 type TealDryrunJSONRequestBody TealDryrunJSONBody
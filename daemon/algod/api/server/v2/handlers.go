@@ -21,7 +21,7 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
-	"io"
+	"math"
 	"net/http"
 	"time"
 
@@ -38,6 +38,7 @@ import (
 	"github.com/algorand/go-algorand/data/transactions/logic"
 	"github.com/algorand/go-algorand/ledger"
 	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/network"
 	"github.com/algorand/go-algorand/node"
 	"github.com/algorand/go-algorand/protocol"
 	"github.com/algorand/go-algorand/rpcs"
@@ -46,6 +47,10 @@ import (
 const maxTealSourceBytes = 1e5
 const maxTealDryrunBytes = 1e5
 
+// defaultBanDuration is the duration of a host ban when none is specified, and maxBanDurationSeconds is the longest one allowed.
+const defaultBanDuration = time.Hour
+const maxBanDurationSeconds = 30 * 24 * 3600
+
 // Handlers is an implementation to the V2 route handler interface defined by the generated code.
 type Handlers struct {
 	Node     NodeInterface
@@ -66,6 +71,10 @@ type NodeInterface interface {
 	StartCatchup(catchpoint string) error
 	AbortCatchup(catchpoint string) error
 	Config() config.Local
+	Peers() ([]network.PeerInfo, error)
+	DisconnectPeer(address string) (int, error)
+	AddPhonebookAddress(address string) error
+	BanHost(host string, duration time.Duration) error
 }
 
 // RegisterParticipationKeys registers participation keys.
@@ -82,6 +91,67 @@ func (v2 *Handlers) ShutdownNode(ctx echo.Context, params private.ShutdownNodePa
 	return ctx.String(http.StatusNotImplemented, "Endpoint not implemented.")
 }
 
+// GetPeers lists the gossip network peers the node is connected to.
+// (GET /v2/peers)
+func (v2 *Handlers) GetPeers(ctx echo.Context) error {
+	peers, err := v2.Node.Peers()
+	if err != nil {
+		return internalError(ctx, err, errFailedRetrievingPeers, v2.Log)
+	}
+	response := private.PeersResponse{Peers: make([]private.Peer, len(peers))}
+	now := time.Now()
+	for i, peer := range peers {
+		response.Peers[i] = peerInfoToPeer(peer, now)
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// DisconnectPeer disconnects the peers matching the given address.
+// (DELETE /v2/peers)
+func (v2 *Handlers) DisconnectPeer(ctx echo.Context, params private.DisconnectPeerParams) error {
+	disconnected, err := v2.Node.DisconnectPeer(params.Address)
+	if err != nil {
+		return internalError(ctx, err, errFailedToDisconnectPeer, v2.Log)
+	}
+	if disconnected == 0 {
+		return notFound(ctx, fmt.Errorf("no connected peer matches %s", params.Address), errPeerNotFound, v2.Log)
+	}
+	return ctx.JSON(http.StatusOK, struct{}{})
+}
+
+// AddPhonebookAddress adds a relay address to the phonebook.
+// (POST /v2/phonebook)
+func (v2 *Handlers) AddPhonebookAddress(ctx echo.Context, params private.AddPhonebookAddressParams) error {
+	err := v2.Node.AddPhonebookAddress(params.Address)
+	if err == node.ErrPeerManagementUnsupported {
+		return internalError(ctx, err, err.Error(), v2.Log)
+	}
+	if err != nil {
+		return badRequest(ctx, err, err.Error(), v2.Log)
+	}
+	return ctx.JSON(http.StatusOK, struct{}{})
+}
+
+// BanHost temporarily bans an IP address.
+// (POST /v2/bans/{host})
+func (v2 *Handlers) BanHost(ctx echo.Context, host string, params private.BanHostParams) error {
+	duration := defaultBanDuration
+	if params.Duration != nil {
+		if *params.Duration == 0 || *params.Duration > maxBanDurationSeconds {
+			return badRequest(ctx, errors.New(errFailedParsingBanDuration), errFailedParsingBanDuration, v2.Log)
+		}
+		duration = time.Duration(*params.Duration) * time.Second
+	}
+	err := v2.Node.BanHost(host, duration)
+	if err == node.ErrPeerManagementUnsupported {
+		return internalError(ctx, err, err.Error(), v2.Log)
+	}
+	if err != nil {
+		return badRequest(ctx, err, err.Error(), v2.Log)
+	}
+	return ctx.JSON(http.StatusOK, struct{}{})
+}
+
 // AccountInformation gets account information for a given account.
 // (GET /v2/accounts/{address})
 func (v2 *Handlers) AccountInformation(ctx echo.Context, address string, params generated.AccountInformationParams) error {
@@ -97,8 +167,17 @@ func (v2 *Handlers) AccountInformation(ctx echo.Context, address string, params
 
 	myLedger := v2.Node.Ledger()
 	lastRound := myLedger.Latest()
+	if params.Round != nil {
+		if basics.Round(*params.Round) > lastRound {
+			return badRequest(ctx, fmt.Errorf("round %d is after latest round %d", *params.Round, lastRound), errRoundAfterLatest, v2.Log)
+		}
+		lastRound = basics.Round(*params.Round)
+	}
 	record, err := myLedger.Lookup(lastRound, addr)
 	if err != nil {
+		if _, ok := err.(*ledger.RoundOffsetError); ok {
+			return notFound(ctx, err, errAccountNotAvailableInRound, v2.Log)
+		}
 		return internalError(ctx, err, errFailedLookingUpLedger, v2.Log)
 	}
 
@@ -142,6 +221,105 @@ func (v2 *Handlers) AccountInformation(ctx echo.Context, address string, params
 	return ctx.JSON(http.StatusOK, response)
 }
 
+// AccountAssetsInformation returns a page of the asset holdings of an account.
+// (GET /v2/accounts/{address}/assets)
+func (v2 *Handlers) AccountAssetsInformation(ctx echo.Context, address string, params generated.AccountAssetsInformationParams) error {
+	page, err := parsePagination(params.Limit, params.Next)
+	if err != nil {
+		return badRequest(ctx, err, err.Error(), v2.Log)
+	}
+
+	addr, err := basics.UnmarshalChecksumAddress(address)
+	if err != nil {
+		return badRequest(ctx, err, errFailedToParseAddress, v2.Log)
+	}
+
+	myLedger := v2.Node.Ledger()
+	lastRound := myLedger.Latest()
+	record, err := myLedger.Lookup(lastRound, addr)
+	if err != nil {
+		return internalError(ctx, err, errFailedLookingUpLedger, v2.Log)
+	}
+
+	indices := make([]basics.CreatableIndex, 0, len(record.Assets))
+	for aidx := range record.Assets {
+		indices = append(indices, basics.CreatableIndex(aidx))
+	}
+	indices, more := page.ascending(indices)
+
+	response := generated.AccountAssetsResponse{
+		Assets: make([]generated.AssetHolding, 0, len(indices)),
+		Round:  uint64(lastRound),
+	}
+	for _, cidx := range indices {
+		holding := record.Assets[basics.AssetIndex(cidx)]
+		// Empty is ok, asset may have been deleted, so we can no
+		// longer fetch the creator
+		var creator string
+		creatorAddr, ok, err := myLedger.GetCreator(cidx, basics.AssetCreatable)
+		if err == nil && ok {
+			creator = creatorAddr.String()
+		}
+		response.Assets = append(response.Assets, generated.AssetHolding{
+			Amount:   holding.Amount,
+			AssetId:  uint64(cidx),
+			Creator:  creator,
+			IsFrozen: holding.Frozen,
+		})
+	}
+	if more {
+		response.NextToken = nextToken(indices[len(indices)-1])
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// AccountApplicationsInformation returns a page of the application local states of an account.
+// (GET /v2/accounts/{address}/applications)
+func (v2 *Handlers) AccountApplicationsInformation(ctx echo.Context, address string, params generated.AccountApplicationsInformationParams) error {
+	page, err := parsePagination(params.Limit, params.Next)
+	if err != nil {
+		return badRequest(ctx, err, err.Error(), v2.Log)
+	}
+
+	addr, err := basics.UnmarshalChecksumAddress(address)
+	if err != nil {
+		return badRequest(ctx, err, errFailedToParseAddress, v2.Log)
+	}
+
+	myLedger := v2.Node.Ledger()
+	lastRound := myLedger.Latest()
+	record, err := myLedger.Lookup(lastRound, addr)
+	if err != nil {
+		return internalError(ctx, err, errFailedLookingUpLedger, v2.Log)
+	}
+
+	indices := make([]basics.CreatableIndex, 0, len(record.AppLocalStates))
+	for appIdx := range record.AppLocalStates {
+		indices = append(indices, basics.CreatableIndex(appIdx))
+	}
+	indices, more := page.ascending(indices)
+
+	response := generated.AccountApplicationsResponse{
+		AppsLocalState: make([]generated.ApplicationLocalState, 0, len(indices)),
+		Round:          uint64(lastRound),
+	}
+	for _, cidx := range indices {
+		state := record.AppLocalStates[basics.AppIndex(cidx)]
+		response.AppsLocalState = append(response.AppsLocalState, generated.ApplicationLocalState{
+			Id:       uint64(cidx),
+			KeyValue: convertTKVToGenerated(&state.KeyValue),
+			Schema: generated.ApplicationStateSchema{
+				NumByteSlice: state.Schema.NumByteSlice,
+				NumUint:      state.Schema.NumUint,
+			},
+		})
+	}
+	if more {
+		response.NextToken = nextToken(indices[len(indices)-1])
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
 // GetBlock gets the block for the given round.
 // (GET /v2/blocks/{round})
 func (v2 *Handlers) GetBlock(ctx echo.Context, round uint64, params generated.GetBlockParams) error {
@@ -182,6 +360,58 @@ func (v2 *Handlers) GetBlock(ctx echo.Context, round uint64, params generated.Ge
 	return ctx.Blob(http.StatusOK, contentType, data)
 }
 
+// GetStateDelta gets the state delta for the given round. If the round is the next round, it waits
+// for the round to be available before returning.
+// (GET /v2/deltas/{round})
+func (v2 *Handlers) GetStateDelta(ctx echo.Context, round uint64, params generated.GetStateDeltaParams) error {
+	handle, contentType, err := getCodecHandle(params.Format)
+	if err != nil {
+		return badRequest(ctx, err, errFailedParsingFormatOption, v2.Log)
+	}
+
+	if v2.Node.Config().StateDeltaExportRounds == 0 {
+		return serviceUnavailable(ctx, fmt.Errorf("GetStateDelta failed as StateDeltaExportRounds is zero"), errStateDeltaExportDisabled, v2.Log)
+	}
+
+	myLedger := v2.Node.Ledger()
+	rnd := basics.Round(round)
+	if latest := myLedger.Latest(); rnd > latest {
+		if rnd > latest+1 {
+			return notFound(ctx, fmt.Errorf("GetStateDelta: round %d is beyond the next round %d", rnd, latest+1), errStateDeltaNotAvailable, v2.Log)
+		}
+		// long poll for the next round.
+		select {
+		case <-v2.Shutdown:
+			return internalError(ctx, fmt.Errorf("GetStateDelta: shutting down"), errServiceShuttingDown, v2.Log)
+		case <-time.After(1 * time.Minute):
+			return notFound(ctx, fmt.Errorf("GetStateDelta: timed out waiting for round %d", rnd), errStateDeltaNotAvailable, v2.Log)
+		case <-myLedger.Wait(rnd):
+		}
+	}
+
+	delta, err := myLedger.ExportedStateDelta(rnd)
+	if err != nil {
+		if _, ok := err.(ledger.StateDeltaUnavailableError); ok {
+			return notFound(ctx, err, errStateDeltaNotAvailable, v2.Log)
+		}
+		return internalError(ctx, err, errFailedLookingUpLedger, v2.Log)
+	}
+
+	// Encoding wasn't working well without embedding "real" objects.
+	response := struct {
+		Delta ledger.ExportedStateDelta `codec:"delta"`
+	}{
+		Delta: delta,
+	}
+
+	data, err := encode(handle, response)
+	if err != nil {
+		return internalError(ctx, err, errFailedToEncodeResponse, v2.Log)
+	}
+
+	return ctx.Blob(http.StatusOK, contentType, data)
+}
+
 // GetSupply gets the current supply reported by the ledger.
 // (GET /v2/ledger/supply)
 func (v2 *Handlers) GetSupply(ctx echo.Context) error {
@@ -285,27 +515,8 @@ func (v2 *Handlers) RawTransaction(ctx echo.Context) error {
 	}
 	proto := config.Consensus[stat.LastVersion]
 
-	var txgroup []transactions.SignedTxn
-	dec := protocol.NewDecoder(ctx.Request().Body)
-	for {
-		var st transactions.SignedTxn
-		err := dec.Decode(&st)
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return badRequest(ctx, err, err.Error(), v2.Log)
-		}
-		txgroup = append(txgroup, st)
-
-		if len(txgroup) > proto.MaxTxGroupSize {
-			err := fmt.Errorf("max group size is %d", proto.MaxTxGroupSize)
-			return badRequest(ctx, err, err.Error(), v2.Log)
-		}
-	}
-
-	if len(txgroup) == 0 {
-		err := errors.New("empty txgroup")
+	txgroup, err := decodeTxGroup(ctx.Request().Body, proto.MaxTxGroupSize)
+	if err != nil {
 		return badRequest(ctx, err, err.Error(), v2.Log)
 	}
 
@@ -319,6 +530,58 @@ func (v2 *Handlers) RawTransaction(ctx echo.Context) error {
 	return ctx.JSON(http.StatusOK, generated.PostTransactionsResponse{TxId: txid.String()})
 }
 
+// SimulateTransaction evaluates a transaction group on top of the latest round, without broadcasting it.
+// (POST /v2/transactions/simulate)
+func (v2 *Handlers) SimulateTransaction(ctx echo.Context, params generated.SimulateTransactionParams) error {
+	handle, contentType, err := getCodecHandle(params.Format)
+	if err != nil {
+		return badRequest(ctx, err, errFailedParsingFormatOption, v2.Log)
+	}
+
+	stat, err := v2.Node.Status()
+	if err != nil {
+		return internalError(ctx, err, errFailedRetrievingNodeStatus, v2.Log)
+	}
+	if stat.Catchpoint != "" {
+		// node is currently catching up to the requested catchpoint.
+		return serviceUnavailable(ctx, fmt.Errorf("SimulateTransaction failed as the node was catchpoint catchuping"), errOperationNotAvailableDuringCatchup, v2.Log)
+	}
+	proto := config.Consensus[stat.LastVersion]
+
+	txgroup, err := decodeTxGroup(ctx.Request().Body, proto.MaxTxGroupSize)
+	if err != nil {
+		return badRequest(ctx, err, err.Error(), v2.Log)
+	}
+
+	verifySignatures := params.SkipSignatureVerification == nil || !*params.SkipSignatureVerification
+	result, err := v2.Node.Ledger().Simulate(txgroup, verifySignatures)
+	if err != nil {
+		return internalError(ctx, err, errFailedToSimulate, v2.Log)
+	}
+
+	// Encoding wasn't working well without embedding "real" objects.
+	response := struct {
+		Round          uint64                         `codec:"round"`
+		Txns           []transactions.SignedTxnWithAD `codec:"txns"`
+		AppCosts       []int                          `codec:"app-costs"`
+		Delta          ledger.ExportedStateDelta      `codec:"delta"`
+		FailureMessage string                         `codec:"failure-message,omitempty"`
+	}{
+		Round:          uint64(result.Round),
+		Txns:           result.Txns,
+		AppCosts:       result.AppCosts,
+		Delta:          result.Delta,
+		FailureMessage: result.FailureMessage,
+	}
+
+	data, err := encode(handle, response)
+	if err != nil {
+		return internalError(ctx, err, errFailedToEncodeResponse, v2.Log)
+	}
+
+	return ctx.Blob(http.StatusOK, contentType, data)
+}
+
 // TealDryrun takes transactions and additional simulated ledger state and returns debugging information.
 // (POST /v2/teal/dryrun)
 func (v2 *Handlers) TealDryrun(ctx echo.Context) error {
@@ -605,6 +868,113 @@ func (v2 *Handlers) GetPendingTransactions(ctx echo.Context, params generated.Ge
 	return v2.getPendingTransactions(ctx, params.Max, params.Format, nil)
 }
 
+// listCreatables returns the locators of the assets or applications on the
+// requested page, most recently created first, along with whether more
+// follow it. When creator is set only its own creatables are listed.
+func listCreatables(myLedger *data.Ledger, lastRound basics.Round, ctype basics.CreatableType, page pagination, creator *basics.Address) ([]basics.CreatableLocator, bool, error) {
+	if creator != nil {
+		record, err := myLedger.Lookup(lastRound, *creator)
+		if err != nil {
+			return nil, false, err
+		}
+
+		var indices []basics.CreatableIndex
+		if ctype == basics.AssetCreatable {
+			for aidx := range record.AssetParams {
+				indices = append(indices, basics.CreatableIndex(aidx))
+			}
+		} else {
+			for appIdx := range record.AppParams {
+				indices = append(indices, basics.CreatableIndex(appIdx))
+			}
+		}
+		indices, more := page.descending(indices)
+
+		locators := make([]basics.CreatableLocator, len(indices))
+		for i, cidx := range indices {
+			locators[i] = basics.CreatableLocator{Type: ctype, Index: cidx, Creator: *creator}
+		}
+		return locators, more, nil
+	}
+
+	// The ledger lists creatables with an index no greater than maxIdx
+	maxIdx := basics.CreatableIndex(math.MaxInt64)
+	if page.resume {
+		if page.after == 0 {
+			return nil, false, nil
+		}
+		maxIdx = page.after - 1
+	}
+
+	// Ask for one more than the page holds to learn whether more follow
+	var locators []basics.CreatableLocator
+	var err error
+	if ctype == basics.AssetCreatable {
+		locators, err = myLedger.ListAssets(basics.AssetIndex(maxIdx), page.limit+1)
+	} else {
+		locators, err = myLedger.ListApplications(basics.AppIndex(maxIdx), page.limit+1)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if uint64(len(locators)) > page.limit {
+		return locators[:page.limit], true, nil
+	}
+	return locators, false, nil
+}
+
+// GetApplications returns a page of applications.
+// (GET /v2/applications)
+func (v2 *Handlers) GetApplications(ctx echo.Context, params generated.GetApplicationsParams) error {
+	page, err := parsePagination(params.Limit, params.Next)
+	if err != nil {
+		return badRequest(ctx, err, err.Error(), v2.Log)
+	}
+
+	var creator *basics.Address
+	if params.Creator != nil {
+		addr, err := basics.UnmarshalChecksumAddress(*params.Creator)
+		if err != nil {
+			return badRequest(ctx, err, errFailedToParseAddress, v2.Log)
+		}
+		creator = &addr
+	}
+
+	myLedger := v2.Node.Ledger()
+	lastRound := myLedger.Latest()
+	locators, more, err := listCreatables(myLedger, lastRound, basics.AppCreatable, page, creator)
+	if err != nil {
+		return internalError(ctx, err, errFailedLookingUpLedger, v2.Log)
+	}
+
+	response := generated.ApplicationsResponse{
+		Applications: make([]generated.Application, 0, len(locators)),
+	}
+	records := make(map[basics.Address]basics.AccountData)
+	for _, loc := range locators {
+		record, ok := records[loc.Creator]
+		if !ok {
+			record, err = myLedger.Lookup(lastRound, loc.Creator)
+			if err != nil {
+				return internalError(ctx, err, errFailedLookingUpLedger, v2.Log)
+			}
+			records[loc.Creator] = record
+		}
+
+		// Ensure no race with application deletion
+		appIdx := basics.AppIndex(loc.Index)
+		appParams, ok := record.AppParams[appIdx]
+		if !ok {
+			continue
+		}
+		response.Applications = append(response.Applications, AppParamsToApplication(loc.Creator.String(), appIdx, &appParams))
+	}
+	if more {
+		response.NextToken = nextToken(locators[len(locators)-1].Index)
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
 // GetApplicationByID returns application information by app idx.
 // (GET /v2/applications/{application-id})
 func (v2 *Handlers) GetApplicationByID(ctx echo.Context, applicationID uint64) error {
@@ -633,6 +1003,83 @@ func (v2 *Handlers) GetApplicationByID(ctx echo.Context, applicationID uint64) e
 	return ctx.JSON(http.StatusOK, response)
 }
 
+// GetApplicationBoxByName returns the value of an application's box
+// (GET /v2/applications/{application-id}/box)
+func (v2 *Handlers) GetApplicationBoxByName(ctx echo.Context, applicationID uint64, params generated.GetApplicationBoxByNameParams) error {
+	appIdx := basics.AppIndex(applicationID)
+	name, err := base64.StdEncoding.DecodeString(params.Name)
+	if err != nil {
+		return badRequest(ctx, err, errFailedToParseBoxName, v2.Log)
+	}
+
+	ledger := v2.Node.Ledger()
+	value, ok, err := ledger.LookupBox(ledger.Latest(), appIdx, string(name))
+	if err != nil {
+		return internalError(ctx, err, errFailedLookingUpLedger, v2.Log)
+	}
+	if !ok {
+		return notFound(ctx, errors.New(errBoxDoesNotExist), errBoxDoesNotExist, v2.Log)
+	}
+
+	response := generated.BoxResponse{
+		Name:  name,
+		Value: value,
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// GetAssets returns a page of assets.
+// (GET /v2/assets)
+func (v2 *Handlers) GetAssets(ctx echo.Context, params generated.GetAssetsParams) error {
+	page, err := parsePagination(params.Limit, params.Next)
+	if err != nil {
+		return badRequest(ctx, err, err.Error(), v2.Log)
+	}
+
+	var creator *basics.Address
+	if params.Creator != nil {
+		addr, err := basics.UnmarshalChecksumAddress(*params.Creator)
+		if err != nil {
+			return badRequest(ctx, err, errFailedToParseAddress, v2.Log)
+		}
+		creator = &addr
+	}
+
+	myLedger := v2.Node.Ledger()
+	lastRound := myLedger.Latest()
+	locators, more, err := listCreatables(myLedger, lastRound, basics.AssetCreatable, page, creator)
+	if err != nil {
+		return internalError(ctx, err, errFailedLookingUpLedger, v2.Log)
+	}
+
+	response := generated.AssetsResponse{
+		Assets: make([]generated.Asset, 0, len(locators)),
+	}
+	records := make(map[basics.Address]basics.AccountData)
+	for _, loc := range locators {
+		record, ok := records[loc.Creator]
+		if !ok {
+			record, err = myLedger.Lookup(lastRound, loc.Creator)
+			if err != nil {
+				return internalError(ctx, err, errFailedLookingUpLedger, v2.Log)
+			}
+			records[loc.Creator] = record
+		}
+
+		// Ensure no race with asset deletion
+		assetIdx := basics.AssetIndex(loc.Index)
+		assetParams, ok := record.AssetParams[assetIdx]
+		if !ok {
+			continue
+		}
+		response.Assets = append(response.Assets, AssetParamsToAsset(loc.Creator.String(), assetIdx, &assetParams))
+	}
+	if more {
+		response.NextToken = nextToken(locators[len(locators)-1].Index)
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
 // GetAssetByID returns application information by app idx.
 // (GET /v2/assets/{asset-id})
 func (v2 *Handlers) GetAssetByID(ctx echo.Context, assetID uint64) error {
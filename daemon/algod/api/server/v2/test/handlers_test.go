@@ -23,6 +23,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/require"
@@ -30,6 +31,7 @@ import (
 	v2 "github.com/algorand/go-algorand/daemon/algod/api/server/v2"
 	"github.com/algorand/go-algorand/daemon/algod/api/server/v2/generated"
 	generatedV2 "github.com/algorand/go-algorand/daemon/algod/api/server/v2/generated"
+	"github.com/algorand/go-algorand/daemon/algod/api/server/v2/generated/private"
 	"github.com/algorand/go-algorand/data/account"
 	"github.com/algorand/go-algorand/data/basics"
 	"github.com/algorand/go-algorand/data/transactions"
@@ -88,6 +90,28 @@ func TestAccountInformation(t *testing.T) {
 	accountInformationTest(t, "bad account", 400)
 }
 
+func accountInformationAtRoundTest(t *testing.T, address string, round uint64, expectedCode int) {
+	handler, c, rec, _, _, releasefunc := setupTestForMethodGet(t)
+	defer releasefunc()
+	err := handler.AccountInformation(c, address, generatedV2.AccountInformationParams{Round: &round})
+	require.NoError(t, err)
+	require.Equal(t, expectedCode, rec.Code)
+	if expectedCode == 200 {
+		actualResponse := generatedV2.AccountResponse{}
+		err = protocol.DecodeJSON(rec.Body.Bytes(), &actualResponse)
+		require.NoError(t, err)
+		require.Equal(t, round, actualResponse.Round)
+	}
+}
+
+func TestAccountInformationAtRound(t *testing.T) {
+	t.Parallel()
+
+	accountInformationAtRoundTest(t, poolAddr.String(), 0, 200)
+	accountInformationAtRoundTest(t, poolAddr.String(), 1, 400)
+	accountInformationAtRoundTest(t, "bad account", 0, 400)
+}
+
 func getBlockTest(t *testing.T, blockNum uint64, format string, expectedCode int) {
 	handler, c, rec, _, _, releasefunc := setupTestForMethodGet(t)
 	defer releasefunc()
@@ -105,6 +129,139 @@ func TestGetBlock(t *testing.T) {
 	getBlockTest(t, 0, "bad format", 400)
 }
 
+func accountAssetsInformationTest(t *testing.T, address string, limit uint64, expectedCode int) {
+	handler, c, rec, _, _, releasefunc := setupTestForMethodGet(t)
+	defer releasefunc()
+	err := handler.AccountAssetsInformation(c, address, generatedV2.AccountAssetsInformationParams{Limit: &limit})
+	require.NoError(t, err)
+	require.Equal(t, expectedCode, rec.Code)
+	if expectedCode == 200 {
+		actualResponse := generatedV2.AccountAssetsResponse{}
+		err = protocol.DecodeJSON(rec.Body.Bytes(), &actualResponse)
+		require.NoError(t, err)
+		require.Empty(t, actualResponse.Assets)
+		require.Nil(t, actualResponse.NextToken)
+	}
+}
+
+func TestAccountAssetsInformation(t *testing.T) {
+	t.Parallel()
+
+	accountAssetsInformationTest(t, poolAddr.String(), 10, 200)
+	accountAssetsInformationTest(t, poolAddr.String(), 0, 400)
+	accountAssetsInformationTest(t, "bad account", 10, 400)
+}
+
+func accountApplicationsInformationTest(t *testing.T, address string, next string, expectedCode int) {
+	handler, c, rec, _, _, releasefunc := setupTestForMethodGet(t)
+	defer releasefunc()
+	err := handler.AccountApplicationsInformation(c, address, generatedV2.AccountApplicationsInformationParams{Next: &next})
+	require.NoError(t, err)
+	require.Equal(t, expectedCode, rec.Code)
+}
+
+func TestAccountApplicationsInformation(t *testing.T) {
+	t.Parallel()
+
+	accountApplicationsInformationTest(t, poolAddr.String(), "5", 200)
+	accountApplicationsInformationTest(t, poolAddr.String(), "five", 400)
+	accountApplicationsInformationTest(t, "bad account", "5", 400)
+}
+
+func getAssetsTest(t *testing.T, creator string, expectedCode int) {
+	handler, c, rec, _, _, releasefunc := setupTestForMethodGet(t)
+	defer releasefunc()
+	params := generatedV2.GetAssetsParams{}
+	if creator != "" {
+		params.Creator = &creator
+	}
+	err := handler.GetAssets(c, params)
+	require.NoError(t, err)
+	require.Equal(t, expectedCode, rec.Code)
+	if expectedCode == 200 {
+		actualResponse := generatedV2.AssetsResponse{}
+		err = protocol.DecodeJSON(rec.Body.Bytes(), &actualResponse)
+		require.NoError(t, err)
+		require.Empty(t, actualResponse.Assets)
+		require.Nil(t, actualResponse.NextToken)
+	}
+}
+
+func TestGetAssets(t *testing.T) {
+	t.Parallel()
+
+	getAssetsTest(t, "", 200)
+	getAssetsTest(t, poolAddr.String(), 200)
+	getAssetsTest(t, "bad account", 400)
+}
+
+func getApplicationsTest(t *testing.T, creator string, expectedCode int) {
+	handler, c, rec, _, _, releasefunc := setupTestForMethodGet(t)
+	defer releasefunc()
+	params := generatedV2.GetApplicationsParams{}
+	if creator != "" {
+		params.Creator = &creator
+	}
+	err := handler.GetApplications(c, params)
+	require.NoError(t, err)
+	require.Equal(t, expectedCode, rec.Code)
+}
+
+func TestGetApplications(t *testing.T) {
+	t.Parallel()
+
+	getApplicationsTest(t, "", 200)
+	getApplicationsTest(t, poolAddr.String(), 200)
+	getApplicationsTest(t, "bad account", 400)
+}
+
+func getApplicationBoxByNameTest(t *testing.T, appIdx uint64, name string, expectedCode int) {
+	handler, c, rec, _, _, releasefunc := setupTestForMethodGet(t)
+	defer releasefunc()
+	err := handler.GetApplicationBoxByName(c, appIdx, generatedV2.GetApplicationBoxByNameParams{Name: name})
+	require.NoError(t, err)
+	require.Equal(t, expectedCode, rec.Code)
+}
+
+func TestGetApplicationBoxByName(t *testing.T) {
+	t.Parallel()
+
+	getApplicationBoxByNameTest(t, 1, "Ym94", 404)
+	getApplicationBoxByNameTest(t, 1, "not base64!", 400)
+}
+
+func getStateDeltaTest(t *testing.T, exportRounds uint64, round uint64, format string, expectedCode int) {
+	mockLedger, _, _, _, releasefunc := testingenv(t, 1, 1, true)
+	defer releasefunc()
+	mockNode := makeMockNode(mockLedger, t.Name(), nil)
+	mockNode.config.StateDeltaExportRounds = exportRounds
+	handler := v2.Handlers{
+		Node:     mockNode,
+		Log:      logging.Base(),
+		Shutdown: make(chan struct{}),
+	}
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	err := handler.GetStateDelta(c, round, generatedV2.GetStateDeltaParams{Format: &format})
+	require.NoError(t, err)
+	require.Equal(t, expectedCode, rec.Code)
+}
+
+func TestGetStateDelta(t *testing.T) {
+	t.Parallel()
+
+	// state delta export is disabled by default
+	getStateDeltaTest(t, 0, 0, "json", 503)
+	// the genesis round has no exported delta
+	getStateDeltaTest(t, 10, 0, "json", 404)
+	getStateDeltaTest(t, 10, 0, "msgpack", 404)
+	// too far in the future to long-poll for
+	getStateDeltaTest(t, 10, 1000, "json", 404)
+	getStateDeltaTest(t, 10, 0, "bad format", 400)
+}
+
 func TestGetSupply(t *testing.T) {
 	t.Parallel()
 
@@ -262,6 +419,66 @@ func TestPostTransaction(t *testing.T) {
 	postTransactionTest(t, 0, 200)
 }
 
+func simulateTransactionTest(t *testing.T, txnToUse int, unsigned bool, skipSignatureVerification bool, format string, expectedCode int) (response generatedV2.SimulateResponse) {
+	numAccounts := 5
+	numTransactions := 5
+	offlineAccounts := true
+	mockLedger, _, _, stxns, releasefunc := testingenv(t, numAccounts, numTransactions, offlineAccounts)
+	defer releasefunc()
+	dummyShutdownChan := make(chan struct{})
+	mockNode := makeMockNode(mockLedger, t.Name(), nil)
+	handler := v2.Handlers{
+		Node:     mockNode,
+		Log:      logging.Base(),
+		Shutdown: dummyShutdownChan,
+	}
+	e := echo.New()
+	var body io.Reader
+	if txnToUse >= 0 {
+		stxn := stxns[txnToUse]
+		if unsigned {
+			stxn = transactions.SignedTxn{Txn: stxn.Txn}
+		}
+		bodyBytes := protocol.Encode(&stxn)
+		body = bytes.NewReader(bodyBytes)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/", body)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	err := handler.SimulateTransaction(c, generatedV2.SimulateTransactionParams{SkipSignatureVerification: &skipSignatureVerification, Format: &format})
+	require.NoError(t, err)
+	require.Equal(t, expectedCode, rec.Code)
+	if rec.Code == 200 && format == "json" {
+		err = protocol.DecodeJSON(rec.Body.Bytes(), &response)
+		require.NoError(t, err)
+	}
+	return
+}
+
+func TestSimulateTransaction(t *testing.T) {
+	t.Parallel()
+
+	simulateTransactionTest(t, -1, false, false, "json", 400)
+	simulateTransactionTest(t, 0, false, false, "bad format", 400)
+	simulateTransactionTest(t, 0, false, false, "msgpack", 200)
+
+	response := simulateTransactionTest(t, 0, false, false, "json", 200)
+	require.Nil(t, response.FailureMessage)
+	require.Equal(t, uint64(1), response.Round)
+	require.Len(t, response.Txns, 1)
+	require.Equal(t, []uint64{0}, response.AppCosts)
+	require.NotEmpty(t, response.Delta)
+
+	// unsigned transactions are rejected unless signature verification is skipped
+	response = simulateTransactionTest(t, 0, true, false, "json", 200)
+	require.NotNil(t, response.FailureMessage)
+	require.Empty(t, response.Txns)
+
+	response = simulateTransactionTest(t, 0, true, true, "json", 200)
+	require.Nil(t, response.FailureMessage)
+	require.Len(t, response.Txns, 1)
+}
+
 func startCatchupTest(t *testing.T, catchpoint string, nodeError error, expectedCode int) {
 	numAccounts := 1
 	numTransactions := 1
@@ -333,6 +550,104 @@ func TestAbortCatchup(t *testing.T) {
 	abortCatchupTest(t, badCatchPoint, 400)
 }
 
+func peerManagementTest(t *testing.T, nodeError error, call func(handler v2.Handlers, c echo.Context) error) *httptest.ResponseRecorder {
+	numAccounts := 1
+	numTransactions := 1
+	offlineAccounts := true
+	mockLedger, _, _, _, releasefunc := testingenv(t, numAccounts, numTransactions, offlineAccounts)
+	defer releasefunc()
+	dummyShutdownChan := make(chan struct{})
+	mockNode := makeMockNode(mockLedger, t.Name(), nodeError)
+	handler := v2.Handlers{
+		Node:     mockNode,
+		Log:      logging.Base(),
+		Shutdown: dummyShutdownChan,
+	}
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	err := call(handler, c)
+	require.NoError(t, err)
+	return rec
+}
+
+func TestGetPeers(t *testing.T) {
+	t.Parallel()
+
+	getPeers := func(handler v2.Handlers, c echo.Context) error {
+		return handler.GetPeers(c)
+	}
+	rec := peerManagementTest(t, nil, getPeers)
+	require.Equal(t, 200, rec.Code)
+	var response private.PeersResponse
+	require.NoError(t, protocol.DecodeJSON(rec.Body.Bytes(), &response))
+	require.Len(t, response.Peers, 2)
+	relay := response.Peers[0]
+	require.Equal(t, "http://relay.algorand.network:4160", relay.Address)
+	require.Equal(t, "10.0.0.1", relay.RemoteHost)
+	require.True(t, relay.Outgoing)
+	require.Equal(t, "relay", *relay.InstanceName)
+	require.Equal(t, "2.1", *relay.Version)
+	require.GreaterOrEqual(t, relay.ConnectionDuration, uint64(60))
+	require.Equal(t, uint64(50*time.Millisecond), *relay.PingRoundTripTime)
+	require.Equal(t, uint64(10), relay.MessagesReceived)
+	require.Equal(t, uint64(5), relay.MessagesSent)
+	incoming := response.Peers[1]
+	require.False(t, incoming.Outgoing)
+	require.Empty(t, incoming.Address)
+	require.Nil(t, incoming.InstanceName)
+	require.Nil(t, incoming.PingRoundTripTime)
+	require.Equal(t, uint64(1000), incoming.PriorityWeight)
+
+	rec = peerManagementTest(t, node.ErrPeerManagementUnsupported, getPeers)
+	require.Equal(t, 500, rec.Code)
+}
+
+func TestDisconnectPeer(t *testing.T) {
+	t.Parallel()
+
+	disconnect := func(address string) func(handler v2.Handlers, c echo.Context) error {
+		return func(handler v2.Handlers, c echo.Context) error {
+			return handler.DisconnectPeer(c, private.DisconnectPeerParams{Address: address})
+		}
+	}
+	require.Equal(t, 200, peerManagementTest(t, nil, disconnect("http://relay.algorand.network:4160")).Code)
+	require.Equal(t, 200, peerManagementTest(t, nil, disconnect("10.0.0.2")).Code)
+	require.Equal(t, 404, peerManagementTest(t, nil, disconnect("10.0.0.3")).Code)
+	require.Equal(t, 500, peerManagementTest(t, node.ErrPeerManagementUnsupported, disconnect("10.0.0.2")).Code)
+}
+
+func TestAddPhonebookAddress(t *testing.T) {
+	t.Parallel()
+
+	addAddress := func(handler v2.Handlers, c echo.Context) error {
+		return handler.AddPhonebookAddress(c, private.AddPhonebookAddressParams{Address: "relay.algorand.network:4160"})
+	}
+	require.Equal(t, 200, peerManagementTest(t, nil, addAddress).Code)
+	require.Equal(t, 400, peerManagementTest(t, errors.New("invalid address"), addAddress).Code)
+	require.Equal(t, 500, peerManagementTest(t, node.ErrPeerManagementUnsupported, addAddress).Code)
+}
+
+func TestBanHost(t *testing.T) {
+	t.Parallel()
+
+	ban := func(duration *uint64) func(handler v2.Handlers, c echo.Context) error {
+		return func(handler v2.Handlers, c echo.Context) error {
+			return handler.BanHost(c, "10.0.0.1", private.BanHostParams{Duration: duration})
+		}
+	}
+	zero := uint64(0)
+	day := uint64(24 * 3600)
+	tooLong := uint64(365 * 24 * 3600)
+	require.Equal(t, 200, peerManagementTest(t, nil, ban(nil)).Code)
+	require.Equal(t, 200, peerManagementTest(t, nil, ban(&day)).Code)
+	require.Equal(t, 400, peerManagementTest(t, nil, ban(&zero)).Code)
+	require.Equal(t, 400, peerManagementTest(t, nil, ban(&tooLong)).Code)
+	require.Equal(t, 400, peerManagementTest(t, errors.New("invalid IP address"), ban(nil)).Code)
+	require.Equal(t, 500, peerManagementTest(t, node.ErrPeerManagementUnsupported, ban(nil)).Code)
+}
+
 func tealCompileTest(t *testing.T, bytesToUse []byte, expectedCode int, enableDeveloperAPI bool) {
 	numAccounts := 1
 	numTransactions := 1
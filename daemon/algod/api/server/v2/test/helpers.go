@@ -34,6 +34,7 @@ import (
 	"github.com/algorand/go-algorand/data/transactions"
 	"github.com/algorand/go-algorand/ledger"
 	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/network"
 	"github.com/algorand/go-algorand/node"
 	"github.com/algorand/go-algorand/node/indexer"
 	"github.com/algorand/go-algorand/protocol"
@@ -180,6 +181,47 @@ func (m mockNode) AbortCatchup(catchpoint string) error {
 	return m.err
 }
 
+var cannedPeers = []network.PeerInfo{
+	{
+		Address:           "http://relay.algorand.network:4160",
+		RemoteHost:        "10.0.0.1",
+		Outgoing:          true,
+		InstanceName:      "relay",
+		Version:           "2.1",
+		ConnectedSince:    time.Now().Add(-time.Minute),
+		PingRoundTripTime: 50 * time.Millisecond,
+		MessagesReceived:  10,
+		MessagesSent:      5,
+	},
+	{
+		RemoteHost:       "10.0.0.2",
+		MessagesReceived: 3,
+		PrioWeight:       1000,
+	},
+}
+
+func (m mockNode) Peers() ([]network.PeerInfo, error) {
+	return cannedPeers, m.err
+}
+
+func (m mockNode) DisconnectPeer(address string) (int, error) {
+	count := 0
+	for _, peer := range cannedPeers {
+		if peer.Address == address || peer.RemoteHost == address {
+			count++
+		}
+	}
+	return count, m.err
+}
+
+func (m mockNode) AddPhonebookAddress(address string) error {
+	return m.err
+}
+
+func (m mockNode) BanHost(host string, duration time.Duration) error {
+	return m.err
+}
+
 ////// mock ledger testing environment follows
 
 var sinkAddr = basics.Address{0x7, 0xda, 0xcb, 0x4b, 0x6d, 0x9e, 0xd1, 0x41, 0xb1, 0x75, 0x76, 0xbd, 0x45, 0x9a, 0xe6, 0x42, 0x1d, 0x48, 0x6d, 0xa3, 0xd4, 0xef, 0x22, 0x47, 0xc4, 0x9, 0xa3, 0x96, 0xb8, 0x2e, 0xa2, 0x21}
@@ -18,18 +18,25 @@ package v2
 
 import (
 	"encoding/base64"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/algorand/go-codec/codec"
 	"github.com/labstack/echo/v4"
 
 	"github.com/algorand/go-algorand/daemon/algod/api/server/v2/generated"
+	"github.com/algorand/go-algorand/daemon/algod/api/server/v2/generated/private"
 	"github.com/algorand/go-algorand/data"
 	"github.com/algorand/go-algorand/data/basics"
 	"github.com/algorand/go-algorand/data/transactions"
 	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/network"
 	"github.com/algorand/go-algorand/node"
 	"github.com/algorand/go-algorand/protocol"
 )
@@ -85,6 +92,74 @@ func byteOrNil(data []byte) *[]byte {
 	return &data
 }
 
+const (
+	// defaultListLimit is the page size of listing endpoints when no limit is given
+	defaultListLimit = 100
+	// maxListLimit is the largest page size accepted by listing endpoints
+	maxListLimit = 1000
+)
+
+// pagination describes the page requested from a listing endpoint. The next
+// token returned with a page is the index of its last creatable, and the
+// following page resumes after it.
+type pagination struct {
+	limit  uint64
+	after  basics.CreatableIndex
+	resume bool
+}
+
+func parsePagination(limit *uint64, next *string) (p pagination, err error) {
+	p.limit = defaultListLimit
+	if limit != nil {
+		if *limit == 0 || *limit > maxListLimit {
+			return pagination{}, fmt.Errorf(errFailedParsingLimit, 1, maxListLimit)
+		}
+		p.limit = *limit
+	}
+	if next != nil {
+		after, err := strconv.ParseUint(*next, 10, 64)
+		if err != nil {
+			return pagination{}, errors.New(errFailedToParseNext)
+		}
+		p.after = basics.CreatableIndex(after)
+		p.resume = true
+	}
+	return p, nil
+}
+
+// ascending sorts indices in increasing order and returns the ones on the
+// requested page, along with whether more follow it.
+func (p pagination) ascending(indices []basics.CreatableIndex) ([]basics.CreatableIndex, bool) {
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	if p.resume {
+		start := sort.Search(len(indices), func(i int) bool { return indices[i] > p.after })
+		indices = indices[start:]
+	}
+	return p.truncate(indices)
+}
+
+// descending is like ascending, for pages in decreasing order.
+func (p pagination) descending(indices []basics.CreatableIndex) ([]basics.CreatableIndex, bool) {
+	sort.Slice(indices, func(i, j int) bool { return indices[i] > indices[j] })
+	if p.resume {
+		start := sort.Search(len(indices), func(i int) bool { return indices[i] < p.after })
+		indices = indices[start:]
+	}
+	return p.truncate(indices)
+}
+
+func (p pagination) truncate(indices []basics.CreatableIndex) ([]basics.CreatableIndex, bool) {
+	if uint64(len(indices)) > p.limit {
+		return indices[:p.limit], true
+	}
+	return indices, false
+}
+
+func nextToken(cidx basics.CreatableIndex) *string {
+	token := strconv.FormatUint(uint64(cidx), 10)
+	return &token
+}
+
 func computeCreatableIndexInPayset(tx node.TxnWithStatus, txnCounter uint64, payset []transactions.SignedTxnWithAD) (cidx *uint64) {
 	// Compute transaction index in block
 	offset := -1
@@ -218,6 +293,32 @@ func decode(handle codec.Handle, data []byte, v interface{}) error {
 	return nil
 }
 
+// decodeTxGroup reads a msgpack encoded transaction group of at most maxGroupSize transactions
+func decodeTxGroup(r io.Reader, maxGroupSize int) ([]transactions.SignedTxn, error) {
+	var txgroup []transactions.SignedTxn
+	dec := protocol.NewDecoder(r)
+	for {
+		var st transactions.SignedTxn
+		err := dec.Decode(&st)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		txgroup = append(txgroup, st)
+
+		if len(txgroup) > maxGroupSize {
+			return nil, fmt.Errorf("max group size is %d", maxGroupSize)
+		}
+	}
+
+	if len(txgroup) == 0 {
+		return nil, errors.New("empty txgroup")
+	}
+	return txgroup, nil
+}
+
 // Helper to convert basics.StateDelta -> *generated.StateDelta
 func stateDeltaToStateDelta(d basics.StateDelta) *generated.StateDelta {
 	if len(d) == 0 {
@@ -266,3 +367,19 @@ func convertToDeltas(txn node.TxnWithStatus) (*[]generated.AccountStateDelta, *g
 
 	return localStateDelta, stateDeltaToStateDelta(txn.ApplyData.EvalDelta.GlobalDelta)
 }
+
+// peerInfoToPeer converts the description of a connected peer to its API representation.
+func peerInfoToPeer(peer network.PeerInfo, now time.Time) private.Peer {
+	return private.Peer{
+		Address:            peer.Address,
+		RemoteHost:         peer.RemoteHost,
+		Outgoing:           peer.Outgoing,
+		InstanceName:       strOrNil(peer.InstanceName),
+		Version:            strOrNil(peer.Version),
+		ConnectionDuration: uint64(now.Sub(peer.ConnectedSince).Seconds()),
+		PingRoundTripTime:  numOrNil(uint64(peer.PingRoundTripTime.Nanoseconds())),
+		MessagesReceived:   peer.MessagesReceived,
+		MessagesSent:       peer.MessagesSent,
+		PriorityWeight:     peer.PrioWeight,
+	}
+}
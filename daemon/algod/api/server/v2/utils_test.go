@@ -0,0 +1,70 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package v2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/data/basics"
+)
+
+func TestPagination(t *testing.T) {
+	uint64Ptr := func(x uint64) *uint64 { return &x }
+	strPtr := func(s string) *string { return &s }
+
+	page, err := parsePagination(nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, pagination{limit: defaultListLimit}, page)
+
+	_, err = parsePagination(uint64Ptr(0), nil)
+	require.Error(t, err)
+	_, err = parsePagination(uint64Ptr(maxListLimit+1), nil)
+	require.Error(t, err)
+	_, err = parsePagination(nil, strPtr("abc"))
+	require.Error(t, err)
+
+	indices := func() []basics.CreatableIndex {
+		return []basics.CreatableIndex{5, 1, 9, 3, 7}
+	}
+
+	page, err = parsePagination(uint64Ptr(2), nil)
+	require.NoError(t, err)
+	selected, more := page.ascending(indices())
+	require.Equal(t, []basics.CreatableIndex{1, 3}, selected)
+	require.True(t, more)
+	selected, more = page.descending(indices())
+	require.Equal(t, []basics.CreatableIndex{9, 7}, selected)
+	require.True(t, more)
+
+	// following pages resume after the index in the next token
+	page, err = parsePagination(uint64Ptr(2), nextToken(3))
+	require.NoError(t, err)
+	selected, more = page.ascending(indices())
+	require.Equal(t, []basics.CreatableIndex{5, 7}, selected)
+	require.True(t, more)
+	selected, more = page.descending(indices())
+	require.Equal(t, []basics.CreatableIndex{1}, selected)
+	require.False(t, more)
+
+	page, err = parsePagination(uint64Ptr(2), nextToken(7))
+	require.NoError(t, err)
+	selected, more = page.ascending(indices())
+	require.Equal(t, []basics.CreatableIndex{9}, selected)
+	require.False(t, more)
+}
@@ -202,7 +202,9 @@ func (s *Server) Start() {
 
 	tcpListener := listener.(*net.TCPListener)
 
-	e := apiServer.NewRouter(s.log, s.node, s.stopping, apiToken, adminAPIToken, tcpListener)
+	scopedTokens := tokens.MakeScopedTokenStore(s.RootPath, tokens.AlgodScopedTokensFilename)
+
+	e := apiServer.NewRouter(s.log, s.node, s.stopping, apiToken, adminAPIToken, scopedTokens, tcpListener)
 
 	errChan := make(chan error, 1)
 	go func() {
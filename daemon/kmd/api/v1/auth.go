@@ -17,9 +17,11 @@
 package v1
 
 import (
+	"context"
 	"crypto/subtle"
 	"net/http"
 
+	"github.com/algorand/go-algorand/daemon/kmd/wallet"
 	"github.com/algorand/go-algorand/logging"
 	"github.com/algorand/go-algorand/util/tokens"
 )
@@ -29,7 +31,18 @@ const (
 	KMDTokenHeader = "X-KMD-API-Token"
 )
 
-func authMiddleware(log logging.Logger, apiToken string) func(http.Handler) http.Handler {
+// walletScopeKey is the request context key holding the ID of the only wallet
+// a wallet scoped API token may use
+type walletScopeKey struct{}
+
+// walletScope returns the ID of the only wallet the request may use, or "" if
+// it may use every wallet
+func walletScope(r *http.Request) string {
+	scope, _ := r.Context().Value(walletScopeKey{}).(string)
+	return scope
+}
+
+func authMiddleware(log logging.Logger, apiToken string, scopedTokens *tokens.ScopedTokenStore) func(http.Handler) http.Handler {
 	// Make sure no one is trying to call us with an invalid token
 	err := tokens.ValidateAPIToken(apiToken)
 	if err != nil {
@@ -51,8 +64,64 @@ func authMiddleware(log logging.Logger, apiToken string) func(http.Handler) http
 				return
 			}
 
+			// Check the named tokens, which are looked up by their hash
+			if scopedTokens != nil && len(providedToken) != 0 {
+				if token, ok := scopedTokens.Lookup(string(providedToken)); ok {
+					switch {
+					case token.Scope == tokens.ScopeAdmin:
+						next.ServeHTTP(w, r)
+					case token.Scope == tokens.ScopeWallet && token.Wallet != "":
+						next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), walletScopeKey{}, token.Wallet)))
+					default:
+						errorResponse(w, http.StatusForbidden, errTokenScope)
+					}
+					return
+				}
+			}
+
 			// Token was incorrect, return an error
 			errorResponse(w, http.StatusUnauthorized, errInvalidAPIToken)
 		})
 	}
 }
+
+// walletInScope returns true if the request may use the wallet with the given ID
+func (ctx reqContext) walletInScope(walletID string) bool {
+	return ctx.walletScope == "" || ctx.walletScope == walletID
+}
+
+// authWithWalletHandleToken authenticates a wallet handle token like the
+// session manager, and additionally checks that the request's API token may
+// use the wallet
+func (ctx reqContext) authWithWalletHandleToken(walletHandleToken []byte) (wallet.Wallet, int64, error) {
+	w, expiresSeconds, err := ctx.sm.AuthWithWalletHandleToken(walletHandleToken)
+	if err != nil {
+		return nil, 0, err
+	}
+	return w, expiresSeconds, ctx.checkWalletScope(w)
+}
+
+// renewWalletHandleToken renews a wallet handle token like the session
+// manager, and additionally checks that the request's API token may use the
+// wallet
+func (ctx reqContext) renewWalletHandleToken(walletHandleToken []byte) (wallet.Wallet, int64, error) {
+	w, expiresSeconds, err := ctx.sm.RenewWalletHandleToken(walletHandleToken)
+	if err != nil {
+		return nil, 0, err
+	}
+	return w, expiresSeconds, ctx.checkWalletScope(w)
+}
+
+func (ctx reqContext) checkWalletScope(w wallet.Wallet) error {
+	if ctx.walletScope == "" {
+		return nil
+	}
+	metadata, err := w.Metadata()
+	if err != nil {
+		return err
+	}
+	if !ctx.walletInScope(string(metadata.ID)) {
+		return errWalletNotInScope
+	}
+	return nil
+}
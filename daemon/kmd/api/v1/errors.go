@@ -26,3 +26,20 @@ var errCouldNotDecode = fmt.Errorf("could not decode request body")
 var errCouldNotDecodeAddress = fmt.Errorf("could not decode address")
 var errCouldNotDecodeTx = fmt.Errorf("could not decode transaction")
 var errInvalidAPIToken = fmt.Errorf("invalid API token")
+var errTokenScope = fmt.Errorf("API token scope does not allow this request")
+var errWalletNotInScope = fmt.Errorf("API token may not use this wallet")
+var errKeyPoliciesNotSupported = fmt.Errorf("wallet does not support key policies")
+var errNoKeyPolicy = fmt.Errorf("key has no signing policy")
+var errPolicyKeyExport = fmt.Errorf("keys with a signing policy can't be exported")
+var errPolicyTxType = fmt.Errorf("transaction type not allowed by the key policy")
+var errPolicyRekey = fmt.Errorf("rekeying not allowed by the key policy")
+var errPolicyClose = fmt.Errorf("closing out not allowed by the key policy")
+var errPolicyMaxAmount = fmt.Errorf("amount exceeds the key policy's limit per transaction")
+var errPolicyDailyAmount = fmt.Errorf("amount exceeds the key policy's daily limit")
+var errPolicyAssetAmount = fmt.Errorf("asset transfers not allowed by the key policy's amount limits")
+var errPolicyReceiver = fmt.Errorf("receiver not allowed by the key policy")
+var errPolicyProgram = fmt.Errorf("program signing not allowed by the key policy")
+var errCouldNotDecodeTxID = fmt.Errorf("could not decode transaction ID")
+var errCouldNotDecodeMultisig = fmt.Errorf("could not decode multisig signature")
+var errPendingMultisigNotSupported = fmt.Errorf("wallet does not support pending multisig transactions")
+var errPendingMultisigNotFound = fmt.Errorf("no pending multisig transaction with this ID")
@@ -30,12 +30,19 @@ import (
 	"github.com/algorand/go-algorand/data/transactions"
 	"github.com/algorand/go-algorand/logging"
 	"github.com/algorand/go-algorand/protocol"
+	"github.com/algorand/go-algorand/util/tokens"
 )
 
 // reqContext is passed to each of the handlers below via wrapCtx, allowing
-// handlers to interact with kmd's session store
+// handlers to interact with kmd's session store, and to log signing requests
+// to the audit log
 type reqContext struct {
-	sm *session.Manager
+	sm  *session.Manager
+	log logging.Logger
+
+	// walletScope is the ID of the only wallet the request's API token may
+	// use, or "" if it may use every wallet
+	walletScope string
 }
 
 // errorResponse sets the specified status code (should != 200), and fills in the
@@ -109,9 +116,12 @@ func getWalletsHandler(ctx reqContext, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Fill in the APIV1 representation of each wallet
+	// Fill in the APIV1 representation of each wallet the API token may use
 	var apiWallets []kmdapi.APIV1Wallet
 	for _, metadata := range walletMetadatas {
+		if !ctx.walletInScope(string(metadata.ID)) {
+			continue
+		}
 		apiWallets = append(apiWallets, apiWalletFromMetadata(metadata))
 	}
 
@@ -151,6 +161,12 @@ func postWalletHandler(ctx reqContext, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Tokens scoped to a single wallet can't add wallets
+	if ctx.walletScope != "" {
+		errorResponse(w, http.StatusForbidden, errTokenScope)
+		return
+	}
+
 	// Fetch the wallet driver
 	walletDriver, err := driver.FetchWalletDriver(req.WalletDriverName)
 	if err != nil {
@@ -232,6 +248,12 @@ func postWalletInitHandler(ctx reqContext, w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	// Make sure the API token may use the wallet
+	if !ctx.walletInScope(req.WalletID) {
+		errorResponse(w, http.StatusForbidden, errWalletNotInScope)
+		return
+	}
+
 	// Fetch the wallet
 	wallet, err := driver.FetchWalletByID([]byte(req.WalletID))
 	if err != nil {
@@ -285,7 +307,7 @@ func postWalletInfoHandler(ctx reqContext, w http.ResponseWriter, r *http.Reques
 	}
 
 	// Fetch the wallet from the WalletHandleToken
-	wallet, expiresSeconds, err := ctx.sm.AuthWithWalletHandleToken([]byte(req.WalletHandleToken))
+	wallet, expiresSeconds, err := ctx.authWithWalletHandleToken([]byte(req.WalletHandleToken))
 	if err != nil {
 		errorResponse(w, http.StatusUnauthorized, err)
 		return
@@ -319,7 +341,8 @@ func postMasterKeyExportHandler(ctx reqContext, w http.ResponseWriter, r *http.R
 	//      Export the master derivation key from the wallet. This key is a master "backup" key for
 	//      the underlying wallet. With it, you can regenerate all of the wallets that have been
 	//      generated with this wallet's `POST /v1/key` endpoint. This key will not allow you to recover
-	//      keys imported from other wallets, however.
+	//      keys imported from other wallets, however. Since the derived keys could then sign without
+	//      their signing policies, wallets holding keys with policies also need the policy password.
 	//    Produces:
 	//    - application/json
 	//    Parameters:
@@ -342,12 +365,26 @@ func postMasterKeyExportHandler(ctx reqContext, w http.ResponseWriter, r *http.R
 	}
 
 	// Fetch the wallet from the WalletHandleToken
-	wallet, _, err := ctx.sm.AuthWithWalletHandleToken([]byte(req.WalletHandleToken))
+	wallet, _, err := ctx.authWithWalletHandleToken([]byte(req.WalletHandleToken))
 	if err != nil {
 		errorResponse(w, http.StatusUnauthorized, err)
 		return
 	}
 
+	// The master derivation key would escape the key policies
+	hasPolicies, err := hasKeyPolicies(wallet)
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	if hasPolicies {
+		err = ctx.checkPolicyPassword(wallet, []byte(req.PolicyPassword))
+		if err != nil {
+			errorResponse(w, http.StatusForbidden, err)
+			return
+		}
+	}
+
 	// Export the master derivation key
 	mdk, err := wallet.ExportMasterDerivationKey([]byte(req.WalletPassword))
 	if err != nil {
@@ -364,6 +401,141 @@ func postMasterKeyExportHandler(ctx reqContext, w http.ResponseWriter, r *http.R
 	successResponse(w, resp)
 }
 
+// postWalletBackupHandler handles `POST /v1/wallet/backup`
+func postWalletBackupHandler(ctx reqContext, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /v1/wallet/backup BackupWallet
+	//---
+	//    Summary: Back up a wallet
+	//    Description: >
+	//      Export the master derivation key, the derived keys, the imported keys and the multisig
+	//      preimages of the wallet into a single archive, encrypted with the passed passphrase.
+	//      Unlike the master derivation key alone, the archive can be used to recover keys imported
+	//      from other wallets. It can be restored into a new wallet with `POST /v1/wallet/restore`.
+	//      Wallets holding keys with signing policies also need the policy password.
+	//    Produces:
+	//    - application/json
+	//    Parameters:
+	//      - name: Backup Wallet Request
+	//        in: body
+	//        required: true
+	//        schema:
+	//          "$ref": "#/definitions/BackupWalletRequest"
+	//    Responses:
+	//      "200":
+	//        "$ref": "#/responses/BackupWalletResponse"
+	var req kmdapi.APIV1POSTWalletBackupRequest
+
+	// Decode the request
+	decoder := protocol.NewJSONDecoder(r.Body)
+	err := decoder.Decode(&req)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, errCouldNotDecode)
+		return
+	}
+
+	// Fetch the wallet from the WalletHandleToken
+	wallet, _, err := ctx.authWithWalletHandleToken([]byte(req.WalletHandleToken))
+	if err != nil {
+		errorResponse(w, http.StatusUnauthorized, err)
+		return
+	}
+
+	// The backed up keys would escape the key policies
+	hasPolicies, err := hasKeyPolicies(wallet)
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	if hasPolicies {
+		err = ctx.checkPolicyPassword(wallet, []byte(req.PolicyPassword))
+		if err != nil {
+			errorResponse(w, http.StatusForbidden, err)
+			return
+		}
+	}
+
+	// Build the encrypted backup
+	backup, err := driver.ExportWalletBackup(wallet, []byte(req.WalletPassword), []byte(req.BackupPassphrase))
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Build the response
+	resp := kmdapi.APIV1POSTWalletBackupResponse{
+		Backup: backup,
+	}
+
+	// Return and encode the response
+	successResponse(w, resp)
+}
+
+// postWalletRestoreHandler handles `POST /v1/wallet/restore`
+func postWalletRestoreHandler(ctx reqContext, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /v1/wallet/restore RestoreWallet
+	//---
+	//    Summary: Restore a wallet from a backup
+	//    Description: >
+	//      Decrypt an archive produced by `POST /v1/wallet/backup` and restore its keys and multisig
+	//      preimages into a new wallet, created with the given driver and password. The name of the
+	//      backed up wallet is used if no wallet name is given.
+	//    Produces:
+	//    - application/json
+	//    Parameters:
+	//      - name: Restore Wallet Request
+	//        in: body
+	//        required: true
+	//        schema:
+	//          "$ref": "#/definitions/RestoreWalletRequest"
+	//    Responses:
+	//      "200":
+	//        "$ref": "#/responses/RestoreWalletResponse"
+	var req kmdapi.APIV1POSTWalletRestoreRequest
+
+	// Decode the request
+	decoder := protocol.NewJSONDecoder(r.Body)
+	err := decoder.Decode(&req)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, errCouldNotDecode)
+		return
+	}
+
+	// Tokens scoped to a single wallet can't add wallets
+	if ctx.walletScope != "" {
+		errorResponse(w, http.StatusForbidden, errTokenScope)
+		return
+	}
+
+	// Fetch the wallet driver
+	walletDriver, err := driver.FetchWalletDriver(req.WalletDriverName)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Restore the backup into a new wallet
+	wallet, err := driver.RestoreWalletBackup(walletDriver, req.Backup, []byte(req.BackupPassphrase), []byte(req.WalletName), []byte(req.WalletPassword))
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Fetch metadata about the wallet we just restored
+	metadata, err := wallet.Metadata()
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Build the response
+	resp := kmdapi.APIV1POSTWalletRestoreResponse{
+		Wallet: apiWalletFromMetadata(metadata),
+	}
+
+	// Return and encode the response
+	successResponse(w, resp)
+}
+
 // postWalletReleaseHandler handles `POST /v1/wallet/release`
 func postWalletReleaseHandler(ctx reqContext, w http.ResponseWriter, r *http.Request) {
 	// swagger:operation POST /v1/wallet/release ReleaseWalletHandleToken
@@ -433,7 +605,7 @@ func postWalletRenewHandler(ctx reqContext, w http.ResponseWriter, r *http.Reque
 	}
 
 	// Renew the walletHandleToken + fetch the wallet
-	wallet, expiresSeconds, err := ctx.sm.RenewWalletHandleToken([]byte(req.WalletHandleToken))
+	wallet, expiresSeconds, err := ctx.renewWalletHandleToken([]byte(req.WalletHandleToken))
 	if err != nil {
 		errorResponse(w, http.StatusUnauthorized, err)
 		return
@@ -485,6 +657,12 @@ func postWalletRenameHandler(ctx reqContext, w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	// Make sure the API token may use the wallet
+	if !ctx.walletInScope(req.WalletID) {
+		errorResponse(w, http.StatusForbidden, errWalletNotInScope)
+		return
+	}
+
 	// Fetch the wallet
 	wallet, err := driver.FetchWalletByID([]byte(req.WalletID))
 	if err != nil {
@@ -560,7 +738,7 @@ func postKeyImportHandler(ctx reqContext, w http.ResponseWriter, r *http.Request
 	}
 
 	// Fetch the wallet from the WalletHandleToken
-	wallet, _, err := ctx.sm.AuthWithWalletHandleToken([]byte(req.WalletHandleToken))
+	wallet, _, err := ctx.authWithWalletHandleToken([]byte(req.WalletHandleToken))
 	if err != nil {
 		errorResponse(w, http.StatusUnauthorized, err)
 		return
@@ -587,7 +765,9 @@ func postKeyExportHandler(ctx reqContext, w http.ResponseWriter, r *http.Request
 	// swagger:operation POST /v1/key/export ExportKey
 	//---
 	//    Summary: Export a key
-	//    Description: Export the secret key associated with the passed public key.
+	//    Description: >
+	//      Export the secret key associated with the passed public key. Keys with a signing policy
+	//      can't be exported.
 	//    Produces:
 	//    - application/json
 	//    Parameters:
@@ -617,13 +797,24 @@ func postKeyExportHandler(ctx reqContext, w http.ResponseWriter, r *http.Request
 	}
 
 	// Fetch the wallet from the WalletHandleToken
-	wallet, _, err := ctx.sm.AuthWithWalletHandleToken([]byte(req.WalletHandleToken))
+	wallet, _, err := ctx.authWithWalletHandleToken([]byte(req.WalletHandleToken))
 	if err != nil {
 		errorResponse(w, http.StatusUnauthorized, err)
 		return
 	}
 
 	// Export the key
+	// Keys with a signing policy never leave kmd, which couldn't enforce it
+	policy, err := lookupKeyPolicy(wallet, crypto.Digest(reqAddr))
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	if policy != nil {
+		errorResponse(w, http.StatusForbidden, errPolicyKeyExport)
+		return
+	}
+
 	secretKey, err := wallet.ExportKey(crypto.Digest(reqAddr), []byte(req.WalletPassword))
 	if err != nil {
 		errorResponse(w, http.StatusBadRequest, err)
@@ -669,7 +860,7 @@ func postKeyHandler(ctx reqContext, w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Fetch the wallet from the WalletHandleToken
-	wallet, _, err := ctx.sm.AuthWithWalletHandleToken([]byte(req.WalletHandleToken))
+	wallet, _, err := ctx.authWithWalletHandleToken([]byte(req.WalletHandleToken))
 	if err != nil {
 		errorResponse(w, http.StatusUnauthorized, err)
 		return
@@ -726,7 +917,7 @@ func deleteKeyHandler(ctx reqContext, w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Fetch the wallet from the WalletHandleToken
-	wallet, _, err := ctx.sm.AuthWithWalletHandleToken([]byte(req.WalletHandleToken))
+	wallet, _, err := ctx.authWithWalletHandleToken([]byte(req.WalletHandleToken))
 	if err != nil {
 		errorResponse(w, http.StatusUnauthorized, err)
 		return
@@ -774,7 +965,7 @@ func postKeyListHandler(ctx reqContext, w http.ResponseWriter, r *http.Request)
 	}
 
 	// Fetch the wallet from the WalletHandleToken
-	wallet, _, err := ctx.sm.AuthWithWalletHandleToken([]byte(req.WalletHandleToken))
+	wallet, _, err := ctx.authWithWalletHandleToken([]byte(req.WalletHandleToken))
 	if err != nil {
 		errorResponse(w, http.StatusUnauthorized, err)
 		return
@@ -796,26 +987,30 @@ func postKeyListHandler(ctx reqContext, w http.ResponseWriter, r *http.Request)
 	successResponse(w, resp)
 }
 
-// postTransactionSignHandler handles `POST /v1/transaction/sign`
-func postTransactionSignHandler(ctx reqContext, w http.ResponseWriter, r *http.Request) {
-	// swagger:operation POST /v1/transaction/sign SignTransaction
+// postKeyPolicyHandler handles `POST /v1/key/policy`
+func postKeyPolicyHandler(ctx reqContext, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /v1/key/policy SetKeyPolicy
 	//---
-	//    Summary: Sign a transaction
+	//    Summary: Set the signing policy of a key
 	//    Description: >
-	//      Signs the passed transaction with a key from the wallet, determined
-	//      by the sender encoded in the transaction.
+	//      Restrict the transactions a key in the wallet may sign: the allowed transaction types,
+	//      the maximum amount per transaction and per day, and the allowed receivers. Rekeying,
+	//      closing out accounts and signing programs are refused unless explicitly allowed.
+	//      Replaces any previous policy of the key, and requires the wallet password along with the
+	//      policy password of the wallet, which the first policy of the wallet sets. Tokens scoped to
+	//      the wallet may not set policies.
 	//    Produces:
 	//    - application/json
 	//    Parameters:
-	//      - name: Sign Transaction Request
+	//      - name: Set Key Policy Request
 	//        in: body
 	//        required: true
 	//        schema:
-	//          "$ref": "#/definitions/SignTransactionRequest"
+	//          "$ref": "#/definitions/SetKeyPolicyRequest"
 	//    Responses:
 	//      "200":
-	//        "$ref": "#/responses/SignTransactionResponse"
-	var req kmdapi.APIV1POSTTransactionSignRequest
+	//        "$ref": "#/responses/SetKeyPolicyResponse"
+	var req kmdapi.APIV1POSTKeyPolicyRequest
 
 	// Decode the request
 	decoder := protocol.NewJSONDecoder(r.Body)
@@ -825,59 +1020,65 @@ func postTransactionSignHandler(ctx reqContext, w http.ResponseWriter, r *http.R
 		return
 	}
 
-	// Fetch the wallet from the WalletHandleToken
-	wallet, _, err := ctx.sm.AuthWithWalletHandleToken([]byte(req.WalletHandleToken))
+	// Decode the address
+	reqAddr, err := basics.UnmarshalChecksumAddress(req.Address)
 	if err != nil {
-		errorResponse(w, http.StatusUnauthorized, err)
+		errorResponse(w, http.StatusBadRequest, errCouldNotDecodeAddress)
 		return
 	}
 
-	// Decode the transaction
-	var tx transactions.Transaction
-	err = protocol.Decode(req.Transaction, &tx)
+	// Decode the policy
+	policy, err := keyPolicyFromAPI(req.Policy)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, err)
+		return
+	}
 
-	// Ensure we were able to decode the transaction
+	// Fetch the wallet from the WalletHandleToken
+	wallet, _, err := ctx.authWithWalletHandleToken([]byte(req.WalletHandleToken))
 	if err != nil {
-		errorResponse(w, http.StatusBadRequest, errCouldNotDecodeTx)
+		errorResponse(w, http.StatusUnauthorized, err)
 		return
 	}
 
-	// Sign the transaction
-	stx, err := wallet.SignTransaction(tx, req.PublicKey, []byte(req.WalletPassword))
+	// Tokens scoped to a single wallet can't change its policies
+	if ctx.walletScope != "" {
+		errorResponse(w, http.StatusForbidden, errTokenScope)
+		return
+	}
+
+	// Set the policy
+	err = setKeyPolicy(wallet, crypto.Digest(reqAddr), policy, []byte(req.WalletPassword), []byte(req.PolicyPassword))
 	if err != nil {
 		errorResponse(w, http.StatusBadRequest, err)
 		return
 	}
 
 	// Build the response
-	resp := kmdapi.APIV1POSTTransactionSignResponse{
-		SignedTransaction: stx,
-	}
+	resp := kmdapi.APIV1POSTKeyPolicyResponse{}
 
 	// Return and encode the response
 	successResponse(w, resp)
 }
 
-// postProgramSignHandler handles `POST /v1/program/sign`
-func postProgramSignHandler(ctx reqContext, w http.ResponseWriter, r *http.Request) {
-	// swagger:operation POST /v1/program/sign SignProgram
+// postKeyPolicyExportHandler handles `POST /v1/key/policy/export`
+func postKeyPolicyExportHandler(ctx reqContext, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /v1/key/policy/export ExportKeyPolicy
 	//---
-	//    Summary: Sign program
-	//    Description: >
-	//      Signs the passed program with a key from the wallet, determined
-	//      by the account named in the request.
+	//    Summary: Export the signing policy of a key
+	//    Description: Returns the signing policy of a key in the wallet.
 	//    Produces:
 	//    - application/json
 	//    Parameters:
-	//      - name: Sign Program Request
+	//      - name: Export Key Policy Request
 	//        in: body
 	//        required: true
 	//        schema:
-	//          "$ref": "#/definitions/SignProgramRequest"
+	//          "$ref": "#/definitions/ExportKeyPolicyRequest"
 	//    Responses:
 	//      "200":
-	//        "$ref": "#/responses/SignProgramResponse"
-	var req kmdapi.APIV1POSTProgramSignRequest
+	//        "$ref": "#/responses/ExportKeyPolicyResponse"
+	var req kmdapi.APIV1POSTKeyPolicyExportRequest
 
 	// Decode the request
 	decoder := protocol.NewJSONDecoder(r.Body)
@@ -887,53 +1088,61 @@ func postProgramSignHandler(ctx reqContext, w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	// Fetch the wallet from the WalletHandleToken
-	wallet, _, err := ctx.sm.AuthWithWalletHandleToken([]byte(req.WalletHandleToken))
+	// Decode the address
+	reqAddr, err := basics.UnmarshalChecksumAddress(req.Address)
 	if err != nil {
-		errorResponse(w, http.StatusUnauthorized, err)
+		errorResponse(w, http.StatusBadRequest, errCouldNotDecodeAddress)
 		return
 	}
 
-	// Decode the address
-	reqAddr, err := basics.UnmarshalChecksumAddress(req.Address)
+	// Fetch the wallet from the WalletHandleToken
+	wallet, _, err := ctx.authWithWalletHandleToken([]byte(req.WalletHandleToken))
 	if err != nil {
-		errorResponse(w, http.StatusBadRequest, errCouldNotDecodeAddress)
+		errorResponse(w, http.StatusUnauthorized, err)
 		return
 	}
 
-	stx, err := wallet.SignProgram(req.Program, crypto.Digest(reqAddr), []byte(req.WalletPassword))
+	// Look up the policy
+	policy, err := lookupKeyPolicy(wallet, crypto.Digest(reqAddr))
 	if err != nil {
-		errorResponse(w, http.StatusBadRequest, err)
+		errorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	if policy == nil {
+		errorResponse(w, http.StatusBadRequest, errNoKeyPolicy)
 		return
 	}
 
 	// Build the response
-	resp := kmdapi.APIV1POSTProgramSignResponse{
-		Signature: stx,
+	resp := kmdapi.APIV1POSTKeyPolicyExportResponse{
+		Policy: apiKeyPolicy(*policy),
 	}
 
 	// Return and encode the response
 	successResponse(w, resp)
 }
 
-// postMultisigListHandler handles `POST /v1/multisig/list`
-func postMultisigListHandler(ctx reqContext, w http.ResponseWriter, r *http.Request) {
-	// swagger:operation POST /v1/multisig/list ListMultisg
+// deleteKeyPolicyHandler handles `DELETE /v1/key/policy`
+func deleteKeyPolicyHandler(ctx reqContext, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /v1/key/policy DeleteKeyPolicy
 	//---
-	//    Summary: List multisig accounts
-	//    Description: Lists all of the multisig accounts whose preimages this wallet stores
+	//    Summary: Delete the signing policy of a key
+	//    Description: >
+	//      Removes the signing policy of a key in the wallet, lifting all of its restrictions.
+	//      Requires the wallet password and the policy password of the wallet, which is forgotten
+	//      along with the last policy. Tokens scoped to the wallet may not delete policies.
 	//    Produces:
 	//    - application/json
 	//    Parameters:
-	//      - name: List Multisig Request
+	//      - name: Delete Key Policy Request
 	//        in: body
 	//        required: true
 	//        schema:
-	//          "$ref": "#/definitions/ListMultisigRequest"
+	//          "$ref": "#/definitions/DeleteKeyPolicyRequest"
 	//    Responses:
 	//      "200":
-	//        "$ref": "#/responses/ListMultisigResponse"
-	var req kmdapi.APIV1POSTMultisigListRequest
+	//        "$ref": "#/responses/DeleteKeyPolicyResponse"
+	var req kmdapi.APIV1DELETEKeyPolicyRequest
 
 	// Decode the request
 	decoder := protocol.NewJSONDecoder(r.Body)
@@ -943,49 +1152,60 @@ func postMultisigListHandler(ctx reqContext, w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	// Decode the address
+	reqAddr, err := basics.UnmarshalChecksumAddress(req.Address)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, errCouldNotDecodeAddress)
+		return
+	}
+
 	// Fetch the wallet from the WalletHandleToken
-	wallet, _, err := ctx.sm.AuthWithWalletHandleToken([]byte(req.WalletHandleToken))
+	wallet, _, err := ctx.authWithWalletHandleToken([]byte(req.WalletHandleToken))
 	if err != nil {
 		errorResponse(w, http.StatusUnauthorized, err)
 		return
 	}
 
-	// List the keys
-	addrs, err := wallet.ListMultisigAddrs()
+	// Tokens scoped to a single wallet can't change its policies
+	if ctx.walletScope != "" {
+		errorResponse(w, http.StatusForbidden, errTokenScope)
+		return
+	}
+
+	// Delete the policy
+	err = deleteKeyPolicy(wallet, crypto.Digest(reqAddr), []byte(req.WalletPassword), []byte(req.PolicyPassword))
 	if err != nil {
-		errorResponse(w, http.StatusInternalServerError, err)
+		errorResponse(w, http.StatusBadRequest, err)
 		return
 	}
 
 	// Build the response
-	resp := kmdapi.APIV1POSTMultisigListResponse{
-		Addresses: encodeAddresses(addrs),
-	}
+	resp := kmdapi.APIV1DELETEKeyPolicyResponse{}
 
 	// Return and encode the response
 	successResponse(w, resp)
 }
 
-// postMultisigImportHandler handles `POST /v1/multisig/import`
-func postMultisigImportHandler(ctx reqContext, w http.ResponseWriter, r *http.Request) {
-	// swagger:operation POST /v1/multisig/import ImportMultisig
+// postTransactionSignHandler handles `POST /v1/transaction/sign`
+func postTransactionSignHandler(ctx reqContext, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /v1/transaction/sign SignTransaction
 	//---
-	//    Summary: Import a multisig account
+	//    Summary: Sign a transaction
 	//    Description: >
-	//      Generates a multisig account from the passed public keys array and multisig
-	//      metadata, and stores all of this in the wallet.
+	//      Signs the passed transaction with a key from the wallet, determined
+	//      by the sender encoded in the transaction.
 	//    Produces:
 	//    - application/json
 	//    Parameters:
-	//      - name: Import Multisig Request
+	//      - name: Sign Transaction Request
 	//        in: body
 	//        required: true
 	//        schema:
-	//          "$ref": "#/definitions/ImportMultisigRequest"
+	//          "$ref": "#/definitions/SignTransactionRequest"
 	//    Responses:
 	//      "200":
-	//        "$ref": "#/responses/ImportMultisigResponse"
-	var req kmdapi.APIV1POSTMultisigImportRequest
+	//        "$ref": "#/responses/SignTransactionResponse"
+	var req kmdapi.APIV1POSTTransactionSignRequest
 
 	// Decode the request
 	decoder := protocol.NewJSONDecoder(r.Body)
@@ -996,29 +1216,245 @@ func postMultisigImportHandler(ctx reqContext, w http.ResponseWriter, r *http.Re
 	}
 
 	// Fetch the wallet from the WalletHandleToken
-	wallet, _, err := ctx.sm.AuthWithWalletHandleToken([]byte(req.WalletHandleToken))
+	wallet, _, err := ctx.authWithWalletHandleToken([]byte(req.WalletHandleToken))
 	if err != nil {
 		errorResponse(w, http.StatusUnauthorized, err)
 		return
 	}
 
-	// Import the key
-	addr, err := wallet.ImportMultisigAddr(req.Version, req.Threshold, req.PKs)
+	// Decode the transaction
+	var tx transactions.Transaction
+	err = protocol.Decode(req.Transaction, &tx)
+
+	// Ensure we were able to decode the transaction
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, errCouldNotDecodeTx)
+		return
+	}
+
+	// The transaction is signed with the sender's key unless a key is passed
+	signer := crypto.Digest(req.PublicKey)
+	if (req.PublicKey == crypto.PublicKey{}) {
+		signer = crypto.Digest(tx.Src())
+	}
+
+	// Enforce the signing key's policy
+	policy, err := lookupKeyPolicy(wallet, signer)
+	if err != nil {
+		ctx.auditTransaction(r, wallet, signer, tx, err)
+		errorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	err = checkTransactionPolicy(policy, tx)
+	if err != nil {
+		ctx.auditTransaction(r, wallet, signer, tx, err)
+		errorResponse(w, http.StatusForbidden, err)
+		return
+	}
+
+	// Sign the transaction
+	stx, err := wallet.SignTransaction(tx, req.PublicKey, []byte(req.WalletPassword))
 	if err != nil {
+		ctx.auditTransaction(r, wallet, signer, tx, err)
 		errorResponse(w, http.StatusBadRequest, err)
 		return
 	}
 
+	// Count the transaction against the key's daily limit, dropping the
+	// signature if it goes over
+	err = recordPolicySpending(wallet, policy, signer, tx)
+	ctx.auditTransaction(r, wallet, signer, tx, err)
+	if err != nil {
+		errorResponse(w, http.StatusForbidden, err)
+		return
+	}
+
 	// Build the response
-	resp := kmdapi.APIV1POSTMultisigImportResponse{
-		Address: encodeAddress(addr),
+	resp := kmdapi.APIV1POSTTransactionSignResponse{
+		SignedTransaction: stx,
 	}
 
 	// Return and encode the response
 	successResponse(w, resp)
 }
 
-// postMultisigExportHandler handles `POST /v1/multisig/export`
+// postProgramSignHandler handles `POST /v1/program/sign`
+func postProgramSignHandler(ctx reqContext, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /v1/program/sign SignProgram
+	//---
+	//    Summary: Sign program
+	//    Description: >
+	//      Signs the passed program with a key from the wallet, determined
+	//      by the account named in the request.
+	//    Produces:
+	//    - application/json
+	//    Parameters:
+	//      - name: Sign Program Request
+	//        in: body
+	//        required: true
+	//        schema:
+	//          "$ref": "#/definitions/SignProgramRequest"
+	//    Responses:
+	//      "200":
+	//        "$ref": "#/responses/SignProgramResponse"
+	var req kmdapi.APIV1POSTProgramSignRequest
+
+	// Decode the request
+	decoder := protocol.NewJSONDecoder(r.Body)
+	err := decoder.Decode(&req)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, errCouldNotDecode)
+		return
+	}
+
+	// Fetch the wallet from the WalletHandleToken
+	wallet, _, err := ctx.authWithWalletHandleToken([]byte(req.WalletHandleToken))
+	if err != nil {
+		errorResponse(w, http.StatusUnauthorized, err)
+		return
+	}
+
+	// Decode the address
+	reqAddr, err := basics.UnmarshalChecksumAddress(req.Address)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, errCouldNotDecodeAddress)
+		return
+	}
+
+	// Enforce the signing key's policy
+	signer := crypto.Digest(reqAddr)
+	policy, err := lookupKeyPolicy(wallet, signer)
+	if err != nil {
+		ctx.auditProgram(r, wallet, signer, req.Program, err)
+		errorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	err = checkProgramPolicy(policy)
+	if err != nil {
+		ctx.auditProgram(r, wallet, signer, req.Program, err)
+		errorResponse(w, http.StatusForbidden, err)
+		return
+	}
+
+	stx, err := wallet.SignProgram(req.Program, signer, []byte(req.WalletPassword))
+	ctx.auditProgram(r, wallet, signer, req.Program, err)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Build the response
+	resp := kmdapi.APIV1POSTProgramSignResponse{
+		Signature: stx,
+	}
+
+	// Return and encode the response
+	successResponse(w, resp)
+}
+
+// postMultisigListHandler handles `POST /v1/multisig/list`
+func postMultisigListHandler(ctx reqContext, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /v1/multisig/list ListMultisg
+	//---
+	//    Summary: List multisig accounts
+	//    Description: Lists all of the multisig accounts whose preimages this wallet stores
+	//    Produces:
+	//    - application/json
+	//    Parameters:
+	//      - name: List Multisig Request
+	//        in: body
+	//        required: true
+	//        schema:
+	//          "$ref": "#/definitions/ListMultisigRequest"
+	//    Responses:
+	//      "200":
+	//        "$ref": "#/responses/ListMultisigResponse"
+	var req kmdapi.APIV1POSTMultisigListRequest
+
+	// Decode the request
+	decoder := protocol.NewJSONDecoder(r.Body)
+	err := decoder.Decode(&req)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, errCouldNotDecode)
+		return
+	}
+
+	// Fetch the wallet from the WalletHandleToken
+	wallet, _, err := ctx.authWithWalletHandleToken([]byte(req.WalletHandleToken))
+	if err != nil {
+		errorResponse(w, http.StatusUnauthorized, err)
+		return
+	}
+
+	// List the keys
+	addrs, err := wallet.ListMultisigAddrs()
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Build the response
+	resp := kmdapi.APIV1POSTMultisigListResponse{
+		Addresses: encodeAddresses(addrs),
+	}
+
+	// Return and encode the response
+	successResponse(w, resp)
+}
+
+// postMultisigImportHandler handles `POST /v1/multisig/import`
+func postMultisigImportHandler(ctx reqContext, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /v1/multisig/import ImportMultisig
+	//---
+	//    Summary: Import a multisig account
+	//    Description: >
+	//      Generates a multisig account from the passed public keys array and multisig
+	//      metadata, and stores all of this in the wallet.
+	//    Produces:
+	//    - application/json
+	//    Parameters:
+	//      - name: Import Multisig Request
+	//        in: body
+	//        required: true
+	//        schema:
+	//          "$ref": "#/definitions/ImportMultisigRequest"
+	//    Responses:
+	//      "200":
+	//        "$ref": "#/responses/ImportMultisigResponse"
+	var req kmdapi.APIV1POSTMultisigImportRequest
+
+	// Decode the request
+	decoder := protocol.NewJSONDecoder(r.Body)
+	err := decoder.Decode(&req)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, errCouldNotDecode)
+		return
+	}
+
+	// Fetch the wallet from the WalletHandleToken
+	wallet, _, err := ctx.authWithWalletHandleToken([]byte(req.WalletHandleToken))
+	if err != nil {
+		errorResponse(w, http.StatusUnauthorized, err)
+		return
+	}
+
+	// Import the key
+	addr, err := wallet.ImportMultisigAddr(req.Version, req.Threshold, req.PKs)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Build the response
+	resp := kmdapi.APIV1POSTMultisigImportResponse{
+		Address: encodeAddress(addr),
+	}
+
+	// Return and encode the response
+	successResponse(w, resp)
+}
+
+// postMultisigExportHandler handles `POST /v1/multisig/export`
 func postMultisigExportHandler(ctx reqContext, w http.ResponseWriter, r *http.Request) {
 	// swagger:operation POST /v1/multisig/export ExportMultisig
 	//---
@@ -1056,7 +1492,7 @@ func postMultisigExportHandler(ctx reqContext, w http.ResponseWriter, r *http.Re
 	}
 
 	// Fetch the wallet from the WalletHandleToken
-	wallet, _, err := ctx.sm.AuthWithWalletHandleToken([]byte(req.WalletHandleToken))
+	wallet, _, err := ctx.authWithWalletHandleToken([]byte(req.WalletHandleToken))
 	if err != nil {
 		errorResponse(w, http.StatusUnauthorized, err)
 		return
@@ -1110,7 +1546,7 @@ func postMultisigTransactionSignHandler(ctx reqContext, w http.ResponseWriter, r
 	}
 
 	// Fetch the wallet from the WalletHandleToken
-	wallet, _, err := ctx.sm.AuthWithWalletHandleToken([]byte(req.WalletHandleToken))
+	wallet, _, err := ctx.authWithWalletHandleToken([]byte(req.WalletHandleToken))
 	if err != nil {
 		errorResponse(w, http.StatusUnauthorized, err)
 		return
@@ -1126,13 +1562,38 @@ func postMultisigTransactionSignHandler(ctx reqContext, w http.ResponseWriter, r
 		return
 	}
 
+	// Enforce the signing key's policy
+	signer := crypto.Digest(req.PublicKey)
+	policy, err := lookupKeyPolicy(wallet, signer)
+	if err != nil {
+		ctx.auditTransaction(r, wallet, signer, tx, err)
+		errorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	err = checkTransactionPolicy(policy, tx)
+	if err != nil {
+		ctx.auditTransaction(r, wallet, signer, tx, err)
+		errorResponse(w, http.StatusForbidden, err)
+		return
+	}
+
 	// Sign the transaction
 	msig, err := wallet.MultisigSignTransaction(tx, req.PublicKey, req.PartialMsig, []byte(req.WalletPassword), req.AuthAddr)
 	if err != nil {
+		ctx.auditTransaction(r, wallet, signer, tx, err)
 		errorResponse(w, http.StatusBadRequest, err)
 		return
 	}
 
+	// Count the transaction against the key's daily limit, dropping the
+	// signature if it goes over
+	err = recordPolicySpending(wallet, policy, signer, tx)
+	ctx.auditTransaction(r, wallet, signer, tx, err)
+	if err != nil {
+		errorResponse(w, http.StatusForbidden, err)
+		return
+	}
+
 	// Build the response
 	resp := kmdapi.APIV1POSTMultisigTransactionSignResponse{
 		Multisig: protocol.Encode(&msig),
@@ -1172,7 +1633,7 @@ func postMultisigProgramSignHandler(ctx reqContext, w http.ResponseWriter, r *ht
 	}
 
 	// Fetch the wallet from the WalletHandleToken
-	wallet, _, err := ctx.sm.AuthWithWalletHandleToken([]byte(req.WalletHandleToken))
+	wallet, _, err := ctx.authWithWalletHandleToken([]byte(req.WalletHandleToken))
 	if err != nil {
 		errorResponse(w, http.StatusUnauthorized, err)
 		return
@@ -1185,8 +1646,24 @@ func postMultisigProgramSignHandler(ctx reqContext, w http.ResponseWriter, r *ht
 		return
 	}
 
-	// Sign the transaction
+	// Enforce the signing key's policy
+	signer := crypto.Digest(req.PublicKey)
+	policy, err := lookupKeyPolicy(wallet, signer)
+	if err != nil {
+		ctx.auditProgram(r, wallet, signer, req.Program, err)
+		errorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	err = checkProgramPolicy(policy)
+	if err != nil {
+		ctx.auditProgram(r, wallet, signer, req.Program, err)
+		errorResponse(w, http.StatusForbidden, err)
+		return
+	}
+
+	// Sign the program
 	msig, err := wallet.MultisigSignProgram(req.Program, crypto.Digest(reqAddr), req.PublicKey, req.PartialMsig, []byte(req.WalletPassword))
+	ctx.auditProgram(r, wallet, signer, req.Program, err)
 	if err != nil {
 		errorResponse(w, http.StatusBadRequest, err)
 		return
@@ -1237,7 +1714,7 @@ func deleteMultisigHandler(ctx reqContext, w http.ResponseWriter, r *http.Reques
 	}
 
 	// Fetch the wallet from the WalletHandleToken
-	wallet, _, err := ctx.sm.AuthWithWalletHandleToken([]byte(req.WalletHandleToken))
+	wallet, _, err := ctx.authWithWalletHandleToken([]byte(req.WalletHandleToken))
 	if err != nil {
 		errorResponse(w, http.StatusUnauthorized, err)
 		return
@@ -1257,11 +1734,426 @@ func deleteMultisigHandler(ctx reqContext, w http.ResponseWriter, r *http.Reques
 	successResponse(w, resp)
 }
 
+// postMultisigPendingHandler handles `POST /v1/multisig/pending`
+func postMultisigPendingHandler(ctx reqContext, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /v1/multisig/pending CreatePendingMultisig
+	//---
+	//    Summary: Start collecting the signatures of a multisig transaction
+	//    Description: >
+	//      Stores a transaction to be signed by a multisig account whose
+	//      preimage this wallet stores, so that the co-signers can add their
+	//      subsignatures until the threshold of the account is met.
+	//    Produces:
+	//    - application/json
+	//    Parameters:
+	//      - name: Create Pending Multisig Request
+	//        in: body
+	//        required: true
+	//        schema:
+	//          "$ref": "#/definitions/CreatePendingMultisigRequest"
+	//    Responses:
+	//      "200":
+	//        "$ref": "#/responses/CreatePendingMultisigResponse"
+	var req kmdapi.APIV1POSTMultisigPendingRequest
+
+	// Decode the request
+	decoder := protocol.NewJSONDecoder(r.Body)
+	err := decoder.Decode(&req)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, errCouldNotDecode)
+		return
+	}
+
+	// Fetch the wallet from the WalletHandleToken
+	wallet, _, err := ctx.authWithWalletHandleToken([]byte(req.WalletHandleToken))
+	if err != nil {
+		errorResponse(w, http.StatusUnauthorized, err)
+		return
+	}
+
+	// Decode the transaction
+	var tx transactions.Transaction
+	err = protocol.Decode(req.Transaction, &tx)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, errCouldNotDecodeTx)
+		return
+	}
+
+	// The multisig account is the sender, unless the sender was rekeyed to it
+	addr := tx.Sender
+	if req.Address != "" {
+		addr, err = basics.UnmarshalChecksumAddress(req.Address)
+		if err != nil {
+			errorResponse(w, http.StatusBadRequest, errCouldNotDecodeAddress)
+			return
+		}
+	}
+
+	// Store the pending transaction
+	pending, err := createPendingMultisig(wallet, tx, crypto.Digest(addr))
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Build the response
+	resp := kmdapi.APIV1POSTMultisigPendingResponse{
+		Pending: apiPendingMultisig(pending),
+	}
+
+	// Return and encode the response
+	successResponse(w, resp)
+}
+
+// postMultisigPendingListHandler handles `POST /v1/multisig/pending/list`
+func postMultisigPendingListHandler(ctx reqContext, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /v1/multisig/pending/list ListPendingMultisig
+	//---
+	//    Summary: List pending multisig transactions
+	//    Description: Lists the multisig transactions whose signatures this wallet is collecting.
+	//    Produces:
+	//    - application/json
+	//    Parameters:
+	//      - name: List Pending Multisig Request
+	//        in: body
+	//        required: true
+	//        schema:
+	//          "$ref": "#/definitions/ListPendingMultisigRequest"
+	//    Responses:
+	//      "200":
+	//        "$ref": "#/responses/ListPendingMultisigResponse"
+	var req kmdapi.APIV1POSTMultisigPendingListRequest
+
+	// Decode the request
+	decoder := protocol.NewJSONDecoder(r.Body)
+	err := decoder.Decode(&req)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, errCouldNotDecode)
+		return
+	}
+
+	// Fetch the wallet from the WalletHandleToken
+	wallet, _, err := ctx.authWithWalletHandleToken([]byte(req.WalletHandleToken))
+	if err != nil {
+		errorResponse(w, http.StatusUnauthorized, err)
+		return
+	}
+
+	// List the pending transactions
+	pendingWallet, err := pendingMultisigWallet(wallet)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	pendings, err := pendingWallet.ListPendingMultisigs()
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Build the response
+	var resp kmdapi.APIV1POSTMultisigPendingListResponse
+	for _, pending := range pendings {
+		resp.Pending = append(resp.Pending, apiPendingMultisig(pending))
+	}
+
+	// Return and encode the response
+	successResponse(w, resp)
+}
+
+// postMultisigPendingExportHandler handles `POST /v1/multisig/pending/export`
+func postMultisigPendingExportHandler(ctx reqContext, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /v1/multisig/pending/export ExportPendingMultisig
+	//---
+	//    Summary: Export a pending multisig transaction
+	//    Description: >
+	//      Returns which co-signers signed a pending multisig transaction, along
+	//      with the signed transaction once the threshold is met.
+	//    Produces:
+	//    - application/json
+	//    Parameters:
+	//      - name: Export Pending Multisig Request
+	//        in: body
+	//        required: true
+	//        schema:
+	//          "$ref": "#/definitions/ExportPendingMultisigRequest"
+	//    Responses:
+	//      "200":
+	//        "$ref": "#/responses/ExportPendingMultisigResponse"
+	var req kmdapi.APIV1POSTMultisigPendingExportRequest
+
+	// Decode the request
+	decoder := protocol.NewJSONDecoder(r.Body)
+	err := decoder.Decode(&req)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, errCouldNotDecode)
+		return
+	}
+
+	// Decode the transaction ID
+	txid, err := decodeTxID(req.TxID)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Fetch the wallet from the WalletHandleToken
+	wallet, _, err := ctx.authWithWalletHandleToken([]byte(req.WalletHandleToken))
+	if err != nil {
+		errorResponse(w, http.StatusUnauthorized, err)
+		return
+	}
+
+	// Fetch the pending transaction
+	pending, err := lookupPendingMultisig(wallet, txid)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Build the response
+	resp := kmdapi.APIV1POSTMultisigPendingExportResponse{
+		Pending: apiPendingMultisig(pending),
+	}
+
+	// Return and encode the response
+	successResponse(w, resp)
+}
+
+// postMultisigPendingSignHandler handles `POST /v1/multisig/pending/sign`
+func postMultisigPendingSignHandler(ctx reqContext, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /v1/multisig/pending/sign SignPendingMultisig
+	//---
+	//    Summary: Sign a pending multisig transaction
+	//    Description: >
+	//      Signs a pending multisig transaction with a key of this wallet, and
+	//      adds the subsignature to it.
+	//    Produces:
+	//    - application/json
+	//    Parameters:
+	//      - name: Sign Pending Multisig Request
+	//        in: body
+	//        required: true
+	//        schema:
+	//          "$ref": "#/definitions/SignPendingMultisigRequest"
+	//    Responses:
+	//      "200":
+	//        "$ref": "#/responses/SignPendingMultisigResponse"
+	var req kmdapi.APIV1POSTMultisigPendingSignRequest
+
+	// Decode the request
+	decoder := protocol.NewJSONDecoder(r.Body)
+	err := decoder.Decode(&req)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, errCouldNotDecode)
+		return
+	}
+
+	// Decode the transaction ID
+	txid, err := decodeTxID(req.TxID)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Fetch the wallet from the WalletHandleToken
+	wallet, _, err := ctx.authWithWalletHandleToken([]byte(req.WalletHandleToken))
+	if err != nil {
+		errorResponse(w, http.StatusUnauthorized, err)
+		return
+	}
+
+	// Fetch the pending transaction
+	pending, err := lookupPendingMultisig(wallet, txid)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	tx := pending.Transaction
+
+	// Enforce the signing key's policy
+	signer := crypto.Digest(req.PublicKey)
+	policy, err := lookupKeyPolicy(wallet, signer)
+	if err != nil {
+		ctx.auditTransaction(r, wallet, signer, tx, err)
+		errorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	err = checkTransactionPolicy(policy, tx)
+	if err != nil {
+		ctx.auditTransaction(r, wallet, signer, tx, err)
+		errorResponse(w, http.StatusForbidden, err)
+		return
+	}
+
+	// Sign the transaction
+	msig, err := wallet.MultisigSignTransaction(tx, req.PublicKey, pending.Msig, []byte(req.WalletPassword), pending.Address)
+	if err != nil {
+		ctx.auditTransaction(r, wallet, signer, tx, err)
+		errorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Count the transaction against the key's daily limit, dropping the
+	// signature if it goes over
+	err = recordPolicySpending(wallet, policy, signer, tx)
+	ctx.auditTransaction(r, wallet, signer, tx, err)
+	if err != nil {
+		errorResponse(w, http.StatusForbidden, err)
+		return
+	}
+
+	// Add the subsignature to the pending transaction
+	pending, err = addPendingMultisigSubsigs(wallet, txid, msig)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Build the response
+	resp := kmdapi.APIV1POSTMultisigPendingSignResponse{
+		Pending: apiPendingMultisig(pending),
+	}
+
+	// Return and encode the response
+	successResponse(w, resp)
+}
+
+// postMultisigPendingSubsigHandler handles `POST /v1/multisig/pending/subsig`
+func postMultisigPendingSubsigHandler(ctx reqContext, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /v1/multisig/pending/subsig AddPendingMultisigSubsig
+	//---
+	//    Summary: Add a co-signer's subsignatures to a pending multisig transaction
+	//    Description: >
+	//      Verifies the subsignatures of a partial multisig signature, such as
+	//      one made by a co-signer's kmd with /v1/multisig/sign, and assembles
+	//      them into a pending multisig transaction.
+	//    Produces:
+	//    - application/json
+	//    Parameters:
+	//      - name: Add Pending Multisig Subsig Request
+	//        in: body
+	//        required: true
+	//        schema:
+	//          "$ref": "#/definitions/AddPendingMultisigSubsigRequest"
+	//    Responses:
+	//      "200":
+	//        "$ref": "#/responses/AddPendingMultisigSubsigResponse"
+	var req kmdapi.APIV1POSTMultisigPendingSubsigRequest
+
+	// Decode the request
+	decoder := protocol.NewJSONDecoder(r.Body)
+	err := decoder.Decode(&req)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, errCouldNotDecode)
+		return
+	}
+
+	// Decode the transaction ID
+	txid, err := decodeTxID(req.TxID)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Decode the partial multisig signature
+	var msig crypto.MultisigSig
+	err = protocol.Decode(req.Multisig, &msig)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, errCouldNotDecodeMultisig)
+		return
+	}
+
+	// Fetch the wallet from the WalletHandleToken
+	wallet, _, err := ctx.authWithWalletHandleToken([]byte(req.WalletHandleToken))
+	if err != nil {
+		errorResponse(w, http.StatusUnauthorized, err)
+		return
+	}
+
+	// Add the subsignatures to the pending transaction
+	pending, err := addPendingMultisigSubsigs(wallet, txid, msig)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Build the response
+	resp := kmdapi.APIV1POSTMultisigPendingSubsigResponse{
+		Pending: apiPendingMultisig(pending),
+	}
+
+	// Return and encode the response
+	successResponse(w, resp)
+}
+
+// deleteMultisigPendingHandler handles `DELETE /v1/multisig/pending`
+func deleteMultisigPendingHandler(ctx reqContext, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /v1/multisig/pending DeletePendingMultisig
+	//---
+	//    Summary: Delete a pending multisig transaction
+	//    Description: Stops collecting the signatures of a pending multisig transaction.
+	//    Produces:
+	//    - application/json
+	//    Parameters:
+	//      - name: Delete Pending Multisig Request
+	//        in: body
+	//        required: true
+	//        schema:
+	//          "$ref": "#/definitions/DeletePendingMultisigRequest"
+	//    Responses:
+	//      "200":
+	//        "$ref": "#/responses/DeletePendingMultisigResponse"
+	var req kmdapi.APIV1DELETEMultisigPendingRequest
+
+	// Decode the request
+	decoder := protocol.NewJSONDecoder(r.Body)
+	err := decoder.Decode(&req)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, errCouldNotDecode)
+		return
+	}
+
+	// Decode the transaction ID
+	txid, err := decodeTxID(req.TxID)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Fetch the wallet from the WalletHandleToken
+	wallet, _, err := ctx.authWithWalletHandleToken([]byte(req.WalletHandleToken))
+	if err != nil {
+		errorResponse(w, http.StatusUnauthorized, err)
+		return
+	}
+
+	// Delete the pending transaction
+	pendingWallet, err := pendingMultisigWallet(wallet)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	err = pendingWallet.DeletePendingMultisig(txid, []byte(req.WalletPassword))
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Build the response
+	resp := kmdapi.APIV1DELETEMultisigPendingResponse{}
+
+	// Return and encode the response
+	successResponse(w, resp)
+}
+
 // wrapCtx is used to pass common context to each request without using any
 // global variables.
 func wrapCtx(ctx reqContext, handler func(reqContext, http.ResponseWriter, *http.Request)) func(http.ResponseWriter, *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
-		handler(ctx, w, r)
+		reqCtx := ctx
+		reqCtx.walletScope = walletScope(r)
+		handler(reqCtx, w, r)
 	}
 }
 
@@ -1280,16 +2172,17 @@ func reqCallbackMiddleware(reqCB func()) func(http.Handler) http.Handler {
 }
 
 // RegisterHandlers sets up the API handlers on the passed router
-func RegisterHandlers(router *mux.Router, sm *session.Manager, log logging.Logger, apiToken string, reqCB func()) {
+func RegisterHandlers(router *mux.Router, sm *session.Manager, log logging.Logger, apiToken string, scopedTokens *tokens.ScopedTokenStore, reqCB func()) {
 	// All /v1 requests require a valid auth token
-	router.Use(authMiddleware(log, apiToken))
+	router.Use(authMiddleware(log, apiToken, scopedTokens))
 
 	// reqCB gets called each time a request matches a route
 	router.Use(reqCallbackMiddleware(reqCB))
 
 	// ctx holds the global context passed to each of the handlers
 	ctx := reqContext{
-		sm: sm,
+		sm:  sm,
+		log: log,
 	}
 
 	router.HandleFunc("/wallets", wrapCtx(ctx, getWalletsHandler)).Methods("GET")
@@ -1299,6 +2192,8 @@ func RegisterHandlers(router *mux.Router, sm *session.Manager, log logging.Logge
 	router.HandleFunc("/wallet/renew", wrapCtx(ctx, postWalletRenewHandler)).Methods("POST")
 	router.HandleFunc("/wallet/rename", wrapCtx(ctx, postWalletRenameHandler)).Methods("POST")
 	router.HandleFunc("/wallet/info", wrapCtx(ctx, postWalletInfoHandler)).Methods("POST")
+	router.HandleFunc("/wallet/backup", wrapCtx(ctx, postWalletBackupHandler)).Methods("POST")
+	router.HandleFunc("/wallet/restore", wrapCtx(ctx, postWalletRestoreHandler)).Methods("POST")
 	router.HandleFunc("/master-key/export", wrapCtx(ctx, postMasterKeyExportHandler)).Methods("POST")
 
 	router.HandleFunc("/key/list", wrapCtx(ctx, postKeyListHandler)).Methods("POST")
@@ -1306,6 +2201,9 @@ func RegisterHandlers(router *mux.Router, sm *session.Manager, log logging.Logge
 	router.HandleFunc("/key/export", wrapCtx(ctx, postKeyExportHandler)).Methods("POST")
 	router.HandleFunc("/key", wrapCtx(ctx, postKeyHandler)).Methods("POST")
 	router.HandleFunc("/key", wrapCtx(ctx, deleteKeyHandler)).Methods("DELETE")
+	router.HandleFunc("/key/policy", wrapCtx(ctx, postKeyPolicyHandler)).Methods("POST")
+	router.HandleFunc("/key/policy/export", wrapCtx(ctx, postKeyPolicyExportHandler)).Methods("POST")
+	router.HandleFunc("/key/policy", wrapCtx(ctx, deleteKeyPolicyHandler)).Methods("DELETE")
 
 	router.HandleFunc("/multisig/list", wrapCtx(ctx, postMultisigListHandler)).Methods("POST")
 	router.HandleFunc("/multisig/sign", wrapCtx(ctx, postMultisigTransactionSignHandler)).Methods("POST")
@@ -1313,6 +2211,12 @@ func RegisterHandlers(router *mux.Router, sm *session.Manager, log logging.Logge
 	router.HandleFunc("/multisig/import", wrapCtx(ctx, postMultisigImportHandler)).Methods("POST")
 	router.HandleFunc("/multisig/export", wrapCtx(ctx, postMultisigExportHandler)).Methods("POST")
 	router.HandleFunc("/multisig", wrapCtx(ctx, deleteMultisigHandler)).Methods("DELETE")
+	router.HandleFunc("/multisig/pending", wrapCtx(ctx, postMultisigPendingHandler)).Methods("POST")
+	router.HandleFunc("/multisig/pending/list", wrapCtx(ctx, postMultisigPendingListHandler)).Methods("POST")
+	router.HandleFunc("/multisig/pending/export", wrapCtx(ctx, postMultisigPendingExportHandler)).Methods("POST")
+	router.HandleFunc("/multisig/pending/sign", wrapCtx(ctx, postMultisigPendingSignHandler)).Methods("POST")
+	router.HandleFunc("/multisig/pending/subsig", wrapCtx(ctx, postMultisigPendingSubsigHandler)).Methods("POST")
+	router.HandleFunc("/multisig/pending", wrapCtx(ctx, deleteMultisigPendingHandler)).Methods("DELETE")
 
 	router.HandleFunc("/transaction/sign", wrapCtx(ctx, postTransactionSignHandler)).Methods("POST")
 	router.HandleFunc("/program/sign", wrapCtx(ctx, postProgramSignHandler)).Methods("POST")
@@ -0,0 +1,186 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/daemon/kmd/config"
+	"github.com/algorand/go-algorand/daemon/kmd/lib/kmdapi"
+	"github.com/algorand/go-algorand/daemon/kmd/session"
+	"github.com/algorand/go-algorand/daemon/kmd/wallet/driver"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+const (
+	testWalletID       = "policies-id"
+	testWalletPassword = "hunter2"
+	testPolicyPassword = "hunter3"
+)
+
+// makeTestWallet creates a SQLite wallet holding two keys in dir, and returns
+// a request context along with a handle token for the wallet
+func makeTestWallet(t *testing.T, dir string) (ctx reqContext, handle string, addrs []basics.Address) {
+	var cfg config.KMDConfig
+	cfg.SessionLifetimeSecs = 60
+	cfg.DriverConfig.SQLiteWalletDriverConfig = config.SQLiteWalletDriverConfig{
+		WalletsDir:   dir,
+		UnsafeScrypt: true,
+		ScryptParams: config.ScryptParams{ScryptN: 2, ScryptR: 1, ScryptP: 1},
+	}
+	var swd driver.SQLiteWalletDriver
+	require.NoError(t, swd.InitWithConfig(cfg, logging.TestingLog(t)))
+	require.NoError(t, swd.CreateWallet([]byte("policies"), []byte(testWalletID), []byte(testWalletPassword), crypto.MasterDerivationKey{}))
+	w, err := swd.FetchWallet([]byte(testWalletID))
+	require.NoError(t, err)
+
+	sm := session.MakeManager(cfg)
+	token, err := sm.InitWalletHandle(w, []byte(testWalletPassword))
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		addr, err := w.GenerateKey(false)
+		require.NoError(t, err)
+		addrs = append(addrs, basics.Address(addr))
+	}
+	return reqContext{sm: sm, log: logging.TestingLog(t)}, string(token), addrs
+}
+
+// callHandler calls a handler with the JSON encoded request, returning the
+// status code of the response
+func callHandler(ctx reqContext, handler func(reqContext, http.ResponseWriter, *http.Request), req interface{}) int {
+	r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(protocol.EncodeJSON(req)))
+	w := httptest.NewRecorder()
+	handler(ctx, w, r)
+	return w.Code
+}
+
+func TestKeyPolicyPassword(t *testing.T) {
+	dir, err := ioutil.TempDir("", "policypassword")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ctx, handle, addrs := makeTestWallet(t, dir)
+	defer ctx.sm.Kill()
+	scoped := ctx
+	scoped.walletScope = testWalletID
+
+	setReq := kmdapi.APIV1POSTKeyPolicyRequest{
+		WalletHandleToken: handle,
+		WalletPassword:    testWalletPassword,
+		PolicyPassword:    testPolicyPassword,
+		Address:           addrs[0].String(),
+		Policy:            kmdapi.APIV1KeyPolicy{MaxAmount: 1000},
+	}
+
+	// Tokens scoped to the wallet can't set policies, even with the
+	// passwords
+	require.Equal(t, http.StatusForbidden, callHandler(scoped, postKeyPolicyHandler, setReq))
+	require.Equal(t, http.StatusOK, callHandler(ctx, postKeyPolicyHandler, setReq))
+
+	// Once set, the policy can't be changed or deleted with the wallet
+	// password alone
+	lifted := setReq
+	lifted.PolicyPassword = testWalletPassword
+	lifted.Policy = kmdapi.APIV1KeyPolicy{}
+	require.Equal(t, http.StatusBadRequest, callHandler(ctx, postKeyPolicyHandler, lifted))
+	lifted.PolicyPassword = ""
+	require.Equal(t, http.StatusBadRequest, callHandler(ctx, postKeyPolicyHandler, lifted))
+
+	deleteReq := kmdapi.APIV1DELETEKeyPolicyRequest{
+		WalletHandleToken: handle,
+		WalletPassword:    testWalletPassword,
+		PolicyPassword:    testWalletPassword,
+		Address:           addrs[0].String(),
+	}
+	require.Equal(t, http.StatusBadRequest, callHandler(ctx, deleteKeyPolicyHandler, deleteReq))
+	deleteReq.PolicyPassword = testPolicyPassword
+	require.Equal(t, http.StatusForbidden, callHandler(scoped, deleteKeyPolicyHandler, deleteReq))
+	require.Equal(t, http.StatusOK, callHandler(ctx, deleteKeyPolicyHandler, deleteReq))
+}
+
+func TestKeyPolicyExport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "policyexport")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ctx, handle, addrs := makeTestWallet(t, dir)
+	defer ctx.sm.Kill()
+	scoped := ctx
+	scoped.walletScope = testWalletID
+
+	keyReq := kmdapi.APIV1POSTKeyExportRequest{
+		WalletHandleToken: handle,
+		WalletPassword:    testWalletPassword,
+		Address:           addrs[0].String(),
+	}
+	mdkReq := kmdapi.APIV1POSTMasterKeyExportRequest{
+		WalletHandleToken: handle,
+		WalletPassword:    testWalletPassword,
+	}
+	backupReq := kmdapi.APIV1POSTWalletBackupRequest{
+		WalletHandleToken: handle,
+		WalletPassword:    testWalletPassword,
+		BackupPassphrase:  "correct horse battery staple",
+	}
+
+	// Without key policies, everything can be exported with the wallet
+	// password
+	require.Equal(t, http.StatusOK, callHandler(scoped, postKeyExportHandler, keyReq))
+	require.Equal(t, http.StatusOK, callHandler(scoped, postMasterKeyExportHandler, mdkReq))
+	require.Equal(t, http.StatusOK, callHandler(scoped, postWalletBackupHandler, backupReq))
+
+	setReq := kmdapi.APIV1POSTKeyPolicyRequest{
+		WalletHandleToken: handle,
+		WalletPassword:    testWalletPassword,
+		PolicyPassword:    testPolicyPassword,
+		Address:           addrs[0].String(),
+		Policy:            kmdapi.APIV1KeyPolicy{MaxAmount: 1000},
+	}
+	require.Equal(t, http.StatusOK, callHandler(ctx, postKeyPolicyHandler, setReq))
+
+	// A key with a policy can't be exported at all, while the other key
+	// still can
+	require.Equal(t, http.StatusForbidden, callHandler(ctx, postKeyExportHandler, keyReq))
+	keyReq.Address = addrs[1].String()
+	require.Equal(t, http.StatusOK, callHandler(ctx, postKeyExportHandler, keyReq))
+
+	// The master derivation key and backups need the policy password, which
+	// tokens scoped to the wallet may not use
+	require.Equal(t, http.StatusForbidden, callHandler(ctx, postMasterKeyExportHandler, mdkReq))
+	require.Equal(t, http.StatusForbidden, callHandler(ctx, postWalletBackupHandler, backupReq))
+	mdkReq.PolicyPassword = testWalletPassword
+	backupReq.PolicyPassword = testWalletPassword
+	require.Equal(t, http.StatusForbidden, callHandler(ctx, postMasterKeyExportHandler, mdkReq))
+	require.Equal(t, http.StatusForbidden, callHandler(ctx, postWalletBackupHandler, backupReq))
+	mdkReq.PolicyPassword = testPolicyPassword
+	backupReq.PolicyPassword = testPolicyPassword
+	require.Equal(t, http.StatusForbidden, callHandler(scoped, postMasterKeyExportHandler, mdkReq))
+	require.Equal(t, http.StatusForbidden, callHandler(scoped, postWalletBackupHandler, backupReq))
+	require.Equal(t, http.StatusOK, callHandler(ctx, postMasterKeyExportHandler, mdkReq))
+	require.Equal(t, http.StatusOK, callHandler(ctx, postWalletBackupHandler, backupReq))
+}
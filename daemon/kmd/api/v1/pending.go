@@ -0,0 +1,111 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/daemon/kmd/lib/kmdapi"
+	"github.com/algorand/go-algorand/daemon/kmd/wallet"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+// pendingMultisigWallet returns the wallet as a store of pending multisig
+// transactions, if it supports them
+func pendingMultisigWallet(w wallet.Wallet) (wallet.PendingMultisigWallet, error) {
+	pendingWallet, ok := w.(wallet.PendingMultisigWallet)
+	if !ok {
+		return nil, errPendingMultisigNotSupported
+	}
+	return pendingWallet, nil
+}
+
+// decodeTxID decodes the API representation of a transaction ID
+func decodeTxID(txidStr string) (txid transactions.Txid, err error) {
+	err = txid.UnmarshalText([]byte(txidStr))
+	if err != nil {
+		err = errCouldNotDecodeTxID
+	}
+	return
+}
+
+// createPendingMultisig starts collecting the subsignatures of the multisig
+// account addr for tx. The wallet must hold the preimage of the account.
+func createPendingMultisig(w wallet.Wallet, tx transactions.Transaction, addr crypto.Digest) (pending wallet.PendingMultisig, err error) {
+	pendingWallet, err := pendingMultisigWallet(w)
+	if err != nil {
+		return
+	}
+
+	version, threshold, pks, err := w.LookupMultisigPreimage(addr)
+	if err != nil {
+		return
+	}
+
+	pending, err = wallet.MakePendingMultisig(tx, version, threshold, pks)
+	if err != nil {
+		return
+	}
+
+	err = pendingWallet.AddPendingMultisig(pending)
+	return
+}
+
+// lookupPendingMultisig fetches a pending multisig transaction by its ID
+func lookupPendingMultisig(w wallet.Wallet, txid transactions.Txid) (pending wallet.PendingMultisig, err error) {
+	pendingWallet, err := pendingMultisigWallet(w)
+	if err != nil {
+		return
+	}
+
+	pending, found, err := pendingWallet.LookupPendingMultisig(txid)
+	if err == nil && !found {
+		err = errPendingMultisigNotFound
+	}
+	return
+}
+
+// addPendingMultisigSubsigs assembles the subsignatures of msig into a pending
+// multisig transaction
+func addPendingMultisigSubsigs(w wallet.Wallet, txid transactions.Txid, msig crypto.MultisigSig) (pending wallet.PendingMultisig, err error) {
+	pendingWallet, err := pendingMultisigWallet(w)
+	if err != nil {
+		return
+	}
+	return pendingWallet.AddPendingMultisigSubsigs(txid, msig)
+}
+
+// apiPendingMultisig converts a pending multisig transaction into its APIV1
+// representation, including the signed transaction once it is complete
+func apiPendingMultisig(pending wallet.PendingMultisig) kmdapi.APIV1PendingMultisig {
+	signed, missing := pending.Signers()
+	apiPending := kmdapi.APIV1PendingMultisig{
+		TxID:        pending.ID().String(),
+		Transaction: protocol.Encode(&pending.Transaction),
+		Address:     basics.Address(pending.Address).GetUserAddress(),
+		Threshold:   pending.Msig.Threshold,
+		Signed:      signed,
+		Missing:     missing,
+		Multisig:    protocol.Encode(&pending.Msig),
+	}
+	if stxn, ok := pending.SignedTxn(); ok {
+		apiPending.Complete = true
+		apiPending.SignedTransaction = protocol.Encode(&stxn)
+	}
+	return apiPending
+}
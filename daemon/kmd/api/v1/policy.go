@@ -0,0 +1,260 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/daemon/kmd/lib/kmdapi"
+	"github.com/algorand/go-algorand/daemon/kmd/wallet"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/data/transactions/logic"
+	"github.com/algorand/go-algorand/logging"
+)
+
+// secondsPerDay is the length of the window of daily spending limits
+const secondsPerDay = 24 * 60 * 60
+
+// keyPolicyFromAPI converts the APIV1 representation of a key policy into our
+// internal format
+func keyPolicyFromAPI(apiPolicy kmdapi.APIV1KeyPolicy) (policy wallet.KeyPolicy, err error) {
+	policy = wallet.KeyPolicy{
+		TxTypes:             apiPolicy.TxTypes,
+		MaxAmount:           apiPolicy.MaxAmount,
+		MaxDailyAmount:      apiPolicy.MaxDailyAmount,
+		AllowRekey:          apiPolicy.AllowRekey,
+		AllowClose:          apiPolicy.AllowClose,
+		AllowProgramSigning: apiPolicy.AllowProgramSigning,
+	}
+	for _, receiver := range apiPolicy.Receivers {
+		addr, err := basics.UnmarshalChecksumAddress(receiver)
+		if err != nil {
+			return wallet.KeyPolicy{}, errCouldNotDecodeAddress
+		}
+		policy.Receivers = append(policy.Receivers, addr)
+	}
+	return policy, nil
+}
+
+// apiKeyPolicy converts our internal key policy format into its APIV1
+// representation
+func apiKeyPolicy(policy wallet.KeyPolicy) kmdapi.APIV1KeyPolicy {
+	apiPolicy := kmdapi.APIV1KeyPolicy{
+		TxTypes:             policy.TxTypes,
+		MaxAmount:           policy.MaxAmount,
+		MaxDailyAmount:      policy.MaxDailyAmount,
+		AllowRekey:          policy.AllowRekey,
+		AllowClose:          policy.AllowClose,
+		AllowProgramSigning: policy.AllowProgramSigning,
+	}
+	for _, receiver := range policy.Receivers {
+		apiPolicy.Receivers = append(apiPolicy.Receivers, receiver.GetUserAddress())
+	}
+	return apiPolicy
+}
+
+// setKeyPolicy sets the signing policy of a key, if the wallet supports key
+// policies
+func setKeyPolicy(w wallet.Wallet, addr crypto.Digest, policy wallet.KeyPolicy, pw []byte, policyPw []byte) error {
+	policyWallet, ok := w.(wallet.PolicyWallet)
+	if !ok {
+		return errKeyPoliciesNotSupported
+	}
+	return policyWallet.SetKeyPolicy(addr, policy, pw, policyPw)
+}
+
+// deleteKeyPolicy removes the signing policy of a key, if the wallet supports
+// key policies
+func deleteKeyPolicy(w wallet.Wallet, addr crypto.Digest, pw []byte, policyPw []byte) error {
+	policyWallet, ok := w.(wallet.PolicyWallet)
+	if !ok {
+		return errKeyPoliciesNotSupported
+	}
+	return policyWallet.DeleteKeyPolicy(addr, pw, policyPw)
+}
+
+// hasKeyPolicies returns true if any key of the wallet has a signing policy
+func hasKeyPolicies(w wallet.Wallet) (bool, error) {
+	policyWallet, ok := w.(wallet.PolicyWallet)
+	if !ok {
+		return false, nil
+	}
+	return policyWallet.HasKeyPolicies()
+}
+
+// checkPolicyPassword checks the policy password of a wallet with key
+// policies, which tokens scoped to the wallet may not use
+func (ctx reqContext) checkPolicyPassword(w wallet.Wallet, policyPw []byte) error {
+	if ctx.walletScope != "" {
+		return errTokenScope
+	}
+	policyWallet, ok := w.(wallet.PolicyWallet)
+	if !ok {
+		return errKeyPoliciesNotSupported
+	}
+	return policyWallet.CheckPolicyPassword(policyPw)
+}
+
+// lookupKeyPolicy returns the signing policy of a key, or nil if the key has
+// none or the wallet doesn't support key policies
+func lookupKeyPolicy(w wallet.Wallet, addr crypto.Digest) (*wallet.KeyPolicy, error) {
+	policyWallet, ok := w.(wallet.PolicyWallet)
+	if !ok {
+		return nil, nil
+	}
+	policy, found, err := policyWallet.LookupKeyPolicy(addr)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// checkTransactionPolicy checks a transaction against the signing key's
+// policy. Daily limits are checked by recordPolicySpending once the
+// transaction is signed.
+func checkTransactionPolicy(policy *wallet.KeyPolicy, tx transactions.Transaction) error {
+	if policy == nil {
+		return nil
+	}
+
+	if len(policy.TxTypes) > 0 {
+		allowed := false
+		for _, txType := range policy.TxTypes {
+			if tx.Type == txType {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return errPolicyTxType
+		}
+	}
+
+	if !tx.RekeyTo.IsZero() && !policy.AllowRekey {
+		return errPolicyRekey
+	}
+	if (!tx.CloseRemainderTo.IsZero() || !tx.AssetCloseTo.IsZero()) && !policy.AllowClose {
+		return errPolicyClose
+	}
+
+	if policy.MaxAmount != 0 || policy.MaxDailyAmount != 0 {
+		// The amount limits are in Algos, and we have no way to tell what
+		// units of an asset are worth
+		if tx.AssetAmount != 0 {
+			return errPolicyAssetAmount
+		}
+	}
+	if policy.MaxAmount != 0 && policySpending(tx) > policy.MaxAmount {
+		return errPolicyMaxAmount
+	}
+
+	if len(policy.Receivers) > 0 {
+		// Every account receiving funds must be allowed, including the
+		// ones closed out to
+		for _, receiver := range []basics.Address{tx.Receiver, tx.CloseRemainderTo, tx.AssetReceiver, tx.AssetCloseTo} {
+			if receiver.IsZero() {
+				continue
+			}
+			allowed := false
+			for _, addr := range policy.Receivers {
+				if receiver == addr {
+					allowed = true
+					break
+				}
+			}
+			if !allowed {
+				return errPolicyReceiver
+			}
+		}
+	}
+
+	return nil
+}
+
+// policySpending returns the Algos a transaction spends, counted against the
+// amount limits of a key policy: the amount sent along with the fee
+func policySpending(tx transactions.Transaction) uint64 {
+	return basics.AddSaturate(tx.Amount.Raw, tx.Fee.Raw)
+}
+
+// checkProgramPolicy checks that the signing key's policy allows signing
+// programs. A signed program can spend on behalf of the key without going
+// through kmd, so it bypasses every other restriction of the policy.
+func checkProgramPolicy(policy *wallet.KeyPolicy) error {
+	if policy != nil && !policy.AllowProgramSigning {
+		return errPolicyProgram
+	}
+	return nil
+}
+
+// recordPolicySpending counts the amount of a signed transaction against the
+// daily limit of the signing key. The signature must be discarded if it fails,
+// so that it never leaves kmd.
+func recordPolicySpending(w wallet.Wallet, policy *wallet.KeyPolicy, addr crypto.Digest, tx transactions.Transaction) error {
+	if policy == nil || policy.MaxDailyAmount == 0 {
+		return nil
+	}
+
+	// lookupKeyPolicy only finds policies in a PolicyWallet
+	policyWallet := w.(wallet.PolicyWallet)
+	day := uint64(time.Now().Unix() / secondsPerDay)
+	ok, err := policyWallet.AddDailySpending(addr, day, policySpending(tx), policy.MaxDailyAmount)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errPolicyDailyAmount
+	}
+	return nil
+}
+
+// auditTransaction adds a transaction signing request to the audit log
+func (ctx reqContext) auditTransaction(r *http.Request, w wallet.Wallet, signer crypto.Digest, tx transactions.Transaction, err error) {
+	ctx.auditSigning(r, w, signer, logging.Fields{
+		"txid":   tx.ID().String(),
+		"txtype": string(tx.Type),
+		"sender": tx.Sender.String(),
+		"amount": tx.Amount.Raw,
+	}, err)
+}
+
+// auditProgram adds a program signing request to the audit log
+func (ctx reqContext) auditProgram(r *http.Request, w wallet.Wallet, signer crypto.Digest, program []byte, err error) {
+	ctx.auditSigning(r, w, signer, logging.Fields{
+		"program": crypto.HashObj(logic.Program(program)).String(),
+	}, err)
+}
+
+// auditSigning logs every signing request along with its outcome, so that
+// the use of each key can be reviewed later
+func (ctx reqContext) auditSigning(r *http.Request, w wallet.Wallet, signer crypto.Digest, fields logging.Fields, err error) {
+	fields["endpoint"] = r.URL.Path
+	fields["signer"] = basics.Address(signer).String()
+	fields["signed"] = err == nil
+	if metadata, merr := w.Metadata(); merr == nil {
+		fields["wallet"] = string(metadata.ID)
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+		ctx.log.WithFields(fields).Warn("kmd audit: signing request refused")
+		return
+	}
+	ctx.log.WithFields(fields).Info("kmd audit: signing request")
+}
@@ -102,6 +102,12 @@ func getPathAndMethod(req kmdapi.APIV1Request) (reqPath string, reqMethod string
 	case kmdapi.APIV1POSTMasterKeyExportRequest:
 		reqPath = "v1/master-key/export"
 		reqMethod = "POST"
+	case kmdapi.APIV1POSTWalletBackupRequest:
+		reqPath = "v1/wallet/backup"
+		reqMethod = "POST"
+	case kmdapi.APIV1POSTWalletRestoreRequest:
+		reqPath = "v1/wallet/restore"
+		reqMethod = "POST"
 	case kmdapi.APIV1POSTKeyImportRequest:
 		reqPath = "v1/key/import"
 		reqMethod = "POST"
@@ -120,6 +126,15 @@ func getPathAndMethod(req kmdapi.APIV1Request) (reqPath string, reqMethod string
 	case kmdapi.APIV1POSTProgramSignRequest:
 		reqPath = "v1/program/sign"
 		reqMethod = "POST"
+	case kmdapi.APIV1POSTKeyPolicyRequest:
+		reqPath = "v1/key/policy"
+		reqMethod = "POST"
+	case kmdapi.APIV1POSTKeyPolicyExportRequest:
+		reqPath = "v1/key/policy/export"
+		reqMethod = "POST"
+	case kmdapi.APIV1DELETEKeyPolicyRequest:
+		reqPath = "v1/key/policy"
+		reqMethod = "DELETE"
 	case kmdapi.APIV1POSTTransactionSignRequest:
 		reqPath = "v1/transaction/sign"
 		reqMethod = "POST"
@@ -141,6 +156,24 @@ func getPathAndMethod(req kmdapi.APIV1Request) (reqPath string, reqMethod string
 	case kmdapi.APIV1DELETEMultisigRequest:
 		reqPath = "v1/multisig"
 		reqMethod = "DELETE"
+	case kmdapi.APIV1POSTMultisigPendingRequest:
+		reqPath = "v1/multisig/pending"
+		reqMethod = "POST"
+	case kmdapi.APIV1POSTMultisigPendingListRequest:
+		reqPath = "v1/multisig/pending/list"
+		reqMethod = "POST"
+	case kmdapi.APIV1POSTMultisigPendingExportRequest:
+		reqPath = "v1/multisig/pending/export"
+		reqMethod = "POST"
+	case kmdapi.APIV1POSTMultisigPendingSignRequest:
+		reqPath = "v1/multisig/pending/sign"
+		reqMethod = "POST"
+	case kmdapi.APIV1POSTMultisigPendingSubsigRequest:
+		reqPath = "v1/multisig/pending/subsig"
+		reqMethod = "POST"
+	case kmdapi.APIV1DELETEMultisigPendingRequest:
+		reqPath = "v1/multisig/pending"
+		reqMethod = "DELETE"
 	}
 	return
 }
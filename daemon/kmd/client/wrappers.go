@@ -98,6 +98,41 @@ func (kcl KMDClient) DeleteKey(walletHandle []byte, pw []byte, addr string) (res
 	return
 }
 
+// SetKeyPolicy wraps kmdapi.APIV1POSTKeyPolicyRequest
+func (kcl KMDClient) SetKeyPolicy(walletHandle []byte, pw []byte, policyPw []byte, addr string, policy kmdapi.APIV1KeyPolicy) (resp kmdapi.APIV1POSTKeyPolicyResponse, err error) {
+	req := kmdapi.APIV1POSTKeyPolicyRequest{
+		WalletHandleToken: string(walletHandle),
+		WalletPassword:    string(pw),
+		PolicyPassword:    string(policyPw),
+		Address:           addr,
+		Policy:            policy,
+	}
+	err = kcl.DoV1Request(req, &resp)
+	return
+}
+
+// ExportKeyPolicy wraps kmdapi.APIV1POSTKeyPolicyExportRequest
+func (kcl KMDClient) ExportKeyPolicy(walletHandle []byte, addr string) (resp kmdapi.APIV1POSTKeyPolicyExportResponse, err error) {
+	req := kmdapi.APIV1POSTKeyPolicyExportRequest{
+		WalletHandleToken: string(walletHandle),
+		Address:           addr,
+	}
+	err = kcl.DoV1Request(req, &resp)
+	return
+}
+
+// DeleteKeyPolicy wraps kmdapi.APIV1DELETEKeyPolicyRequest
+func (kcl KMDClient) DeleteKeyPolicy(walletHandle []byte, pw []byte, policyPw []byte, addr string) (resp kmdapi.APIV1DELETEKeyPolicyResponse, err error) {
+	req := kmdapi.APIV1DELETEKeyPolicyRequest{
+		WalletHandleToken: string(walletHandle),
+		WalletPassword:    string(pw),
+		PolicyPassword:    string(policyPw),
+		Address:           addr,
+	}
+	err = kcl.DoV1Request(req, &resp)
+	return
+}
+
 // ListMultisigAddrs wraps kmdapi.APIV1POSTMultisigListRequest
 func (kcl KMDClient) ListMultisigAddrs(walletHandle []byte) (resp kmdapi.APIV1POSTMultisigListResponse, err error) {
 	req := kmdapi.APIV1POSTMultisigListRequest{
@@ -168,6 +203,70 @@ func (kcl KMDClient) MultisigSignProgram(walletHandle, pw []byte, addr string, d
 	return
 }
 
+// CreatePendingMultisig wraps kmdapi.APIV1POSTMultisigPendingRequest
+func (kcl KMDClient) CreatePendingMultisig(walletHandle []byte, tx []byte, addr string) (resp kmdapi.APIV1POSTMultisigPendingResponse, err error) {
+	req := kmdapi.APIV1POSTMultisigPendingRequest{
+		WalletHandleToken: string(walletHandle),
+		Transaction:       tx,
+		Address:           addr,
+	}
+	err = kcl.DoV1Request(req, &resp)
+	return
+}
+
+// ListPendingMultisigs wraps kmdapi.APIV1POSTMultisigPendingListRequest
+func (kcl KMDClient) ListPendingMultisigs(walletHandle []byte) (resp kmdapi.APIV1POSTMultisigPendingListResponse, err error) {
+	req := kmdapi.APIV1POSTMultisigPendingListRequest{
+		WalletHandleToken: string(walletHandle),
+	}
+	err = kcl.DoV1Request(req, &resp)
+	return
+}
+
+// ExportPendingMultisig wraps kmdapi.APIV1POSTMultisigPendingExportRequest
+func (kcl KMDClient) ExportPendingMultisig(walletHandle []byte, txid string) (resp kmdapi.APIV1POSTMultisigPendingExportResponse, err error) {
+	req := kmdapi.APIV1POSTMultisigPendingExportRequest{
+		WalletHandleToken: string(walletHandle),
+		TxID:              txid,
+	}
+	err = kcl.DoV1Request(req, &resp)
+	return
+}
+
+// SignPendingMultisig wraps kmdapi.APIV1POSTMultisigPendingSignRequest
+func (kcl KMDClient) SignPendingMultisig(walletHandle, pw []byte, txid string, pk crypto.PublicKey) (resp kmdapi.APIV1POSTMultisigPendingSignResponse, err error) {
+	req := kmdapi.APIV1POSTMultisigPendingSignRequest{
+		WalletHandleToken: string(walletHandle),
+		WalletPassword:    string(pw),
+		TxID:              txid,
+		PublicKey:         pk,
+	}
+	err = kcl.DoV1Request(req, &resp)
+	return
+}
+
+// AddPendingMultisigSubsig wraps kmdapi.APIV1POSTMultisigPendingSubsigRequest
+func (kcl KMDClient) AddPendingMultisigSubsig(walletHandle []byte, txid string, msig []byte) (resp kmdapi.APIV1POSTMultisigPendingSubsigResponse, err error) {
+	req := kmdapi.APIV1POSTMultisigPendingSubsigRequest{
+		WalletHandleToken: string(walletHandle),
+		TxID:              txid,
+		Multisig:          msig,
+	}
+	err = kcl.DoV1Request(req, &resp)
+	return
+}
+
+// DeletePendingMultisig wraps kmdapi.APIV1DELETEMultisigPendingRequest
+func (kcl KMDClient) DeletePendingMultisig(walletHandle, pw []byte, txid string) (resp kmdapi.APIV1DELETEMultisigPendingResponse, err error) {
+	req := kmdapi.APIV1DELETEMultisigPendingRequest{
+		WalletHandleToken: string(walletHandle),
+		WalletPassword:    string(pw),
+		TxID:              txid,
+	}
+	err = kcl.DoV1Request(req, &resp)
+	return
+}
+
 // RenewWalletHandle wraps kmdapi.APIV1POSTKeyListRequest
 func (kcl KMDClient) RenewWalletHandle(walletHandle []byte) (resp kmdapi.APIV1POSTWalletRenewResponse, err error) {
 	req := kmdapi.APIV1POSTWalletRenewRequest{
@@ -197,6 +296,31 @@ func (kcl KMDClient) ExportMasterDerivationKey(walletHandle []byte, walletPasswo
 	return
 }
 
+// BackupWallet wraps kmdapi.APIV1POSTWalletBackupRequest
+func (kcl KMDClient) BackupWallet(walletHandle []byte, walletPassword []byte, policyPassword []byte, passphrase []byte) (resp kmdapi.APIV1POSTWalletBackupResponse, err error) {
+	req := kmdapi.APIV1POSTWalletBackupRequest{
+		WalletHandleToken: string(walletHandle),
+		WalletPassword:    string(walletPassword),
+		PolicyPassword:    string(policyPassword),
+		BackupPassphrase:  string(passphrase),
+	}
+	err = kcl.DoV1Request(req, &resp)
+	return
+}
+
+// RestoreWallet wraps kmdapi.APIV1POSTWalletRestoreRequest
+func (kcl KMDClient) RestoreWallet(walletName []byte, walletDriverName string, walletPassword []byte, backup []byte, passphrase []byte) (resp kmdapi.APIV1POSTWalletRestoreResponse, err error) {
+	req := kmdapi.APIV1POSTWalletRestoreRequest{
+		WalletName:       string(walletName),
+		WalletDriverName: walletDriverName,
+		WalletPassword:   string(walletPassword),
+		Backup:           backup,
+		BackupPassphrase: string(passphrase),
+	}
+	err = kcl.DoV1Request(req, &resp)
+	return
+}
+
 // SignTransaction wraps kmdapi.APIV1POSTTransactionSignRequest
 func (kcl KMDClient) SignTransaction(walletHandle, pw []byte, pk crypto.PublicKey, tx transactions.Transaction) (resp kmdapi.APIV1POSTTransactionSignResponse, err error) {
 	txBytes := protocol.Encode(&tx)
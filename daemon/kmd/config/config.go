@@ -19,8 +19,12 @@ package config
 import (
 	"encoding/json"
 	"io/ioutil"
+	"net"
+	"net/url"
 	"path/filepath"
 
+	"golang.org/x/crypto/bcrypt"
+
 	"github.com/algorand/go-algorand/util/codecs"
 )
 
@@ -44,7 +48,8 @@ type KMDConfig struct {
 
 // DriverConfig contains config info specific to each wallet driver
 type DriverConfig struct {
-	SQLiteWalletDriverConfig SQLiteWalletDriverConfig `json:"sqlite"`
+	SQLiteWalletDriverConfig       SQLiteWalletDriverConfig       `json:"sqlite"`
+	RemoteSignerWalletDriverConfig RemoteSignerWalletDriverConfig `json:"remote"`
 }
 
 // SQLiteWalletDriverConfig is configuration specific to the SQLiteWalletDriver
@@ -54,6 +59,25 @@ type SQLiteWalletDriverConfig struct {
 	ScryptParams ScryptParams `json:"scrypt"`
 }
 
+// RemoteSignerWalletDriverConfig is configuration specific to the
+// RemoteSignerWalletDriver
+type RemoteSignerWalletDriverConfig struct {
+	Signers []RemoteSignerConfig `json:"signers"`
+}
+
+// RemoteSignerConfig describes a remote signing service, which is exposed as a
+// wallet holding the keys of the service. PasswordHash is the bcrypt hash of
+// the wallet password, which kmd clients must present to use the wallet. The
+// URL must use https, unless it points to a loopback address.
+type RemoteSignerConfig struct {
+	WalletName   string `json:"wallet_name"`
+	URL          string `json:"url"`
+	TokenFile    string `json:"token_file"`
+	CACertFile   string `json:"ca_cert_file"`
+	PasswordHash string `json:"password_hash"`
+	TimeoutSecs  uint64 `json:"timeout_secs"`
+}
+
 // ScryptParams stores the parameters used for key derivation. This allows
 // upgrading security parameters over time
 type ScryptParams struct {
@@ -89,9 +113,57 @@ func (k KMDConfig) Validate() error {
 			return ErrSQLiteWalletNotAbsolute
 		}
 	}
+
+	// Each remote signer needs a unique wallet name, a secure URL, a password
+	// hash and an absolute token file path
+	walletNames := make(map[string]bool)
+	for _, signer := range k.DriverConfig.RemoteSignerWalletDriverConfig.Signers {
+		if signer.WalletName == "" || walletNames[signer.WalletName] {
+			return ErrRemoteSignerWalletName
+		}
+		walletNames[signer.WalletName] = true
+		if signer.URL == "" {
+			return ErrRemoteSignerURLRequired
+		}
+		if !remoteSignerURLSecure(signer.URL) {
+			return ErrRemoteSignerURLInsecure
+		}
+		if _, err := bcrypt.Cost([]byte(signer.PasswordHash)); err != nil {
+			return ErrRemoteSignerPasswordHash
+		}
+		if !filepath.IsAbs(signer.TokenFile) {
+			return ErrRemoteSignerTokenNotAbsolute
+		}
+		if signer.CACertFile != "" && !filepath.IsAbs(signer.CACertFile) {
+			return ErrRemoteSignerCACertNotAbsolute
+		}
+	}
 	return nil
 }
 
+// remoteSignerURLSecure returns true if the URL uses https, or if it points to
+// a loopback address, where the token and the transactions never leave the
+// host
+func remoteSignerURLSecure(signerURL string) bool {
+	u, err := url.Parse(signerURL)
+	if err != nil {
+		return false
+	}
+	switch u.Scheme {
+	case "https":
+		return true
+	case "http":
+		host := u.Hostname()
+		if host == "localhost" {
+			return true
+		}
+		ip := net.ParseIP(host)
+		return ip != nil && ip.IsLoopback()
+	default:
+		return false
+	}
+}
+
 // LoadKMDConfig tries to read the the kmd configuration from disk, merging the
 // default kmd configuration with what it finds
 func LoadKMDConfig(dataDir string) (cfg KMDConfig, err error) {
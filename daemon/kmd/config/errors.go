@@ -22,3 +22,21 @@ import (
 
 // ErrSQLiteWalletNotAbsolute is returned when the passed sqlite wallet directory is relative
 var ErrSQLiteWalletNotAbsolute = fmt.Errorf("sqlite wallets path must be absolute path")
+
+// ErrRemoteSignerWalletName is returned when a remote signer has an empty or a duplicate wallet name
+var ErrRemoteSignerWalletName = fmt.Errorf("remote signers must have unique, non-empty wallet names")
+
+// ErrRemoteSignerURLRequired is returned when a remote signer has no URL
+var ErrRemoteSignerURLRequired = fmt.Errorf("remote signers must have a url")
+
+// ErrRemoteSignerURLInsecure is returned when a remote signer URL doesn't use https and isn't a loopback address
+var ErrRemoteSignerURLInsecure = fmt.Errorf("remote signer url must use https, unless it is a loopback address")
+
+// ErrRemoteSignerPasswordHash is returned when a remote signer has no valid bcrypt password hash
+var ErrRemoteSignerPasswordHash = fmt.Errorf("remote signers must have a bcrypt password hash")
+
+// ErrRemoteSignerTokenNotAbsolute is returned when the token file of a remote signer is not an absolute path
+var ErrRemoteSignerTokenNotAbsolute = fmt.Errorf("remote signer token file must be absolute path")
+
+// ErrRemoteSignerCACertNotAbsolute is returned when the CA certificate file of a remote signer is relative
+var ErrRemoteSignerCACertNotAbsolute = fmt.Errorf("remote signer CA certificate file must be absolute path")
@@ -53,3 +53,36 @@ type APIV1WalletHandle struct {
 	Wallet         APIV1Wallet `json:"wallet"`
 	ExpiresSeconds int64       `json:"expires_seconds"`
 }
+
+// APIV1KeyPolicy is the API's representation of the signing policy of a key.
+// Empty lists and zero amounts don't restrict anything, while rekeying,
+// closing out accounts and signing programs are refused unless allowed. The
+// amount limits, in microAlgos, include the fee, and asset transfers are
+// refused when any of them is set.
+type APIV1KeyPolicy struct {
+	TxTypes             []protocol.TxType `json:"tx_types"`
+	MaxAmount           uint64            `json:"max_amount"`
+	MaxDailyAmount      uint64            `json:"max_daily_amount"`
+	Receivers           []string          `json:"receivers"`
+	AllowRekey          bool              `json:"allow_rekey"`
+	AllowClose          bool              `json:"allow_close"`
+	AllowProgramSigning bool              `json:"allow_program_signing"`
+}
+
+// APIV1PendingMultisig is the API's representation of a multisig transaction
+// whose co-signers are still adding their subsignatures. The signed
+// transaction is filled in once enough co-signers signed.
+type APIV1PendingMultisig struct {
+	TxID string `json:"txid"`
+	// swagger:strfmt byte
+	Transaction []byte           `json:"transaction"`
+	Address     string           `json:"address"`
+	Threshold   uint8            `json:"threshold"`
+	Signed      []APIV1PublicKey `json:"signed"`
+	Missing     []APIV1PublicKey `json:"missing"`
+	// swagger:strfmt byte
+	Multisig []byte `json:"multisig"`
+	Complete bool   `json:"complete"`
+	// swagger:strfmt byte
+	SignedTransaction []byte `json:"signed_transaction,omitempty"`
+}
@@ -107,6 +107,32 @@ type APIV1POSTMasterKeyExportRequest struct {
 	APIV1RequestEnvelope
 	WalletHandleToken string `json:"wallet_handle_token"`
 	WalletPassword    string `json:"wallet_password"`
+	PolicyPassword    string `json:"policy_password"`
+}
+
+// APIV1POSTWalletBackupRequest is the request for `POST /v1/wallet/backup`
+//
+// swagger:model BackupWalletRequest
+type APIV1POSTWalletBackupRequest struct {
+	APIV1RequestEnvelope
+	WalletHandleToken string `json:"wallet_handle_token"`
+	WalletPassword    string `json:"wallet_password"`
+	PolicyPassword    string `json:"policy_password"`
+	BackupPassphrase  string `json:"backup_passphrase"`
+}
+
+// APIV1POSTWalletRestoreRequest is the request for `POST /v1/wallet/restore`
+//
+// swagger:model RestoreWalletRequest
+type APIV1POSTWalletRestoreRequest struct {
+	APIV1RequestEnvelope
+	WalletName       string `json:"wallet_name"`
+	WalletDriverName string `json:"wallet_driver_name"`
+	WalletPassword   string `json:"wallet_password"`
+	BackupPassphrase string `json:"backup_passphrase"`
+
+	// swagger:strfmt byte
+	Backup []byte `json:"backup"`
 }
 
 // APIV1POSTKeyImportRequest is the request for `POST /v1/key/import`
@@ -155,6 +181,38 @@ type APIV1POSTKeyListRequest struct {
 	WalletHandleToken string `json:"wallet_handle_token"`
 }
 
+// APIV1POSTKeyPolicyRequest is the request for `POST /v1/key/policy`
+//
+// swagger:model SetKeyPolicyRequest
+type APIV1POSTKeyPolicyRequest struct {
+	APIV1RequestEnvelope
+	WalletHandleToken string         `json:"wallet_handle_token"`
+	WalletPassword    string         `json:"wallet_password"`
+	PolicyPassword    string         `json:"policy_password"`
+	Address           string         `json:"address"`
+	Policy            APIV1KeyPolicy `json:"policy"`
+}
+
+// APIV1POSTKeyPolicyExportRequest is the request for `POST /v1/key/policy/export`
+//
+// swagger:model ExportKeyPolicyRequest
+type APIV1POSTKeyPolicyExportRequest struct {
+	APIV1RequestEnvelope
+	WalletHandleToken string `json:"wallet_handle_token"`
+	Address           string `json:"address"`
+}
+
+// APIV1DELETEKeyPolicyRequest is the request for `DELETE /v1/key/policy`
+//
+// swagger:model DeleteKeyPolicyRequest
+type APIV1DELETEKeyPolicyRequest struct {
+	APIV1RequestEnvelope
+	WalletHandleToken string `json:"wallet_handle_token"`
+	WalletPassword    string `json:"wallet_password"`
+	PolicyPassword    string `json:"policy_password"`
+	Address           string `json:"address"`
+}
+
 // APIV1POSTTransactionSignRequest is the request for `POST /v1/transaction/sign`
 //
 // swagger:model SignTransactionRequest
@@ -244,3 +302,67 @@ type APIV1POSTMultisigProgramSignRequest struct {
 	PartialMsig    crypto.MultisigSig `json:"partial_multisig"`
 	WalletPassword string             `json:"wallet_password"`
 }
+
+// APIV1POSTMultisigPendingRequest is the request for `POST /v1/multisig/pending`
+//
+// swagger:model CreatePendingMultisigRequest
+type APIV1POSTMultisigPendingRequest struct {
+	APIV1RequestEnvelope
+	WalletHandleToken string `json:"wallet_handle_token"`
+	// swagger:strfmt byte
+	Transaction []byte `json:"transaction"`
+	// Address is the multisig account signing the transaction, if the sender
+	// was rekeyed to it
+	Address string `json:"address"`
+}
+
+// APIV1POSTMultisigPendingListRequest is the request for `POST /v1/multisig/pending/list`
+//
+// swagger:model ListPendingMultisigRequest
+type APIV1POSTMultisigPendingListRequest struct {
+	APIV1RequestEnvelope
+	WalletHandleToken string `json:"wallet_handle_token"`
+}
+
+// APIV1POSTMultisigPendingExportRequest is the request for `POST /v1/multisig/pending/export`
+//
+// swagger:model ExportPendingMultisigRequest
+type APIV1POSTMultisigPendingExportRequest struct {
+	APIV1RequestEnvelope
+	WalletHandleToken string `json:"wallet_handle_token"`
+	TxID              string `json:"txid"`
+}
+
+// APIV1POSTMultisigPendingSignRequest is the request for `POST /v1/multisig/pending/sign`
+//
+// swagger:model SignPendingMultisigRequest
+type APIV1POSTMultisigPendingSignRequest struct {
+	APIV1RequestEnvelope
+	WalletHandleToken string           `json:"wallet_handle_token"`
+	TxID              string           `json:"txid"`
+	PublicKey         crypto.PublicKey `json:"public_key"`
+	WalletPassword    string           `json:"wallet_password"`
+}
+
+// APIV1POSTMultisigPendingSubsigRequest is the request for `POST /v1/multisig/pending/subsig`
+//
+// swagger:model AddPendingMultisigSubsigRequest
+type APIV1POSTMultisigPendingSubsigRequest struct {
+	APIV1RequestEnvelope
+	WalletHandleToken string `json:"wallet_handle_token"`
+	TxID              string `json:"txid"`
+	// Multisig is a partial multisig signature of the transaction, as
+	// returned by `POST /v1/multisig/sign`
+	// swagger:strfmt byte
+	Multisig []byte `json:"multisig"`
+}
+
+// APIV1DELETEMultisigPendingRequest is the request for `DELETE /v1/multisig/pending`
+//
+// swagger:model DeletePendingMultisigRequest
+type APIV1DELETEMultisigPendingRequest struct {
+	APIV1RequestEnvelope
+	WalletHandleToken string `json:"wallet_handle_token"`
+	TxID              string `json:"txid"`
+	WalletPassword    string `json:"wallet_password"`
+}
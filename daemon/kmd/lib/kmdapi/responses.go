@@ -109,6 +109,22 @@ type APIV1POSTMasterKeyExportResponse struct {
 	MasterDerivationKey APIV1MasterDerivationKey `json:"master_derivation_key"`
 }
 
+// APIV1POSTWalletBackupResponse is the response to `POST /v1/wallet/backup`
+// friendly:BackupWalletResponse
+type APIV1POSTWalletBackupResponse struct {
+	APIV1ResponseEnvelope
+
+	// swagger:strfmt byte
+	Backup []byte `json:"backup"`
+}
+
+// APIV1POSTWalletRestoreResponse is the response to `POST /v1/wallet/restore`
+// friendly:RestoreWalletResponse
+type APIV1POSTWalletRestoreResponse struct {
+	APIV1ResponseEnvelope
+	Wallet APIV1Wallet `json:"wallet"`
+}
+
 // APIV1POSTKeyImportResponse is the repsonse to `POST /v1/key/import`
 // friendly:ImportKeyResponse
 type APIV1POSTKeyImportResponse struct {
@@ -143,6 +159,25 @@ type APIV1POSTKeyListResponse struct {
 	Addresses []string `json:"addresses"`
 }
 
+// APIV1POSTKeyPolicyResponse is the response to `POST /v1/key/policy`
+// friendly:SetKeyPolicyResponse
+type APIV1POSTKeyPolicyResponse struct {
+	APIV1ResponseEnvelope
+}
+
+// APIV1POSTKeyPolicyExportResponse is the response to `POST /v1/key/policy/export`
+// friendly:ExportKeyPolicyResponse
+type APIV1POSTKeyPolicyExportResponse struct {
+	APIV1ResponseEnvelope
+	Policy APIV1KeyPolicy `json:"policy"`
+}
+
+// APIV1DELETEKeyPolicyResponse is the response to `DELETE /v1/key/policy`
+// friendly:DeleteKeyPolicyResponse
+type APIV1DELETEKeyPolicyResponse struct {
+	APIV1ResponseEnvelope
+}
+
 // APIV1POSTTransactionSignResponse is the repsonse to `POST /v1/transaction/sign`
 // friendly:SignTransactionResponse
 type APIV1POSTTransactionSignResponse struct {
@@ -207,3 +242,44 @@ type APIV1POSTMultisigProgramSignResponse struct {
 	// swagger:strfmt byte
 	Multisig []byte `json:"multisig"`
 }
+
+// APIV1POSTMultisigPendingResponse is the response to `POST /v1/multisig/pending`
+// friendly:CreatePendingMultisigResponse
+type APIV1POSTMultisigPendingResponse struct {
+	APIV1ResponseEnvelope
+	Pending APIV1PendingMultisig `json:"pending"`
+}
+
+// APIV1POSTMultisigPendingListResponse is the response to `POST /v1/multisig/pending/list`
+// friendly:ListPendingMultisigResponse
+type APIV1POSTMultisigPendingListResponse struct {
+	APIV1ResponseEnvelope
+	Pending []APIV1PendingMultisig `json:"pending"`
+}
+
+// APIV1POSTMultisigPendingExportResponse is the response to `POST /v1/multisig/pending/export`
+// friendly:ExportPendingMultisigResponse
+type APIV1POSTMultisigPendingExportResponse struct {
+	APIV1ResponseEnvelope
+	Pending APIV1PendingMultisig `json:"pending"`
+}
+
+// APIV1POSTMultisigPendingSignResponse is the response to `POST /v1/multisig/pending/sign`
+// friendly:SignPendingMultisigResponse
+type APIV1POSTMultisigPendingSignResponse struct {
+	APIV1ResponseEnvelope
+	Pending APIV1PendingMultisig `json:"pending"`
+}
+
+// APIV1POSTMultisigPendingSubsigResponse is the response to `POST /v1/multisig/pending/subsig`
+// friendly:AddPendingMultisigSubsigResponse
+type APIV1POSTMultisigPendingSubsigResponse struct {
+	APIV1ResponseEnvelope
+	Pending APIV1PendingMultisig `json:"pending"`
+}
+
+// APIV1DELETEMultisigPendingResponse is the response to `DELETE /v1/multisig/pending`
+// friendly:DeletePendingMultisigResponse
+type APIV1DELETEMultisigPendingResponse struct {
+	APIV1ResponseEnvelope
+}
@@ -0,0 +1,118 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package remotesigner defines the protocol kmd uses to forward signing
+// requests to a remote signing service, along with a client for it and a
+// reference signer holding its keys in memory.
+package remotesigner
+
+import (
+	"errors"
+
+	"github.com/algorand/go-algorand/crypto"
+)
+
+const (
+	// TokenHeader is the HTTP header used for the pre-shared auth token
+	TokenHeader = "X-Algorand-Signer-Token"
+
+	// KeysPath lists the public keys held by the signer
+	KeysPath = "/v1/keys"
+	// TransactionSignPath signs a transaction
+	TransactionSignPath = "/v1/transaction/sign"
+	// ProgramSignPath signs a program
+	ProgramSignPath = "/v1/program/sign"
+	// MultisigTransactionSignPath signs a transaction as a member of a multisig
+	MultisigTransactionSignPath = "/v1/multisig/sign"
+	// MultisigProgramSignPath signs a program as a member of a multisig
+	MultisigProgramSignPath = "/v1/multisig/signprogram"
+)
+
+// Response is implemented by all of the responses of the signer
+type Response interface {
+	GetError() error
+}
+
+// ResponseEnvelope is a common envelope that all responses follow
+type ResponseEnvelope struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+	Error   bool     `json:"error"`
+	Message string   `json:"message"`
+}
+
+// GetError allows ResponseEnvelope and any type embedding it to conform to the
+// Response interface
+func (r ResponseEnvelope) GetError() error {
+	if r.Error {
+		return errors.New(r.Message)
+	}
+	return nil
+}
+
+// KeysRequest is the request for `POST /v1/keys`
+type KeysRequest struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+}
+
+// KeysResponse is the response to `POST /v1/keys`
+type KeysResponse struct {
+	ResponseEnvelope
+	Keys []crypto.PublicKey `json:"keys"`
+}
+
+// TransactionSignRequest is the request for `POST /v1/transaction/sign`
+type TransactionSignRequest struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+	// Transaction is the msgpack encoded transaction to sign
+	Transaction []byte           `json:"transaction"`
+	PublicKey   crypto.PublicKey `json:"public_key"`
+}
+
+// ProgramSignRequest is the request for `POST /v1/program/sign`
+type ProgramSignRequest struct {
+	_struct   struct{}         `codec:",omitempty,omitemptyarray"`
+	Program   []byte           `json:"program"`
+	PublicKey crypto.PublicKey `json:"public_key"`
+}
+
+// MultisigTransactionSignRequest is the request for `POST /v1/multisig/sign`
+type MultisigTransactionSignRequest struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+	// Transaction is the msgpack encoded transaction to sign
+	Transaction []byte             `json:"transaction"`
+	PublicKey   crypto.PublicKey   `json:"public_key"`
+	PartialMsig crypto.MultisigSig `json:"partial_multisig"`
+	// Signer is the multisig address the transaction is authorized by, if it
+	// isn't the sender
+	Signer crypto.Digest `json:"signer"`
+}
+
+// MultisigProgramSignRequest is the request for `POST /v1/multisig/signprogram`
+type MultisigProgramSignRequest struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+	Program []byte   `json:"program"`
+	// Address is the multisig address the program is signed for
+	Address     crypto.Digest      `json:"address"`
+	PublicKey   crypto.PublicKey   `json:"public_key"`
+	PartialMsig crypto.MultisigSig `json:"partial_multisig"`
+}
+
+// SignResponse is the response to all of the signing requests, holding the
+// signature of the requested key
+type SignResponse struct {
+	ResponseEnvelope
+	Signature crypto.Signature `json:"signature"`
+}
@@ -0,0 +1,139 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package remotesigner
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+const defaultTimeoutSecs = 30
+
+// Client is the client used to forward signing requests to a remote signer
+type Client struct {
+	httpClient http.Client
+	apiToken   string
+	url        string
+}
+
+// MakeClient instantiates a Client for the signer at the given URL. The
+// transport may be nil, in which case the default transport is used.
+func MakeClient(url string, apiToken string, transport http.RoundTripper, timeout time.Duration) Client {
+	if timeout == 0 {
+		timeout = defaultTimeoutSecs * time.Second
+	}
+	return Client{
+		httpClient: http.Client{
+			Transport: transport,
+			Timeout:   timeout,
+		},
+		apiToken: apiToken,
+		url:      strings.TrimSuffix(url, "/"),
+	}
+}
+
+// doRequest posts the request to the given path of the signer, and decodes
+// its response
+func (c Client) doRequest(path string, req interface{}, resp Response) error {
+	hreq, err := http.NewRequest(http.MethodPost, c.url+path, bytes.NewReader(protocol.EncodeJSON(req)))
+	if err != nil {
+		return err
+	}
+	hreq.Header.Set(TokenHeader, c.apiToken)
+
+	hresp, err := c.httpClient.Do(hreq)
+	if err != nil {
+		return err
+	}
+	defer hresp.Body.Close()
+
+	err = protocol.NewJSONDecoder(hresp.Body).Decode(resp)
+	if err != nil {
+		if hresp.StatusCode != http.StatusOK {
+			return fmt.Errorf("remote signer error response status code %d", hresp.StatusCode)
+		}
+		return err
+	}
+	return resp.GetError()
+}
+
+// ListKeys returns the public keys held by the signer
+func (c Client) ListKeys() ([]crypto.PublicKey, error) {
+	var resp KeysResponse
+	err := c.doRequest(KeysPath, KeysRequest{}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Keys, nil
+}
+
+// SignTransaction asks the signer to sign the transaction with the given key
+func (c Client) SignTransaction(tx transactions.Transaction, pk crypto.PublicKey) (crypto.Signature, error) {
+	req := TransactionSignRequest{
+		Transaction: protocol.Encode(&tx),
+		PublicKey:   pk,
+	}
+	var resp SignResponse
+	err := c.doRequest(TransactionSignPath, req, &resp)
+	return resp.Signature, err
+}
+
+// SignProgram asks the signer to sign the program with the given key
+func (c Client) SignProgram(program []byte, pk crypto.PublicKey) (crypto.Signature, error) {
+	req := ProgramSignRequest{
+		Program:   program,
+		PublicKey: pk,
+	}
+	var resp SignResponse
+	err := c.doRequest(ProgramSignPath, req, &resp)
+	return resp.Signature, err
+}
+
+// MultisigSignTransaction asks the signer to sign the transaction with the
+// given key, as a member of the multisig of the partial signature
+func (c Client) MultisigSignTransaction(tx transactions.Transaction, pk crypto.PublicKey, partial crypto.MultisigSig, signer crypto.Digest) (crypto.Signature, error) {
+	req := MultisigTransactionSignRequest{
+		Transaction: protocol.Encode(&tx),
+		PublicKey:   pk,
+		PartialMsig: partial,
+		Signer:      signer,
+	}
+	var resp SignResponse
+	err := c.doRequest(MultisigTransactionSignPath, req, &resp)
+	return resp.Signature, err
+}
+
+// MultisigSignProgram asks the signer to sign the program with the given key,
+// as a member of the multisig of the partial signature
+func (c Client) MultisigSignProgram(program []byte, addr crypto.Digest, pk crypto.PublicKey, partial crypto.MultisigSig) (crypto.Signature, error) {
+	req := MultisigProgramSignRequest{
+		Program:     program,
+		Address:     addr,
+		PublicKey:   pk,
+		PartialMsig: partial,
+	}
+	var resp SignResponse
+	err := c.doRequest(MultisigProgramSignPath, req, &resp)
+	return resp.Signature, err
+}
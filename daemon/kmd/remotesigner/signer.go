@@ -0,0 +1,221 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package remotesigner
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/data/transactions/logic"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/algorand/go-algorand/util/tokens"
+)
+
+var errInvalidToken = errors.New("invalid signer token")
+var errMethodNotAllowed = errors.New("method not allowed")
+var errKeyNotFound = errors.New("key is not held by this signer")
+var errMsigWrongAddr = errors.New("given multisig preimage hashes to neither the sender nor the signer")
+var errMsigWrongKey = errors.New("given key is not a possible signer for this multisig")
+
+// Signer is a reference remote signer. It holds its keys in memory, and signs
+// any request authenticated with its token; a signing service would apply its
+// own policies before signing. It also serves as a local stand-in for a
+// signing service in tests.
+type Signer struct {
+	keys     map[crypto.PublicKey]*crypto.SignatureSecrets
+	apiToken []byte
+	log      logging.Logger
+	mux      *http.ServeMux
+}
+
+// MakeSigner creates a Signer holding the given keys, and serving the requests
+// authenticated with the given token
+func MakeSigner(secrets []*crypto.SignatureSecrets, apiToken string, log logging.Logger) (*Signer, error) {
+	err := tokens.ValidateAPIToken(apiToken)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Signer{
+		keys:     make(map[crypto.PublicKey]*crypto.SignatureSecrets, len(secrets)),
+		apiToken: []byte(apiToken),
+		log:      log,
+		mux:      http.NewServeMux(),
+	}
+	for _, secret := range secrets {
+		s.keys[secret.SignatureVerifier] = secret
+	}
+	s.mux.HandleFunc(KeysPath, s.handleKeys)
+	s.mux.HandleFunc(TransactionSignPath, s.handleTransactionSign)
+	s.mux.HandleFunc(ProgramSignPath, s.handleProgramSign)
+	s.mux.HandleFunc(MultisigTransactionSignPath, s.handleMultisigTransactionSign)
+	s.mux.HandleFunc(MultisigProgramSignPath, s.handleMultisigProgramSign)
+	return s, nil
+}
+
+// ServeHTTP authenticates the request, and serves it
+func (s *Signer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Check the token in constant time
+	providedToken := []byte(r.Header.Get(TokenHeader))
+	if subtle.ConstantTimeCompare(providedToken, s.apiToken) != 1 {
+		errorResponse(w, http.StatusUnauthorized, errInvalidToken)
+		return
+	}
+	if r.Method != http.MethodPost {
+		errorResponse(w, http.StatusMethodNotAllowed, errMethodNotAllowed)
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+func errorResponse(w http.ResponseWriter, status int, err error) {
+	resp := ResponseEnvelope{
+		Error:   true,
+		Message: err.Error(),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(protocol.EncodeJSON(&resp))
+}
+
+func successResponse(w http.ResponseWriter, resp Response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(protocol.EncodeJSON(resp))
+}
+
+// decodeRequest decodes the request body, responding with an error if it
+// can't be decoded
+func decodeRequest(w http.ResponseWriter, r *http.Request, req interface{}) bool {
+	err := protocol.NewJSONDecoder(r.Body).Decode(req)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, err)
+		return false
+	}
+	return true
+}
+
+// signWith signs the message with the given key, responding with the
+// signature or with an error if the key isn't held by the signer
+func (s *Signer) signWith(w http.ResponseWriter, pk crypto.PublicKey, message crypto.Hashable) {
+	secrets, ok := s.keys[pk]
+	if !ok {
+		errorResponse(w, http.StatusNotFound, errKeyNotFound)
+		return
+	}
+	successResponse(w, &SignResponse{Signature: secrets.Sign(message)})
+}
+
+// checkMultisig checks that the key is a member of the multisig of the
+// partial signature, and that the multisig hashes to one of the addresses
+func checkMultisig(partial crypto.MultisigSig, pk crypto.PublicKey, addrs ...crypto.Digest) error {
+	addr, err := crypto.MultisigAddrGenWithSubsigs(partial.Version, partial.Threshold, partial.Subsigs)
+	if err != nil {
+		return err
+	}
+	err = errMsigWrongAddr
+	for _, a := range addrs {
+		if addr == a {
+			err = nil
+			break
+		}
+	}
+	if err != nil {
+		return err
+	}
+	for _, subsig := range partial.Subsigs {
+		if subsig.Key == pk {
+			return nil
+		}
+	}
+	return errMsigWrongKey
+}
+
+func (s *Signer) handleKeys(w http.ResponseWriter, r *http.Request) {
+	var req KeysRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	resp := KeysResponse{Keys: make([]crypto.PublicKey, 0, len(s.keys))}
+	for pk := range s.keys {
+		resp.Keys = append(resp.Keys, pk)
+	}
+	successResponse(w, &resp)
+}
+
+func (s *Signer) handleTransactionSign(w http.ResponseWriter, r *http.Request) {
+	var req TransactionSignRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	var tx transactions.Transaction
+	err := protocol.Decode(req.Transaction, &tx)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	s.log.Infof("signing transaction %s of %s with %s", tx.ID(), tx.Sender, basics.Address(req.PublicKey))
+	s.signWith(w, req.PublicKey, tx)
+}
+
+func (s *Signer) handleProgramSign(w http.ResponseWriter, r *http.Request) {
+	var req ProgramSignRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	s.log.Infof("signing program %s with %s", crypto.HashObj(logic.Program(req.Program)), basics.Address(req.PublicKey))
+	s.signWith(w, req.PublicKey, logic.Program(req.Program))
+}
+
+func (s *Signer) handleMultisigTransactionSign(w http.ResponseWriter, r *http.Request) {
+	var req MultisigTransactionSignRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	var tx transactions.Transaction
+	err := protocol.Decode(req.Transaction, &tx)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	err = checkMultisig(req.PartialMsig, req.PublicKey, crypto.Digest(tx.Src()), req.Signer)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	s.log.Infof("signing multisig transaction %s of %s with %s", tx.ID(), tx.Sender, basics.Address(req.PublicKey))
+	s.signWith(w, req.PublicKey, tx)
+}
+
+func (s *Signer) handleMultisigProgramSign(w http.ResponseWriter, r *http.Request) {
+	var req MultisigProgramSignRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	err := checkMultisig(req.PartialMsig, req.PublicKey, req.Address)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	s.log.Infof("signing multisig program %s for %s with %s", crypto.HashObj(logic.Program(req.Program)), basics.Address(req.Address), basics.Address(req.PublicKey))
+	s.signWith(w, req.PublicKey, logic.Program(req.Program))
+}
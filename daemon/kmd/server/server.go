@@ -211,8 +211,9 @@ func (ws *WalletServer) Start(kill chan os.Signal) (died chan error, sock string
 func (ws *WalletServer) start(kill chan os.Signal) (died chan error, sock string, err error) {
 	// Initialize HTTP server
 	watchdogCB := ws.makeWatchdogCallback(kill)
+	scopedTokens := tokens.MakeScopedTokenStore(ws.DataDir, tokens.KmdScopedTokensFilename)
 	srv := http.Server{
-		Handler: api.Handler(ws.SessionManager, ws.Log, ws.AllowedOrigins, ws.APIToken, watchdogCB),
+		Handler: api.Handler(ws.SessionManager, ws.Log, ws.AllowedOrigins, ws.APIToken, scopedTokens, watchdogCB),
 	}
 
 	// Read the kill channel and shut down the server gracefully
@@ -0,0 +1,334 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package driver
+
+import (
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/daemon/kmd/config"
+	"github.com/algorand/go-algorand/daemon/kmd/wallet"
+)
+
+const (
+	// walletBackupVersion is the version of the archives written by
+	// ExportWalletBackup. Bump it whenever walletBackup changes. Version 2
+	// added the key policies.
+	walletBackupVersion = 2
+)
+
+// walletBackupScryptParams are used to derive the key encrypting a backup from
+// its passphrase. Backups are meant to be stored away from kmd, so we don't
+// use the (possibly unsafe) scrypt parameters from the kmd config.
+var walletBackupScryptParams = config.ScryptParams{
+	ScryptN: 2 * minScryptN,
+	ScryptR: minScryptR,
+	ScryptP: minScryptP,
+}
+
+// walletBackupArchive is the envelope of a wallet backup. The version is kept
+// outside of the encrypted blob so that we can refuse archives we don't
+// understand before asking scrypt to do any work.
+type walletBackupArchive struct {
+	Version uint64 `codec:"version"`
+	Backup  []byte `codec:"backup"`
+}
+
+// walletBackup holds everything needed to rebuild a wallet: the master
+// derivation key, the index of the last derived key and the indexes of the
+// derived keys still held by the wallet, along with the imported keys and the
+// multisig preimages, which can't be derived from the master key, and the
+// signing policies of the keys along with the hash of the policy password.
+// What the keys spent toward their daily limits isn't backed up.
+type walletBackup struct {
+	WalletName            []byte                     `codec:"wallet_name"`
+	MasterDerivationKey   crypto.MasterDerivationKey `codec:"mdk"`
+	MaxKeyIdx             uint64                     `codec:"max_key_idx"`
+	DerivedKeys           []uint64                   `codec:"derived_keys"`
+	ImportedKeys          []crypto.PrivateKey        `codec:"imported_keys"`
+	MultisigPreimages     []walletBackupMultisig     `codec:"msig_preimages"`
+	KeyPolicies           []walletBackupKeyPolicy    `codec:"key_policies"`
+	KeyPolicyPasswordHash []byte                     `codec:"key_policy_pw_hash"`
+}
+
+// walletBackupMultisig is the preimage of a multisig address
+type walletBackupMultisig struct {
+	Version   uint8              `codec:"version"`
+	Threshold uint8              `codec:"threshold"`
+	PKs       []crypto.PublicKey `codec:"pks"`
+}
+
+// walletBackupKeyPolicy is the signing policy of a key
+type walletBackupKeyPolicy struct {
+	Address crypto.Digest    `codec:"addr"`
+	Policy  wallet.KeyPolicy `codec:"policy"`
+}
+
+// derivedKeyWallet is implemented by wallets which keep track of the keys they
+// derived from their master derivation key. Keys of other wallets are all
+// backed up as imported keys.
+type derivedKeyWallet interface {
+	derivedKeyIndexes() (maxKeyIdx uint64, indexes map[crypto.Digest]uint64, err error)
+	restoreDerivedKeys(maxKeyIdx uint64, indexes []uint64) error
+}
+
+// keyPolicyBackupWallet is implemented by wallets whose key policies can be
+// backed up and restored along with the hash of their policy password, which
+// the restored policies keep
+type keyPolicyBackupWallet interface {
+	keyPolicyPasswordHash() ([]byte, error)
+	restoreKeyPolicies(passwordHash []byte, policies []walletBackupKeyPolicy) error
+}
+
+// discardableWalletDriver is implemented by drivers which can delete a wallet
+// they created, so that a failed restore doesn't leave a partial wallet behind
+type discardableWalletDriver interface {
+	discardWallet(id []byte) error
+}
+
+// ExportWalletBackup exports the keys and multisig preimages of an initialized
+// wallet into a versioned archive, encrypted with the passed passphrase
+func ExportWalletBackup(w wallet.Wallet, pw []byte, passphrase []byte) ([]byte, error) {
+	if len(passphrase) == 0 {
+		return nil, errBackupPassphrase
+	}
+
+	metadata, err := w.Metadata()
+	if err != nil {
+		return nil, err
+	}
+
+	// ExportMasterDerivationKey checks the password for us
+	mdk, err := w.ExportMasterDerivationKey(pw)
+	if err != nil {
+		return nil, err
+	}
+
+	backup := walletBackup{
+		WalletName:          metadata.Name,
+		MasterDerivationKey: mdk,
+	}
+
+	derived := make(map[crypto.Digest]uint64)
+	if dw, ok := w.(derivedKeyWallet); ok {
+		backup.MaxKeyIdx, derived, err = dw.derivedKeyIndexes()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	policyWallet, hasPolicies := w.(wallet.PolicyWallet)
+
+	addrs, err := w.ListKeys()
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range addrs {
+		if hasPolicies {
+			policy, found, err := policyWallet.LookupKeyPolicy(addr)
+			if err != nil {
+				return nil, err
+			}
+			if found {
+				backup.KeyPolicies = append(backup.KeyPolicies, walletBackupKeyPolicy{
+					Address: addr,
+					Policy:  policy,
+				})
+			}
+		}
+
+		if idx, ok := derived[addr]; ok {
+			backup.DerivedKeys = append(backup.DerivedKeys, idx)
+			continue
+		}
+		sk, err := w.ExportKey(addr, pw)
+		if err != nil {
+			return nil, err
+		}
+		backup.ImportedKeys = append(backup.ImportedKeys, sk)
+	}
+
+	if len(backup.KeyPolicies) > 0 {
+		kpw, ok := w.(keyPolicyBackupWallet)
+		if !ok {
+			return nil, errBackupKeyPolicies
+		}
+		backup.KeyPolicyPasswordHash, err = kpw.keyPolicyPasswordHash()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	msigAddrs, err := w.ListMultisigAddrs()
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range msigAddrs {
+		version, threshold, pks, err := w.LookupMultisigPreimage(addr)
+		if err != nil {
+			return nil, err
+		}
+		backup.MultisigPreimages = append(backup.MultisigPreimages, walletBackupMultisig{
+			Version:   version,
+			Threshold: threshold,
+			PKs:       pks,
+		})
+	}
+
+	blob, err := encryptBlobWithPasswordBlankOK(msgpackEncode(backup), PTWalletBackup, passphrase, &walletBackupScryptParams)
+	if err != nil {
+		return nil, err
+	}
+
+	archive := walletBackupArchive{
+		Version: walletBackupVersion,
+		Backup:  blob,
+	}
+	return msgpackEncode(archive), nil
+}
+
+// RestoreWalletBackup decrypts a backup archive and restores it into a new
+// wallet created by the passed driver, returning the initialized wallet. The
+// name of the backed up wallet is used if name is empty.
+func RestoreWalletBackup(walletDriver Driver, archive []byte, passphrase []byte, name []byte, pw []byte) (wallet.Wallet, error) {
+	backup, err := decryptWalletBackup(archive, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	walletID, err := wallet.GenerateWalletID()
+	if err != nil {
+		return nil, err
+	}
+	if len(name) == 0 {
+		name = backup.WalletName
+	}
+	if len(name) == 0 {
+		name = walletID
+	}
+
+	err = walletDriver.CreateWallet(name, walletID, pw, backup.MasterDerivationKey)
+	if err != nil {
+		return nil, err
+	}
+	w, err := restoreWalletKeys(walletDriver, walletID, backup, pw)
+	if err != nil {
+		// Don't leave a partially restored wallet behind, which would also
+		// take the name of the wallet for a new attempt
+		if dwd, ok := walletDriver.(discardableWalletDriver); ok {
+			dwd.discardWallet(walletID)
+		}
+		return nil, err
+	}
+	return w, nil
+}
+
+// restoreWalletKeys restores the keys, multisig preimages and key policies of a
+// backup into the newly created wallet with the given id
+func restoreWalletKeys(walletDriver Driver, walletID []byte, backup walletBackup, pw []byte) (wallet.Wallet, error) {
+	w, err := walletDriver.FetchWallet(walletID)
+	if err != nil {
+		return nil, err
+	}
+	err = w.Init(pw)
+	if err != nil {
+		return nil, err
+	}
+
+	// Wallets which don't track derived keys get them as imported keys
+	if dw, ok := w.(derivedKeyWallet); ok {
+		err = dw.restoreDerivedKeys(backup.MaxKeyIdx, backup.DerivedKeys)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		for _, idx := range backup.DerivedKeys {
+			_, sk, err := extractKeyWithIndex(backup.MasterDerivationKey[:], idx)
+			if err != nil {
+				return nil, err
+			}
+			backup.ImportedKeys = append(backup.ImportedKeys, sk)
+		}
+	}
+
+	for _, sk := range backup.ImportedKeys {
+		_, err = w.ImportKey(sk)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, msig := range backup.MultisigPreimages {
+		_, err = w.ImportMultisigAddr(msig.Version, msig.Threshold, msig.PKs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Restoring the keys without their policies would lift every signing
+	// restriction, so wallets without key policies can't restore them
+	if len(backup.KeyPolicies) > 0 {
+		kpw, ok := w.(keyPolicyBackupWallet)
+		if !ok {
+			return nil, errBackupKeyPolicies
+		}
+		err = kpw.restoreKeyPolicies(backup.KeyPolicyPasswordHash, backup.KeyPolicies)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return w, nil
+}
+
+// decryptWalletBackup checks the version of a backup archive and decrypts it
+func decryptWalletBackup(archive []byte, passphrase []byte) (backup walletBackup, err error) {
+	var envelope walletBackupArchive
+	err = msgpackDecode(archive, &envelope)
+	if err != nil {
+		err = errBackupMalformed
+		return
+	}
+	// Archives from older versions lack the fields added since, which decode
+	// as empty
+	if envelope.Version == 0 || envelope.Version > walletBackupVersion {
+		err = errBackupVersion
+		return
+	}
+
+	// The archive is untrusted, so we don't let it pick the scrypt parameters
+	// (a huge N would stall kmd) or skip scrypt. Every version so far uses
+	// walletBackupScryptParams.
+	var blob encryptedDBBlob
+	err = msgpackDecode(envelope.Backup, &blob)
+	if err != nil {
+		err = errBackupMalformed
+		return
+	}
+	if !blob.DoScrypt || blob.ScryptParams != walletBackupScryptParams {
+		err = errBackupScryptParams
+		return
+	}
+
+	plaintext, err := decryptBlobWithPassword(envelope.Backup, PTWalletBackup, passphrase)
+	if err != nil {
+		return
+	}
+	err = msgpackDecode(plaintext, &backup)
+	if err != nil {
+		err = errBackupMalformed
+	}
+	return
+}
@@ -0,0 +1,27 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package driver
+
+import (
+	"fmt"
+)
+
+var errBackupPassphrase = fmt.Errorf("a passphrase is required to encrypt the wallet backup")
+var errBackupMalformed = fmt.Errorf("malformed wallet backup")
+var errBackupVersion = fmt.Errorf("unsupported wallet backup version, must be at most %d", walletBackupVersion)
+var errBackupScryptParams = fmt.Errorf("wallet backup was not encrypted with the expected scrypt parameters")
+var errBackupKeyPolicies = fmt.Errorf("wallet does not support the key policies of the backup")
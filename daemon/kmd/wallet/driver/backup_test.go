@@ -0,0 +1,203 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package driver
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/daemon/kmd/config"
+	"github.com/algorand/go-algorand/daemon/kmd/wallet"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/logging"
+)
+
+func TestWalletBackupRestore(t *testing.T) {
+	// Keep scrypt cheap for the test
+	fastScrypt := config.ScryptParams{ScryptN: 2, ScryptR: 1, ScryptP: 1}
+	defer func(params config.ScryptParams) {
+		walletBackupScryptParams = params
+	}(walletBackupScryptParams)
+	walletBackupScryptParams = fastScrypt
+
+	// The backup is restored by the kmd it was made by, and by another one
+	makeDriver := func() *SQLiteWalletDriver {
+		dir, err := ioutil.TempDir("", "walletbackup")
+		require.NoError(t, err)
+		var cfg config.KMDConfig
+		cfg.DriverConfig.SQLiteWalletDriverConfig = config.SQLiteWalletDriverConfig{
+			WalletsDir:   dir,
+			UnsafeScrypt: true,
+			ScryptParams: fastScrypt,
+		}
+		var swd SQLiteWalletDriver
+		require.NoError(t, swd.InitWithConfig(cfg, logging.TestingLog(t)))
+		return &swd
+	}
+	swd := makeDriver()
+	defer os.RemoveAll(swd.sqliteCfg.WalletsDir)
+	other := makeDriver()
+	defer os.RemoveAll(other.sqliteCfg.WalletsDir)
+
+	pw := []byte("hunter2")
+	require.NoError(t, swd.CreateWallet([]byte("source"), []byte("source-id"), pw, crypto.MasterDerivationKey{}))
+	source, err := swd.FetchWallet([]byte("source-id"))
+	require.NoError(t, err)
+	require.NoError(t, source.Init(pw))
+
+	// Three derived keys, one of which is deleted, and an imported key
+	var derived []crypto.Digest
+	for i := 0; i < 3; i++ {
+		addr, err := source.GenerateKey(false)
+		require.NoError(t, err)
+		derived = append(derived, addr)
+	}
+	require.NoError(t, source.DeleteKey(derived[1], pw))
+
+	var seed crypto.Seed
+	crypto.RandBytes(seed[:])
+	secrets := crypto.GenerateSignatureSecrets(seed)
+	imported, err := source.ImportKey(crypto.PrivateKey(secrets.SK))
+	require.NoError(t, err)
+
+	pks := []crypto.PublicKey{crypto.PublicKey(derived[0]), crypto.PublicKey(imported)}
+	msigAddr, err := source.ImportMultisigAddr(1, 2, pks)
+	require.NoError(t, err)
+
+	policy := wallet.KeyPolicy{MaxAmount: 1000, Receivers: []basics.Address{basics.Address(imported)}}
+	require.NoError(t, source.(wallet.PolicyWallet).SetKeyPolicy(derived[0], policy, pw, []byte("policy")))
+
+	passphrase := []byte("correct horse battery staple")
+	_, err = ExportWalletBackup(source, pw, nil)
+	require.Equal(t, errBackupPassphrase, err)
+	_, err = ExportWalletBackup(source, []byte("wrong"), passphrase)
+	require.Error(t, err)
+	archive, err := ExportWalletBackup(source, pw, passphrase)
+	require.NoError(t, err)
+
+	// The archive can't be opened with the wrong passphrase, or once its
+	// version is changed
+	_, err = RestoreWalletBackup(other, archive, []byte("wrong"), nil, pw)
+	require.Error(t, err)
+	var envelope walletBackupArchive
+	require.NoError(t, msgpackDecode(archive, &envelope))
+	envelope.Version++
+	_, err = RestoreWalletBackup(other, msgpackEncode(envelope), passphrase, nil, pw)
+	require.Equal(t, errBackupVersion, err)
+	envelope.Version--
+
+	// The archive can't pick its own scrypt parameters, or skip scrypt
+	var blob encryptedDBBlob
+	require.NoError(t, msgpackDecode(envelope.Backup, &blob))
+	blob.ScryptN = 1 << 30
+	_, err = RestoreWalletBackup(other, msgpackEncode(walletBackupArchive{Version: envelope.Version, Backup: msgpackEncode(blob)}), passphrase, nil, pw)
+	require.Equal(t, errBackupScryptParams, err)
+	rawKey := make([]byte, masterKeyLen)
+	noScrypt, err := encryptBlobWithPasswordBlankOK(msgpackEncode(walletBackup{}), PTWalletBackup, rawKey, nil)
+	require.NoError(t, err)
+	_, err = RestoreWalletBackup(other, msgpackEncode(walletBackupArchive{Version: envelope.Version, Backup: noScrypt}), rawKey, nil, pw)
+	require.Equal(t, errBackupScryptParams, err)
+
+	// The wallet name is taken from the backup when none is given, and may
+	// not be in use already
+	_, err = RestoreWalletBackup(swd, archive, passphrase, nil, pw)
+	require.Equal(t, errSameName, err)
+	restored, err := RestoreWalletBackup(other, archive, passphrase, nil, []byte("hunter3"))
+	require.NoError(t, err)
+	metadata, err := restored.Metadata()
+	require.NoError(t, err)
+	require.Equal(t, []byte("source"), metadata.Name)
+	require.NotEqual(t, []byte("source-id"), metadata.ID)
+
+	renamed, err := RestoreWalletBackup(swd, archive, passphrase, []byte("renamed"), pw)
+	require.NoError(t, err)
+	metadata, err = renamed.Metadata()
+	require.NoError(t, err)
+	require.Equal(t, []byte("renamed"), metadata.Name)
+
+	sourceMDK, err := source.ExportMasterDerivationKey(pw)
+	require.NoError(t, err)
+	restoredMDK, err := restored.ExportMasterDerivationKey([]byte("hunter3"))
+	require.NoError(t, err)
+	require.Equal(t, sourceMDK, restoredMDK)
+
+	for _, w := range []wallet.Wallet{restored, renamed} {
+		keys, err := w.ListKeys()
+		require.NoError(t, err)
+		require.ElementsMatch(t, []crypto.Digest{derived[0], derived[2], imported}, keys)
+
+		msigAddrs, err := w.ListMultisigAddrs()
+		require.NoError(t, err)
+		require.Equal(t, []crypto.Digest{msigAddr}, msigAddrs)
+		version, threshold, msigPKs, err := w.LookupMultisigPreimage(msigAddr)
+		require.NoError(t, err)
+		require.Equal(t, uint8(1), version)
+		require.Equal(t, uint8(2), threshold)
+		require.Equal(t, pks, msigPKs)
+
+		// Key policies are restored along with the keys
+		restoredPolicy, found, err := w.(wallet.PolicyWallet).LookupKeyPolicy(derived[0])
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, policy, restoredPolicy)
+		_, found, err = w.(wallet.PolicyWallet).LookupKeyPolicy(imported)
+		require.NoError(t, err)
+		require.False(t, found)
+		require.NoError(t, w.(wallet.PolicyWallet).CheckPolicyPassword([]byte("policy")))
+		require.Error(t, w.(wallet.PolicyWallet).CheckPolicyPassword(pw))
+
+		// Derived keys keep their indexes, so the deleted key isn't
+		// generated again
+		sourceMax, sourceIndexes, err := source.(derivedKeyWallet).derivedKeyIndexes()
+		require.NoError(t, err)
+		restoredMax, restoredIndexes, err := w.(derivedKeyWallet).derivedKeyIndexes()
+		require.NoError(t, err)
+		require.Equal(t, sourceMax, restoredMax)
+		require.Equal(t, sourceIndexes, restoredIndexes)
+	}
+
+	next, err := source.GenerateKey(false)
+	require.NoError(t, err)
+	restoredNext, err := restored.GenerateKey(false)
+	require.NoError(t, err)
+	require.Equal(t, next, restoredNext)
+
+	// A backup which can't be fully restored leaves no wallet behind, and
+	// its name can be used again
+	var unknown crypto.Digest
+	crypto.RandBytes(unknown[:])
+	broken := walletBackup{
+		WalletName:   []byte("broken"),
+		ImportedKeys: []crypto.PrivateKey{crypto.PrivateKey(secrets.SK)},
+		KeyPolicies:  []walletBackupKeyPolicy{{Address: unknown, Policy: policy}},
+	}
+	blob, err := encryptBlobWithPasswordBlankOK(msgpackEncode(broken), PTWalletBackup, passphrase, &walletBackupScryptParams)
+	require.NoError(t, err)
+	brokenArchive := msgpackEncode(walletBackupArchive{Version: walletBackupVersion, Backup: blob})
+	_, err = RestoreWalletBackup(other, brokenArchive, passphrase, nil, pw)
+	require.Error(t, err)
+
+	metadatas, err := other.ListWalletMetadatas()
+	require.NoError(t, err)
+	require.Len(t, metadatas, 1)
+	require.Equal(t, []byte("source"), metadatas[0].Name)
+	require.NoError(t, other.CreateWallet([]byte("broken"), []byte("broken-id"), pw, crypto.MasterDerivationKey{}))
+}
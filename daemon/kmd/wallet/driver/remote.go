@@ -0,0 +1,403 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package driver
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/algorand/go-deadlock"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/daemon/kmd/config"
+	"github.com/algorand/go-algorand/daemon/kmd/remotesigner"
+	"github.com/algorand/go-algorand/daemon/kmd/wallet"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/data/transactions/logic"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/algorand/go-algorand/util/tokens"
+)
+
+const (
+	remoteSignerWalletDriverName    = "remote"
+	remoteSignerWalletDriverVersion = 1
+	remoteSignerIDLen               = 16
+)
+
+var remoteSignerWalletSupportedTxs = []protocol.TxType{protocol.PaymentTx, protocol.KeyRegistrationTx}
+
+// RemoteSignerWalletDriver exposes the signing services configured in the
+// kmd config as wallets. The spending keys never leave the signing service:
+// the signing requests are forwarded to it, authenticated with a pre-shared
+// token, and the signatures it returns are checked before being used.
+type RemoteSignerWalletDriver struct {
+	mu      deadlock.Mutex
+	wallets map[string]*RemoteSignerWallet
+	log     logging.Logger
+}
+
+// RemoteSignerWallet represents a signing service under the
+// RemoteSignerWalletDriver. The wallet password is checked against the hash
+// in the kmd config before any request is forwarded; it is then up to the
+// signing service to decide which requests it signs.
+type RemoteSignerWallet struct {
+	id           string
+	name         string
+	passwordHash []byte
+	client       remotesigner.Client
+}
+
+// InitWithConfig accepts a driver configuration, and creates a wallet for
+// each of the configured signers
+func (rwd *RemoteSignerWalletDriver) InitWithConfig(cfg config.KMDConfig, log logging.Logger) error {
+	rwd.mu.Lock()
+	defer rwd.mu.Unlock()
+
+	rwd.log = log
+	rwd.wallets = make(map[string]*RemoteSignerWallet)
+	for _, signerCfg := range cfg.DriverConfig.RemoteSignerWalletDriverConfig.Signers {
+		rw, err := makeRemoteSignerWallet(signerCfg)
+		if err != nil {
+			return err
+		}
+		rwd.wallets[rw.id] = rw
+	}
+	return nil
+}
+
+func makeRemoteSignerWallet(cfg config.RemoteSignerConfig) (*RemoteSignerWallet, error) {
+	apiToken, err := tokens.GetAndValidateAPIToken(filepath.Dir(cfg.TokenFile), filepath.Base(cfg.TokenFile))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the token of remote signer '%s': %v", cfg.WalletName, err)
+	}
+
+	var transport http.RoundTripper
+	if cfg.CACertFile != "" {
+		caCert, err := ioutil.ReadFile(cfg.CACertFile)
+		if err != nil {
+			return nil, err
+		}
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(caCert) {
+			return nil, errRemoteSignerCACert
+		}
+		transport = &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{RootCAs: rootCAs},
+		}
+	}
+
+	return &RemoteSignerWallet{
+		id:           remoteSignerID(cfg.WalletName),
+		name:         cfg.WalletName,
+		passwordHash: []byte(cfg.PasswordHash),
+		client:       remotesigner.MakeClient(cfg.URL, apiToken, transport, time.Duration(cfg.TimeoutSecs)*time.Second),
+	}, nil
+}
+
+// remoteSignerID derives the wallet ID of a signer from its wallet name, so
+// that it remains the same across restarts
+func remoteSignerID(walletName string) string {
+	nameHash := sha512.Sum512_256([]byte("remote-signer:" + walletName))
+	return fmt.Sprintf("%x", nameHash[:remoteSignerIDLen])
+}
+
+// ListWalletMetadatas returns all wallets supported by this driver
+func (rwd *RemoteSignerWalletDriver) ListWalletMetadatas() (metadatas []wallet.Metadata, err error) {
+	rwd.mu.Lock()
+	defer rwd.mu.Unlock()
+
+	for _, rw := range rwd.wallets {
+		md, err := rw.Metadata()
+		if err != nil {
+			return nil, err
+		}
+		metadatas = append(metadatas, md)
+	}
+
+	// Sort metadatas by ID
+	sort.Slice(metadatas, func(i, j int) bool {
+		return bytes.Compare(metadatas[i].ID, metadatas[j].ID) < 0
+	})
+
+	return metadatas, nil
+}
+
+// CreateWallet implements the Driver interface. Remote signer wallets are
+// created from the kmd config only.
+func (rwd *RemoteSignerWalletDriver) CreateWallet(name []byte, id []byte, pw []byte, mdk crypto.MasterDerivationKey) error {
+	return errNotSupported
+}
+
+// RenameWallet implements the Driver interface.
+func (rwd *RemoteSignerWalletDriver) RenameWallet(newName []byte, id []byte, pw []byte) error {
+	return errNotSupported
+}
+
+// FetchWallet looks up a wallet by ID and returns it
+func (rwd *RemoteSignerWalletDriver) FetchWallet(id []byte) (wallet.Wallet, error) {
+	rwd.mu.Lock()
+	defer rwd.mu.Unlock()
+
+	rw, ok := rwd.wallets[string(id)]
+	if !ok {
+		return nil, errWalletNotFound
+	}
+	return rw, nil
+}
+
+// Init implements the Wallet interface, checking the wallet password.
+func (rw *RemoteSignerWallet) Init(pw []byte) error {
+	return rw.CheckPassword(pw)
+}
+
+// CheckPassword implements the Wallet interface, comparing the password with
+// the hash from the kmd config.
+func (rw *RemoteSignerWallet) CheckPassword(pw []byte) error {
+	if bcrypt.CompareHashAndPassword(rw.passwordHash, pw) != nil {
+		return errRemoteSignerPassword
+	}
+	return nil
+}
+
+// ExportMasterDerivationKey implements the Wallet interface.
+func (rw *RemoteSignerWallet) ExportMasterDerivationKey(pw []byte) (crypto.MasterDerivationKey, error) {
+	return crypto.MasterDerivationKey{}, errNotSupported
+}
+
+// Metadata implements the Wallet interface.
+func (rw *RemoteSignerWallet) Metadata() (wallet.Metadata, error) {
+	return wallet.Metadata{
+		ID:                    []byte(rw.id),
+		Name:                  []byte(rw.name),
+		DriverName:            remoteSignerWalletDriverName,
+		DriverVersion:         remoteSignerWalletDriverVersion,
+		SupportedTransactions: remoteSignerWalletSupportedTxs,
+	}, nil
+}
+
+// ListKeys implements the Wallet interface, returning the keys held by the
+// signing service.
+func (rw *RemoteSignerWallet) ListKeys() ([]crypto.Digest, error) {
+	pks, err := rw.client.ListKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := make([]crypto.Digest, len(pks))
+	for i, pk := range pks {
+		addrs[i] = publicKeyToAddress(pk)
+	}
+	return addrs, nil
+}
+
+// ImportKey implements the Wallet interface.
+func (rw *RemoteSignerWallet) ImportKey(sk crypto.PrivateKey) (crypto.Digest, error) {
+	return crypto.Digest{}, errNotSupported
+}
+
+// ExportKey implements the Wallet interface.
+func (rw *RemoteSignerWallet) ExportKey(pk crypto.Digest, pw []byte) (crypto.PrivateKey, error) {
+	return crypto.PrivateKey{}, errNotSupported
+}
+
+// GenerateKey implements the Wallet interface.
+func (rw *RemoteSignerWallet) GenerateKey(displayMnemonic bool) (crypto.Digest, error) {
+	return crypto.Digest{}, errNotSupported
+}
+
+// DeleteKey implements the Wallet interface.
+func (rw *RemoteSignerWallet) DeleteKey(pk crypto.Digest, pw []byte) error {
+	return errNotSupported
+}
+
+// ImportMultisigAddr implements the Wallet interface.
+func (rw *RemoteSignerWallet) ImportMultisigAddr(version, threshold uint8, pks []crypto.PublicKey) (crypto.Digest, error) {
+	return crypto.Digest{}, errNotSupported
+}
+
+// LookupMultisigPreimage implements the Wallet interface.
+func (rw *RemoteSignerWallet) LookupMultisigPreimage(crypto.Digest) (version, threshold uint8, pks []crypto.PublicKey, err error) {
+	return 0, 0, nil, errNotSupported
+}
+
+// ListMultisigAddrs implements the Wallet interface.
+func (rw *RemoteSignerWallet) ListMultisigAddrs() (addrs []crypto.Digest, err error) {
+	return nil, nil
+}
+
+// DeleteMultisigAddr implements the Wallet interface.
+func (rw *RemoteSignerWallet) DeleteMultisigAddr(addr crypto.Digest, pw []byte) error {
+	return errNotSupported
+}
+
+// SignTransaction implements the Wallet interface, forwarding the request to
+// the signing service.
+func (rw *RemoteSignerWallet) SignTransaction(tx transactions.Transaction, pk crypto.PublicKey, pw []byte) ([]byte, error) {
+	err := rw.CheckPassword(pw)
+	if err != nil {
+		return nil, err
+	}
+
+	if (pk == crypto.PublicKey{}) {
+		pk = crypto.PublicKey(tx.Src())
+	}
+
+	sig, err := rw.client.SignTransaction(tx, pk)
+	if err != nil {
+		return nil, err
+	}
+	if !pk.Verify(tx, sig) {
+		return nil, errRemoteSignature
+	}
+
+	stxn := transactions.SignedTxn{
+		Txn: tx,
+		Sig: sig,
+	}
+
+	// Set the AuthAddr if the key we signed with doesn't match the txn sender
+	if basics.Address(pk) != tx.Sender {
+		stxn.AuthAddr = basics.Address(pk)
+	}
+
+	return protocol.Encode(&stxn), nil
+}
+
+// SignProgram implements the Wallet interface, forwarding the request to the
+// signing service.
+func (rw *RemoteSignerWallet) SignProgram(data []byte, src crypto.Digest, pw []byte) ([]byte, error) {
+	err := rw.CheckPassword(pw)
+	if err != nil {
+		return nil, err
+	}
+
+	pk := crypto.PublicKey(src)
+	sig, err := rw.client.SignProgram(data, pk)
+	if err != nil {
+		return nil, err
+	}
+	if !pk.Verify(logic.Program(data), sig) {
+		return nil, errRemoteSignature
+	}
+
+	return sig[:], nil
+}
+
+// MultisigSignTransaction implements the Wallet interface, forwarding the
+// request to the signing service. The wallet doesn't store multisig
+// preimages, so the partial multisig must hold the preimage.
+func (rw *RemoteSignerWallet) MultisigSignTransaction(tx transactions.Transaction, pk crypto.PublicKey, partial crypto.MultisigSig, pw []byte, signer crypto.Digest) (crypto.MultisigSig, error) {
+	err := rw.CheckPassword(pw)
+	if err != nil {
+		return partial, err
+	}
+
+	err = checkRemoteMultisig(partial, pk, crypto.Digest(tx.Src()), signer)
+	if err != nil {
+		return partial, err
+	}
+
+	sig, err := rw.client.MultisigSignTransaction(tx, pk, partial, signer)
+	if err != nil {
+		return partial, err
+	}
+	if !pk.Verify(tx, sig) {
+		return partial, errRemoteSignature
+	}
+
+	return addMultisigSubsig(partial, pk, sig), nil
+}
+
+// MultisigSignProgram implements the Wallet interface, forwarding the request
+// to the signing service. As with MultisigSignTransaction, the partial
+// multisig must hold the preimage.
+func (rw *RemoteSignerWallet) MultisigSignProgram(data []byte, src crypto.Digest, pk crypto.PublicKey, partial crypto.MultisigSig, pw []byte) (crypto.MultisigSig, error) {
+	err := rw.CheckPassword(pw)
+	if err != nil {
+		return partial, err
+	}
+
+	err = checkRemoteMultisig(partial, pk, src)
+	if err != nil {
+		return partial, err
+	}
+
+	sig, err := rw.client.MultisigSignProgram(data, src, pk, partial)
+	if err != nil {
+		return partial, err
+	}
+	if !pk.Verify(logic.Program(data), sig) {
+		return partial, errRemoteSignature
+	}
+
+	return addMultisigSubsig(partial, pk, sig), nil
+}
+
+// checkRemoteMultisig checks that the partial multisig holds a preimage which
+// hashes to one of the addresses, and that the key is one of its members
+func checkRemoteMultisig(partial crypto.MultisigSig, pk crypto.PublicKey, addrs ...crypto.Digest) error {
+	if partial.Version == 0 && partial.Threshold == 0 && len(partial.Subsigs) == 0 {
+		return errMsigDataNotFound
+	}
+
+	addr, err := crypto.MultisigAddrGenWithSubsigs(partial.Version, partial.Threshold, partial.Subsigs)
+	if err != nil {
+		return err
+	}
+	err = errMsigWrongAddr
+	for _, a := range addrs {
+		if addr == a {
+			err = nil
+			break
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, subsig := range partial.Subsigs {
+		if subsig.Key == pk {
+			return nil
+		}
+	}
+	return errMsigWrongKey
+}
+
+// addMultisigSubsig returns a copy of the partial multisig, with the
+// signature of the given key added to it
+func addMultisigSubsig(partial crypto.MultisigSig, pk crypto.PublicKey, sig crypto.Signature) crypto.MultisigSig {
+	msig := partial
+	msig.Subsigs = append([]crypto.MultisigSubsig(nil), partial.Subsigs...)
+	for i := range msig.Subsigs {
+		if msig.Subsigs[i].Key == pk {
+			msig.Subsigs[i].Sig = sig
+		}
+	}
+	return msig
+}
@@ -0,0 +1,195 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package driver
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/daemon/kmd/config"
+	"github.com/algorand/go-algorand/daemon/kmd/remotesigner"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/data/transactions/logic"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+var testRemoteSignerPassword = []byte("remote signer password")
+
+func makeTestRemoteSignerWallet(t *testing.T, secrets []*crypto.SignatureSecrets, signerToken string, walletToken string) (wallet *RemoteSignerWallet, cleanup func()) {
+	signer, err := remotesigner.MakeSigner(secrets, signerToken, logging.TestingLog(t))
+	require.NoError(t, err)
+	server := httptest.NewServer(signer)
+
+	dir, err := ioutil.TempDir("", "remotesigner")
+	require.NoError(t, err)
+	tokenFile := filepath.Join(dir, "signer.token")
+	require.NoError(t, ioutil.WriteFile(tokenFile, []byte(walletToken), 0600))
+
+	passwordHash, err := bcrypt.GenerateFromPassword(testRemoteSignerPassword, bcrypt.MinCost)
+	require.NoError(t, err)
+
+	var cfg config.KMDConfig
+	cfg.DriverConfig.RemoteSignerWalletDriverConfig.Signers = []config.RemoteSignerConfig{{
+		WalletName:   "remote",
+		URL:          server.URL,
+		TokenFile:    tokenFile,
+		PasswordHash: string(passwordHash),
+	}}
+	require.NoError(t, cfg.Validate())
+
+	var rwd RemoteSignerWalletDriver
+	require.NoError(t, rwd.InitWithConfig(cfg, logging.TestingLog(t)))
+	metadatas, err := rwd.ListWalletMetadatas()
+	require.NoError(t, err)
+	require.Len(t, metadatas, 1)
+	require.Equal(t, "remote", string(metadatas[0].Name))
+	require.Equal(t, remoteSignerWalletDriverName, metadatas[0].DriverName)
+
+	w, err := rwd.FetchWallet(metadatas[0].ID)
+	require.NoError(t, err)
+	require.Error(t, w.Init(nil))
+	require.NoError(t, w.Init(testRemoteSignerPassword))
+	return w.(*RemoteSignerWallet), func() {
+		server.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestRemoteSignerWallet(t *testing.T) {
+	var secrets []*crypto.SignatureSecrets
+	for i := 0; i < 3; i++ {
+		var seed crypto.Seed
+		crypto.RandBytes(seed[:])
+		secrets = append(secrets, crypto.GenerateSignatureSecrets(seed))
+	}
+	token := strings.Repeat("a", 64)
+	// the last key isn't held by the signer
+	w, cleanup := makeTestRemoteSignerWallet(t, secrets[:2], token, token)
+	defer cleanup()
+
+	keys, err := w.ListKeys()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []crypto.Digest{publicKeyToAddress(secrets[0].SignatureVerifier), publicKeyToAddress(secrets[1].SignatureVerifier)}, keys)
+
+	tx := transactions.Transaction{
+		Type: protocol.PaymentTx,
+		Header: transactions.Header{
+			Sender:     basics.Address(secrets[0].SignatureVerifier),
+			Fee:        basics.MicroAlgos{Raw: 1000},
+			FirstValid: 1,
+			LastValid:  1000,
+		},
+		PaymentTxnFields: transactions.PaymentTxnFields{
+			Receiver: basics.Address(secrets[2].SignatureVerifier),
+			Amount:   basics.MicroAlgos{Raw: 100000},
+		},
+	}
+
+	// nothing is signed without the wallet password
+	_, err = w.SignTransaction(tx, crypto.PublicKey{}, []byte("wrong password"))
+	require.Error(t, err)
+	_, err = w.SignProgram([]byte{1, 32, 1, 1, 34}, crypto.Digest(secrets[0].SignatureVerifier), nil)
+	require.Error(t, err)
+
+	// the sender's key is used by default, and the signer's key sets the AuthAddr
+	for _, signer := range []int{0, 1} {
+		pk := crypto.PublicKey{}
+		if signer != 0 {
+			pk = secrets[signer].SignatureVerifier
+		}
+		encoded, err := w.SignTransaction(tx, pk, testRemoteSignerPassword)
+		require.NoError(t, err)
+		var stxn transactions.SignedTxn
+		require.NoError(t, protocol.Decode(encoded, &stxn))
+		require.Equal(t, tx.Sign(secrets[signer]), stxn)
+	}
+	_, err = w.SignTransaction(tx, secrets[2].SignatureVerifier, testRemoteSignerPassword)
+	require.Error(t, err)
+
+	program := []byte{1, 32, 1, 1, 34}
+	sig, err := w.SignProgram(program, crypto.Digest(secrets[1].SignatureVerifier), testRemoteSignerPassword)
+	require.NoError(t, err)
+	expected := secrets[1].Sign(logic.Program(program))
+	require.Equal(t, expected[:], sig)
+
+	// a 2-out-of-3 multisig, signed by the two keys held by the signer
+	pks := []crypto.PublicKey{secrets[0].SignatureVerifier, secrets[1].SignatureVerifier, secrets[2].SignatureVerifier}
+	msigAddr, err := crypto.MultisigAddrGen(1, 2, pks)
+	require.NoError(t, err)
+	msigTx := tx
+	msigTx.Sender = basics.Address(msigAddr)
+	msig := crypto.MultisigSig{Version: 1, Threshold: 2}
+	for _, pk := range pks {
+		msig.Subsigs = append(msig.Subsigs, crypto.MultisigSubsig{Key: pk})
+	}
+	_, err = w.MultisigSignTransaction(msigTx, pks[0], crypto.MultisigSig{}, testRemoteSignerPassword, crypto.Digest{})
+	require.Error(t, err)
+	_, err = w.MultisigSignTransaction(tx, pks[0], msig, testRemoteSignerPassword, crypto.Digest{})
+	require.Error(t, err)
+	_, err = w.MultisigSignTransaction(msigTx, pks[2], msig, testRemoteSignerPassword, crypto.Digest{})
+	require.Error(t, err)
+	for _, pk := range pks[:2] {
+		msig, err = w.MultisigSignTransaction(msigTx, pk, msig, testRemoteSignerPassword, crypto.Digest{})
+		require.NoError(t, err)
+	}
+	verified, err := crypto.MultisigVerify(msigTx, msigAddr, msig)
+	require.NoError(t, err)
+	require.True(t, verified)
+
+	// a wallet with the wrong token is rejected by the signer
+	wrong, cleanupWrong := makeTestRemoteSignerWallet(t, secrets[:2], token, strings.Repeat("b", 64))
+	defer cleanupWrong()
+	_, err = wrong.ListKeys()
+	require.Error(t, err)
+	_, err = wrong.SignTransaction(tx, crypto.PublicKey{}, testRemoteSignerPassword)
+	require.Error(t, err)
+}
+
+func TestRemoteSignerConfig(t *testing.T) {
+	passwordHash, err := bcrypt.GenerateFromPassword(testRemoteSignerPassword, bcrypt.MinCost)
+	require.NoError(t, err)
+
+	validate := func(url string, passwordHash string) error {
+		var cfg config.KMDConfig
+		cfg.DriverConfig.RemoteSignerWalletDriverConfig.Signers = []config.RemoteSignerConfig{{
+			WalletName:   "remote",
+			URL:          url,
+			TokenFile:    "/signer.token",
+			PasswordHash: passwordHash,
+		}}
+		return cfg.Validate()
+	}
+
+	for _, url := range []string{"https://signer.example.com", "https://10.0.0.1:8443", "http://127.0.0.1:8080", "http://[::1]:8080", "http://localhost:8080"} {
+		require.NoError(t, validate(url, string(passwordHash)), url)
+	}
+	for _, url := range []string{"http://signer.example.com", "http://10.0.0.1:8080", "http://[::ffff:10.0.0.1]:8080", "ftp://127.0.0.1", "signer.example.com"} {
+		require.Equal(t, config.ErrRemoteSignerURLInsecure, validate(url, string(passwordHash)), url)
+	}
+	require.Equal(t, config.ErrRemoteSignerPasswordHash, validate("https://signer.example.com", ""))
+	require.Equal(t, config.ErrRemoteSignerPasswordHash, validate("https://signer.example.com", "password"))
+}
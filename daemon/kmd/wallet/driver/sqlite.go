@@ -79,6 +79,26 @@ CREATE TABLE IF NOT EXISTS msig_addrs (
 	threshold INT NOT NULL,
 	pks BLOB NOT NULL
 );
+
+CREATE TABLE IF NOT EXISTS pending_multisigs (
+	txid BLOB PRIMARY KEY,
+	pending BLOB NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS key_policies (
+	address BLOB PRIMARY KEY,
+	policy BLOB NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS key_policy_password (
+	password_hash BLOB NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS key_spending (
+	address BLOB PRIMARY KEY,
+	day INT NOT NULL,
+	amount INT NOT NULL
+);
 `
 
 // SQLiteWalletDriver is the default wallet driver used by kmd. Keys are stored
@@ -489,6 +509,32 @@ func (swd *SQLiteWalletDriver) CreateWallet(name []byte, id []byte, pw []byte, m
 	return nil
 }
 
+// discardWallet deletes the database of a wallet and releases its name and
+// id, so that a wallet which could not be set up doesn't stay behind
+func (swd *SQLiteWalletDriver) discardWallet(id []byte) error {
+	swd.mux.Lock()
+	defer swd.mux.Unlock()
+
+	dbPaths, err := swd.findDBPathsByID(id)
+	if err != nil {
+		return err
+	}
+	for _, dbPath := range dbPaths {
+		err = os.Remove(dbPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i, nameID := range swd.claimedWallets {
+		if bytes.Equal(nameID[1], id) {
+			swd.claimedWallets = append(swd.claimedWallets[:i], swd.claimedWallets[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
 // FetchWallet looks up a wallet by ID and returns it, failing if there's more
 // than one wallet with the given ID
 func (swd *SQLiteWalletDriver) FetchWallet(id []byte) (sqWallet wallet.Wallet, err error) {
@@ -529,6 +575,14 @@ func (swd *SQLiteWalletDriver) fetchWalletLocked(id []byte) (sqWallet wallet.Wal
 	}
 	defer db.Close()
 
+	// Wallets created by older versions lack the tables added since, so
+	// bring their schema up to date
+	_, err = db.Exec(walletSchema)
+	if err != nil {
+		err = errDatabase
+		return
+	}
+
 	// Fill in the wallet details
 	sqWallet = &SQLiteWallet{
 		masterEncryptionKey: nil,
@@ -998,6 +1052,127 @@ func (sw *SQLiteWallet) DeleteKey(addr crypto.Digest, pw []byte) (err error) {
 	return
 }
 
+// derivedKeyIndexes returns the index of the last key derived from the master
+// derivation key, along with the index of each derived key still held by the
+// wallet
+func (sw *SQLiteWallet) derivedKeyIndexes() (maxKeyIdx uint64, indexes map[crypto.Digest]uint64, err error) {
+	// Connect to the database
+	db, err := sqlx.Connect("sqlite3", dbConnectionURL(sw.dbPath))
+	if err != nil {
+		err = errDatabaseConnect
+		return
+	}
+	defer db.Close()
+
+	// Fetch and decrypt the highest index
+	var encryptedHighestIndexBlob []byte
+	err = db.Get(&encryptedHighestIndexBlob, "SELECT max_key_idx_encrypted FROM metadata LIMIT 1")
+	if err != nil {
+		err = errDatabase
+		return
+	}
+	highestIndexBlob, err := decryptBlobWithPassword(encryptedHighestIndexBlob, PTMaxKeyIdx, sw.masterEncryptionKey)
+	if err != nil {
+		return
+	}
+	err = msgpackDecode(highestIndexBlob, &maxKeyIdx)
+	if err != nil {
+		return
+	}
+
+	// Imported keys don't have an index
+	rows, err := db.Query("SELECT address, key_idx FROM keys WHERE key_idx IS NOT NULL")
+	if err != nil {
+		err = errDatabase
+		return
+	}
+	defer rows.Close()
+
+	indexes = make(map[crypto.Digest]uint64)
+	for rows.Next() {
+		var addrBytes []byte
+		var idx uint64
+		err = rows.Scan(&addrBytes, &idx)
+		if err != nil {
+			err = errDatabase
+			return
+		}
+		var addr crypto.Digest
+		copy(addr[:], addrBytes)
+		indexes[addr] = idx
+	}
+	err = rows.Err()
+	if err != nil {
+		err = errDatabase
+	}
+	return
+}
+
+// restoreDerivedKeys regenerates the keys with the passed indexes from the
+// master derivation key, and sets the index of the last derived key, so that
+// keys deleted from the wallet aren't generated again
+func (sw *SQLiteWallet) restoreDerivedKeys(maxKeyIdx uint64, indexes []uint64) (err error) {
+	if maxKeyIdx >= sqliteIntOverflow {
+		return errTooManyKeys
+	}
+
+	// Connect to the database
+	db, err := sqlx.Connect("sqlite3", dbConnectionURL(sw.dbPath))
+	if err != nil {
+		return errDatabaseConnect
+	}
+	defer db.Close()
+
+	// Begin an exclusive database transaction
+	tx, err := db.Beginx()
+	if err != nil {
+		return errDatabase
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	for _, idx := range indexes {
+		if idx == 0 || idx > maxKeyIdx {
+			return errKeyIndex
+		}
+
+		genPK, genSK, err := extractKeyWithIndex(sw.masterDerivationKey, idx)
+		if err != nil {
+			return err
+		}
+		genAddr := publicKeyToAddress(genPK)
+
+		skEncrypted, err := encryptBlobWithKey(msgpackEncode(genSK), PTSecretKey, sw.masterEncryptionKey)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec("INSERT INTO keys (address, secret_key_encrypted, key_idx) VALUES(?, ?, ?)", genAddr[:], skEncrypted, idx)
+		err = checkDBError(err)
+		if err != nil {
+			return err
+		}
+	}
+
+	encryptedIdxBlob, err := encryptBlobWithKey(msgpackEncode(maxKeyIdx), PTMaxKeyIdx, sw.masterEncryptionKey)
+	if err != nil {
+		return
+	}
+	_, err = tx.Exec("UPDATE metadata SET max_key_idx_encrypted = ?", encryptedIdxBlob)
+	if err != nil {
+		return errDatabase
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errDatabase
+	}
+	return nil
+}
+
 // ImportMultisigAddr imports a multisig address, taking in version, threshold,
 // and public keys
 func (sw *SQLiteWallet) ImportMultisigAddr(version, threshold uint8, pks []crypto.PublicKey) (addr crypto.Digest, err error) {
@@ -45,3 +45,8 @@ var errNameTooLong = fmt.Errorf("wallet name too long, must be <= %d bytes", sql
 var errIDTooLong = fmt.Errorf("wallet id too long, must be <= %d bytes", sqliteMaxWalletIDLen)
 var errMsigWrongAddr = fmt.Errorf("given multisig preimage hashes to neither Sender nor AuthAddr")
 var errMsigWrongKey = fmt.Errorf("given key is not a possible signer for this multisig")
+var errKeyIndex = fmt.Errorf("derived key index out of range")
+var errPendingMsigExists = fmt.Errorf("transaction is already pending multisig signatures")
+var errPendingMsigNotFound = fmt.Errorf("transaction is not pending multisig signatures in this wallet")
+var errPolicyPassword = fmt.Errorf("wrong key policy password")
+var errPolicyPasswordBlank = fmt.Errorf("the first key policy of a wallet needs a policy password")
@@ -0,0 +1,199 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package driver
+
+import (
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/daemon/kmd/wallet"
+	"github.com/algorand/go-algorand/data/transactions"
+)
+
+// AddPendingMultisig stores a multisig transaction, so that its co-signers can
+// add their subsignatures
+func (sw *SQLiteWallet) AddPendingMultisig(pending wallet.PendingMultisig) error {
+	// Only multisig accounts whose preimage the wallet holds can be coordinated
+	_, _, _, err := sw.LookupMultisigPreimage(pending.Address)
+	if err != nil {
+		return err
+	}
+
+	db, err := sqlx.Connect("sqlite3", dbConnectionURL(sw.dbPath))
+	if err != nil {
+		return errDatabaseConnect
+	}
+	defer db.Close()
+
+	txid := pending.ID()
+	var cnt int
+	err = db.Get(&cnt, "SELECT COUNT(1) FROM pending_multisigs WHERE txid=?", txid[:])
+	if err != nil {
+		return errDatabase
+	}
+	if cnt != 0 {
+		return errPendingMsigExists
+	}
+
+	_, err = db.Exec("INSERT INTO pending_multisigs (txid, pending) VALUES(?, ?)", txid[:], msgpackEncode(pending))
+	if err != nil {
+		return errDatabase
+	}
+	return nil
+}
+
+// LookupPendingMultisig fetches a pending multisig transaction by its ID
+func (sw *SQLiteWallet) LookupPendingMultisig(txid transactions.Txid) (pending wallet.PendingMultisig, found bool, err error) {
+	db, err := sqlx.Connect("sqlite3", dbConnectionURL(sw.dbPath))
+	if err != nil {
+		err = errDatabaseConnect
+		return
+	}
+	defer db.Close()
+
+	var blob []byte
+	err = db.Get(&blob, "SELECT pending FROM pending_multisigs WHERE txid=?", txid[:])
+	if err == sql.ErrNoRows {
+		err = nil
+		return
+	}
+	if err != nil {
+		err = errDatabase
+		return
+	}
+
+	err = msgpackDecode(blob, &pending)
+	if err != nil {
+		return
+	}
+	found = true
+	return
+}
+
+// ListPendingMultisigs lists the pending multisig transactions of the wallet
+func (sw *SQLiteWallet) ListPendingMultisigs() (pendings []wallet.PendingMultisig, err error) {
+	db, err := sqlx.Connect("sqlite3", dbConnectionURL(sw.dbPath))
+	if err != nil {
+		err = errDatabaseConnect
+		return
+	}
+	defer db.Close()
+
+	var blobs [][]byte
+	err = db.Select(&blobs, "SELECT pending FROM pending_multisigs ORDER BY rowid")
+	if err != nil {
+		return nil, errDatabase
+	}
+
+	for _, blob := range blobs {
+		var pending wallet.PendingMultisig
+		err = msgpackDecode(blob, &pending)
+		if err != nil {
+			return nil, err
+		}
+		pendings = append(pendings, pending)
+	}
+	return pendings, nil
+}
+
+// DeletePendingMultisig stops coordinating a multisig transaction
+func (sw *SQLiteWallet) DeletePendingMultisig(txid transactions.Txid, pw []byte) error {
+	// Check the password
+	err := sw.CheckPassword(pw)
+	if err != nil {
+		return err
+	}
+
+	db, err := sqlx.Connect("sqlite3", dbConnectionURL(sw.dbPath))
+	if err != nil {
+		return errDatabaseConnect
+	}
+	defer db.Close()
+
+	result, err := db.Exec("DELETE FROM pending_multisigs WHERE txid=?", txid[:])
+	if err != nil {
+		return errDatabase
+	}
+	cnt, err := result.RowsAffected()
+	if err != nil {
+		return errDatabase
+	}
+	if cnt == 0 {
+		return errPendingMsigNotFound
+	}
+	return nil
+}
+
+// AddPendingMultisigSubsigs verifies the subsignatures of msig and assembles
+// them into the pending multisig transaction
+func (sw *SQLiteWallet) AddPendingMultisigSubsigs(txid transactions.Txid, msig crypto.MultisigSig) (pending wallet.PendingMultisig, err error) {
+	db, err := sqlx.Connect("sqlite3", dbConnectionURL(sw.dbPath))
+	if err != nil {
+		err = errDatabaseConnect
+		return
+	}
+	defer db.Close()
+
+	// Begin an exclusive database transaction, so that subsignatures added
+	// concurrently by several co-signers don't overwrite each other
+	tx, err := db.Beginx()
+	if err != nil {
+		err = errDatabase
+		return
+	}
+
+	var blob []byte
+	err = tx.Get(&blob, "SELECT pending FROM pending_multisigs WHERE txid=?", txid[:])
+	if err == sql.ErrNoRows {
+		tx.Rollback()
+		err = errPendingMsigNotFound
+		return
+	}
+	if err != nil {
+		tx.Rollback()
+		err = errDatabase
+		return
+	}
+
+	err = msgpackDecode(blob, &pending)
+	if err != nil {
+		tx.Rollback()
+		return
+	}
+
+	err = pending.AddSubsigs(msig)
+	if err != nil {
+		tx.Rollback()
+		return
+	}
+
+	_, err = tx.Exec("UPDATE pending_multisigs SET pending=? WHERE txid=?", msgpackEncode(pending), txid[:])
+	if err != nil {
+		tx.Rollback()
+		err = errDatabase
+		return
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		err = errDatabase
+		return
+	}
+	return
+}
@@ -0,0 +1,145 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package driver
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/daemon/kmd/config"
+	"github.com/algorand/go-algorand/daemon/kmd/wallet"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+func TestSQLitePendingMultisig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pendingmsig")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var cfg config.KMDConfig
+	cfg.DriverConfig.SQLiteWalletDriverConfig = config.SQLiteWalletDriverConfig{
+		WalletsDir:   dir,
+		UnsafeScrypt: true,
+		ScryptParams: config.ScryptParams{ScryptN: 2, ScryptR: 1, ScryptP: 1},
+	}
+	var swd SQLiteWalletDriver
+	require.NoError(t, swd.InitWithConfig(cfg, logging.TestingLog(t)))
+
+	pw := []byte("hunter2")
+	require.NoError(t, swd.CreateWallet([]byte("pending"), []byte("pending-id"), pw, crypto.MasterDerivationKey{}))
+	w, err := swd.FetchWallet([]byte("pending-id"))
+	require.NoError(t, err)
+	require.NoError(t, w.Init(pw))
+	pendingWallet := w.(wallet.PendingMultisigWallet)
+
+	// A 2-of-3 multisig account with one key in the wallet, and two held by
+	// co-signers elsewhere
+	localKey, err := w.GenerateKey(false)
+	require.NoError(t, err)
+	var seed crypto.Seed
+	crypto.RandBytes(seed[:])
+	remote1 := crypto.GenerateSignatureSecrets(seed)
+	crypto.RandBytes(seed[:])
+	remote2 := crypto.GenerateSignatureSecrets(seed)
+	pks := []crypto.PublicKey{crypto.PublicKey(localKey), remote1.SignatureVerifier, remote2.SignatureVerifier}
+	addr, err := w.ImportMultisigAddr(1, 2, pks)
+	require.NoError(t, err)
+
+	tx := transactions.Transaction{
+		Type: protocol.PaymentTx,
+		Header: transactions.Header{
+			Sender:     basics.Address(addr),
+			FirstValid: 1,
+			LastValid:  1000,
+		},
+		PaymentTxnFields: transactions.PaymentTxnFields{
+			Receiver: basics.Address(localKey),
+			Amount:   basics.MicroAlgos{Raw: 1000},
+		},
+	}
+
+	pending, err := wallet.MakePendingMultisig(tx, 1, 2, pks)
+	require.NoError(t, err)
+	require.Equal(t, addr, pending.Address)
+	require.NoError(t, pendingWallet.AddPendingMultisig(pending))
+	require.Equal(t, errPendingMsigExists, pendingWallet.AddPendingMultisig(pending))
+
+	stored, found, err := pendingWallet.LookupPendingMultisig(tx.ID())
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, pending, stored)
+	require.False(t, stored.Complete())
+	signed, missing := stored.Signers()
+	require.Empty(t, signed)
+	require.Equal(t, pks, missing)
+
+	// Sign with the wallet's key
+	msig, err := w.MultisigSignTransaction(tx, crypto.PublicKey(localKey), stored.Msig, pw, stored.Address)
+	require.NoError(t, err)
+	stored, err = pendingWallet.AddPendingMultisigSubsigs(tx.ID(), msig)
+	require.NoError(t, err)
+	signed, _ = stored.Signers()
+	require.Equal(t, []crypto.PublicKey{crypto.PublicKey(localKey)}, signed)
+	_, ok := stored.SignedTxn()
+	require.False(t, ok)
+
+	// Subsignatures of another transaction are rejected
+	otherTx := tx
+	otherTx.Amount.Raw++
+	badMsig, err := crypto.MultisigSign(otherTx, addr, 1, 2, pks, *remote1)
+	require.NoError(t, err)
+	_, err = pendingWallet.AddPendingMultisigSubsigs(tx.ID(), badMsig)
+	require.Error(t, err)
+	_, err = pendingWallet.AddPendingMultisigSubsigs(otherTx.ID(), msig)
+	require.Equal(t, errPendingMsigNotFound, err)
+
+	// A co-signer's subsignature meets the threshold
+	remoteMsig, err := crypto.MultisigSign(tx, addr, 1, 2, pks, *remote2)
+	require.NoError(t, err)
+	stored, err = pendingWallet.AddPendingMultisigSubsigs(tx.ID(), remoteMsig)
+	require.NoError(t, err)
+	require.True(t, stored.Complete())
+	_, missing = stored.Signers()
+	require.Equal(t, []crypto.PublicKey{remote1.SignatureVerifier}, missing)
+
+	stxn, ok := stored.SignedTxn()
+	require.True(t, ok)
+	require.Equal(t, tx, stxn.Txn)
+	require.Equal(t, basics.Address{}, stxn.AuthAddr)
+	verified, err := crypto.MultisigVerify(tx, addr, stxn.Msig)
+	require.NoError(t, err)
+	require.True(t, verified)
+
+	pendings, err := pendingWallet.ListPendingMultisigs()
+	require.NoError(t, err)
+	require.Len(t, pendings, 1)
+
+	// Deleting needs the password
+	require.Error(t, pendingWallet.DeletePendingMultisig(tx.ID(), []byte("wrong")))
+	require.NoError(t, pendingWallet.DeletePendingMultisig(tx.ID(), pw))
+	require.Equal(t, errPendingMsigNotFound, pendingWallet.DeletePendingMultisig(tx.ID(), pw))
+	_, found, err = pendingWallet.LookupPendingMultisig(tx.ID())
+	require.NoError(t, err)
+	require.False(t, found)
+}
@@ -0,0 +1,310 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package driver
+
+import (
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/daemon/kmd/wallet"
+)
+
+// SetKeyPolicy sets the signing policy of a key in the wallet, replacing any
+// previous policy. If the wallet has no policy password yet, policyPw becomes
+// its policy password.
+func (sw *SQLiteWallet) SetKeyPolicy(addr crypto.Digest, policy wallet.KeyPolicy, pw []byte, policyPw []byte) error {
+	// Check the password
+	err := sw.CheckPassword(pw)
+	if err != nil {
+		return err
+	}
+
+	db, err := sqlx.Connect("sqlite3", dbConnectionURL(sw.dbPath))
+	if err != nil {
+		return errDatabaseConnect
+	}
+	defer db.Close()
+
+	// Only keys held by the wallet can have a policy
+	var cnt int
+	err = db.Get(&cnt, "SELECT COUNT(1) FROM keys WHERE address=?", addr[:])
+	if err != nil {
+		return errDatabase
+	}
+	if cnt == 0 {
+		return errKeyNotFound
+	}
+
+	// Begin a database transaction, so that concurrent requests can't both
+	// pick the policy password
+	tx, err := db.Beginx()
+	if err != nil {
+		return errDatabase
+	}
+
+	var hash []byte
+	err = tx.Get(&hash, "SELECT password_hash FROM key_policy_password LIMIT 1")
+	switch {
+	case err == sql.ErrNoRows:
+		if len(policyPw) == 0 {
+			tx.Rollback()
+			return errPolicyPasswordBlank
+		}
+		hash, err = bcrypt.GenerateFromPassword(policyPw, bcrypt.DefaultCost)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		_, err = tx.Exec("INSERT INTO key_policy_password (password_hash) VALUES(?)", hash)
+		if err != nil {
+			tx.Rollback()
+			return errDatabase
+		}
+	case err != nil:
+		tx.Rollback()
+		return errDatabase
+	case bcrypt.CompareHashAndPassword(hash, policyPw) != nil:
+		tx.Rollback()
+		return errPolicyPassword
+	}
+
+	_, err = tx.Exec("INSERT OR REPLACE INTO key_policies (address, policy) VALUES(?, ?)", addr[:], msgpackEncode(policy))
+	if err != nil {
+		tx.Rollback()
+		return errDatabase
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errDatabase
+	}
+	return nil
+}
+
+// LookupKeyPolicy fetches the signing policy of a key, if it has one
+func (sw *SQLiteWallet) LookupKeyPolicy(addr crypto.Digest) (policy wallet.KeyPolicy, found bool, err error) {
+	db, err := sqlx.Connect("sqlite3", dbConnectionURL(sw.dbPath))
+	if err != nil {
+		err = errDatabaseConnect
+		return
+	}
+	defer db.Close()
+
+	var blob []byte
+	err = db.Get(&blob, "SELECT policy FROM key_policies WHERE address=?", addr[:])
+	if err == sql.ErrNoRows {
+		err = nil
+		return
+	}
+	if err != nil {
+		err = errDatabase
+		return
+	}
+
+	err = msgpackDecode(blob, &policy)
+	if err != nil {
+		return
+	}
+	found = true
+	return
+}
+
+// DeleteKeyPolicy removes the signing policy of a key, along with its daily
+// spending. Removing the last policy also removes the policy password.
+func (sw *SQLiteWallet) DeleteKeyPolicy(addr crypto.Digest, pw []byte, policyPw []byte) error {
+	// Check the passwords
+	err := sw.CheckPassword(pw)
+	if err != nil {
+		return err
+	}
+	err = sw.CheckPolicyPassword(policyPw)
+	if err != nil {
+		return err
+	}
+
+	db, err := sqlx.Connect("sqlite3", dbConnectionURL(sw.dbPath))
+	if err != nil {
+		return errDatabaseConnect
+	}
+	defer db.Close()
+
+	_, err = db.Exec("DELETE FROM key_policies WHERE address=?", addr[:])
+	if err != nil {
+		return errDatabase
+	}
+	_, err = db.Exec("DELETE FROM key_spending WHERE address=?", addr[:])
+	if err != nil {
+		return errDatabase
+	}
+	_, err = db.Exec("DELETE FROM key_policy_password WHERE NOT EXISTS (SELECT 1 FROM key_policies)")
+	if err != nil {
+		return errDatabase
+	}
+	return nil
+}
+
+// HasKeyPolicies returns true if any key of the wallet has a signing policy
+func (sw *SQLiteWallet) HasKeyPolicies() (bool, error) {
+	db, err := sqlx.Connect("sqlite3", dbConnectionURL(sw.dbPath))
+	if err != nil {
+		return false, errDatabaseConnect
+	}
+	defer db.Close()
+
+	var cnt int
+	err = db.Get(&cnt, "SELECT COUNT(1) FROM key_policies")
+	if err != nil {
+		return false, errDatabase
+	}
+	return cnt > 0, nil
+}
+
+// CheckPolicyPassword checks the policy password of the wallet, which a
+// wallet without key policies doesn't have
+func (sw *SQLiteWallet) CheckPolicyPassword(policyPw []byte) error {
+	hash, err := sw.keyPolicyPasswordHash()
+	if err != nil {
+		return err
+	}
+	if hash == nil || bcrypt.CompareHashAndPassword(hash, policyPw) != nil {
+		return errPolicyPassword
+	}
+	return nil
+}
+
+// keyPolicyPasswordHash returns the bcrypt hash of the policy password, or
+// nil if the wallet has none
+func (sw *SQLiteWallet) keyPolicyPasswordHash() ([]byte, error) {
+	db, err := sqlx.Connect("sqlite3", dbConnectionURL(sw.dbPath))
+	if err != nil {
+		return nil, errDatabaseConnect
+	}
+	defer db.Close()
+
+	var hash []byte
+	err = db.Get(&hash, "SELECT password_hash FROM key_policy_password LIMIT 1")
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errDatabase
+	}
+	return hash, nil
+}
+
+// restoreKeyPolicies stores the key policies and the policy password hash of
+// a backup into a wallet without key policies
+func (sw *SQLiteWallet) restoreKeyPolicies(passwordHash []byte, policies []walletBackupKeyPolicy) error {
+	if len(passwordHash) == 0 {
+		return errBackupMalformed
+	}
+
+	db, err := sqlx.Connect("sqlite3", dbConnectionURL(sw.dbPath))
+	if err != nil {
+		return errDatabaseConnect
+	}
+	defer db.Close()
+
+	tx, err := db.Beginx()
+	if err != nil {
+		return errDatabase
+	}
+
+	_, err = tx.Exec("INSERT INTO key_policy_password (password_hash) VALUES(?)", passwordHash)
+	if err != nil {
+		tx.Rollback()
+		return errDatabase
+	}
+	for _, kp := range policies {
+		// Only keys held by the wallet can have a policy
+		var cnt int
+		err = tx.Get(&cnt, "SELECT COUNT(1) FROM keys WHERE address=?", kp.Address[:])
+		if err != nil {
+			tx.Rollback()
+			return errDatabase
+		}
+		if cnt == 0 {
+			tx.Rollback()
+			return errKeyNotFound
+		}
+
+		_, err = tx.Exec("INSERT INTO key_policies (address, policy) VALUES(?, ?)", kp.Address[:], msgpackEncode(kp.Policy))
+		if err != nil {
+			tx.Rollback()
+			return errDatabase
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errDatabase
+	}
+	return nil
+}
+
+// AddDailySpending adds amount to what the key spent on the given day, unless
+// the total would exceed limit
+func (sw *SQLiteWallet) AddDailySpending(addr crypto.Digest, day uint64, amount uint64, limit uint64) (ok bool, err error) {
+	if day >= sqliteIntOverflow || limit >= sqliteIntOverflow {
+		return false, errDatabase
+	}
+
+	db, err := sqlx.Connect("sqlite3", dbConnectionURL(sw.dbPath))
+	if err != nil {
+		err = errDatabaseConnect
+		return
+	}
+	defer db.Close()
+
+	// Begin an exclusive database transaction, so that concurrent signing
+	// requests can't both fit under the limit
+	tx, err := db.Beginx()
+	if err != nil {
+		return false, errDatabase
+	}
+
+	var spentDay, spent uint64
+	err = tx.QueryRow("SELECT day, amount FROM key_spending WHERE address=?", addr[:]).Scan(&spentDay, &spent)
+	if err != nil && err != sql.ErrNoRows {
+		tx.Rollback()
+		return false, errDatabase
+	}
+
+	// Spending from previous days doesn't count
+	if spentDay != day {
+		spent = 0
+	}
+	if amount > limit || spent > limit-amount {
+		tx.Rollback()
+		return false, nil
+	}
+
+	_, err = tx.Exec("INSERT OR REPLACE INTO key_spending (address, day, amount) VALUES(?, ?, ?)", addr[:], day, spent+amount)
+	if err != nil {
+		tx.Rollback()
+		return false, errDatabase
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, errDatabase
+	}
+	return true, nil
+}
@@ -0,0 +1,166 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package driver
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/daemon/kmd/config"
+	"github.com/algorand/go-algorand/daemon/kmd/wallet"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+func TestSQLiteKeyPolicy(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keypolicy")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var cfg config.KMDConfig
+	cfg.DriverConfig.SQLiteWalletDriverConfig = config.SQLiteWalletDriverConfig{
+		WalletsDir:   dir,
+		UnsafeScrypt: true,
+		ScryptParams: config.ScryptParams{ScryptN: 2, ScryptR: 1, ScryptP: 1},
+	}
+	var swd SQLiteWalletDriver
+	require.NoError(t, swd.InitWithConfig(cfg, logging.TestingLog(t)))
+
+	pw := []byte("hunter2")
+	require.NoError(t, swd.CreateWallet([]byte("policies"), []byte("policies-id"), pw, crypto.MasterDerivationKey{}))
+	w, err := swd.FetchWallet([]byte("policies-id"))
+	require.NoError(t, err)
+	require.NoError(t, w.Init(pw))
+	policyWallet := w.(wallet.PolicyWallet)
+
+	addr, err := w.GenerateKey(false)
+	require.NoError(t, err)
+
+	_, found, err := policyWallet.LookupKeyPolicy(addr)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	policy := wallet.KeyPolicy{
+		TxTypes:        []protocol.TxType{protocol.PaymentTx},
+		MaxAmount:      1000,
+		MaxDailyAmount: 2500,
+		Receivers:      []basics.Address{basics.Address(addr)},
+		AllowClose:     true,
+	}
+
+	// Setting a policy needs the password and a key held by the wallet, and
+	// the first policy picks the policy password
+	policyPw := []byte("hunter3")
+	require.Error(t, policyWallet.SetKeyPolicy(addr, policy, []byte("wrong"), policyPw))
+	require.Equal(t, errKeyNotFound, policyWallet.SetKeyPolicy(crypto.Digest{1}, policy, pw, policyPw))
+	require.Equal(t, errPolicyPasswordBlank, policyWallet.SetKeyPolicy(addr, policy, pw, nil))
+	require.Equal(t, errPolicyPassword, policyWallet.CheckPolicyPassword(policyPw))
+	hasPolicies, err := policyWallet.HasKeyPolicies()
+	require.NoError(t, err)
+	require.False(t, hasPolicies)
+	require.NoError(t, policyWallet.SetKeyPolicy(addr, policy, pw, policyPw))
+	require.NoError(t, policyWallet.CheckPolicyPassword(policyPw))
+	hasPolicies, err = policyWallet.HasKeyPolicies()
+	require.NoError(t, err)
+	require.True(t, hasPolicies)
+
+	// Later policies need the policy password
+	require.Equal(t, errPolicyPassword, policyWallet.SetKeyPolicy(addr, wallet.KeyPolicy{}, pw, pw))
+	require.Equal(t, errPolicyPassword, policyWallet.SetKeyPolicy(addr, wallet.KeyPolicy{}, pw, nil))
+
+	stored, found, err := policyWallet.LookupKeyPolicy(addr)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, policy, stored)
+
+	// Spending accumulates within a day, and starts over the next day
+	for _, amount := range []uint64{1000, 1000, 500} {
+		ok, err := policyWallet.AddDailySpending(addr, 100, amount, policy.MaxDailyAmount)
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+	ok, err := policyWallet.AddDailySpending(addr, 100, 1, policy.MaxDailyAmount)
+	require.NoError(t, err)
+	require.False(t, ok)
+	ok, err = policyWallet.AddDailySpending(addr, 101, 2000, policy.MaxDailyAmount)
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = policyWallet.AddDailySpending(addr, 101, 1000, policy.MaxDailyAmount)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.Error(t, policyWallet.DeleteKeyPolicy(addr, []byte("wrong"), policyPw))
+	require.Equal(t, errPolicyPassword, policyWallet.DeleteKeyPolicy(addr, pw, pw))
+	require.NoError(t, policyWallet.DeleteKeyPolicy(addr, pw, policyPw))
+	_, found, err = policyWallet.LookupKeyPolicy(addr)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	// Without policies left, the next policy picks a new policy password
+	require.Equal(t, errPolicyPassword, policyWallet.CheckPolicyPassword(policyPw))
+	require.NoError(t, policyWallet.SetKeyPolicy(addr, policy, pw, pw))
+	require.NoError(t, policyWallet.CheckPolicyPassword(pw))
+}
+
+func TestSQLiteWalletSchemaUpgrade(t *testing.T) {
+	dir, err := ioutil.TempDir("", "schemaupgrade")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var cfg config.KMDConfig
+	cfg.DriverConfig.SQLiteWalletDriverConfig = config.SQLiteWalletDriverConfig{
+		WalletsDir:   dir,
+		UnsafeScrypt: true,
+		ScryptParams: config.ScryptParams{ScryptN: 2, ScryptR: 1, ScryptP: 1},
+	}
+	var swd SQLiteWalletDriver
+	require.NoError(t, swd.InitWithConfig(cfg, logging.TestingLog(t)))
+
+	pw := []byte("hunter2")
+	require.NoError(t, swd.CreateWallet([]byte("old"), []byte("old-id"), pw, crypto.MasterDerivationKey{}))
+
+	// Drop the tables which wallets created by older versions lack
+	paths, err := swd.findDBPathsByID([]byte("old-id"))
+	require.NoError(t, err)
+	require.Len(t, paths, 1)
+	db, err := sqlx.Connect("sqlite3", dbConnectionURL(paths[0]))
+	require.NoError(t, err)
+	_, err = db.Exec("DROP TABLE pending_multisigs; DROP TABLE key_policies; DROP TABLE key_policy_password; DROP TABLE key_spending")
+	db.Close()
+	require.NoError(t, err)
+
+	// Opening the wallet creates them again
+	w, err := swd.FetchWallet([]byte("old-id"))
+	require.NoError(t, err)
+	require.NoError(t, w.Init(pw))
+	addr, err := w.GenerateKey(false)
+	require.NoError(t, err)
+	policyWallet := w.(wallet.PolicyWallet)
+	require.NoError(t, policyWallet.SetKeyPolicy(addr, wallet.KeyPolicy{MaxAmount: 1000}, pw, pw))
+	_, found, err := policyWallet.LookupKeyPolicy(addr)
+	require.NoError(t, err)
+	require.True(t, found)
+	pending, err := w.(wallet.PendingMultisigWallet).ListPendingMultisigs()
+	require.NoError(t, err)
+	require.Empty(t, pending)
+}
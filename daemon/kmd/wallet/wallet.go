@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/data/basics"
 	"github.com/algorand/go-algorand/data/transactions"
 	"github.com/algorand/go-algorand/protocol"
 )
@@ -29,6 +30,8 @@ const (
 	walletIDBytes = 16
 )
 
+var errInvalidSubsig = fmt.Errorf("subsignature does not verify against the transaction")
+
 // Wallet represents the interface that any wallet technology must satisfy in
 // order to be used with KMD. Wallets start in a locked state until they are
 // initialized with Init.
@@ -59,6 +62,136 @@ type Wallet interface {
 	MultisigSignProgram(program []byte, src crypto.Digest, pk crypto.PublicKey, partial crypto.MultisigSig, pw []byte) (crypto.MultisigSig, error)
 }
 
+// PolicyWallet is implemented by wallets which can store signing policies for
+// their keys. kmd enforces the policies before asking the wallet to sign.
+//
+// Changing the policies takes a policy password on top of the wallet password,
+// so that whoever may only sign with the keys can't lift their restrictions.
+// The first policy set picks the policy password, which stays until the last
+// policy is deleted.
+type PolicyWallet interface {
+	SetKeyPolicy(addr crypto.Digest, policy KeyPolicy, pw []byte, policyPw []byte) error
+	LookupKeyPolicy(addr crypto.Digest) (policy KeyPolicy, found bool, err error)
+	DeleteKeyPolicy(addr crypto.Digest, pw []byte, policyPw []byte) error
+	HasKeyPolicies() (bool, error)
+	CheckPolicyPassword(policyPw []byte) error
+
+	// AddDailySpending adds amount to what the key spent on the given day,
+	// unless the total would exceed limit, in which case nothing is recorded
+	// and ok is false
+	AddDailySpending(addr crypto.Digest, day uint64, amount uint64, limit uint64) (ok bool, err error)
+}
+
+// KeyPolicy restricts what a key of a wallet may sign. Empty lists and zero
+// amounts don't restrict anything, while rekeying, closing out accounts and
+// signing programs (which delegate the key's authority) are refused unless
+// explicitly allowed. The amount limits count the fee along with the amount
+// sent, and asset transfers are refused when any of them is set.
+type KeyPolicy struct {
+	TxTypes             []protocol.TxType `codec:"tx_types"`
+	MaxAmount           uint64            `codec:"max_amount"`
+	MaxDailyAmount      uint64            `codec:"max_daily_amount"`
+	Receivers           []basics.Address  `codec:"receivers"`
+	AllowRekey          bool              `codec:"allow_rekey"`
+	AllowClose          bool              `codec:"allow_close"`
+	AllowProgramSigning bool              `codec:"allow_program_signing"`
+}
+
+// PendingMultisigWallet is implemented by wallets which can coordinate the
+// signing of multisig transactions, collecting the subsignatures of the
+// co-signers until the threshold of the multisig account is met.
+type PendingMultisigWallet interface {
+	AddPendingMultisig(pending PendingMultisig) error
+	LookupPendingMultisig(txid transactions.Txid) (pending PendingMultisig, found bool, err error)
+	ListPendingMultisigs() ([]PendingMultisig, error)
+	DeletePendingMultisig(txid transactions.Txid, pw []byte) error
+
+	// AddPendingMultisigSubsigs verifies the subsignatures of msig and
+	// assembles them into the pending multisig transaction
+	AddPendingMultisigSubsigs(txid transactions.Txid, msig crypto.MultisigSig) (PendingMultisig, error)
+}
+
+// PendingMultisig is a multisig transaction whose co-signers are still adding
+// their subsignatures. Msig starts out as the blank preimage of the multisig
+// account and is complete once it holds threshold subsignatures.
+type PendingMultisig struct {
+	Transaction transactions.Transaction `codec:"txn"`
+	// Address is the multisig account signing the transaction, which isn't
+	// the sender if the sender was rekeyed to it
+	Address crypto.Digest      `codec:"addr"`
+	Msig    crypto.MultisigSig `codec:"msig"`
+}
+
+// MakePendingMultisig creates a pending multisig transaction, without any
+// subsignatures, for the multisig account with the given preimage
+func MakePendingMultisig(tx transactions.Transaction, version, threshold uint8, pks []crypto.PublicKey) (PendingMultisig, error) {
+	addr, err := crypto.MultisigAddrGen(version, threshold, pks)
+	if err != nil {
+		return PendingMultisig{}, err
+	}
+	return PendingMultisig{
+		Transaction: tx,
+		Address:     addr,
+		Msig:        crypto.MultisigPreimageFromPKs(version, threshold, pks),
+	}, nil
+}
+
+// ID returns the ID of the pending transaction
+func (p PendingMultisig) ID() transactions.Txid {
+	return p.Transaction.ID()
+}
+
+// Signers returns the keys of the multisig account which have, and haven't
+// yet, signed the transaction
+func (p PendingMultisig) Signers() (signed []crypto.PublicKey, missing []crypto.PublicKey) {
+	for _, subsig := range p.Msig.Subsigs {
+		if subsig.Sig == (crypto.Signature{}) {
+			missing = append(missing, subsig.Key)
+		} else {
+			signed = append(signed, subsig.Key)
+		}
+	}
+	return
+}
+
+// Complete returns true once enough co-signers signed the transaction
+func (p PendingMultisig) Complete() bool {
+	signed, _ := p.Signers()
+	return len(signed) >= int(p.Msig.Threshold)
+}
+
+// AddSubsigs verifies the subsignatures of msig against the transaction and
+// assembles them into the pending multisig
+func (p *PendingMultisig) AddSubsigs(msig crypto.MultisigSig) error {
+	for _, subsig := range msig.Subsigs {
+		if subsig.Sig != (crypto.Signature{}) && !subsig.Key.Verify(p.Transaction, subsig.Sig) {
+			return errInvalidSubsig
+		}
+	}
+
+	assembled, err := crypto.MultisigAssemble([]crypto.MultisigSig{p.Msig, msig})
+	if err != nil {
+		return err
+	}
+	p.Msig = assembled
+	return nil
+}
+
+// SignedTxn returns the signed transaction, once it is complete
+func (p PendingMultisig) SignedTxn() (stxn transactions.SignedTxn, ok bool) {
+	if !p.Complete() {
+		return
+	}
+	stxn = transactions.SignedTxn{
+		Txn:  p.Transaction,
+		Msig: p.Msig,
+	}
+	if p.Address != crypto.Digest(p.Transaction.Sender) {
+		stxn.AuthAddr = basics.Address(p.Address)
+	}
+	return stxn, true
+}
+
 // Metadata represents high-level information about a wallet, like its name, id
 // and what operations it supports
 type Metadata struct {
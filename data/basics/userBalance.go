@@ -17,6 +17,7 @@
 package basics
 
 import (
+	"encoding/binary"
 	"fmt"
 	"reflect"
 
@@ -199,6 +200,13 @@ type AccountData struct {
 	// we created local for applications we opted in to), so that we don't
 	// have to iterate over all of them to compute MinBalance.
 	TotalAppSchema StateSchema `codec:"tsch"`
+
+	// TotalBoxes and TotalBoxBytes track the number of boxes held by
+	// an application account, and the sum of their name and value
+	// sizes, so that MinBalance can charge for them without reading
+	// the boxes themselves.
+	TotalBoxes    uint64 `codec:"tbx"`
+	TotalBoxBytes uint64 `codec:"tbxb"`
 }
 
 // AppLocalState stores the LocalState associated with an application. It also
@@ -282,6 +290,21 @@ type AssetIndex uint64
 // AppParams
 type AppIndex uint64
 
+// ToBeHashed implements crypto.Hashable, so that each application has a
+// deterministic account address that cannot collide with an ed25519 key.
+func (app AppIndex) ToBeHashed() (protocol.HashID, []byte) {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(app))
+	return protocol.AppIndex, buf[:]
+}
+
+// Address returns the address of the account controlled by the application.
+// Funds held by this account can only be moved by inner transactions issued
+// by the application itself.
+func (app AppIndex) Address() Address {
+	return Address(crypto.HashObj(app))
+}
+
 // CreatableIndex represents either an AssetIndex or AppIndex, which come from
 // the same namespace of indices as each other (both assets and apps are
 // "creatables")
@@ -436,10 +459,24 @@ func (u AccountData) MinBalance(proto *config.ConsensusParams) (res MicroAlgos)
 	schemaCost := u.TotalAppSchema.MinBalance(proto)
 	min = AddSaturate(min, schemaCost.Raw)
 
+	// MinBalance for the boxes held by an application account
+	min = AddSaturate(min, u.BoxMinBalance(proto).Raw)
+
 	res.Raw = min
 	return res
 }
 
+// BoxMinBalance computes the part of the minimum balance requirements of an
+// application account charged for its boxes: a flat amount for each box, and
+// an amount for each byte the boxes occupy. Boxes are stored outside of the
+// balance record, so this part doesn't count toward MaximumMinimumBalance.
+func (u AccountData) BoxMinBalance(proto *config.ConsensusParams) (res MicroAlgos) {
+	boxFlatCost := MulSaturate(proto.BoxFlatMinBalance, u.TotalBoxes)
+	boxByteCost := MulSaturate(proto.BoxByteMinBalance, u.TotalBoxBytes)
+	res.Raw = AddSaturate(boxFlatCost, boxByteCost)
+	return res
+}
+
 // VotingStake returns the amount of MicroAlgos associated with the user's account
 // for the purpose of participating in the Algorand protocol.  It assumes the
 // caller has already updated rewards appropriately using WithUpdatedRewards().
@@ -46,6 +46,12 @@ const (
 	// can contain. Its value is verified against consensus parameters in
 	// TestEncodedAppTxnAllocationBounds
 	encodedMaxForeignAssets = 32
+
+	// encodedMaxInnerTransactions sets the allocation bound for the maximum
+	// number of inner transactions that the ApplyData of an application call
+	// decoded off of the wire can contain. Its value is verified against
+	// consensus parameters in TestEncodedAppTxnAllocationBounds
+	encodedMaxInnerTransactions = 16
 )
 
 // OnCompletion is an enum representing some layer 1 side effect that an
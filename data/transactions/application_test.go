@@ -108,5 +108,8 @@ func TestEncodedAppTxnAllocationBounds(t *testing.T) {
 		if proto.MaxAppTxnForeignAssets > encodedMaxForeignAssets {
 			require.Failf(t, "proto.MaxAppTxnForeignAssets > encodedMaxForeignAssets", "protocol version = %s", protoVer)
 		}
+		if proto.MaxInnerTransactions > encodedMaxInnerTransactions {
+			require.Failf(t, "proto.MaxInnerTransactions > encodedMaxInnerTransactions", "protocol version = %s", protoVer)
+		}
 	}
 }
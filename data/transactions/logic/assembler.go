@@ -26,7 +26,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -46,6 +48,9 @@ type labelReference struct {
 	position int
 
 	label string
+
+	// subroutine references may point backward, within a signed 16 bit offset
+	subroutine bool
 }
 
 // OpStream is destination for program and scratch space
@@ -61,6 +66,21 @@ type OpStream struct {
 	bytec        [][]byte
 	noBytecBlock bool
 
+	// number of references to each int and byte literal, used to decide
+	// which constants belong in the intcblock and bytecblock
+	intcRefs  map[uint64]int
+	bytecRefs map[string]int
+
+	// set when the program refers to constants by their index, so the
+	// layout of the corresponding constant block may not be changed
+	intcPinned  bool
+	bytecPinned bool
+
+	// set when reassembling with optimized constant blocks: literals not
+	// found in the block are pushed with pushint and pushbytes
+	pushInts  bool
+	pushBytes bool
+
 	// Keep a stack of the types of what we would push and pop to typecheck a program
 	typeStack []StackType
 
@@ -106,7 +126,12 @@ func (ops *OpStream) RecordSourceLine() {
 
 // ReferToLabel records an opcode label refence to resolve later
 func (ops *OpStream) ReferToLabel(sourceLine, pc int, label string) {
-	ops.labelReferences = append(ops.labelReferences, labelReference{sourceLine, pc, label})
+	ops.labelReferences = append(ops.labelReferences, labelReference{sourceLine, pc, label, false})
+}
+
+// referToSubroutine records a callsub label reference to resolve later
+func (ops *OpStream) referToSubroutine(sourceLine, pc int, label string) {
+	ops.labelReferences = append(ops.labelReferences, labelReference{sourceLine, pc, label, true})
 }
 
 func (ops *OpStream) tpush(argType StackType) {
@@ -155,6 +180,11 @@ func (ops *OpStream) Intc(constIndex uint) error {
 
 // Uint writes opcodes for loading a uint literal
 func (ops *OpStream) Uint(val uint64) error {
+	if ops.intcRefs == nil {
+		ops.intcRefs = make(map[uint64]int)
+	}
+	ops.intcRefs[val]++
+
 	found := false
 	var constIndex uint
 	for i, cv := range ops.intc {
@@ -165,12 +195,26 @@ func (ops *OpStream) Uint(val uint64) error {
 		}
 	}
 	if !found {
+		if ops.pushInts {
+			return ops.pushInt(val)
+		}
 		constIndex = uint(len(ops.intc))
 		ops.intc = append(ops.intc, val)
 	}
 	return ops.Intc(constIndex)
 }
 
+// pushInt writes a pushint opcode with the value as its immediate
+func (ops *OpStream) pushInt(val uint64) error {
+	ops.Out.WriteByte(0x81) // pushint
+	var scratch [binary.MaxVarintLen64]byte
+	l := binary.PutUvarint(scratch[:], val)
+	ops.Out.Write(scratch[:l])
+	ops.trace("pushint %d", val)
+	ops.tpush(StackUint64)
+	return nil
+}
+
 // Bytec writes opcodes for loading a []byte constant onto the stack.
 func (ops *OpStream) Bytec(constIndex uint) error {
 	switch constIndex {
@@ -200,6 +244,11 @@ func (ops *OpStream) Bytec(constIndex uint) error {
 // ByteLiteral writes opcodes and data for loading a []byte literal
 // Values are accumulated so that they can be put into a bytecblock
 func (ops *OpStream) ByteLiteral(val []byte) error {
+	if ops.bytecRefs == nil {
+		ops.bytecRefs = make(map[string]int)
+	}
+	ops.bytecRefs[string(val)]++
+
 	found := false
 	var constIndex uint
 	for i, cv := range ops.bytec {
@@ -210,12 +259,70 @@ func (ops *OpStream) ByteLiteral(val []byte) error {
 		}
 	}
 	if !found {
+		if ops.pushBytes {
+			return ops.pushByteLiteral(val)
+		}
 		constIndex = uint(len(ops.bytec))
 		ops.bytec = append(ops.bytec, val)
 	}
 	return ops.Bytec(constIndex)
 }
 
+// pushByteLiteral writes a pushbytes opcode with the value as its immediate
+func (ops *OpStream) pushByteLiteral(val []byte) error {
+	ops.Out.WriteByte(0x80) // pushbytes
+	var scratch [binary.MaxVarintLen64]byte
+	l := binary.PutUvarint(scratch[:], uint64(len(val)))
+	ops.Out.Write(scratch[:l])
+	ops.Out.Write(val)
+	ops.trace("pushbytes %s", hex.EncodeToString(val))
+	ops.tpush(StackBytes)
+	return nil
+}
+
+// planConstants prepares an OpStream to reassemble the program with constant
+// blocks holding only the literals referenced more than once, most referenced
+// first so that they get the short intc_N and bytec_N opcodes. The remaining
+// literals are pushed with pushint and pushbytes. Constants are left as they
+// are if the program refers to them by index, or if the version of the
+// program predates pushint and pushbytes.
+func (ops *OpStream) planConstants() (planned OpStream, ok bool) {
+	planned = OpStream{Version: ops.Version}
+	if ops.Version < optimizeConstantsEnabledVersion {
+		return
+	}
+
+	if !ops.intcPinned && len(ops.intc) > 0 {
+		// ops.intc is in order of first reference, which breaks ties
+		ints := append([]uint64(nil), ops.intc...)
+		sort.SliceStable(ints, func(i, j int) bool {
+			return ops.intcRefs[ints[i]] > ops.intcRefs[ints[j]]
+		})
+		for _, iv := range ints {
+			if ops.intcRefs[iv] > 1 {
+				planned.intc = append(planned.intc, iv)
+			}
+		}
+		planned.pushInts = true
+	}
+
+	if !ops.bytecPinned && len(ops.bytec) > 0 {
+		byteslices := append([][]byte(nil), ops.bytec...)
+		sort.SliceStable(byteslices, func(i, j int) bool {
+			return ops.bytecRefs[string(byteslices[i])] > ops.bytecRefs[string(byteslices[j])]
+		})
+		for _, bv := range byteslices {
+			if ops.bytecRefs[string(bv)] > 1 {
+				planned.bytec = append(planned.bytec, bv)
+			}
+		}
+		planned.pushBytes = true
+	}
+
+	ok = planned.pushInts || planned.pushBytes
+	return
+}
+
 // Arg writes opcodes for loading from Lsig.Args
 func (ops *OpStream) Arg(val uint64) error {
 	switch val {
@@ -360,6 +467,7 @@ func assembleIntC(ops *OpStream, spec *OpSpec, args []string) error {
 	if err != nil {
 		return err
 	}
+	ops.intcPinned = true
 	return ops.Intc(uint(constIndex))
 }
 func assembleByteC(ops *OpStream, spec *OpSpec, args []string) error {
@@ -367,9 +475,41 @@ func assembleByteC(ops *OpStream, spec *OpSpec, args []string) error {
 	if err != nil {
 		return err
 	}
+	ops.bytecPinned = true
 	return ops.Bytec(uint(constIndex))
 }
 
+// intc_0..intc_3 and bytec_0..bytec_3 refer to constants by index as well
+func assembleIntCN(ops *OpStream, spec *OpSpec, args []string) error {
+	ops.intcPinned = true
+	return asmDefault(ops, spec, args)
+}
+func assembleByteCN(ops *OpStream, spec *OpSpec, args []string) error {
+	ops.bytecPinned = true
+	return asmDefault(ops, spec, args)
+}
+
+func assemblePushInt(ops *OpStream, spec *OpSpec, args []string) error {
+	if len(args) != 1 {
+		return errors.New("pushint needs one argument")
+	}
+	val, err := strconv.ParseUint(args[0], 0, 64)
+	if err != nil {
+		return err
+	}
+	return ops.pushInt(val)
+}
+func assemblePushBytes(ops *OpStream, spec *OpSpec, args []string) error {
+	if len(args) == 0 {
+		return errors.New("pushbytes needs byte literal argument")
+	}
+	val, _, err := parseBinaryArgs(args)
+	if err != nil {
+		return err
+	}
+	return ops.pushByteLiteral(val)
+}
+
 func base32DecdodeAnyPadding(x string) (val []byte, err error) {
 	val, err = base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(x)
 	if err != nil {
@@ -536,6 +676,7 @@ func assembleIntCBlock(ops *OpStream, spec *OpSpec, args []string) error {
 	l := binary.PutUvarint(scratch[:], uint64(len(args)))
 	ops.Out.Write(scratch[:l])
 	ops.intc = make([]uint64, len(args))
+	ops.intcPinned = true
 	for i, xs := range args {
 		cu, err := strconv.ParseUint(xs, 0, 64)
 		if err != nil {
@@ -571,6 +712,7 @@ func assembleByteCBlock(ops *OpStream, spec *OpSpec, args []string) error {
 	}
 	ops.bytec = bvals
 	ops.noBytecBlock = true
+	ops.bytecPinned = true
 	return nil
 }
 
@@ -614,6 +756,22 @@ func assembleBranch(ops *OpStream, spec *OpSpec, args []string) error {
 	return nil
 }
 
+func assembleCallSub(ops *OpStream, spec *OpSpec, args []string) error {
+	if len(args) != 1 {
+		return errors.New("callsub operation needs label argument")
+	}
+	ops.referToSubroutine(ops.sourceLine, ops.Out.Len(), args[0])
+	err := ops.checkArgs(*spec)
+	if err != nil {
+		return err
+	}
+	ops.Out.WriteByte(spec.Opcode)
+	// zero bytes will get replaced with actual offset in resolveLabels()
+	ops.Out.WriteByte(0)
+	ops.Out.WriteByte(0)
+	return nil
+}
+
 func assembleLoad(ops *OpStream, spec *OpSpec, args []string) error {
 	if len(args) != 1 {
 		return errors.New("load operation needs one argument")
@@ -651,6 +809,27 @@ func assembleStore(ops *OpStream, spec *OpSpec, args []string) error {
 	return nil
 }
 
+func assembleDig(ops *OpStream, spec *OpSpec, args []string) error {
+	if len(args) != 1 {
+		return errors.New("dig operation needs one argument")
+	}
+	val, err := strconv.ParseUint(args[0], 0, 64)
+	if err != nil {
+		return err
+	}
+	if val > 255 {
+		return errors.New("dig limited to 0..255")
+	}
+	err = ops.checkArgs(*spec)
+	if err != nil {
+		return err
+	}
+	ops.tpusha(spec.Returns)
+	ops.Out.WriteByte(spec.Opcode)
+	ops.Out.WriteByte(byte(val))
+	return nil
+}
+
 func assembleSubstring(ops *OpStream, spec *OpSpec, args []string) error {
 	if len(args) != 2 {
 		return errors.New("substring expects 2 args")
@@ -827,6 +1006,29 @@ func assembleGlobal(ops *OpStream, spec *OpSpec, args []string) error {
 	return ops.Global(uint64(val))
 }
 
+func assembleItxnField(ops *OpStream, spec *OpSpec, args []string) error {
+	if len(args) != 1 {
+		return errors.New("itxn_field expects one argument")
+	}
+	fs, ok := txnFieldSpecByName[args[0]]
+	if !ok {
+		return fmt.Errorf("itxn_field unknown arg %s", args[0])
+	}
+	if !innerTxnFields[fs.field] {
+		return fmt.Errorf("itxn_field %s is not settable", args[0])
+	}
+	if fs.version > ops.Version {
+		return fmt.Errorf("itxn_field %s available in version %d. Missed #pragma version?", args[0], fs.version)
+	}
+	err := ops.checkArgs(*spec)
+	if err != nil {
+		return err
+	}
+	ops.Out.WriteByte(spec.Opcode)
+	ops.Out.WriteByte(uint8(fs.field))
+	return nil
+}
+
 func assembleAssetHolding(ops *OpStream, spec *OpSpec, args []string) error {
 	if len(args) != 1 {
 		return errors.New("asset_holding_get expects one argument")
@@ -1098,11 +1300,11 @@ func (ops *OpStream) resolveLabels() (err error) {
 		}
 		// all branch instructions (currently) are opcode byte and 2 offset bytes, and the destination is relative to the next pc as if the branch was a no-op
 		naturalPc := lr.position + 3
-		if dest < naturalPc {
+		if dest < naturalPc && !lr.subroutine {
 			return fmt.Errorf(":%d label %v is before reference but only forward jumps are allowed", lr.sourceLine, lr.label)
 		}
 		jump := dest - naturalPc
-		if jump > 0x7fff {
+		if jump > 0x7fff || jump < -0x8000 {
 			return fmt.Errorf(":%d label %v is too far away", lr.sourceLine, lr.label)
 		}
 		raw[lr.position+1] = uint8(jump >> 8)
@@ -1231,6 +1433,15 @@ func AssembleStringWithVersionEx(text string, version uint64) ([]byte, map[int]i
 	if err != nil {
 		return nil, nil, err
 	}
+	// Now that all the constants are known, assemble again with their
+	// blocks laid out to make the program as small as possible
+	if planned, ok := ops.planConstants(); ok {
+		ops = planned
+		err = ops.assemble(strings.NewReader(text))
+		if err != nil {
+			return nil, nil, err
+		}
+	}
 	program, err := ops.Bytes()
 	return program, ops.offsetToLine, err
 }
@@ -1465,6 +1676,47 @@ func checkByteConstBlock(cx *evalContext) int {
 	return 1
 }
 
+func parsePushInt(program []byte, pc int) (val uint64, nextpc int, err error) {
+	pos := pc + 1
+	val, bytesUsed := binary.Uvarint(program[pos:])
+	if bytesUsed <= 0 {
+		err = fmt.Errorf("could not decode int at pc=%d", pos)
+		return
+	}
+	nextpc = pos + bytesUsed
+	return
+}
+
+func checkPushInt(cx *evalContext) int {
+	_, cx.nextpc, cx.err = parsePushInt(cx.program, cx.pc)
+	return 1
+}
+
+var errShortPushBytes = errors.New("pushbytes ran past end of program")
+
+func parsePushBytes(program []byte, pc int) (val []byte, nextpc int, err error) {
+	pos := pc + 1
+	length, bytesUsed := binary.Uvarint(program[pos:])
+	if bytesUsed <= 0 {
+		err = fmt.Errorf("could not decode []byte length at pc=%d", pos)
+		return
+	}
+	pos += bytesUsed
+	end := uint64(pos) + length
+	if end > uint64(len(program)) || end < uint64(pos) {
+		err = errShortPushBytes
+		return
+	}
+	val = program[pos:end]
+	nextpc = int(end)
+	return
+}
+
+func checkPushBytes(cx *evalContext) int {
+	_, cx.nextpc, cx.err = parsePushBytes(cx.program, cx.pc)
+	return 1
+}
+
 func disIntcblock(dis *disassembleState, spec *OpSpec) {
 	var intc []uint64
 	intc, dis.nextpc, dis.err = parseIntcblock(dis.program, dis.pc)
@@ -1525,6 +1777,36 @@ func disBytec(dis *disassembleState, spec *OpSpec) {
 	_, dis.err = fmt.Fprintf(dis.out, "bytec %d\n", dis.program[dis.pc+1])
 }
 
+func disPushInt(dis *disassembleState, spec *OpSpec) {
+	var val uint64
+	val, dis.nextpc, dis.err = parsePushInt(dis.program, dis.pc)
+	if dis.err != nil {
+		return
+	}
+	_, dis.err = fmt.Fprintf(dis.out, "%s %d\n", spec.Name, val)
+}
+
+func disPushBytes(dis *disassembleState, spec *OpSpec) {
+	var val []byte
+	val, dis.nextpc, dis.err = parsePushBytes(dis.program, dis.pc)
+	if dis.err != nil {
+		return
+	}
+	_, dis.err = fmt.Fprintf(dis.out, "%s 0x%s\n", spec.Name, hex.EncodeToString(val))
+}
+
+func disDig(dis *disassembleState, spec *OpSpec) {
+	lastIdx := dis.pc + 1
+	if len(dis.program) <= lastIdx {
+		missing := lastIdx - len(dis.program) + 1
+		dis.err = fmt.Errorf("unexpected %s opcode end: missing %d bytes", spec.Name, missing)
+		return
+	}
+	n := uint(dis.program[dis.pc+1])
+	dis.nextpc = dis.pc + 2
+	_, dis.err = fmt.Fprintf(dis.out, "%s %d\n", spec.Name, n)
+}
+
 func disArg(dis *disassembleState, spec *OpSpec) {
 	lastIdx := dis.pc + 1
 	if len(dis.program) <= lastIdx {
@@ -1620,6 +1902,22 @@ func disGlobal(dis *disassembleState, spec *OpSpec) {
 	_, dis.err = fmt.Fprintf(dis.out, "global %s\n", GlobalFieldNames[garg])
 }
 
+func disItxnField(dis *disassembleState, spec *OpSpec) {
+	lastIdx := dis.pc + 1
+	if len(dis.program) <= lastIdx {
+		missing := lastIdx - len(dis.program) + 1
+		dis.err = fmt.Errorf("unexpected %s opcode end: missing %d bytes", spec.Name, missing)
+		return
+	}
+	dis.nextpc = dis.pc + 2
+	txarg := dis.program[dis.pc+1]
+	if int(txarg) >= len(TxnFieldNames) {
+		dis.err = fmt.Errorf("invalid txn arg index %d at pc=%d", txarg, dis.pc)
+		return
+	}
+	_, dis.err = fmt.Fprintf(dis.out, "%s %s\n", spec.Name, TxnFieldNames[txarg])
+}
+
 func disBranch(dis *disassembleState, spec *OpSpec) {
 	lastIdx := dis.pc + 2
 	if len(dis.program) <= lastIdx {
@@ -1640,6 +1938,26 @@ func disBranch(dis *disassembleState, spec *OpSpec) {
 	_, dis.err = fmt.Fprintf(dis.out, "%s %s\n", spec.Name, label)
 }
 
+func disCallSub(dis *disassembleState, spec *OpSpec) {
+	lastIdx := dis.pc + 2
+	if len(dis.program) <= lastIdx {
+		missing := lastIdx - len(dis.program) + 1
+		dis.err = fmt.Errorf("unexpected %s opcode end: missing %d bytes", spec.Name, missing)
+		return
+	}
+
+	dis.nextpc = dis.pc + 3
+	offset := int16(uint16(dis.program[dis.pc+1])<<8 | uint16(dis.program[dis.pc+2]))
+	target := int(offset) + dis.pc + 3
+	label, labelExists := dis.pendingLabels[target]
+	if !labelExists {
+		dis.labelCount++
+		label = fmt.Sprintf("label%d", dis.labelCount)
+		dis.putLabel(label, target)
+	}
+	_, dis.err = fmt.Fprintf(dis.out, "%s %s\n", spec.Name, label)
+}
+
 func disLoad(dis *disassembleState, spec *OpSpec) {
 	lastIdx := dis.pc + 1
 	if len(dis.program) <= lastIdx {
@@ -1718,6 +2036,24 @@ func disassembleInstrumented(program []byte) (text string, ds disInfo, err error
 		return
 	}
 	fmt.Fprintf(dis.out, "// version %d\n", version)
+	if version >= subroutineVersion {
+		// callsub may refer to a label preceding it, so collect all the
+		// labels before producing any output.
+		pre := disassembleState{program: program, out: ioutil.Discard, pc: vlen}
+		for pre.pc < len(program) {
+			op := opsByOpcode[version][program[pre.pc]]
+			if op.Name == "" {
+				break
+			}
+			op.dis(&pre, &op)
+			if pre.err != nil {
+				break
+			}
+			pre.pc = pre.nextpc
+		}
+		dis.labelCount = pre.labelCount
+		dis.pendingLabels = pre.pendingLabels
+	}
 	dis.pc = vlen
 	for dis.pc < len(program) {
 		err = dis.outputLabelIfNeeded()
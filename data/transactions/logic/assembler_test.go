@@ -212,6 +212,80 @@ txn ConfigAssetClawback
 txn FreezeAsset
 txn FreezeAssetAccount
 txn FreezeAssetFrozen
+itxn_begin
+int 1
+itxn_field TypeEnum
+itxn_submit
+callsub stuff
+stuff:
+retsub
+pushint 1
+pushbytes 0x4242
+int 1
+int 2
+shl
+int 1
+shr
+sqrt
+int 2
+getbit
+int 1
+int 0
+setbit
+byte 0x1234
+int 0
+getbyte
+pop
+byte 0x1234
+int 1
+int 2
+setbyte
+int 1
+int 2
+swap
+int 0
+select
+dig 2
+byte 0x1234
+byte 0x1234
+b+
+byte 0x1234
+b-
+byte 0x1234
+b/
+byte 0x1234
+b*
+byte 0x1234
+b%
+byte 0x1234
+b|
+byte 0x1234
+b&
+byte 0x1234
+b==
+byte 0x1234
+byte 0x1234
+b<
+byte 0x1234
+int 1
+box_create
+byte 0x1234
+int 0
+int 1
+box_extract
+byte 0x1234
+int 0
+byte 0x1234
+box_replace
+byte 0x1234
+box_del
+byte 0x1234
+box_len
+byte 0x1234
+box_get
+byte 0x1234
+byte 0x1234
+box_put
 `
 
 // Check that assembly output is stable across time.
@@ -238,7 +312,7 @@ func TestAssemble(t *testing.T) {
 	program, err := AssembleStringWithVersion(bigTestAssembleNonsenseProgram, AssemblerMaxVersion)
 	require.NoError(t, err)
 	// check that compilation is stable over time and we assemble to the same bytes this month that we did last month.
-	expectedBytes, _ := hex.DecodeString("022008b7a60cf8acd19181cf959a12f8acd19181cf951af8acd19181cf15f8acd191810f01020026050212340c68656c6c6f20776f726c6421208dae2087fbba51304eb02b91f656948397a7946390e8cb70fc9ea4d95f92251d024242047465737400320032013202320328292929292a0431003101310231043105310731083109310a310b310c310d310e310f3111311231133114311533000033000133000233000433000533000733000833000933000a33000b33000c33000d33000e33000f3300113300123300133300143300152d2e0102222324252104082209240a220b230c240d250e230f23102311231223132314181b1c2b171615400003290349483403350222231d4a484848482a50512a63222352410003420000432105602105612105270463484821052b62482b642b65484821052b2106662b21056721072b682b692107210570004848210771004848361c0037001a0031183119311b311d311e311f3120210721051e312131223123312431253126312731283129312a312b312c312d312e312f")
+	expectedBytes, _ := hex.DecodeString("032008b7a60cf8acd19181cf959a12f8acd19181cf951af8acd19181cf15f8acd191810f01020026050212340c68656c6c6f20776f726c6421208dae2087fbba51304eb02b91f656948397a7946390e8cb70fc9ea4d95f92251d024242047465737400320032013202320328292929292a0431003101310231043105310731083109310a310b310c310d310e310f3111311231133114311533000033000133000233000433000533000733000833000933000a33000b33000c33000d33000e33000f3300113300123300133300143300152d2e0102222324252104082209240a220b230c240d250e230f23102311231223132314181b1c2b171615400003290349483403350222231d4a484848482a50512a63222352410003420000432105602105612105270463484821052b62482b642b65484821052b2106662b21056721072b682b692107210570004848210771004848361c0037001a0031183119311b311d311e311f3120210721051e312131223123312431253126312731283129312a312b312c312d312e312fb12105b210b38800008981018002424221052106902105919221065321052107542821075548282105210656210521064c21074d4b022828a028a128a228a328a428a728a828a62828a5282105b92821072105ba28210728bb28bc28bd28be2828bf")
 	if bytes.Compare(expectedBytes, program) != 0 {
 		// this print is for convenience if the program has been changed. the hex string can be copy pasted back in as a new expected result.
 		t.Log(hex.EncodeToString(program))
@@ -474,7 +548,12 @@ func TestAssembleInt(t *testing.T) {
 			program, err := AssembleStringWithVersion(text, v)
 			require.NoError(t, err)
 			s := hex.EncodeToString(program)
-			require.Equal(t, mutateProgVersion(v, "012001bef5fad70c22"), s)
+			expected := "012001bef5fad70c22"
+			if v >= optimizeConstantsEnabledVersion {
+				// a constant used only once is pushed rather than stored in the intcblock
+				expected = "0181bef5fad70c"
+			}
+			require.Equal(t, mutateProgVersion(v, expected), s)
 		})
 	}
 }
@@ -510,11 +589,15 @@ func TestAssembleBytes(t *testing.T) {
 	}
 	for v := uint64(1); v <= AssemblerMaxVersion; v++ {
 		t.Run(fmt.Sprintf("v=%d", v), func(t *testing.T) {
+			expected := "0126010661626364656628"
+			if v >= optimizeConstantsEnabledVersion {
+				expected = "018006616263646566"
+			}
 			for _, vi := range variations {
 				program, err := AssembleStringWithVersion(vi, v)
 				require.NoError(t, err)
 				s := hex.EncodeToString(program)
-				require.Equal(t, mutateProgVersion(v, "0126010661626364656628"), s)
+				require.Equal(t, mutateProgVersion(v, expected), s)
 			}
 
 		})
@@ -774,11 +857,46 @@ byte b64 avGWRM+yy3BCavBDXO/FYTNZ6o2Jai5edsMCBdDEz//=
 			program, err := AssembleStringWithVersion(text, v)
 			require.NoError(t, err)
 			s := hex.EncodeToString(program)
-			require.Equal(t, mutateProgVersion(v, "01200101260320fff19644cfb2cb70426af0435cefc5613359ea8d896a2e5e76c30205d0c4cfed206af19644cfb2cb70426af0435cefc5613359ea8d896a2e5e76c30205d0c4cfff20fff19644cfb2cb70426af0435cefc5613359ea8d896a2e5e76c30205d0c4cfef2829122210122a291211"), s)
+			expected := "01200101260320fff19644cfb2cb70426af0435cefc5613359ea8d896a2e5e76c30205d0c4cfed206af19644cfb2cb70426af0435cefc5613359ea8d896a2e5e76c30205d0c4cfff20fff19644cfb2cb70426af0435cefc5613359ea8d896a2e5e76c30205d0c4cfef2829122210122a291211"
+			if v >= optimizeConstantsEnabledVersion {
+				// only the second constant is used twice, so it alone goes to the bytecblock
+				expected = "012601206af19644cfb2cb70426af0435cefc5613359ea8d896a2e5e76c30205d0c4cfff8020fff19644cfb2cb70426af0435cefc5613359ea8d896a2e5e76c30205d0c4cfed2812810110128020fff19644cfb2cb70426af0435cefc5613359ea8d896a2e5e76c30205d0c4cfef281211"
+			}
+			require.Equal(t, mutateProgVersion(v, expected), s)
 		})
 	}
 }
 
+func TestAssembleOptimizedConstants(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		source   string
+		expected string
+	}{
+		// a constant referenced once is pushed instead of stored in the intcblock
+		{"int 5\nint 7\nint 5\n+\n+", "03200105228107220808"},
+		// the most referenced constants get the smallest indices
+		{"int 1\nint 2\nint 2\nint 2\nint 1\n+\n+\n+\n+", "0320020201232222222308080808"},
+		{"byte 0x01\nbyte 0x02\nbyte 0x02\nconcat\nconcat\nlen", "03260101028001012828505015"},
+		// an explicit intcblock pins the int constants but not the byte constants
+		{"intcblock 7 9\nint 9\nbyte 0x01\nlen\n+", "0320020709238001011508"},
+		// an explicit bytec pins the byte constants but not the int constants
+		{"bytecblock 0x01\nbytec 0\nbyte 0x01\nconcat\nlen\nint 2\n==", "032601010128285015810212"},
+	}
+	for _, test := range tests {
+		program, err := AssembleStringWithVersion(test.source, optimizeConstantsEnabledVersion)
+		require.NoError(t, err, test.source)
+		require.Equal(t, test.expected, hex.EncodeToString(program), test.source)
+
+		// the optimization does not change what the program computes
+		ep := defaultEvalParams(nil, nil)
+		pass, err := Eval(program, ep)
+		require.NoError(t, err, test.source)
+		require.True(t, pass, test.source)
+	}
+}
+
 func TestAssembleRejectUnkLabel(t *testing.T) {
 	t.Parallel()
 	text := `int 1
@@ -811,7 +929,7 @@ func TestAssembleDisassemble(t *testing.T) {
 	// Specifically constructed program text that should be recreated by Disassemble()
 	// TODO: disassemble to int/byte psuedo-ops instead of raw intcblock/bytecblock/intc/bytec
 	t.Parallel()
-	text := `// version 2
+	text := `// version 3
 intcblock 0 1 2 3 4 5
 bytecblock 0xcafed00d 0x1337 0x2001 0xdeadbeef 0x70077007
 intc_1
@@ -840,6 +958,7 @@ global LogicSigVersion
 global Round
 global LatestTimestamp
 global CurrentApplicationID
+global CurrentApplicationAddress
 txn Sender
 txn Fee
 bnz label1
@@ -915,7 +1034,8 @@ func TestAssembleDisassembleCycle(t *testing.T) {
 	t.Parallel()
 
 	tests := map[uint64]string{
-		2: bigTestAssembleNonsenseProgram,
+		3: bigTestAssembleNonsenseProgram,
+		2: bigTestAssembleNonsenseProgram[:strings.Index(bigTestAssembleNonsenseProgram, "itxn_begin")],
 		1: bigTestAssembleNonsenseProgram[:strings.Index(bigTestAssembleNonsenseProgram, "dup2")],
 	}
 
@@ -925,7 +1045,7 @@ func TestAssembleDisassembleCycle(t *testing.T) {
 			require.NoError(t, err)
 			t2, err := Disassemble(program)
 			require.NoError(t, err)
-			p2, err := AssembleStringV2(t2)
+			p2, err := AssembleStringWithVersion(t2, v)
 			if err != nil {
 				t.Log(t2)
 			}
@@ -1000,7 +1120,7 @@ func TestAssembleDisassembleErrors(t *testing.T) {
 	source = "int 0\nasset_params_get AssetTotal"
 	program, err = AssembleStringWithVersion(source, AssemblerMaxVersion)
 	require.NoError(t, err)
-	program[6] = 0x50 // params field
+	program[len(program)-1] = 0x50 // params field
 	_, err = Disassemble(program)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "invalid asset params arg index")
@@ -1262,7 +1382,7 @@ err
 // comment
 !
 `
-	program, offsets, err = AssembleStringWithVersionEx(source, AssemblerMaxVersion)
+	program, offsets, err = AssembleStringWithVersionEx(source, 2)
 	require.NoError(t, err)
 	require.Equal(t, 6, len(program))
 	require.Equal(t, 2, len(offsets))
@@ -1278,6 +1398,24 @@ err
 	line, ok = offsets[5]
 	require.True(t, ok)
 	require.Equal(t, 2, line)
+
+	// with constant optimization int 0 becomes pushint 0
+	program, offsets, err = AssembleStringWithVersionEx(source, optimizeConstantsEnabledVersion)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(program))
+	require.Equal(t, 2, len(offsets))
+	// pushint 0
+	line, ok = offsets[1]
+	require.True(t, ok)
+	require.Equal(t, 0, line)
+	// pushint immediate
+	line, ok = offsets[2]
+	require.False(t, ok)
+	require.Equal(t, 0, line)
+	// !
+	line, ok = offsets[3]
+	require.True(t, ok)
+	require.Equal(t, 2, line)
 }
 
 func TestHasStatefulOps(t *testing.T) {
@@ -332,33 +332,35 @@ func TestBackwardCompatTEALv1(t *testing.T) {
 
 // ensure v2 fields error on pre TEAL v2 logicsig version
 // ensure v2 fields error in v1 program
+// ensure v2 fields error in v1 program
 func TestBackwardCompatGlobalFields(t *testing.T) {
 	t.Parallel()
-	var fields []string
+	var fields []globalFieldSpec
 	for _, fs := range globalFieldSpecs {
 		if fs.version > 1 {
-			fields = append(fields, fs.gfield.String())
+			fields = append(fields, fs)
 		}
 	}
 	require.Greater(t, len(fields), 1)
 
 	ledger := makeTestLedger(nil)
 	for _, field := range fields {
-		text := fmt.Sprintf("global %s", field)
+		text := fmt.Sprintf("global %s", field.gfield.String())
+		available := fmt.Sprintf("available in version %d", field.version)
 		// check V1 assembler fails
 		program, err := AssembleStringWithVersion(text, 0)
 		require.Error(t, err)
-		require.Contains(t, err.Error(), "available in version 2")
+		require.Contains(t, err.Error(), available)
 		require.Nil(t, program)
 
 		program, err = AssembleStringWithVersion(text, 1)
 		require.Error(t, err)
-		require.Contains(t, err.Error(), "available in version 2")
+		require.Contains(t, err.Error(), available)
 		require.Nil(t, program)
 
 		program, err = AssembleString(text)
 		require.Error(t, err)
-		require.Contains(t, err.Error(), "available in version 2")
+		require.Contains(t, err.Error(), available)
 		require.Nil(t, program)
 
 		program, err = AssembleStringWithVersion(text, AssemblerMaxVersion)
@@ -529,3 +531,41 @@ done:`
 		})
 	}
 }
+
+func TestBackwardCompatConstants(t *testing.T) {
+	// TEAL v3 assembler pushes single use constants with pushint and pushbytes
+	// but programs of earlier versions must assemble to the same bytes as before
+	t.Parallel()
+	source := `int 1
+byte 0x41
+len
+==`
+
+	for v := uint64(0); v <= AssemblerMaxVersion; v++ {
+		t.Run(fmt.Sprintf("v=%d", v), func(t *testing.T) {
+			program, err := AssembleStringWithVersion(source, v)
+			require.NoError(t, err)
+			expected := fmt.Sprintf("%02x2001012601014122281512", v)
+			if v >= optimizeConstantsEnabledVersion {
+				expected = fmt.Sprintf("%02x81018001411512", v)
+			}
+			require.Equal(t, expected, hex.EncodeToString(program))
+
+			ep := defaultEvalParams(nil, nil)
+			pass, err := Eval(program, ep)
+			require.NoError(t, err)
+			require.True(t, pass)
+		})
+	}
+
+	for v := uint64(0); v < optimizeConstantsEnabledVersion; v++ {
+		t.Run(fmt.Sprintf("v=%d", v), func(t *testing.T) {
+			_, err := AssembleStringWithVersion("pushint 1", v)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "unknown opcode pushint")
+			_, err = AssembleStringWithVersion("pushbytes 0x41\nlen", v)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "unknown opcode pushbytes")
+		})
+	}
+}
@@ -65,6 +65,9 @@ var opDocList = []stringString{
 	{"~", "bitwise invert value X"},
 	{"mulw", "A times B out to 128-bit long result as low (top) and high uint64 values on the stack"},
 	{"addw", "A plus B out to 128-bit long result as sum (top) and carry-bit uint64 values on the stack"},
+	{"shl", "A times 2^B, modulo 2^64"},
+	{"shr", "A divided by 2^B"},
+	{"sqrt", "The largest integer X such that X^2 <= A"},
 	{"intcblock", "load block of uint64 constants"},
 	{"intc", "push value from uint64 constants to stack by index into constants"},
 	{"intc_0", "push constant 0 from intcblock to stack"},
@@ -77,6 +80,8 @@ var opDocList = []stringString{
 	{"bytec_1", "push constant 1 from bytecblock to stack"},
 	{"bytec_2", "push constant 2 from bytecblock to stack"},
 	{"bytec_3", "push constant 3 from bytecblock to stack"},
+	{"pushbytes", "push the following program bytes to the stack"},
+	{"pushint", "push immediate UINT to the stack as an integer"},
 	{"arg", "push Args[N] value to stack by index"},
 	{"arg_0", "push Args[0] to stack"},
 	{"arg_1", "push Args[1] to stack"},
@@ -96,9 +101,16 @@ var opDocList = []stringString{
 	{"pop", "discard value X from stack"},
 	{"dup", "duplicate last value on stack"},
 	{"dup2", "duplicate two last values on stack: A, B -> A, B, A, B"},
+	{"dig", "push the Nth value from the top of the stack. dig 0 is equivalent to dup"},
+	{"swap", "swaps two last values on stack: A, B -> B, A"},
+	{"select", "selects one of two values based on top-of-stack: A, B, C -> (if C != 0 then B else A)"},
 	{"concat", "pop two byte strings A and B and join them, push the result"},
 	{"substring", "pop a byte string X. For immediate values in 0..255 M and N: extract a range of bytes from it starting at M up to but not including N, push the substring result. If N < M, or either is larger than the string length, the program fails"},
 	{"substring3", "pop a byte string A and two integers B and C. Extract a range of bytes from A starting at B up to but not including C, push the substring result. If C < B, or either is larger than the string length, the program fails"},
+	{"getbit", "pop a target A (integer or byte-array), and index B. Push the Bth bit of A."},
+	{"setbit", "pop a target A, index B, and bit C. Set the Bth bit of A to C, and push the result"},
+	{"getbyte", "pop a byte-array A and integer B. Extract the Bth byte of A and push it as an integer"},
+	{"setbyte", "pop a byte-array A, integer B, and small integer C (between 0..255). Set the Bth byte of A to C, and push the result"},
 	{"balance", "get balance for the requested account specified by Txn.Accounts[A] in microalgos. A is specified as an account index in the Accounts field of the ApplicationCall transaction, zero index means the sender"},
 	{"app_opted_in", "check if account specified by Txn.Accounts[A] opted in for the application B => {0 or 1}"},
 	{"app_local_get", "read from account specified by Txn.Accounts[A] from local state of the current application key B => value"},
@@ -111,6 +123,27 @@ var opDocList = []stringString{
 	{"app_global_del", "delete key A from a global state of the current application"},
 	{"asset_holding_get", "read from account specified by Txn.Accounts[A] and asset B holding field X (imm arg) => {0 or 1 (top), value}"},
 	{"asset_params_get", "read from asset Txn.ForeignAssets[A] params field X (imm arg) => {0 or 1 (top), value}"},
+	{"callsub", "branch unconditionally to offset, pushing the next instruction onto the call stack"},
+	{"retsub", "pop the top instruction from the call stack and branch to it"},
+	{"b+", "A plus B, where A and B are byte-arrays interpreted as big-endian unsigned integers"},
+	{"b-", "A minus B, where A and B are byte-arrays interpreted as big-endian unsigned integers. Panic on underflow."},
+	{"b/", "A divided by B, where A and B are byte-arrays interpreted as big-endian unsigned integers. Panic if B is zero."},
+	{"b*", "A times B, where A and B are byte-arrays interpreted as big-endian unsigned integers."},
+	{"b%", "A modulo B, where A and B are byte-arrays interpreted as big-endian unsigned integers. Panic if B is zero."},
+	{"b<", "A is less than B, where A and B are byte-arrays interpreted as big-endian unsigned integers => { 0 or 1}"},
+	{"b==", "A is equal to B, where A and B are byte-arrays interpreted as big-endian unsigned integers => { 0 or 1}"},
+	{"b|", "A bitwise-or B, where A and B are byte-arrays, zero-left extended to the greater of their lengths"},
+	{"b&", "A bitwise-and B, where A and B are byte-arrays, zero-left extended to the greater of their lengths"},
+	{"itxn_begin", "begin preparation of a new inner transaction, sent from the account of the current application"},
+	{"itxn_field", "set field F of the current inner transaction to A"},
+	{"itxn_submit", "submit the current inner transaction, to be executed once the program approves"},
+	{"box_create", "create a box named A, of length B. Fail if A is empty or B exceeds the maximum box size. Returns 0 if A already existed, else 1"},
+	{"box_extract", "read C bytes from box A, starting at offset B. Fail if A does not exist, or the byte range is outside A's size."},
+	{"box_replace", "write byte-array C into box A, starting at offset B. Fail if A does not exist, or the byte range is outside A's size."},
+	{"box_del", "delete box named A if it exists. Return 1 if A existed, 0 otherwise"},
+	{"box_len", "X is the length of box A if A exists, else 0. Y is 1 if A exists, else 0."},
+	{"box_get", "X is the contents of box A if A exists, else ''. Y is 1 if A exists, else 0."},
+	{"box_put", "replaces the contents of box A with byte-array B. Fails if A exists and len(B) != len(box A). Creates A if it does not exist"},
 }
 
 var opDocByName map[string]string
@@ -129,6 +162,8 @@ var opcodeImmediateNoteList = []stringString{
 	{"intc", "{uint8 int constant index}"},
 	{"bytecblock", "{varuint length} [({varuint value length} bytes), ...]"},
 	{"bytec", "{uint8 byte constant index}"},
+	{"pushbytes", "{varuint length} {bytes}"},
+	{"pushint", "{varuint int}"},
 	{"arg", "{uint8 arg index N}"},
 	{"txn", "{uint8 transaction field index}"},
 	{"gtxn", "{uint8 transaction group index}{uint8 transaction field index}"},
@@ -140,9 +175,12 @@ var opcodeImmediateNoteList = []stringString{
 	{"b", "{0..0x7fff forward branch offset, big endian}"},
 	{"load", "{uint8 position in scratch space to load from}"},
 	{"store", "{uint8 position in scratch space to store to}"},
+	{"dig", "{uint8 depth}"},
 	{"substring", "{uint8 start position}{uint8 end position}"},
 	{"asset_holding_get", "{uint8 asset holding field index}"},
 	{"asset_params_get", "{uint8 asset params field index}"},
+	{"callsub", "{-0x8000..0x7fff signed subroutine offset, big endian}"},
+	{"itxn_field", "{uint8 transaction field index}"},
 }
 var opcodeImmediateNotes map[string]string
 
@@ -162,6 +200,11 @@ var opDocExtraList = []stringString{
 	{"b", "See `bnz` for details on how branches work. `b` always jumps to the offset."},
 	{"intcblock", "`intcblock` loads following program bytes into an array of integer constants in the evaluator. These integer constants can be referred to by `intc` and `intc_*` which will push the value onto the stack. Subsequent calls to `intcblock` reset and replace the integer constants available to the script."},
 	{"bytecblock", "`bytecblock` loads the following program bytes into an array of byte string constants in the evaluator. These constants can be referred to by `bytec` and `bytec_*` which will push the value onto the stack. Subsequent calls to `bytecblock` reset and replace the bytes constants available to the script."},
+	{"pushbytes", "pushbytes args are not added to the bytecblock during assembly processes"},
+	{"pushint", "pushint args are not added to the intcblock during assembly processes"},
+	{"getbit", "see explanation of bit ordering in setbit"},
+	{"setbit", "When A is a uint64, index 0 is the least significant bit. Setting bit 3 to 1 on the integer 0 yields 8, or 2^3. When A is a byte array, index 0 is the leftmost bit of the leftmost byte. Setting bits 0 through 11 to 1 in a 4-byte-array of 0s yields the byte array 0xfff00000. Setting bit 3 to 1 on the 1-byte-array 0x00 yields the byte array 0x10."},
+	{"shl", "`shl` and `shr` fail if B is larger than 63."},
 	{"*", "Overflow is an error condition which halts execution and fails the transaction. Full precision is available from `mulw`."},
 	{"+", "Overflow is an error condition which halts execution and fails the transaction. Full precision is available from `addw`."},
 	{"txn", "FirstValidTime causes the program to fail. The field is reserved for future use."},
@@ -178,6 +221,18 @@ var opDocExtraList = []stringString{
 	{"app_global_del", "params: state key.\n\nDeleting a key which is already absent has no effect on the application global state. (In particular, it does _not_ cause the program to fail.)"},
 	{"asset_holding_get", "params: account index, asset id. Return: did_exist flag (1 if exist and 0 otherwise), value."},
 	{"asset_params_get", "params: txn.ForeignAssets offset. Return: did_exist flag (1 if exist and 0 otherwise), value."},
+	{"callsub", "The call stack is separate from the data stack. Only `callsub` and `retsub` manipulate it. Unlike the branch offsets of `bnz`, the subroutine offset is a signed 16 bit integer, so a subroutine may precede the `callsub` referring to it. The call stack is limited to 8 nested calls.\n\nSince a subroutine may run more than once, programs of LogicSigVersion 3 and above are no longer rejected for possible loops. Instead, the cost of every executed instruction is accumulated while running, and the program fails once the total exceeds LogicSigMaxCost (or MaxAppProgramCost in stateful mode)."},
+	{"retsub", "The call stack is separate from the data stack. Only `callsub` and `retsub` manipulate it. `retsub` fails if the call stack is empty."},
+	{"b+", "The result may be one byte longer than the longest input, so it may not be usable as an input to further byte math."},
+	{"b*", "The result may be as long as the sum of the lengths of the inputs, so it may not be usable as an input to further byte math."},
+	{"b==", "Unlike `==`, leading zero bytes are ignored, so 0x0001 b== 0x01 is true."},
+	{"itxn_begin", "`itxn_begin` initializes Sender to the application address, Fee to the minimum allowable, and FirstValid/LastValid to the current round. At most MaxInnerTransactions inner transactions may be submitted by a single program."},
+	{"itxn_field", "`itxn_field` fails if A is of the wrong type for F, including a byte array of the wrong size for an address. Only the fields of payment, asset transfer and asset configuration transactions may be set: Sender, FirstValid, LastValid, Lease and RekeyTo cannot."},
+	{"itxn_submit", "`itxn_submit` fails if the Type of the inner transaction is not `pay`, `axfer` or `acfg`. Submitted inner transactions are executed in order from the application account after the ApprovalProgram approves and its state changes have been applied. If any of them fails, the whole application call fails. `itxn_submit` fails in a ClearStateProgram."},
+	{"box_create", "Newly created boxes are filled with 0 bytes. `box_create` will fail if the referenced box already exists with a different size. Otherwise, existing boxes are unchanged by `box_create`."},
+	{"box_get", "For boxes that exceed 4,096 bytes, consider `box_create`, `box_extract`, and `box_replace`"},
+	{"box_put", "For boxes that exceed 4,096 bytes, consider `box_create`, `box_extract`, and `box_replace`"},
+	{"box_del", "Deleting a box releases the minimum balance it required from the application account."},
 }
 
 var opDocExtras map[string]string
@@ -199,10 +254,13 @@ type OpGroup struct {
 
 // OpGroupList is groupings of ops for documentation purposes.
 var OpGroupList = []OpGroup{
-	{"Arithmetic", []string{"sha256", "keccak256", "sha512_256", "ed25519verify", "+", "-", "/", "*", "<", ">", "<=", ">=", "&&", "||", "==", "!=", "!", "len", "itob", "btoi", "%", "|", "&", "^", "~", "mulw", "addw", "concat", "substring", "substring3"}},
-	{"Loading Values", []string{"intcblock", "intc", "intc_0", "intc_1", "intc_2", "intc_3", "bytecblock", "bytec", "bytec_0", "bytec_1", "bytec_2", "bytec_3", "arg", "arg_0", "arg_1", "arg_2", "arg_3", "txn", "gtxn", "txna", "gtxna", "global", "load", "store"}},
-	{"Flow Control", []string{"err", "bnz", "bz", "b", "return", "pop", "dup", "dup2"}},
+	{"Arithmetic", []string{"sha256", "keccak256", "sha512_256", "ed25519verify", "+", "-", "/", "*", "<", ">", "<=", ">=", "&&", "||", "==", "!=", "!", "len", "itob", "btoi", "%", "|", "&", "^", "~", "mulw", "addw", "shl", "shr", "sqrt", "concat", "substring", "substring3", "getbit", "setbit", "getbyte", "setbyte"}},
+	{"Byte Array Arithmetic", []string{"b+", "b-", "b/", "b*", "b%", "b<", "b==", "b|", "b&"}},
+	{"Loading Values", []string{"intcblock", "intc", "intc_0", "intc_1", "intc_2", "intc_3", "bytecblock", "bytec", "bytec_0", "bytec_1", "bytec_2", "bytec_3", "pushbytes", "pushint", "arg", "arg_0", "arg_1", "arg_2", "arg_3", "txn", "gtxn", "txna", "gtxna", "global", "load", "store"}},
+	{"Flow Control", []string{"err", "bnz", "bz", "b", "return", "pop", "dup", "dup2", "dig", "swap", "select", "callsub", "retsub"}},
 	{"State Access", []string{"balance", "app_opted_in", "app_local_get", "app_local_get_ex", "app_global_get", "app_global_get_ex", "app_local_put", "app_global_put", "app_local_del", "app_global_del", "asset_holding_get", "asset_params_get"}},
+	{"Inner Transactions", []string{"itxn_begin", "itxn_field", "itxn_submit"}},
+	{"Box Access", []string{"box_create", "box_extract", "box_replace", "box_del", "box_len", "box_get", "box_put"}},
 }
 
 // OpCost returns the relative cost score for an op
@@ -345,6 +403,7 @@ var globalFieldDocList = []stringString{
 	{"Round", "Current round number"},
 	{"LatestTimestamp", "Last confirmed block UNIX timestamp. Fails if negative"},
 	{"CurrentApplicationID", "ID of current application executing. Fails if no such application is executing"},
+	{"CurrentApplicationAddress", "Address that the current application controls. Fails if no such application is executing"},
 }
 
 // globalFieldDocs are notes on fields available in `global`
@@ -50,6 +50,9 @@ const EvalMaxScratchSize = 255
 // MaxStringSize is the limit of byte strings created by `concat`
 const MaxStringSize = 4096
 
+// MaxByteMathSize is the limit of byte strings supplied as input to byte math opcodes
+const MaxByteMathSize = 64
+
 // stackValue is the type for the operand stack.
 // Each stackValue is either a valid []byte value or a uint64 value.
 // If (.Bytes != nil) the stackValue is a []byte value, otherwise uint64 value.
@@ -79,6 +82,15 @@ func (sv *stackValue) String() string {
 	return fmt.Sprintf("%d 0x%x", sv.Uint, sv.Uint)
 }
 
+func (sv *stackValue) address() (addr basics.Address, err error) {
+	if len(sv.Bytes) != len(addr) {
+		err = fmt.Errorf("expected %d byte address but got %d bytes", len(addr), len(sv.Bytes))
+		return
+	}
+	copy(addr[:], sv.Bytes)
+	return
+}
+
 func stackValueFromTealValue(tv *basics.TealValue) (sv stackValue, err error) {
 	switch tv.Type {
 	case basics.TealBytesType:
@@ -133,6 +145,11 @@ type LedgerForLogic interface {
 	ApplicationID() basics.AppIndex
 	LocalSchema() basics.StateSchema
 	GlobalSchema() basics.StateSchema
+
+	GetBox(appIdx basics.AppIndex, name string) ([]byte, bool, error)
+	NewBox(appIdx basics.AppIndex, name string, value []byte) error
+	SetBox(appIdx basics.AppIndex, name string, value []byte) error
+	DelBox(appIdx basics.AppIndex, name string) (bool, error)
 }
 
 // EvalParams contains data that comes into condition evaluation.
@@ -161,6 +178,10 @@ type EvalParams struct {
 	// MinTealVersion is nil, we will compute it ourselves
 	MinTealVersion *uint64
 
+	// optional, if set the cost of each program evaluated with these
+	// params is added to it, whether or not the program approves
+	Cost *int
+
 	// determines eval mode: runModeSignature or runModeApplication
 	runModeFlags runMode
 }
@@ -231,10 +252,17 @@ type evalContext struct {
 	stepCount int
 	cost      int
 
+	// Return addresses of the subroutines entered by callsub
+	callstack []int
+
 	// Ordered set of pc values that a branch could go to.
 	// If Check pc skips a target, the source branch was invalid!
 	branchTargets []int
 
+	// Set of pc values where Check has seen an instruction begin. Used to
+	// validate callsub targets preceding the callsub instruction.
+	instructionStarts map[int]bool
+
 	programHashCached crypto.Digest
 	txidCache         map[int]transactions.Txid
 
@@ -244,6 +272,11 @@ type evalContext struct {
 	readOnlyLocalStates  map[ckey]basics.TealKeyValue
 	appEvalDelta         basics.EvalDelta
 
+	// Inner transaction being filled in by itxn_field, and the inner
+	// transactions already submitted by itxn_submit
+	subtxn    *transactions.Transaction
+	innerTxns []transactions.Transaction
+
 	// Stores state & disassembly for the optional debugger
 	debugState DebugState
 }
@@ -300,6 +333,15 @@ var errTooManyArgs = errors.New("LogicSig has too many arguments")
 
 // EvalStateful executes stateful TEAL program
 func EvalStateful(program []byte, params EvalParams) (pass bool, delta basics.EvalDelta, err error) {
+	pass, delta, _, err = EvalStatefulWithInnerTxns(program, params)
+	return
+}
+
+// EvalStatefulWithInnerTxns executes stateful TEAL program like EvalStateful,
+// and also returns the inner transactions submitted by the program. The inner
+// transactions are not applied here: that is up to the caller, once the
+// program has approved.
+func EvalStatefulWithInnerTxns(program []byte, params EvalParams) (pass bool, delta basics.EvalDelta, innerTxns []transactions.Transaction, err error) {
 	var cx evalContext
 	cx.EvalParams = params
 	cx.runModeFlags = runModeApplication
@@ -331,7 +373,7 @@ func EvalStateful(program []byte, params EvalParams) (pass bool, delta basics.Ev
 		}
 	}
 
-	return pass, cx.appEvalDelta, err
+	return pass, cx.appEvalDelta, cx.innerTxns, err
 }
 
 // Eval checks to see if a transaction passes logic
@@ -372,6 +414,12 @@ func eval(program []byte, cx *evalContext) (pass bool, err error) {
 		}
 	}()
 
+	defer func() {
+		if cx.EvalParams.Cost != nil {
+			*cx.EvalParams.Cost += cx.cost
+		}
+	}()
+
 	if (cx.EvalParams.Proto == nil) || (cx.EvalParams.Proto.LogicSigVersion == 0) {
 		err = errLogicSignNotSupported
 		return
@@ -431,7 +479,13 @@ func eval(program []byte, cx *evalContext) (pass bool, err error) {
 
 		cx.step()
 		cx.stepCount++
-		if cx.stepCount > len(cx.program) {
+		if cx.version >= subroutineVersion {
+			// subroutines may run the same code more than once, so rather
+			// than detecting loops we keep the program within its budget.
+			if cx.cost > cx.budget() {
+				return false, fmt.Errorf("dynamic cost budget of %d exceeded, executing pc=%d", cx.budget(), cx.pc)
+			}
+		} else if cx.stepCount > len(cx.program) {
 			return false, errLoopDetected
 		}
 	}
@@ -521,9 +575,11 @@ func check(program []byte, params EvalParams) (cost int, err error) {
 	cx.pc = vlen
 	cx.EvalParams = params
 	cx.program = program
+	cx.instructionStarts = make(map[int]bool)
 
 	for cx.pc < len(cx.program) {
 		prevpc := cx.pc
+		cx.instructionStarts[cx.pc] = true
 		cost += cx.checkStep()
 		if cx.err != nil {
 			break
@@ -564,6 +620,17 @@ func boolToUint(x bool) uint64 {
 // MaxStackDepth should move to consensus params
 const MaxStackDepth = 1000
 
+// MaxCallStackDepth is the maximal number of nested subroutine calls
+const MaxCallStackDepth = 8
+
+// budget returns the maximal total cost the program may reach while running
+func (cx *evalContext) budget() int {
+	if cx.runModeFlags == runModeApplication {
+		return cx.Proto.MaxAppProgramCost
+	}
+	return int(cx.Proto.LogicSigMaxCost)
+}
+
 func (cx *evalContext) step() {
 	opcode := cx.program[cx.pc]
 	spec := &opsByOpcode[cx.version][opcode]
@@ -1027,6 +1094,198 @@ func opBitNot(cx *evalContext) {
 	cx.stack[last].Uint = cx.stack[last].Uint ^ 0xffffffffffffffff
 }
 
+func opShiftLeft(cx *evalContext) {
+	last := len(cx.stack) - 1
+	prev := last - 1
+	if cx.stack[last].Uint > 63 {
+		cx.err = fmt.Errorf("shl arg too big, (%d)", cx.stack[last].Uint)
+		return
+	}
+	cx.stack[prev].Uint = cx.stack[prev].Uint << cx.stack[last].Uint
+	cx.stack = cx.stack[:last]
+}
+
+func opShiftRight(cx *evalContext) {
+	last := len(cx.stack) - 1
+	prev := last - 1
+	if cx.stack[last].Uint > 63 {
+		cx.err = fmt.Errorf("shr arg too big, (%d)", cx.stack[last].Uint)
+		return
+	}
+	cx.stack[prev].Uint = cx.stack[prev].Uint >> cx.stack[last].Uint
+	cx.stack = cx.stack[:last]
+}
+
+func opSqrt(cx *evalContext) {
+	/*
+		It would not be safe to use math.Sqrt, because we would have to
+		convert our u64 to an f64, but f64 cannot represent all u64s exactly.
+
+		This algorithm comes from Jack W. Crenshaw's 1998 article in Embedded:
+		http://www.embedded.com/electronics-blogs/programmer-s-toolbox/4219659/Integer-Square-Roots
+	*/
+	last := len(cx.stack) - 1
+
+	sq := cx.stack[last].Uint
+	var rem uint64 = 0
+	var root uint64 = 0
+
+	for i := 0; i < 32; i++ {
+		root <<= 1
+		rem = (rem << 2) | (sq >> (64 - 2))
+		sq <<= 2
+		if root < rem {
+			rem -= root | 1
+			root += 2
+		}
+	}
+	cx.stack[last].Uint = root >> 1
+}
+
+// bytesOperands pops the two byte-array operands of a byte math opcode and
+// returns them as big-endian unsigned integers, leaving A in place on the stack
+// for the result.
+func bytesOperands(cx *evalContext) (a, b *big.Int, ok bool) {
+	last := len(cx.stack) - 1
+	prev := last - 1
+	if len(cx.stack[prev].Bytes) > MaxByteMathSize || len(cx.stack[last].Bytes) > MaxByteMathSize {
+		cx.err = errors.New("math attempted on large byte-array")
+		return
+	}
+	a = new(big.Int).SetBytes(cx.stack[prev].Bytes)
+	b = new(big.Int).SetBytes(cx.stack[last].Bytes)
+	cx.stack = cx.stack[:last]
+	return a, b, true
+}
+
+func setBytesResult(cx *evalContext, result *big.Int) {
+	last := len(cx.stack) - 1
+	// big.Int.Bytes() of zero is empty, but must not be nil to stay a []byte value
+	cx.stack[last].Bytes = nilToEmpty(result.Bytes())
+}
+
+func setBoolResult(cx *evalContext, cond bool) {
+	last := len(cx.stack) - 1
+	cx.stack[last].Bytes = nil
+	if cond {
+		cx.stack[last].Uint = 1
+	} else {
+		cx.stack[last].Uint = 0
+	}
+}
+
+func opBytesPlus(cx *evalContext) {
+	a, b, ok := bytesOperands(cx)
+	if !ok {
+		return
+	}
+	setBytesResult(cx, a.Add(a, b))
+}
+
+func opBytesMinus(cx *evalContext) {
+	a, b, ok := bytesOperands(cx)
+	if !ok {
+		return
+	}
+	if a.Cmp(b) < 0 {
+		cx.err = errors.New("byte math would have negative result")
+		return
+	}
+	setBytesResult(cx, a.Sub(a, b))
+}
+
+func opBytesDiv(cx *evalContext) {
+	a, b, ok := bytesOperands(cx)
+	if !ok {
+		return
+	}
+	if b.Sign() == 0 {
+		cx.err = errors.New("b/ division by zero")
+		return
+	}
+	setBytesResult(cx, a.Quo(a, b))
+}
+
+func opBytesMul(cx *evalContext) {
+	a, b, ok := bytesOperands(cx)
+	if !ok {
+		return
+	}
+	setBytesResult(cx, a.Mul(a, b))
+}
+
+func opBytesModulo(cx *evalContext) {
+	a, b, ok := bytesOperands(cx)
+	if !ok {
+		return
+	}
+	if b.Sign() == 0 {
+		cx.err = errors.New("b% modulo by zero")
+		return
+	}
+	setBytesResult(cx, a.Rem(a, b))
+}
+
+func opBytesLt(cx *evalContext) {
+	a, b, ok := bytesOperands(cx)
+	if !ok {
+		return
+	}
+	setBoolResult(cx, a.Cmp(b) < 0)
+}
+
+func opBytesEq(cx *evalContext) {
+	a, b, ok := bytesOperands(cx)
+	if !ok {
+		return
+	}
+	setBoolResult(cx, a.Cmp(b) == 0)
+}
+
+// bytesLogicOperands returns fresh copies of the two byte-array operands of a
+// bitwise byte math opcode, the shorter one zero extended on the left to the
+// length of the longer one.
+func bytesLogicOperands(cx *evalContext) (a, b []byte, ok bool) {
+	last := len(cx.stack) - 1
+	prev := last - 1
+	if len(cx.stack[prev].Bytes) > MaxByteMathSize || len(cx.stack[last].Bytes) > MaxByteMathSize {
+		cx.err = errors.New("math attempted on large byte-array")
+		return
+	}
+	size := len(cx.stack[prev].Bytes)
+	if len(cx.stack[last].Bytes) > size {
+		size = len(cx.stack[last].Bytes)
+	}
+	a = make([]byte, size)
+	copy(a[size-len(cx.stack[prev].Bytes):], cx.stack[prev].Bytes)
+	b = make([]byte, size)
+	copy(b[size-len(cx.stack[last].Bytes):], cx.stack[last].Bytes)
+	cx.stack = cx.stack[:last]
+	return a, b, true
+}
+
+func opBytesBitOr(cx *evalContext) {
+	a, b, ok := bytesLogicOperands(cx)
+	if !ok {
+		return
+	}
+	for i := range a {
+		a[i] |= b[i]
+	}
+	cx.stack[len(cx.stack)-1].Bytes = a
+}
+
+func opBytesBitAnd(cx *evalContext) {
+	a, b, ok := bytesLogicOperands(cx)
+	if !ok {
+		return
+	}
+	for i := range a {
+		a[i] &= b[i]
+	}
+	cx.stack[len(cx.stack)-1].Bytes = a
+}
+
 func opIntConstBlock(cx *evalContext) {
 	cx.intc, cx.nextpc, cx.err = parseIntcblock(cx.program, cx.pc)
 }
@@ -1085,6 +1344,24 @@ func opByteConst3(cx *evalContext) {
 	opByteConstN(cx, 3)
 }
 
+func opPushInt(cx *evalContext) {
+	var val uint64
+	val, cx.nextpc, cx.err = parsePushInt(cx.program, cx.pc)
+	if cx.err != nil {
+		return
+	}
+	cx.stack = append(cx.stack, stackValue{Uint: val})
+}
+
+func opPushBytes(cx *evalContext) {
+	var val []byte
+	val, cx.nextpc, cx.err = parsePushBytes(cx.program, cx.pc)
+	if cx.err != nil {
+		return
+	}
+	cx.stack = append(cx.stack, stackValue{Bytes: val})
+}
+
 func opArgN(cx *evalContext, n uint64) {
 	if n >= uint64(len(cx.Txn.Lsig.Args)) {
 		cx.err = fmt.Errorf("cannot load arg[%d] of %d", n, len(cx.Txn.Lsig.Args))
@@ -1175,6 +1452,53 @@ func opB(cx *evalContext) {
 	cx.nextpc = cx.pc + 3 + int(offset)
 }
 
+// checks callsub {int16 be offset}. Unlike branches, callsub may refer to
+// a subroutine preceding it (or itself), in which case its target must be an
+// instruction that has already been checked.
+func checkCallSub(cx *evalContext) int {
+	offset := int16(uint16(cx.program[cx.pc+1])<<8 | uint16(cx.program[cx.pc+2]))
+	cx.nextpc = cx.pc + 3
+	target := cx.nextpc + int(offset)
+	if target >= len(cx.program) {
+		cx.err = errors.New("callsub target beyond end of program")
+		return 1
+	}
+	if target <= cx.pc {
+		if !cx.instructionStarts[target] {
+			cx.err = fmt.Errorf("callsub target at %d not an aligned instruction", target)
+		}
+		return 1
+	}
+	cx.branchTargets = append(cx.branchTargets, target)
+	sort.Ints(cx.branchTargets)
+	return 1
+}
+
+func opCallSub(cx *evalContext) {
+	if len(cx.callstack) >= MaxCallStackDepth {
+		cx.err = fmt.Errorf("callsub exceeds max call stack depth of %d", MaxCallStackDepth)
+		return
+	}
+	offset := int16(uint16(cx.program[cx.pc+1])<<8 | uint16(cx.program[cx.pc+2]))
+	target := cx.pc + 3 + int(offset)
+	if target < 0 || target >= len(cx.program) {
+		cx.err = fmt.Errorf("callsub target %d outside of program", target)
+		return
+	}
+	cx.callstack = append(cx.callstack, cx.pc+3)
+	cx.nextpc = target
+}
+
+func opRetSub(cx *evalContext) {
+	top := len(cx.callstack) - 1
+	if top < 0 {
+		cx.err = errors.New("retsub with empty callstack")
+		return
+	}
+	cx.nextpc = cx.callstack[top]
+	cx.callstack = cx.callstack[:top]
+}
+
 func opPop(cx *evalContext) {
 	last := len(cx.stack) - 1
 	cx.stack = cx.stack[:last]
@@ -1192,6 +1516,37 @@ func opDup2(cx *evalContext) {
 	cx.stack = append(cx.stack, cx.stack[prev:]...)
 }
 
+func opDig(cx *evalContext) {
+	depth := int(uint(cx.program[cx.pc+1]))
+	idx := len(cx.stack) - 1 - depth
+	// the generic stack check in step() only ensures a single value is
+	// present, so the requested depth is checked here
+	if idx < 0 {
+		cx.err = fmt.Errorf("dig %d with stack size = %d", depth, len(cx.stack))
+		return
+	}
+	sv := cx.stack[idx]
+	cx.stack = append(cx.stack, sv)
+	cx.nextpc = cx.pc + 2
+}
+
+func opSwap(cx *evalContext) {
+	last := len(cx.stack) - 1
+	prev := last - 1
+	cx.stack[last], cx.stack[prev] = cx.stack[prev], cx.stack[last]
+}
+
+func opSelect(cx *evalContext) {
+	last := len(cx.stack) - 1 // condition on top
+	prev := last - 1          // true is one down
+	pprev := prev - 1         // false below that
+
+	if cx.stack[last].Uint != 0 {
+		cx.stack[pprev] = cx.stack[prev]
+	}
+	cx.stack = cx.stack[:prev]
+}
+
 func (cx *evalContext) assetHoldingEnumToValue(holding *basics.AssetHolding, field uint64) (sv stackValue, err error) {
 	switch AssetHoldingField(field) {
 	case AssetBalance:
@@ -1535,6 +1890,14 @@ func (cx *evalContext) getApplicationID() (rnd uint64, err error) {
 	return uint64(cx.Ledger.ApplicationID()), nil
 }
 
+func (cx *evalContext) getApplicationAddress() (addr basics.Address, err error) {
+	if cx.Ledger == nil {
+		err = fmt.Errorf("ledger not available")
+		return
+	}
+	return cx.Ledger.ApplicationID().Address(), nil
+}
+
 var zeroAddress basics.Address
 
 func (cx *evalContext) globalFieldToStack(field GlobalField) (sv stackValue, err error) {
@@ -1557,6 +1920,10 @@ func (cx *evalContext) globalFieldToStack(field GlobalField) (sv stackValue, err
 		sv.Uint, err = cx.getLatestTimestamp()
 	case CurrentApplicationID:
 		sv.Uint, err = cx.getApplicationID()
+	case CurrentApplicationAddress:
+		var addr basics.Address
+		addr, err = cx.getApplicationAddress()
+		sv.Bytes = addr[:]
 	default:
 		err = fmt.Errorf("invalid global[%d]", field)
 	}
@@ -1710,6 +2077,119 @@ func opSubstring3(cx *evalContext) {
 	cx.stack = cx.stack[:prev]
 }
 
+func opGetBit(cx *evalContext) {
+	last := len(cx.stack) - 1
+	prev := last - 1
+	idx := cx.stack[last].Uint
+	target := cx.stack[prev]
+
+	var bit uint64
+	if target.argType() == StackUint64 {
+		if idx > 63 {
+			cx.err = errors.New("getbit index > 63 with Uint")
+			return
+		}
+		mask := uint64(1) << idx
+		bit = (target.Uint & mask) >> idx
+	} else {
+		// indexing into a byte array, where bit 0 is the high order bit
+		// of the first byte
+		byteIdx := idx / 8
+		if byteIdx >= uint64(len(target.Bytes)) {
+			cx.err = errors.New("getbit index beyond byteslice")
+			return
+		}
+		bitIdx := idx % 8
+		mask := byte(0x80) >> bitIdx
+		bit = uint64((target.Bytes[byteIdx] & mask) >> (7 - bitIdx))
+	}
+	cx.stack[prev].Uint = bit
+	cx.stack[prev].Bytes = nil
+	cx.stack = cx.stack[:last]
+}
+
+func opSetBit(cx *evalContext) {
+	last := len(cx.stack) - 1
+	prev := last - 1
+	pprev := prev - 1
+
+	bit := cx.stack[last].Uint
+	idx := cx.stack[prev].Uint
+	target := cx.stack[pprev]
+
+	if bit > 1 {
+		cx.err = errors.New("setbit value > 1")
+		return
+	}
+
+	if target.argType() == StackUint64 {
+		if idx > 63 {
+			cx.err = errors.New("setbit index > 63 with Uint")
+			return
+		}
+		mask := uint64(1) << idx
+		if bit == 1 {
+			cx.stack[pprev].Uint |= mask
+		} else {
+			cx.stack[pprev].Uint &^= mask
+		}
+	} else {
+		byteIdx := idx / 8
+		if byteIdx >= uint64(len(target.Bytes)) {
+			cx.err = errors.New("setbit index beyond byteslice")
+			return
+		}
+		bitIdx := idx % 8
+		mask := byte(0x80) >> bitIdx
+		// copy, as the byte array may be shared with constants or scratch space
+		newBytes := append([]byte(nil), target.Bytes...)
+		if bit == 1 {
+			newBytes[byteIdx] |= mask
+		} else {
+			newBytes[byteIdx] &^= mask
+		}
+		cx.stack[pprev].Bytes = newBytes
+	}
+	cx.stack = cx.stack[:prev]
+}
+
+func opGetByte(cx *evalContext) {
+	last := len(cx.stack) - 1
+	prev := last - 1
+
+	idx := cx.stack[last].Uint
+	target := cx.stack[prev]
+
+	if idx >= uint64(len(target.Bytes)) {
+		cx.err = errors.New("getbyte index beyond array length")
+		return
+	}
+	cx.stack[prev].Uint = uint64(target.Bytes[idx])
+	cx.stack[prev].Bytes = nil
+	cx.stack = cx.stack[:last]
+}
+
+func opSetByte(cx *evalContext) {
+	last := len(cx.stack) - 1
+	prev := last - 1
+	pprev := prev - 1
+
+	if cx.stack[last].Uint > 255 {
+		cx.err = errors.New("setbyte value > 255")
+		return
+	}
+	idx := cx.stack[prev].Uint
+	if idx >= uint64(len(cx.stack[pprev].Bytes)) {
+		cx.err = errors.New("setbyte index beyond array length")
+		return
+	}
+	// copy, as the byte array may be shared with constants or scratch space
+	newBytes := append([]byte(nil), cx.stack[pprev].Bytes...)
+	newBytes[idx] = byte(cx.stack[last].Uint)
+	cx.stack[pprev].Bytes = newBytes
+	cx.stack = cx.stack[:prev]
+}
+
 func opBalance(cx *evalContext) {
 	last := len(cx.stack) - 1 // account offset
 
@@ -2202,3 +2682,397 @@ func opAssetParamsGet(cx *evalContext) {
 
 	cx.nextpc = cx.pc + 2
 }
+
+func opItxnBegin(cx *evalContext) {
+	if cx.Ledger == nil {
+		cx.err = fmt.Errorf("ledger not available")
+		return
+	}
+
+	if cx.subtxn != nil {
+		cx.err = errors.New("itxn_begin without itxn_submit")
+		return
+	}
+
+	if len(cx.innerTxns) >= cx.Proto.MaxInnerTransactions {
+		cx.err = fmt.Errorf("too many inner transactions, max is %d", cx.Proto.MaxInnerTransactions)
+		return
+	}
+
+	// The inner transaction is sent from the application account and is
+	// only valid in the current round
+	rnd := cx.Ledger.Round()
+	cx.subtxn = &transactions.Transaction{
+		Header: transactions.Header{
+			Sender:     cx.Ledger.ApplicationID().Address(),
+			Fee:        basics.MicroAlgos{Raw: cx.Proto.MinTxnFee},
+			FirstValid: rnd,
+			LastValid:  rnd,
+		},
+	}
+}
+
+func (cx *evalContext) stackToTxnField(txn *transactions.Transaction, field TxnField, sv *stackValue) (err error) {
+	fs, ok := txnFieldSpecByField[field]
+	if !ok || fs.version > cx.version || !innerTxnFields[field] {
+		return fmt.Errorf("invalid itxn_field %s", field.String())
+	}
+	if fs.ftype != sv.argType() {
+		return fmt.Errorf("%s expected field type is %s but got %s", field.String(), fs.ftype.String(), sv.argType().String())
+	}
+
+	switch field {
+	case Type:
+		txn.Type = protocol.TxType(sv.Bytes)
+	case TypeEnum:
+		if sv.Uint >= uint64(len(TxnTypeNames)) {
+			return fmt.Errorf("invalid TypeEnum %d", sv.Uint)
+		}
+		txn.Type = protocol.TxType(TxnTypeNames[sv.Uint])
+	case Fee:
+		txn.Fee.Raw = sv.Uint
+	case Note:
+		txn.Note = append([]byte(nil), sv.Bytes...)
+	case Receiver:
+		txn.Receiver, err = sv.address()
+	case Amount:
+		txn.Amount.Raw = sv.Uint
+	case CloseRemainderTo:
+		txn.CloseRemainderTo, err = sv.address()
+	case XferAsset:
+		txn.XferAsset = basics.AssetIndex(sv.Uint)
+	case AssetAmount:
+		txn.AssetAmount = sv.Uint
+	case AssetSender:
+		txn.AssetSender, err = sv.address()
+	case AssetReceiver:
+		txn.AssetReceiver, err = sv.address()
+	case AssetCloseTo:
+		txn.AssetCloseTo, err = sv.address()
+	case ConfigAsset:
+		txn.ConfigAsset = basics.AssetIndex(sv.Uint)
+	case ConfigAssetTotal:
+		txn.AssetParams.Total = sv.Uint
+	case ConfigAssetDecimals:
+		if sv.Uint > math.MaxUint32 {
+			return fmt.Errorf("invalid ConfigAssetDecimals %d", sv.Uint)
+		}
+		txn.AssetParams.Decimals = uint32(sv.Uint)
+	case ConfigAssetDefaultFrozen:
+		if sv.Uint > 1 {
+			return fmt.Errorf("invalid ConfigAssetDefaultFrozen %d", sv.Uint)
+		}
+		txn.AssetParams.DefaultFrozen = sv.Uint == 1
+	case ConfigAssetUnitName:
+		txn.AssetParams.UnitName = string(sv.Bytes)
+	case ConfigAssetName:
+		txn.AssetParams.AssetName = string(sv.Bytes)
+	case ConfigAssetURL:
+		txn.AssetParams.URL = string(sv.Bytes)
+	case ConfigAssetMetadataHash:
+		if len(sv.Bytes) != len(txn.AssetParams.MetadataHash) {
+			return fmt.Errorf("ConfigAssetMetadataHash must be %d bytes", len(txn.AssetParams.MetadataHash))
+		}
+		copy(txn.AssetParams.MetadataHash[:], sv.Bytes)
+	case ConfigAssetManager:
+		txn.AssetParams.Manager, err = sv.address()
+	case ConfigAssetReserve:
+		txn.AssetParams.Reserve, err = sv.address()
+	case ConfigAssetFreeze:
+		txn.AssetParams.Freeze, err = sv.address()
+	case ConfigAssetClawback:
+		txn.AssetParams.Clawback, err = sv.address()
+	default:
+		err = fmt.Errorf("invalid itxn_field %s", field.String())
+	}
+	return
+}
+
+func opItxnField(cx *evalContext) {
+	last := len(cx.stack) - 1 // field value
+
+	if cx.subtxn == nil {
+		cx.err = errors.New("itxn_field without itxn_begin")
+		return
+	}
+
+	field := TxnField(uint64(cx.program[cx.pc+1]))
+	err := cx.stackToTxnField(cx.subtxn, field, &cx.stack[last])
+	if err != nil {
+		cx.err = err
+		return
+	}
+
+	cx.stack = cx.stack[:last]
+	cx.nextpc = cx.pc + 2
+}
+
+func opItxnSubmit(cx *evalContext) {
+	if cx.subtxn == nil {
+		cx.err = errors.New("itxn_submit without itxn_begin")
+		return
+	}
+
+	if !innerTxnTypes[cx.subtxn.Type] {
+		cx.err = fmt.Errorf("inner transaction type %q not allowed", cx.subtxn.Type)
+		return
+	}
+
+	if cx.subtxn.Fee.Raw < cx.Proto.MinTxnFee {
+		cx.err = fmt.Errorf("inner transaction fee %d below min %d", cx.subtxn.Fee.Raw, cx.Proto.MinTxnFee)
+		return
+	}
+
+	// A failing ClearStateProgram does not fail its transaction, and the
+	// inner transactions it issues would never be executed
+	if cx.Txn.Txn.OnCompletion == transactions.ClearStateOC {
+		cx.err = errors.New("inner transactions may not be issued by a ClearStateProgram")
+		return
+	}
+
+	cx.innerTxns = append(cx.innerTxns, *cx.subtxn)
+	cx.subtxn = nil
+}
+
+// boxAccess validates the name of a box of the current application before a
+// box opcode reads or modifies it.
+func (cx *evalContext) boxAccess(name []byte) error {
+	if cx.Ledger == nil {
+		return fmt.Errorf("ledger not available")
+	}
+	if cx.Proto.MaxBoxSize == 0 {
+		return errors.New("boxes are not enabled")
+	}
+	// A failing ClearStateProgram does not fail its transaction, so it
+	// cannot be allowed to leave box modifications behind
+	if cx.Txn.Txn.OnCompletion == transactions.ClearStateOC {
+		return errors.New("boxes may not be accessed by a ClearStateProgram")
+	}
+	if len(name) == 0 {
+		return errors.New("box names may not be zero length")
+	}
+	if len(name) > cx.Proto.MaxAppBoxNameLen {
+		return fmt.Errorf("box name too long: length was %d, maximum is %d", len(name), cx.Proto.MaxAppBoxNameLen)
+	}
+	return nil
+}
+
+// boxGet returns the contents of an existing box of the current application
+func (cx *evalContext) boxGet(name []byte) ([]byte, error) {
+	value, ok, err := cx.Ledger.GetBox(cx.Ledger.ApplicationID(), string(name))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("no such box %#x", name)
+	}
+	return value, nil
+}
+
+// boxCreate creates a box of the current application unless it already
+// exists, reporting whether it was created. An existing box must have the
+// requested size.
+func (cx *evalContext) boxCreate(name []byte, value []byte) (bool, error) {
+	if uint64(len(value)) > cx.Proto.MaxBoxSize {
+		return false, fmt.Errorf("box size too large: %d, maximum is %d", len(value), cx.Proto.MaxBoxSize)
+	}
+	appIdx := cx.Ledger.ApplicationID()
+	current, exists, err := cx.Ledger.GetBox(appIdx, string(name))
+	if err != nil {
+		return false, err
+	}
+	if exists {
+		if len(current) != len(value) {
+			return false, fmt.Errorf("box size mismatch: %d %d", len(current), len(value))
+		}
+		return false, nil
+	}
+	return true, cx.Ledger.NewBox(appIdx, string(name), value)
+}
+
+func opBoxCreate(cx *evalContext) {
+	last := len(cx.stack) - 1 // size
+	prev := last - 1          // name
+
+	name := cx.stack[prev].Bytes
+	size := cx.stack[last].Uint
+
+	err := cx.boxAccess(name)
+	if err != nil {
+		cx.err = err
+		return
+	}
+	if size > cx.Proto.MaxBoxSize {
+		cx.err = fmt.Errorf("box size too large: %d, maximum is %d", size, cx.Proto.MaxBoxSize)
+		return
+	}
+
+	created, err := cx.boxCreate(name, make([]byte, size))
+	if err != nil {
+		cx.err = err
+		return
+	}
+
+	cx.stack[prev] = stackValue{Uint: boolToUint(created)}
+	cx.stack = cx.stack[:last]
+}
+
+func opBoxExtract(cx *evalContext) {
+	last := len(cx.stack) - 1 // length
+	prev := last - 1          // offset
+	pprev := prev - 1         // name
+
+	name := cx.stack[pprev].Bytes
+	start := cx.stack[prev].Uint
+	length := cx.stack[last].Uint
+
+	err := cx.boxAccess(name)
+	if err != nil {
+		cx.err = err
+		return
+	}
+	contents, err := cx.boxGet(name)
+	if err != nil {
+		cx.err = err
+		return
+	}
+
+	end := start + length
+	if end < start || end > uint64(len(contents)) {
+		cx.err = fmt.Errorf("extraction end %d is beyond length of box: %d", end, len(contents))
+		return
+	}
+
+	cx.stack[pprev].Bytes = append([]byte(nil), contents[start:end]...)
+	cx.stack = cx.stack[:prev]
+}
+
+func opBoxReplace(cx *evalContext) {
+	last := len(cx.stack) - 1 // replacement
+	prev := last - 1          // offset
+	pprev := prev - 1         // name
+
+	name := cx.stack[pprev].Bytes
+	start := cx.stack[prev].Uint
+	replacement := cx.stack[last].Bytes
+
+	err := cx.boxAccess(name)
+	if err != nil {
+		cx.err = err
+		return
+	}
+	contents, err := cx.boxGet(name)
+	if err != nil {
+		cx.err = err
+		return
+	}
+
+	end := start + uint64(len(replacement))
+	if end < start || end > uint64(len(contents)) {
+		cx.err = fmt.Errorf("replacement end %d is beyond length of box: %d", end, len(contents))
+		return
+	}
+
+	// The ledger owns contents, so the modified box is a fresh copy
+	value := append([]byte(nil), contents...)
+	copy(value[start:end], replacement)
+	err = cx.Ledger.SetBox(cx.Ledger.ApplicationID(), string(name), value)
+	if err != nil {
+		cx.err = err
+		return
+	}
+
+	cx.stack = cx.stack[:pprev]
+}
+
+func opBoxDel(cx *evalContext) {
+	last := len(cx.stack) - 1 // name
+
+	name := cx.stack[last].Bytes
+
+	err := cx.boxAccess(name)
+	if err != nil {
+		cx.err = err
+		return
+	}
+
+	deleted, err := cx.Ledger.DelBox(cx.Ledger.ApplicationID(), string(name))
+	if err != nil {
+		cx.err = err
+		return
+	}
+
+	cx.stack[last] = stackValue{Uint: boolToUint(deleted)}
+}
+
+func opBoxLen(cx *evalContext) {
+	last := len(cx.stack) - 1 // name
+
+	name := cx.stack[last].Bytes
+
+	err := cx.boxAccess(name)
+	if err != nil {
+		cx.err = err
+		return
+	}
+
+	contents, exists, err := cx.Ledger.GetBox(cx.Ledger.ApplicationID(), string(name))
+	if err != nil {
+		cx.err = err
+		return
+	}
+
+	cx.stack[last] = stackValue{Uint: uint64(len(contents))}
+	cx.stack = append(cx.stack, stackValue{Uint: boolToUint(exists)})
+}
+
+func opBoxGet(cx *evalContext) {
+	last := len(cx.stack) - 1 // name
+
+	name := cx.stack[last].Bytes
+
+	err := cx.boxAccess(name)
+	if err != nil {
+		cx.err = err
+		return
+	}
+
+	contents, exists, err := cx.Ledger.GetBox(cx.Ledger.ApplicationID(), string(name))
+	if err != nil {
+		cx.err = err
+		return
+	}
+
+	cx.stack[last] = stackValue{Bytes: append([]byte{}, contents...)}
+	cx.stack = append(cx.stack, stackValue{Uint: boolToUint(exists)})
+}
+
+func opBoxPut(cx *evalContext) {
+	last := len(cx.stack) - 1 // value
+	prev := last - 1          // name
+
+	name := cx.stack[prev].Bytes
+	value := append([]byte(nil), cx.stack[last].Bytes...)
+
+	err := cx.boxAccess(name)
+	if err != nil {
+		cx.err = err
+		return
+	}
+
+	// box_put creates the box if needed; an existing box keeps its size
+	created, err := cx.boxCreate(name, value)
+	if err != nil {
+		cx.err = err
+		return
+	}
+	if !created {
+		err = cx.Ledger.SetBox(cx.Ledger.ApplicationID(), string(name), value)
+		if err != nil {
+			cx.err = err
+			return
+		}
+	}
+
+	cx.stack = cx.stack[:prev]
+}
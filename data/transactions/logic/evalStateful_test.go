@@ -26,6 +26,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/transactions"
 	"github.com/algorand/go-algorand/protocol"
 )
 
@@ -40,6 +41,7 @@ type testLedger struct {
 	balances     map[basics.Address]balanceRecord
 	applications map[basics.AppIndex]map[string]basics.TealValue
 	assets       map[basics.AssetIndex]basics.AssetParams
+	boxes        map[basics.AppIndex]map[string][]byte
 	localCount   int
 	globalCount  int
 	appID        uint64
@@ -63,6 +65,7 @@ func makeTestLedger(balances map[basics.Address]uint64) *testLedger {
 	}
 	l.applications = make(map[basics.AppIndex]map[string]basics.TealValue)
 	l.assets = make(map[basics.AssetIndex]basics.AssetParams)
+	l.boxes = make(map[basics.AppIndex]map[string][]byte)
 	return l
 }
 
@@ -177,6 +180,40 @@ func (l *testLedger) GlobalSchema() basics.StateSchema {
 	}
 }
 
+func (l *testLedger) GetBox(appIdx basics.AppIndex, name string) ([]byte, bool, error) {
+	value, ok := l.boxes[appIdx][name]
+	return value, ok, nil
+}
+
+func (l *testLedger) NewBox(appIdx basics.AppIndex, name string, value []byte) error {
+	if _, ok := l.boxes[appIdx][name]; ok {
+		return fmt.Errorf("box %s already exists", name)
+	}
+	if l.boxes[appIdx] == nil {
+		l.boxes[appIdx] = make(map[string][]byte)
+	}
+	l.boxes[appIdx][name] = value
+	return nil
+}
+
+func (l *testLedger) SetBox(appIdx basics.AppIndex, name string, value []byte) error {
+	current, ok := l.boxes[appIdx][name]
+	if !ok {
+		return fmt.Errorf("no such box %s", name)
+	}
+	if len(current) != len(value) {
+		return fmt.Errorf("box size mismatch: %d %d", len(current), len(value))
+	}
+	l.boxes[appIdx][name] = value
+	return nil
+}
+
+func (l *testLedger) DelBox(appIdx basics.AppIndex, name string) (bool, error) {
+	_, ok := l.boxes[appIdx][name]
+	delete(l.boxes[appIdx], name)
+	return ok, nil
+}
+
 func TestEvalModes(t *testing.T) {
 	t.Parallel()
 	// ed25519verify and err are tested separately below
@@ -1114,8 +1151,8 @@ int 1
 	require.True(t, pass)
 
 	// check holdings invalid offsets
-	require.Equal(t, opsByName[ep.Proto.LogicSigVersion]["asset_holding_get"].Opcode, program[8])
-	program[9] = 0x02
+	require.Equal(t, opsByName[ep.Proto.LogicSigVersion]["asset_holding_get"].Opcode, program[7])
+	program[8] = 0x02
 	_, _, err = EvalStateful(program, ep)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "invalid asset holding field 2")
@@ -2709,3 +2746,362 @@ int 42
 	require.NoError(t, err)
 	require.True(t, pass)
 }
+
+func TestCurrentApplicationAddress(t *testing.T) {
+	t.Parallel()
+	appAddr := basics.AppIndex(42).Address()
+	source := fmt.Sprintf(`global CurrentApplicationAddress
+addr %s
+==
+`, appAddr.String())
+	ledger := makeTestLedger(
+		map[basics.Address]uint64{},
+	)
+	ledger.appID = 42
+	program, err := AssembleStringWithVersion(source, AssemblerMaxVersion)
+	require.NoError(t, err)
+
+	ep := defaultEvalParams(nil, nil)
+	_, err = CheckStateful(program, ep)
+	require.NoError(t, err)
+	_, _, err = EvalStateful(program, ep)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "ledger not available")
+
+	pass, err := Eval(program, ep)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not allowed in current mode")
+	require.False(t, pass)
+
+	ep.Ledger = ledger
+	pass, _, err = EvalStateful(program, ep)
+	require.NoError(t, err)
+	require.True(t, pass)
+
+	// each application has its own address
+	ledger.appID = 43
+	pass, _, err = EvalStateful(program, ep)
+	require.NoError(t, err)
+	require.False(t, pass)
+
+	// the address is not available before TEAL v3
+	_, err = AssembleStringWithVersion(source, 2)
+	require.Error(t, err)
+}
+
+func TestInnerTransactions(t *testing.T) {
+	t.Parallel()
+
+	ledger := makeTestLedger(
+		map[basics.Address]uint64{},
+	)
+	ledger.appID = 42
+	appAddr := basics.AppIndex(42).Address()
+
+	ep := defaultEvalParams(nil, nil)
+	ep.Proto.MinTxnFee = 1000
+	ep.Ledger = ledger
+
+	source := `itxn_begin
+int pay
+itxn_field TypeEnum
+txn Sender
+itxn_field Receiver
+int 5000
+itxn_field Amount
+byte "note"
+itxn_field Note
+itxn_submit
+itxn_begin
+byte "axfer"
+itxn_field Type
+int 7
+itxn_field XferAsset
+txn Sender
+itxn_field AssetReceiver
+int 2000
+itxn_field Fee
+itxn_submit
+int 1
+`
+	program, err := AssembleStringWithVersion(source, AssemblerMaxVersion)
+	require.NoError(t, err)
+	_, err = CheckStateful(program, ep)
+	require.NoError(t, err)
+	pass, _, itxns, err := EvalStatefulWithInnerTxns(program, ep)
+	require.NoError(t, err)
+	require.True(t, pass)
+	require.Len(t, itxns, 2)
+
+	require.Equal(t, protocol.PaymentTx, itxns[0].Type)
+	require.Equal(t, appAddr, itxns[0].Sender)
+	require.Equal(t, ep.Txn.Txn.Sender, itxns[0].Receiver)
+	require.Equal(t, uint64(5000), itxns[0].Amount.Raw)
+	require.Equal(t, uint64(1000), itxns[0].Fee.Raw)
+	require.Equal(t, []byte("note"), itxns[0].Note)
+	require.Equal(t, itxns[0].FirstValid, itxns[0].LastValid)
+
+	require.Equal(t, protocol.AssetTransferTx, itxns[1].Type)
+	require.Equal(t, appAddr, itxns[1].Sender)
+	require.Equal(t, basics.AssetIndex(7), itxns[1].XferAsset)
+	require.Equal(t, uint64(2000), itxns[1].Fee.Raw)
+
+	type sourceError struct {
+		src string
+		err string
+	}
+	sources := []sourceError{
+		{"int 1\nitxn_field TypeEnum\nint 1", "itxn_field without itxn_begin"},
+		{"itxn_submit\nint 1", "itxn_submit without itxn_begin"},
+		{"itxn_begin\nitxn_begin\nint 1", "itxn_begin without itxn_submit"},
+		{"itxn_begin\nint appl\nitxn_field TypeEnum\nitxn_submit\nint 1", `inner transaction type "appl" not allowed`},
+		{"itxn_begin\nint pay\nitxn_field TypeEnum\nint 999\nitxn_field Fee\nitxn_submit\nint 1", "inner transaction fee 999 below min 1000"},
+		{"itxn_begin\nbyte \"short\"\nitxn_field Receiver\nint 1", "expected 32 byte address but got 5 bytes"},
+		{"itxn_begin\nint 5\nitxn_field Note\nint 1", "Note expected field type is []byte but got uint64"},
+		{"itxn_begin\nint 2\nitxn_field ConfigAssetDefaultFrozen\nint 1", "invalid ConfigAssetDefaultFrozen 2"},
+	}
+	for _, se := range sources {
+		program, err := AssembleStringWithVersion(se.src, AssemblerMaxVersion)
+		require.NoError(t, err, se.src)
+		_, _, err = EvalStateful(program, ep)
+		require.Error(t, err, se.src)
+		require.Contains(t, err.Error(), se.err, se.src)
+	}
+
+	// at most MaxInnerTransactions may be issued
+	one := "itxn_begin\nint pay\nitxn_field TypeEnum\nitxn_submit\n"
+	program, err = AssembleStringWithVersion(strings.Repeat(one, ep.Proto.MaxInnerTransactions)+"int 1", AssemblerMaxVersion)
+	require.NoError(t, err)
+	pass, _, itxns, err = EvalStatefulWithInnerTxns(program, ep)
+	require.NoError(t, err)
+	require.True(t, pass)
+	require.Len(t, itxns, ep.Proto.MaxInnerTransactions)
+
+	program, err = AssembleStringWithVersion(strings.Repeat(one, ep.Proto.MaxInnerTransactions+1)+"int 1", AssemblerMaxVersion)
+	require.NoError(t, err)
+	_, _, err = EvalStateful(program, ep)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "too many inner transactions")
+
+	// a ClearStateProgram may not issue inner transactions
+	ep.Txn.Txn.OnCompletion = transactions.ClearStateOC
+	program, err = AssembleStringWithVersion(one+"int 1", AssemblerMaxVersion)
+	require.NoError(t, err)
+	_, _, err = EvalStateful(program, ep)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "ClearStateProgram")
+	ep.Txn.Txn.OnCompletion = transactions.NoOpOC
+
+	// inner transactions are not available to stateless programs
+	_, err = AssembleStringWithVersion("itxn_begin\nint 1", 2)
+	require.Error(t, err)
+	program, err = AssembleStringWithVersion("itxn_begin\nint 1", AssemblerMaxVersion)
+	require.NoError(t, err)
+	_, err = Eval(program, ep)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not allowed in current mode")
+}
+
+func TestBoxes(t *testing.T) {
+	t.Parallel()
+
+	ledger := makeTestLedger(
+		map[basics.Address]uint64{},
+	)
+	ledger.appID = 42
+
+	ep := defaultEvalParams(nil, nil)
+	ep.Proto.MaxBoxSize = 16
+	ep.Proto.MaxAppBoxNameLen = 8
+	ep.Ledger = ledger
+
+	run := func(source string) error {
+		program, err := AssembleStringWithVersion(source, AssemblerMaxVersion)
+		require.NoError(t, err)
+		_, err = CheckStateful(program, ep)
+		require.NoError(t, err)
+		pass, _, err := EvalStateful(program, ep)
+		if err == nil && !pass {
+			err = fmt.Errorf("program rejected")
+		}
+		return err
+	}
+
+	// create, write and read back a box
+	err := run(`byte "self"
+int 4
+box_create
+byte "self"
+int 4
+box_create
+!
+&&
+byte "self"
+int 1
+byte 0x6566
+box_replace
+byte "self"
+int 0
+int 4
+box_extract
+byte 0x00656600
+==
+&&
+byte "self"
+box_len
+store 0
+int 4
+==
+load 0
+&&
+&&
+`)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0, 'e', 'f', 0}, ledger.boxes[42]["self"])
+
+	// box_put creates a box or overwrites one of the same size
+	err = run(`byte "other"
+byte "ab"
+box_put
+byte "other"
+byte "cd"
+box_put
+byte "other"
+box_get
+store 0
+byte "cd"
+==
+load 0
+&&
+`)
+	require.NoError(t, err)
+	require.Equal(t, []byte("cd"), ledger.boxes[42]["other"])
+
+	err = run(`byte "other"
+byte "abc"
+box_put
+int 1
+`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "box size mismatch")
+
+	err = run(`byte "self"
+int 2
+box_create
+`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "box size mismatch")
+
+	// out of range access
+	err = run(`byte "self"
+int 2
+int 3
+box_extract
+len
+`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "beyond length of box")
+
+	err = run(`byte "self"
+int 3
+byte 0x0102
+box_replace
+int 1
+`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "beyond length of box")
+
+	// missing boxes
+	err = run(`byte "missing"
+box_len
+!
+store 0
+!
+load 0
+&&
+byte "missing"
+box_get
+!
+store 1
+len
+!
+load 1
+&&
+&&
+`)
+	require.NoError(t, err)
+
+	err = run(`byte "missing"
+int 0
+int 1
+box_extract
+len
+`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no such box")
+
+	// limits on names and sizes
+	err = run(`byte "toolongname"
+int 1
+box_create
+`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "box name too long")
+
+	err = run(`byte ""
+box_len
+pop
+`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "zero length")
+
+	err = run(`byte "big"
+int 17
+box_create
+`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "box size too large")
+
+	// boxes belong to the current application
+	ledger.appID = 43
+	err = run(`byte "self"
+box_len
+!
+store 0
+!
+load 0
+&&
+`)
+	require.NoError(t, err)
+	ledger.appID = 42
+
+	// deletion
+	err = run(`byte "self"
+box_del
+byte "self"
+box_del
+!
+&&
+`)
+	require.NoError(t, err)
+	require.NotContains(t, ledger.boxes[42], "self")
+
+	// ClearStateProgram may not use boxes
+	ep.Txn.Txn.OnCompletion = transactions.ClearStateOC
+	err = run(`byte "other"
+box_len
+pop
+`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "ClearStateProgram")
+	ep.Txn.Txn.OnCompletion = transactions.NoOpOC
+
+	// boxes must be enabled by the consensus protocol
+	ep.Proto.MaxBoxSize = 0
+	err = run(`byte "other"
+box_len
+pop
+`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not enabled")
+}
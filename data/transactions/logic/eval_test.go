@@ -22,6 +22,8 @@ import (
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
+	"math/big"
+	"math/rand"
 	"strconv"
 	"strings"
 	"testing"
@@ -51,8 +53,11 @@ func defaultEvalProtoWithVersion(version uint64) config.ConsensusParams {
 	return config.ConsensusParams{
 		LogicSigVersion:     version,
 		LogicSigMaxCost:     20000,
+		MaxAppProgramCost:   700,
 		MaxAppKeyLen:        64,
 		MaxAppBytesValueLen: 64,
+		// set to 4 so the limit can be exercised by tests
+		MaxInnerTransactions: 4,
 	}
 }
 
@@ -612,6 +617,165 @@ int 1`, v)
 	}
 }
 
+func TestSubroutine(t *testing.T) {
+	t.Parallel()
+	source := `#pragma version 3
+int 1
+callsub double
+callsub double
+int 4
+==
+b done
+double:
+dup
++
+retsub
+done:
+`
+	program, err := AssembleStringWithVersion(source, 3)
+	require.NoError(t, err)
+	_, err = Check(program, defaultEvalParams(nil, nil))
+	require.NoError(t, err)
+	pass, err := Eval(program, defaultEvalParams(nil, nil))
+	require.NoError(t, err)
+	require.True(t, pass)
+
+	// subroutines may precede the callsub referring to them
+	source = `#pragma version 3
+b main
+square:
+dup
+*
+retsub
+main:
+int 3
+callsub square
+callsub square
+int 81
+==
+`
+	program, err = AssembleStringWithVersion(source, 3)
+	require.NoError(t, err)
+	_, err = Check(program, defaultEvalParams(nil, nil))
+	require.NoError(t, err)
+	pass, err = Eval(program, defaultEvalParams(nil, nil))
+	require.NoError(t, err)
+	require.True(t, pass)
+
+	// retsub with an empty call stack fails
+	program, err = AssembleStringWithVersion("#pragma version 3\nint 1\nretsub", 3)
+	require.NoError(t, err)
+	pass, err = Eval(program, defaultEvalParams(nil, nil))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "retsub with empty callstack")
+	require.False(t, pass)
+
+	// unbounded recursion fails once the call stack is exhausted
+	program, err = AssembleStringWithVersion("#pragma version 3\nrecurse:\ncallsub recurse\nint 1", 3)
+	require.NoError(t, err)
+	_, err = Check(program, defaultEvalParams(nil, nil))
+	require.NoError(t, err)
+	pass, err = Eval(program, defaultEvalParams(nil, nil))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "max call stack depth")
+	require.False(t, pass)
+}
+
+func TestSubroutineCostBudget(t *testing.T) {
+	t.Parallel()
+	// each call to hash10 costs 10 sha256 runs, and each call to hash100
+	// ten times that, so running hash100 6 times exceeds LogicSigMaxCost.
+	source := `#pragma version 3
+byte 0x00
+callsub hash100
+callsub hash100
+callsub hash100
+callsub hash100
+callsub hash100
+callsub hash100
+len
+b done
+hash100:
+callsub hash10
+callsub hash10
+callsub hash10
+callsub hash10
+callsub hash10
+callsub hash10
+callsub hash10
+callsub hash10
+callsub hash10
+callsub hash10
+retsub
+hash10:
+sha256
+sha256
+sha256
+sha256
+sha256
+sha256
+sha256
+sha256
+sha256
+sha256
+retsub
+done:
+`
+	program, err := AssembleStringWithVersion(source, 3)
+	require.NoError(t, err)
+	cost, err := Check(program, defaultEvalParams(nil, nil))
+	require.NoError(t, err)
+	require.True(t, cost < 20000)
+	pass, err := Eval(program, defaultEvalParams(nil, nil))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "dynamic cost budget")
+	require.False(t, pass)
+
+	// the same program with fewer calls fits within the budget
+	program, err = AssembleStringWithVersion(strings.Replace(source, "callsub hash100\ncallsub hash100\ncallsub hash100\n", "", 1), 3)
+	require.NoError(t, err)
+	pass, err = Eval(program, defaultEvalParams(nil, nil))
+	require.NoError(t, err)
+	require.True(t, pass)
+}
+
+func TestEvalCost(t *testing.T) {
+	t.Parallel()
+	program, err := AssembleStringWithVersion("int 1\nint 2\n+", 2)
+	require.NoError(t, err)
+
+	var cost int
+	ep := defaultEvalParams(nil, nil)
+	ep.Cost = &cost
+	pass, err := Eval(program, ep)
+	require.NoError(t, err)
+	require.True(t, pass)
+	require.Equal(t, 3, cost)
+
+	// costs accumulate, including those of rejected programs
+	program, err = AssembleStringWithVersion("int 0", 2)
+	require.NoError(t, err)
+	pass, err = Eval(program, ep)
+	require.NoError(t, err)
+	require.False(t, pass)
+	require.Equal(t, 4, cost)
+}
+
+func TestSubroutineBadTarget(t *testing.T) {
+	t.Parallel()
+	// callsub back into the middle of the two byte "intc 0" instruction
+	program := []byte{0x03, 0x20, 0x01, 0x01, 0x21, 0x00, 0x88, 0xff, 0xfc}
+	_, err := Check(program, defaultEvalParams(nil, nil))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not an aligned instruction")
+
+	// callsub beyond the end of the program
+	program = []byte{0x03, 0x88, 0x00, 0x00}
+	_, err = Check(program, defaultEvalParams(nil, nil))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "beyond end of program")
+}
+
 func TestSubUnderflow(t *testing.T) {
 	t.Parallel()
 	for v := uint64(1); v <= AssemblerMaxVersion; v++ {
@@ -1271,7 +1435,7 @@ int 1
 
 const globalV2TestProgram = `global LogicSigVersion
 int 2
-==
+>=
 &&
 global Round
 int 0
@@ -1287,6 +1451,13 @@ int 42
 &&
 `
 
+const globalV3TestProgram = `global CurrentApplicationAddress
+len
+int 32
+==
+&&
+`
+
 func TestGlobal(t *testing.T) {
 	t.Parallel()
 	type desc struct {
@@ -1306,6 +1477,14 @@ func TestGlobal(t *testing.T) {
 			},
 			func(program []byte, ep EvalParams) (int, error) { return CheckStateful(program, ep) },
 		},
+		3: {
+			CurrentApplicationAddress, globalV1TestProgram + globalV2TestProgram + globalV3TestProgram,
+			func(p []byte, ep EvalParams) (bool, error) {
+				pass, _, err := EvalStateful(p, ep)
+				return pass, err
+			},
+			func(program []byte, ep EvalParams) (int, error) { return CheckStateful(program, ep) },
+		},
 	}
 	ledger := makeTestLedger(nil)
 	ledger.appID = 42
@@ -1334,11 +1513,12 @@ func TestGlobal(t *testing.T) {
 			block.BlockHeader.Round = 999999
 			block.BlockHeader.TimeStamp = 2069
 			proto := config.ConsensusParams{
-				MinTxnFee:       123,
-				MinBalance:      1000000,
-				MaxTxnLife:      999,
-				LogicSigVersion: LogicVersion,
-				LogicSigMaxCost: 20000,
+				MinTxnFee:         123,
+				MinBalance:        1000000,
+				MaxTxnLife:        999,
+				LogicSigVersion:   LogicVersion,
+				LogicSigMaxCost:   20000,
+				MaxAppProgramCost: 700,
 			}
 			ep := defaultEvalParams(&sb, &txn)
 			ep.TxnGroup = txgroup
@@ -3032,8 +3212,9 @@ int 1
 			source := fmt.Sprintf(template, line)
 			program, err := AssembleStringWithVersion(source, AssemblerMaxVersion)
 			require.NoError(t, err)
-			program[7] = 0xff // clobber the branch offset
-			program[8] = 0xff // clobber the branch offset
+			// int 0 assembles to pushint 0, so the branch offset follows it at 4 and 5
+			program[4] = 0xff // clobber the branch offset
+			program[5] = 0xff // clobber the branch offset
 			_, err = Check(program, ep)
 			require.Error(t, err)
 			require.Contains(t, err.Error(), "too large")
@@ -3688,6 +3869,280 @@ dup2
 	require.False(t, pass)
 }
 
+func testAccepts(t *testing.T, source string) {
+	t.Helper()
+	program, err := AssembleStringWithVersion(source, AssemblerMaxVersion)
+	require.NoError(t, err)
+	ep := defaultEvalParams(nil, nil)
+	_, err = Check(program, ep)
+	require.NoError(t, err)
+	pass, err := Eval(program, ep)
+	require.NoError(t, err)
+	require.True(t, pass)
+}
+
+func testPanics(t *testing.T, source string, problem string) {
+	t.Helper()
+	program, err := AssembleStringWithVersion(source, AssemblerMaxVersion)
+	require.NoError(t, err)
+	ep := defaultEvalParams(nil, nil)
+	_, err = Check(program, ep)
+	require.NoError(t, err)
+	pass, err := Eval(program, ep)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), problem)
+	require.False(t, pass)
+	isNotPanic(t, err)
+}
+
+func TestPushConstants(t *testing.T) {
+	t.Parallel()
+
+	testAccepts(t, "pushint 1")
+	testAccepts(t, "pushint 0xffffffffffffffff\nint 18446744073709551615\n==")
+	testAccepts(t, "pushbytes \"\"\nlen\n!")
+	testAccepts(t, "pushbytes \"abc\"\nbyte 0x616263\n==")
+	testAccepts(t, "pushbytes base64(AAAA)\nlen\nint 3\n==")
+
+	// a program cut off within the immediate data fails Check
+	program, err := AssembleStringWithVersion("pushbytes 0x01020304", AssemblerMaxVersion)
+	require.NoError(t, err)
+	ep := defaultEvalParams(nil, nil)
+	_, err = Check(program[:len(program)-1], ep)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "pushbytes ran past end of program")
+
+	program, err = AssembleStringWithVersion("pushint 300", AssemblerMaxVersion)
+	require.NoError(t, err)
+	_, err = Check(program[:len(program)-1], ep)
+	require.Error(t, err)
+}
+
+func TestShifts(t *testing.T) {
+	t.Parallel()
+
+	testAccepts(t, "int 1\nint 0\nshl\nint 1\n==")
+	testAccepts(t, "int 1\nint 1\nshl\nint 2\n==")
+	testAccepts(t, "int 1\nint 63\nshl\nint 0x8000000000000000\n==")
+	testAccepts(t, "int 3\nint 63\nshl\nint 0x8000000000000000\n==")
+	testAccepts(t, "int 0xffffffffffffffff\nint 4\nshl\nint 0xfffffffffffffff0\n==")
+	testPanics(t, "int 1\nint 64\nshl\nint 1", "shl arg too big")
+
+	testAccepts(t, "int 1\nint 0\nshr\nint 1\n==")
+	testAccepts(t, "int 2\nint 1\nshr\nint 1\n==")
+	testAccepts(t, "int 0x8000000000000000\nint 63\nshr\nint 1\n==")
+	testAccepts(t, "int 0xffffffffffffffff\nint 60\nshr\nint 15\n==")
+	testPanics(t, "int 1\nint 64\nshr\nint 1", "shr arg too big")
+}
+
+func TestSqrt(t *testing.T) {
+	t.Parallel()
+
+	testAccepts(t, "int 0\nsqrt\nint 0\n==")
+	testAccepts(t, "int 1\nsqrt\nint 1\n==")
+	testAccepts(t, "int 3\nsqrt\nint 1\n==")
+	testAccepts(t, "int 4\nsqrt\nint 2\n==")
+	testAccepts(t, "int 5\nsqrt\nint 2\n==")
+	testAccepts(t, "int 99\nsqrt\nint 9\n==")
+	testAccepts(t, "int 100\nsqrt\nint 10\n==")
+	testAccepts(t, "int 4294967296\nsqrt\nint 65536\n==")
+	testAccepts(t, "int 0xffffffffffffffff\nsqrt\nint 0xffffffff\n==")
+}
+
+func TestGetSetBit(t *testing.T) {
+	t.Parallel()
+
+	testAccepts(t, "int 1\nint 0\ngetbit\nint 1\n==")
+	testAccepts(t, "int 1\nint 1\ngetbit\nint 0\n==")
+	testAccepts(t, "int 0x8000000000000000\nint 63\ngetbit\nint 1\n==")
+	testPanics(t, "int 1\nint 64\ngetbit", "getbit index > 63")
+
+	testAccepts(t, "int 0\nint 3\nint 1\nsetbit\nint 8\n==")
+	testAccepts(t, "int 15\nint 0\nint 0\nsetbit\nint 14\n==")
+	testAccepts(t, "int 15\nint 0\nint 1\nsetbit\nint 15\n==")
+	testPanics(t, "int 1\nint 64\nint 0\nsetbit", "setbit index > 63")
+	testPanics(t, "int 1\nint 3\nint 2\nsetbit", "setbit value > 1")
+
+	// bit 0 of a byte array is the high order bit of its first byte
+	testAccepts(t, "byte 0x80\nint 0\ngetbit\nint 1\n==")
+	testAccepts(t, "byte 0x80\nint 7\ngetbit\nint 0\n==")
+	testAccepts(t, "byte 0x0001\nint 15\ngetbit\nint 1\n==")
+	testPanics(t, "byte 0x0001\nint 16\ngetbit", "getbit index beyond byteslice")
+
+	testAccepts(t, "byte 0x00\nint 3\nint 1\nsetbit\nbyte 0x10\n==")
+	testAccepts(t, "byte 0x0000\nint 15\nint 1\nsetbit\nbyte 0x0001\n==")
+	testAccepts(t, "byte 0xff\nint 0\nint 0\nsetbit\nbyte 0x7f\n==")
+	testPanics(t, "byte 0x00\nint 8\nint 1\nsetbit", "setbit index beyond byteslice")
+
+	// setbit must not modify the original constant
+	testAccepts(t, "byte 0x00\ndup\nint 0\nint 1\nsetbit\npop\nbyte 0x00\n==")
+}
+
+func TestGetSetByte(t *testing.T) {
+	t.Parallel()
+
+	testAccepts(t, "byte 0x0102\nint 0\ngetbyte\nint 1\n==")
+	testAccepts(t, "byte 0x0102\nint 1\ngetbyte\nint 2\n==")
+	testPanics(t, "byte 0x0102\nint 2\ngetbyte", "getbyte index beyond array length")
+
+	testAccepts(t, "byte 0x0102\nint 0\nint 255\nsetbyte\nbyte 0xff02\n==")
+	testAccepts(t, "byte 0x0102\nint 1\nint 0\nsetbyte\nbyte 0x0100\n==")
+	testPanics(t, "byte 0x0102\nint 2\nint 1\nsetbyte", "setbyte index beyond array length")
+	testPanics(t, "byte 0x0102\nint 0\nint 256\nsetbyte", "setbyte value > 255")
+
+	// setbyte must not modify the original constant
+	testAccepts(t, "byte 0x0102\ndup\nint 0\nint 7\nsetbyte\npop\nbyte 0x0102\n==")
+}
+
+func TestSelectSwapDig(t *testing.T) {
+	t.Parallel()
+
+	testAccepts(t, "int 1\nint 2\nint 0\nselect\nint 1\n==")
+	testAccepts(t, "int 1\nint 2\nint 7\nselect\nint 2\n==")
+	testAccepts(t, "byte 0x01\nbyte 0x02\nint 1\nselect\nbyte 0x02\n==")
+	testPanics(t, "int 1\nint 0\nselect", "stack underflow")
+
+	testAccepts(t, "int 1\nint 2\nswap\nint 1\n==\nswap\nint 2\n==\n&&")
+	testAccepts(t, "int 1\nbyte 0x02\nswap\npop\nlen\nint 1\n==")
+	testPanics(t, "int 1\nswap", "stack underflow")
+
+	testAccepts(t, "int 3\nint 2\nint 1\ndig 2\nint 3\n==\nswap\npop\nswap\npop\nswap\npop")
+	testAccepts(t, "int 3\ndig 0\n==")
+	testPanics(t, "int 3\ndig 1", "dig 1 with stack size = 1")
+}
+
+func TestBytesMath(t *testing.T) {
+	t.Parallel()
+
+	values := [][]byte{
+		{},
+		{0x00},
+		{0x01},
+		{0x00, 0x01},
+		{0x02},
+		{0x07},
+		{0xff},
+		{0x01, 0x00},
+		{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		{0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		[]byte(strings.Repeat("\xff", 32)),
+		[]byte("\x80" + strings.Repeat("\x00", 63)),
+		[]byte(strings.Repeat("\xff", MaxByteMathSize)),
+	}
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 8; i++ {
+		v := make([]byte, rnd.Intn(MaxByteMathSize+1))
+		rnd.Read(v)
+		values = append(values, v)
+	}
+
+	padded := func(a, b []byte) (x, y []byte) {
+		size := len(a)
+		if len(b) > size {
+			size = len(b)
+		}
+		x = make([]byte, size)
+		copy(x[size-len(a):], a)
+		y = make([]byte, size)
+		copy(y[size-len(b):], b)
+		return
+	}
+
+	// each case returns the expected result as bytes or uint64, or the expected panic
+	type expectation func(a, b []byte) (result interface{}, problem string)
+	ops := map[string]expectation{
+		"b+": func(a, b []byte) (interface{}, string) {
+			x, y := new(big.Int).SetBytes(a), new(big.Int).SetBytes(b)
+			return x.Add(x, y).Bytes(), ""
+		},
+		"b-": func(a, b []byte) (interface{}, string) {
+			x, y := new(big.Int).SetBytes(a), new(big.Int).SetBytes(b)
+			if x.Cmp(y) < 0 {
+				return nil, "byte math would have negative result"
+			}
+			return x.Sub(x, y).Bytes(), ""
+		},
+		"b/": func(a, b []byte) (interface{}, string) {
+			x, y := new(big.Int).SetBytes(a), new(big.Int).SetBytes(b)
+			if y.Sign() == 0 {
+				return nil, "division by zero"
+			}
+			return x.Quo(x, y).Bytes(), ""
+		},
+		"b*": func(a, b []byte) (interface{}, string) {
+			x, y := new(big.Int).SetBytes(a), new(big.Int).SetBytes(b)
+			return x.Mul(x, y).Bytes(), ""
+		},
+		"b%": func(a, b []byte) (interface{}, string) {
+			x, y := new(big.Int).SetBytes(a), new(big.Int).SetBytes(b)
+			if y.Sign() == 0 {
+				return nil, "modulo by zero"
+			}
+			return x.Rem(x, y).Bytes(), ""
+		},
+		"b<": func(a, b []byte) (interface{}, string) {
+			x, y := new(big.Int).SetBytes(a), new(big.Int).SetBytes(b)
+			if x.Cmp(y) < 0 {
+				return uint64(1), ""
+			}
+			return uint64(0), ""
+		},
+		"b==": func(a, b []byte) (interface{}, string) {
+			x, y := new(big.Int).SetBytes(a), new(big.Int).SetBytes(b)
+			if x.Cmp(y) == 0 {
+				return uint64(1), ""
+			}
+			return uint64(0), ""
+		},
+		"b|": func(a, b []byte) (interface{}, string) {
+			x, y := padded(a, b)
+			for i := range x {
+				x[i] |= y[i]
+			}
+			return x, ""
+		},
+		"b&": func(a, b []byte) (interface{}, string) {
+			x, y := padded(a, b)
+			for i := range x {
+				x[i] &= y[i]
+			}
+			return x, ""
+		},
+	}
+
+	for name, expect := range ops {
+		for _, a := range values {
+			for _, b := range values {
+				source := fmt.Sprintf("byte 0x%s\nbyte 0x%s\n%s\n", hex.EncodeToString(a), hex.EncodeToString(b), name)
+				result, problem := expect(a, b)
+				switch r := result.(type) {
+				case []byte:
+					// comparing with == also checks the result length
+					testAccepts(t, source+fmt.Sprintf("byte 0x%s\n==", hex.EncodeToString(r)))
+				case uint64:
+					testAccepts(t, source+fmt.Sprintf("int %d\n==", r))
+				default:
+					testPanics(t, source+"int 1", problem)
+				}
+			}
+		}
+	}
+
+	// inputs are limited to MaxByteMathSize bytes
+	large := hex.EncodeToString([]byte(strings.Repeat("\x01", MaxByteMathSize+1)))
+	for name := range ops {
+		testPanics(t, fmt.Sprintf("byte 0x%s\nbyte 0x01\n%s\nint 1", large, name), "math attempted on large byte-array")
+		testPanics(t, fmt.Sprintf("byte 0x01\nbyte 0x%s\n%s\nint 1", large, name), "math attempted on large byte-array")
+	}
+	// so the result of b+ may not be usable as input to more byte math
+	max := hex.EncodeToString([]byte(strings.Repeat("\xff", MaxByteMathSize)))
+	testPanics(t, fmt.Sprintf("byte 0x%s\nbyte 0x01\nb+\nbyte 0x01\nb+\nint 1", max), "math attempted on large byte-array")
+
+	// b| and b& do not modify their inputs
+	testAccepts(t, "byte 0x00ff\ndup\nbyte 0xff00\nb|\npop\nbyte 0x00ff\n==")
+	testAccepts(t, "byte 0x00ff\ndup\nbyte 0xff00\nb&\npop\nbyte 0x00ff\n==")
+}
+
 func TestStringLiteral(t *testing.T) {
 	t.Parallel()
 
@@ -3912,7 +4367,7 @@ func TestAllowedOpcodesV2(t *testing.T) {
 
 	cnt := 0
 	for _, spec := range OpSpecs {
-		if spec.Version > 1 && !excluded[spec.Name] {
+		if spec.Version == 2 && !excluded[spec.Name] {
 			source, ok := tests[spec.Name]
 			require.True(t, ok, fmt.Sprintf("Missed opcode in the test: %s", spec.Name))
 			program, err := AssembleStringWithVersion(source, AssemblerMaxVersion)
@@ -3948,6 +4403,76 @@ func TestAllowedOpcodesV2(t *testing.T) {
 	require.Equal(t, len(tests), cnt)
 }
 
+// check all v3 opcodes: allowed in v3 and not allowed before
+func TestAllowedOpcodesV3(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"callsub":     "callsub l\nl:\nretsub",
+		"retsub":      "retsub",
+		"itxn_begin":  "itxn_begin",
+		"itxn_field":  "int 1\nitxn_field TypeEnum",
+		"itxn_submit": "itxn_submit",
+		"pushbytes":   "pushbytes 0x45",
+		"pushint":     "pushint 7\npushint 4",
+		"shl":         "int 1\nshl",
+		"shr":         "int 1\nshr",
+		"sqrt":        "sqrt",
+		"getbit":      "int 15\ngetbit",
+		"setbit":      "int 15\nint 64\nint 0\nsetbit",
+		"getbyte":     "byte 0x42\nint 2\ngetbyte",
+		"setbyte":     "byte 0x42\nint 2\nint 255\nsetbyte",
+		"select":      "select",
+		"swap":        "int 1\nbyte 0x42\nswap",
+		"dig":         "int 3\ndig 0",
+		"b+":          "byte 0x01\nbyte 0x02\nb+",
+		"b-":          "byte 0x01\nbyte 0x02\nb-",
+		"b/":          "byte 0x01\nbyte 0x\nb/",
+		"b*":          "byte 0x01\nbyte 0x02\nb*",
+		"b%":          "byte 0x01\nbyte 0x\nb%",
+		"b<":          "byte 0x01\nbyte 0x02\nb<\nint 5",
+		"b==":         "byte 0x01\nbyte 0x02\nb==\nint 5",
+		"b|":          "byte 0x01\nbyte 0x02\nb|",
+		"b&":          "byte 0x01\nbyte 0x02\nb&",
+		"box_create":  "byte 0x01\nint 1\nbox_create",
+		"box_extract": "byte 0x01\nint 0\nint 1\nbox_extract",
+		"box_replace": "byte 0x01\nint 0\nbyte 0x01\nbox_replace",
+		"box_del":     "byte 0x01\nbox_del",
+		"box_len":     "byte 0x01\nbox_len",
+		"box_get":     "byte 0x01\nbox_get",
+		"box_put":     "byte 0x01\nbyte 0x02\nbox_put",
+	}
+
+	ep := defaultEvalParams(nil, nil)
+
+	cnt := 0
+	for _, spec := range OpSpecs {
+		if spec.Version == 3 {
+			source, ok := tests[spec.Name]
+			require.True(t, ok, fmt.Sprintf("Missed opcode in the test: %s", spec.Name))
+			program, err := AssembleStringWithVersion(source, AssemblerMaxVersion)
+			require.NoError(t, err, source)
+			_, err = CheckStateful(program, ep)
+			require.NoError(t, err, source)
+			_, _, err = EvalStateful(program, ep)
+			require.Error(t, err, source)
+			require.NotContains(t, err.Error(), "illegal opcode")
+
+			for v := byte(0); v < 3; v++ {
+				program[0] = v
+				_, err = Check(program, ep)
+				require.Error(t, err, source)
+				require.Contains(t, err.Error(), "illegal opcode")
+				_, err = Eval(program, ep)
+				require.Error(t, err, source)
+				require.Contains(t, err.Error(), "illegal opcode")
+			}
+			cnt++
+		}
+	}
+	require.Equal(t, len(tests), cnt)
+}
+
 func TestRekeyFailsOnOldVersion(t *testing.T) {
 	t.Parallel()
 	for v := uint64(0); v < rekeyingEnabledVersion; v++ {
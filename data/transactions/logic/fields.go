@@ -218,6 +218,43 @@ var txnaFieldSpecByField = map[TxnField]txnFieldSpec{
 	Accounts:        {Accounts, StackBytes, 2},
 }
 
+// innerTxnFields are the txn fields that itxn_field may set. The sender and
+// the validity window of an inner transaction are always chosen by the
+// evaluator, and rekeying the application account is not possible.
+var innerTxnFields = map[TxnField]bool{
+	Type:                     true,
+	TypeEnum:                 true,
+	Fee:                      true,
+	Note:                     true,
+	Receiver:                 true,
+	Amount:                   true,
+	CloseRemainderTo:         true,
+	XferAsset:                true,
+	AssetAmount:              true,
+	AssetSender:              true,
+	AssetReceiver:            true,
+	AssetCloseTo:             true,
+	ConfigAsset:              true,
+	ConfigAssetTotal:         true,
+	ConfigAssetDecimals:      true,
+	ConfigAssetDefaultFrozen: true,
+	ConfigAssetUnitName:      true,
+	ConfigAssetName:          true,
+	ConfigAssetURL:           true,
+	ConfigAssetMetadataHash:  true,
+	ConfigAssetManager:       true,
+	ConfigAssetReserve:       true,
+	ConfigAssetFreeze:        true,
+	ConfigAssetClawback:      true,
+}
+
+// innerTxnTypes are the transaction types that itxn_submit may issue
+var innerTxnTypes = map[protocol.TxType]bool{
+	protocol.PaymentTx:       true,
+	protocol.AssetTransferTx: true,
+	protocol.AssetConfigTx:   true,
+}
+
 // TxnTypeNames is the values of Txn.Type in enum order
 var TxnTypeNames = []string{
 	string(protocol.UnknownTx),
@@ -283,6 +320,8 @@ const (
 	LatestTimestamp
 	// CurrentApplicationID uint64
 	CurrentApplicationID
+	// CurrentApplicationAddress [32]byte
+	CurrentApplicationAddress
 
 	invalidGlobalField
 )
@@ -310,6 +349,7 @@ var globalFieldSpecs = []globalFieldSpec{
 	{Round, StackUint64, runModeApplication, 2},
 	{LatestTimestamp, StackUint64, runModeApplication, 2},
 	{CurrentApplicationID, StackUint64, runModeApplication, 2},
+	{CurrentApplicationAddress, StackBytes, runModeApplication, 3},
 }
 
 // GlobalFieldSpecByField maps GlobalField to spec
@@ -21,7 +21,7 @@ import (
 )
 
 // LogicVersion defines default assembler and max eval versions
-const LogicVersion = 2
+const LogicVersion = 3
 
 // rekeyingEnabledVersion is the version of TEAL where RekeyTo functionality
 // was enabled. This is important to remember so that old TEAL accounts cannot
@@ -33,6 +33,16 @@ const rekeyingEnabledVersion = 2
 // from being used with applications. Do not edit!
 const appsEnabledVersion = 2
 
+// subroutineVersion is the version of TEAL where callsub/retsub were
+// introduced, along with the run-time enforcement of the program cost
+// budget which replaced the static loop detection. Do not edit!
+const subroutineVersion = 3
+
+// optimizeConstantsEnabledVersion is the version of TEAL where the assembler
+// starts to push constants referenced only once with pushint and pushbytes
+// and orders the intcblock and bytecblock by number of references. Do not edit!
+const optimizeConstantsEnabledVersion = 3
+
 // opSize records the length in bytes for an op that is constant-length but not length 1
 type opSize struct {
 	cost      int
@@ -59,6 +69,7 @@ type OpSpec struct {
 var oneBytes = StackTypes{StackBytes}
 var twoBytes = StackTypes{StackBytes, StackBytes}
 var threeBytes = StackTypes{StackBytes, StackBytes, StackBytes}
+var byteInt = StackTypes{StackBytes, StackUint64}
 var byteIntInt = StackTypes{StackBytes, StackUint64, StackUint64}
 var oneInt = StackTypes{StackUint64}
 var twoInts = StackTypes{StackUint64, StackUint64}
@@ -111,16 +122,16 @@ var OpSpecs = []OpSpec{
 
 	{0x20, "intcblock", opIntConstBlock, assembleIntCBlock, disIntcblock, nil, nil, 1, modeAny, opSize{1, 0, checkIntConstBlock}},
 	{0x21, "intc", opIntConstLoad, assembleIntC, disIntc, nil, oneInt, 1, modeAny, opSize{1, 2, nil}},
-	{0x22, "intc_0", opIntConst0, asmDefault, disDefault, nil, oneInt, 1, modeAny, opSizeDefault},
-	{0x23, "intc_1", opIntConst1, asmDefault, disDefault, nil, oneInt, 1, modeAny, opSizeDefault},
-	{0x24, "intc_2", opIntConst2, asmDefault, disDefault, nil, oneInt, 1, modeAny, opSizeDefault},
-	{0x25, "intc_3", opIntConst3, asmDefault, disDefault, nil, oneInt, 1, modeAny, opSizeDefault},
+	{0x22, "intc_0", opIntConst0, assembleIntCN, disDefault, nil, oneInt, 1, modeAny, opSizeDefault},
+	{0x23, "intc_1", opIntConst1, assembleIntCN, disDefault, nil, oneInt, 1, modeAny, opSizeDefault},
+	{0x24, "intc_2", opIntConst2, assembleIntCN, disDefault, nil, oneInt, 1, modeAny, opSizeDefault},
+	{0x25, "intc_3", opIntConst3, assembleIntCN, disDefault, nil, oneInt, 1, modeAny, opSizeDefault},
 	{0x26, "bytecblock", opByteConstBlock, assembleByteCBlock, disBytecblock, nil, nil, 1, modeAny, opSize{1, 0, checkByteConstBlock}},
 	{0x27, "bytec", opByteConstLoad, assembleByteC, disBytec, nil, oneBytes, 1, modeAny, opSize{1, 2, nil}},
-	{0x28, "bytec_0", opByteConst0, asmDefault, disDefault, nil, oneBytes, 1, modeAny, opSizeDefault},
-	{0x29, "bytec_1", opByteConst1, asmDefault, disDefault, nil, oneBytes, 1, modeAny, opSizeDefault},
-	{0x2a, "bytec_2", opByteConst2, asmDefault, disDefault, nil, oneBytes, 1, modeAny, opSizeDefault},
-	{0x2b, "bytec_3", opByteConst3, asmDefault, disDefault, nil, oneBytes, 1, modeAny, opSizeDefault},
+	{0x28, "bytec_0", opByteConst0, assembleByteCN, disDefault, nil, oneBytes, 1, modeAny, opSizeDefault},
+	{0x29, "bytec_1", opByteConst1, assembleByteCN, disDefault, nil, oneBytes, 1, modeAny, opSizeDefault},
+	{0x2a, "bytec_2", opByteConst2, assembleByteCN, disDefault, nil, oneBytes, 1, modeAny, opSizeDefault},
+	{0x2b, "bytec_3", opByteConst3, assembleByteCN, disDefault, nil, oneBytes, 1, modeAny, opSizeDefault},
 	{0x2c, "arg", opArg, assembleArg, disArg, nil, oneBytes, 1, runModeSignature, opSize{1, 2, nil}},
 	{0x2d, "arg_0", opArg0, asmDefault, disDefault, nil, oneBytes, 1, runModeSignature, opSizeDefault},
 	{0x2e, "arg_1", opArg1, asmDefault, disDefault, nil, oneBytes, 1, runModeSignature, opSizeDefault},
@@ -146,10 +157,17 @@ var OpSpecs = []OpSpec{
 	{0x48, "pop", opPop, asmDefault, disDefault, oneAny, nil, 1, modeAny, opSizeDefault},
 	{0x49, "dup", opDup, asmDefault, disDefault, oneAny, twoAny, 1, modeAny, opSizeDefault},
 	{0x4a, "dup2", opDup2, asmDefault, disDefault, twoAny, twoAny.plus(twoAny), 2, modeAny, opSizeDefault},
+	{0x4b, "dig", opDig, assembleDig, disDig, oneAny, twoAny, 3, modeAny, opSize{1, 2, nil}},
+	{0x4c, "swap", opSwap, asmDefault, disDefault, twoAny, twoAny, 3, modeAny, opSizeDefault},
+	{0x4d, "select", opSelect, asmDefault, disDefault, twoAny.plus(oneInt), oneAny, 3, modeAny, opSizeDefault},
 
 	{0x50, "concat", opConcat, asmDefault, disDefault, twoBytes, oneBytes, 2, modeAny, opSizeDefault},
 	{0x51, "substring", opSubstring, assembleSubstring, disSubstring, oneBytes, oneBytes, 2, modeAny, opSize{1, 3, nil}},
 	{0x52, "substring3", opSubstring3, asmDefault, disDefault, byteIntInt, oneBytes, 2, modeAny, opSizeDefault},
+	{0x53, "getbit", opGetBit, asmDefault, disDefault, oneAny.plus(oneInt), oneInt, 3, modeAny, opSizeDefault},
+	{0x54, "setbit", opSetBit, asmDefault, disDefault, oneAny.plus(twoInts), oneAny, 3, modeAny, opSizeDefault},
+	{0x55, "getbyte", opGetByte, asmDefault, disDefault, byteInt, oneInt, 3, modeAny, opSizeDefault},
+	{0x56, "setbyte", opSetByte, asmDefault, disDefault, byteIntInt, oneBytes, 3, modeAny, opSizeDefault},
 
 	{0x60, "balance", opBalance, asmDefault, disDefault, oneInt, oneInt, 2, runModeApplication, opSizeDefault},
 	{0x61, "app_opted_in", opAppCheckOptedIn, asmDefault, disDefault, twoInts, oneInt, 2, runModeApplication, opSizeDefault},
@@ -164,6 +182,39 @@ var OpSpecs = []OpSpec{
 
 	{0x70, "asset_holding_get", opAssetHoldingGet, assembleAssetHolding, disAssetHolding, twoInts, oneInt.plus(oneAny), 2, runModeApplication, opSize{1, 2, nil}},
 	{0x71, "asset_params_get", opAssetParamsGet, assembleAssetParams, disAssetParams, oneInt, oneInt.plus(oneAny), 2, runModeApplication, opSize{1, 2, nil}},
+
+	{0x80, "pushbytes", opPushBytes, assemblePushBytes, disPushBytes, nil, oneBytes, 3, modeAny, opSize{1, 0, checkPushBytes}},
+	{0x81, "pushint", opPushInt, assemblePushInt, disPushInt, nil, oneInt, 3, modeAny, opSize{1, 0, checkPushInt}},
+
+	{0x88, "callsub", opCallSub, assembleCallSub, disCallSub, nil, nil, 3, modeAny, opSize{1, 3, checkCallSub}},
+	{0x89, "retsub", opRetSub, asmDefault, disDefault, nil, nil, 3, modeAny, opSizeDefault},
+
+	{0x90, "shl", opShiftLeft, asmDefault, disDefault, twoInts, oneInt, 3, modeAny, opSizeDefault},
+	{0x91, "shr", opShiftRight, asmDefault, disDefault, twoInts, oneInt, 3, modeAny, opSizeDefault},
+	{0x92, "sqrt", opSqrt, asmDefault, disDefault, oneInt, oneInt, 3, modeAny, opSize{4, 1, nil}},
+
+	// Byte-array math, with byte strings interpreted as big-endian unsigned integers
+	{0xa0, "b+", opBytesPlus, asmDefault, disDefault, twoBytes, oneBytes, 3, modeAny, opSize{10, 1, nil}},
+	{0xa1, "b-", opBytesMinus, asmDefault, disDefault, twoBytes, oneBytes, 3, modeAny, opSize{10, 1, nil}},
+	{0xa2, "b/", opBytesDiv, asmDefault, disDefault, twoBytes, oneBytes, 3, modeAny, opSize{20, 1, nil}},
+	{0xa3, "b*", opBytesMul, asmDefault, disDefault, twoBytes, oneBytes, 3, modeAny, opSize{20, 1, nil}},
+	{0xa4, "b%", opBytesModulo, asmDefault, disDefault, twoBytes, oneBytes, 3, modeAny, opSize{20, 1, nil}},
+	{0xa5, "b<", opBytesLt, asmDefault, disDefault, twoBytes, oneInt, 3, modeAny, opSizeDefault},
+	{0xa6, "b==", opBytesEq, asmDefault, disDefault, twoBytes, oneInt, 3, modeAny, opSizeDefault},
+	{0xa7, "b|", opBytesBitOr, asmDefault, disDefault, twoBytes, oneBytes, 3, modeAny, opSize{6, 1, nil}},
+	{0xa8, "b&", opBytesBitAnd, asmDefault, disDefault, twoBytes, oneBytes, 3, modeAny, opSize{6, 1, nil}},
+
+	{0xb1, "itxn_begin", opItxnBegin, asmDefault, disDefault, nil, nil, 3, runModeApplication, opSizeDefault},
+	{0xb2, "itxn_field", opItxnField, assembleItxnField, disItxnField, oneAny, nil, 3, runModeApplication, opSize{1, 2, nil}},
+	{0xb3, "itxn_submit", opItxnSubmit, asmDefault, disDefault, nil, nil, 3, runModeApplication, opSizeDefault},
+
+	{0xb9, "box_create", opBoxCreate, asmDefault, disDefault, byteInt, oneInt, 3, runModeApplication, opSizeDefault},
+	{0xba, "box_extract", opBoxExtract, asmDefault, disDefault, byteIntInt, oneBytes, 3, runModeApplication, opSizeDefault},
+	{0xbb, "box_replace", opBoxReplace, asmDefault, disDefault, byteInt.plus(oneBytes), nil, 3, runModeApplication, opSizeDefault},
+	{0xbc, "box_del", opBoxDel, asmDefault, disDefault, oneBytes, oneInt, 3, runModeApplication, opSizeDefault},
+	{0xbd, "box_len", opBoxLen, asmDefault, disDefault, oneBytes, twoInts, 3, runModeApplication, opSizeDefault},
+	{0xbe, "box_get", opBoxGet, asmDefault, disDefault, oneBytes, oneBytes.plus(oneInt), 3, runModeApplication, opSizeDefault},
+	{0xbf, "box_put", opBoxPut, asmDefault, disDefault, twoBytes, nil, 3, runModeApplication, opSizeDefault},
 }
 
 type sortByOpcode []OpSpec
@@ -84,7 +84,7 @@ func TestOpcodesByVersion(t *testing.T) {
 		OpSpecs2[idx] = cp
 	}
 
-	opSpecs := make([][]OpSpec, 2)
+	opSpecs := make([][]OpSpec, LogicVersion)
 	for v := uint64(1); v <= LogicVersion; v++ {
 		t.Run(fmt.Sprintf("v=%d", v), func(t *testing.T) {
 			opSpecs[v-1] = OpcodesByVersion(v)
@@ -117,8 +117,8 @@ func TestOpcodesByVersion(t *testing.T) {
 func TestOpcodesVersioningV2(t *testing.T) {
 	t.Parallel()
 
-	require.Equal(t, 3, len(opsByOpcode))
-	require.Equal(t, 3, len(opsByName))
+	require.Equal(t, LogicVersion+1, len(opsByOpcode))
+	require.Equal(t, LogicVersion+1, len(opsByName))
 
 	// ensure v0 has only v0 opcodes
 	cntv0 := 0
@@ -203,3 +203,31 @@ func TestOpcodesVersioningV2(t *testing.T) {
 
 	require.Equal(t, cntv2, cntv1+newOpcodes)
 }
+
+func TestOpcodesVersioningV3(t *testing.T) {
+	t.Parallel()
+
+	// ensure v3 has v1, v2 and v3 opcodes
+	cntv2 := 0
+	for _, spec := range opsByOpcode[2] {
+		if spec.op != nil {
+			cntv2++
+		}
+	}
+	cntv3 := 0
+	for _, spec := range opsByOpcode[3] {
+		if spec.op != nil {
+			require.True(t, spec.Version >= 1 && spec.Version <= 3)
+			cntv3++
+		}
+	}
+	require.Equal(t, cntv3, len(opsByName[3]))
+
+	// hardcode and ensure amount of new v3 opcodes
+	// callsub, retsub, itxn_begin, itxn_field, itxn_submit,
+	// pushbytes, pushint, shl, shr, sqrt, getbit, setbit, getbyte, setbyte, select, swap, dig,
+	// b+, b-, b/, b*, b%, b<, b==, b|, b&,
+	// box_create, box_extract, box_replace, box_del, box_len, box_get, box_put
+	newOpcodes := 33
+	require.Equal(t, cntv2+newOpcodes, cntv3)
+}
@@ -764,8 +764,8 @@ func (z *ApplicationCallTxnFields) MsgIsZero() bool {
 func (z *ApplyData) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
 	// omitempty: check for empty values
-	zb0001Len := uint32(5)
-	var zb0001Mask uint8 /* 6 bits */
+	zb0001Len := uint32(6)
+	var zb0001Mask uint8 /* 7 bits */
 	if (*z).ClosingAmount.MsgIsZero() {
 		zb0001Len--
 		zb0001Mask |= 0x2
@@ -774,18 +774,22 @@ func (z *ApplyData) MarshalMsg(b []byte) (o []byte, err error) {
 		zb0001Len--
 		zb0001Mask |= 0x4
 	}
-	if (*z).CloseRewards.MsgIsZero() {
+	if len((*z).InnerTxns) == 0 {
 		zb0001Len--
 		zb0001Mask |= 0x8
 	}
-	if (*z).ReceiverRewards.MsgIsZero() {
+	if (*z).CloseRewards.MsgIsZero() {
 		zb0001Len--
 		zb0001Mask |= 0x10
 	}
-	if (*z).SenderRewards.MsgIsZero() {
+	if (*z).ReceiverRewards.MsgIsZero() {
 		zb0001Len--
 		zb0001Mask |= 0x20
 	}
+	if (*z).SenderRewards.MsgIsZero() {
+		zb0001Len--
+		zb0001Mask |= 0x40
+	}
 	// variable map header, size zb0001Len
 	o = append(o, 0x80|uint8(zb0001Len))
 	if zb0001Len != 0 {
@@ -808,6 +812,22 @@ func (z *ApplyData) MarshalMsg(b []byte) (o []byte, err error) {
 			}
 		}
 		if (zb0001Mask & 0x8) == 0 { // if not empty
+			// string "itx"
+			o = append(o, 0xa3, 0x69, 0x74, 0x78)
+			if (*z).InnerTxns == nil {
+				o = msgp.AppendNil(o)
+			} else {
+				o = msgp.AppendArrayHeader(o, uint32(len((*z).InnerTxns)))
+			}
+			for zb0003 := range (*z).InnerTxns {
+				o, err = (*z).InnerTxns[zb0003].MarshalMsg(o)
+				if err != nil {
+					err = msgp.WrapError(err, "InnerTxns", zb0003)
+					return
+				}
+			}
+		}
+		if (zb0001Mask & 0x10) == 0 { // if not empty
 			// string "rc"
 			o = append(o, 0xa2, 0x72, 0x63)
 			o, err = (*z).CloseRewards.MarshalMsg(o)
@@ -816,7 +836,7 @@ func (z *ApplyData) MarshalMsg(b []byte) (o []byte, err error) {
 				return
 			}
 		}
-		if (zb0001Mask & 0x10) == 0 { // if not empty
+		if (zb0001Mask & 0x20) == 0 { // if not empty
 			// string "rr"
 			o = append(o, 0xa2, 0x72, 0x72)
 			o, err = (*z).ReceiverRewards.MarshalMsg(o)
@@ -825,7 +845,7 @@ func (z *ApplyData) MarshalMsg(b []byte) (o []byte, err error) {
 				return
 			}
 		}
-		if (zb0001Mask & 0x20) == 0 { // if not empty
+		if (zb0001Mask & 0x40) == 0 { // if not empty
 			// string "rs"
 			o = append(o, 0xa2, 0x72, 0x73)
 			o, err = (*z).SenderRewards.MarshalMsg(o)
@@ -896,6 +916,35 @@ func (z *ApplyData) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				return
 			}
 		}
+		if zb0001 > 0 {
+			zb0001--
+			var zb0004 int
+			var zb0005 bool
+			zb0004, zb0005, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "struct-from-array", "InnerTxns")
+				return
+			}
+			if zb0004 > encodedMaxInnerTransactions {
+				err = msgp.ErrOverflow(uint64(zb0004), uint64(encodedMaxInnerTransactions))
+				err = msgp.WrapError(err, "struct-from-array", "InnerTxns")
+				return
+			}
+			if zb0005 {
+				(*z).InnerTxns = nil
+			} else if (*z).InnerTxns != nil && cap((*z).InnerTxns) >= zb0004 {
+				(*z).InnerTxns = ((*z).InnerTxns)[:zb0004]
+			} else {
+				(*z).InnerTxns = make([]SignedTxnWithAD, zb0004)
+			}
+			for zb0003 := range (*z).InnerTxns {
+				bts, err = (*z).InnerTxns[zb0003].UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "struct-from-array", "InnerTxns", zb0003)
+					return
+				}
+			}
+		}
 		if zb0001 > 0 {
 			err = msgp.ErrTooManyArrayFields(zb0001)
 			if err != nil {
@@ -949,6 +998,33 @@ func (z *ApplyData) UnmarshalMsg(bts []byte) (o []byte, err error) {
 					err = msgp.WrapError(err, "EvalDelta")
 					return
 				}
+			case "itx":
+				var zb0006 int
+				var zb0007 bool
+				zb0006, zb0007, bts, err = msgp.ReadArrayHeaderBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "InnerTxns")
+					return
+				}
+				if zb0006 > encodedMaxInnerTransactions {
+					err = msgp.ErrOverflow(uint64(zb0006), uint64(encodedMaxInnerTransactions))
+					err = msgp.WrapError(err, "InnerTxns")
+					return
+				}
+				if zb0007 {
+					(*z).InnerTxns = nil
+				} else if (*z).InnerTxns != nil && cap((*z).InnerTxns) >= zb0006 {
+					(*z).InnerTxns = ((*z).InnerTxns)[:zb0006]
+				} else {
+					(*z).InnerTxns = make([]SignedTxnWithAD, zb0006)
+				}
+				for zb0003 := range (*z).InnerTxns {
+					bts, err = (*z).InnerTxns[zb0003].UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "InnerTxns", zb0003)
+						return
+					}
+				}
 			default:
 				err = msgp.ErrNoField(string(field))
 				if err != nil {
@@ -969,13 +1045,16 @@ func (_ *ApplyData) CanUnmarshalMsg(z interface{}) bool {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *ApplyData) Msgsize() (s int) {
-	s = 1 + 3 + (*z).ClosingAmount.Msgsize() + 3 + (*z).SenderRewards.Msgsize() + 3 + (*z).ReceiverRewards.Msgsize() + 3 + (*z).CloseRewards.Msgsize() + 3 + (*z).EvalDelta.Msgsize()
+	s = 1 + 3 + (*z).ClosingAmount.Msgsize() + 3 + (*z).SenderRewards.Msgsize() + 3 + (*z).ReceiverRewards.Msgsize() + 3 + (*z).CloseRewards.Msgsize() + 3 + (*z).EvalDelta.Msgsize() + 4 + msgp.ArrayHeaderSize
+	for zb0003 := range (*z).InnerTxns {
+		s += (*z).InnerTxns[zb0003].Msgsize()
+	}
 	return
 }
 
 // MsgIsZero returns whether this is a zero value
 func (z *ApplyData) MsgIsZero() bool {
-	return ((*z).ClosingAmount.MsgIsZero()) && ((*z).SenderRewards.MsgIsZero()) && ((*z).ReceiverRewards.MsgIsZero()) && ((*z).CloseRewards.MsgIsZero()) && ((*z).EvalDelta.MsgIsZero())
+	return ((*z).ClosingAmount.MsgIsZero()) && ((*z).SenderRewards.MsgIsZero()) && ((*z).ReceiverRewards.MsgIsZero()) && ((*z).CloseRewards.MsgIsZero()) && ((*z).EvalDelta.MsgIsZero()) && (len((*z).InnerTxns) == 0)
 }
 
 // MarshalMsg implements msgp.Marshaler
@@ -3054,8 +3133,8 @@ func (z *SignedTxn) MsgIsZero() bool {
 func (z *SignedTxnInBlock) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
 	// omitempty: check for empty values
-	zb0001Len := uint32(12)
-	var zb0001Mask uint16 /* 16 bits */
+	zb0001Len := uint32(13)
+	var zb0001Mask uint32 /* 17 bits */
 	if (*z).SignedTxnWithAD.ApplyData.ClosingAmount.MsgIsZero() {
 		zb0001Len--
 		zb0001Mask |= 0x10
@@ -3072,38 +3151,42 @@ func (z *SignedTxnInBlock) MarshalMsg(b []byte) (o []byte, err error) {
 		zb0001Len--
 		zb0001Mask |= 0x80
 	}
-	if (*z).SignedTxnWithAD.SignedTxn.Lsig.MsgIsZero() {
+	if len((*z).SignedTxnWithAD.ApplyData.InnerTxns) == 0 {
 		zb0001Len--
 		zb0001Mask |= 0x100
 	}
-	if (*z).SignedTxnWithAD.SignedTxn.Msig.MsgIsZero() {
+	if (*z).SignedTxnWithAD.SignedTxn.Lsig.MsgIsZero() {
 		zb0001Len--
 		zb0001Mask |= 0x200
 	}
-	if (*z).SignedTxnWithAD.ApplyData.CloseRewards.MsgIsZero() {
+	if (*z).SignedTxnWithAD.SignedTxn.Msig.MsgIsZero() {
 		zb0001Len--
 		zb0001Mask |= 0x400
 	}
-	if (*z).SignedTxnWithAD.ApplyData.ReceiverRewards.MsgIsZero() {
+	if (*z).SignedTxnWithAD.ApplyData.CloseRewards.MsgIsZero() {
 		zb0001Len--
 		zb0001Mask |= 0x800
 	}
-	if (*z).SignedTxnWithAD.ApplyData.SenderRewards.MsgIsZero() {
+	if (*z).SignedTxnWithAD.ApplyData.ReceiverRewards.MsgIsZero() {
 		zb0001Len--
 		zb0001Mask |= 0x1000
 	}
-	if (*z).SignedTxnWithAD.SignedTxn.AuthAddr.MsgIsZero() {
+	if (*z).SignedTxnWithAD.ApplyData.SenderRewards.MsgIsZero() {
 		zb0001Len--
 		zb0001Mask |= 0x2000
 	}
-	if (*z).SignedTxnWithAD.SignedTxn.Sig.MsgIsZero() {
+	if (*z).SignedTxnWithAD.SignedTxn.AuthAddr.MsgIsZero() {
 		zb0001Len--
 		zb0001Mask |= 0x4000
 	}
-	if (*z).SignedTxnWithAD.SignedTxn.Txn.MsgIsZero() {
+	if (*z).SignedTxnWithAD.SignedTxn.Sig.MsgIsZero() {
 		zb0001Len--
 		zb0001Mask |= 0x8000
 	}
+	if (*z).SignedTxnWithAD.SignedTxn.Txn.MsgIsZero() {
+		zb0001Len--
+		zb0001Mask |= 0x10000
+	}
 	// variable map header, size zb0001Len
 	o = append(o, 0x80|uint8(zb0001Len))
 	if zb0001Len != 0 {
@@ -3136,6 +3219,22 @@ func (z *SignedTxnInBlock) MarshalMsg(b []byte) (o []byte, err error) {
 			o = msgp.AppendBool(o, (*z).HasGenesisID)
 		}
 		if (zb0001Mask & 0x100) == 0 { // if not empty
+			// string "itx"
+			o = append(o, 0xa3, 0x69, 0x74, 0x78)
+			if (*z).SignedTxnWithAD.ApplyData.InnerTxns == nil {
+				o = msgp.AppendNil(o)
+			} else {
+				o = msgp.AppendArrayHeader(o, uint32(len((*z).SignedTxnWithAD.ApplyData.InnerTxns)))
+			}
+			for zb0003 := range (*z).SignedTxnWithAD.ApplyData.InnerTxns {
+				o, err = (*z).SignedTxnWithAD.ApplyData.InnerTxns[zb0003].MarshalMsg(o)
+				if err != nil {
+					err = msgp.WrapError(err, "InnerTxns", zb0003)
+					return
+				}
+			}
+		}
+		if (zb0001Mask & 0x200) == 0 { // if not empty
 			// string "lsig"
 			o = append(o, 0xa4, 0x6c, 0x73, 0x69, 0x67)
 			o, err = (*z).SignedTxnWithAD.SignedTxn.Lsig.MarshalMsg(o)
@@ -3144,7 +3243,7 @@ func (z *SignedTxnInBlock) MarshalMsg(b []byte) (o []byte, err error) {
 				return
 			}
 		}
-		if (zb0001Mask & 0x200) == 0 { // if not empty
+		if (zb0001Mask & 0x400) == 0 { // if not empty
 			// string "msig"
 			o = append(o, 0xa4, 0x6d, 0x73, 0x69, 0x67)
 			o, err = (*z).SignedTxnWithAD.SignedTxn.Msig.MarshalMsg(o)
@@ -3153,7 +3252,7 @@ func (z *SignedTxnInBlock) MarshalMsg(b []byte) (o []byte, err error) {
 				return
 			}
 		}
-		if (zb0001Mask & 0x400) == 0 { // if not empty
+		if (zb0001Mask & 0x800) == 0 { // if not empty
 			// string "rc"
 			o = append(o, 0xa2, 0x72, 0x63)
 			o, err = (*z).SignedTxnWithAD.ApplyData.CloseRewards.MarshalMsg(o)
@@ -3162,7 +3261,7 @@ func (z *SignedTxnInBlock) MarshalMsg(b []byte) (o []byte, err error) {
 				return
 			}
 		}
-		if (zb0001Mask & 0x800) == 0 { // if not empty
+		if (zb0001Mask & 0x1000) == 0 { // if not empty
 			// string "rr"
 			o = append(o, 0xa2, 0x72, 0x72)
 			o, err = (*z).SignedTxnWithAD.ApplyData.ReceiverRewards.MarshalMsg(o)
@@ -3171,7 +3270,7 @@ func (z *SignedTxnInBlock) MarshalMsg(b []byte) (o []byte, err error) {
 				return
 			}
 		}
-		if (zb0001Mask & 0x1000) == 0 { // if not empty
+		if (zb0001Mask & 0x2000) == 0 { // if not empty
 			// string "rs"
 			o = append(o, 0xa2, 0x72, 0x73)
 			o, err = (*z).SignedTxnWithAD.ApplyData.SenderRewards.MarshalMsg(o)
@@ -3180,7 +3279,7 @@ func (z *SignedTxnInBlock) MarshalMsg(b []byte) (o []byte, err error) {
 				return
 			}
 		}
-		if (zb0001Mask & 0x2000) == 0 { // if not empty
+		if (zb0001Mask & 0x4000) == 0 { // if not empty
 			// string "sgnr"
 			o = append(o, 0xa4, 0x73, 0x67, 0x6e, 0x72)
 			o, err = (*z).SignedTxnWithAD.SignedTxn.AuthAddr.MarshalMsg(o)
@@ -3189,7 +3288,7 @@ func (z *SignedTxnInBlock) MarshalMsg(b []byte) (o []byte, err error) {
 				return
 			}
 		}
-		if (zb0001Mask & 0x4000) == 0 { // if not empty
+		if (zb0001Mask & 0x8000) == 0 { // if not empty
 			// string "sig"
 			o = append(o, 0xa3, 0x73, 0x69, 0x67)
 			o, err = (*z).SignedTxnWithAD.SignedTxn.Sig.MarshalMsg(o)
@@ -3198,7 +3297,7 @@ func (z *SignedTxnInBlock) MarshalMsg(b []byte) (o []byte, err error) {
 				return
 			}
 		}
-		if (zb0001Mask & 0x8000) == 0 { // if not empty
+		if (zb0001Mask & 0x10000) == 0 { // if not empty
 			// string "txn"
 			o = append(o, 0xa3, 0x74, 0x78, 0x6e)
 			o, err = (*z).SignedTxnWithAD.SignedTxn.Txn.MarshalMsg(o)
@@ -3309,6 +3408,35 @@ func (z *SignedTxnInBlock) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				return
 			}
 		}
+		if zb0001 > 0 {
+			zb0001--
+			var zb0004 int
+			var zb0005 bool
+			zb0004, zb0005, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "struct-from-array", "InnerTxns")
+				return
+			}
+			if zb0004 > encodedMaxInnerTransactions {
+				err = msgp.ErrOverflow(uint64(zb0004), uint64(encodedMaxInnerTransactions))
+				err = msgp.WrapError(err, "struct-from-array", "InnerTxns")
+				return
+			}
+			if zb0005 {
+				(*z).SignedTxnWithAD.ApplyData.InnerTxns = nil
+			} else if (*z).SignedTxnWithAD.ApplyData.InnerTxns != nil && cap((*z).SignedTxnWithAD.ApplyData.InnerTxns) >= zb0004 {
+				(*z).SignedTxnWithAD.ApplyData.InnerTxns = ((*z).SignedTxnWithAD.ApplyData.InnerTxns)[:zb0004]
+			} else {
+				(*z).SignedTxnWithAD.ApplyData.InnerTxns = make([]SignedTxnWithAD, zb0004)
+			}
+			for zb0003 := range (*z).SignedTxnWithAD.ApplyData.InnerTxns {
+				bts, err = (*z).SignedTxnWithAD.ApplyData.InnerTxns[zb0003].UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "struct-from-array", "InnerTxns", zb0003)
+					return
+				}
+			}
+		}
 		if zb0001 > 0 {
 			zb0001--
 			(*z).HasGenesisID, bts, err = msgp.ReadBoolBytes(bts)
@@ -3408,6 +3536,33 @@ func (z *SignedTxnInBlock) UnmarshalMsg(bts []byte) (o []byte, err error) {
 					err = msgp.WrapError(err, "EvalDelta")
 					return
 				}
+			case "itx":
+				var zb0006 int
+				var zb0007 bool
+				zb0006, zb0007, bts, err = msgp.ReadArrayHeaderBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "InnerTxns")
+					return
+				}
+				if zb0006 > encodedMaxInnerTransactions {
+					err = msgp.ErrOverflow(uint64(zb0006), uint64(encodedMaxInnerTransactions))
+					err = msgp.WrapError(err, "InnerTxns")
+					return
+				}
+				if zb0007 {
+					(*z).SignedTxnWithAD.ApplyData.InnerTxns = nil
+				} else if (*z).SignedTxnWithAD.ApplyData.InnerTxns != nil && cap((*z).SignedTxnWithAD.ApplyData.InnerTxns) >= zb0006 {
+					(*z).SignedTxnWithAD.ApplyData.InnerTxns = ((*z).SignedTxnWithAD.ApplyData.InnerTxns)[:zb0006]
+				} else {
+					(*z).SignedTxnWithAD.ApplyData.InnerTxns = make([]SignedTxnWithAD, zb0006)
+				}
+				for zb0003 := range (*z).SignedTxnWithAD.ApplyData.InnerTxns {
+					bts, err = (*z).SignedTxnWithAD.ApplyData.InnerTxns[zb0003].UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "InnerTxns", zb0003)
+						return
+					}
+				}
 			case "hgi":
 				(*z).HasGenesisID, bts, err = msgp.ReadBoolBytes(bts)
 				if err != nil {
@@ -3440,21 +3595,25 @@ func (_ *SignedTxnInBlock) CanUnmarshalMsg(z interface{}) bool {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *SignedTxnInBlock) Msgsize() (s int) {
-	s = 1 + 4 + (*z).SignedTxnWithAD.SignedTxn.Sig.Msgsize() + 5 + (*z).SignedTxnWithAD.SignedTxn.Msig.Msgsize() + 5 + (*z).SignedTxnWithAD.SignedTxn.Lsig.Msgsize() + 4 + (*z).SignedTxnWithAD.SignedTxn.Txn.Msgsize() + 5 + (*z).SignedTxnWithAD.SignedTxn.AuthAddr.Msgsize() + 3 + (*z).SignedTxnWithAD.ApplyData.ClosingAmount.Msgsize() + 3 + (*z).SignedTxnWithAD.ApplyData.SenderRewards.Msgsize() + 3 + (*z).SignedTxnWithAD.ApplyData.ReceiverRewards.Msgsize() + 3 + (*z).SignedTxnWithAD.ApplyData.CloseRewards.Msgsize() + 3 + (*z).SignedTxnWithAD.ApplyData.EvalDelta.Msgsize() + 4 + msgp.BoolSize + 4 + msgp.BoolSize
+	s = 1 + 4 + (*z).SignedTxnWithAD.SignedTxn.Sig.Msgsize() + 5 + (*z).SignedTxnWithAD.SignedTxn.Msig.Msgsize() + 5 + (*z).SignedTxnWithAD.SignedTxn.Lsig.Msgsize() + 4 + (*z).SignedTxnWithAD.SignedTxn.Txn.Msgsize() + 5 + (*z).SignedTxnWithAD.SignedTxn.AuthAddr.Msgsize() + 3 + (*z).SignedTxnWithAD.ApplyData.ClosingAmount.Msgsize() + 3 + (*z).SignedTxnWithAD.ApplyData.SenderRewards.Msgsize() + 3 + (*z).SignedTxnWithAD.ApplyData.ReceiverRewards.Msgsize() + 3 + (*z).SignedTxnWithAD.ApplyData.CloseRewards.Msgsize() + 3 + (*z).SignedTxnWithAD.ApplyData.EvalDelta.Msgsize() + 4 + msgp.ArrayHeaderSize
+	for zb0003 := range (*z).SignedTxnWithAD.ApplyData.InnerTxns {
+		s += (*z).SignedTxnWithAD.ApplyData.InnerTxns[zb0003].Msgsize()
+	}
+	s += 4 + msgp.BoolSize + 4 + msgp.BoolSize
 	return
 }
 
 // MsgIsZero returns whether this is a zero value
 func (z *SignedTxnInBlock) MsgIsZero() bool {
-	return ((*z).SignedTxnWithAD.SignedTxn.Sig.MsgIsZero()) && ((*z).SignedTxnWithAD.SignedTxn.Msig.MsgIsZero()) && ((*z).SignedTxnWithAD.SignedTxn.Lsig.MsgIsZero()) && ((*z).SignedTxnWithAD.SignedTxn.Txn.MsgIsZero()) && ((*z).SignedTxnWithAD.SignedTxn.AuthAddr.MsgIsZero()) && ((*z).SignedTxnWithAD.ApplyData.ClosingAmount.MsgIsZero()) && ((*z).SignedTxnWithAD.ApplyData.SenderRewards.MsgIsZero()) && ((*z).SignedTxnWithAD.ApplyData.ReceiverRewards.MsgIsZero()) && ((*z).SignedTxnWithAD.ApplyData.CloseRewards.MsgIsZero()) && ((*z).SignedTxnWithAD.ApplyData.EvalDelta.MsgIsZero()) && ((*z).HasGenesisID == false) && ((*z).HasGenesisHash == false)
+	return ((*z).SignedTxnWithAD.SignedTxn.Sig.MsgIsZero()) && ((*z).SignedTxnWithAD.SignedTxn.Msig.MsgIsZero()) && ((*z).SignedTxnWithAD.SignedTxn.Lsig.MsgIsZero()) && ((*z).SignedTxnWithAD.SignedTxn.Txn.MsgIsZero()) && ((*z).SignedTxnWithAD.SignedTxn.AuthAddr.MsgIsZero()) && ((*z).SignedTxnWithAD.ApplyData.ClosingAmount.MsgIsZero()) && ((*z).SignedTxnWithAD.ApplyData.SenderRewards.MsgIsZero()) && ((*z).SignedTxnWithAD.ApplyData.ReceiverRewards.MsgIsZero()) && ((*z).SignedTxnWithAD.ApplyData.CloseRewards.MsgIsZero()) && ((*z).SignedTxnWithAD.ApplyData.EvalDelta.MsgIsZero()) && (len((*z).SignedTxnWithAD.ApplyData.InnerTxns) == 0) && ((*z).HasGenesisID == false) && ((*z).HasGenesisHash == false)
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *SignedTxnWithAD) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
 	// omitempty: check for empty values
-	zb0001Len := uint32(10)
-	var zb0001Mask uint16 /* 13 bits */
+	zb0001Len := uint32(11)
+	var zb0001Mask uint16 /* 14 bits */
 	if (*z).ApplyData.ClosingAmount.MsgIsZero() {
 		zb0001Len--
 		zb0001Mask |= 0x8
@@ -3463,38 +3622,42 @@ func (z *SignedTxnWithAD) MarshalMsg(b []byte) (o []byte, err error) {
 		zb0001Len--
 		zb0001Mask |= 0x10
 	}
-	if (*z).SignedTxn.Lsig.MsgIsZero() {
+	if len((*z).ApplyData.InnerTxns) == 0 {
 		zb0001Len--
 		zb0001Mask |= 0x20
 	}
-	if (*z).SignedTxn.Msig.MsgIsZero() {
+	if (*z).SignedTxn.Lsig.MsgIsZero() {
 		zb0001Len--
 		zb0001Mask |= 0x40
 	}
-	if (*z).ApplyData.CloseRewards.MsgIsZero() {
+	if (*z).SignedTxn.Msig.MsgIsZero() {
 		zb0001Len--
 		zb0001Mask |= 0x80
 	}
-	if (*z).ApplyData.ReceiverRewards.MsgIsZero() {
+	if (*z).ApplyData.CloseRewards.MsgIsZero() {
 		zb0001Len--
 		zb0001Mask |= 0x100
 	}
-	if (*z).ApplyData.SenderRewards.MsgIsZero() {
+	if (*z).ApplyData.ReceiverRewards.MsgIsZero() {
 		zb0001Len--
 		zb0001Mask |= 0x200
 	}
-	if (*z).SignedTxn.AuthAddr.MsgIsZero() {
+	if (*z).ApplyData.SenderRewards.MsgIsZero() {
 		zb0001Len--
 		zb0001Mask |= 0x400
 	}
-	if (*z).SignedTxn.Sig.MsgIsZero() {
+	if (*z).SignedTxn.AuthAddr.MsgIsZero() {
 		zb0001Len--
 		zb0001Mask |= 0x800
 	}
-	if (*z).SignedTxn.Txn.MsgIsZero() {
+	if (*z).SignedTxn.Sig.MsgIsZero() {
 		zb0001Len--
 		zb0001Mask |= 0x1000
 	}
+	if (*z).SignedTxn.Txn.MsgIsZero() {
+		zb0001Len--
+		zb0001Mask |= 0x2000
+	}
 	// variable map header, size zb0001Len
 	o = append(o, 0x80|uint8(zb0001Len))
 	if zb0001Len != 0 {
@@ -3517,6 +3680,22 @@ func (z *SignedTxnWithAD) MarshalMsg(b []byte) (o []byte, err error) {
 			}
 		}
 		if (zb0001Mask & 0x20) == 0 { // if not empty
+			// string "itx"
+			o = append(o, 0xa3, 0x69, 0x74, 0x78)
+			if (*z).ApplyData.InnerTxns == nil {
+				o = msgp.AppendNil(o)
+			} else {
+				o = msgp.AppendArrayHeader(o, uint32(len((*z).ApplyData.InnerTxns)))
+			}
+			for zb0003 := range (*z).ApplyData.InnerTxns {
+				o, err = (*z).ApplyData.InnerTxns[zb0003].MarshalMsg(o)
+				if err != nil {
+					err = msgp.WrapError(err, "InnerTxns", zb0003)
+					return
+				}
+			}
+		}
+		if (zb0001Mask & 0x40) == 0 { // if not empty
 			// string "lsig"
 			o = append(o, 0xa4, 0x6c, 0x73, 0x69, 0x67)
 			o, err = (*z).SignedTxn.Lsig.MarshalMsg(o)
@@ -3525,7 +3704,7 @@ func (z *SignedTxnWithAD) MarshalMsg(b []byte) (o []byte, err error) {
 				return
 			}
 		}
-		if (zb0001Mask & 0x40) == 0 { // if not empty
+		if (zb0001Mask & 0x80) == 0 { // if not empty
 			// string "msig"
 			o = append(o, 0xa4, 0x6d, 0x73, 0x69, 0x67)
 			o, err = (*z).SignedTxn.Msig.MarshalMsg(o)
@@ -3534,7 +3713,7 @@ func (z *SignedTxnWithAD) MarshalMsg(b []byte) (o []byte, err error) {
 				return
 			}
 		}
-		if (zb0001Mask & 0x80) == 0 { // if not empty
+		if (zb0001Mask & 0x100) == 0 { // if not empty
 			// string "rc"
 			o = append(o, 0xa2, 0x72, 0x63)
 			o, err = (*z).ApplyData.CloseRewards.MarshalMsg(o)
@@ -3543,7 +3722,7 @@ func (z *SignedTxnWithAD) MarshalMsg(b []byte) (o []byte, err error) {
 				return
 			}
 		}
-		if (zb0001Mask & 0x100) == 0 { // if not empty
+		if (zb0001Mask & 0x200) == 0 { // if not empty
 			// string "rr"
 			o = append(o, 0xa2, 0x72, 0x72)
 			o, err = (*z).ApplyData.ReceiverRewards.MarshalMsg(o)
@@ -3552,7 +3731,7 @@ func (z *SignedTxnWithAD) MarshalMsg(b []byte) (o []byte, err error) {
 				return
 			}
 		}
-		if (zb0001Mask & 0x200) == 0 { // if not empty
+		if (zb0001Mask & 0x400) == 0 { // if not empty
 			// string "rs"
 			o = append(o, 0xa2, 0x72, 0x73)
 			o, err = (*z).ApplyData.SenderRewards.MarshalMsg(o)
@@ -3561,7 +3740,7 @@ func (z *SignedTxnWithAD) MarshalMsg(b []byte) (o []byte, err error) {
 				return
 			}
 		}
-		if (zb0001Mask & 0x400) == 0 { // if not empty
+		if (zb0001Mask & 0x800) == 0 { // if not empty
 			// string "sgnr"
 			o = append(o, 0xa4, 0x73, 0x67, 0x6e, 0x72)
 			o, err = (*z).SignedTxn.AuthAddr.MarshalMsg(o)
@@ -3570,7 +3749,7 @@ func (z *SignedTxnWithAD) MarshalMsg(b []byte) (o []byte, err error) {
 				return
 			}
 		}
-		if (zb0001Mask & 0x800) == 0 { // if not empty
+		if (zb0001Mask & 0x1000) == 0 { // if not empty
 			// string "sig"
 			o = append(o, 0xa3, 0x73, 0x69, 0x67)
 			o, err = (*z).SignedTxn.Sig.MarshalMsg(o)
@@ -3579,7 +3758,7 @@ func (z *SignedTxnWithAD) MarshalMsg(b []byte) (o []byte, err error) {
 				return
 			}
 		}
-		if (zb0001Mask & 0x1000) == 0 { // if not empty
+		if (zb0001Mask & 0x2000) == 0 { // if not empty
 			// string "txn"
 			o = append(o, 0xa3, 0x74, 0x78, 0x6e)
 			o, err = (*z).SignedTxn.Txn.MarshalMsg(o)
@@ -3690,6 +3869,35 @@ func (z *SignedTxnWithAD) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				return
 			}
 		}
+		if zb0001 > 0 {
+			zb0001--
+			var zb0004 int
+			var zb0005 bool
+			zb0004, zb0005, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "struct-from-array", "InnerTxns")
+				return
+			}
+			if zb0004 > encodedMaxInnerTransactions {
+				err = msgp.ErrOverflow(uint64(zb0004), uint64(encodedMaxInnerTransactions))
+				err = msgp.WrapError(err, "struct-from-array", "InnerTxns")
+				return
+			}
+			if zb0005 {
+				(*z).ApplyData.InnerTxns = nil
+			} else if (*z).ApplyData.InnerTxns != nil && cap((*z).ApplyData.InnerTxns) >= zb0004 {
+				(*z).ApplyData.InnerTxns = ((*z).ApplyData.InnerTxns)[:zb0004]
+			} else {
+				(*z).ApplyData.InnerTxns = make([]SignedTxnWithAD, zb0004)
+			}
+			for zb0003 := range (*z).ApplyData.InnerTxns {
+				bts, err = (*z).ApplyData.InnerTxns[zb0003].UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "struct-from-array", "InnerTxns", zb0003)
+					return
+				}
+			}
+		}
 		if zb0001 > 0 {
 			err = msgp.ErrTooManyArrayFields(zb0001)
 			if err != nil {
@@ -3773,6 +3981,33 @@ func (z *SignedTxnWithAD) UnmarshalMsg(bts []byte) (o []byte, err error) {
 					err = msgp.WrapError(err, "EvalDelta")
 					return
 				}
+			case "itx":
+				var zb0006 int
+				var zb0007 bool
+				zb0006, zb0007, bts, err = msgp.ReadArrayHeaderBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "InnerTxns")
+					return
+				}
+				if zb0006 > encodedMaxInnerTransactions {
+					err = msgp.ErrOverflow(uint64(zb0006), uint64(encodedMaxInnerTransactions))
+					err = msgp.WrapError(err, "InnerTxns")
+					return
+				}
+				if zb0007 {
+					(*z).ApplyData.InnerTxns = nil
+				} else if (*z).ApplyData.InnerTxns != nil && cap((*z).ApplyData.InnerTxns) >= zb0006 {
+					(*z).ApplyData.InnerTxns = ((*z).ApplyData.InnerTxns)[:zb0006]
+				} else {
+					(*z).ApplyData.InnerTxns = make([]SignedTxnWithAD, zb0006)
+				}
+				for zb0003 := range (*z).ApplyData.InnerTxns {
+					bts, err = (*z).ApplyData.InnerTxns[zb0003].UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "InnerTxns", zb0003)
+						return
+					}
+				}
 			default:
 				err = msgp.ErrNoField(string(field))
 				if err != nil {
@@ -3793,13 +4028,16 @@ func (_ *SignedTxnWithAD) CanUnmarshalMsg(z interface{}) bool {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *SignedTxnWithAD) Msgsize() (s int) {
-	s = 1 + 4 + (*z).SignedTxn.Sig.Msgsize() + 5 + (*z).SignedTxn.Msig.Msgsize() + 5 + (*z).SignedTxn.Lsig.Msgsize() + 4 + (*z).SignedTxn.Txn.Msgsize() + 5 + (*z).SignedTxn.AuthAddr.Msgsize() + 3 + (*z).ApplyData.ClosingAmount.Msgsize() + 3 + (*z).ApplyData.SenderRewards.Msgsize() + 3 + (*z).ApplyData.ReceiverRewards.Msgsize() + 3 + (*z).ApplyData.CloseRewards.Msgsize() + 3 + (*z).ApplyData.EvalDelta.Msgsize()
+	s = 1 + 4 + (*z).SignedTxn.Sig.Msgsize() + 5 + (*z).SignedTxn.Msig.Msgsize() + 5 + (*z).SignedTxn.Lsig.Msgsize() + 4 + (*z).SignedTxn.Txn.Msgsize() + 5 + (*z).SignedTxn.AuthAddr.Msgsize() + 3 + (*z).ApplyData.ClosingAmount.Msgsize() + 3 + (*z).ApplyData.SenderRewards.Msgsize() + 3 + (*z).ApplyData.ReceiverRewards.Msgsize() + 3 + (*z).ApplyData.CloseRewards.Msgsize() + 3 + (*z).ApplyData.EvalDelta.Msgsize() + 4 + msgp.ArrayHeaderSize
+	for zb0003 := range (*z).ApplyData.InnerTxns {
+		s += (*z).ApplyData.InnerTxns[zb0003].Msgsize()
+	}
 	return
 }
 
 // MsgIsZero returns whether this is a zero value
 func (z *SignedTxnWithAD) MsgIsZero() bool {
-	return ((*z).SignedTxn.Sig.MsgIsZero()) && ((*z).SignedTxn.Msig.MsgIsZero()) && ((*z).SignedTxn.Lsig.MsgIsZero()) && ((*z).SignedTxn.Txn.MsgIsZero()) && ((*z).SignedTxn.AuthAddr.MsgIsZero()) && ((*z).ApplyData.ClosingAmount.MsgIsZero()) && ((*z).ApplyData.SenderRewards.MsgIsZero()) && ((*z).ApplyData.ReceiverRewards.MsgIsZero()) && ((*z).ApplyData.CloseRewards.MsgIsZero()) && ((*z).ApplyData.EvalDelta.MsgIsZero())
+	return ((*z).SignedTxn.Sig.MsgIsZero()) && ((*z).SignedTxn.Msig.MsgIsZero()) && ((*z).SignedTxn.Lsig.MsgIsZero()) && ((*z).SignedTxn.Txn.MsgIsZero()) && ((*z).SignedTxn.AuthAddr.MsgIsZero()) && ((*z).ApplyData.ClosingAmount.MsgIsZero()) && ((*z).ApplyData.SenderRewards.MsgIsZero()) && ((*z).ApplyData.ReceiverRewards.MsgIsZero()) && ((*z).ApplyData.CloseRewards.MsgIsZero()) && ((*z).ApplyData.EvalDelta.MsgIsZero()) && (len((*z).ApplyData.InnerTxns) == 0)
 }
 
 // MarshalMsg implements msgp.Marshaler
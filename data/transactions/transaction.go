@@ -109,10 +109,15 @@ type ApplyData struct {
 	ReceiverRewards basics.MicroAlgos `codec:"rr"`
 	CloseRewards    basics.MicroAlgos `codec:"rc"`
 	EvalDelta       basics.EvalDelta  `codec:"dt"`
+
+	// InnerTxns are the transactions issued by an application call from
+	// the application's account, each with its own ApplyData.
+	InnerTxns []SignedTxnWithAD `codec:"itx,allocbound=encodedMaxInnerTransactions"`
 }
 
 // Equal returns true if two ApplyDatas are equal, ignoring nilness equality on
-// EvalDelta's internal deltas (see EvalDelta.Equal for more information)
+// EvalDelta's internal deltas (see EvalDelta.Equal for more information).
+// Inner transactions are compared by their transaction IDs.
 func (ad ApplyData) Equal(o ApplyData) bool {
 	if ad.ClosingAmount != o.ClosingAmount {
 		return false
@@ -129,6 +134,17 @@ func (ad ApplyData) Equal(o ApplyData) bool {
 	if !ad.EvalDelta.Equal(o.EvalDelta) {
 		return false
 	}
+	if len(ad.InnerTxns) != len(o.InnerTxns) {
+		return false
+	}
+	for i := range ad.InnerTxns {
+		if ad.InnerTxns[i].ID() != o.InnerTxns[i].ID() {
+			return false
+		}
+		if !ad.InnerTxns[i].ApplyData.Equal(o.InnerTxns[i].ApplyData) {
+			return false
+		}
+	}
 	return true
 }
 
@@ -36,6 +36,7 @@ import (
 type accountsDbQueries struct {
 	listCreatablesStmt           *sql.Stmt
 	lookupStmt                   *sql.Stmt
+	lookupHistoryStmt            *sql.Stmt
 	lookupCreatorStmt            *sql.Stmt
 	deleteStoredCatchpoint       *sql.Stmt
 	insertStoredCatchpoint       *sql.Stmt
@@ -45,6 +46,7 @@ type accountsDbQueries struct {
 	insertCatchpointStateUint64  *sql.Stmt
 	selectCatchpointStateString  *sql.Stmt
 	insertCatchpointStateString  *sql.Stmt
+	lookupKvStmt                 *sql.Stmt
 }
 
 var accountsSchema = []string{
@@ -79,8 +81,24 @@ var accountsSchema = []string{
 		id string primary key,
 		intval integer,
 		strval text)`,
+	accountsHistorySchema,
+	kvStoreSchema,
 }
 
+// accountsHistorySchema defines the historical accounts table. Every row in the table holds the account data
+// the address had right before it was modified by round rnd.
+var accountsHistorySchema = `CREATE TABLE IF NOT EXISTS accounthistory (
+		address blob,
+		rnd integer,
+		data blob,
+		PRIMARY KEY (address, rnd))`
+
+// kvStoreSchema defines the key/value store table, which holds the application boxes apart from the
+// account records.
+var kvStoreSchema = `CREATE TABLE IF NOT EXISTS kvstore (
+		key blob primary key,
+		value blob)`
+
 // TODO: Post applications, rename assetcreators -> creatables and rename
 // 'asset' column -> 'creatable'
 var creatablesMigration = []string{
@@ -107,12 +125,14 @@ var accountsResetExprs = []string{
 	`DROP TABLE IF EXISTS storedcatchpoints`,
 	`DROP TABLE IF EXISTS catchpointstate`,
 	`DROP TABLE IF EXISTS accounthashes`,
+	`DROP TABLE IF EXISTS accounthistory`,
+	`DROP TABLE IF EXISTS kvstore`,
 }
 
 // accountDBVersion is the database version that this binary would know how to support and how to upgrade to.
 // details about the content of each of the versions can be found in the upgrade functions upgradeDatabaseSchemaXXXX
 // and their descriptions.
-var accountDBVersion = int32(4)
+var accountDBVersion = int32(6)
 
 type accountDelta struct {
 	old basics.AccountData
@@ -140,16 +160,66 @@ const (
 	catchpointStateCatchupBalancesRound = catchpointState("catchpointCatchupBalancesRound")
 )
 
+// catchpointStagingSection is a section of the catchpoint file whose content was committed to the catchpoint staging tables.
+type catchpointStagingSection struct {
+	name     string
+	accounts uint64
+	kvs      uint64
+	bytes    uint64
+	// header is the content of the catchpoint file header section, which is kept so that the header doesn't need to be
+	// downloaded again when the catchup is resumed.
+	header []byte
+}
+
+func writeCatchpointStagingSections(ctx context.Context, tx *sql.Tx, sections []catchpointStagingSection) error {
+	insertStmt, err := tx.PrepareContext(ctx, "INSERT INTO catchpointsections(name, accounts, kvs, bytes, header) VALUES(?, ?, ?, ?, ?)")
+	if err != nil {
+		return err
+	}
+	defer insertStmt.Close()
+
+	for _, section := range sections {
+		_, err = insertStmt.ExecContext(ctx, section.name, section.accounts, section.kvs, section.bytes, section.header)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readCatchpointStagingSections(ctx context.Context, tx *sql.Tx) (sections []catchpointStagingSection, err error) {
+	rows, err := tx.QueryContext(ctx, "SELECT name, accounts, kvs, bytes, header FROM catchpointsections ORDER BY rowid")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var section catchpointStagingSection
+		err = rows.Scan(&section.name, &section.accounts, &section.kvs, &section.bytes, &section.header)
+		if err != nil {
+			return nil, err
+		}
+		sections = append(sections, section)
+	}
+	return sections, rows.Err()
+}
+
+// writeCatchpointStagingCreatable writes a creatable into the catchpoint staging table. The existing entry is replaced, if
+// any, since the sections processed after the last commit of the staging trie are processed again when an interrupted
+// catchup is resumed; duplicate entries of the catchpoint file are still detected by the staging trie.
 func writeCatchpointStagingCreatable(ctx context.Context, tx *sql.Tx, addr basics.Address, cidx basics.CreatableIndex, ctype basics.CreatableType) error {
-	_, err := tx.ExecContext(ctx, "INSERT INTO catchpointassetcreators(asset, creator, ctype) VALUES(?, ?, ?)", cidx, addr[:], ctype)
+	_, err := tx.ExecContext(ctx, "INSERT OR REPLACE INTO catchpointassetcreators(asset, creator, ctype) VALUES(?, ?, ?)", cidx, addr[:], ctype)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// writeCatchpointStagingBalances writes the given balances into the catchpoint staging table, replacing the existing
+// entries for the same reason writeCatchpointStagingCreatable does.
 func writeCatchpointStagingBalances(ctx context.Context, tx *sql.Tx, bals []encodedBalanceRecord, proto config.ConsensusParams) error {
-	insertStmt, err := tx.PrepareContext(ctx, "INSERT INTO catchpointbalances(address, normalizedonlinebalance, data) VALUES(?, ?, ?)")
+	insertStmt, err := tx.PrepareContext(ctx, "INSERT OR REPLACE INTO catchpointbalances(address, normalizedonlinebalance, data) VALUES(?, ?, ?)")
 	if err != nil {
 		return err
 	}
@@ -179,11 +249,29 @@ func writeCatchpointStagingBalances(ctx context.Context, tx *sql.Tx, bals []enco
 	return nil
 }
 
+func writeCatchpointStagingKVs(ctx context.Context, tx *sql.Tx, kvs []encodedKVRecord) error {
+	insertStmt, err := tx.PrepareContext(ctx, "INSERT INTO catchpointkvstore(key, value) VALUES(?, ?)")
+	if err != nil {
+		return err
+	}
+	defer insertStmt.Close()
+
+	for _, kv := range kvs {
+		_, err = insertStmt.ExecContext(ctx, kv.Key, kv.Value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func resetCatchpointStagingBalances(ctx context.Context, tx *sql.Tx, newCatchup bool) (err error) {
 	s := []string{
 		"DROP TABLE IF EXISTS catchpointbalances",
 		"DROP TABLE IF EXISTS catchpointassetcreators",
 		"DROP TABLE IF EXISTS catchpointaccounthashes",
+		"DROP TABLE IF EXISTS catchpointkvstore",
+		"DROP TABLE IF EXISTS catchpointsections",
 		"DELETE FROM accounttotals where id='catchpointStaging'",
 	}
 
@@ -199,6 +287,8 @@ func resetCatchpointStagingBalances(ctx context.Context, tx *sql.Tx, newCatchup
 			"CREATE TABLE IF NOT EXISTS catchpointassetcreators (asset integer primary key, creator blob, ctype integer)",
 			"CREATE TABLE IF NOT EXISTS catchpointbalances (address blob primary key, data blob, normalizedonlinebalance integer)",
 			"CREATE TABLE IF NOT EXISTS catchpointaccounthashes (id integer primary key, data blob)",
+			"CREATE TABLE IF NOT EXISTS catchpointkvstore (key blob primary key, value blob)",
+			"CREATE TABLE IF NOT EXISTS catchpointsections (name text, accounts integer, kvs integer, bytes integer, header blob)",
 			createNormalizedOnlineBalanceIndex(idxname, "catchpointbalances"),
 		)
 	}
@@ -220,14 +310,17 @@ func applyCatchpointStagingBalances(ctx context.Context, tx *sql.Tx, balancesRou
 		"ALTER TABLE accountbase RENAME TO accountbase_old",
 		"ALTER TABLE assetcreators RENAME TO assetcreators_old",
 		"ALTER TABLE accounthashes RENAME TO accounthashes_old",
+		"ALTER TABLE kvstore RENAME TO kvstore_old",
 
 		"ALTER TABLE catchpointbalances RENAME TO accountbase",
 		"ALTER TABLE catchpointassetcreators RENAME TO assetcreators",
 		"ALTER TABLE catchpointaccounthashes RENAME TO accounthashes",
+		"ALTER TABLE catchpointkvstore RENAME TO kvstore",
 
 		"DROP TABLE IF EXISTS accountbase_old",
 		"DROP TABLE IF EXISTS assetcreators_old",
 		"DROP TABLE IF EXISTS accounthashes_old",
+		"DROP TABLE IF EXISTS kvstore_old",
 	}
 
 	for _, stmt := range stmts {
@@ -245,7 +338,9 @@ func applyCatchpointStagingBalances(ctx context.Context, tx *sql.Tx, balancesRou
 	if err != nil {
 		return err
 	}
-	return
+
+	// the accounts history can't span across the catchpoint, since the rounds prior to it were never applied.
+	return resetAccountsHistory(tx)
 }
 
 func getCatchpoint(tx *sql.Tx, round basics.Round) (fileName string, catchpoint string, fileSize int64, err error) {
@@ -432,6 +527,15 @@ func accountsDbInit(r db.Queryable, w db.Queryable) (*accountsDbQueries, error)
 		return nil, err
 	}
 
+	// the historical state takes precedence over the current one. Both are read by a single statement, so that
+	// a concurrent commit can't modify the account between the two reads.
+	qs.lookupHistoryStmt, err = r.Prepare("SELECT data FROM (" +
+		"SELECT data, 0 AS current FROM (SELECT data FROM accounthistory WHERE address=? AND rnd>? ORDER BY rnd ASC LIMIT 1) " +
+		"UNION ALL SELECT data, 1 FROM accountbase WHERE address=?) ORDER BY current LIMIT 1")
+	if err != nil {
+		return nil, err
+	}
+
 	qs.lookupCreatorStmt, err = r.Prepare("SELECT creator FROM assetcreators WHERE asset = ? AND ctype = ?")
 	if err != nil {
 		return nil, err
@@ -476,6 +580,11 @@ func accountsDbInit(r db.Queryable, w db.Queryable) (*accountsDbQueries, error)
 	if err != nil {
 		return nil, err
 	}
+
+	qs.lookupKvStmt, err = r.Prepare("SELECT value FROM kvstore WHERE key=?")
+	if err != nil {
+		return nil, err
+	}
 	return qs, nil
 }
 
@@ -546,6 +655,47 @@ func (qs *accountsDbQueries) lookup(addr basics.Address) (data basics.AccountDat
 	return
 }
 
+// lookupKv looks up the value of the given key in the key/value store. ok is false when the key is not present.
+func (qs *accountsDbQueries) lookupKv(key string) (value []byte, ok bool, err error) {
+	err = db.Retry(func() error {
+		var buf []byte
+		err := qs.lookupKvStmt.QueryRow([]byte(key)).Scan(&buf)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		ok = true
+		// sqlite returns a NULL blob for a zero length value
+		value = append([]byte{}, buf...)
+		return nil
+	})
+	return
+}
+
+// lookupHistory looks up the account data of the given address as of round rnd. It comes from the historical
+// accounts table, or from the current accountbase entry when the account wasn't modified since rnd.
+func (qs *accountsDbQueries) lookupHistory(addr basics.Address, rnd basics.Round) (data basics.AccountData, err error) {
+	err = db.Retry(func() error {
+		var buf []byte
+		err := qs.lookupHistoryStmt.QueryRow(addr[:], rnd, addr[:]).Scan(&buf)
+		if err == nil {
+			return protocol.Decode(buf, &data)
+		}
+
+		if err == sql.ErrNoRows {
+			// Return the zero value of data
+			return nil
+		}
+
+		return err
+	})
+
+	return
+}
+
 func (qs *accountsDbQueries) storeCatchpoint(ctx context.Context, round basics.Round, fileName string, catchpoint string, fileSize int64) (err error) {
 	err = db.Retry(func() (err error) {
 		_, err = qs.deleteStoredCatchpoint.ExecContext(ctx, round)
@@ -653,6 +803,7 @@ func (qs *accountsDbQueries) close() {
 	preparedQueries := []**sql.Stmt{
 		&qs.listCreatablesStmt,
 		&qs.lookupStmt,
+		&qs.lookupHistoryStmt,
 		&qs.lookupCreatorStmt,
 		&qs.deleteStoredCatchpoint,
 		&qs.insertStoredCatchpoint,
@@ -662,6 +813,7 @@ func (qs *accountsDbQueries) close() {
 		&qs.insertCatchpointStateUint64,
 		&qs.selectCatchpointStateString,
 		&qs.insertCatchpointStateString,
+		&qs.lookupKvStmt,
 	}
 	for _, preparedQuery := range preparedQueries {
 		if (*preparedQuery) != nil {
@@ -779,8 +931,8 @@ func accountsPutTotals(tx *sql.Tx, totals AccountTotals, catchpointStaging bool)
 	return err
 }
 
-// accountsNewRound updates the accountbase and assetcreators by applying the provided deltas to the accounts / creatables.
-func accountsNewRound(tx *sql.Tx, updates map[basics.Address]accountDelta, creatables map[basics.CreatableIndex]modifiedCreatable, proto config.ConsensusParams) (err error) {
+// accountsNewRound updates the accountbase, assetcreators and kvstore by applying the provided deltas to the accounts / creatables / key/value entries.
+func accountsNewRound(tx *sql.Tx, updates map[basics.Address]accountDelta, creatables map[basics.CreatableIndex]modifiedCreatable, kvs map[string]kvValueDelta, proto config.ConsensusParams) (err error) {
 
 	var insertCreatableIdxStmt, deleteCreatableIdxStmt, deleteStmt, replaceStmt *sql.Stmt
 
@@ -834,9 +986,88 @@ func accountsNewRound(tx *sql.Tx, updates map[basics.Address]accountDelta, creat
 		}
 	}
 
+	if len(kvs) > 0 {
+		var replaceKvStmt, deleteKvStmt *sql.Stmt
+		replaceKvStmt, err = tx.Prepare("REPLACE INTO kvstore (key, value) VALUES (?, ?)")
+		if err != nil {
+			return
+		}
+		defer replaceKvStmt.Close()
+
+		deleteKvStmt, err = tx.Prepare("DELETE FROM kvstore WHERE key=?")
+		if err != nil {
+			return
+		}
+		defer deleteKvStmt.Close()
+
+		for key, kvdelta := range kvs {
+			if kvdelta.newExists {
+				_, err = replaceKvStmt.Exec([]byte(key), kvdelta.new)
+			} else {
+				_, err = deleteKvStmt.Exec([]byte(key))
+			}
+			if err != nil {
+				return
+			}
+		}
+	}
+
 	return
 }
 
+// accountsHistoryNewRound records the prior state of every account modified by round rnd in the historical accounts table.
+func accountsHistoryNewRound(tx *sql.Tx, rnd basics.Round, updates map[basics.Address]accountDelta) (err error) {
+	if len(updates) == 0 {
+		return
+	}
+
+	insertStmt, err := tx.Prepare("INSERT OR REPLACE INTO accounthistory (address, rnd, data) VALUES (?, ?, ?)")
+	if err != nil {
+		return
+	}
+	defer insertStmt.Close()
+
+	for addr, data := range updates {
+		_, err = insertStmt.Exec(addr[:], rnd, protocol.Encode(&data.old))
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// accountsHistoryInitialize prepares the historical accounts table for use, and returns the earliest round for which
+// account lookups could be served from it. When the history is enabled for the first time, it starts at the current
+// accounts round. When the history is disabled, the table is cleared so that re-enabling it later won't leave a gap.
+func accountsHistoryInitialize(tx *sql.Tx, enabled bool, rnd basics.Round) (historyRound basics.Round, err error) {
+	if !enabled {
+		err = resetAccountsHistory(tx)
+		return
+	}
+
+	err = tx.QueryRow("SELECT rnd FROM acctrounds WHERE id='historybase'").Scan(&historyRound)
+	if err == sql.ErrNoRows {
+		_, err = tx.Exec("INSERT INTO acctrounds(id, rnd) VALUES('historybase', ?)", rnd)
+		historyRound = rnd
+	}
+	return
+}
+
+// resetAccountsHistory removes all the entries from the historical accounts table.
+func resetAccountsHistory(tx *sql.Tx) (err error) {
+	stmts := []string{
+		"DELETE FROM accounthistory",
+		"DELETE FROM acctrounds WHERE id='historybase'",
+	}
+	for _, stmt := range stmts {
+		_, err = tx.Exec(stmt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // totalsNewRounds updates the accountsTotals by applying series of round changes
 func totalsNewRounds(tx *sql.Tx, updates []map[basics.Address]accountDelta, accountTotals []AccountTotals, protos []config.ConsensusParams) (err error) {
 	var ot basics.OverflowTracker
@@ -1123,3 +1354,51 @@ func (iterator *encodedAccountsBatchIter) Close() {
 		iterator.rows = nil
 	}
 }
+
+// encodedKVsBatchIter allows us to iterate over the entries of the kvstore table, in key order.
+type encodedKVsBatchIter struct {
+	rows *sql.Rows
+}
+
+// Next returns an array containing up to kvCount key/value entries, in the same way they appear in the database.
+func (iterator *encodedKVsBatchIter) Next(ctx context.Context, tx *sql.Tx, kvCount int) (kvs []encodedKVRecord, err error) {
+	if iterator.rows == nil {
+		iterator.rows, err = tx.QueryContext(ctx, "SELECT key, value FROM kvstore ORDER BY key")
+		if err != nil {
+			return
+		}
+	}
+
+	kvs = make([]encodedKVRecord, 0, kvCount)
+	for iterator.rows.Next() {
+		var key, value []byte
+		err = iterator.rows.Scan(&key, &value)
+		if err != nil {
+			iterator.Close()
+			return
+		}
+
+		kvs = append(kvs, encodedKVRecord{Key: key, Value: value})
+		if len(kvs) == kvCount {
+			// we're done with this iteration.
+			return
+		}
+	}
+
+	err = iterator.rows.Err()
+	if err != nil {
+		iterator.Close()
+		return
+	}
+	// we just finished reading the table.
+	iterator.Close()
+	return
+}
+
+// Close shuts down the encodedKVsBatchIter, releasing database resources.
+func (iterator *encodedKVsBatchIter) Close() {
+	if iterator.rows != nil {
+		iterator.rows.Close()
+		iterator.rows = nil
+	}
+}
@@ -527,7 +527,7 @@ func TestAccountDBRound(t *testing.T) {
 		accts = newaccts
 		ctbsWithDeletes := randomCreatableSampling(i, ctbsList, randomCtbs,
 			expectedDbImage, numElementsPerSegement)
-		err = accountsNewRound(tx, updates, ctbsWithDeletes, proto)
+		err = accountsNewRound(tx, updates, ctbsWithDeletes, nil, proto)
 		require.NoError(t, err)
 		err = totalsNewRounds(tx, []map[basics.Address]accountDelta{updates}, []AccountTotals{{}}, []config.ConsensusParams{proto})
 		require.NoError(t, err)
@@ -538,6 +538,143 @@ func TestAccountDBRound(t *testing.T) {
 	}
 }
 
+func TestAccountsKvStore(t *testing.T) {
+	proto := config.Consensus[protocol.ConsensusCurrentVersion]
+
+	dbs, _ := dbOpenTest(t, true)
+	setDbLogging(t, dbs)
+	defer dbs.close()
+
+	tx, err := dbs.wdb.Handle.Begin()
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	err = accountsInit(tx, randomAccounts(5, true), proto)
+	require.NoError(t, err)
+
+	qs, err := accountsDbInit(tx, tx)
+	require.NoError(t, err)
+	defer qs.close()
+
+	checkKv := func(key string, value string, exists bool) {
+		v, ok, err := qs.lookupKv(key)
+		require.NoError(t, err)
+		require.Equal(t, exists, ok)
+		if exists {
+			require.Equal(t, value, string(v))
+		}
+	}
+
+	kvs := map[string]kvValueDelta{
+		"a":     {new: []byte("first"), newExists: true},
+		"b":     {new: []byte("second"), newExists: true},
+		"empty": {new: []byte{}, newExists: true},
+	}
+	err = accountsNewRound(tx, nil, nil, kvs, proto)
+	require.NoError(t, err)
+	checkKv("a", "first", true)
+	checkKv("b", "second", true)
+	checkKv("empty", "", true)
+	checkKv("c", "", false)
+
+	kvs = map[string]kvValueDelta{
+		"a": {old: []byte("first"), oldExists: true, new: []byte("third"), newExists: true},
+		"b": {old: []byte("second"), oldExists: true},
+	}
+	err = accountsNewRound(tx, nil, nil, kvs, proto)
+	require.NoError(t, err)
+	checkKv("a", "third", true)
+	checkKv("b", "", false)
+
+	var iterator encodedKVsBatchIter
+	defer iterator.Close()
+	entries, err := iterator.Next(context.Background(), tx, 1)
+	require.NoError(t, err)
+	require.Equal(t, []encodedKVRecord{{Key: []byte("a"), Value: []byte("third")}}, entries)
+	entries, err = iterator.Next(context.Background(), tx, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, []byte("empty"), entries[0].Key)
+	require.Empty(t, entries[0].Value)
+}
+
+func TestAccountsHistory(t *testing.T) {
+	proto := config.Consensus[protocol.ConsensusCurrentVersion]
+
+	dbs, _ := dbOpenTest(t, true)
+	setDbLogging(t, dbs)
+	defer dbs.close()
+
+	accts := randomAccounts(20, true)
+	snapshots := []map[basics.Address]basics.AccountData{accts}
+	err := dbs.wdb.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
+		err = accountsInit(tx, accts, proto)
+		if err != nil {
+			return err
+		}
+		historyRound, err := accountsHistoryInitialize(tx, true, 0)
+		if err != nil {
+			return err
+		}
+		require.Equal(t, basics.Round(0), historyRound)
+
+		for i := 1; i < 10; i++ {
+			updates, newaccts, _ := randomDeltas(10, accts, 0)
+			accts = newaccts
+			snapshots = append(snapshots, accts)
+			err = accountsHistoryNewRound(tx, basics.Round(i), updates)
+			if err != nil {
+				return err
+			}
+			err = accountsNewRound(tx, updates, nil, nil, proto)
+			if err != nil {
+				return err
+			}
+			err = updateAccountsRound(tx, basics.Round(i), 0)
+			if err != nil {
+				return err
+			}
+		}
+
+		// initializing the history again should retain the original history round
+		historyRound, err = accountsHistoryInitialize(tx, true, 9)
+		require.Equal(t, basics.Round(0), historyRound)
+		return err
+	})
+	require.NoError(t, err)
+
+	qs, err := accountsDbInit(dbs.rdb.Handle, dbs.wdb.Handle)
+	require.NoError(t, err)
+	defer qs.close()
+
+	addrs := make(map[basics.Address]bool)
+	for _, snapshot := range snapshots {
+		for addr := range snapshot {
+			addrs[addr] = true
+		}
+	}
+	for rnd, snapshot := range snapshots {
+		for addr := range addrs {
+			data, err := qs.lookupHistory(addr, basics.Round(rnd))
+			require.NoError(t, err)
+			require.Equal(t, snapshot[addr], data, "round %d address %v", rnd, addr)
+		}
+	}
+
+	// disabling the history clears it
+	err = dbs.wdb.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
+		_, err = accountsHistoryInitialize(tx, false, 9)
+		if err != nil {
+			return err
+		}
+		var count int
+		err = tx.QueryRow("SELECT COUNT(*) FROM accounthistory").Scan(&count)
+		require.Zero(t, count)
+		return err
+	})
+	require.NoError(t, err)
+}
+
 // checkCreatables compares the expected database image to the actual databse content
 func checkCreatables(t *testing.T,
 	tx *sql.Tx, iteration int,
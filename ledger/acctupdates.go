@@ -20,6 +20,7 @@ import (
 	"container/heap"
 	"context"
 	"database/sql"
+	"encoding/binary"
 	"encoding/hex"
 	"fmt"
 	"io"
@@ -94,6 +95,17 @@ type modifiedCreatable struct {
 	ndeltas int
 }
 
+type modifiedKv struct {
+	// value and exists hold the most recent state of this key/value
+	// store entry
+	value  []byte
+	exists bool
+
+	// Keeps track of how many times this key appears in
+	// accountUpdates.kvDeltas
+	ndeltas int
+}
+
 type accountUpdates struct {
 	// constant variables ( initialized on initialize, and never changed afterward )
 
@@ -112,6 +124,9 @@ type accountUpdates struct {
 	// archivalLedger determines whether the associated ledger was configured as archival ledger or not.
 	archivalLedger bool
 
+	// accountHistory determines whether the prior state of the modified accounts is recorded in the historical accounts table.
+	accountHistory bool
+
 	// catchpointFileHistoryLength defines how many catchpoint files we want to store back.
 	// 0 means don't store any, -1 mean unlimited and positive number suggest the number of most recent catchpoint files.
 	catchpointFileHistoryLength int
@@ -131,6 +146,10 @@ type accountUpdates struct {
 	// cached to avoid SQL queries.
 	dbRound basics.Round
 
+	// historyRound is the earliest round for which the historical accounts table could be used to look up accounts.
+	// It's meaningful only when accountHistory is set.
+	historyRound basics.Round
+
 	// deltas stores updates for every round after dbRound.
 	deltas []map[basics.Address]accountDelta
 
@@ -145,6 +164,13 @@ type accountUpdates struct {
 	// appears in creatableDeltas
 	creatables map[basics.CreatableIndex]modifiedCreatable
 
+	// kvDeltas stores key/value store updates for every round after dbRound.
+	kvDeltas []map[string]kvValueDelta
+
+	// kvStore stores the most recent state for every key that appears
+	// in kvDeltas
+	kvStore map[string]modifiedKv
+
 	// protos stores consensus parameters dbRound and every
 	// round after it; i.e., protos is one longer than deltas.
 	protos []config.ConsensusParams
@@ -233,6 +259,7 @@ func (au *accountUpdates) initialize(cfg config.Local, dbPathPrefix string, gene
 	au.initAccounts = genesisAccounts
 	au.dbDirectory = filepath.Dir(dbPathPrefix)
 	au.archivalLedger = cfg.Archival
+	au.accountHistory = cfg.Archival && cfg.EnableAccountHistory
 	switch cfg.CatchpointTracking {
 	case -1:
 		au.catchpointInterval = 0
@@ -334,7 +361,11 @@ func (au *accountUpdates) IsWritingCatchpointFile() bool {
 func (au *accountUpdates) Lookup(rnd basics.Round, addr basics.Address, withRewards bool) (data basics.AccountData, err error) {
 	au.accountsMu.RLock()
 	defer au.accountsMu.RUnlock()
-	return au.lookupImpl(rnd, addr, withRewards)
+	data, err = au.lookupImpl(rnd, addr, withRewards)
+	if _, ok := err.(*RoundOffsetError); ok && au.accountHistory {
+		return au.lookupHistoryImpl(rnd, addr, withRewards)
+	}
+	return
 }
 
 // ListAssets lists the assets by their asset index, limiting to the first maxResults
@@ -527,6 +558,13 @@ func (au *accountUpdates) onlineTop(rnd basics.Round, voteRnd basics.Round, n ui
 	return res, nil
 }
 
+// LookupKv returns the value of the given key in the key/value store at the given round
+func (au *accountUpdates) LookupKv(rnd basics.Round, key string) (value []byte, ok bool, err error) {
+	au.accountsMu.RLock()
+	defer au.accountsMu.RUnlock()
+	return au.lookupKvImpl(rnd, key)
+}
+
 // GetLastCatchpointLabel retrieves the last catchpoint label that was stored to the database.
 func (au *accountUpdates) GetLastCatchpointLabel() string {
 	au.accountsMu.RLock()
@@ -781,6 +819,11 @@ func (aul *accountUpdatesLedgerEvaluator) GetCreatorForRound(rnd basics.Round, c
 	return aul.au.getCreatorForRoundImpl(rnd, cidx, ctype)
 }
 
+// LookupKv returns the value of the given key in the key/value store at the given round
+func (aul *accountUpdatesLedgerEvaluator) LookupKv(rnd basics.Round, key string) ([]byte, bool, error) {
+	return aul.au.lookupKvImpl(rnd, key)
+}
+
 // totalsImpl returns the totals for a given round
 func (au *accountUpdates) totalsImpl(rnd basics.Round) (totals AccountTotals, err error) {
 	offset, err := au.roundOffset(rnd)
@@ -866,6 +909,11 @@ func (au *accountUpdates) initializeFromDisk(l ledgerForTracker) (lastBalancesRo
 			}
 		}
 
+		au.historyRound, err0 = accountsHistoryInitialize(tx, au.accountHistory, au.dbRound)
+		if err0 != nil {
+			return err0
+		}
+
 		totals, err0 := accountsTotals(tx, false)
 		if err0 != nil {
 			return err0
@@ -899,8 +947,10 @@ func (au *accountUpdates) initializeFromDisk(l ledgerForTracker) (lastBalancesRo
 	au.protos = []config.ConsensusParams{config.Consensus[hdr.CurrentProtocol]}
 	au.deltas = nil
 	au.creatableDeltas = nil
+	au.kvDeltas = nil
 	au.accounts = make(map[basics.Address]modifiedAccount)
 	au.creatables = make(map[basics.CreatableIndex]modifiedCreatable)
+	au.kvStore = make(map[string]modifiedKv)
 	au.deltasAccum = []int{0}
 
 	// keep these channel closed if we're not generating catchpoint
@@ -932,6 +982,25 @@ func accountHashBuilder(addr basics.Address, accountData basics.AccountData, enc
 	return hash[:]
 }
 
+// kvHashBuilder calculates the hash key used for the trie by combining the key and the value of a key/value store entry.
+// The key length is hashed along, so that no two distinct entries hash the same data.
+func kvHashBuilder(key string, value []byte) []byte {
+	hash := make([]byte, 4+crypto.DigestSize)
+	copy(hash, kvHashPrefix)
+	entry := make([]byte, 0, len(kvHashPrefix)+8+len(key)+len(value))
+	entry = append(entry, kvHashPrefix...)
+	entry = append(entry, make([]byte, 8)...)
+	binary.BigEndian.PutUint64(entry[len(kvHashPrefix):], uint64(len(key)))
+	entry = append(entry, key...)
+	entry = append(entry, value...)
+	entryHash := crypto.Hash(entry)
+	copy(hash[4:], entryHash[:])
+	return hash[:]
+}
+
+// kvHashPrefix leads the trie hash of every key/value store entry, keeping these apart from the account entries.
+const kvHashPrefix = "KVS:"
+
 // accountsInitialize initializes the accounts DB if needed and return current account round.
 // as part of the initialization, it tests the current database schema version, and perform upgrade
 // procedures to bring it up to the database schema supported by the binary.
@@ -979,6 +1048,18 @@ func (au *accountUpdates) accountsInitialize(ctx context.Context, tx *sql.Tx) (b
 					au.log.Warnf("accountsInitialize failed to upgrade accounts database (ledger.tracker.sqlite) from schema 3 : %v", err)
 					return 0, err
 				}
+			case 4:
+				dbVersion, err = au.upgradeDatabaseSchema4(ctx, tx)
+				if err != nil {
+					au.log.Warnf("accountsInitialize failed to upgrade accounts database (ledger.tracker.sqlite) from schema 4 : %v", err)
+					return 0, err
+				}
+			case 5:
+				dbVersion, err = au.upgradeDatabaseSchema5(ctx, tx)
+				if err != nil {
+					au.log.Warnf("accountsInitialize failed to upgrade accounts database (ledger.tracker.sqlite) from schema 5 : %v", err)
+					return 0, err
+				}
 			default:
 				return 0, fmt.Errorf("accountsInitialize unable to upgrade database from schema version %d", dbVersion)
 			}
@@ -1077,6 +1158,36 @@ func (au *accountUpdates) accountsInitialize(ctx context.Context, tx *sql.Tx) (b
 			}
 		}
 
+		// add the key/value store entries as well.
+		var kvsIterator encodedKVsBatchIter
+		defer kvsIterator.Close()
+		for {
+			kvs, err := kvsIterator.Next(ctx, tx, trieRebuildAccountChunkSize)
+			if err != nil {
+				return rnd, err
+			}
+			if len(kvs) == 0 {
+				break
+			}
+			for _, kv := range kvs {
+				hash := kvHashBuilder(string(kv.Key), kv.Value)
+				added, err := trie.Add(hash)
+				if err != nil {
+					return rnd, fmt.Errorf("accountsInitialize was unable to add changes to trie: %v", err)
+				}
+				if !added {
+					au.log.Warnf("accountsInitialize attempted to add duplicate hash '%s' to merkle trie for key %#x", hex.EncodeToString(hash), kv.Key)
+				}
+			}
+			_, err = trie.Evict(true)
+			if err != nil {
+				return 0, fmt.Errorf("accountsInitialize was unable to commit changes to trie: %v", err)
+			}
+			if len(kvs) < trieRebuildAccountChunkSize {
+				break
+			}
+		}
+
 		// this trie Evict will commit using the current transaction.
 		// if anything goes wrong, it will still get rolled back.
 		_, err = trie.Evict(true)
@@ -1231,6 +1342,38 @@ func (au *accountUpdates) upgradeDatabaseSchema3(ctx context.Context, tx *sql.Tx
 	return 4, nil
 }
 
+// upgradeDatabaseSchema4 upgrades the database schema from version 4 to version 5,
+// adding the accounthistory table.
+func (au *accountUpdates) upgradeDatabaseSchema4(ctx context.Context, tx *sql.Tx) (updatedDBVersion int32, err error) {
+	_, err = tx.ExecContext(ctx, accountsHistorySchema)
+	if err != nil {
+		return 0, fmt.Errorf("accountsInitialize unable to create the accounthistory table: %v", err)
+	}
+
+	// update version
+	_, err = db.SetUserVersion(ctx, tx, 5)
+	if err != nil {
+		return 0, fmt.Errorf("accountsInitialize unable to update database schema version from 4 to 5: %v", err)
+	}
+	return 5, nil
+}
+
+// upgradeDatabaseSchema5 upgrades the database schema from version 5 to version 6,
+// adding the kvstore table which holds the application boxes.
+func (au *accountUpdates) upgradeDatabaseSchema5(ctx context.Context, tx *sql.Tx) (updatedDBVersion int32, err error) {
+	_, err = tx.ExecContext(ctx, kvStoreSchema)
+	if err != nil {
+		return 0, fmt.Errorf("accountsInitialize unable to create the kvstore table: %v", err)
+	}
+
+	// update version
+	_, err = db.SetUserVersion(ctx, tx, 6)
+	if err != nil {
+		return 0, fmt.Errorf("accountsInitialize unable to update database schema version from 5 to 6: %v", err)
+	}
+	return 6, nil
+}
+
 // deleteStoredCatchpoints iterates over the storedcatchpoints table and deletes all the files stored on disk.
 // once all the files have been deleted, it would go ahead and remove the entries from the table.
 func (au *accountUpdates) deleteStoredCatchpoints(ctx context.Context, dbQueries *accountsDbQueries) (err error) {
@@ -1264,8 +1407,8 @@ func (au *accountUpdates) deleteStoredCatchpoints(ctx context.Context, dbQueries
 	return nil
 }
 
-// accountsUpdateBalances applies the given deltas array to the merkle trie
-func (au *accountUpdates) accountsUpdateBalances(accountsDeltasRound []map[basics.Address]accountDelta, offset uint64) (err error) {
+// accountsUpdateBalances applies the given accounts and key/value store deltas arrays to the merkle trie
+func (au *accountUpdates) accountsUpdateBalances(accountsDeltasRound []map[basics.Address]accountDelta, kvDeltasRound []map[string]kvValueDelta, offset uint64) (err error) {
 	if au.catchpointInterval == 0 {
 		return nil
 	}
@@ -1299,6 +1442,32 @@ func (au *accountUpdates) accountsUpdateBalances(accountsDeltasRound []map[basic
 				}
 			}
 		}
+		for key, delta := range kvDeltasRound[i] {
+			if delta.oldExists {
+				deleteHash := kvHashBuilder(key, delta.old)
+				deleted, err = au.balancesTrie.Delete(deleteHash)
+				if err != nil {
+					return err
+				}
+				if !deleted {
+					au.log.Warnf("failed to delete hash '%s' from merkle trie for key %#x", hex.EncodeToString(deleteHash), key)
+				} else {
+					accumulatedChanges++
+				}
+			}
+			if delta.newExists {
+				addHash := kvHashBuilder(key, delta.new)
+				added, err = au.balancesTrie.Add(addHash)
+				if err != nil {
+					return err
+				}
+				if !added {
+					au.log.Warnf("attempted to add duplicate hash '%s' to merkle trie for key %#x", hex.EncodeToString(addHash), key)
+				} else {
+					accumulatedChanges++
+				}
+			}
+		}
 		if accumulatedChanges >= trieAccumulatedChangesFlush {
 			accumulatedChanges = 0
 			err = au.balancesTrie.Commit()
@@ -1331,6 +1500,7 @@ func (au *accountUpdates) newBlockImpl(blk bookkeeping.Block, delta StateDelta)
 	au.deltas = append(au.deltas, delta.accts)
 	au.protos = append(au.protos, proto)
 	au.creatableDeltas = append(au.creatableDeltas, delta.creatables)
+	au.kvDeltas = append(au.kvDeltas, delta.kvMods)
 	au.roundDigest = append(au.roundDigest, blk.Digest())
 	au.deltasAccum = append(au.deltasAccum, len(delta.accts)+au.deltasAccum[len(au.deltasAccum)-1])
 
@@ -1358,6 +1528,14 @@ func (au *accountUpdates) newBlockImpl(blk bookkeeping.Block, delta StateDelta)
 		au.creatables[cidx] = mcreat
 	}
 
+	for key, kvdelta := range delta.kvMods {
+		mkv := au.kvStore[key]
+		mkv.value = kvdelta.new
+		mkv.exists = kvdelta.newExists
+		mkv.ndeltas++
+		au.kvStore[key] = mkv
+	}
+
 	if ot.Overflowed {
 		au.log.Panicf("accountUpdates: newBlock %d overflowed totals", rnd)
 	}
@@ -1417,6 +1595,28 @@ func (au *accountUpdates) lookupImpl(rnd basics.Round, addr basics.Address, with
 	return au.accountsq.lookup(addr)
 }
 
+// lookupHistoryImpl returns the account data for a given address at a round which precedes the in-memory deltas, using
+// the historical accounts table. The withRewards indicates whether the rewards should be added to the AccountData before returning.
+func (au *accountUpdates) lookupHistoryImpl(rnd basics.Round, addr basics.Address, withRewards bool) (data basics.AccountData, err error) {
+	if rnd < au.historyRound || rnd >= au.dbRound {
+		return basics.AccountData{}, &RoundOffsetError{round: rnd, dbRound: au.historyRound}
+	}
+
+	data, err = au.accountsq.lookupHistory(addr, rnd)
+	if err != nil {
+		return basics.AccountData{}, err
+	}
+
+	if withRewards {
+		hdr, err := au.ledger.BlockHdr(rnd)
+		if err != nil {
+			return basics.AccountData{}, err
+		}
+		data = data.WithUpdatedRewards(config.Consensus[hdr.CurrentProtocol], hdr.RewardsLevel)
+	}
+	return data, nil
+}
+
 // getCreatorForRoundImpl returns the asset/app creator for a given asset/app index at a given round
 func (au *accountUpdates) getCreatorForRoundImpl(rnd basics.Round, cidx basics.CreatableIndex, ctype basics.CreatableType) (creator basics.Address, ok bool, err error) {
 	offset, err := au.roundOffset(rnd)
@@ -1452,6 +1652,34 @@ func (au *accountUpdates) getCreatorForRoundImpl(rnd basics.Round, cidx basics.C
 	return au.accountsq.lookupCreator(cidx, ctype)
 }
 
+// lookupKvImpl returns the value of the given key in the key/value store at the given round
+func (au *accountUpdates) lookupKvImpl(rnd basics.Round, key string) (value []byte, ok bool, err error) {
+	offset, err := au.roundOffset(rnd)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// If this is the most recent round, au.kvStore has the latest state
+	// and we can skip scanning backwards over kvDeltas
+	if offset == uint64(len(au.deltas)) {
+		mkv, ok := au.kvStore[key]
+		if ok {
+			return mkv.value, mkv.exists, nil
+		}
+	} else {
+		for offset > 0 {
+			offset--
+			kvdelta, ok := au.kvDeltas[offset][key]
+			if ok {
+				return kvdelta.new, kvdelta.newExists, nil
+			}
+		}
+	}
+
+	// Check the database
+	return au.accountsq.lookupKv(key)
+}
+
 // accountsCreateCatchpointLabel creates a catchpoint label and write it.
 func (au *accountUpdates) accountsCreateCatchpointLabel(committedRound basics.Round, totals AccountTotals, ledgerBlockDigest crypto.Digest, trieBalancesHash crypto.Digest) (label string, err error) {
 	cpLabel := makeCatchpointLabel(committedRound, ledgerBlockDigest, trieBalancesHash, totals)
@@ -1537,10 +1765,12 @@ func (au *accountUpdates) commitRound(offset uint64, dbRound basics.Round, lookb
 	// create a copy of the deltas, round totals and protos for the range we're going to flush.
 	deltas := make([]map[basics.Address]accountDelta, offset, offset)
 	creatableDeltas := make([]map[basics.CreatableIndex]modifiedCreatable, offset, offset)
+	kvDeltas := make([]map[string]kvValueDelta, offset, offset)
 	roundTotals := make([]AccountTotals, offset+1, offset+1)
 	protos := make([]config.ConsensusParams, offset+1, offset+1)
 	copy(deltas, au.deltas[:offset])
 	copy(creatableDeltas, au.creatableDeltas[:offset])
+	copy(kvDeltas, au.kvDeltas[:offset])
 	copy(roundTotals, au.roundTotals[:offset+1])
 	copy(protos, au.protos[:offset+1])
 
@@ -1549,6 +1779,7 @@ func (au *accountUpdates) commitRound(offset uint64, dbRound basics.Round, lookb
 	// au.accounts.
 	flushcount := make(map[basics.Address]int)
 	creatableFlushcount := make(map[basics.CreatableIndex]int)
+	kvFlushcount := make(map[string]int)
 
 	var committedRoundDigest crypto.Digest
 
@@ -1574,6 +1805,9 @@ func (au *accountUpdates) commitRound(offset uint64, dbRound basics.Round, lookb
 		for cidx := range creatableDeltas[i] {
 			creatableFlushcount[cidx] = creatableFlushcount[cidx] + 1
 		}
+		for key := range kvDeltas[i] {
+			kvFlushcount[key] = kvFlushcount[key] + 1
+		}
 	}
 
 	var catchpointLabel string
@@ -1604,7 +1838,13 @@ func (au *accountUpdates) commitRound(offset uint64, dbRound basics.Round, lookb
 			treeTargetRound = dbRound + basics.Round(offset)
 		}
 		for i := uint64(0); i < offset; i++ {
-			err = accountsNewRound(tx, deltas[i], creatableDeltas[i], genesisProto)
+			if au.accountHistory {
+				err = accountsHistoryNewRound(tx, dbRound+basics.Round(i)+1, deltas[i])
+				if err != nil {
+					return err
+				}
+			}
+			err = accountsNewRound(tx, deltas[i], creatableDeltas[i], kvDeltas[i], genesisProto)
 			if err != nil {
 				return err
 			}
@@ -1614,7 +1854,7 @@ func (au *accountUpdates) commitRound(offset uint64, dbRound basics.Round, lookb
 			return err
 		}
 
-		err = au.accountsUpdateBalances(deltas, offset)
+		err = au.accountsUpdateBalances(deltas, kvDeltas, offset)
 		if err != nil {
 			return err
 		}
@@ -1695,12 +1935,31 @@ func (au *accountUpdates) commitRound(offset uint64, dbRound basics.Round, lookb
 		}
 	}
 
+	for key, cnt := range kvFlushcount {
+		mkv, ok := au.kvStore[key]
+		if !ok {
+			au.log.Panicf("inconsistency: flushed %d changes to key %#x, but not in au.kvStore", cnt, key)
+		}
+
+		if cnt > mkv.ndeltas {
+			au.log.Panicf("inconsistency: flushed %d changes to key %#x, but au.kvStore had %d", cnt, key, mkv.ndeltas)
+		}
+
+		mkv.ndeltas -= cnt
+		if mkv.ndeltas == 0 {
+			delete(au.kvStore, key)
+		} else {
+			au.kvStore[key] = mkv
+		}
+	}
+
 	au.deltas = au.deltas[offset:]
 	au.deltasAccum = au.deltasAccum[offset:]
 	au.roundDigest = au.roundDigest[offset:]
 	au.protos = au.protos[offset:]
 	au.roundTotals = au.roundTotals[offset:]
 	au.creatableDeltas = au.creatableDeltas[offset:]
+	au.kvDeltas = au.kvDeltas[offset:]
 	au.dbRound = newBase
 	au.lastFlushTime = flushTime
 
@@ -17,9 +17,11 @@
 package ledger
 
 import (
+	"encoding/binary"
 	"fmt"
 
 	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/transactions"
 	"github.com/algorand/go-algorand/data/transactions/logic"
 	"github.com/algorand/go-algorand/ledger/apply"
 )
@@ -49,12 +51,12 @@ type appLedger struct {
 
 // Eval evaluates a stateful TEAL program for an application. InitLedger must
 // be called before calling Eval.
-func (ae *appTealEvaluator) Eval(program []byte) (pass bool, stateDelta basics.EvalDelta, err error) {
+func (ae *appTealEvaluator) Eval(program []byte) (pass bool, stateDelta basics.EvalDelta, innerTxns []transactions.Transaction, err error) {
 	if ae.evalParams.Ledger == nil {
 		err = fmt.Errorf("appTealEvaluator Ledger not initialized")
 		return
 	}
-	return logic.EvalStateful(program, ae.evalParams)
+	return logic.EvalStatefulWithInnerTxns(program, ae.evalParams)
 }
 
 // Check computes the cost of a TEAL program for an application. InitLedger must
@@ -247,3 +249,81 @@ func (al *appLedger) LocalSchema() basics.StateSchema {
 func (al *appLedger) GlobalSchema() basics.StateSchema {
 	return al.schemas.GlobalStateSchema
 }
+
+// boxKey returns the key under which a box of an application is kept in the
+// ledger's key/value store. The application ID is encoded big-endian so that
+// the boxes of an application are stored contiguously.
+func boxKey(appIdx basics.AppIndex, name string) string {
+	key := make([]byte, len(boxKeyPrefix)+8, len(boxKeyPrefix)+8+len(name))
+	copy(key, boxKeyPrefix)
+	binary.BigEndian.PutUint64(key[len(boxKeyPrefix):], uint64(appIdx))
+	return string(append(key, name...))
+}
+
+const boxKeyPrefix = "bx:"
+
+func (al *appLedger) GetBox(appIdx basics.AppIndex, name string) ([]byte, bool, error) {
+	return al.balances.GetBox(appIdx, name)
+}
+
+// NewBox creates a box for an application and accounts for it in the
+// MinBalance of the application account.
+func (al *appLedger) NewBox(appIdx basics.AppIndex, name string, value []byte) error {
+	record, err := al.balances.Get(appIdx.Address(), false)
+	if err != nil {
+		return err
+	}
+
+	record.TotalBoxes = basics.AddSaturate(record.TotalBoxes, 1)
+	record.TotalBoxBytes = basics.AddSaturate(record.TotalBoxBytes, uint64(len(name)+len(value)))
+	err = al.balances.Put(record)
+	if err != nil {
+		return err
+	}
+
+	return al.balances.PutBox(appIdx, name, value)
+}
+
+// SetBox overwrites the contents of an existing box. Its size must not
+// change, since the MinBalance of the application account depends on it.
+func (al *appLedger) SetBox(appIdx basics.AppIndex, name string, value []byte) error {
+	current, ok, err := al.balances.GetBox(appIdx, name)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("box %#x of app %d does not exist", name, appIdx)
+	}
+	if len(current) != len(value) {
+		return fmt.Errorf("box %#x of app %d has size %d, cannot set %d bytes", name, appIdx, len(current), len(value))
+	}
+
+	return al.balances.PutBox(appIdx, name, value)
+}
+
+// DelBox deletes a box, if it exists, and releases the MinBalance the
+// application account was charged for it.
+func (al *appLedger) DelBox(appIdx basics.AppIndex, name string) (bool, error) {
+	current, ok, err := al.balances.GetBox(appIdx, name)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	record, err := al.balances.Get(appIdx.Address(), false)
+	if err != nil {
+		return false, err
+	}
+
+	size := uint64(len(name) + len(current))
+	if record.TotalBoxes == 0 || record.TotalBoxBytes < size {
+		return false, fmt.Errorf("app %d account does not hold box %#x", appIdx, name)
+	}
+	record.TotalBoxes--
+	record.TotalBoxBytes -= size
+	err = al.balances.Put(record)
+	if err != nil {
+		return false, err
+	}
+
+	return true, al.balances.DelBox(appIdx, name)
+}
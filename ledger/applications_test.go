@@ -74,6 +74,18 @@ func (b *testBalances) GetCreator(cidx basics.CreatableIndex, ctype basics.Creat
 	return basics.Address{}, false, nil
 }
 
+func (b *testBalances) GetBox(appIdx basics.AppIndex, name string) ([]byte, bool, error) {
+	return nil, false, nil
+}
+
+func (b *testBalances) PutBox(appIdx basics.AppIndex, name string, value []byte) error {
+	return nil
+}
+
+func (b *testBalances) DelBox(appIdx basics.AppIndex, name string) error {
+	return nil
+}
+
 func (b *testBalances) Move(src, dst basics.Address, amount basics.MicroAlgos, srcRewards *basics.MicroAlgos, dstRewards *basics.MicroAlgos) error {
 	return nil
 }
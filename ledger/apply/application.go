@@ -379,7 +379,12 @@ func applyClearState(ac *transactions.ApplicationCallTxnFields, balances Balance
 		// Execute the ClearStateProgram before we've deleted the LocalState
 		// for this account. If the ClearStateProgram does not fail, apply any
 		// state deltas it generated.
-		pass, evalDelta, err := steva.Eval(params.ClearStateProgram)
+		pass, evalDelta, innerTxns, err := steva.Eval(params.ClearStateProgram)
+		if err == nil && len(innerTxns) > 0 {
+			// The evaluator fails a ClearStateProgram submitting inner
+			// transactions, so this can only come from a faulty evaluator
+			err = fmt.Errorf("ClearStateProgram issued %d inner transactions", len(innerTxns))
+		}
 		if err == nil && pass {
 			// Program execution may produce some GlobalState and LocalState
 			// deltas. Apply them, provided they don't exceed the bounds set by
@@ -461,6 +466,7 @@ func ApplicationCall(ac transactions.ApplicationCallTxnFields, header transactio
 		// non-empty EvalDelta. Not required for correctness.
 		if err != nil && ad != nil {
 			ad.EvalDelta = basics.EvalDelta{}
+			ad.InnerTxns = nil
 		}
 	}()
 
@@ -534,7 +540,7 @@ func ApplicationCall(ac transactions.ApplicationCallTxnFields, header transactio
 	}
 
 	// Execute the Approval program
-	approved, evalDelta, err := steva.Eval(params.ApprovalProgram)
+	approved, evalDelta, innerTxns, err := steva.Eval(params.ApprovalProgram)
 	if err != nil {
 		return err
 	}
@@ -587,6 +593,19 @@ func ApplicationCall(ac transactions.ApplicationCallTxnFields, header transactio
 		}
 
 	case transactions.DeleteApplicationOC:
+		// The boxes of a deleted application could never be deleted, and
+		// the MinBalance they hold in the application account never
+		// released, so they must all be deleted first
+		if balances.ConsensusParams().MaxBoxSize > 0 {
+			appRecord, err := balances.Get(appIdx.Address(), false)
+			if err != nil {
+				return err
+			}
+			if appRecord.TotalBoxes > 0 {
+				return fmt.Errorf("cannot delete app %d while it holds %d boxes", appIdx, appRecord.TotalBoxes)
+			}
+		}
+
 		// Deleting the application. Fetch the creator's balance record
 		record, err := balances.Get(creator, false)
 		if err != nil {
@@ -644,5 +663,15 @@ func ApplicationCall(ac transactions.ApplicationCallTxnFields, header transactio
 	// stateful TEAL interpreter to apply state changes
 	ad.EvalDelta = evalDelta
 
+	// Record the inner transactions issued by the ApprovalProgram. The
+	// caller is responsible for executing them and filling in their
+	// ApplyData.
+	if len(innerTxns) > 0 {
+		ad.InnerTxns = make([]transactions.SignedTxnWithAD, len(innerTxns))
+		for i, itx := range innerTxns {
+			ad.InnerTxns[i].SignedTxn.Txn = itx
+		}
+	}
+
 	return nil
 }
@@ -157,6 +157,18 @@ func (b *testBalances) GetCreator(cidx basics.CreatableIndex, ctype basics.Creat
 	return basics.Address{}, false, nil
 }
 
+func (b *testBalances) GetBox(appIdx basics.AppIndex, name string) ([]byte, bool, error) {
+	return nil, false, nil
+}
+
+func (b *testBalances) PutBox(appIdx basics.AppIndex, name string, value []byte) error {
+	return nil
+}
+
+func (b *testBalances) DelBox(appIdx basics.AppIndex, name string) error {
+	return nil
+}
+
 func (b *testBalances) Move(src, dst basics.Address, amount basics.MicroAlgos, srcRewards *basics.MicroAlgos, dstRewards *basics.MicroAlgos) error {
 	return nil
 }
@@ -204,17 +216,18 @@ func (b *testBalances) SetProto(name protocol.ConsensusVersion) {
 }
 
 type testEvaluator struct {
-	pass   bool
-	delta  basics.EvalDelta
-	appIdx basics.AppIndex
+	pass      bool
+	delta     basics.EvalDelta
+	innerTxns []transactions.Transaction
+	appIdx    basics.AppIndex
 }
 
 // Eval for tests that fail on program version > 10 and returns pass/delta from its own state rather than running the program
-func (e *testEvaluator) Eval(program []byte) (pass bool, stateDelta basics.EvalDelta, err error) {
+func (e *testEvaluator) Eval(program []byte) (pass bool, stateDelta basics.EvalDelta, innerTxns []transactions.Transaction, err error) {
 	if len(program) < 1 || program[0] > 10 {
-		return false, basics.EvalDelta{}, fmt.Errorf("mock eval error")
+		return false, basics.EvalDelta{}, nil, fmt.Errorf("mock eval error")
 	}
-	return e.pass, e.delta, nil
+	return e.pass, e.delta, e.innerTxns, nil
 }
 
 // Check for tests that fail on program version > 10 and returns program len as cost
@@ -881,6 +894,7 @@ func TestAppCallApplyCreateOptIn(t *testing.T) {
 
 	b.balances = make(map[basics.Address]basics.AccountData)
 	b.balances[creator] = basics.AccountData{}
+	b.balances[appIdx.Address()] = basics.AccountData{}
 	b.SetProto(protocol.ConsensusFuture)
 	b.appCreators = map[basics.AppIndex]basics.Address{appIdx: creator}
 
@@ -1105,6 +1119,7 @@ func TestAppCallApplyCloseOut(t *testing.T) {
 		AppParams: map[basics.AppIndex]basics.AppParams{appIdx: params},
 	}
 	b.balances[creator] = cp
+	b.balances[appIdx.Address()] = basics.AccountData{}
 	b.appCreators = map[basics.AppIndex]basics.Address{appIdx: creator}
 
 	b.SetProto(protocol.ConsensusFuture)
@@ -1188,6 +1203,7 @@ func TestAppCallApplyUpdate(t *testing.T) {
 		AppParams: map[basics.AppIndex]basics.AppParams{appIdx: params},
 	}
 	b.balances[creator] = cp
+	b.balances[appIdx.Address()] = basics.AccountData{}
 	b.appCreators = map[basics.AppIndex]basics.Address{appIdx: creator}
 
 	b.SetProto(protocol.ConsensusFuture)
@@ -1218,6 +1234,77 @@ func TestAppCallApplyUpdate(t *testing.T) {
 	a.Equal(basics.EvalDelta{}, ad.EvalDelta)
 }
 
+func TestAppCallApplyInnerTxns(t *testing.T) {
+	a := require.New(t)
+
+	creator := getRandomAddress(a)
+	sender := getRandomAddress(a)
+	var txnCounter uint64 = 1
+	appIdx := basics.AppIndex(txnCounter + 1)
+
+	ac := transactions.ApplicationCallTxnFields{
+		ApplicationID: appIdx,
+		OnCompletion:  transactions.NoOpOC,
+	}
+	params := basics.AppParams{
+		ApprovalProgram:   []byte{1},
+		ClearStateProgram: []byte{1},
+	}
+	h := transactions.Header{
+		Sender: sender,
+	}
+	var steva testEvaluator
+	var ad *transactions.ApplyData = &transactions.ApplyData{}
+	var b testBalances
+
+	b.balances = make(map[basics.Address]basics.AccountData)
+	b.balances[creator] = basics.AccountData{
+		AppParams: map[basics.AppIndex]basics.AppParams{appIdx: params},
+	}
+	b.balances[sender] = basics.AccountData{}
+	b.appCreators = map[basics.AppIndex]basics.Address{appIdx: creator}
+
+	b.SetProto(protocol.ConsensusFuture)
+
+	itxn := transactions.Transaction{
+		Type: protocol.PaymentTx,
+		Header: transactions.Header{
+			Sender: appIdx.Address(),
+		},
+		PaymentTxnFields: transactions.PaymentTxnFields{
+			Receiver: sender,
+			Amount:   basics.MicroAlgos{Raw: 1},
+		},
+	}
+	steva.innerTxns = []transactions.Transaction{itxn}
+
+	// rejected programs do not record inner transactions
+	steva.pass = false
+	err := ApplicationCall(ac, h, &b, ad, txnCounter, &steva)
+	a.Error(err)
+	a.Contains(err.Error(), "transaction rejected by ApprovalProgram")
+	a.Empty(ad.InnerTxns)
+
+	steva.pass = true
+	err = ApplicationCall(ac, h, &b, ad, txnCounter, &steva)
+	a.NoError(err)
+	a.Equal([]transactions.SignedTxnWithAD{{SignedTxn: transactions.SignedTxn{Txn: itxn}}}, ad.InnerTxns)
+
+	// a ClearStateProgram issuing inner transactions fails: they are not
+	// recorded, nor are its state changes, but the local state is cleared
+	b.balances[sender] = basics.AccountData{
+		AppLocalStates: map[basics.AppIndex]basics.AppLocalState{appIdx: {}},
+	}
+	ac.OnCompletion = transactions.ClearStateOC
+	ad = &transactions.ApplyData{}
+	steva.delta = basics.EvalDelta{GlobalDelta: basics.StateDelta{"key": basics.ValueDelta{Action: basics.SetUintAction, Uint: 1}}}
+	err = ApplicationCall(ac, h, &b, ad, txnCounter, &steva)
+	a.NoError(err)
+	a.Empty(ad.InnerTxns)
+	a.Equal(basics.EvalDelta{}, ad.EvalDelta)
+	a.NotContains(b.putBalances[sender].AppLocalStates, appIdx)
+}
+
 func TestAppCallApplyDelete(t *testing.T) {
 	a := require.New(t)
 
@@ -1251,6 +1338,7 @@ func TestAppCallApplyDelete(t *testing.T) {
 		AppParams: map[basics.AppIndex]basics.AppParams{appIdx: params},
 	}
 	b.balances[creator] = cp
+	b.balances[appIdx.Address()] = basics.AccountData{}
 	b.appCreators = map[basics.AppIndex]basics.Address{appIdx: creator}
 
 	b.SetProto(protocol.ConsensusFuture)
@@ -1266,9 +1354,17 @@ func TestAppCallApplyDelete(t *testing.T) {
 	a.Equal(cbr, br)
 	a.Equal(basics.EvalDelta{}, ad.EvalDelta)
 
-	// check deletion on empty balance record - happy case
+	// apps holding boxes can't be deleted
 	steva.pass = true
 	b.balances[sender] = basics.AccountData{}
+	b.balances[appIdx.Address()] = basics.AccountData{TotalBoxes: 1, TotalBoxBytes: 8}
+	err = ApplicationCall(ac, h, &b, ad, txnCounter, &steva)
+	a.Error(err)
+	a.Contains(err.Error(), "holds 1 boxes")
+	a.Equal(0, b.putWith)
+	b.balances[appIdx.Address()] = basics.AccountData{}
+
+	// check deletion on empty balance record - happy case
 	err = ApplicationCall(ac, h, &b, ad, txnCounter, &steva)
 	a.NoError(err)
 	a.Equal(0, b.put)
@@ -1304,6 +1400,7 @@ func TestAppCallApplyCreateClearState(t *testing.T) {
 
 	b.balances = make(map[basics.Address]basics.AccountData)
 	b.balances[creator] = basics.AccountData{}
+	b.balances[appIdx.Address()] = basics.AccountData{}
 	b.SetProto(protocol.ConsensusFuture)
 	b.appCreators = map[basics.AppIndex]basics.Address{appIdx: creator}
 
@@ -1349,6 +1446,7 @@ func TestAppCallApplyCreateDelete(t *testing.T) {
 
 	b.balances = make(map[basics.Address]basics.AccountData)
 	b.balances[creator] = basics.AccountData{}
+	b.balances[appIdx.Address()] = basics.AccountData{}
 	b.SetProto(protocol.ConsensusFuture)
 	b.appCreators = map[basics.AppIndex]basics.Address{appIdx: creator}
 
@@ -19,6 +19,7 @@ package apply
 import (
 	"github.com/algorand/go-algorand/config"
 	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/transactions"
 )
 
 // Balances allow to move MicroAlgos from one address to another and to update balance records, or to access and modify individual balance records
@@ -38,6 +39,18 @@ type Balances interface {
 	// GetCreator gets the address of the account that created a given creatable
 	GetCreator(cidx basics.CreatableIndex, ctype basics.CreatableType) (basics.Address, bool, error)
 
+	// GetBox looks up the value of a named box of an application. Boxes
+	// are kept apart from the account records; ok is false if the box
+	// does not exist.
+	GetBox(appIdx basics.AppIndex, name string) (value []byte, ok bool, err error)
+
+	// PutBox creates or overwrites a named box of an application. It does
+	// not account for the box in the MinBalance of the application.
+	PutBox(appIdx basics.AppIndex, name string, value []byte) error
+
+	// DelBox deletes a named box of an application
+	DelBox(appIdx basics.AppIndex, name string) error
+
 	// Move MicroAlgos from one account to another, doing all necessary overflow checking (convenience method)
 	// TODO: Does this need to be part of the balances interface, or can it just be implemented here as a function that calls Put and Get?
 	Move(src, dst basics.Address, amount basics.MicroAlgos, srcRewards *basics.MicroAlgos, dstRewards *basics.MicroAlgos) error
@@ -53,7 +66,7 @@ type Balances interface {
 // transactions packages (when the apply methods were in the transactions
 // package).
 type StateEvaluator interface {
-	Eval(program []byte) (pass bool, stateDelta basics.EvalDelta, err error)
+	Eval(program []byte) (pass bool, stateDelta basics.EvalDelta, innerTxns []transactions.Transaction, err error)
 	Check(program []byte) (cost int, err error)
 	InitLedger(balances Balances, appIdx basics.AppIndex, schemas basics.StateSchemas) error
 }
@@ -43,6 +43,18 @@ func (balances keyregTestBalances) GetCreator(cidx basics.CreatableIndex, ctype
 	return basics.Address{}, true, nil
 }
 
+func (balances keyregTestBalances) GetBox(basics.AppIndex, string) ([]byte, bool, error) {
+	return nil, false, nil
+}
+
+func (balances keyregTestBalances) PutBox(basics.AppIndex, string, []byte) error {
+	return nil
+}
+
+func (balances keyregTestBalances) DelBox(basics.AppIndex, string) error {
+	return nil
+}
+
 func (balances keyregTestBalances) Put(basics.BalanceRecord) error {
 	return nil
 }
@@ -96,6 +96,18 @@ func (balances mockBalances) GetCreator(idx basics.CreatableIndex, ctype basics.
 	return basics.Address{}, true, nil
 }
 
+func (balances mockBalances) GetBox(basics.AppIndex, string) ([]byte, bool, error) {
+	return nil, false, nil
+}
+
+func (balances mockBalances) PutBox(basics.AppIndex, string, []byte) error {
+	return nil
+}
+
+func (balances mockBalances) DelBox(basics.AppIndex, string) error {
+	return nil
+}
+
 func (balances mockBalances) Put(basics.BalanceRecord) error {
 	return nil
 }
@@ -39,6 +39,9 @@ const (
 	// note that the last chunk would typically be less than this number.
 	BalancesPerCatchpointFileChunk = 512
 
+	// KVsPerCatchpointFileChunk defines the number of key/value store entries that would be stored in each chunk in the catchpoint file.
+	KVsPerCatchpointFileChunk = 512
+
 	// catchpointFileVersion is the catchpoint file version
 	catchpointFileVersion = uint64(0200)
 )
@@ -61,11 +64,15 @@ type catchpointWriter struct {
 	balancesChunk     catchpointFileBalancesChunk
 	fileHeader        *CatchpointFileHeader
 	balancesChunkNum  uint64
+	balancesDone      bool
+	kvsChunk          catchpointFileKVsChunk
+	kvsChunkNum       uint64
 	writtenBytes      int64
 	blocksRound       basics.Round
 	blockHeaderDigest crypto.Digest
 	label             string
 	accountsIterator  encodedAccountsBatchIter
+	kvsIterator       encodedKVsBatchIter
 }
 
 type encodedBalanceRecord struct {
@@ -95,6 +102,18 @@ type catchpointFileBalancesChunk struct {
 	Balances []encodedBalanceRecord `codec:"bl,allocbound=BalancesPerCatchpointFileChunk"`
 }
 
+// encodedKVRecord is a single entry of the key/value store, as written to the catchpoint file.
+type encodedKVRecord struct {
+	Key   []byte `codec:"k"`
+	Value []byte `codec:"v"`
+}
+
+// catchpointFileKVsChunk holds the key/value store entries of a "kvs.N.msgpack" section of the catchpoint file.
+// These sections follow all the balances sections.
+type catchpointFileKVsChunk struct {
+	KVs []encodedKVRecord `codec:"kv"`
+}
+
 func makeCatchpointWriter(ctx context.Context, filePath string, tx *sql.Tx, blocksRound basics.Round, blockHeaderDigest crypto.Digest, label string) *catchpointWriter {
 	return &catchpointWriter{
 		ctx:               ctx,
@@ -109,6 +128,7 @@ func makeCatchpointWriter(ctx context.Context, filePath string, tx *sql.Tx, bloc
 
 func (cw *catchpointWriter) Abort() error {
 	cw.accountsIterator.Close()
+	cw.kvsIterator.Close()
 	if cw.tar != nil {
 		cw.tar.Close()
 	}
@@ -176,6 +196,10 @@ func (cw *catchpointWriter) WriteStep(stepCtx context.Context) (more bool, err e
 			return
 		}
 
+		if cw.balancesDone {
+			return cw.writeKVsStep()
+		}
+
 		if len(cw.balancesChunk.Balances) == 0 {
 			err = cw.readDatabaseStep(cw.ctx, cw.tx)
 			if err != nil {
@@ -207,24 +231,56 @@ func (cw *catchpointWriter) WriteStep(stepCtx context.Context) (more bool, err e
 			}
 
 			if len(cw.balancesChunk.Balances) < BalancesPerCatchpointFileChunk || cw.balancesChunkNum == cw.fileHeader.TotalChunks {
-				cw.tar.Close()
-				cw.gzip.Close()
-				cw.file.Close()
-				cw.balancesChunk.Balances = nil
-				cw.file = nil
-				var fileInfo os.FileInfo
-				fileInfo, err = os.Stat(cw.filePath)
-				if err != nil {
-					return false, err
-				}
-				cw.writtenBytes = fileInfo.Size()
-				return false, nil
+				cw.balancesDone = true
 			}
 			cw.balancesChunk.Balances = nil
 		}
 	}
 }
 
+// writeKVsStep writes the next chunk of the key/value store entries, and closes the file once all of these were written.
+func (cw *catchpointWriter) writeKVsStep() (more bool, err error) {
+	cw.kvsChunk.KVs, err = cw.kvsIterator.Next(cw.ctx, cw.tx, KVsPerCatchpointFileChunk)
+	if err != nil {
+		return
+	}
+
+	if len(cw.kvsChunk.KVs) > 0 {
+		cw.kvsChunkNum++
+		encodedChunk := protocol.EncodeReflect(&cw.kvsChunk)
+		err = cw.tar.WriteHeader(&tar.Header{
+			Name: fmt.Sprintf("kvs.%d.msgpack", cw.kvsChunkNum),
+			Mode: 0600,
+			Size: int64(len(encodedChunk)),
+		})
+		if err != nil {
+			return
+		}
+		_, err = cw.tar.Write(encodedChunk)
+		if err != nil {
+			return
+		}
+	}
+
+	if len(cw.kvsChunk.KVs) == KVsPerCatchpointFileChunk {
+		cw.kvsChunk.KVs = nil
+		return true, nil
+	}
+
+	cw.tar.Close()
+	cw.gzip.Close()
+	cw.file.Close()
+	cw.kvsChunk.KVs = nil
+	cw.file = nil
+	var fileInfo os.FileInfo
+	fileInfo, err = os.Stat(cw.filePath)
+	if err != nil {
+		return false, err
+	}
+	cw.writtenBytes = fileInfo.Size()
+	return false, nil
+}
+
 func (cw *catchpointWriter) readDatabaseStep(ctx context.Context, tx *sql.Tx) (err error) {
 	cw.balancesChunk.Balances, err = cw.accountsIterator.Next(ctx, tx, BalancesPerCatchpointFileChunk)
 	if err == nil {
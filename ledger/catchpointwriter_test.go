@@ -250,6 +250,25 @@ func TestFullCatchpointWriter(t *testing.T) {
 	err := au.loadFromDisk(ml)
 	require.NoError(t, err)
 	au.close()
+
+	// add enough key/value entries to span several chunks
+	kvs := make(map[string][]byte)
+	for i := 0; i < KVsPerCatchpointFileChunk*2+1; i++ {
+		value := make([]byte, 16)
+		crypto.RandBytes(value)
+		kvs[boxKey(basics.AppIndex(i+1), "box")] = value
+	}
+	err = ml.trackerDB().wdb.Atomic(func(ctx context.Context, tx *sql.Tx) error {
+		for key, value := range kvs {
+			_, err := tx.ExecContext(ctx, "INSERT INTO kvstore(key, value) VALUES(?, ?)", []byte(key), value)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
 	fileName := filepath.Join(temporaryDirectroy, "15.catchpoint")
 	blocksRound := basics.Round(12345)
 	blockHeaderDigest := crypto.Hash([]byte{1, 2, 3})
@@ -328,4 +347,13 @@ func TestFullCatchpointWriter(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, acct, acctData)
 	}
+
+	// as should the key/value entries
+	require.Equal(t, uint64(len(kvs)), catchupProgress.ProcessedKVs)
+	for key, value := range kvs {
+		kvValue, ok, err := l.LookupKv(0, key)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, value, kvValue)
+	}
 }
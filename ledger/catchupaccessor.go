@@ -54,6 +54,10 @@ type CatchpointCatchupAccessor interface {
 	// ProgressStagingBalances deserialize the given bytes as a temporary staging balances
 	ProgressStagingBalances(ctx context.Context, sectionName string, bytes []byte, progress *CatchpointCatchupAccessorProgress) (err error)
 
+	// ResumeStagingBalances restores the progress of an interrupted processing of the catchpoint file, and returns the names
+	// of the sections which were already processed.
+	ResumeStagingBalances(ctx context.Context, progress *CatchpointCatchupAccessorProgress) (processedSections []string, err error)
+
 	// GetCatchupBlockRound returns the latest block round matching the current catchpoint
 	GetCatchupBlockRound(ctx context.Context) (round basics.Round, err error)
 
@@ -223,11 +227,17 @@ type CatchpointCatchupAccessorProgress struct {
 	ProcessedAccounts uint64
 	ProcessedBytes    uint64
 	TotalChunks       uint64
+	ProcessedKVs      uint64
 	SeenHeader        bool
 
 	// Having the cachedTrie here would help to accelerate the catchup process since the trie maintain an internal cache of nodes.
 	// While rebuilding the trie, we don't want to force and reload (some) of these nodes into the cache for each catchpoint file chunk.
 	cachedTrie *merkletrie.Trie
+
+	// pendingSections are the balances sections processed since the cachedTrie was last committed. They are recorded as
+	// processed along with the next commit, since their accounts would be missing from the trie if the catchup got
+	// interrupted before it.
+	pendingSections []catchpointStagingSection
 }
 
 // ProgressStagingBalances deserialize the given bytes as a temporary staging balances
@@ -236,11 +246,55 @@ func (c *CatchpointCatchupAccessorImpl) ProgressStagingBalances(ctx context.Cont
 		return c.processStagingContent(ctx, bytes, progress)
 	}
 	if strings.HasPrefix(sectionName, "balances.") && strings.HasSuffix(sectionName, ".msgpack") {
-		return c.processStagingBalances(ctx, bytes, progress)
+		return c.processStagingBalances(ctx, sectionName, bytes, progress)
+	}
+	if strings.HasPrefix(sectionName, "kvs.") && strings.HasSuffix(sectionName, ".msgpack") {
+		return c.processStagingKVs(ctx, sectionName, bytes, progress)
 	}
 	// we want to allow undefined sections to support backward compatibility.
 	c.log.Warnf("CatchpointCatchupAccessorImpl::ProgressStagingBalances encountered unexpected section name '%s' of length %d, which would be ignored", sectionName, len(bytes))
-	return nil
+	// the section is still recorded as processed, so that it isn't downloaded again if the catchup is resumed.
+	wdb := c.ledger.trackerDB().wdb
+	return wdb.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
+		return writeCatchpointStagingSections(ctx, tx, []catchpointStagingSection{{name: sectionName}})
+	})
+}
+
+// ResumeStagingBalances restores the progress of an interrupted processing of the catchpoint file, and returns the names
+// of the sections which were already processed. The balances sections processed after the last commit of the staging trie
+// aren't included, and need to be processed again.
+func (c *CatchpointCatchupAccessorImpl) ResumeStagingBalances(ctx context.Context, progress *CatchpointCatchupAccessorProgress) (processedSections []string, err error) {
+	rdb := c.ledger.trackerDB().rdb
+	var sections []catchpointStagingSection
+	err = rdb.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
+		sections, err = readCatchpointStagingSections(ctx, tx)
+		return
+	})
+	if err != nil {
+		return nil, fmt.Errorf("CatchpointCatchupAccessorImpl::ResumeStagingBalances: unable to read the processed sections: %v", err)
+	}
+
+	*progress = CatchpointCatchupAccessorProgress{}
+	for _, section := range sections {
+		if section.name == "content.msgpack" {
+			var fileHeader CatchpointFileHeader
+			err = protocol.Decode(section.header, &fileHeader)
+			if err != nil {
+				return nil, fmt.Errorf("CatchpointCatchupAccessorImpl::ResumeStagingBalances: unable to decode the catchpoint file header: %v", err)
+			}
+			progress.SeenHeader = true
+			progress.TotalAccounts = fileHeader.TotalAccounts
+			progress.TotalChunks = fileHeader.TotalChunks
+		}
+		progress.ProcessedAccounts += section.accounts
+		progress.ProcessedKVs += section.kvs
+		progress.ProcessedBytes += section.bytes
+		processedSections = append(processedSections, section.name)
+	}
+	if progress.SeenHeader && progress.ProcessedAccounts < progress.TotalAccounts {
+		c.ledger.setSynchronousMode(ctx, c.ledger.accountsRebuildSynchronousMode)
+	}
+	return processedSections, nil
 }
 
 // processStagingContent deserialize the given bytes as a temporary staging balances content
@@ -274,7 +328,10 @@ func (c *CatchpointCatchupAccessorImpl) processStagingContent(ctx context.Contex
 			return fmt.Errorf("CatchpointCatchupAccessorImpl::processStagingContent: unable to write catchpoint catchup state '%s': %v", catchpointStateCatchupBlockRound, err)
 		}
 		err = accountsPutTotals(tx, fileHeader.Totals, true)
-		return
+		if err != nil {
+			return
+		}
+		return writeCatchpointStagingSections(ctx, tx, []catchpointStagingSection{{name: "content.msgpack", header: bytes}})
 	})
 	ledgerProcessstagingcontentMicros.AddMicrosecondsSince(start, nil)
 	if err == nil {
@@ -287,7 +344,7 @@ func (c *CatchpointCatchupAccessorImpl) processStagingContent(ctx context.Contex
 }
 
 // processStagingBalances deserialize the given bytes as a temporary staging balances
-func (c *CatchpointCatchupAccessorImpl) processStagingBalances(ctx context.Context, bytes []byte, progress *CatchpointCatchupAccessorProgress) (err error) {
+func (c *CatchpointCatchupAccessorImpl) processStagingBalances(ctx context.Context, sectionName string, bytes []byte, progress *CatchpointCatchupAccessorProgress) (err error) {
 	if !progress.SeenHeader {
 		return fmt.Errorf("CatchpointCatchupAccessorImpl::processStagingBalances: content chunk was missing")
 	}
@@ -306,6 +363,12 @@ func (c *CatchpointCatchupAccessorImpl) processStagingBalances(ctx context.Conte
 	wdb := c.ledger.trackerDB().wdb
 	start := time.Now()
 	ledgerProcessstagingbalancesCount.Inc(nil)
+	pendingSections := append(progress.pendingSections[:len(progress.pendingSections):len(progress.pendingSections)], catchpointStagingSection{
+		name:     sectionName,
+		accounts: uint64(len(balances.Balances)),
+		bytes:    uint64(len(bytes)),
+	})
+	committed := false
 	err = wdb.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
 		// create the merkle trie for the balances
 		var mc *merkleCommitter
@@ -366,13 +429,22 @@ func (c *CatchpointCatchupAccessorImpl) processStagingBalances(ctx context.Conte
 		}
 
 		// periodically, perform commit & evict to flush it to the disk and rebalance the cache memory utilization.
+		committed = progress.commitDue(uint64(len(balances.Balances)))
 		err = progress.EvictAsNeeded(uint64(len(balances.Balances)))
-		return
+		if err != nil || !committed {
+			return
+		}
+		// the accounts of the pending sections are now part of the committed trie.
+		return writeCatchpointStagingSections(ctx, tx, pendingSections)
 	})
 	ledgerProcessstagingbalancesMicros.AddMicrosecondsSince(start, nil)
 	if err == nil {
 		progress.ProcessedAccounts += uint64(len(balances.Balances))
 		progress.ProcessedBytes += uint64(len(bytes))
+		progress.pendingSections = pendingSections
+		if committed {
+			progress.pendingSections = nil
+		}
 	}
 	// not strictly required, but clean up the pointer in case of either a failure or when we're done.
 	if err != nil || progress.ProcessedAccounts == progress.TotalAccounts {
@@ -383,19 +455,92 @@ func (c *CatchpointCatchupAccessorImpl) processStagingBalances(ctx context.Conte
 	return err
 }
 
+// processStagingKVs deserialize the given bytes as a temporary staging key/value store chunk. The key/value
+// sections follow all the balances sections, so the trie is committed as each of them is processed.
+func (c *CatchpointCatchupAccessorImpl) processStagingKVs(ctx context.Context, sectionName string, bytes []byte, progress *CatchpointCatchupAccessorProgress) (err error) {
+	if !progress.SeenHeader {
+		return fmt.Errorf("CatchpointCatchupAccessorImpl::processStagingKVs: content chunk was missing")
+	}
+	if progress.ProcessedAccounts != progress.TotalAccounts {
+		return fmt.Errorf("CatchpointCatchupAccessorImpl::processStagingKVs: key/value chunk received before all the accounts were processed")
+	}
+
+	var kvs catchpointFileKVsChunk
+	err = protocol.DecodeReflect(bytes, &kvs)
+	if err != nil {
+		return err
+	}
+
+	if len(kvs.KVs) == 0 {
+		return fmt.Errorf("processStagingKVs received a chunk with no key/value entries")
+	}
+
+	wdb := c.ledger.trackerDB().wdb
+	err = wdb.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
+		var mc *merkleCommitter
+		mc, err = makeMerkleCommitter(tx, true)
+		if err != nil {
+			return
+		}
+
+		var trie *merkletrie.Trie
+		trie, err = merkletrie.MakeTrie(mc, trieCachedNodesCount)
+		if err != nil {
+			return
+		}
+
+		err = writeCatchpointStagingKVs(ctx, tx, kvs.KVs)
+		if err != nil {
+			return
+		}
+
+		for _, kv := range kvs.KVs {
+			hash := kvHashBuilder(string(kv.Key), kv.Value)
+			var added bool
+			added, err = trie.Add(hash)
+			if err != nil {
+				return
+			}
+			if !added {
+				return fmt.Errorf("CatchpointCatchupAccessorImpl::processStagingKVs: The provided catchpoint file contained the same key/value entry more than once. Key %#x, hash '%s'", kv.Key, hex.EncodeToString(hash))
+			}
+		}
+
+		_, err = trie.Evict(true)
+		if err != nil {
+			return
+		}
+		return writeCatchpointStagingSections(ctx, tx, []catchpointStagingSection{{
+			name:  sectionName,
+			kvs:   uint64(len(kvs.KVs)),
+			bytes: uint64(len(bytes)),
+		}})
+	})
+	if err == nil {
+		progress.ProcessedKVs += uint64(len(kvs.KVs))
+		progress.ProcessedBytes += uint64(len(bytes))
+	}
+	return err
+}
+
 // EvictAsNeeded calls Evict on the cachedTrie periodically, or once we're done updating the trie.
 func (progress *CatchpointCatchupAccessorProgress) EvictAsNeeded(balancesCount uint64) (err error) {
 	if progress.cachedTrie == nil {
 		return nil
 	}
 	// periodically, perform commit & evict to flush it to the disk and rebalance the cache memory utilization.
-	if (progress.ProcessedAccounts/trieRebuildCommitFrequency) < ((progress.ProcessedAccounts+balancesCount)/trieRebuildCommitFrequency) ||
-		(progress.ProcessedAccounts+balancesCount) == progress.TotalAccounts {
+	if progress.commitDue(balancesCount) {
 		_, err = progress.cachedTrie.Evict(true)
 	}
 	return
 }
 
+// commitDue returns whether the cachedTrie is to be committed once the given number of accounts are added to it.
+func (progress *CatchpointCatchupAccessorProgress) commitDue(balancesCount uint64) bool {
+	return (progress.ProcessedAccounts/trieRebuildCommitFrequency) < ((progress.ProcessedAccounts+balancesCount)/trieRebuildCommitFrequency) ||
+		(progress.ProcessedAccounts+balancesCount) == progress.TotalAccounts
+}
+
 // GetCatchupBlockRound returns the latest block round matching the current catchpoint
 func (c *CatchpointCatchupAccessorImpl) GetCatchupBlockRound(ctx context.Context) (round basics.Round, err error) {
 	var iRound uint64
@@ -130,3 +130,78 @@ func BenchmarkRestoringFromCatchpointFile(b *testing.B) {
 		})
 	}
 }
+
+func TestCatchupAccessorResumeStagingBalances(t *testing.T) {
+	genesisInitState, _ := testGenerateInitState(t, protocol.ConsensusCurrentVersion)
+	log := logging.TestingLog(t)
+	cfg := config.GetDefaultLocal()
+	cfg.Archival = false
+	l, err := OpenLedger(log, t.Name(), true, genesisInitState, cfg)
+	require.NoError(t, err, "could not open ledger")
+	defer l.Close()
+
+	ctx := context.Background()
+	catchpointAccessor := MakeCatchpointCatchupAccessor(l, log)
+	require.NoError(t, catchpointAccessor.ResetStagingBalances(ctx, true))
+
+	const chunksCount = 3
+	const chunkSize = 10
+	fileHeader := CatchpointFileHeader{
+		Version:       catchpointFileVersion,
+		TotalAccounts: chunksCount * chunkSize,
+		TotalChunks:   chunksCount,
+	}
+	var progress CatchpointCatchupAccessorProgress
+	err = catchpointAccessor.ProgressStagingBalances(ctx, "content.msgpack", protocol.Encode(&fileHeader), &progress)
+	require.NoError(t, err)
+
+	chunkNames := make([]string, chunksCount)
+	encodedAccountChunks := make([][]byte, chunksCount)
+	for i := range encodedAccountChunks {
+		var balances catchpointFileBalancesChunk
+		balances.Balances = make([]encodedBalanceRecord, chunkSize)
+		for j := range balances.Balances {
+			accountData := basics.AccountData{}
+			accountData.MicroAlgos.Raw = crypto.RandUint63()
+			balances.Balances[j].AccountData = protocol.Encode(&accountData)
+			crypto.RandBytes(balances.Balances[j].Address[:])
+		}
+		chunkNames[i] = fmt.Sprintf("balances.%d.%d.msgpack", i+1, chunksCount)
+		encodedAccountChunks[i] = protocol.Encode(&balances)
+	}
+
+	// the trie isn't committed before the last chunk is processed, so only the header is resumed.
+	for i := 0; i < chunksCount-1; i++ {
+		err = catchpointAccessor.ProgressStagingBalances(ctx, chunkNames[i], encodedAccountChunks[i], &progress)
+		require.NoError(t, err)
+	}
+	var resumed CatchpointCatchupAccessorProgress
+	processedSections, err := catchpointAccessor.ResumeStagingBalances(ctx, &resumed)
+	require.NoError(t, err)
+	require.Equal(t, []string{"content.msgpack"}, processedSections)
+	require.True(t, resumed.SeenHeader)
+	require.Equal(t, fileHeader.TotalAccounts, resumed.TotalAccounts)
+	require.Equal(t, fileHeader.TotalChunks, resumed.TotalChunks)
+	require.Zero(t, resumed.ProcessedAccounts)
+
+	// the chunks which were processed before are processed again once resumed.
+	for i := 0; i < chunksCount; i++ {
+		err = catchpointAccessor.ProgressStagingBalances(ctx, chunkNames[i], encodedAccountChunks[i], &resumed)
+		require.NoError(t, err)
+	}
+	processedSections, err = catchpointAccessor.ResumeStagingBalances(ctx, &resumed)
+	require.NoError(t, err)
+	require.Equal(t, append([]string{"content.msgpack"}, chunkNames...), processedSections)
+	require.Equal(t, fileHeader.TotalAccounts, resumed.ProcessedAccounts)
+
+	// a chunk seen twice is still rejected.
+	err = catchpointAccessor.ProgressStagingBalances(ctx, chunkNames[0], encodedAccountChunks[0], &resumed)
+	require.Error(t, err)
+
+	// nothing is resumed once the staging balances are reset.
+	require.NoError(t, catchpointAccessor.ResetStagingBalances(ctx, true))
+	processedSections, err = catchpointAccessor.ResumeStagingBalances(ctx, &resumed)
+	require.NoError(t, err)
+	require.Empty(t, processedSections)
+	require.False(t, resumed.SeenHeader)
+}
@@ -39,6 +39,7 @@ type roundCowParent interface {
 	getCreator(cidx basics.CreatableIndex, ctype basics.CreatableType) (basics.Address, bool, error)
 	compactCertLast() basics.Round
 	blockHdr(rnd basics.Round) (bookkeeping.BlockHeader, error)
+	getKv(key string) ([]byte, bool, error)
 }
 
 type roundCowState struct {
@@ -56,12 +57,19 @@ type StateDelta struct {
 	// new Txids for the txtail and TxnCounter, mapped to txn.LastValid
 	Txids map[transactions.Txid]basics.Round
 
+	// number of inner transactions issued by applications, which count
+	// towards the TxnCounter but are not tracked in the txtail
+	innerTxnCount uint64
+
 	// new txleases for the txtail mapped to expiration
 	txleases map[txlease]basics.Round
 
 	// new creatables creator lookup table
 	creatables map[basics.CreatableIndex]modifiedCreatable
 
+	// modified entries of the key/value store that holds application boxes
+	kvMods map[string]kvValueDelta
+
 	// new block header; read-only
 	hdr *bookkeeping.BlockHeader
 
@@ -70,6 +78,16 @@ type StateDelta struct {
 	compactCertSeen basics.Round
 }
 
+// kvValueDelta records the change of a key in the key/value store. The old
+// value is kept so that the change can be backed out of the balances trie;
+// oldExists and newExists tell a missing key apart from an empty value.
+type kvValueDelta struct {
+	old       []byte
+	oldExists bool
+	new       []byte
+	newExists bool
+}
+
 func makeRoundCowState(b roundCowParent, hdr bookkeeping.BlockHeader) *roundCowState {
 	return &roundCowState{
 		lookupParent: b,
@@ -80,6 +98,7 @@ func makeRoundCowState(b roundCowParent, hdr bookkeeping.BlockHeader) *roundCowS
 			Txids:      make(map[transactions.Txid]basics.Round),
 			txleases:   make(map[txlease]basics.Round),
 			creatables: make(map[basics.CreatableIndex]modifiedCreatable),
+			kvMods:     make(map[string]kvValueDelta),
 			hdr:        &hdr,
 		},
 	}
@@ -109,6 +128,14 @@ func (cb *roundCowState) lookup(addr basics.Address) (data basics.AccountData, e
 	return cb.lookupParent.lookup(addr)
 }
 
+func (cb *roundCowState) getKv(key string) (value []byte, ok bool, err error) {
+	delta, ok := cb.mods.kvMods[key]
+	if ok {
+		return delta.new, delta.newExists, nil
+	}
+	return cb.lookupParent.getKv(key)
+}
+
 func (cb *roundCowState) isDup(firstValid, lastValid basics.Round, txid transactions.Txid, txl txlease) (bool, error) {
 	_, present := cb.mods.Txids[txid]
 	if present {
@@ -126,7 +153,7 @@ func (cb *roundCowState) isDup(firstValid, lastValid basics.Round, txid transact
 }
 
 func (cb *roundCowState) txnCounter() uint64 {
-	return cb.lookupParent.txnCounter() + uint64(len(cb.mods.Txids))
+	return cb.lookupParent.txnCounter() + uint64(len(cb.mods.Txids)) + cb.mods.innerTxnCount
 }
 
 func (cb *roundCowState) compactCertLast() basics.Round {
@@ -165,11 +192,38 @@ func (cb *roundCowState) put(addr basics.Address, old basics.AccountData, new ba
 	}
 }
 
+func (cb *roundCowState) setKv(key string, value []byte) error {
+	return cb.modifyKv(key, value, true)
+}
+
+func (cb *roundCowState) delKv(key string) error {
+	return cb.modifyKv(key, nil, false)
+}
+
+func (cb *roundCowState) modifyKv(key string, value []byte, exists bool) error {
+	delta, present := cb.mods.kvMods[key]
+	if !present {
+		old, oldExists, err := cb.lookupParent.getKv(key)
+		if err != nil {
+			return err
+		}
+		delta = kvValueDelta{old: old, oldExists: oldExists}
+	}
+	delta.new = value
+	delta.newExists = exists
+	cb.mods.kvMods[key] = delta
+	return nil
+}
+
 func (cb *roundCowState) addTx(txn transactions.Transaction, txid transactions.Txid) {
 	cb.mods.Txids[txid] = txn.LastValid
 	cb.mods.txleases[txlease{sender: txn.Sender, lease: txn.Lease}] = txn.LastValid
 }
 
+func (cb *roundCowState) addInnerTx() {
+	cb.mods.innerTxnCount++
+}
+
 func (cb *roundCowState) sawCompactCert(rnd basics.Round) {
 	cb.mods.compactCertSeen = rnd
 }
@@ -184,6 +238,7 @@ func (cb *roundCowState) child() *roundCowState {
 			Txids:      make(map[transactions.Txid]basics.Round),
 			txleases:   make(map[txlease]basics.Round),
 			creatables: make(map[basics.CreatableIndex]modifiedCreatable),
+			kvMods:     make(map[string]kvValueDelta),
 			hdr:        cb.mods.hdr,
 		},
 	}
@@ -205,12 +260,21 @@ func (cb *roundCowState) commitToParent() {
 	for txid, lv := range cb.mods.Txids {
 		cb.commitParent.mods.Txids[txid] = lv
 	}
+	cb.commitParent.mods.innerTxnCount += cb.mods.innerTxnCount
 	for txl, expires := range cb.mods.txleases {
 		cb.commitParent.mods.txleases[txl] = expires
 	}
 	for cidx, delta := range cb.mods.creatables {
 		cb.commitParent.mods.creatables[cidx] = delta
 	}
+	for key, delta := range cb.mods.kvMods {
+		prev, present := cb.commitParent.mods.kvMods[key]
+		if present {
+			delta.old = prev.old
+			delta.oldExists = prev.oldExists
+		}
+		cb.commitParent.mods.kvMods[key] = delta
+	}
 	cb.commitParent.mods.compactCertSeen = cb.mods.compactCertSeen
 }
 
@@ -28,6 +28,7 @@ import (
 
 type mockLedger struct {
 	balanceMap map[basics.Address]basics.AccountData
+	kvMap      map[string][]byte
 }
 
 func (ml *mockLedger) lookup(addr basics.Address) (basics.AccountData, error) {
@@ -62,6 +63,11 @@ func (ml *mockLedger) blockHdr(_ basics.Round) (bookkeeping.BlockHeader, error)
 	return bookkeeping.BlockHeader{}, nil
 }
 
+func (ml *mockLedger) getKv(key string) ([]byte, bool, error) {
+	value, ok := ml.kvMap[key]
+	return value, ok, nil
+}
+
 func checkCow(t *testing.T, cow *roundCowState, accts map[basics.Address]basics.AccountData) {
 	for addr, data := range accts {
 		d, err := cow.lookup(addr)
@@ -114,3 +120,82 @@ func TestCowBalance(t *testing.T) {
 	c1.commitToParent()
 	checkCow(t, c0, accts2)
 }
+
+func TestCowTxnCounter(t *testing.T) {
+	ml := mockLedger{balanceMap: map[basics.Address]basics.AccountData{}}
+
+	c0 := makeRoundCowState(&ml, bookkeeping.BlockHeader{})
+	require.Equal(t, uint64(0), c0.txnCounter())
+
+	c0.addTx(transactions.Transaction{}, transactions.Txid{1})
+	require.Equal(t, uint64(1), c0.txnCounter())
+
+	// inner transactions count towards the counter of the child and,
+	// once committed, of the parent
+	c1 := c0.child()
+	c1.addInnerTx()
+	c1.addInnerTx()
+	c1.addTx(transactions.Transaction{}, transactions.Txid{2})
+	require.Equal(t, uint64(1), c0.txnCounter())
+	require.Equal(t, uint64(4), c1.txnCounter())
+
+	c1.commitToParent()
+	require.Equal(t, uint64(4), c0.txnCounter())
+
+	// uncommitted children leave the parent untouched
+	c2 := c0.child()
+	c2.addInnerTx()
+	require.Equal(t, uint64(5), c2.txnCounter())
+	require.Equal(t, uint64(4), c0.txnCounter())
+}
+
+func TestCowKv(t *testing.T) {
+	ml := mockLedger{
+		balanceMap: map[basics.Address]basics.AccountData{},
+		kvMap:      map[string][]byte{"a": []byte("base"), "b": []byte("gone")},
+	}
+
+	checkKv := func(cow *roundCowState, key string, value string, exists bool) {
+		v, ok, err := cow.getKv(key)
+		require.NoError(t, err)
+		require.Equal(t, exists, ok)
+		if exists {
+			require.Equal(t, value, string(v))
+		}
+	}
+
+	c0 := makeRoundCowState(&ml, bookkeeping.BlockHeader{})
+	checkKv(c0, "a", "base", true)
+	checkKv(c0, "c", "", false)
+
+	c1 := c0.child()
+	require.NoError(t, c1.setKv("a", []byte("new")))
+	require.NoError(t, c1.setKv("c", []byte("created")))
+	require.NoError(t, c1.delKv("b"))
+	checkKv(c0, "a", "base", true)
+	checkKv(c0, "b", "gone", true)
+	checkKv(c0, "c", "", false)
+	checkKv(c1, "a", "new", true)
+	checkKv(c1, "b", "", false)
+	checkKv(c1, "c", "created", true)
+
+	// a deletion in a grandchild shadows a creation in its parent
+	c2 := c1.child()
+	require.NoError(t, c2.delKv("c"))
+	checkKv(c1, "c", "created", true)
+	checkKv(c2, "c", "", false)
+
+	c2.commitToParent()
+	checkKv(c1, "c", "", false)
+
+	c1.commitToParent()
+	checkKv(c0, "a", "new", true)
+	checkKv(c0, "b", "", false)
+	checkKv(c0, "c", "", false)
+
+	// the deltas keep the values from before the first modification
+	require.Len(t, c0.mods.kvMods, 3)
+	require.Equal(t, kvValueDelta{old: []byte("base"), oldExists: true, new: []byte("new"), newExists: true}, c0.mods.kvMods["a"])
+	require.Equal(t, kvValueDelta{old: []byte("gone"), oldExists: true}, c0.mods.kvMods["b"])
+	require.Equal(t, kvValueDelta{}, c0.mods.kvMods["c"])
+}
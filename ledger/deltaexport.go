@@ -0,0 +1,273 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package ledger
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/logging"
+)
+
+// ExportedStateDeltaVersion is the current version of the ExportedStateDelta encoding. It needs to be
+// incremented whenever the structure of ExportedStateDelta changes in a non backward compatible way.
+const ExportedStateDeltaVersion = 1
+
+// ExportedStateDelta is the versioned, serialization friendly representation of the StateDelta
+// of a single round. It is intended to be consumed by out-of-process consumers which need to
+// track the exact state changes applied by each round.
+type ExportedStateDelta struct {
+	// Version is the encoding version of this delta; see ExportedStateDeltaVersion.
+	Version uint64 `codec:"v"`
+
+	// Round is the round for which this delta was generated.
+	Round basics.Round `codec:"rnd"`
+
+	// Accounts contains the modified accounts, sorted by address.
+	Accounts []ExportedAccountDelta `codec:"accts"`
+
+	// Creatables contains the created or deleted assets and applications, sorted by index.
+	Creatables []ExportedCreatableDelta `codec:"crtbls"`
+
+	// Totals are the account totals at the end of the round.
+	Totals AccountTotals `codec:"totals"`
+}
+
+// ExportedAccountDelta describes the changes made to a single account during a round.
+type ExportedAccountDelta struct {
+	// Address is the address of the modified account.
+	Address basics.Address `codec:"addr"`
+
+	// Data is the account data at the end of the round. A closed account would have an empty Data.
+	Data basics.AccountData `codec:"data"`
+
+	// AppLocalDeltas describes the changes made to the key/value stores of the local states of this account.
+	AppLocalDeltas map[basics.AppIndex]basics.StateDelta `codec:"ld"`
+
+	// AppGlobalDeltas describes the changes made to the global key/value stores of the applications created by this account.
+	AppGlobalDeltas map[basics.AppIndex]basics.StateDelta `codec:"gd"`
+}
+
+// ExportedCreatableDelta describes the creation or deletion of an asset or an application.
+type ExportedCreatableDelta struct {
+	// Index is the asset or application index.
+	Index basics.CreatableIndex `codec:"idx"`
+
+	// Type specifies whether the creatable is an asset or an application.
+	Type basics.CreatableType `codec:"type"`
+
+	// Creator is the address of the account which created the creatable.
+	Creator basics.Address `codec:"creator"`
+
+	// Created is true if the creatable was created on this round, and false if it was deleted.
+	Created bool `codec:"created"`
+}
+
+// StateDeltaUnavailableError is returned when the exported state delta for a given round is not available.
+type StateDeltaUnavailableError struct {
+	Round    basics.Round
+	Earliest basics.Round
+	Latest   basics.Round
+}
+
+// Error satisfies builtin interface `error`
+func (err StateDeltaUnavailableError) Error() string {
+	if err.Latest == 0 {
+		return fmt.Sprintf("state delta for round %d is not available; no state deltas have been exported", err.Round)
+	}
+	return fmt.Sprintf("state delta for round %d is not available (earliest %d, latest %d)", err.Round, err.Earliest, err.Latest)
+}
+
+// stateDeltaExporter is a ledger tracker which retains the exported state deltas of the most recent rounds.
+// The exported deltas are kept in memory only; once the ledger is reloaded, the exporter starts
+// accumulating the deltas from the next round onward.
+type stateDeltaExporter struct {
+	// retainRounds is the number of most recent rounds for which we retain the exported state delta.
+	// A value of zero disables the exporter.
+	retainRounds uint64
+
+	// accts is used to retrieve the account totals of the exported rounds.
+	accts *accountUpdates
+
+	// deltas contains the exported state deltas of consecutive rounds, ordered by round.
+	deltas []ExportedStateDelta
+
+	log logging.Logger
+}
+
+// initialize initializes the stateDeltaExporter structure
+func (sde *stateDeltaExporter) initialize(cfg config.Local, accts *accountUpdates) {
+	sde.retainRounds = cfg.StateDeltaExportRounds
+	sde.accts = accts
+}
+
+func (sde *stateDeltaExporter) loadFromDisk(l ledgerForTracker) error {
+	sde.log = l.trackerLog()
+	sde.deltas = nil
+	return nil
+}
+
+func (sde *stateDeltaExporter) close() {
+}
+
+func (sde *stateDeltaExporter) newBlock(blk bookkeeping.Block, delta StateDelta) {
+	if sde.retainRounds == 0 {
+		return
+	}
+
+	rnd := blk.Round()
+	if len(sde.deltas) > 0 && sde.deltas[len(sde.deltas)-1].Round+1 != rnd {
+		// the rounds are no longer consecutive ( i.e. the ledger was reset during catchpoint catchup );
+		// the previously exported deltas are no longer relevant.
+		sde.deltas = nil
+	}
+
+	exported, err := sde.exportDelta(rnd, delta)
+	if err != nil {
+		sde.log.Warnf("stateDeltaExporter: unable to export state delta for round %d : %v", rnd, err)
+		sde.deltas = nil
+		return
+	}
+
+	sde.deltas = append(sde.deltas, exported)
+	if uint64(len(sde.deltas)) > sde.retainRounds {
+		sde.deltas = sde.deltas[uint64(len(sde.deltas))-sde.retainRounds:]
+	}
+}
+
+func (sde *stateDeltaExporter) committedUpTo(rnd basics.Round) basics.Round {
+	return rnd
+}
+
+// exportDelta converts the given StateDelta into an ExportedStateDelta
+func (sde *stateDeltaExporter) exportDelta(rnd basics.Round, delta StateDelta) (ExportedStateDelta, error) {
+	totals, err := sde.accts.Totals(rnd)
+	if err != nil {
+		return ExportedStateDelta{}, err
+	}
+	return makeExportedStateDelta(rnd, delta, totals), nil
+}
+
+// makeExportedStateDelta converts the given StateDelta of round rnd, with the given account totals
+// at the end of the round, into an ExportedStateDelta
+func makeExportedStateDelta(rnd basics.Round, delta StateDelta, totals AccountTotals) (exported ExportedStateDelta) {
+	exported.Version = ExportedStateDeltaVersion
+	exported.Round = rnd
+	exported.Totals = totals
+
+	exported.Accounts = make([]ExportedAccountDelta, 0, len(delta.accts))
+	for addr, acctDelta := range delta.accts {
+		exported.Accounts = append(exported.Accounts, ExportedAccountDelta{
+			Address:         addr,
+			Data:            acctDelta.new,
+			AppLocalDeltas:  appLocalStatesDelta(acctDelta.old.AppLocalStates, acctDelta.new.AppLocalStates),
+			AppGlobalDeltas: appGlobalStatesDelta(acctDelta.old.AppParams, acctDelta.new.AppParams),
+		})
+	}
+	sort.Slice(exported.Accounts, func(i, j int) bool {
+		return bytes.Compare(exported.Accounts[i].Address[:], exported.Accounts[j].Address[:]) < 0
+	})
+
+	exported.Creatables = make([]ExportedCreatableDelta, 0, len(delta.creatables))
+	for cidx, creatableDelta := range delta.creatables {
+		exported.Creatables = append(exported.Creatables, ExportedCreatableDelta{
+			Index:   cidx,
+			Type:    creatableDelta.ctype,
+			Creator: creatableDelta.creator,
+			Created: creatableDelta.created,
+		})
+	}
+	sort.Slice(exported.Creatables, func(i, j int) bool {
+		return exported.Creatables[i].Index < exported.Creatables[j].Index
+	})
+	return
+}
+
+// lookup returns the exported state delta for the given round
+func (sde *stateDeltaExporter) lookup(rnd basics.Round) (ExportedStateDelta, error) {
+	if len(sde.deltas) == 0 {
+		return ExportedStateDelta{}, StateDeltaUnavailableError{Round: rnd}
+	}
+	earliest := sde.deltas[0].Round
+	latest := sde.deltas[len(sde.deltas)-1].Round
+	if rnd < earliest || rnd > latest {
+		return ExportedStateDelta{}, StateDeltaUnavailableError{Round: rnd, Earliest: earliest, Latest: latest}
+	}
+	return sde.deltas[rnd-earliest], nil
+}
+
+// appLocalStatesDelta returns the key/value changes between the old and new local states of each of the applications.
+func appLocalStatesDelta(old, new map[basics.AppIndex]basics.AppLocalState) (deltas map[basics.AppIndex]basics.StateDelta) {
+	for aidx, newState := range new {
+		deltas = addTealKeyValueDelta(deltas, aidx, old[aidx].KeyValue, newState.KeyValue)
+	}
+	for aidx, oldState := range old {
+		if _, ok := new[aidx]; !ok {
+			deltas = addTealKeyValueDelta(deltas, aidx, oldState.KeyValue, nil)
+		}
+	}
+	return
+}
+
+// appGlobalStatesDelta returns the key/value changes between the old and new global states of each of the applications.
+func appGlobalStatesDelta(old, new map[basics.AppIndex]basics.AppParams) (deltas map[basics.AppIndex]basics.StateDelta) {
+	for aidx, newParams := range new {
+		deltas = addTealKeyValueDelta(deltas, aidx, old[aidx].GlobalState, newParams.GlobalState)
+	}
+	for aidx, oldParams := range old {
+		if _, ok := new[aidx]; !ok {
+			deltas = addTealKeyValueDelta(deltas, aidx, oldParams.GlobalState, nil)
+		}
+	}
+	return
+}
+
+// addTealKeyValueDelta adds the StateDelta which transforms old into new to the given deltas map, allocating the map if needed.
+// Empty state deltas are not added to the map.
+func addTealKeyValueDelta(deltas map[basics.AppIndex]basics.StateDelta, aidx basics.AppIndex, old, new basics.TealKeyValue) map[basics.AppIndex]basics.StateDelta {
+	var stateDelta basics.StateDelta
+	for key, newValue := range new {
+		if oldValue, ok := old[key]; ok && oldValue == newValue {
+			continue
+		}
+		if stateDelta == nil {
+			stateDelta = make(basics.StateDelta)
+		}
+		stateDelta[key] = newValue.ToValueDelta()
+	}
+	for key := range old {
+		if _, ok := new[key]; ok {
+			continue
+		}
+		if stateDelta == nil {
+			stateDelta = make(basics.StateDelta)
+		}
+		stateDelta[key] = basics.ValueDelta{Action: basics.DeleteAction}
+	}
+	if len(stateDelta) == 0 {
+		return deltas
+	}
+	if deltas == nil {
+		deltas = make(map[basics.AppIndex]basics.StateDelta)
+	}
+	deltas[aidx] = stateDelta
+	return deltas
+}
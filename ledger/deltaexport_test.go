@@ -0,0 +1,138 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package ledger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+func TestAppStatesDelta(t *testing.T) {
+	a := require.New(t)
+
+	old := map[basics.AppIndex]basics.AppLocalState{
+		1: {KeyValue: basics.TealKeyValue{
+			"unchanged": {Type: basics.TealUintType, Uint: 1},
+			"modified":  {Type: basics.TealUintType, Uint: 2},
+			"deleted":   {Type: basics.TealBytesType, Bytes: "abc"},
+		}},
+		2: {KeyValue: basics.TealKeyValue{
+			"key": {Type: basics.TealUintType, Uint: 3},
+		}},
+		3: {KeyValue: basics.TealKeyValue{
+			"key": {Type: basics.TealUintType, Uint: 4},
+		}},
+	}
+	new := map[basics.AppIndex]basics.AppLocalState{
+		1: {KeyValue: basics.TealKeyValue{
+			"unchanged": {Type: basics.TealUintType, Uint: 1},
+			"modified":  {Type: basics.TealBytesType, Bytes: "xyz"},
+			"added":     {Type: basics.TealUintType, Uint: 5},
+		}},
+		3: {KeyValue: basics.TealKeyValue{
+			"key": {Type: basics.TealUintType, Uint: 4},
+		}},
+		4: {KeyValue: basics.TealKeyValue{
+			"key": {Type: basics.TealBytesType, Bytes: "new"},
+		}},
+	}
+
+	deltas := appLocalStatesDelta(old, new)
+	a.Equal(map[basics.AppIndex]basics.StateDelta{
+		1: {
+			"modified": {Action: basics.SetBytesAction, Bytes: "xyz"},
+			"added":    {Action: basics.SetUintAction, Uint: 5},
+			"deleted":  {Action: basics.DeleteAction},
+		},
+		2: {
+			"key": {Action: basics.DeleteAction},
+		},
+		4: {
+			"key": {Action: basics.SetBytesAction, Bytes: "new"},
+		},
+	}, deltas)
+
+	// no changes should yield a nil map
+	a.Nil(appLocalStatesDelta(old, old))
+	a.Nil(appGlobalStatesDelta(nil, map[basics.AppIndex]basics.AppParams{5: {}}))
+
+	globalDeltas := appGlobalStatesDelta(nil, map[basics.AppIndex]basics.AppParams{
+		5: {GlobalState: basics.TealKeyValue{"g": {Type: basics.TealUintType, Uint: 7}}},
+	})
+	a.Equal(map[basics.AppIndex]basics.StateDelta{
+		5: {"g": {Action: basics.SetUintAction, Uint: 7}},
+	}, globalDeltas)
+}
+
+func TestStateDeltaExporterLookup(t *testing.T) {
+	a := require.New(t)
+
+	var sde stateDeltaExporter
+	_, err := sde.lookup(1)
+	a.Error(err)
+	a.IsType(StateDeltaUnavailableError{}, err)
+
+	for rnd := basics.Round(10); rnd < 20; rnd++ {
+		sde.deltas = append(sde.deltas, ExportedStateDelta{Version: ExportedStateDeltaVersion, Round: rnd})
+	}
+
+	for rnd := basics.Round(10); rnd < 20; rnd++ {
+		delta, err := sde.lookup(rnd)
+		a.NoError(err)
+		a.Equal(rnd, delta.Round)
+	}
+
+	_, err = sde.lookup(9)
+	a.Equal(StateDeltaUnavailableError{Round: 9, Earliest: 10, Latest: 19}, err)
+	_, err = sde.lookup(20)
+	a.Equal(StateDeltaUnavailableError{Round: 20, Earliest: 10, Latest: 19}, err)
+}
+
+func TestExportedStateDeltaEncoding(t *testing.T) {
+	a := require.New(t)
+
+	var addr basics.Address
+	addr[0] = 1
+	delta := ExportedStateDelta{
+		Version: ExportedStateDeltaVersion,
+		Round:   5,
+		Accounts: []ExportedAccountDelta{
+			{
+				Address: addr,
+				Data:    basics.AccountData{MicroAlgos: basics.MicroAlgos{Raw: 100}},
+				AppLocalDeltas: map[basics.AppIndex]basics.StateDelta{
+					1: {"key": {Action: basics.SetUintAction, Uint: 2}},
+				},
+			},
+		},
+		Creatables: []ExportedCreatableDelta{
+			{Index: 1, Type: basics.AppCreatable, Creator: addr, Created: true},
+		},
+	}
+
+	var decoded ExportedStateDelta
+	a.NoError(protocol.DecodeReflect(protocol.EncodeReflect(delta), &decoded))
+	a.Equal(delta, decoded)
+
+	var decodedJSON ExportedStateDelta
+	a.NoError(protocol.DecodeJSON(protocol.EncodeJSON(delta), &decodedJSON))
+	a.Equal(delta, decodedJSON)
+}
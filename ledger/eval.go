@@ -91,6 +91,10 @@ func (x *roundCowBase) blockHdr(r basics.Round) (bookkeeping.BlockHeader, error)
 	return x.l.BlockHdr(r)
 }
 
+func (x *roundCowBase) getKv(key string) ([]byte, bool, error) {
+	return x.l.LookupKv(x.rnd, key)
+}
+
 // wrappers for roundCowState to satisfy the (current) apply.Balances interface
 func (cs *roundCowState) Get(addr basics.Address, withPendingRewards bool) (basics.BalanceRecord, error) {
 	acctdata, err := cs.lookup(addr)
@@ -107,6 +111,18 @@ func (cs *roundCowState) GetCreator(cidx basics.CreatableIndex, ctype basics.Cre
 	return cs.getCreator(cidx, ctype)
 }
 
+func (cs *roundCowState) GetBox(appIdx basics.AppIndex, name string) ([]byte, bool, error) {
+	return cs.getKv(boxKey(appIdx, name))
+}
+
+func (cs *roundCowState) PutBox(appIdx basics.AppIndex, name string, value []byte) error {
+	return cs.setKv(boxKey(appIdx, name), value)
+}
+
+func (cs *roundCowState) DelBox(appIdx basics.AppIndex, name string) error {
+	return cs.delKv(boxKey(appIdx, name))
+}
+
 func (cs *roundCowState) Put(record basics.BalanceRecord) error {
 	return cs.PutWithCreatable(record, nil, nil)
 }
@@ -213,6 +229,10 @@ type BlockEvaluator struct {
 
 	blockGenerated bool // prevent repeated GenerateBlock calls
 
+	// appCosts, when non-nil, collects the TEAL cost of each of the
+	// transactions in the group being evaluated; it is used for simulation
+	appCosts []int
+
 	l ledgerForEvaluator
 }
 
@@ -223,6 +243,7 @@ type ledgerForEvaluator interface {
 	isDup(config.ConsensusParams, basics.Round, basics.Round, basics.Round, transactions.Txid, txlease) (bool, error)
 	LookupWithoutRewards(basics.Round, basics.Address) (basics.AccountData, error)
 	GetCreatorForRound(basics.Round, basics.CreatableIndex, basics.CreatableType) (basics.Address, bool, error)
+	LookupKv(basics.Round, string) ([]byte, bool, error)
 	CompactCertVoters(basics.Round) (*VotersForRound, error)
 }
 
@@ -543,6 +564,10 @@ func (eval *BlockEvaluator) prepareAppEvaluators(txgroup []transactions.SignedTx
 			},
 		}
 
+		if eval.appCosts != nil {
+			steva.evalParams.Cost = &eval.appCosts[i]
+		}
+
 		res[i] = &steva
 	}
 	return
@@ -721,10 +746,13 @@ func (eval *BlockEvaluator) transaction(txn transactions.SignedTxn, appEval *app
 				txid, addr, dataNew.MicroAlgos.Raw, effectiveMinBalance.Raw, len(dataNew.Assets))
 		}
 
-		// Check if we have exceeded the maximum minimum balance
+		// Check if we have exceeded the maximum minimum balance. Boxes
+		// are stored outside of the balance record, so they are not
+		// limited by it.
 		if eval.proto.MaximumMinimumBalance != 0 {
-			if effectiveMinBalance.Raw > eval.proto.MaximumMinimumBalance {
-				return fmt.Errorf("transaction %v: account %v would use too much space after this transaction. Minimum balance requirements would be %d (greater than max %d)", txid, addr, effectiveMinBalance.Raw, eval.proto.MaximumMinimumBalance)
+			recordMinBalance := effectiveMinBalance.Raw - dataNew.BoxMinBalance(&eval.proto).Raw
+			if recordMinBalance > eval.proto.MaximumMinimumBalance {
+				return fmt.Errorf("transaction %v: account %v would use too much space after this transaction. Minimum balance requirements would be %d (greater than max %d)", txid, addr, recordMinBalance, eval.proto.MaximumMinimumBalance)
 			}
 		}
 	}
@@ -784,6 +812,9 @@ func applyTransaction(tx transactions.Transaction, balances *roundCowState, stev
 
 	case protocol.ApplicationCallTx:
 		err = apply.ApplicationCall(tx.ApplicationCallTxnFields, tx.Header, balances, &ad, ctr, steva)
+		if err == nil {
+			err = applyInnerTransactions(ad.InnerTxns, balances, spec)
+		}
 
 	case protocol.CompactCertTx:
 		err = balances.compactCert(tx.CertRound, tx.Cert, tx.Header.FirstValid)
@@ -803,6 +834,38 @@ func applyTransaction(tx transactions.Transaction, balances *roundCowState, stev
 	return
 }
 
+// applyInnerTransactions executes the inner transactions issued by an
+// application, in order, filling in the ApplyData of each one. Every inner
+// transaction advances the TxnCounter, so that assets created by them get
+// distinct indices.
+func applyInnerTransactions(itxns []transactions.SignedTxnWithAD, balances *roundCowState, spec transactions.SpecialAddresses) error {
+	params := balances.ConsensusParams()
+	if len(itxns) > params.MaxInnerTransactions {
+		return fmt.Errorf("too many inner transactions: %d > %d", len(itxns), params.MaxInnerTransactions)
+	}
+
+	for i := range itxns {
+		itx := &itxns[i].SignedTxn.Txn
+		switch itx.Type {
+		case protocol.PaymentTx, protocol.AssetTransferTx, protocol.AssetConfigTx:
+		default:
+			return fmt.Errorf("inner transaction %d: type %s not allowed", i, itx.Type)
+		}
+
+		err := itx.WellFormed(spec, params)
+		if err != nil {
+			return fmt.Errorf("inner transaction %d: %v", i, err)
+		}
+
+		balances.addInnerTx()
+		itxns[i].ApplyData, err = applyTransaction(*itx, balances, nil, spec, balances.txnCounter())
+		if err != nil {
+			return fmt.Errorf("inner transaction %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
 // compactCertVotersAndTotal returns the expected values of CompactCertVoters
 // and CompactCertVotersTotal for a block.
 func (eval *BlockEvaluator) compactCertVotersAndTotal() (root crypto.Digest, total basics.MicroAlgos, err error) {
@@ -29,6 +29,7 @@ import (
 	"github.com/algorand/go-algorand/data/basics"
 	"github.com/algorand/go-algorand/data/bookkeeping"
 	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/data/transactions/logic"
 	"github.com/algorand/go-algorand/logging"
 	"github.com/algorand/go-algorand/protocol"
 	"github.com/algorand/go-algorand/util/execpool"
@@ -223,6 +224,311 @@ func TestRekeying(t *testing.T) {
 	// TODO: More tests
 }
 
+func TestAppAccount(t *testing.T) {
+	// Pretend TEAL v3 and inner transactions are supported
+	actual := config.Consensus[protocol.ConsensusCurrentVersion]
+	pretend := actual
+	pretend.LogicSigVersion = 3
+	pretend.MaxInnerTransactions = 4
+	config.Consensus[protocol.ConsensusCurrentVersion] = pretend
+	defer func() {
+		config.Consensus[protocol.ConsensusCurrentVersion] = actual
+	}()
+
+	genesisInitState, addrs, keys := genesis(10)
+	dbName := fmt.Sprintf("%s.%d", t.Name(), crypto.RandUint64())
+	const inMem = true
+	cfg := config.GetDefaultLocal()
+	cfg.Archival = true
+	l, err := OpenLedger(logging.Base(), dbName, inMem, genesisInitState, cfg)
+	require.NoError(t, err)
+	defer l.Close()
+
+	// the approval program opts the application account in to the asset
+	// passed as its first argument
+	approval, err := logic.AssembleString(`#pragma version 3
+txn ApplicationID
+bz done
+itxn_begin
+int axfer
+itxn_field TypeEnum
+txna ApplicationArgs 0
+btoi
+itxn_field XferAsset
+global CurrentApplicationAddress
+itxn_field AssetReceiver
+int 0
+itxn_field AssetAmount
+itxn_submit
+done:
+int 1
+`)
+	require.NoError(t, err)
+	clearState, err := logic.AssembleString("#pragma version 3\nint 1")
+	require.NoError(t, err)
+
+	newBlock := bookkeeping.MakeBlock(genesisInitState.Block.BlockHeader)
+	eval, err := l.StartEvaluator(newBlock.BlockHeader, 0)
+	require.NoError(t, err)
+
+	genHash := genesisInitState.Block.BlockHeader.GenesisHash
+	header := transactions.Header{
+		Sender:      addrs[0],
+		Fee:         minFee,
+		FirstValid:  newBlock.Round(),
+		LastValid:   newBlock.Round(),
+		GenesisHash: genHash,
+	}
+
+	// the first two transactions of the block create asset 1 and application 2
+	assetIdx := basics.AssetIndex(1)
+	appIdx := basics.AppIndex(2)
+	appAddr := appIdx.Address()
+
+	txns := []transactions.Transaction{
+		{
+			Type:   protocol.AssetConfigTx,
+			Header: header,
+			AssetConfigTxnFields: transactions.AssetConfigTxnFields{
+				AssetParams: basics.AssetParams{Total: 100, UnitName: "x"},
+			},
+		},
+		{
+			Type:   protocol.ApplicationCallTx,
+			Header: header,
+			ApplicationCallTxnFields: transactions.ApplicationCallTxnFields{
+				ApprovalProgram:   approval,
+				ClearStateProgram: clearState,
+			},
+		},
+		// the application account can receive payments
+		{
+			Type:   protocol.PaymentTx,
+			Header: header,
+			PaymentTxnFields: transactions.PaymentTxnFields{
+				Receiver: appAddr,
+				Amount:   basics.MicroAlgos{Raw: 1000000},
+			},
+		},
+		{
+			Type:   protocol.ApplicationCallTx,
+			Header: header,
+			ApplicationCallTxnFields: transactions.ApplicationCallTxnFields{
+				ApplicationID:   appIdx,
+				ApplicationArgs: [][]byte{{0, 0, 0, 0, 0, 0, 0, byte(assetIdx)}},
+			},
+		},
+		// and, once opted in, assets
+		{
+			Type:   protocol.AssetTransferTx,
+			Header: header,
+			AssetTransferTxnFields: transactions.AssetTransferTxnFields{
+				XferAsset:     assetIdx,
+				AssetAmount:   5,
+				AssetReceiver: appAddr,
+			},
+		},
+	}
+	for i, txn := range txns {
+		txn.Note = []byte{byte(i)}
+		err = eval.Transaction(txn.Sign(keys[0]), transactions.ApplyData{})
+		require.NoError(t, err, "transaction %d", i)
+	}
+
+	validatedBlock, err := eval.GenerateBlock()
+	require.NoError(t, err)
+	l.AddValidatedBlock(*validatedBlock, agreement.Certificate{})
+
+	creator, err := l.Lookup(newBlock.Round(), addrs[0])
+	require.NoError(t, err)
+	require.Contains(t, creator.AssetParams, assetIdx)
+	require.Contains(t, creator.AppParams, appIdx)
+
+	appAcct, err := l.Lookup(newBlock.Round(), appAddr)
+	require.NoError(t, err)
+	// the opt in fee was paid from the application account
+	require.Equal(t, 1000000-minFee.Raw, appAcct.MicroAlgos.Raw)
+	require.Equal(t, map[basics.AssetIndex]basics.AssetHolding{assetIdx: {Amount: 5}}, appAcct.Assets)
+}
+
+func TestAppBoxes(t *testing.T) {
+	// Pretend TEAL v3 and boxes are supported
+	actual := config.Consensus[protocol.ConsensusCurrentVersion]
+	pretend := actual
+	pretend.LogicSigVersion = 3
+	pretend.MaxBoxSize = 32
+	pretend.MaxAppBoxNameLen = 8
+	pretend.BoxFlatMinBalance = 2500
+	pretend.BoxByteMinBalance = 400
+	// boxes don't count toward the maximum minimum balance, which only
+	// leaves room for the application here
+	pretend.AppFlatParamsMinBalance = 1000
+	pretend.MaximumMinimumBalance = pretend.MinBalance + pretend.AppFlatParamsMinBalance
+	config.Consensus[protocol.ConsensusCurrentVersion] = pretend
+	defer func() {
+		config.Consensus[protocol.ConsensusCurrentVersion] = actual
+	}()
+
+	genesisInitState, addrs, keys := genesis(10)
+	dbName := fmt.Sprintf("%s.%d", t.Name(), crypto.RandUint64())
+	const inMem = true
+	cfg := config.GetDefaultLocal()
+	cfg.Archival = true
+	l, err := OpenLedger(logging.Base(), dbName, inMem, genesisInitState, cfg)
+	require.NoError(t, err)
+	defer l.Close()
+
+	// the approval program deletes the box named by its second argument
+	// if the first is "del", and otherwise puts its third argument there
+	approval, err := logic.AssembleString(`#pragma version 3
+txn ApplicationID
+bz done
+txna ApplicationArgs 0
+byte "del"
+==
+bnz del
+txna ApplicationArgs 1
+txna ApplicationArgs 2
+box_put
+b done
+del:
+txna ApplicationArgs 1
+box_del
+pop
+done:
+int 1
+`)
+	require.NoError(t, err)
+	clearState, err := logic.AssembleString("#pragma version 3\nint 1")
+	require.NoError(t, err)
+
+	newBlock := bookkeeping.MakeBlock(genesisInitState.Block.BlockHeader)
+	eval, err := l.StartEvaluator(newBlock.BlockHeader, 0)
+	require.NoError(t, err)
+
+	genHash := genesisInitState.Block.BlockHeader.GenesisHash
+	header := transactions.Header{
+		Sender:      addrs[0],
+		Fee:         minFee,
+		FirstValid:  newBlock.Round(),
+		LastValid:   newBlock.Round(),
+		GenesisHash: genHash,
+	}
+
+	appIdx := basics.AppIndex(1)
+	appAddr := appIdx.Address()
+	call := func(args ...string) transactions.Transaction {
+		txn := transactions.Transaction{
+			Type:   protocol.ApplicationCallTx,
+			Header: header,
+			ApplicationCallTxnFields: transactions.ApplicationCallTxnFields{
+				ApplicationID: appIdx,
+			},
+		}
+		for _, arg := range args {
+			txn.ApplicationArgs = append(txn.ApplicationArgs, []byte(arg))
+		}
+		return txn
+	}
+
+	create := transactions.Transaction{
+		Type:   protocol.ApplicationCallTx,
+		Header: header,
+		ApplicationCallTxnFields: transactions.ApplicationCallTxnFields{
+			ApprovalProgram:   approval,
+			ClearStateProgram: clearState,
+		},
+	}
+	fund := transactions.Transaction{
+		Type:   protocol.PaymentTx,
+		Header: header,
+		PaymentTxnFields: transactions.PaymentTxnFields{
+			Receiver: appAddr,
+			Amount:   basics.MicroAlgos{Raw: 1000000},
+		},
+	}
+
+	txns := []struct {
+		txn transactions.Transaction
+		err string
+	}{
+		{create, ""},
+		// boxes are paid for by the application account
+		{call("put", "box", "hello"), "below min"},
+		{fund, ""},
+		{call("put", "box", "hello"), ""},
+		{call("put", "tmp", "x"), ""},
+		{call("del", "tmp"), ""},
+	}
+	for i, test := range txns {
+		test.txn.Note = []byte{byte(i)}
+		err = eval.Transaction(test.txn.Sign(keys[0]), transactions.ApplyData{})
+		if test.err == "" {
+			require.NoError(t, err, "transaction %d", i)
+		} else {
+			require.Error(t, err, "transaction %d", i)
+			require.Contains(t, err.Error(), test.err, "transaction %d", i)
+		}
+	}
+
+	validatedBlock, err := eval.GenerateBlock()
+	require.NoError(t, err)
+	l.AddValidatedBlock(*validatedBlock, agreement.Certificate{})
+
+	value, ok, err := l.LookupBox(newBlock.Round(), appIdx, "box")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []byte("hello"), value)
+
+	_, ok, err = l.LookupBox(newBlock.Round(), appIdx, "tmp")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// boxes are not visible before they were created
+	_, ok, err = l.LookupBox(genesisInitState.Block.Round(), appIdx, "box")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	appAcct, err := l.Lookup(newBlock.Round(), appAddr)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), appAcct.TotalBoxes)
+	require.Equal(t, uint64(len("box")+len("hello")), appAcct.TotalBoxBytes)
+	require.Equal(t, pretend.MinBalance+2500+400*8, appAcct.MinBalance(&pretend).Raw)
+
+	// the application can only be deleted once its boxes are, so that
+	// their MinBalance is released
+	newBlock = bookkeeping.MakeBlock(validatedBlock.Block().BlockHeader)
+	eval, err = l.StartEvaluator(newBlock.BlockHeader, 0)
+	require.NoError(t, err)
+	header.FirstValid = newBlock.Round()
+	header.LastValid = newBlock.Round()
+
+	keepBox := call("put", "box", "hello")
+	keepBox.OnCompletion = transactions.DeleteApplicationOC
+	err = eval.Transaction(keepBox.Sign(keys[0]), transactions.ApplyData{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "holds 1 boxes")
+
+	delBox := call("del", "box")
+	delBox.OnCompletion = transactions.DeleteApplicationOC
+	err = eval.Transaction(delBox.Sign(keys[0]), transactions.ApplyData{})
+	require.NoError(t, err)
+
+	validatedBlock, err = eval.GenerateBlock()
+	require.NoError(t, err)
+	l.AddValidatedBlock(*validatedBlock, agreement.Certificate{})
+
+	_, ok, err = l.LookupBox(newBlock.Round(), appIdx, "box")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	appAcct, err = l.Lookup(newBlock.Round(), appAddr)
+	require.NoError(t, err)
+	require.Zero(t, appAcct.TotalBoxes)
+	require.Zero(t, appAcct.TotalBoxBytes)
+	require.Equal(t, pretend.MinBalance, appAcct.MinBalance(&pretend).Raw)
+}
+
 func TestPrepareAppEvaluators(t *testing.T) {
 	eval := BlockEvaluator{
 		prevHeader: bookkeeping.BlockHeader{
@@ -75,6 +75,7 @@ type Ledger struct {
 	notifier blockNotifier
 	time     timeTracker
 	metrics  metricsTracker
+	deltas   stateDeltaExporter
 
 	trackers  trackerRegistry
 	trackerMu deadlock.RWMutex
@@ -143,6 +144,7 @@ func OpenLedger(
 	}
 
 	l.accts.initialize(cfg, dbPathPrefix, l.genesisProto, l.genesisAccounts)
+	l.deltas.initialize(cfg, &l.accts)
 
 	err = l.reloadLedger()
 	if err != nil {
@@ -182,6 +184,7 @@ func (l *Ledger) reloadLedger() error {
 	l.trackers.register(&l.bulletin) // provide closed channel signaling support for completed rounds
 	l.trackers.register(&l.notifier) // send OnNewBlocks to subscribers
 	l.trackers.register(&l.metrics)  // provides metrics reporting support
+	l.trackers.register(&l.deltas)   // retains the exported state deltas of the most recent rounds
 
 	err = l.trackers.loadFromDisk(l)
 	if err != nil {
@@ -398,6 +401,20 @@ func (l *Ledger) GetCreatorForRound(rnd basics.Round, cidx basics.CreatableIndex
 	return l.accts.GetCreatorForRound(rnd, cidx, ctype)
 }
 
+// LookupKv returns the value of the given key in the ledger's key/value store at round rnd,
+// setting ok to false if the key does not exist.
+func (l *Ledger) LookupKv(rnd basics.Round, key string) (value []byte, ok bool, err error) {
+	l.trackerMu.RLock()
+	defer l.trackerMu.RUnlock()
+	return l.accts.LookupKv(rnd, key)
+}
+
+// LookupBox returns the contents of the named box of an application at round rnd,
+// setting ok to false if the box does not exist.
+func (l *Ledger) LookupBox(rnd basics.Round, appIdx basics.AppIndex, name string) (value []byte, ok bool, err error) {
+	return l.LookupKv(rnd, boxKey(appIdx, name))
+}
+
 // GetCreator is like GetCreatorForRound, but for the latest round and race-free
 // with respect to ledger.Latest()
 func (l *Ledger) GetCreator(cidx basics.CreatableIndex, ctype basics.CreatableType) (basics.Address, bool, error) {
@@ -463,6 +480,14 @@ func (l *Ledger) LookupWithoutRewards(rnd basics.Round, addr basics.Address) (ba
 	return data, nil
 }
 
+// ExportedStateDelta returns the exported state delta of round rnd. The exported state
+// deltas are retained only for the most recent rounds, as configured by StateDeltaExportRounds.
+func (l *Ledger) ExportedStateDelta(rnd basics.Round) (ExportedStateDelta, error) {
+	l.trackerMu.RLock()
+	defer l.trackerMu.RUnlock()
+	return l.deltas.lookup(rnd)
+}
+
 // Totals returns the totals of all accounts at the end of round rnd.
 func (l *Ledger) Totals(rnd basics.Round) (AccountTotals, error) {
 	l.trackerMu.RLock()
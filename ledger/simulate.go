@@ -0,0 +1,152 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package ledger
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/data/transactions/verify"
+)
+
+// SimulationResult describes the outcome of evaluating a transaction group on top of
+// the latest round, without adding it to the ledger.
+type SimulationResult struct {
+	// Round is the round in which the group was evaluated, i.e. the round following the latest one.
+	Round basics.Round
+
+	// Txns contains the evaluated transactions along with their ApplyData. It is empty if the group failed.
+	Txns []transactions.SignedTxnWithAD
+
+	// AppCosts contains the cost of the TEAL programs run by each of the transactions of the group.
+	// It is zero for transactions which are not application calls, or which were not evaluated.
+	AppCosts []int
+
+	// Delta is the state delta which would result from the group. It is empty if the group failed.
+	Delta ExportedStateDelta
+
+	// FailureMessage is the reason for which the group would be rejected, or empty if the group
+	// would be accepted.
+	FailureMessage string
+}
+
+// Simulate evaluates the given transaction group on top of the latest round, the same way the
+// transaction pool would, and returns the outcome without modifying the ledger. If verifySignatures
+// is false, the signatures of the transactions are not checked, allowing unsigned transactions
+// to be simulated; the transactions still need to be well formed.
+// A failing group does not yield an error; the reason for the failure is reported in the result instead.
+func (l *Ledger) Simulate(txgroup []transactions.SignedTxn, verifySignatures bool) (SimulationResult, error) {
+	if len(txgroup) == 0 {
+		return SimulationResult{}, errors.New("empty transaction group")
+	}
+
+	latest := l.Latest()
+	prev, err := l.BlockHdr(latest)
+	if err != nil {
+		return SimulationResult{}, err
+	}
+
+	// Ensure we know about the next protocol version (MakeBlock will panic
+	// if we don't)
+	_, upgradeState, err := bookkeeping.ProcessUpgradeParams(prev)
+	if err != nil {
+		return SimulationResult{}, err
+	}
+	proto, ok := config.Consensus[upgradeState.CurrentProtocol]
+	if !ok {
+		return SimulationResult{}, fmt.Errorf("next protocol version %v is not supported", upgradeState.CurrentProtocol)
+	}
+
+	next := bookkeeping.MakeBlock(prev)
+	result := SimulationResult{
+		Round:    next.Round(),
+		AppCosts: make([]int, len(txgroup)),
+	}
+
+	if len(txgroup) > proto.MaxTxGroupSize {
+		result.FailureMessage = fmt.Sprintf("group size %d exceeds maximum %d", len(txgroup), proto.MaxTxGroupSize)
+		return result, nil
+	}
+
+	contexts := verify.PrepareContexts(txgroup, next.BlockHeader)
+	for i := range txgroup {
+		if verifySignatures {
+			err = verify.Txn(&txgroup[i], contexts[i])
+		} else {
+			err = txgroup[i].Txn.WellFormed(contexts[i].CurrSpecAddrs, proto)
+		}
+		if err != nil {
+			result.FailureMessage = fmt.Sprintf("transaction %v: %v", txgroup[i].ID(), err)
+			return result, nil
+		}
+	}
+
+	eval, err := l.StartEvaluator(next.BlockHeader, len(txgroup))
+	if err != nil {
+		return SimulationResult{}, err
+	}
+	eval.appCosts = result.AppCosts
+
+	txads := make([]transactions.SignedTxnWithAD, len(txgroup))
+	for i := range txgroup {
+		txads[i].SignedTxn = txgroup[i]
+	}
+	err = eval.TransactionGroup(txads)
+	if err != nil {
+		result.FailureMessage = err.Error()
+		return result, nil
+	}
+
+	result.Txns = make([]transactions.SignedTxnWithAD, len(eval.block.Payset))
+	for i, txib := range eval.block.Payset {
+		result.Txns[i].SignedTxn, result.Txns[i].ApplyData, err = eval.block.DecodeSignedTxn(txib)
+		if err != nil {
+			return SimulationResult{}, err
+		}
+	}
+
+	totals, err := simulatedTotals(l, latest, eval.proto, eval.block.RewardsLevel, eval.state.mods)
+	if err != nil {
+		return SimulationResult{}, err
+	}
+	result.Delta = makeExportedStateDelta(result.Round, eval.state.mods, totals)
+	return result, nil
+}
+
+// simulatedTotals returns the account totals which would result from applying the given delta,
+// evaluated at the given rewards level, on top of round latest.
+func simulatedTotals(l *Ledger, latest basics.Round, proto config.ConsensusParams, rewardsLevel uint64, delta StateDelta) (AccountTotals, error) {
+	totals, err := l.Totals(latest)
+	if err != nil {
+		return AccountTotals{}, err
+	}
+
+	var ot basics.OverflowTracker
+	totals.applyRewards(rewardsLevel, &ot)
+	for _, acctDelta := range delta.accts {
+		totals.delAccount(proto, acctDelta.old, &ot)
+		totals.addAccount(proto, acctDelta.new, &ot)
+	}
+	if ot.Overflowed {
+		return AccountTotals{}, fmt.Errorf("overflow computing the account totals of round %d", latest+1)
+	}
+	return totals, nil
+}
@@ -0,0 +1,131 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package ledger
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/data/transactions/logic"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+func TestSimulate(t *testing.T) {
+	genesisInitState, addrs, keys := genesis(10)
+	dbName := fmt.Sprintf("%s.%d", t.Name(), crypto.RandUint64())
+	const inMem = true
+	cfg := config.GetDefaultLocal()
+	cfg.Archival = true
+	l, err := OpenLedger(logging.Base(), dbName, inMem, genesisInitState, cfg)
+	require.NoError(t, err)
+	defer l.Close()
+
+	before, err := l.Lookup(l.Latest(), addrs[1])
+	require.NoError(t, err)
+
+	header := transactions.Header{
+		Sender:      addrs[0],
+		Fee:         minFee,
+		FirstValid:  l.Latest(),
+		LastValid:   l.Latest() + 10,
+		GenesisHash: genesisInitState.GenesisHash,
+	}
+	pay := transactions.Transaction{
+		Type:   protocol.PaymentTx,
+		Header: header,
+		PaymentTxnFields: transactions.PaymentTxnFields{
+			Receiver: addrs[1],
+			Amount:   basics.MicroAlgos{Raw: 1000},
+		},
+	}
+
+	// unsigned transactions are only accepted when signatures are not verified
+	unsigned := []transactions.SignedTxn{{Txn: pay}}
+	res, err := l.Simulate(unsigned, true)
+	require.NoError(t, err)
+	require.NotEmpty(t, res.FailureMessage)
+	require.Empty(t, res.Txns)
+
+	res, err = l.Simulate(unsigned, false)
+	require.NoError(t, err)
+	require.Empty(t, res.FailureMessage)
+	require.Equal(t, l.Latest()+1, res.Round)
+	require.Equal(t, res.Round, res.Delta.Round)
+	require.Len(t, res.Txns, 1)
+	require.Equal(t, pay, res.Txns[0].Txn)
+	require.Equal(t, []int{0}, res.AppCosts)
+
+	var found bool
+	for _, acct := range res.Delta.Accounts {
+		if acct.Address == addrs[1] {
+			found = true
+			require.Equal(t, before.MicroAlgos.Raw+1000, acct.Data.MicroAlgos.Raw)
+		}
+	}
+	require.True(t, found)
+
+	// the ledger itself is left untouched
+	after, err := l.Lookup(l.Latest(), addrs[1])
+	require.NoError(t, err)
+	require.Equal(t, before, after)
+
+	res, err = l.Simulate([]transactions.SignedTxn{pay.Sign(keys[0])}, true)
+	require.NoError(t, err)
+	require.Empty(t, res.FailureMessage)
+
+	overspend := pay
+	overspend.Amount = basics.MicroAlgos{Raw: before.MicroAlgos.Raw * 10}
+	res, err = l.Simulate([]transactions.SignedTxn{overspend.Sign(keys[0])}, true)
+	require.NoError(t, err)
+	require.Contains(t, res.FailureMessage, "overspend")
+	require.Empty(t, res.Txns)
+
+	// the cost of the approval program is reported, whether or not it approves
+	approval, err := logic.AssembleStringWithVersion("int 1\nint 2\n+", 2)
+	require.NoError(t, err)
+	clearState, err := logic.AssembleStringWithVersion("int 1", 2)
+	require.NoError(t, err)
+	create := transactions.Transaction{
+		Type:   protocol.ApplicationCallTx,
+		Header: header,
+		ApplicationCallTxnFields: transactions.ApplicationCallTxnFields{
+			ApprovalProgram:   approval,
+			ClearStateProgram: clearState,
+		},
+	}
+	res, err = l.Simulate([]transactions.SignedTxn{{Txn: create}}, false)
+	require.NoError(t, err)
+	require.Empty(t, res.FailureMessage)
+	require.Equal(t, []int{3}, res.AppCosts)
+	require.Len(t, res.Delta.Creatables, 1)
+	require.Equal(t, basics.AppCreatable, res.Delta.Creatables[0].Type)
+	require.True(t, res.Delta.Creatables[0].Created)
+
+	create.ApprovalProgram, err = logic.AssembleStringWithVersion("int 1\nint 1\n-", 2)
+	require.NoError(t, err)
+	res, err = l.Simulate([]transactions.SignedTxn{{Txn: create}}, false)
+	require.NoError(t, err)
+	require.Contains(t, res.FailureMessage, "rejected")
+	require.Equal(t, []int{3}, res.AppCosts)
+}
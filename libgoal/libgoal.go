@@ -986,8 +986,9 @@ func MakeDryrunStateGenerated(client Client, txnOrStxn interface{}, other []tran
 					appParams = app.Params
 				}
 				dr.Apps = append(dr.Apps, generatedV2.Application{
-					Id:     uint64(appIdx),
-					Params: appParams,
+					Id:      uint64(appIdx),
+					Address: appIdx.Address().String(),
+					Params:  appParams,
 				})
 			}
 
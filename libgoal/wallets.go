@@ -220,3 +220,39 @@ func (c *Client) ExportMasterDerivationKey(wh []byte, pw []byte) (mdk crypto.Mas
 	// Return the mdk from the response
 	return resp.MasterDerivationKey, nil
 }
+
+// BackupWallet returns a passphrase-encrypted archive of the keys and multisig
+// preimages of the given wallet. Wallets holding keys with signing policies
+// also need their policy password.
+func (c *Client) BackupWallet(wh []byte, pw []byte, policyPw []byte, passphrase []byte) (backup []byte, err error) {
+	kmd, err := c.ensureKmdClient()
+	if err != nil {
+		return
+	}
+
+	// Back up the wallet
+	resp, err := kmd.BackupWallet(wh, pw, policyPw, passphrase)
+	if err != nil {
+		return
+	}
+
+	// Return the archive from the response
+	return resp.Backup, nil
+}
+
+// RestoreWallet restores a wallet backup into a new kmd wallet, returning the
+// ID of the new wallet
+func (c *Client) RestoreWallet(name []byte, password []byte, backup []byte, passphrase []byte) ([]byte, error) {
+	kmd, err := c.ensureKmdClient()
+	if err != nil {
+		return nil, err
+	}
+
+	// Restore the wallet
+	resp, err := kmd.RestoreWallet(name, defaultWalletDriver, password, backup, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(resp.Wallet.ID), nil
+}
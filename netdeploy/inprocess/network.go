@@ -0,0 +1,125 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package inprocess runs the nodes of a deployed private network within the current process, connected through an
+// in-memory network instead of real sockets.
+package inprocess
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/netdeploy"
+	"github.com/algorand/go-algorand/network"
+	"github.com/algorand/go-algorand/node"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+// Network is a private network whose nodes run in the current process.
+type Network struct {
+	// Nodes maps the name of each node (its data directory name) to the running node.
+	Nodes map[string]*node.AlgorandFullNode
+	// Memory is the simulated network connecting the nodes, which can be used to adjust latencies and partitions.
+	Memory *network.MemoryNetwork
+}
+
+// Start starts all the nodes of the deployed network in the current process. The relays are reachable at their
+// node name, and all the other nodes connect to them.
+func Start(deployed netdeploy.Network, memoryConfig network.MemoryNetworkConfig, log logging.Logger) (*Network, error) {
+	n := &Network{
+		Nodes:  make(map[string]*node.AlgorandFullNode),
+		Memory: network.MakeMemoryNetwork(memoryConfig),
+	}
+
+	relayDirs := deployed.RelayDataDirs()
+	var relayNames []string
+	for _, relayDir := range relayDirs {
+		relayNames = append(relayNames, filepath.Base(relayDir))
+	}
+
+	for _, relayDir := range relayDirs {
+		err := n.startNode(relayDir, true, relayNames, log)
+		if err != nil {
+			n.Stop()
+			return nil, err
+		}
+	}
+	for _, nodeDir := range deployed.NodeDataDirs() {
+		err := n.startNode(nodeDir, false, relayNames, log)
+		if err != nil {
+			n.Stop()
+			return nil, err
+		}
+	}
+	return n, nil
+}
+
+func (n *Network) startNode(dataDir string, relay bool, relayNames []string, log logging.Logger) error {
+	name := filepath.Base(dataDir)
+	cfg, err := config.LoadConfigFromDisk(dataDir)
+	if err != nil {
+		return fmt.Errorf("cannot load config of node %s: %v", name, err)
+	}
+	genesisText, err := ioutil.ReadFile(filepath.Join(dataDir, config.GenesisJSONFile))
+	if err != nil {
+		return fmt.Errorf("cannot read genesis of node %s: %v", name, err)
+	}
+	var genesis bookkeeping.Genesis
+	err = protocol.DecodeJSON(genesisText, &genesis)
+	if err != nil {
+		return fmt.Errorf("cannot parse genesis of node %s: %v", name, err)
+	}
+	err = config.LoadConfigurableConsensusProtocols(dataDir)
+	if err != nil {
+		return fmt.Errorf("cannot load consensus protocols of node %s: %v", name, err)
+	}
+
+	// only the relays accept incoming connections, the addresses are node names within the memory network
+	cfg.NetAddress = ""
+	if relay {
+		cfg.NetAddress = name
+	}
+	var phonebook []string
+	for _, relayName := range relayNames {
+		if relayName != name {
+			phonebook = append(phonebook, relayName)
+		}
+	}
+
+	nodeLog := log.With("node", name)
+	p2pNode, err := n.Memory.NewNode(nodeLog, cfg, name, phonebook, genesis.ID())
+	if err != nil {
+		return err
+	}
+	fullNode, err := node.MakeFullWithNetwork(nodeLog, dataDir, cfg, p2pNode, genesis)
+	if err != nil {
+		return fmt.Errorf("cannot create node %s: %v", name, err)
+	}
+	fullNode.Start()
+	n.Nodes[name] = fullNode
+	return nil
+}
+
+// Stop stops all the nodes of the network.
+func (n *Network) Stop() {
+	for _, fullNode := range n.Nodes {
+		fullNode.Stop()
+	}
+}
@@ -140,6 +140,15 @@ func (n Network) PrimaryDataDir() string {
 	return n.getNodeFullPath(n.cfg.RelayDirs[0])
 }
 
+// RelayDataDirs returns an array of relay data directories, starting with the primary one
+func (n Network) RelayDataDirs() []string {
+	var directories []string
+	for _, relayDir := range n.cfg.RelayDirs {
+		directories = append(directories, n.getNodeFullPath(relayDir))
+	}
+	return directories
+}
+
 // NodeDataDirs returns an array of node data directories (not the relays)
 func (n Network) NodeDataDirs() []string {
 	var directories []string
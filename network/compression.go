@@ -0,0 +1,145 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"bytes"
+	"compress/flate"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/algorand/go-algorand/util/metrics"
+)
+
+// deflateCompressionCodec compresses message payloads using the DEFLATE format.
+const deflateCompressionCodec = "deflate"
+
+// supportedCompressionCodecs contains the list of message compression codecs supported by this node ( in order of preference ).
+var supportedCompressionCodecs = []string{deflateCompressionCodec}
+
+// compressedMessageTags are the tags of the messages whose payload may be compressed on connections for which
+// a compression codec was negotiated. On such connections, the payload of these messages is always prefixed by
+// a byte telling whether it was compressed or not.
+var compressedMessageTags = map[protocol.Tag]bool{
+	protocol.ProposalPayloadTag: true,
+	protocol.TopicMsgRespTag:    true,
+	protocol.TxnTag:             true,
+	protocol.UniCatchupResTag:   true,
+	protocol.UniEnsBlockResTag:  true,
+}
+
+// compressionMinSize is the payload size below which payloads are sent uncompressed, as they are not worth compressing.
+const compressionMinSize = 1024
+
+const (
+	payloadUncompressed byte = 0
+	payloadDeflate      byte = 1
+)
+
+var networkSentDecompressedBytesTotal = metrics.MakeCounter(metrics.NetworkSentDecompressedBytesTotal)
+var networkReceivedDecompressedBytesTotal = metrics.MakeCounter(metrics.NetworkReceivedDecompressedBytesTotal)
+var networkCompressionSavedBytesTotal = metrics.MakeCounter(metrics.NetworkCompressionSavedBytesTotal)
+
+var errEmptyCompressedPayload = errors.New("missing payload encoding")
+
+// compressibleMessage is a tagged message which may be sent to several peers, and which is compressed at most
+// once, for the peers with which a compression codec was negotiated.
+type compressibleMessage struct {
+	data []byte
+
+	encoded     []byte
+	encodedDone bool
+	saved       int
+}
+
+// forPeer returns the message encoded for the given peer.
+func (m *compressibleMessage) forPeer(wp *wsPeer) []byte {
+	// the tags are always 2 char long
+	if wp.compression == "" || !compressedMessageTags[protocol.Tag(m.data[:2])] {
+		return m.data
+	}
+	if !m.encodedDone {
+		m.encoded, m.saved = encodeMessagePayload(m.data)
+		m.encodedDone = true
+	}
+	if m.saved > 0 {
+		networkSentDecompressedBytesTotal.AddUint64(uint64(len(m.data)), nil)
+		networkCompressionSavedBytesTotal.AddUint64(uint64(m.saved), map[string]string{"tag": string(m.data[:2])})
+	}
+	return m.encoded
+}
+
+// compressMessage returns the given tagged message encoded for the peer.
+func (wp *wsPeer) compressMessage(data []byte) []byte {
+	msg := compressibleMessage{data: data}
+	return msg.forPeer(wp)
+}
+
+// encodeMessagePayload prefixes the payload of the given tagged message with its encoding, compressing
+// it when worthwhile. It returns the encoded message and the number of bytes saved by the compression.
+func encodeMessagePayload(data []byte) (encoded []byte, saved int) {
+	tagLen := 2
+	payload := data[tagLen:]
+	if len(payload) >= compressionMinSize {
+		var buf bytes.Buffer
+		buf.Write(data[:tagLen])
+		buf.WriteByte(payloadDeflate)
+		// NewWriter only fails on invalid compression levels
+		writer, _ := flate.NewWriter(&buf, flate.BestSpeed)
+		_, err := writer.Write(payload)
+		if err == nil {
+			err = writer.Close()
+		}
+		if err == nil && buf.Len() < len(data)+1 {
+			return buf.Bytes(), len(data) + 1 - buf.Len()
+		}
+	}
+	encoded = make([]byte, len(data)+1)
+	copy(encoded, data[:tagLen])
+	encoded[tagLen] = payloadUncompressed
+	copy(encoded[tagLen+1:], payload)
+	return encoded, 0
+}
+
+// decodeMessagePayload returns the payload of a message received on a connection for which a compression
+// codec was negotiated. The decompressed payload may not exceed maxSize bytes.
+func decodeMessagePayload(payload []byte, maxSize int) ([]byte, error) {
+	if len(payload) == 0 {
+		return nil, errEmptyCompressedPayload
+	}
+	switch payload[0] {
+	case payloadUncompressed:
+		return payload[1:], nil
+	case payloadDeflate:
+		reader := flate.NewReader(bytes.NewReader(payload[1:]))
+		defer reader.Close()
+		decompressed, err := ioutil.ReadAll(io.LimitReader(reader, int64(maxSize)+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(decompressed) > maxSize {
+			return nil, ErrIncomingMsgTooLarge
+		}
+		networkReceivedDecompressedBytesTotal.AddUint64(uint64(len(decompressed)), nil)
+		return decompressed, nil
+	default:
+		return nil, fmt.Errorf("unknown payload encoding %d", payload[0])
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+func TestMessagePayloadEncoding(t *testing.T) {
+	peer := &wsPeer{compression: deflateCompressionCodec}
+	large := append([]byte(protocol.ProposalPayloadTag), bytes.Repeat([]byte("payload"), 1000)...)
+	encoded := peer.compressMessage(large)
+	require.Less(t, len(encoded), len(large))
+	require.Equal(t, []byte(protocol.ProposalPayloadTag), encoded[:2])
+	require.Equal(t, payloadDeflate, encoded[2])
+	decoded, err := decodeMessagePayload(encoded[2:], maxMessageLength)
+	require.NoError(t, err)
+	require.Equal(t, large[2:], decoded)
+
+	// the decompressed payload size is limited
+	_, err = decodeMessagePayload(encoded[2:], len(large)-3)
+	require.Equal(t, ErrIncomingMsgTooLarge, err)
+
+	// small and incompressible payloads are sent as is
+	small := append([]byte(protocol.ProposalPayloadTag), []byte("payload")...)
+	random := make([]byte, 2*compressionMinSize)
+	crypto.RandBytes(random)
+	random = append([]byte(protocol.TxnTag), random...)
+	for _, msg := range [][]byte{small, random} {
+		encoded = peer.compressMessage(msg)
+		require.Equal(t, len(msg)+1, len(encoded))
+		require.Equal(t, payloadUncompressed, encoded[2])
+		decoded, err = decodeMessagePayload(encoded[2:], maxMessageLength)
+		require.NoError(t, err)
+		require.Equal(t, msg[2:], decoded)
+	}
+
+	// other tags, or peers without compression, get the message unchanged
+	vote := append([]byte(protocol.AgreementVoteTag), bytes.Repeat([]byte("vote"), 1000)...)
+	require.Equal(t, vote, peer.compressMessage(vote))
+	require.Equal(t, large, (&wsPeer{}).compressMessage(large))
+
+	_, err = decodeMessagePayload(nil, maxMessageLength)
+	require.Error(t, err)
+	_, err = decodeMessagePayload([]byte{7, 1, 2}, maxMessageLength)
+	require.Error(t, err)
+}
+
+func TestCompressibleMessageCompressedOnce(t *testing.T) {
+	large := append([]byte(protocol.ProposalPayloadTag), bytes.Repeat([]byte("payload"), 1000)...)
+	msg := compressibleMessage{data: large}
+	peerA := &wsPeer{compression: deflateCompressionCodec}
+	peerB := &wsPeer{compression: deflateCompressionCodec}
+	encoded := msg.forPeer(peerA)
+	require.Equal(t, payloadDeflate, encoded[2])
+	require.True(t, &encoded[0] == &msg.forPeer(peerB)[0])
+	require.Equal(t, large, msg.forPeer(&wsPeer{}))
+}
+
+func TestCheckCompressionMatch(t *testing.T) {
+	wn := makeTestWebsocketNode(t)
+	wn.config.EnableGossipCompression = true
+	require.Equal(t, deflateCompressionCodec, wn.checkCompressionMatch([]string{"zstd", deflateCompressionCodec}))
+	require.Equal(t, "", wn.checkCompressionMatch([]string{"zstd"}))
+	require.Equal(t, "", wn.checkCompressionMatch(nil))
+
+	wn.config.EnableGossipCompression = false
+	require.Equal(t, "", wn.checkCompressionMatch([]string{deflateCompressionCodec}))
+}
+
+// Set up two nodes with compression enabled, and check that large proposal payloads
+// are received intact.
+func TestWebsocketNetworkCompression(t *testing.T) {
+	for _, compressionB := range []bool{true, false} {
+		netA := makeTestWebsocketNode(t)
+		netA.config.GossipFanout = 1
+		netA.config.EnableGossipCompression = true
+		netA.Start()
+		netB := makeTestWebsocketNode(t)
+		netB.config.GossipFanout = 1
+		netB.config.EnableGossipCompression = compressionB
+		addrA, postListen := netA.Address()
+		require.True(t, postListen)
+		netB.phonebook.ReplacePeerList([]string{addrA}, "default")
+		netB.Start()
+
+		payload := bytes.Repeat([]byte("proposal"), 1000)
+		received := make(chan []byte, 2)
+		netB.RegisterHandlers([]TaggedMessageHandler{{Tag: protocol.ProposalPayloadTag, MessageHandler: HandlerFunc(func(msg IncomingMessage) OutgoingMessage {
+			received <- msg.Data
+			return OutgoingMessage{}
+		})}})
+
+		readyTimeout := time.NewTimer(2 * time.Second)
+		waitReady(t, netA, readyTimeout.C)
+		waitReady(t, netB, readyTimeout.C)
+
+		peers := netB.peerSnapshot(nil)
+		require.Len(t, peers, 1)
+		if compressionB {
+			require.Equal(t, deflateCompressionCodec, peers[0].compression)
+		} else {
+			require.Equal(t, "", peers[0].compression)
+		}
+
+		netA.Broadcast(context.Background(), protocol.ProposalPayloadTag, payload, true, nil)
+		netA.Broadcast(context.Background(), protocol.ProposalPayloadTag, []byte("small"), true, nil)
+		var messages [][]byte
+		for len(messages) < 2 {
+			select {
+			case data := <-received:
+				messages = append(messages, data)
+			case <-time.After(2 * time.Second):
+				t.Fatalf("timeout waiting for the proposal payloads")
+			}
+		}
+		require.ElementsMatch(t, [][]byte{payload, []byte("small")}, messages)
+		netB.Stop()
+		netA.Stop()
+	}
+}
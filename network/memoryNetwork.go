@@ -0,0 +1,936 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/algorand/go-deadlock"
+	"github.com/gorilla/mux"
+
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+// The memory network lets many nodes run in a single process without any sockets: every node gets a MemoryNode,
+// which implements the GossipNode interface, and all the MemoryNodes of a MemoryNetwork exchange their messages
+// through simulated links. Each link delivers the messages in order, after a configurable latency, and may drop some
+// of them; the network may also be partitioned, in which case messages between the different parts are dropped and
+// no new connections are made between them. The HTTP handlers registered by a node are served to the other nodes
+// through an in-memory http.RoundTripper subject to the same latency and partitions.
+//
+// The random choices of the network are derived from its seed, and from the link or the node they are made for along
+// with their sequence number there: the n-th message sent on a link is dropped or delayed the same way in every run
+// with the same seed, whichever goroutines send the other messages. The network isn't deterministic though: messages
+// are delivered on the wall clock, and the nodes' own goroutines decide when the messages are sent, so runs with the
+// same seed aren't replayed exactly.
+
+// MemoryNetworkConfig configures the simulated links of a MemoryNetwork.
+type MemoryNetworkConfig struct {
+	// Latency is the time it takes a message to go through a link.
+	Latency time.Duration
+
+	// Jitter is the maximal random delay added to the latency of each message.
+	Jitter time.Duration
+
+	// DropRate is the probability for a message to be dropped by a link.
+	DropRate float64
+
+	// Seed seeds the random choices made by the network ( jitter, drops and peer selection ), so that runs with the
+	// same seed make the same choices for the same message of a link, or the same connection attempt of a node.
+	Seed int64
+
+	// MeshInterval is how often the nodes try to connect to new peers when they have less than GossipFanout of them.
+	// Defaults to one second.
+	MeshInterval time.Duration
+
+	// EventualReadyDelay is the time after which a node which has less than GossipFanout peers is considered ready
+	// anyway. Defaults to one second.
+	EventualReadyDelay time.Duration
+}
+
+// memoryLink identifies the directed link from a node to another.
+type memoryLink struct {
+	from string
+	to   string
+}
+
+// memoryLinkConfig overrides the network wide latency and drop rate of a link.
+type memoryLinkConfig struct {
+	latency  time.Duration
+	dropRate float64
+}
+
+// MemoryNetwork is a simulated network connecting MemoryNodes.
+type MemoryNetwork struct {
+	config MemoryNetworkConfig
+
+	// mu protects the nodes, the connections between them, the link overrides and the partitions.
+	mu         deadlock.Mutex
+	nodes      map[string]*MemoryNode
+	links      map[memoryLink]memoryLinkConfig
+	partitions map[string]int
+
+	// drawsMu protects draws, the number of random choices made so far for each link or node.
+	drawsMu deadlock.Mutex
+	draws   map[string]uint64
+}
+
+var errMemoryNodeAddressInUse = errors.New("memory network address already in use")
+var errMemoryNetworkUnreachable = errors.New("memory network node is unreachable")
+
+// MakeMemoryNetwork creates an empty simulated network.
+func MakeMemoryNetwork(config MemoryNetworkConfig) *MemoryNetwork {
+	if config.MeshInterval <= 0 {
+		config.MeshInterval = time.Second
+	}
+	if config.EventualReadyDelay <= 0 {
+		config.EventualReadyDelay = time.Second
+	}
+	return &MemoryNetwork{
+		config:     config,
+		nodes:      make(map[string]*MemoryNode),
+		links:      make(map[memoryLink]memoryLinkConfig),
+		partitions: make(map[string]int),
+		draws:      make(map[string]uint64),
+	}
+}
+
+// NewNode creates a node of the network, reachable at the given address. The node connects to the nodes of its
+// phonebook which accept incoming connections, i.e. the ones which have a NetAddress configured. An address may be
+// reused once the node previously using it was stopped.
+func (mn *MemoryNetwork) NewNode(log logging.Logger, config config.Local, address string, phonebookAddresses []string, genesisID string) (*MemoryNode, error) {
+	mn.mu.Lock()
+	defer mn.mu.Unlock()
+	if existing, has := mn.nodes[address]; has && !existing.stopped {
+		return nil, errMemoryNodeAddressInUse
+	}
+	node := &MemoryNode{
+		net:           mn,
+		log:           log,
+		config:        config,
+		address:       address,
+		phonebook:     append([]string(nil), phonebookAddresses...),
+		GenesisID:     genesisID,
+		handlers:      MakeMultiplexer(log),
+		router:        mux.NewRouter(),
+		relayMessages: config.NetAddress != "" || config.ForceRelayMessages,
+		listening:     config.NetAddress != "",
+		peers:         make(map[string]*memoryPeer),
+		readyChan:     make(chan struct{}),
+	}
+	node.ctx, node.cancel = context.WithCancel(context.Background())
+	mn.nodes[address] = node
+	return node, nil
+}
+
+// SetLinkLatency sets the latency of the directed link between two nodes, overriding the network latency.
+func (mn *MemoryNetwork) SetLinkLatency(from, to string, latency time.Duration) {
+	mn.mu.Lock()
+	defer mn.mu.Unlock()
+	link := memoryLink{from: from, to: to}
+	linkConfig, has := mn.links[link]
+	if !has {
+		linkConfig.dropRate = mn.config.DropRate
+	}
+	linkConfig.latency = latency
+	mn.links[link] = linkConfig
+}
+
+// SetLinkDropRate sets the drop rate of the directed link between two nodes, overriding the network drop rate.
+func (mn *MemoryNetwork) SetLinkDropRate(from, to string, dropRate float64) {
+	mn.mu.Lock()
+	defer mn.mu.Unlock()
+	link := memoryLink{from: from, to: to}
+	linkConfig, has := mn.links[link]
+	if !has {
+		linkConfig.latency = mn.config.Latency
+	}
+	linkConfig.dropRate = dropRate
+	mn.links[link] = linkConfig
+}
+
+// Partition splits the network into the given groups of node addresses: the messages sent between nodes of different
+// groups are dropped, and no new connection is made between them. The nodes which are not listed form a group of
+// their own.
+func (mn *MemoryNetwork) Partition(groups ...[]string) {
+	mn.mu.Lock()
+	defer mn.mu.Unlock()
+	mn.partitions = make(map[string]int)
+	for i, group := range groups {
+		for _, address := range group {
+			mn.partitions[address] = i + 1
+		}
+	}
+}
+
+// Heal removes the partitions of the network.
+func (mn *MemoryNetwork) Heal() {
+	mn.Partition()
+}
+
+// reachable returns whether the given nodes are in the same partition. Should be called with mn.mu held.
+func (mn *MemoryNetwork) reachable(from, to string) bool {
+	return mn.partitions[from] == mn.partitions[to]
+}
+
+// nextRand returns the random source of the next choice made for the given key, which is derived from the network
+// seed, the key and the number of choices made for the key so far.
+func (mn *MemoryNetwork) nextRand(key string) *rand.Rand {
+	mn.drawsMu.Lock()
+	seq := mn.draws[key]
+	mn.draws[key] = seq + 1
+	mn.drawsMu.Unlock()
+
+	keyHash := fnv.New64a()
+	keyHash.Write([]byte(key))
+	return rand.New(&memoryRandSource{state: (uint64(mn.config.Seed) ^ keyHash.Sum64()) + seq*0xbf58476d1ce4e5b9})
+}
+
+// memoryRandSource is a splitmix64 random source, which is cheap enough to be seeded for each choice.
+type memoryRandSource struct {
+	state uint64
+}
+
+func (s *memoryRandSource) Seed(seed int64) {
+	s.state = uint64(seed)
+}
+
+func (s *memoryRandSource) Uint64() uint64 {
+	s.state += 0x9e3779b97f4a7c15
+	z := s.state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+func (s *memoryRandSource) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
+
+// linkDelay returns the time it takes for the next message of the given kind from a node to another to go through
+// their link, or false if the message is to be dropped.
+func (mn *MemoryNetwork) linkDelay(kind, from, to string) (time.Duration, bool) {
+	mn.mu.Lock()
+	linkConfig, has := mn.links[memoryLink{from: from, to: to}]
+	reachable := mn.reachable(from, to)
+	mn.mu.Unlock()
+	if !has {
+		linkConfig = memoryLinkConfig{latency: mn.config.Latency, dropRate: mn.config.DropRate}
+	}
+	r := mn.nextRand(kind + ":" + from + ">" + to)
+	if !reachable {
+		return 0, false
+	}
+	if linkConfig.dropRate > 0 && r.Float64() < linkConfig.dropRate {
+		return 0, false
+	}
+	delay := linkConfig.latency
+	if mn.config.Jitter > 0 {
+		delay += time.Duration(r.Int63n(int64(mn.config.Jitter)))
+	}
+	return delay, true
+}
+
+// shuffle returns the given addresses in a random order, for the next connection attempt of the given node.
+func (mn *MemoryNetwork) shuffle(node string, addresses []string) []string {
+	shuffled := append([]string(nil), addresses...)
+	mn.nextRand("connect:"+node).Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	return shuffled
+}
+
+// connect creates a connection from a node to another. Should be called with mn.mu held.
+func (mn *MemoryNetwork) connect(from, to *MemoryNode) {
+	outgoing := makeMemoryPeer(from, to, true)
+	incoming := makeMemoryPeer(to, from, false)
+	outgoing.other = incoming
+	incoming.other = outgoing
+	from.addPeer(outgoing)
+	to.addPeer(incoming)
+	from.wg.Add(1)
+	go outgoing.deliverThread()
+	to.wg.Add(1)
+	go incoming.deliverThread()
+	from.log.Debugf("memory network connection from %s to %s", from.address, to.address)
+}
+
+// disconnect closes a connection. Should be called with mn.mu held.
+func (mn *MemoryNetwork) disconnect(peer *memoryPeer) {
+	peer.close()
+	peer.other.close()
+	peer.node.removePeer(peer)
+	peer.remote.removePeer(peer.other)
+}
+
+// MemoryNode is a node of a MemoryNetwork. It implements the GossipNode interface.
+type MemoryNode struct {
+	net *MemoryNetwork
+
+	log logging.Logger
+
+	config config.Local
+
+	address string
+
+	phonebook []string
+
+	GenesisID string
+
+	handlers *Multiplexer
+
+	router *mux.Router
+
+	relayMessages bool
+
+	// listening is set for the nodes which accept incoming connections.
+	listening bool
+
+	// running and stopped are protected by the network lock.
+	running bool
+	stopped bool
+
+	peersLock deadlock.RWMutex
+	peers     map[string]*memoryPeer
+
+	messagesOfInterestMu deadlock.RWMutex
+	messagesOfInterest   map[protocol.Tag]bool
+
+	ready     int32
+	readyChan chan struct{}
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// Address returns the root URL of the node.
+func (node *MemoryNode) Address() (string, bool) {
+	node.net.mu.Lock()
+	defer node.net.mu.Unlock()
+	return node.rootURL(), node.running
+}
+
+func (node *MemoryNode) rootURL() string {
+	return "http://" + node.address
+}
+
+// Broadcast sends a message to all the peers of the node, except the given one.
+// If wait is true then the call blocks until the message has been queued on all the links.
+func (node *MemoryNode) Broadcast(ctx context.Context, tag protocol.Tag, data []byte, wait bool, except Peer) error {
+	for _, peer := range node.peerSnapshot() {
+		if Peer(peer) == except {
+			continue
+		}
+		if err := peer.send(ctx, tag, data, wait); err != nil && ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// Relay broadcasts a message if the node relays messages.
+func (node *MemoryNode) Relay(ctx context.Context, tag protocol.Tag, data []byte, wait bool, except Peer) error {
+	if node.relayMessages {
+		return node.Broadcast(ctx, tag, data, wait, except)
+	}
+	return nil
+}
+
+// Disconnect closes the connection with a peer.
+func (node *MemoryNode) Disconnect(badnode Peer) {
+	peer, ok := badnode.(*memoryPeer)
+	if !ok {
+		return
+	}
+	node.net.mu.Lock()
+	defer node.net.mu.Unlock()
+	node.net.disconnect(peer)
+}
+
+// DisconnectPeers closes all the connections of the node.
+func (node *MemoryNode) DisconnectPeers() {
+	node.net.mu.Lock()
+	defer node.net.mu.Unlock()
+	for _, peer := range node.peerSnapshot() {
+		node.net.disconnect(peer)
+	}
+}
+
+// Ready returns a chan that will be closed when the node has enough peers.
+func (node *MemoryNode) Ready() chan struct{} {
+	return node.readyChan
+}
+
+// RegisterHTTPHandler registers an HTTP handler served to the other nodes of the network.
+func (node *MemoryNode) RegisterHTTPHandler(path string, handler http.Handler) {
+	node.router.Handle(path, handler)
+}
+
+// RequestConnectOutgoing connects to new peers until the node has GossipFanout outgoing connections.
+// `replace` drops the existing outgoing connections first.
+func (node *MemoryNode) RequestConnectOutgoing(replace bool, quit <-chan struct{}) {
+	node.net.mu.Lock()
+	defer node.net.mu.Unlock()
+	if replace {
+		for _, peer := range node.peerSnapshot() {
+			if peer.outgoing {
+				node.net.disconnect(peer)
+			}
+		}
+	}
+	node.connectOutgoing()
+}
+
+// connectOutgoing connects to random phonebook nodes until the node has GossipFanout outgoing connections. Should
+// be called with the network lock held.
+func (node *MemoryNode) connectOutgoing() {
+	if !node.running {
+		return
+	}
+	needed := node.config.GossipFanout
+	for _, peer := range node.peerSnapshot() {
+		if peer.outgoing {
+			needed--
+		}
+	}
+	for _, address := range node.net.shuffle(node.address, node.phonebook) {
+		if needed <= 0 {
+			return
+		}
+		target := node.net.nodes[address]
+		if target == nil || target == node || !target.running || !target.listening || !node.net.reachable(node.address, address) {
+			continue
+		}
+		if node.getPeer(address) != nil {
+			continue
+		}
+		node.net.connect(node, target)
+		needed--
+	}
+}
+
+// GetPeers returns a snapshot of the peers of the node, according to the specified options.
+func (node *MemoryNode) GetPeers(options ...PeerOption) []Peer {
+	outPeers := make([]Peer, 0)
+	for _, option := range options {
+		switch option {
+		case PeersConnectedOut:
+			for _, peer := range node.peerSnapshot() {
+				if peer.outgoing {
+					outPeers = append(outPeers, peer)
+				}
+			}
+		case PeersConnectedIn:
+			for _, peer := range node.peerSnapshot() {
+				if !peer.outgoing {
+					outPeers = append(outPeers, peer)
+				}
+			}
+		case PeersPhonebook:
+			for _, address := range node.phonebook {
+				outPeers = append(outPeers, &memoryHTTPPeer{node: node, address: address})
+			}
+		}
+	}
+	return outPeers
+}
+
+// Start starts connecting to the other nodes of the network.
+func (node *MemoryNode) Start() {
+	node.net.mu.Lock()
+	defer node.net.mu.Unlock()
+	if node.running || node.stopped {
+		return
+	}
+	node.running = true
+	node.connectOutgoing()
+	node.wg.Add(2)
+	go node.meshThread()
+	go node.eventualReady()
+}
+
+// Stop closes all the connections of the node. A stopped node cannot be started again, but a new node may be created
+// at the same address.
+func (node *MemoryNode) Stop() {
+	node.net.mu.Lock()
+	node.running = false
+	node.stopped = true
+	for _, peer := range node.peerSnapshot() {
+		node.net.disconnect(peer)
+	}
+	node.net.mu.Unlock()
+	node.cancel()
+	node.wg.Wait()
+}
+
+// RegisterHandlers adds to the set of given message handlers.
+func (node *MemoryNode) RegisterHandlers(dispatch []TaggedMessageHandler) {
+	node.handlers.RegisterHandlers(dispatch)
+}
+
+// ClearHandlers deregisters all the existing message handlers.
+func (node *MemoryNode) ClearHandlers() {
+	node.handlers.ClearHandlers([]Tag{})
+}
+
+// GetRoundTripper returns a Transport serving the requests from the HTTP handlers of the other nodes of the network.
+func (node *MemoryNode) GetRoundTripper() http.RoundTripper {
+	return &memoryRoundTripper{node: node}
+}
+
+// OnNetworkAdvance does nothing; the memory network doesn't monitor its connections.
+func (node *MemoryNode) OnNetworkAdvance() {}
+
+// GetHTTPRequestConnection returns nil, since the requests are not served through connections.
+func (node *MemoryNode) GetHTTPRequestConnection(request *http.Request) (conn net.Conn) {
+	return nil
+}
+
+// RegisterMessageInterest notifies the network that this node wants to receive messages with the specified tag.
+// Once called, the node only receives the default messages and the ones it registered an interest in.
+func (node *MemoryNode) RegisterMessageInterest(t protocol.Tag) error {
+	node.messagesOfInterestMu.Lock()
+	defer node.messagesOfInterestMu.Unlock()
+	if node.messagesOfInterest == nil {
+		node.messagesOfInterest = make(map[protocol.Tag]bool)
+		for tag, flag := range defaultSendMessageTags {
+			node.messagesOfInterest[tag] = flag
+		}
+	}
+	node.messagesOfInterest[t] = true
+	return nil
+}
+
+// interestedIn returns whether the node wants to receive messages with the given tag.
+func (node *MemoryNode) interestedIn(tag protocol.Tag) bool {
+	node.messagesOfInterestMu.RLock()
+	defer node.messagesOfInterestMu.RUnlock()
+	return node.messagesOfInterest == nil || node.messagesOfInterest[tag]
+}
+
+func (node *MemoryNode) peerSnapshot() []*memoryPeer {
+	node.peersLock.RLock()
+	defer node.peersLock.RUnlock()
+	peers := make([]*memoryPeer, 0, len(node.peers))
+	for _, peer := range node.peers {
+		peers = append(peers, peer)
+	}
+	return peers
+}
+
+func (node *MemoryNode) getPeer(address string) *memoryPeer {
+	node.peersLock.RLock()
+	defer node.peersLock.RUnlock()
+	return node.peers[address]
+}
+
+func (node *MemoryNode) addPeer(peer *memoryPeer) {
+	node.peersLock.Lock()
+	node.peers[peer.remote.address] = peer
+	numPeers := len(node.peers)
+	node.peersLock.Unlock()
+	if numPeers >= node.config.GossipFanout {
+		node.setReady()
+	}
+}
+
+func (node *MemoryNode) removePeer(peer *memoryPeer) {
+	node.peersLock.Lock()
+	defer node.peersLock.Unlock()
+	if node.peers[peer.remote.address] == peer {
+		delete(node.peers, peer.remote.address)
+	}
+}
+
+func (node *MemoryNode) setReady() {
+	if atomic.CompareAndSwapInt32(&node.ready, 0, 1) {
+		node.log.Debug("ready")
+		close(node.readyChan)
+	}
+}
+
+// eventualReady marks the node as ready after a while, even if it doesn't have enough peers.
+func (node *MemoryNode) eventualReady() {
+	defer node.wg.Done()
+	timer := time.NewTimer(node.net.config.EventualReadyDelay)
+	defer timer.Stop()
+	select {
+	case <-node.ctx.Done():
+	case <-timer.C:
+		node.setReady()
+	}
+}
+
+// meshThread periodically connects to new peers.
+func (node *MemoryNode) meshThread() {
+	defer node.wg.Done()
+	ticker := time.NewTicker(node.net.config.MeshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-node.ctx.Done():
+			return
+		}
+		node.net.mu.Lock()
+		node.connectOutgoing()
+		node.net.mu.Unlock()
+	}
+}
+
+// handleMessage passes a message received from a peer to the handlers of the node, and acts on their outcome.
+func (node *MemoryNode) handleMessage(sender *memoryPeer, tag protocol.Tag, data []byte) {
+	if tag == protocol.TopicMsgRespTag {
+		sender.handleResponse(data)
+		return
+	}
+	msg := IncomingMessage{
+		Sender:   sender,
+		Tag:      tag,
+		Data:     data,
+		Net:      node,
+		Received: time.Now().UnixNano(),
+	}
+	outmsg := node.handlers.Handle(msg)
+	switch outmsg.Action {
+	case Disconnect:
+		go node.Disconnect(sender)
+	case Broadcast:
+		node.Broadcast(node.ctx, msg.Tag, msg.Data, false, msg.Sender)
+	case Respond:
+		sender.Respond(node.ctx, msg, outmsg.Topics)
+	default:
+	}
+}
+
+// memoryMessageQueueLength is the number of messages a link can hold before it starts dropping new ones.
+const memoryMessageQueueLength = 1000
+
+// memoryMessage is a message going through a link.
+type memoryMessage struct {
+	tag       protocol.Tag
+	data      []byte
+	deliverAt time.Time
+}
+
+// memoryPeer is one end of a connection between two nodes of a memory network: it's the peer representing the remote
+// node among the peers of the local node, and holds the link carrying the messages sent to the remote node.
+type memoryPeer struct {
+	node   *MemoryNode
+	remote *MemoryNode
+
+	// other is the peer representing the local node among the peers of the remote node.
+	other *memoryPeer
+
+	outgoing bool
+
+	queue     chan memoryMessage
+	closing   chan struct{}
+	closeOnce sync.Once
+
+	// lastDeliverAt is the delivery time of the last message queued, which keeps the messages in order. Protected by
+	// sendMu.
+	sendMu        deadlock.Mutex
+	lastDeliverAt time.Time
+
+	requestNonce          uint64
+	responseChannels      map[uint64]chan *Response
+	responseChannelsMutex deadlock.Mutex
+}
+
+func makeMemoryPeer(node, remote *MemoryNode, outgoing bool) *memoryPeer {
+	return &memoryPeer{
+		node:             node,
+		remote:           remote,
+		outgoing:         outgoing,
+		queue:            make(chan memoryMessage, memoryMessageQueueLength),
+		closing:          make(chan struct{}),
+		responseChannels: make(map[uint64]chan *Response),
+	}
+}
+
+func (peer *memoryPeer) close() {
+	peer.closeOnce.Do(func() {
+		close(peer.closing)
+	})
+}
+
+// send queues a message on the link to the remote node. If wait is false, the message is dropped when the link is full.
+func (peer *memoryPeer) send(ctx context.Context, tag protocol.Tag, data []byte, wait bool) error {
+	if !peer.remote.interestedIn(tag) {
+		return nil
+	}
+	delay, delivered := peer.node.net.linkDelay("gossip", peer.node.address, peer.remote.address)
+	if !delivered {
+		return nil
+	}
+	peer.sendMu.Lock()
+	defer peer.sendMu.Unlock()
+	msg := memoryMessage{tag: tag, data: data, deliverAt: time.Now().Add(delay)}
+	if msg.deliverAt.Before(peer.lastDeliverAt) {
+		msg.deliverAt = peer.lastDeliverAt
+	}
+	if wait {
+		select {
+		case peer.queue <- msg:
+		case <-peer.closing:
+			return fmt.Errorf("peer closing %s", peer.remote.address)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	} else {
+		select {
+		case peer.queue <- msg:
+		case <-peer.closing:
+			return fmt.Errorf("peer closing %s", peer.remote.address)
+		default:
+			peer.node.log.Debugf("memory network link from %s to %s is full, dropping %s message", peer.node.address, peer.remote.address, tag)
+			return nil
+		}
+	}
+	peer.lastDeliverAt = msg.deliverAt
+	return nil
+}
+
+// deliverThread delivers the messages of the link to the remote node once their latency elapsed.
+func (peer *memoryPeer) deliverThread() {
+	defer peer.node.wg.Done()
+	for {
+		var msg memoryMessage
+		select {
+		case msg = <-peer.queue:
+		case <-peer.closing:
+			return
+		}
+		if delay := time.Until(msg.deliverAt); delay > 0 {
+			timer := time.NewTimer(delay)
+			select {
+			case <-timer.C:
+			case <-peer.closing:
+				timer.Stop()
+				return
+			}
+		}
+		peer.remote.handleMessage(peer.other, msg.tag, msg.data)
+	}
+}
+
+// GetAddress returns the root URL of the remote node.
+func (peer *memoryPeer) GetAddress() string {
+	return peer.remote.rootURL()
+}
+
+// GetHTTPClient returns a client for the remote node.
+func (peer *memoryPeer) GetHTTPClient() *http.Client {
+	return &http.Client{Transport: peer.node.GetRoundTripper()}
+}
+
+// PrepareURL substitutes placeholders like "{genesisID}" for their values.
+func (peer *memoryPeer) PrepareURL(rawURL string) string {
+	return strings.Replace(rawURL, "{genesisID}", peer.node.GenesisID, -1)
+}
+
+// Unicast sends the given bytes to the remote node.
+func (peer *memoryPeer) Unicast(ctx context.Context, data []byte, tag protocol.Tag) error {
+	return peer.send(ctx, tag, data, true)
+}
+
+// Version returns the preferred protocol version, which memory peers always use.
+func (peer *memoryPeer) Version() string {
+	return SupportedProtocolVersions[0]
+}
+
+// SupportsTxSyncReconciliation returns whether both nodes enabled the transaction-sync set reconciliation protocol.
+func (peer *memoryPeer) SupportsTxSyncReconciliation() bool {
+	return peer.node.config.EnableTxSyncReconciliation && peer.remote.config.EnableTxSyncReconciliation
+}
+
+// Request sends a request to the remote node and waits for its response.
+func (peer *memoryPeer) Request(ctx context.Context, tag Tag, topics Topics) (resp *Response, e error) {
+	nonce := make([]byte, 8)
+	binary.LittleEndian.PutUint64(nonce, atomic.AddUint64(&peer.requestNonce, 1))
+	topics = append(topics, Topic{key: "nonce", data: nonce})
+	serializedMsg := topics.MarshallTopics()
+	hash := hashTopics(serializedMsg)
+
+	responseChannel := make(chan *Response, 1)
+	peer.responseChannelsMutex.Lock()
+	peer.responseChannels[hash] = responseChannel
+	peer.responseChannelsMutex.Unlock()
+	defer func() {
+		peer.responseChannelsMutex.Lock()
+		delete(peer.responseChannels, hash)
+		peer.responseChannelsMutex.Unlock()
+	}()
+
+	if e = peer.send(ctx, tag, serializedMsg, true); e != nil {
+		return
+	}
+	select {
+	case resp = <-responseChannel:
+		return resp, nil
+	case <-peer.closing:
+		return nil, fmt.Errorf("peer closing %s", peer.remote.address)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Respond sends the response to a request received from the remote node.
+func (peer *memoryPeer) Respond(ctx context.Context, reqMsg IncomingMessage, topics Topics) (e error) {
+	requestHashData := make([]byte, binary.MaxVarintLen64)
+	binary.PutUvarint(requestHashData, hashTopics(reqMsg.Data))
+	topics = append(topics, Topic{key: requestHashKey, data: requestHashData})
+	return peer.send(ctx, protocol.TopicMsgRespTag, topics.MarshallTopics(), true)
+}
+
+// handleResponse passes a response received from the remote node to the request waiting for it.
+func (peer *memoryPeer) handleResponse(data []byte) {
+	topics, err := UnmarshallTopics(data)
+	if err != nil {
+		peer.node.log.Warnf("memory network: could not read the response from %s: %v", peer.remote.address, err)
+		return
+	}
+	requestHash, found := topics.GetValue(requestHashKey)
+	if !found {
+		peer.node.log.Warnf("memory network: response from %s is missing the %s", peer.remote.address, requestHashKey)
+		return
+	}
+	hashKey, _ := binary.Uvarint(requestHash)
+	peer.responseChannelsMutex.Lock()
+	channel, found := peer.responseChannels[hashKey]
+	delete(peer.responseChannels, hashKey)
+	peer.responseChannelsMutex.Unlock()
+	if !found {
+		peer.node.log.Debugf("memory network: received a response from %s for a stale request", peer.remote.address)
+		return
+	}
+	channel <- &Response{Topics: topics}
+}
+
+// memoryHTTPPeer is a phonebook entry of a memory node, which can be sent HTTP requests.
+type memoryHTTPPeer struct {
+	node    *MemoryNode
+	address string
+}
+
+// GetAddress returns the root URL of the node.
+func (peer *memoryHTTPPeer) GetAddress() string {
+	return "http://" + peer.address
+}
+
+// GetHTTPClient returns a client for the node.
+func (peer *memoryHTTPPeer) GetHTTPClient() *http.Client {
+	return &http.Client{Transport: peer.node.GetRoundTripper()}
+}
+
+// PrepareURL substitutes placeholders like "{genesisID}" for their values.
+func (peer *memoryHTTPPeer) PrepareURL(rawURL string) string {
+	return strings.Replace(rawURL, "{genesisID}", peer.node.GenesisID, -1)
+}
+
+// memoryRoundTripper serves the HTTP requests of a node from the HTTP handlers of the other nodes of the network.
+type memoryRoundTripper struct {
+	node *MemoryNode
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (rt *memoryRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	from := rt.node.address
+	to := request.URL.Host
+	mn := rt.node.net
+	mn.mu.Lock()
+	target := mn.nodes[to]
+	reachable := target != nil && target.running && mn.reachable(from, to)
+	mn.mu.Unlock()
+	if !reachable {
+		return nil, fmt.Errorf("%s: %v", to, errMemoryNetworkUnreachable)
+	}
+	delay, _ := mn.linkDelay("http", from, to)
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-request.Context().Done():
+			timer.Stop()
+			return nil, request.Context().Err()
+		}
+	}
+
+	serverRequest := request.Clone(request.Context())
+	serverRequest.RemoteAddr = from
+	serverRequest.RequestURI = request.URL.RequestURI()
+	if serverRequest.Body == nil {
+		serverRequest.Body = http.NoBody
+	}
+	writer := &memoryResponseWriter{header: make(http.Header)}
+	target.router.ServeHTTP(writer, serverRequest)
+	if writer.statusCode == 0 {
+		writer.statusCode = http.StatusOK
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", writer.statusCode, http.StatusText(writer.statusCode)),
+		StatusCode:    writer.statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        writer.header,
+		Body:          ioutil.NopCloser(bytes.NewReader(writer.body.Bytes())),
+		ContentLength: int64(writer.body.Len()),
+		Request:       request,
+	}, nil
+}
+
+// memoryResponseWriter buffers the response of an HTTP handler.
+type memoryResponseWriter struct {
+	header     http.Header
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (w *memoryResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *memoryResponseWriter) Write(data []byte) (int, error) {
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusOK
+	}
+	return w.body.Write(data)
+}
+
+func (w *memoryResponseWriter) WriteHeader(statusCode int) {
+	if w.statusCode == 0 {
+		w.statusCode = statusCode
+	}
+}
@@ -0,0 +1,271 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+func makeTestMemoryNode(t *testing.T, net *MemoryNetwork, address string, relay bool, phonebook ...string) *MemoryNode {
+	cfg := defaultConfig
+	cfg.GossipFanout = 1
+	cfg.NetAddress = ""
+	if relay {
+		cfg.NetAddress = address
+	}
+	node, err := net.NewNode(logging.TestingLog(t), cfg, address, phonebook, "test-genesisID")
+	require.NoError(t, err)
+	return node
+}
+
+// makeTestMemoryTopology creates a relay, and two nodes connected to it, and starts them.
+func makeTestMemoryTopology(t *testing.T, net *MemoryNetwork) (relay, nodeA, nodeB *MemoryNode) {
+	relay = makeTestMemoryNode(t, net, "relay", true)
+	nodeA = makeTestMemoryNode(t, net, "nodeA", false, "relay")
+	nodeB = makeTestMemoryNode(t, net, "nodeB", false, "relay")
+	relay.Start()
+	nodeA.Start()
+	nodeB.Start()
+	readyTimeout := time.NewTimer(2 * time.Second)
+	for _, node := range []*MemoryNode{relay, nodeA, nodeB} {
+		select {
+		case <-node.Ready():
+		case <-readyTimeout.C:
+			require.FailNow(t, "timeout waiting for ready")
+		}
+	}
+	require.Len(t, relay.GetPeers(PeersConnectedIn), 2)
+	return
+}
+
+func receiveTestMessages(node *MemoryNode, tag protocol.Tag) chan IncomingMessage {
+	received := make(chan IncomingMessage, 10)
+	node.RegisterHandlers([]TaggedMessageHandler{{Tag: tag, MessageHandler: HandlerFunc(func(msg IncomingMessage) OutgoingMessage {
+		received <- msg
+		return OutgoingMessage{Action: Broadcast}
+	})}})
+	return received
+}
+
+func TestMemoryNetworkBroadcast(t *testing.T) {
+	net := MakeMemoryNetwork(MemoryNetworkConfig{Latency: 10 * time.Millisecond})
+	relay, nodeA, nodeB := makeTestMemoryTopology(t, net)
+	defer relay.Stop()
+	defer nodeA.Stop()
+	defer nodeB.Stop()
+
+	relayReceived := receiveTestMessages(relay, protocol.TxnTag)
+	nodeReceived := receiveTestMessages(nodeB, protocol.TxnTag)
+
+	start := time.Now()
+	require.NoError(t, nodeA.Broadcast(context.Background(), protocol.TxnTag, []byte("foo"), false, nil))
+	select {
+	case msg := <-nodeReceived:
+		require.Equal(t, []byte("foo"), msg.Data)
+		require.Equal(t, nodeB, msg.Net)
+		require.Equal(t, "http://relay", msg.Sender.(UnicastPeer).GetAddress())
+	case <-time.After(2 * time.Second):
+		require.FailNow(t, "message was not relayed")
+	}
+	// the message went through two links
+	require.True(t, time.Now().Sub(start) >= 20*time.Millisecond)
+	require.Len(t, relayReceived, 1)
+
+	// nodes don't relay messages
+	require.NoError(t, nodeB.Relay(context.Background(), protocol.TxnTag, []byte("bar"), false, nil))
+	time.Sleep(100 * time.Millisecond)
+	require.Len(t, relayReceived, 1)
+}
+
+func TestMemoryNetworkDropAndPartition(t *testing.T) {
+	net := MakeMemoryNetwork(MemoryNetworkConfig{})
+	relay, nodeA, nodeB := makeTestMemoryTopology(t, net)
+	defer relay.Stop()
+	defer nodeA.Stop()
+	defer nodeB.Stop()
+	relayReceived := receiveTestMessages(relay, protocol.TxnTag)
+
+	net.SetLinkDropRate("nodeA", "relay", 1)
+	nodeA.Broadcast(context.Background(), protocol.TxnTag, []byte("dropped"), false, nil)
+	net.SetLinkDropRate("nodeA", "relay", 0)
+
+	net.Partition([]string{"nodeA"}, []string{"relay", "nodeB"})
+	nodeA.Broadcast(context.Background(), protocol.TxnTag, []byte("partitioned"), false, nil)
+	nodeB.Broadcast(context.Background(), protocol.TxnTag, []byte("delivered"), false, nil)
+	select {
+	case msg := <-relayReceived:
+		require.Equal(t, []byte("delivered"), msg.Data)
+	case <-time.After(2 * time.Second):
+		require.FailNow(t, "message was not delivered")
+	}
+
+	// no new connection is made across the partition
+	nodeA.DisconnectPeers()
+	nodeA.RequestConnectOutgoing(false, nil)
+	require.Empty(t, nodeA.GetPeers(PeersConnectedOut))
+
+	net.Heal()
+	nodeA.RequestConnectOutgoing(false, nil)
+	require.Len(t, nodeA.GetPeers(PeersConnectedOut), 1)
+	nodeA.Broadcast(context.Background(), protocol.TxnTag, []byte("healed"), false, nil)
+	select {
+	case msg := <-relayReceived:
+		require.Equal(t, []byte("healed"), msg.Data)
+	case <-time.After(2 * time.Second):
+		require.FailNow(t, "message was not delivered")
+	}
+	require.Empty(t, relayReceived)
+}
+
+func TestMemoryNetworkRequest(t *testing.T) {
+	net := MakeMemoryNetwork(MemoryNetworkConfig{Latency: time.Millisecond, Jitter: time.Millisecond})
+	relay, nodeA, nodeB := makeTestMemoryTopology(t, net)
+	defer relay.Stop()
+	defer nodeA.Stop()
+	defer nodeB.Stop()
+
+	relay.RegisterHandlers([]TaggedMessageHandler{{Tag: protocol.UniCatchupReqTag, MessageHandler: HandlerFunc(func(msg IncomingMessage) OutgoingMessage {
+		topics, err := UnmarshallTopics(msg.Data)
+		require.NoError(t, err)
+		value, found := topics.GetValue("key")
+		require.True(t, found)
+		return OutgoingMessage{Action: Respond, Topics: Topics{MakeTopic("echo", value)}}
+	})}})
+
+	peers := nodeA.GetPeers(PeersConnectedOut)
+	require.Len(t, peers, 1)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	resp, err := peers[0].(UnicastPeer).Request(ctx, protocol.UniCatchupReqTag, Topics{MakeTopic("key", []byte("value"))})
+	require.NoError(t, err)
+	value, found := resp.Topics.GetValue("echo")
+	require.True(t, found)
+	require.Equal(t, []byte("value"), value)
+}
+
+func TestMemoryNetworkHTTP(t *testing.T) {
+	net := MakeMemoryNetwork(MemoryNetworkConfig{})
+	relay, nodeA, nodeB := makeTestMemoryTopology(t, net)
+	defer relay.Stop()
+	defer nodeA.Stop()
+	defer nodeB.Stop()
+
+	relay.RegisterHTTPHandler("/v1/{genesisID}/test", http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		response.Header().Set("Content-Type", "text/plain")
+		response.WriteHeader(http.StatusAccepted)
+		response.Write([]byte(request.RemoteAddr))
+	}))
+
+	peers := nodeA.GetPeers(PeersPhonebook)
+	require.Len(t, peers, 1)
+	peer := peers[0].(HTTPPeer)
+	url := peer.GetAddress() + peer.PrepareURL("/v1/{genesisID}/test")
+	response, err := peer.GetHTTPClient().Get(url)
+	require.NoError(t, err)
+	body, err := ioutil.ReadAll(response.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusAccepted, response.StatusCode)
+	require.Equal(t, "text/plain", response.Header.Get("Content-Type"))
+	require.Equal(t, "nodeA", string(body))
+
+	response, err = peer.GetHTTPClient().Get(peer.GetAddress() + "/missing")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, response.StatusCode)
+
+	net.Partition([]string{"nodeA"})
+	_, err = peer.GetHTTPClient().Get(url)
+	require.Error(t, err)
+}
+
+func TestMemoryNetworkMessageOfInterest(t *testing.T) {
+	net := MakeMemoryNetwork(MemoryNetworkConfig{})
+	relay := makeTestMemoryNode(t, net, "relay", true)
+	nodeA := makeTestMemoryNode(t, net, "nodeA", false, "relay")
+	require.NoError(t, nodeA.RegisterMessageInterest(protocol.AgreementVoteTag))
+	relay.Start()
+	nodeA.Start()
+	defer relay.Stop()
+	defer nodeA.Stop()
+	<-nodeA.Ready()
+
+	// the unknown tag isn't a default message, and nodeA didn't register an interest in it
+	received := receiveTestMessages(nodeA, protocol.UnknownMsgTag)
+	receivedVotes := receiveTestMessages(nodeA, protocol.AgreementVoteTag)
+	relay.Broadcast(context.Background(), protocol.UnknownMsgTag, []byte("filtered"), true, nil)
+	relay.Broadcast(context.Background(), protocol.AgreementVoteTag, []byte("vote"), true, nil)
+	select {
+	case msg := <-receivedVotes:
+		require.Equal(t, []byte("vote"), msg.Data)
+	case <-time.After(2 * time.Second):
+		require.FailNow(t, "message was not delivered")
+	}
+	require.Empty(t, received)
+}
+
+func TestMemoryNetworkAddressInUse(t *testing.T) {
+	net := MakeMemoryNetwork(MemoryNetworkConfig{})
+	node := makeTestMemoryNode(t, net, "node", false)
+	_, err := net.NewNode(logging.TestingLog(t), config.GetDefaultLocal(), "node", nil, "test-genesisID")
+	require.Equal(t, errMemoryNodeAddressInUse, err)
+
+	node.Start()
+	node.Stop()
+	makeTestMemoryNode(t, net, "node", false)
+}
+
+func TestMemoryNetworkSeed(t *testing.T) {
+	config := MemoryNetworkConfig{Latency: time.Millisecond, Jitter: time.Second, DropRate: 0.5, Seed: 1}
+	type choice struct {
+		delay     time.Duration
+		delivered bool
+	}
+	choices := func(net *MemoryNetwork, otherLinks int) (made []choice) {
+		for i := 0; i < 100; i++ {
+			// the choices made for the other links don't change the ones made for this one
+			for j := 0; j < otherLinks; j++ {
+				net.linkDelay("gossip", "nodeA", "nodeC")
+				net.linkDelay("http", "nodeA", "nodeB")
+			}
+			delay, delivered := net.linkDelay("gossip", "nodeA", "nodeB")
+			made = append(made, choice{delay: delay, delivered: delivered})
+		}
+		return made
+	}
+
+	first := choices(MakeMemoryNetwork(config), 0)
+	require.Equal(t, first, choices(MakeMemoryNetwork(config), 3))
+	dropped := 0
+	for _, c := range first {
+		if !c.delivered {
+			dropped++
+		}
+	}
+	require.True(t, dropped > 0 && dropped < len(first))
+
+	config.Seed = 2
+	require.NotEqual(t, first, choices(MakeMemoryNetwork(config), 0))
+}
@@ -0,0 +1,188 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/algorand/go-algorand/protocol"
+)
+
+// The peer exchange lets nodes discover relays without relying on DNS: nodes periodically ask the relays
+// they are connected to for the addresses of the relays these are connected to, and add them to their phonebook.
+// The addresses learned from a relay replace the ones previously learned from the same relay, so that the
+// phonebook keeps track of where each address came from.
+
+// peerExchangeNetworkName is the phonebook network name of the addresses learned through the peer exchange.
+const peerExchangeNetworkName = "peer-exchange"
+
+// peerExchangeAddressesKey is the topic holding the comma separated list of addresses in a peer exchange response.
+const peerExchangeAddressesKey = "addresses"
+
+// peerExchangeInterval is how often a node asks its relays for their known relays.
+const peerExchangeInterval = 10 * time.Minute
+
+// peerExchangeRequestTimeout is the time a node waits for a relay to answer a peer exchange request.
+const peerExchangeRequestTimeout = 30 * time.Second
+
+// peerExchangeMinServeInterval is the minimal time between two peer exchange requests of a peer which the
+// relay answers; more frequent requests are ignored.
+const peerExchangeMinServeInterval = time.Minute
+
+// peerExchangeMaxAddresses is the maximal number of addresses a peer exchange response may contain.
+const peerExchangeMaxAddresses = 64
+
+// peerExchangeMaxAddressLength is the maximal length of an address in a peer exchange response.
+const peerExchangeMaxAddressLength = 256
+
+// peerExchangeMaxPhonebookEntries is the maximal number of phonebook entries learned through the peer exchange.
+const peerExchangeMaxPhonebookEntries = 1000
+
+var errPeerExchangeMissingAddresses = errors.New("peer exchange response is missing the addresses")
+var errPeerExchangeTooManyAddresses = errors.New("peer exchange response has too many addresses")
+
+func peerExchangeHandler(message IncomingMessage) OutgoingMessage {
+	wn := message.Net.(*WebsocketNetwork)
+	peer := message.Sender.(*wsPeer)
+	if !wn.relayMessages {
+		// only relays share the relays they know about.
+		return OutgoingMessage{}
+	}
+	now := time.Now().UnixNano()
+	lastServed := atomic.LoadInt64(&peer.peerExchangeServed)
+	if time.Duration(now-lastServed) < peerExchangeMinServeInterval || !atomic.CompareAndSwapInt64(&peer.peerExchangeServed, lastServed, now) {
+		wn.log.Debugf("ignoring peer exchange request from %s: too frequent", peer.rootURL)
+		return OutgoingMessage{}
+	}
+	addresses := wn.peerExchangeAddresses(peer)
+	return OutgoingMessage{
+		Action: Respond,
+		Topics: Topics{MakeTopic(peerExchangeAddressesKey, []byte(strings.Join(addresses, ",")))},
+	}
+}
+
+var peerExchangeHandlers = []TaggedMessageHandler{
+	TaggedMessageHandler{protocol.PeerExchangeTag, HandlerFunc(peerExchangeHandler)},
+}
+
+// peerExchangeAddresses returns the addresses of the relays shared with the given peer: our own public address,
+// when it is configured, and the addresses of the relays we are connected to.
+func (wn *WebsocketNetwork) peerExchangeAddresses(except *wsPeer) []string {
+	addresses := make([]string, 0, peerExchangeMaxAddresses)
+	if wn.config.PublicAddress != "" {
+		addresses = append(addresses, wn.config.PublicAddress)
+	}
+	for _, peer := range wn.peerSnapshot(nil) {
+		if len(addresses) >= peerExchangeMaxAddresses {
+			break
+		}
+		if !peer.outgoing || peer == except || !validPeerExchangeAddress(peer.rootURL) {
+			continue
+		}
+		addresses = append(addresses, peer.rootURL)
+	}
+	return addresses
+}
+
+// validPeerExchangeAddress checks that an address received through the peer exchange is either a host:port
+// address or an http(s) URL.
+func validPeerExchangeAddress(addr string) bool {
+	if len(addr) == 0 || len(addr) > peerExchangeMaxAddressLength || strings.Contains(addr, ",") {
+		return false
+	}
+	if HostColonPortPattern.MatchString(addr) {
+		return true
+	}
+	parsedURL, err := url.Parse(addr)
+	if err != nil {
+		return false
+	}
+	return (parsedURL.Scheme == "http" || parsedURL.Scheme == "https") && parsedURL.Host != "" && parsedURL.User == nil
+}
+
+// parsePeerExchangeResponse returns the valid addresses of a peer exchange response, excluding our own.
+func (wn *WebsocketNetwork) parsePeerExchangeResponse(resp *Response) ([]string, error) {
+	data, found := resp.Topics.GetValue(peerExchangeAddressesKey)
+	if !found {
+		return nil, errPeerExchangeMissingAddresses
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	entries := strings.Split(string(data), ",")
+	if len(entries) > peerExchangeMaxAddresses {
+		return nil, errPeerExchangeTooManyAddresses
+	}
+	addresses := make([]string, 0, len(entries))
+	for _, addr := range entries {
+		if !validPeerExchangeAddress(addr) {
+			wn.log.Debugf("ignoring invalid peer exchange address %#v", addr)
+			continue
+		}
+		if addr == wn.config.PublicAddress {
+			continue
+		}
+		addresses = append(addresses, addr)
+	}
+	return addresses, nil
+}
+
+// exchangePeers asks the given relay for the relays it knows about, and adds them to the phonebook.
+func (wn *WebsocketNetwork) exchangePeers(peer *wsPeer) {
+	ctx, cancel := context.WithTimeout(wn.ctx, peerExchangeRequestTimeout)
+	defer cancel()
+	resp, err := peer.Request(ctx, protocol.PeerExchangeTag, Topics{})
+	if err != nil {
+		wn.log.Debugf("peer exchange with %s failed: %v", peer.rootURL, err)
+		return
+	}
+	addresses, err := wn.parsePeerExchangeResponse(resp)
+	if err != nil {
+		wn.log.Warnf("bad peer exchange response from %s: %v", peer.rootURL, err)
+		wn.disconnect(peer, disconnectBadData)
+		return
+	}
+	wn.log.Debugf("got %d peer exchange addresses from %s", len(addresses), peer.rootURL)
+	wn.phonebook.ReplaceSourcedPeerList(addresses, peerExchangeNetworkName, peer.rootURL, peerExchangeMaxPhonebookEntries)
+}
+
+// peerExchangeThread periodically asks the relays we are connected to for the relays they know about.
+func (wn *WebsocketNetwork) peerExchangeThread() {
+	defer wn.wg.Done()
+	ticker := time.NewTicker(peerExchangeInterval)
+	defer ticker.Stop()
+	var peers []*wsPeer
+	for {
+		select {
+		case <-ticker.C:
+		case <-wn.ctx.Done():
+			return
+		}
+
+		peers = wn.peerSnapshot(peers)
+		for _, peer := range peers {
+			if peer.outgoing {
+				wn.exchangePeers(peer)
+			}
+		}
+	}
+}
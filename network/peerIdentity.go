@@ -0,0 +1,323 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+// The peer identity handshake lets a node prove that it owns an ed25519 key, so that relays can
+// allowlist peers by public key rather than by IP address:
+//  1. the client sends a signed challenge along with its public key in the IdentityChallengeHeader.
+//  2. the relay answers with its own public key, the client challenge and a new challenge, all signed,
+//     in the IdentityChallengeResponseHeader. The client verifies the relay identity against it.
+//  3. once connected, the client sends the signed relay challenge in a NetIDVerifyTag message, which
+//     proves to the relay that the client owns the key it presented. Until then, the relay ignores all the
+//     other messages of the peer, does not send it any broadcast and does not list it in GetPeers. The peer
+//     is disconnected if it does not prove its identity within peerIdentityVerificationTimeout.
+
+// peerIdentityVerificationTimeout is the time given to an incoming peer to prove that it owns the
+// identity it presented before it gets disconnected.
+const peerIdentityVerificationTimeout = 30 * time.Second
+
+// identityChallengeValue is a random value a peer is asked to sign.
+type identityChallengeValue [32]byte
+
+func newIdentityChallengeValue() (v identityChallengeValue) {
+	crypto.RandBytes(v[:])
+	return
+}
+
+// identityChallenge is sent by a client to a relay when connecting to it.
+type identityChallenge struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Key       crypto.PublicKey       `codec:"pk"`
+	Challenge identityChallengeValue `codec:"c"`
+}
+
+// ToBeHashed implements the crypto.Hashable interface
+func (c identityChallenge) ToBeHashed() (protocol.HashID, []byte) {
+	return protocol.NetIDChallenge, protocol.EncodeReflect(&c)
+}
+
+type identityChallengeSigned struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Msg       identityChallenge `codec:"ic"`
+	Signature crypto.Signature  `codec:"sig"`
+}
+
+// identityChallengeResponse is sent by a relay in response to an identityChallenge.
+type identityChallengeResponse struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Key               crypto.PublicKey       `codec:"pk"`
+	Challenge         identityChallengeValue `codec:"c"`
+	ResponseChallenge identityChallengeValue `codec:"rc"`
+}
+
+// ToBeHashed implements the crypto.Hashable interface
+func (r identityChallengeResponse) ToBeHashed() (protocol.HashID, []byte) {
+	return protocol.NetIDResponse, protocol.EncodeReflect(&r)
+}
+
+type identityChallengeResponseSigned struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Msg       identityChallengeResponse `codec:"icr"`
+	Signature crypto.Signature          `codec:"sig"`
+}
+
+// identityVerification is sent by a client to a relay once connected, to answer the relay challenge.
+type identityVerification struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	ResponseChallenge identityChallengeValue `codec:"rc"`
+}
+
+// ToBeHashed implements the crypto.Hashable interface
+func (v identityVerification) ToBeHashed() (protocol.HashID, []byte) {
+	return protocol.NetIDVerify, protocol.EncodeReflect(&v)
+}
+
+type identityVerificationSigned struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Msg       identityVerification `codec:"iv"`
+	Signature crypto.Signature     `codec:"sig"`
+}
+
+// setIdentityChallengeHeader adds a new identity challenge to the request headers of an outgoing
+// connection, and returns the challenge. Nothing is added if the node has no identity.
+func (wn *WebsocketNetwork) setIdentityChallengeHeader(header http.Header) identityChallengeValue {
+	if wn.identityKeys == nil {
+		return identityChallengeValue{}
+	}
+	challenge := identityChallenge{
+		Key:       wn.identityKeys.SignatureVerifier,
+		Challenge: newIdentityChallengeValue(),
+	}
+	signed := identityChallengeSigned{
+		Msg:       challenge,
+		Signature: wn.identityKeys.Sign(challenge),
+	}
+	header.Set(IdentityChallengeHeader, base64.StdEncoding.EncodeToString(protocol.EncodeReflect(&signed)))
+	return challenge.Challenge
+}
+
+// checkIdentityResponse verifies the identity response of a relay to the given challenge. It returns the
+// identity of the relay and the challenge the relay asked us to sign, or a zero identity if no identity was
+// exchanged. An error is returned if the response is invalid, or if the relay is not allowlisted.
+func (wn *WebsocketNetwork) checkIdentityResponse(header http.Header, challenge identityChallengeValue) (identity crypto.PublicKey, responseChallenge identityChallengeValue, err error) {
+	if wn.identityKeys == nil {
+		return
+	}
+	encoded := header.Get(IdentityChallengeResponseHeader)
+	if encoded == "" {
+		if len(wn.identityAllowlist) > 0 {
+			err = fmt.Errorf("missing %s header", IdentityChallengeResponseHeader)
+		}
+		return
+	}
+	var signed identityChallengeResponseSigned
+	err = decodeIdentityHeader(encoded, &signed)
+	if err != nil {
+		return
+	}
+	if signed.Msg.Challenge != challenge {
+		err = fmt.Errorf("identity response does not match the challenge")
+		return
+	}
+	if !signed.Msg.Key.Verify(signed.Msg, signed.Signature) {
+		err = fmt.Errorf("invalid identity response signature")
+		return
+	}
+	if len(wn.identityAllowlist) > 0 && !wn.identityAllowlist[signed.Msg.Key] {
+		err = fmt.Errorf("identity %v is not allowlisted", basics.Address(signed.Msg.Key))
+		return
+	}
+	return signed.Msg.Key, signed.Msg.ResponseChallenge, nil
+}
+
+// makeIdentityVerification returns the tagged message answering the given relay challenge.
+func (wn *WebsocketNetwork) makeIdentityVerification(responseChallenge identityChallengeValue) []byte {
+	verification := identityVerification{ResponseChallenge: responseChallenge}
+	signed := identityVerificationSigned{
+		Msg:       verification,
+		Signature: wn.identityKeys.Sign(verification),
+	}
+	return append([]byte(protocol.NetIDVerifyTag), protocol.EncodeReflect(&signed)...)
+}
+
+// checkIncomingIdentity verifies the identity challenge of an incoming connection. It returns the identity
+// presented by the peer, the challenge the peer needs to sign to prove that it owns it, and the encoded
+// response to set in the IdentityChallengeResponseHeader. A zero identity is returned if no identity was exchanged.
+// If the challenge is invalid, or the peer is not allowlisted, the error response is written and the http
+// status is returned.
+func (wn *WebsocketNetwork) checkIncomingIdentity(response http.ResponseWriter, request *http.Request) (identity crypto.PublicKey, responseChallenge identityChallengeValue, encodedResponse string, status int) {
+	if wn.identityKeys == nil {
+		return identity, responseChallenge, "", http.StatusOK
+	}
+	encoded := request.Header.Get(IdentityChallengeHeader)
+	if encoded == "" {
+		if len(wn.identityAllowlist) > 0 {
+			wn.log.Infof("new peer %s did not present an identity", request.RemoteAddr)
+			networkConnectionsDroppedTotal.Inc(map[string]string{"reason": "missing identity"})
+			response.WriteHeader(http.StatusForbidden)
+			response.Write([]byte("an allowlisted identity is required"))
+			return identity, responseChallenge, "", http.StatusForbidden
+		}
+		return identity, responseChallenge, "", http.StatusOK
+	}
+
+	var signed identityChallengeSigned
+	err := decodeIdentityHeader(encoded, &signed)
+	if err == nil && !signed.Msg.Key.Verify(signed.Msg, signed.Signature) {
+		err = fmt.Errorf("invalid identity challenge signature")
+	}
+	if err != nil {
+		wn.log.Infof("new peer %s sent a bad identity challenge: %v", request.RemoteAddr, err)
+		networkConnectionsDroppedTotal.Inc(map[string]string{"reason": "bad identity"})
+		response.WriteHeader(http.StatusPreconditionFailed)
+		response.Write([]byte("bad identity challenge"))
+		return identity, responseChallenge, "", http.StatusPreconditionFailed
+	}
+	if len(wn.identityAllowlist) > 0 && !wn.identityAllowlist[signed.Msg.Key] {
+		wn.log.Infof("new peer %s identity %v is not allowlisted", request.RemoteAddr, basics.Address(signed.Msg.Key))
+		networkConnectionsDroppedTotal.Inc(map[string]string{"reason": "identity not allowlisted"})
+		response.WriteHeader(http.StatusForbidden)
+		response.Write([]byte("identity is not allowlisted"))
+		return identity, responseChallenge, "", http.StatusForbidden
+	}
+
+	challengeResponse := identityChallengeResponse{
+		Key:               wn.identityKeys.SignatureVerifier,
+		Challenge:         signed.Msg.Challenge,
+		ResponseChallenge: newIdentityChallengeValue(),
+	}
+	signedResponse := identityChallengeResponseSigned{
+		Msg:       challengeResponse,
+		Signature: wn.identityKeys.Sign(challengeResponse),
+	}
+	encodedResponse = base64.StdEncoding.EncodeToString(protocol.EncodeReflect(&signedResponse))
+	return signed.Msg.Key, challengeResponse.ResponseChallenge, encodedResponse, http.StatusOK
+}
+
+func decodeIdentityHeader(encoded string, obj interface{}) error {
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return err
+	}
+	return protocol.DecodeReflect(data, obj)
+}
+
+// identityPending returns true if the peer presented an identity it did not prove to own yet.
+func (wp *wsPeer) identityPending() bool {
+	return wp.identity != (crypto.PublicKey{}) && atomic.LoadUint32(&wp.identityVerified) == 0
+}
+
+// handleIdentityVerification checks the answer of an incoming peer to our identity challenge, and
+// returns false if the peer failed to prove that it owns its identity.
+func (wp *wsPeer) handleIdentityVerification(msg IncomingMessage) bool {
+	if !wp.identityPending() {
+		wp.net.log.Warnf("wsPeer handleIdentityVerification: unexpected identity verification from %s", wp.rootURL)
+		return true
+	}
+	var signed identityVerificationSigned
+	err := protocol.DecodeReflect(msg.Data, &signed)
+	if err != nil {
+		wp.net.log.Warnf("wsPeer handleIdentityVerification: could not decode the message from %s: %v", wp.rootURL, err)
+		return false
+	}
+	if signed.Msg.ResponseChallenge != wp.identityChallenge || !wp.identity.Verify(signed.Msg, signed.Signature) {
+		wp.net.log.Warnf("wsPeer handleIdentityVerification: peer %s failed to verify identity %v", wp.rootURL, basics.Address(wp.identity))
+		return false
+	}
+	atomic.StoreUint32(&wp.identityVerified, 1)
+	return true
+}
+
+// identityDeadline disconnects the peer if it did not prove that it owns its identity within
+// peerIdentityVerificationTimeout, whether or not it sends any message.
+func (wp *wsPeer) identityDeadline() {
+	defer wp.wg.Done()
+	timer := time.NewTimer(peerIdentityVerificationTimeout - time.Since(wp.createTime))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-wp.closing:
+		return
+	}
+	if !wp.identityPending() {
+		return
+	}
+	wp.net.log.Warnf("wsPeer identityDeadline: peer %s did not verify its identity", wp.rootURL)
+	networkConnectionsDroppedTotal.Inc(map[string]string{"reason": "unverified identity"})
+	wp.internalClose(disconnectBadIdentity)
+}
+
+// parsePeerIdentityAllowlist parses a comma separated list of peer identities, given in address form.
+func parsePeerIdentityAllowlist(allowlist string) (map[crypto.PublicKey]bool, error) {
+	identities := make(map[crypto.PublicKey]bool)
+	for _, entry := range strings.Split(allowlist, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		addr, err := basics.UnmarshalChecksumAddress(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid peer identity %s: %v", entry, err)
+		}
+		identities[crypto.PublicKey(addr)] = true
+	}
+	return identities, nil
+}
+
+// LoadPeerIdentity loads the key used to identify the node to its peers from the given file,
+// creating a new key if the file does not exist.
+func LoadPeerIdentity(filename string) (*crypto.SignatureSecrets, error) {
+	var seed crypto.Seed
+	data, err := ioutil.ReadFile(filename)
+	if os.IsNotExist(err) {
+		crypto.RandBytes(seed[:])
+		err = ioutil.WriteFile(filename, seed[:], 0600)
+		if err != nil {
+			return nil, err
+		}
+		return crypto.GenerateSignatureSecrets(seed), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != len(seed) {
+		return nil, fmt.Errorf("peer identity file %s has an invalid length %d", filename, len(data))
+	}
+	copy(seed[:], data)
+	return crypto.GenerateSignatureSecrets(seed), nil
+}
@@ -0,0 +1,244 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"context"
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+func makeTestIdentity() *crypto.SignatureSecrets {
+	var seed crypto.Seed
+	crypto.RandBytes(seed[:])
+	return crypto.GenerateSignatureSecrets(seed)
+}
+
+func TestPeerIdentityHandshake(t *testing.T) {
+	client := makeTestWebsocketNode(t)
+	client.identityKeys = makeTestIdentity()
+	relay := makeTestWebsocketNode(t)
+	relay.identityKeys = makeTestIdentity()
+	relay.identityAllowlist = map[crypto.PublicKey]bool{client.identityKeys.SignatureVerifier: true}
+
+	requestHeader := make(http.Header)
+	challenge := client.setIdentityChallengeHeader(requestHeader)
+	require.NotEmpty(t, requestHeader.Get(IdentityChallengeHeader))
+
+	request := httptest.NewRequest("GET", "/", nil)
+	request.Header = requestHeader
+	recorder := httptest.NewRecorder()
+	identity, responseChallenge, encodedResponse, status := relay.checkIncomingIdentity(recorder, request)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, client.identityKeys.SignatureVerifier, identity)
+	require.NotEmpty(t, encodedResponse)
+
+	responseHeader := make(http.Header)
+	responseHeader.Set(IdentityChallengeResponseHeader, encodedResponse)
+	relayIdentity, relayChallenge, err := client.checkIdentityResponse(responseHeader, challenge)
+	require.NoError(t, err)
+	require.Equal(t, relay.identityKeys.SignatureVerifier, relayIdentity)
+	require.Equal(t, responseChallenge, relayChallenge)
+
+	// the response needs to answer our own challenge
+	_, _, err = client.checkIdentityResponse(responseHeader, newIdentityChallengeValue())
+	require.Error(t, err)
+
+	// the client only proves its identity once it answers the relay challenge
+	peer := &wsPeer{wsPeerCore: makePeerCore(relay, "test", nil, ""), identity: identity, identityChallenge: responseChallenge}
+	require.True(t, peer.identityPending())
+	verification := client.makeIdentityVerification(newIdentityChallengeValue())
+	require.False(t, peer.handleIdentityVerification(IncomingMessage{Data: verification[len(protocol.NetIDVerifyTag):]}))
+	require.True(t, peer.identityPending())
+
+	verification = client.makeIdentityVerification(relayChallenge)
+	require.Equal(t, protocol.NetIDVerifyTag, protocol.Tag(verification[:len(protocol.NetIDVerifyTag)]))
+	require.True(t, peer.handleIdentityVerification(IncomingMessage{Data: verification[len(protocol.NetIDVerifyTag):]}))
+	require.False(t, peer.identityPending())
+
+	// a peer which does not hold the key can't answer the challenge
+	impostor := makeTestWebsocketNode(t)
+	impostor.identityKeys = makeTestIdentity()
+	peer = &wsPeer{wsPeerCore: makePeerCore(relay, "test", nil, ""), identity: identity, identityChallenge: responseChallenge}
+	verification = impostor.makeIdentityVerification(relayChallenge)
+	require.False(t, peer.handleIdentityVerification(IncomingMessage{Data: verification[len(protocol.NetIDVerifyTag):]}))
+}
+
+func TestPeerIdentityRejected(t *testing.T) {
+	relay := makeTestWebsocketNode(t)
+	relay.identityKeys = makeTestIdentity()
+	allowed := makeTestIdentity()
+	relay.identityAllowlist = map[crypto.PublicKey]bool{allowed.SignatureVerifier: true}
+
+	// peers without an identity are rejected when an allowlist is set
+	request := httptest.NewRequest("GET", "/", nil)
+	_, _, _, status := relay.checkIncomingIdentity(httptest.NewRecorder(), request)
+	require.Equal(t, http.StatusForbidden, status)
+
+	// peers which are not allowlisted are rejected
+	other := makeTestWebsocketNode(t)
+	other.identityKeys = makeTestIdentity()
+	request = httptest.NewRequest("GET", "/", nil)
+	other.setIdentityChallengeHeader(request.Header)
+	_, _, _, status = relay.checkIncomingIdentity(httptest.NewRecorder(), request)
+	require.Equal(t, http.StatusForbidden, status)
+
+	// tampered challenges are rejected
+	request = httptest.NewRequest("GET", "/", nil)
+	challenge := identityChallenge{Key: allowed.SignatureVerifier, Challenge: newIdentityChallengeValue()}
+	signed := identityChallengeSigned{Msg: challenge, Signature: other.identityKeys.Sign(challenge)}
+	request.Header.Set(IdentityChallengeHeader, base64.StdEncoding.EncodeToString(protocol.EncodeReflect(&signed)))
+	_, _, _, status = relay.checkIncomingIdentity(httptest.NewRecorder(), request)
+	require.Equal(t, http.StatusPreconditionFailed, status)
+
+	// without an allowlist, peers without an identity are accepted
+	relay.identityAllowlist = nil
+	request = httptest.NewRequest("GET", "/", nil)
+	identity, _, encodedResponse, status := relay.checkIncomingIdentity(httptest.NewRecorder(), request)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, crypto.PublicKey{}, identity)
+	require.Empty(t, encodedResponse)
+}
+
+// Set up a relay which allowlists the identity of a node, and check that messages
+// from the node are accepted once it proved its identity.
+func TestPeerIdentityConnection(t *testing.T) {
+	netA := makeTestWebsocketNode(t)
+	netA.config.GossipFanout = 1
+	netA.identityKeys = makeTestIdentity()
+	netB := makeTestWebsocketNode(t)
+	netB.config.GossipFanout = 1
+	netB.identityKeys = makeTestIdentity()
+	netA.identityAllowlist = map[crypto.PublicKey]bool{netB.identityKeys.SignatureVerifier: true}
+	netB.identityAllowlist = map[crypto.PublicKey]bool{netA.identityKeys.SignatureVerifier: true}
+
+	counter := newMessageCounter(t, 1)
+	counterDone := counter.done
+	netA.RegisterHandlers([]TaggedMessageHandler{TaggedMessageHandler{Tag: protocol.TxnTag, MessageHandler: counter}})
+	netA.Start()
+	defer func() { t.Log("stopping A"); netA.Stop(); t.Log("A done") }()
+	addrA, postListen := netA.Address()
+	require.True(t, postListen)
+	netB.phonebook.ReplacePeerList([]string{addrA}, "default")
+	netB.Start()
+	defer func() { t.Log("stopping B"); netB.Stop(); t.Log("B done") }()
+
+	readyTimeout := time.NewTimer(2 * time.Second)
+	waitReady(t, netA, readyTimeout.C)
+	waitReady(t, netB, readyTimeout.C)
+
+	// messages are only accepted once the identity is verified.
+	var peers []*wsPeer
+	require.Eventually(t, func() bool {
+		peers = netA.peerSnapshot(nil)
+		return len(peers) == 1 && !peers[0].identityPending()
+	}, 2*time.Second, 10*time.Millisecond)
+	require.Equal(t, netB.identityKeys.SignatureVerifier, peers[0].identity)
+	require.Equal(t, uint32(1), atomic.LoadUint32(&peers[0].identityVerified))
+
+	netB.Broadcast(context.Background(), protocol.TxnTag, []byte("foo"), true, nil)
+	select {
+	case <-counterDone:
+	case <-time.After(2 * time.Second):
+		t.Errorf("timeout, count=%d, wanted 1", counter.count)
+	}
+
+	peers = netB.peerSnapshot(nil)
+	require.Len(t, peers, 1)
+	require.Equal(t, netA.identityKeys.SignatureVerifier, peers[0].identity)
+}
+
+// Check that a peer which did not prove its identity yet gets no broadcast, is not listed
+// in GetPeers, and is disconnected once the verification deadline passes even if it stays silent.
+func TestPeerIdentityPending(t *testing.T) {
+	node := makeTestWebsocketNode(t)
+	node.Start()
+	defer node.Stop()
+
+	peer := &wsPeer{
+		wsPeerCore:         makePeerCore(node, "test", nil, ""),
+		conn:               &nopConnSingleton,
+		closing:            make(chan struct{}),
+		sendBufferHighPrio: make(chan sendMessage, 1),
+		sendBufferBulk:     make(chan sendMessage, 1),
+		identity:           makeTestIdentity().SignatureVerifier,
+		createTime:         time.Now().Add(100*time.Millisecond - peerIdentityVerificationTimeout),
+	}
+	require.True(t, peer.identityPending())
+	node.addPeer(peer)
+	require.Equal(t, 1, node.NumPeers())
+	require.Empty(t, node.GetPeers(PeersConnectedIn))
+
+	require.NoError(t, node.Broadcast(context.Background(), protocol.TxnTag, []byte("foo"), true, nil))
+	require.Empty(t, peer.sendBufferHighPrio)
+	require.Empty(t, peer.sendBufferBulk)
+
+	peer.wg.Add(1)
+	go peer.identityDeadline()
+	require.Eventually(t, func() bool { return node.NumPeers() == 0 }, 2*time.Second, 10*time.Millisecond)
+	peer.wg.Wait()
+}
+
+func TestParsePeerIdentityAllowlist(t *testing.T) {
+	keyA := makeTestIdentity().SignatureVerifier
+	keyB := makeTestIdentity().SignatureVerifier
+
+	allowlist, err := parsePeerIdentityAllowlist("")
+	require.NoError(t, err)
+	require.Empty(t, allowlist)
+
+	allowlist, err = parsePeerIdentityAllowlist(basics.Address(keyA).String() + ", " + basics.Address(keyB).String())
+	require.NoError(t, err)
+	require.Equal(t, map[crypto.PublicKey]bool{keyA: true, keyB: true}, allowlist)
+
+	_, err = parsePeerIdentityAllowlist("not an address")
+	require.Error(t, err)
+}
+
+func TestLoadPeerIdentity(t *testing.T) {
+	dir, err := ioutil.TempDir("", "peerIdentity")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "peer_identity.key")
+
+	created, err := LoadPeerIdentity(filename)
+	require.NoError(t, err)
+	stat, err := os.Stat(filename)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), stat.Mode().Perm())
+
+	loaded, err := LoadPeerIdentity(filename)
+	require.NoError(t, err)
+	require.Equal(t, created.SignatureVerifier, loaded.SignatureVerifier)
+
+	require.NoError(t, ioutil.WriteFile(filename, []byte("short"), 0600))
+	_, err = LoadPeerIdentity(filename)
+	require.Error(t, err)
+}
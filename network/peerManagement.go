@@ -0,0 +1,175 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// operatorPhonebookNetworkName is the phonebook network name of the addresses added by the node operator.
+const operatorPhonebookNetworkName = "operator"
+
+// PeerInfo describes a peer the node is connected to, as reported to the node operator.
+type PeerInfo struct {
+	// Address is the root URL of outgoing peers, or the public address announced by incoming peers, which may be empty.
+	Address string
+
+	// RemoteHost is the remote host of the connection.
+	RemoteHost string
+
+	Outgoing      bool
+	InstanceName  string
+	TelemetryGUID string
+	Version       string
+
+	// ConnectedSince is the time at which the connection was established.
+	ConnectedSince time.Time
+
+	// PingRoundTripTime is the round trip time of the last ping sent to the peer, or zero if none completed yet.
+	PingRoundTripTime time.Duration
+
+	MessagesReceived uint64
+	MessagesSent     uint64
+	PrioWeight       uint64
+}
+
+// remoteHost returns the host the connection with the peer was made with.
+func (wp *wsPeer) remoteHost() string {
+	if !wp.outgoing {
+		return wp.originAddress
+	}
+	if addr := wp.conn.RemoteAddr(); addr != nil {
+		return justHost(addr.String())
+	}
+	return ""
+}
+
+// PeerInfos returns a description of each of the peers the node is currently connected to.
+func (wn *WebsocketNetwork) PeerInfos() []PeerInfo {
+	peers := wn.peerSnapshot(nil)
+	infos := make([]PeerInfo, len(peers))
+	for i, peer := range peers {
+		_, rtt := peer.pingTimes()
+		infos[i] = PeerInfo{
+			Address:           peer.rootURL,
+			RemoteHost:        peer.remoteHost(),
+			Outgoing:          peer.outgoing,
+			InstanceName:      peer.InstanceName,
+			TelemetryGUID:     peer.TelemetryGUID,
+			Version:           peer.version,
+			ConnectedSince:    peer.createTime,
+			PingRoundTripTime: rtt,
+			MessagesReceived:  atomic.LoadUint64(&peer.messagesReceived),
+			MessagesSent:      atomic.LoadUint64(&peer.messagesSent),
+			PrioWeight:        peer.prioWeight,
+		}
+	}
+	return infos
+}
+
+// DisconnectPeerAddress disconnects the peers whose address or remote host matches the given address, and
+// returns the number of peers which were disconnected.
+func (wn *WebsocketNetwork) DisconnectPeerAddress(address string) int {
+	return wn.disconnectMatching(disconnectOperatorRequest, func(peer *wsPeer) bool {
+		return address != "" && (peer.rootURL == address || sameHost(peer.remoteHost(), address))
+	})
+}
+
+// disconnectMatching disconnects the peers matching the given predicate, and returns their number.
+func (wn *WebsocketNetwork) disconnectMatching(reason disconnectReason, match func(peer *wsPeer) bool) int {
+	count := 0
+	for _, peer := range wn.peerSnapshot(nil) {
+		if match(peer) {
+			wn.disconnect(peer, reason)
+			count++
+		}
+	}
+	return count
+}
+
+// AddPhonebookAddress adds the address of a relay to the phonebook, so that the node may connect to it.
+// The address stays in the phonebook regardless of the DNS bootstrap updates.
+func (wn *WebsocketNetwork) AddPhonebookAddress(address string) error {
+	address = strings.TrimSpace(address)
+	if address == "" {
+		return errors.New("empty address")
+	}
+	parsedURL, err := ParseHostOrURL(address)
+	if err != nil || parsedURL.Host == "" {
+		return fmt.Errorf("invalid address %s, expected host:port or a URL", address)
+	}
+	wn.phonebook.ExtendPeerList([]string{address}, operatorPhonebookNetworkName)
+	return nil
+}
+
+// BanHost disconnects the peers connected from or to the given IP address, and rejects the connections
+// with it until the ban expires.
+func (wn *WebsocketNetwork) BanHost(host string, duration time.Duration) error {
+	ip := parseHostIP(host)
+	if ip == nil {
+		return fmt.Errorf("invalid IP address %s", host)
+	}
+	if duration <= 0 {
+		return fmt.Errorf("invalid ban duration %v", duration)
+	}
+	wn.bannedHostsMu.Lock()
+	wn.bannedHosts[ip.String()] = time.Now().Add(duration)
+	wn.bannedHostsMu.Unlock()
+	wn.log.Infof("banned host %s for %v", ip, duration)
+
+	wn.disconnectMatching(disconnectBannedHost, func(peer *wsPeer) bool {
+		return ip.Equal(parseHostIP(peer.remoteHost()))
+	})
+	return nil
+}
+
+// isBanned returns true if the given host is currently banned, clearing the expired bans.
+func (wn *WebsocketNetwork) isBanned(host string) bool {
+	wn.bannedHostsMu.Lock()
+	defer wn.bannedHostsMu.Unlock()
+	now := time.Now()
+	for bannedHost, expires := range wn.bannedHosts {
+		if !now.Before(expires) {
+			delete(wn.bannedHosts, bannedHost)
+		}
+	}
+	if ip := parseHostIP(host); ip != nil {
+		host = ip.String()
+	}
+	_, banned := wn.bannedHosts[host]
+	return banned
+}
+
+// parseHostIP parses a host which is an IP address, possibly enclosed in brackets, returning nil if it isn't one.
+func parseHostIP(host string) net.IP {
+	return net.ParseIP(strings.TrimSuffix(strings.TrimPrefix(host, "["), "]"))
+}
+
+// sameHost returns true if the given hosts are the same, comparing them as IP addresses when they are ones, so
+// that the different ways of writing an address match.
+func sameHost(a, b string) bool {
+	ipA, ipB := parseHostIP(a), parseHostIP(b)
+	if ipA == nil || ipB == nil {
+		return a == b
+	}
+	return ipA.Equal(ipB)
+}
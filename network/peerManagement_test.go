@@ -0,0 +1,131 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/protocol"
+)
+
+func TestPeerInfosAndDisconnect(t *testing.T) {
+	netA := makeTestWebsocketNode(t)
+	netA.config.GossipFanout = 1
+	netA.Start()
+	defer netA.Stop()
+	netB := makeTestWebsocketNode(t)
+	netB.config.GossipFanout = 1
+	addrA, postListen := netA.Address()
+	require.True(t, postListen)
+	netB.phonebook.ReplacePeerList([]string{addrA}, "default")
+	netB.Start()
+	defer netB.Stop()
+
+	readyTimeout := time.NewTimer(2 * time.Second)
+	waitReady(t, netA, readyTimeout.C)
+	waitReady(t, netB, readyTimeout.C)
+
+	infosA := netA.PeerInfos()
+	require.Len(t, infosA, 1)
+	require.False(t, infosA[0].Outgoing)
+	require.Equal(t, "127.0.0.1", infosA[0].RemoteHost)
+	infosB := netB.PeerInfos()
+	require.Len(t, infosB, 1)
+	require.True(t, infosB[0].Outgoing)
+	require.Equal(t, addrA, infosB[0].Address)
+	require.Equal(t, "127.0.0.1", infosB[0].RemoteHost)
+	require.NotEmpty(t, infosB[0].Version)
+	require.False(t, infosB[0].ConnectedSince.IsZero())
+
+	require.NoError(t, netB.Broadcast(context.Background(), protocol.TxnTag, []byte("txn"), true, nil))
+	require.Eventually(t, func() bool {
+		infosA := netA.PeerInfos()
+		infosB := netB.PeerInfos()
+		return len(infosA) == 1 && infosA[0].MessagesReceived > 0 && len(infosB) == 1 && infosB[0].MessagesSent > 0
+	}, 2*time.Second, 10*time.Millisecond)
+
+	require.Equal(t, 0, netB.DisconnectPeerAddress("http://127.0.0.1:1"))
+	require.Equal(t, 0, netB.DisconnectPeerAddress(""))
+	require.Equal(t, 1, netB.DisconnectPeerAddress(addrA))
+	require.Empty(t, netB.PeerInfos())
+}
+
+func TestAddPhonebookAddress(t *testing.T) {
+	wn := makeTestWebsocketNode(t)
+	require.Error(t, wn.AddPhonebookAddress(""))
+	require.Error(t, wn.AddPhonebookAddress("no-port"))
+	require.NoError(t, wn.AddPhonebookAddress("relay.algorand.network:4160"))
+	require.Equal(t, []string{"relay.algorand.network:4160"}, wn.phonebook.GetAddresses(10))
+
+	// the DNS bootstrap updates leave the address in place
+	wn.phonebook.ReplacePeerList([]string{"other.algorand.network:4160"}, wn.config.DNSBootstrapID)
+	require.ElementsMatch(t, []string{"relay.algorand.network:4160", "other.algorand.network:4160"}, wn.phonebook.GetAddresses(10))
+}
+
+func TestBanHost(t *testing.T) {
+	netA := makeTestWebsocketNode(t)
+	netA.config.GossipFanout = 1
+	netA.Start()
+	defer netA.Stop()
+	netB := makeTestWebsocketNode(t)
+	netB.config.GossipFanout = 1
+	addrA, postListen := netA.Address()
+	require.True(t, postListen)
+	netB.phonebook.ReplacePeerList([]string{addrA}, "default")
+	netB.Start()
+	defer netB.Stop()
+
+	readyTimeout := time.NewTimer(2 * time.Second)
+	waitReady(t, netA, readyTimeout.C)
+	waitReady(t, netB, readyTimeout.C)
+
+	require.Error(t, netA.BanHost("not-an-ip", time.Minute))
+	require.Error(t, netA.BanHost("127.0.0.1", 0))
+	require.False(t, netA.isBanned("127.0.0.1"))
+
+	// the ban applies to the address regardless of how it is written
+	require.NoError(t, netA.BanHost("::ffff:127.0.0.1", time.Minute))
+	require.True(t, netA.isBanned("127.0.0.1"))
+	require.True(t, netA.isBanned("[::ffff:127.0.0.1]"))
+	require.False(t, netA.isBanned("10.0.0.1"))
+	require.Empty(t, netA.PeerInfos())
+
+	// incoming connections from the banned host are rejected
+	netB.RequestConnectOutgoing(false, nil)
+	time.Sleep(100 * time.Millisecond)
+	require.Empty(t, netA.PeerInfos())
+
+	// bans expire
+	require.NoError(t, netA.BanHost("10.0.0.1", time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+	require.False(t, netA.isBanned("10.0.0.1"))
+}
+
+func TestSameHost(t *testing.T) {
+	require.True(t, sameHost("127.0.0.1", "127.0.0.1"))
+	require.True(t, sameHost("127.0.0.1", "::ffff:127.0.0.1"))
+	require.True(t, sameHost("::1", "[::1]"))
+	require.True(t, sameHost("2001:db8::1", "2001:0db8:0:0:0:0:0:1"))
+	require.False(t, sameHost("127.0.0.1", "127.0.0.2"))
+	require.False(t, sameHost("::1", "127.0.0.1"))
+	require.True(t, sameHost("relay.algorand.network", "relay.algorand.network"))
+	require.False(t, sameHost("relay.algorand.network", "127.0.0.1"))
+}
@@ -0,0 +1,158 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"time"
+
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/algorand/go-algorand/util/metrics"
+)
+
+// peerQuotaThrottleWindow is the period over which the time an incoming peer spends being throttled is accumulated.
+const peerQuotaThrottleWindow = time.Minute
+
+// the per-peer quota metrics are gauges labeled by peer address, so that the values of disconnected peers expire.
+var networkPeerThrottledMessagesTotal = metrics.MakeGauge(metrics.NetworkPeerThrottledMessagesTotal)
+var networkPeerThrottledSecondsTotal = metrics.MakeGauge(metrics.NetworkPeerThrottledSecondsTotal)
+
+// tokenBucket is a token bucket rate limiter: tokens are added to the bucket at a fixed rate, up to its capacity, and
+// each unit of work takes a token out of the bucket. The bucket is allowed to go into deficit, in which case the caller
+// is expected to wait until the bucket is refilled. It is not safe for concurrent use.
+type tokenBucket struct {
+	rate     float64 // tokens per second
+	capacity float64
+	tokens   float64
+	last     time.Time
+}
+
+func makeTokenBucket(rate uint64, burst time.Duration, now time.Time) *tokenBucket {
+	capacity := float64(rate) * burst.Seconds()
+	return &tokenBucket{
+		rate:     float64(rate),
+		capacity: capacity,
+		tokens:   capacity,
+		last:     now,
+	}
+}
+
+// take removes n tokens from the bucket, and returns how long the caller has to wait for the bucket to be
+// refilled, or zero if there were enough tokens in the bucket.
+func (b *tokenBucket) take(n float64, now time.Time) time.Duration {
+	if now.After(b.last) {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.capacity {
+			b.tokens = b.capacity
+		}
+		b.last = now
+	}
+	b.tokens -= n
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// peerQuotas tracks the bandwidth and message rate quotas of an incoming peer. It is only used by the read loop
+// of the peer.
+type peerQuotas struct {
+	// bandwidth limits the number of bytes received from the peer; nil if there is no bandwidth quota.
+	bandwidth *tokenBucket
+
+	// messages limits the number of messages received from the peer, per tag.
+	messages map[protocol.Tag]*tokenBucket
+
+	// throttleLimit is how long the peer may be throttled within a throttle window before being disconnected.
+	throttleLimit time.Duration
+
+	windowStart time.Time
+	throttled   time.Duration
+}
+
+// makePeerQuotas creates the quotas of an incoming peer from the configuration. It returns nil if no quota
+// is configured.
+func makePeerQuotas(cfg config.Local, now time.Time) *peerQuotas {
+	burst := time.Duration(cfg.PeerQuotaBurstSeconds) * time.Second
+	if burst < time.Second {
+		burst = time.Second
+	}
+	q := &peerQuotas{
+		messages:      make(map[protocol.Tag]*tokenBucket),
+		throttleLimit: time.Duration(cfg.PeerQuotaThrottleLimitSeconds) * time.Second,
+		windowStart:   now,
+	}
+	if cfg.PeerBandwidthQuota > 0 {
+		q.bandwidth = makeTokenBucket(cfg.PeerBandwidthQuota, burst, now)
+	}
+	for tag, rate := range cfg.PeerMessageRateQuotas {
+		if rate > 0 {
+			q.messages[protocol.Tag(tag)] = makeTokenBucket(rate, burst, now)
+		}
+	}
+	if q.bandwidth == nil && len(q.messages) == 0 {
+		return nil
+	}
+	return q
+}
+
+// take accounts for a message of the given tag and size received from the peer. It returns how long the peer
+// should be throttled for, and whether the peer has been throttled for longer than allowed and should be disconnected.
+func (q *peerQuotas) take(tag protocol.Tag, size int, now time.Time) (wait time.Duration, exceeded bool) {
+	if q.bandwidth != nil {
+		wait = q.bandwidth.take(float64(size), now)
+	}
+	if bucket, has := q.messages[tag]; has {
+		if tagWait := bucket.take(1, now); tagWait > wait {
+			wait = tagWait
+		}
+	}
+	if wait <= 0 {
+		return 0, false
+	}
+	if now.Sub(q.windowStart) >= peerQuotaThrottleWindow {
+		q.windowStart = now
+		q.throttled = 0
+	}
+	q.throttled += wait
+	return wait, q.throttled > q.throttleLimit
+}
+
+// throttle applies the quotas of the peer to a message received from it, blocking the read loop for as long
+// as the peer is over its quotas. It returns false if the peer should be disconnected.
+func (wp *wsPeer) throttle(tag protocol.Tag, size int) bool {
+	wait, exceeded := wp.quotas.take(tag, size, time.Now())
+	if wait == 0 {
+		return true
+	}
+	labels := map[string]string{"peer": wp.originAddress}
+	networkPeerThrottledMessagesTotal.Add(1, labels)
+	if exceeded {
+		wp.net.log.Warnf("wsPeer throttle: peer %s exceeded its quotas for too long", wp.originAddress)
+		networkConnectionsDroppedTotal.Inc(map[string]string{"reason": "quota exceeded"})
+		return false
+	}
+	networkPeerThrottledSecondsTotal.Add(wait.Seconds(), labels)
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-wp.closing:
+		return false
+	}
+}
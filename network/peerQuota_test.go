@@ -0,0 +1,149 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/protocol"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+	bucket := makeTokenBucket(10, 2*time.Second, now)
+
+	// the burst is available right away
+	for i := 0; i < 20; i++ {
+		require.Equal(t, time.Duration(0), bucket.take(1, now))
+	}
+	require.Equal(t, 100*time.Millisecond, bucket.take(1, now))
+	require.Equal(t, 200*time.Millisecond, bucket.take(1, now))
+
+	// tokens are refilled over time, up to the capacity of the bucket
+	now = now.Add(time.Second)
+	require.Equal(t, time.Duration(0), bucket.take(8, now))
+	now = now.Add(time.Hour)
+	require.Equal(t, time.Duration(0), bucket.take(20, now))
+	require.Equal(t, 500*time.Millisecond, bucket.take(5, now))
+}
+
+func TestMakePeerQuotas(t *testing.T) {
+	cfg := defaultConfig
+	cfg.PeerBandwidthQuota = 0
+	cfg.PeerMessageRateQuotas = nil
+	require.Nil(t, makePeerQuotas(cfg, time.Now()))
+
+	cfg.PeerMessageRateQuotas = map[string]uint64{"TX": 0}
+	require.Nil(t, makePeerQuotas(cfg, time.Now()))
+
+	cfg.PeerMessageRateQuotas = map[string]uint64{"TX": 100, "AV": 0}
+	q := makePeerQuotas(cfg, time.Now())
+	require.NotNil(t, q)
+	require.Nil(t, q.bandwidth)
+	require.Len(t, q.messages, 1)
+	require.Contains(t, q.messages, protocol.TxnTag)
+
+	cfg.PeerMessageRateQuotas = nil
+	cfg.PeerBandwidthQuota = 1000
+	q = makePeerQuotas(cfg, time.Now())
+	require.NotNil(t, q)
+	require.NotNil(t, q.bandwidth)
+	require.Empty(t, q.messages)
+}
+
+func TestPeerQuotasThrottleLimit(t *testing.T) {
+	cfg := defaultConfig
+	cfg.PeerBandwidthQuota = 100
+	cfg.PeerMessageRateQuotas = map[string]uint64{"TX": 1}
+	cfg.PeerQuotaBurstSeconds = 1
+	cfg.PeerQuotaThrottleLimitSeconds = 2
+	now := time.Now()
+	q := makePeerQuotas(cfg, now)
+
+	// other tags are only subject to the bandwidth quota
+	wait, exceeded := q.take(protocol.AgreementVoteTag, 100, now)
+	require.Equal(t, time.Duration(0), wait)
+	require.False(t, exceeded)
+	wait, exceeded = q.take(protocol.AgreementVoteTag, 50, now)
+	require.Equal(t, 500*time.Millisecond, wait)
+	require.False(t, exceeded)
+
+	// the longest wait of the bandwidth and tag quotas is applied
+	now = now.Add(time.Second)
+	wait, exceeded = q.take(protocol.TxnTag, 10, now)
+	require.Equal(t, time.Duration(0), wait)
+	require.False(t, exceeded)
+	wait, exceeded = q.take(protocol.TxnTag, 10, now)
+	require.Equal(t, time.Second, wait)
+	require.False(t, exceeded)
+
+	// the peer gets disconnected once it was throttled for too long within the window
+	wait, exceeded = q.take(protocol.TxnTag, 10, now)
+	require.Equal(t, 2*time.Second, wait)
+	require.True(t, exceeded)
+
+	// a new window starts afresh
+	now = now.Add(peerQuotaThrottleWindow)
+	q.take(protocol.TxnTag, 10, now)
+	wait, exceeded = q.take(protocol.TxnTag, 10, now)
+	require.Equal(t, time.Second, wait)
+	require.False(t, exceeded)
+}
+
+// Set up two nodes where A applies a message rate quota to its incoming peers, and check that
+// A disconnects B once B floods it with transactions.
+func TestWebsocketNetworkPeerQuotaExceeded(t *testing.T) {
+	cfg := defaultConfig
+	cfg.GossipFanout = 1
+	cfg.PeerMessageRateQuotas = map[string]uint64{"TX": 1}
+	cfg.PeerQuotaBurstSeconds = 1
+	cfg.PeerQuotaThrottleLimitSeconds = 0
+	netA := makeTestWebsocketNodeWithConfig(t, cfg)
+	netA.Start()
+	defer netA.Stop()
+	netB := makeTestWebsocketNode(t)
+	netB.config.GossipFanout = 1
+	addrA, postListen := netA.Address()
+	require.True(t, postListen)
+	netB.phonebook.ReplacePeerList([]string{addrA}, "default")
+	netB.Start()
+	defer netB.Stop()
+
+	readyTimeout := time.NewTimer(2 * time.Second)
+	waitReady(t, netA, readyTimeout.C)
+	waitReady(t, netB, readyTimeout.C)
+
+	peersA := netA.peerSnapshot(nil)
+	require.Len(t, peersA, 1)
+	require.NotNil(t, peersA[0].quotas)
+	peersB := netB.peerSnapshot(nil)
+	require.Len(t, peersB, 1)
+	require.Nil(t, peersB[0].quotas)
+
+	for i := 0; i < 5; i++ {
+		netB.Broadcast(context.Background(), protocol.TxnTag, []byte{byte(i)}, true, nil)
+	}
+	select {
+	case <-peersB[0].closing:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("peer was not disconnected after exceeding its quota")
+	}
+}
@@ -56,6 +56,11 @@ type Phonebook interface {
 
 	// ExtendPeerList adds unique addresses to this set of addresses
 	ExtendPeerList(more []string, networkName string)
+
+	// ReplaceSourcedPeerList merges a set of addresses learned from source with the ones previously learned from it for networkName
+	// new entries are being added, as long as networkName has no more than maxEntries entries
+	// existing items learned from source that aren't included in addresses lose source, and are removed once they have no source left
+	ReplaceSourcedPeerList(addresses []string, networkName string, source string, maxEntries int)
 }
 
 // addressData: holds the information associated with each phonebook address.
@@ -63,10 +68,12 @@ type Phonebook interface {
 // recentConnectionTimes: is the log of connection times used to observe the maximum
 //                        connections to the address in a given time window.
 // networkNames: lists the networks to which the given address belongs.
+// sources: lists the sources from which the address was learned, for the addresses added with ReplaceSourcedPeerList.
 type addressData struct {
 	retryAfter            time.Time
 	recentConnectionTimes []time.Time
 	networkNames          map[string]bool
+	sources               map[string]bool
 }
 
 func makePhonebookEntryData(networkName string) addressData {
@@ -309,6 +316,60 @@ func (e *phonebookImpl) ExtendPeerList(more []string, networkName string) {
 	}
 }
 
+// ReplaceSourcedPeerList merges a set of addresses learned from source with the ones previously learned from it.
+// new entries in addresses are being added, as long as networkName has no more than maxEntries entries
+// existing items learned from source that aren't included in addresses lose source,
+// and are removed from networkName once they have no source left
+// matching entries don't change
+func (e *phonebookImpl) ReplaceSourcedPeerList(addresses []string, networkName string, source string, maxEntries int) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	// prepare a map of items we'd like to remove, and count the entries of networkName.
+	removeItems := make(map[string]bool, 0)
+	networkEntries := 0
+	for k, pbd := range e.data {
+		if pbd.networkNames[networkName] {
+			networkEntries++
+			if pbd.sources[source] {
+				removeItems[k] = true
+			}
+		}
+	}
+
+	for _, addr := range addresses {
+		pbData, has := e.data[addr]
+		if !has || !pbData.networkNames[networkName] {
+			if networkEntries >= maxEntries {
+				continue
+			}
+			networkEntries++
+		}
+		if !has {
+			pbData = makePhonebookEntryData(networkName)
+			e.data[addr] = pbData
+		}
+		pbData.networkNames[networkName] = true
+		if pbData.sources == nil {
+			pbData.sources = make(map[string]bool)
+			e.data[addr] = pbData
+		}
+		pbData.sources[source] = true
+
+		// do not remove this entry
+		delete(removeItems, addr)
+	}
+
+	// remove the source of the items that were missing in addresses
+	for k := range removeItems {
+		pbData := e.data[k]
+		delete(pbData.sources, source)
+		if len(pbData.sources) == 0 {
+			e.deletePhonebookEntry(k, networkName)
+		}
+	}
+}
+
 // Length returns the number of addrs contained
 func (e *phonebookImpl) Length() int {
 	e.lock.RLock()
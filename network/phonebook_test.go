@@ -349,6 +349,29 @@ func TestWaitAndAddConnectionTimeShortWindow(t *testing.T) {
 	require.Equal(t, 1, len(phBookData))
 }
 
+func TestReplaceSourcedPeerList(t *testing.T) {
+	entries := MakePhonebook(1, 1).(*phonebookImpl)
+	entries.ReplacePeerList([]string{"a:4160", "b:4160"}, "dns")
+
+	entries.ReplaceSourcedPeerList([]string{"b:4160", "c:4160"}, "exchange", "relay1", 10)
+	entries.ReplaceSourcedPeerList([]string{"c:4160", "d:4160"}, "exchange", "relay2", 10)
+	testPhonebookAll(t, []string{"a:4160", "b:4160", "c:4160", "d:4160"}, entries)
+	require.Equal(t, map[string]bool{"relay1": true, "relay2": true}, entries.data["c:4160"].sources)
+
+	// addresses no longer advertised by a source are removed once they have no source left
+	entries.ReplaceSourcedPeerList([]string{}, "exchange", "relay1", 10)
+	testPhonebookAll(t, []string{"a:4160", "b:4160", "c:4160", "d:4160"}, entries)
+	require.Equal(t, map[string]bool{"relay2": true}, entries.data["c:4160"].sources)
+	require.Equal(t, map[string]bool{"dns": true}, entries.data["b:4160"].networkNames)
+
+	entries.ReplaceSourcedPeerList([]string{}, "exchange", "relay2", 10)
+	testPhonebookAll(t, []string{"a:4160", "b:4160"}, entries)
+
+	// the number of entries learned is limited
+	entries.ReplaceSourcedPeerList([]string{"e:4160", "f:4160", "g:4160"}, "exchange", "relay1", 2)
+	require.Equal(t, 4, entries.Length())
+}
+
 func BenchmarkThreadsafePhonebook(b *testing.B) {
 	ph := MakePhonebook(1, 1).(*phonebookImpl)
 	threads := 5
@@ -0,0 +1,88 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/algorand/go-algorand/config"
+)
+
+// makeTLSConfigs creates the TLS configurations used for mutual TLS on the gossip network.
+// When no certificate authority is configured, both configurations are nil, and the default
+// TLS settings are used: incoming connections are not asked for a client certificate, and
+// relay certificates are verified against the system roots.
+// An error is returned if TLS is requested without a node certificate, since the listener would
+// otherwise silently fall back to plain http.
+func makeTLSConfigs(cfg config.Local) (serverConfig *tls.Config, clientConfig *tls.Config, err error) {
+	if (cfg.TLSCAFile != "" || cfg.EnableGossipTLS) && (cfg.TLSCertFile == "" || cfg.TLSKeyFile == "") {
+		return nil, nil, fmt.Errorf("TLSCertFile and TLSKeyFile are required when TLSCAFile or EnableGossipTLS is set")
+	}
+	if cfg.TLSCAFile == "" {
+		return nil, nil, nil
+	}
+	caPEM, err := ioutil.ReadFile(cfg.TLSCAFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read TLS certificate authority file %s: %v", cfg.TLSCAFile, err)
+	}
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(caPEM) {
+		return nil, nil, fmt.Errorf("no certificate found in TLS certificate authority file %s", cfg.TLSCAFile)
+	}
+
+	serverConfig = &tls.Config{
+		ClientCAs:  caPool,
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		MinVersion: tls.VersionTLS12,
+	}
+	clientConfig = &tls.Config{
+		RootCAs:    caPool,
+		MinVersion: tls.VersionTLS12,
+	}
+	// present the node certificate to the relays we connect to.
+	cert, err := tls.LoadX509KeyPair(cfg.TLSCertFile, cfg.TLSKeyFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load TLS certificate: %v", err)
+	}
+	clientConfig.Certificates = []tls.Certificate{cert}
+	return serverConfig, clientConfig, nil
+}
+
+// setupTLS applies the mutual TLS configuration to the http server and to the outgoing connections
+// made by the network.
+func (wn *WebsocketNetwork) setupTLS() error {
+	serverConfig, clientConfig, err := makeTLSConfigs(wn.config)
+	if err != nil {
+		return err
+	}
+	wn.server.TLSConfig = serverConfig
+	wn.tlsClientConfig = clientConfig
+	wn.transport.innerTransport.TLSClientConfig = clientConfig
+	return nil
+}
+
+// peerRootURL returns the root url of the relay at the given address. When EnableGossipTLS is set,
+// relays given as host:port are contacted over https.
+func (wn *WebsocketNetwork) peerRootURL(addr string) string {
+	if wn.config.EnableGossipTLS && HostColonPortPattern.MatchString(addr) {
+		return "https://" + addr
+	}
+	return addr
+}
@@ -0,0 +1,122 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestCertificate creates a self-signed certificate and writes it, along with its key, in dir.
+func writeTestCertificate(t *testing.T, dir string) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	require.NoError(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}), 0600))
+	require.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return
+}
+
+func TestMakeTLSConfigs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tlsConfig")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	certFile, keyFile := writeTestCertificate(t, dir)
+
+	cfg := defaultConfig
+	serverConfig, clientConfig, err := makeTLSConfigs(cfg)
+	require.NoError(t, err)
+	require.Nil(t, serverConfig)
+	require.Nil(t, clientConfig)
+
+	// TLS without a node certificate would leave the listener on plain http.
+	cfg.TLSCAFile = certFile
+	_, _, err = makeTLSConfigs(cfg)
+	require.Error(t, err)
+
+	cfg.TLSCAFile = ""
+	cfg.EnableGossipTLS = true
+	_, _, err = makeTLSConfigs(cfg)
+	require.Error(t, err)
+
+	cfg.TLSCertFile = certFile
+	_, _, err = makeTLSConfigs(cfg)
+	require.Error(t, err)
+
+	cfg.TLSKeyFile = keyFile
+	serverConfig, clientConfig, err = makeTLSConfigs(cfg)
+	require.NoError(t, err)
+	require.Nil(t, serverConfig)
+	require.Nil(t, clientConfig)
+
+	cfg.TLSCAFile = certFile
+	serverConfig, clientConfig, err = makeTLSConfigs(cfg)
+	require.NoError(t, err)
+	require.Equal(t, tls.RequireAndVerifyClientCert, serverConfig.ClientAuth)
+	require.NotNil(t, serverConfig.ClientCAs)
+	require.NotNil(t, clientConfig.RootCAs)
+	require.Len(t, clientConfig.Certificates, 1)
+
+	cfg.TLSCAFile = keyFile
+	_, _, err = makeTLSConfigs(cfg)
+	require.Error(t, err)
+
+	cfg.TLSCAFile = filepath.Join(dir, "missing.pem")
+	_, _, err = makeTLSConfigs(cfg)
+	require.Error(t, err)
+}
+
+func TestPeerRootURL(t *testing.T) {
+	wn := makeTestWebsocketNode(t)
+	wn.GenesisID = "test genesisID"
+	require.Equal(t, "r7.algodev.network.:4166", wn.peerRootURL("r7.algodev.network.:4166"))
+
+	wn.config.EnableGossipTLS = true
+	require.Equal(t, "https://r7.algodev.network.:4166", wn.peerRootURL("r7.algodev.network.:4166"))
+	require.Equal(t, "http://r7.algodev.network.:4166", wn.peerRootURL("http://r7.algodev.network.:4166"))
+	addrtest(t, wn, "wss://r7.algodev.network.:4166/v1/test%20genesisID/gossip", "r7.algodev.network.:4166")
+	addrtest(t, wn, "ws://r7.algodev.network.:4166/v1/test%20genesisID/gossip", "http://r7.algodev.network.:4166")
+}
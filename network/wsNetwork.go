@@ -19,6 +19,7 @@ package network
 import (
 	"container/heap"
 	"context"
+	"crypto/tls"
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -381,6 +382,21 @@ type WebsocketNetwork struct {
 	// that messagesOfInterestEnc does not change once it is set during
 	// network start.
 	messagesOfInterestMu deadlock.Mutex
+
+	// tlsClientConfig is the TLS configuration used for outgoing connections when mutual TLS is enabled.
+	tlsClientConfig *tls.Config
+
+	// identityKeys is the key used to identify this node to its peers. When nil, the peer identity
+	// handshake is disabled.
+	identityKeys *crypto.SignatureSecrets
+
+	// identityAllowlist is the set of peer identities allowed to connect to this node. When empty,
+	// any peer is accepted.
+	identityAllowlist map[crypto.PublicKey]bool
+
+	// bannedHosts maps the hosts banned by the node operator to the time at which their ban expires.
+	bannedHosts   map[string]time.Time
+	bannedHostsMu deadlock.Mutex
 }
 
 type broadcastRequest struct {
@@ -560,13 +576,13 @@ func (wn *WebsocketNetwork) GetPeers(options ...PeerOption) []Peer {
 			var addrs []string
 			addrs = wn.phonebook.GetAddresses(1000)
 			for _, addr := range addrs {
-				peerCore := makePeerCore(wn, addr, wn.GetRoundTripper(), "" /*origin address*/)
+				peerCore := makePeerCore(wn, wn.peerRootURL(addr), wn.GetRoundTripper(), "" /*origin address*/)
 				outPeers = append(outPeers, &peerCore)
 			}
 		case PeersConnectedIn:
 			wn.peersLock.RLock()
 			for _, peer := range wn.peers {
-				if !peer.outgoing {
+				if !peer.outgoing && !peer.identityPending() {
 					outPeers = append(outPeers, Peer(peer))
 				}
 			}
@@ -617,6 +633,7 @@ func (wn *WebsocketNetwork) setup() {
 	wn.meshUpdateRequests = make(chan meshRequest, 5)
 	wn.readyChan = make(chan struct{})
 	wn.tryConnectAddrs = make(map[string]int64)
+	wn.bannedHosts = make(map[string]time.Time)
 	wn.eventualReadyDelay = time.Minute
 	wn.prioTracker = newPrioTracker(wn)
 	if wn.slowWritingPeerMonitorInterval == 0 {
@@ -702,6 +719,11 @@ func (wn *WebsocketNetwork) Start() {
 	if wn.prioScheme != nil {
 		wn.RegisterHandlers(prioHandlers)
 	}
+	if wn.config.EnablePeerExchange {
+		wn.RegisterHandlers(peerExchangeHandlers)
+		wn.wg.Add(1)
+		go wn.peerExchangeThread()
+	}
 	if wn.listener != nil {
 		wn.wg.Add(1)
 		go wn.httpdThread()
@@ -839,6 +861,20 @@ func getCommonHeaders(headers http.Header) (otherTelemetryGUID, otherInstanceNam
 
 // checkIncomingConnectionLimits perform the connection limits counting for the incoming connections.
 func (wn *WebsocketNetwork) checkIncomingConnectionLimits(response http.ResponseWriter, request *http.Request, remoteHost, otherTelemetryGUID, otherInstanceName string) int {
+	if wn.isBanned(remoteHost) {
+		networkConnectionsDroppedTotal.Inc(map[string]string{"reason": "banned_host"})
+		wn.log.EventWithDetails(telemetryspec.Network, telemetryspec.ConnectPeerFailEvent,
+			telemetryspec.ConnectPeerFailEventDetails{
+				Address:      remoteHost,
+				HostName:     otherTelemetryGUID,
+				Incoming:     true,
+				InstanceName: otherInstanceName,
+				Reason:       "Banned Host",
+			})
+		response.WriteHeader(http.StatusForbidden)
+		return http.StatusForbidden
+	}
+
 	if wn.numIncomingPeers() >= wn.config.IncomingConnectionsLimit {
 		networkConnectionsDroppedTotal.Inc(map[string]string{"reason": "incoming_connection_limit"})
 		wn.log.EventWithDetails(telemetryspec.Network, telemetryspec.ConnectPeerFailEvent,
@@ -893,6 +929,36 @@ func (wn *WebsocketNetwork) checkProtocolVersionMatch(otherHeaders http.Header)
 	return "", otherVersion
 }
 
+// checkCompressionMatch returns the message compression codec to use with a peer, given the codecs it accepts, or an
+// empty string if messages are not to be compressed.
+func (wn *WebsocketNetwork) checkCompressionMatch(otherCodecs []string) string {
+	if !wn.config.EnableGossipCompression {
+		return ""
+	}
+	for _, supportedCodec := range supportedCompressionCodecs {
+		for _, otherCodec := range otherCodecs {
+			if supportedCodec == otherCodec {
+				return supportedCodec
+			}
+		}
+	}
+	return ""
+}
+
+// checkTxSyncReconciliation returns whether the transaction-sync set reconciliation protocol can be used with a peer,
+// given the transaction-sync protocols it advertised.
+func (wn *WebsocketNetwork) checkTxSyncReconciliation(otherProtocols []string) bool {
+	if !wn.config.EnableTxSyncReconciliation {
+		return false
+	}
+	for _, otherProtocol := range otherProtocols {
+		if otherProtocol == TxSyncReconciliationProtocol {
+			return true
+		}
+	}
+	return false
+}
+
 // checkIncomingConnectionVariables checks the variables that were provided on the request, and compares them to the
 // local server supported parameters. If all good, it returns http.StatusOK; otherwise, it write the error to the ResponseWriter
 // and returns the http status.
@@ -982,6 +1048,12 @@ func (wn *WebsocketNetwork) ServeHTTP(response http.ResponseWriter, request *htt
 		return
 	}
 
+	identity, idChallenge, identityResponse, status := wn.checkIncomingIdentity(response, request)
+	if status != http.StatusOK {
+		// we've already logged and written all response(s).
+		return
+	}
+
 	// if UseXForwardedForAddressField is not empty, attempt to override the otherPublicAddr with the X Forwarded For origin
 	trackedRequest.otherPublicAddr = trackedRequest.remoteAddr
 
@@ -994,6 +1066,17 @@ func (wn *WebsocketNetwork) ServeHTTP(response http.ResponseWriter, request *htt
 		challenge = wn.prioScheme.NewPrioChallenge()
 		responseHeader.Set(PriorityChallengeHeader, challenge)
 	}
+	if identityResponse != "" {
+		responseHeader.Set(IdentityChallengeResponseHeader, identityResponse)
+	}
+	compression := wn.checkCompressionMatch(request.Header[textproto.CanonicalMIMEHeaderKey(ProtocolAcceptCompressionHeader)])
+	if compression != "" {
+		responseHeader.Set(ProtocolCompressionHeader, compression)
+	}
+	txSyncReconciliation := wn.checkTxSyncReconciliation(request.Header[textproto.CanonicalMIMEHeaderKey(ProtocolTxSyncHeader)])
+	if txSyncReconciliation {
+		responseHeader.Set(ProtocolTxSyncHeader, TxSyncReconciliationProtocol)
+	}
 	conn, err := wn.upgrader.Upgrade(response, request, responseHeader)
 	if err != nil {
 		wn.log.Info("ws upgrade fail ", err)
@@ -1007,14 +1090,18 @@ func (wn *WebsocketNetwork) ServeHTTP(response http.ResponseWriter, request *htt
 	}
 
 	peer := &wsPeer{
-		wsPeerCore:        makePeerCore(wn, trackedRequest.otherPublicAddr, wn.GetRoundTripper(), trackedRequest.remoteHost),
-		conn:              conn,
-		outgoing:          false,
-		InstanceName:      trackedRequest.otherInstanceName,
-		incomingMsgFilter: wn.incomingMsgFilter,
-		prioChallenge:     challenge,
-		createTime:        trackedRequest.created,
-		version:           matchingVersion,
+		wsPeerCore:           makePeerCore(wn, trackedRequest.otherPublicAddr, wn.GetRoundTripper(), trackedRequest.remoteHost),
+		conn:                 conn,
+		outgoing:             false,
+		InstanceName:         trackedRequest.otherInstanceName,
+		incomingMsgFilter:    wn.incomingMsgFilter,
+		prioChallenge:        challenge,
+		createTime:           trackedRequest.created,
+		version:              matchingVersion,
+		identity:             identity,
+		identityChallenge:    idChallenge,
+		compression:          compression,
+		txSyncReconciliation: txSyncReconciliation,
 	}
 	peer.TelemetryGUID = trackedRequest.otherTelemetryGUID
 	peer.init(wn.config, wn.outgoingMessagesBufferSize)
@@ -1189,6 +1276,7 @@ func (wn *WebsocketNetwork) innerBroadcast(request broadcastRequest, prio bool,
 	mbytes := make([]byte, len(tbytes)+len(request.data))
 	copy(mbytes, tbytes)
 	copy(mbytes[len(tbytes):], request.data)
+	msg := compressibleMessage{data: mbytes}
 
 	var digest crypto.Digest
 	if request.tag != protocol.MsgDigestSkipTag && len(request.data) >= messageFilterSize {
@@ -1204,11 +1292,11 @@ func (wn *WebsocketNetwork) innerBroadcast(request broadcastRequest, prio bool,
 		if wn.config.BroadcastConnectionsLimit >= 0 && sentMessageCount >= wn.config.BroadcastConnectionsLimit {
 			break
 		}
-		if peer == request.except {
+		if peer == request.except || peer.identityPending() {
 			peers[pi] = nil
 			continue
 		}
-		ok := peer.writeNonBlock(mbytes, prio, digest, request.enqueueTime)
+		ok := peer.writeNonBlock(msg.forPeer(peer), prio, digest, request.enqueueTime)
 		if ok {
 			peers[pi] = nil
 			sentMessageCount++
@@ -1270,7 +1358,7 @@ func (wn *WebsocketNetwork) isConnectedTo(addr string) bool {
 	wn.peersLock.RLock()
 	defer wn.peersLock.RUnlock()
 	for _, peer := range wn.peers {
-		if addr == peer.rootURL {
+		if wn.peerRootURL(addr) == peer.rootURL {
 			return true
 		}
 	}
@@ -1638,6 +1726,21 @@ const ProtocolVersionHeader = "X-Algorand-Version"
 // ProtocolAcceptVersionHeader HTTP header for accept protocol version. Client use this to advertise supported protocol versions.
 const ProtocolAcceptVersionHeader = "X-Algorand-Accept-Version"
 
+// ProtocolAcceptCompressionHeader HTTP header for accepted message compression codecs. Client use this to advertise
+// the codecs it supports for compressing message payloads.
+const ProtocolAcceptCompressionHeader = "X-Algorand-Accept-Compression"
+
+// ProtocolCompressionHeader HTTP header for the message compression codec selected by the server for the connection.
+const ProtocolCompressionHeader = "X-Algorand-Compression"
+
+// ProtocolTxSyncHeader HTTP header for the websocket transaction-sync protocols supported by a node, in addition to the
+// http bloom filter based one. Both the client and the server advertise the protocols they support.
+const ProtocolTxSyncHeader = "X-Algorand-TxSync"
+
+// TxSyncReconciliationProtocol is the websocket transaction-sync protocol which reconciles the pending transactions
+// of two nodes using invertible bloom lookup tables.
+const TxSyncReconciliationProtocol = "iblt1"
+
 // SupportedProtocolVersions contains the list of supported protocol versions by this node ( in order of preference ).
 var SupportedProtocolVersions = []string{"2.1", "1"}
 
@@ -1662,6 +1765,12 @@ const InstanceNameHeader = "X-Algorand-InstanceName"
 // PriorityChallengeHeader HTTP header informs a client about the challenge it should sign to increase network priority.
 const PriorityChallengeHeader = "X-Algorand-PriorityChallenge"
 
+// IdentityChallengeHeader HTTP header carries the signed identity challenge a client sends to a relay.
+const IdentityChallengeHeader = "X-Algorand-IdentityChallenge"
+
+// IdentityChallengeResponseHeader HTTP header carries the signed response of a relay to the identity challenge of a client.
+const IdentityChallengeResponseHeader = "X-Algorand-IdentityChallengeResponse"
+
 // TooManyRequestsRetryAfterHeader HTTP header let the client know when to make the next connection attempt
 const TooManyRequestsRetryAfterHeader = "Retry-After"
 
@@ -1694,7 +1803,7 @@ func ParseHostOrURL(addr string) (*url.URL, error) {
 
 // addrToGossipAddr parses host:port or a URL and returns the URL to the websocket interface at that address.
 func (wn *WebsocketNetwork) addrToGossipAddr(addr string) (string, error) {
-	parsedURL, err := ParseHostOrURL(addr)
+	parsedURL, err := ParseHostOrURL(wn.peerRootURL(addr))
 	if err != nil {
 		wn.log.Warnf("could not parse addr %#v: %s", addr, err)
 		return "", errBadAddr
@@ -1770,15 +1879,25 @@ func (wn *WebsocketNetwork) tryConnect(addr, gossipAddr string) {
 	}
 	// for backward compatability, include the ProtocolVersion header as well.
 	requestHeader.Set(ProtocolVersionHeader, ProtocolVersion)
+	if wn.config.EnableGossipCompression {
+		for _, supportedCodec := range supportedCompressionCodecs {
+			requestHeader.Add(ProtocolAcceptCompressionHeader, supportedCodec)
+		}
+	}
+	if wn.config.EnableTxSyncReconciliation {
+		requestHeader.Set(ProtocolTxSyncHeader, TxSyncReconciliationProtocol)
+	}
 	SetUserAgentHeader(requestHeader)
 	myInstanceName := wn.log.GetInstanceName()
 	requestHeader.Set(InstanceNameHeader, myInstanceName)
+	idChallenge := wn.setIdentityChallengeHeader(requestHeader)
 	var websocketDialer = websocket.Dialer{
 		Proxy:             http.ProxyFromEnvironment,
 		HandshakeTimeout:  45 * time.Second,
 		EnableCompression: false,
 		NetDialContext:    wn.dialer.DialContext,
 		NetDial:           wn.dialer.Dial,
+		TLSClientConfig:   wn.tlsClientConfig,
 	}
 
 	conn, response, err := websocketDialer.DialContext(wn.ctx, gossipAddr, requestHeader)
@@ -1824,6 +1943,21 @@ func (wn *WebsocketNetwork) tryConnect(addr, gossipAddr string) {
 		return
 	}
 
+	identity, responseChallenge, err := wn.checkIdentityResponse(response.Header, idChallenge)
+	if err != nil {
+		wn.log.Warnf("ws connect(%s) fail - bad identity : %v", gossipAddr, err)
+		networkConnectionsDroppedTotal.Inc(map[string]string{"reason": "bad identity"})
+		conn.Close()
+		return
+	}
+
+	if remoteHost := justHost(conn.RemoteAddr().String()); wn.isBanned(remoteHost) {
+		wn.log.Infof("ws connect(%s) aborted due to the host %s being banned", gossipAddr, remoteHost)
+		networkConnectionsDroppedTotal.Inc(map[string]string{"reason": "banned_host"})
+		conn.Close()
+		return
+	}
+
 	throttledConnection := false
 	if atomic.AddInt32(&wn.throttledOutgoingConnections, int32(-1)) >= 0 {
 		throttledConnection = true
@@ -1832,7 +1966,7 @@ func (wn *WebsocketNetwork) tryConnect(addr, gossipAddr string) {
 	}
 
 	peer := &wsPeer{
-		wsPeerCore:                  makePeerCore(wn, addr, wn.GetRoundTripper(), "" /* origin */),
+		wsPeerCore:                  makePeerCore(wn, wn.peerRootURL(addr), wn.GetRoundTripper(), "" /* origin */),
 		conn:                        conn,
 		outgoing:                    true,
 		incomingMsgFilter:           wn.incomingMsgFilter,
@@ -1840,6 +1974,12 @@ func (wn *WebsocketNetwork) tryConnect(addr, gossipAddr string) {
 		connMonitor:                 wn.connPerfMonitor,
 		throttledOutgoingConnection: throttledConnection,
 		version:                     matchingVersion,
+		identity:                    identity,
+		compression:                 wn.checkCompressionMatch(response.Header[textproto.CanonicalMIMEHeaderKey(ProtocolCompressionHeader)]),
+		txSyncReconciliation:        wn.checkTxSyncReconciliation(response.Header[textproto.CanonicalMIMEHeaderKey(ProtocolTxSyncHeader)]),
+	}
+	if identity != (crypto.PublicKey{}) {
+		peer.identityVerified = 1
 	}
 	peer.TelemetryGUID, peer.InstanceName, _ = getCommonHeaders(response.Header)
 	peer.init(wn.config, wn.outgoingMessagesBufferSize)
@@ -1858,6 +1998,22 @@ func (wn *WebsocketNetwork) tryConnect(addr, gossipAddr string) {
 	peers.Set(float64(wn.NumPeers()), nil)
 	outgoingPeers.Set(float64(wn.numOutgoingPeers()), nil)
 
+	if identity != (crypto.PublicKey{}) {
+		// prove that we own the identity we presented in our challenge.
+		sent := peer.writeNonBlock(wn.makeIdentityVerification(responseChallenge), true, crypto.Digest{}, time.Now())
+		if !sent {
+			wn.log.With("remote", addr).With("local", localAddr).Warnf("could not send identity verification to %v", addr)
+		}
+	}
+
+	if wn.config.EnablePeerExchange {
+		wn.wg.Add(1)
+		go func() {
+			defer wn.wg.Done()
+			wn.exchangePeers(peer)
+		}()
+	}
+
 	if wn.prioScheme != nil {
 		challenge := response.Header.Get(PriorityChallengeHeader)
 		if challenge != "" {
@@ -1887,6 +2043,14 @@ func NewWebsocketNetwork(log logging.Logger, config config.Local, phonebookAddre
 	}
 
 	wn.setup()
+	err = wn.setupTLS()
+	if err != nil {
+		return nil, err
+	}
+	wn.identityAllowlist, err = parsePeerIdentityAllowlist(config.PeerIdentityAllowlist)
+	if err != nil {
+		return nil, err
+	}
 	return wn, nil
 }
 
@@ -1900,6 +2064,12 @@ func (wn *WebsocketNetwork) SetPrioScheme(s NetPrioScheme) {
 	wn.prioScheme = s
 }
 
+// SetIdentity specifies the key used to identify the network node to its peers,
+// enabling the peer identity handshake
+func (wn *WebsocketNetwork) SetIdentity(keys *crypto.SignatureSecrets) {
+	wn.identityKeys = keys
+}
+
 // called from wsPeer to report that it has closed
 func (wn *WebsocketNetwork) peerRemoteClose(peer *wsPeer, reason disconnectReason) {
 	wn.removePeer(peer, reason)
@@ -1635,3 +1635,31 @@ func TestWebsocketDisconnection(t *testing.T) {
 		require.FailNow(t, "The DisconnectPeerEvent was missing")
 	}
 }
+
+func TestWebsocketNetworkTxSyncReconciliation(t *testing.T) {
+	for _, reconciliationB := range []bool{true, false} {
+		netA := makeTestWebsocketNode(t)
+		netA.config.GossipFanout = 1
+		netA.config.EnableTxSyncReconciliation = true
+		netA.Start()
+		netB := makeTestWebsocketNode(t)
+		netB.config.GossipFanout = 1
+		netB.config.EnableTxSyncReconciliation = reconciliationB
+		addrA, postListen := netA.Address()
+		require.True(t, postListen)
+		netB.phonebook.ReplacePeerList([]string{addrA}, "default")
+		netB.Start()
+
+		readyTimeout := time.NewTimer(2 * time.Second)
+		waitReady(t, netA, readyTimeout.C)
+		waitReady(t, netB, readyTimeout.C)
+
+		for _, peers := range [][]Peer{netA.GetPeers(PeersConnectedIn), netB.GetPeers(PeersConnectedOut)} {
+			require.Len(t, peers, 1)
+			require.Equal(t, reconciliationB, peers[0].(TxSyncPeer).SupportsTxSyncReconciliation())
+		}
+
+		netB.Stop()
+		netA.Stop()
+	}
+}
@@ -65,13 +65,16 @@ var outgoingNetworkMessageFilteredOutBytesTotal = metrics.MakeCounter(metrics.Ou
 // allow to be sent without receiving any explicit request.
 var defaultSendMessageTags = map[protocol.Tag]bool{
 	protocol.AgreementVoteTag:   true,
+	protocol.NetIDVerifyTag:     true,
 	protocol.MsgDigestSkipTag:   true,
 	protocol.NetPrioResponseTag: true,
+	protocol.PeerExchangeTag:    true,
 	protocol.PingTag:            true,
 	protocol.PingReplyTag:       true,
 	protocol.ProposalPayloadTag: true,
 	protocol.TopicMsgRespTag:    true,
 	protocol.MsgOfInterestTag:   true,
+	protocol.TxSyncRequestTag:   true,
 	protocol.TxnTag:             true,
 	protocol.UniCatchupReqTag:   true,
 	protocol.UniEnsBlockReqTag:  true,
@@ -116,6 +119,10 @@ const disconnectSlowConn disconnectReason = "SlowConnection"
 const disconnectLeastPerformingPeer disconnectReason = "LeastPerformingPeer"
 const disconnectCliqueResolve disconnectReason = "CliqueResolving"
 const disconnectRequestReceived disconnectReason = "DisconnectRequest"
+const disconnectBadIdentity disconnectReason = "BadIdentity"
+const disconnectQuotaExceeded disconnectReason = "QuotaExceeded"
+const disconnectOperatorRequest disconnectReason = "OperatorRequest"
+const disconnectBannedHost disconnectReason = "BannedHost"
 
 // Response is the structure holding the response from the server
 type Response struct {
@@ -133,6 +140,14 @@ type wsPeer struct {
 	// peer, or zero if no message is being written.
 	intermittentOutgoingMessageEnqueueTime int64
 
+	// peerExchangeServed contains the UnixNano of the last time we answered a peer exchange request of the peer.
+	// we want this to be a 64-bit aligned for atomics.
+	peerExchangeServed int64
+
+	// messagesReceived and messagesSent count the messages exchanged with the peer. Accessed atomically.
+	messagesReceived uint64
+	messagesSent     uint64
+
 	wsPeerCore
 
 	// conn will be *websocket.Conn (except in testing)
@@ -175,6 +190,25 @@ type wsPeer struct {
 	prioAddress basics.Address
 	prioWeight  uint64
 
+	// identity is the public key the peer presented during the peer identity handshake, if any.
+	identity crypto.PublicKey
+
+	// Challenge an incoming peer needs to sign to prove that it owns its identity
+	identityChallenge identityChallengeValue
+
+	// identityVerified is set to 1 once the peer proved that it owns its identity. Accessed atomically.
+	identityVerified uint32
+
+	// compression is the message compression codec negotiated with the peer, or empty if messages are sent uncompressed.
+	compression string
+
+	// txSyncReconciliation is set when both the peer and this node support the transaction-sync set reconciliation protocol.
+	txSyncReconciliation bool
+
+	// quotas are the bandwidth and message rate quotas applied to the messages received from an incoming peer, or nil
+	// if the peer is not subject to quotas.
+	quotas *peerQuotas
+
 	// createTime is the time at which the connection was established with the peer.
 	createTime time.Time
 
@@ -230,6 +264,13 @@ type UnicastPeer interface {
 	Respond(ctx context.Context, reqMsg IncomingMessage, topics Topics) (e error)
 }
 
+// TxSyncPeer is implemented by peers which may be asked for missing pending transactions over websockets.
+type TxSyncPeer interface {
+	UnicastPeer
+	// SupportsTxSyncReconciliation returns whether the peer supports the transaction-sync set reconciliation protocol.
+	SupportsTxSyncReconciliation() bool
+}
+
 // Create a wsPeerCore object
 func makePeerCore(net *WebsocketNetwork, rootURL string, roundTripper http.RoundTripper, originAddress string) wsPeerCore {
 	return wsPeerCore{
@@ -262,6 +303,12 @@ func (wp *wsPeer) Version() string {
 	return wp.version
 }
 
+// SupportsTxSyncReconciliation returns whether the transaction-sync set reconciliation protocol was negotiated with the peer.
+// (Implements TxSyncPeer)
+func (wp *wsPeer) SupportsTxSyncReconciliation() bool {
+	return wp.txSyncReconciliation
+}
+
 // 	Unicast sends the given bytes to this specific peer. Does not wait for message to be sent.
 // (Implements UnicastPeer)
 func (wp *wsPeer) Unicast(ctx context.Context, msg []byte, tag protocol.Tag) error {
@@ -276,7 +323,7 @@ func (wp *wsPeer) Unicast(ctx context.Context, msg []byte, tag protocol.Tag) err
 		digest = crypto.Hash(mbytes)
 	}
 
-	ok := wp.writeNonBlock(mbytes, false, digest, time.Now())
+	ok := wp.writeNonBlock(wp.compressMessage(mbytes), false, digest, time.Now())
 	if !ok {
 		networkBroadcastsDropped.Inc(nil)
 		err = fmt.Errorf("wsPeer failed to unicast: %v", wp.GetAddress())
@@ -302,7 +349,7 @@ func (wp *wsPeer) Respond(ctx context.Context, reqMsg IncomingMessage, responseT
 	// Send serializedMsg
 	select {
 	case wp.sendBufferBulk <- sendMessage{
-		data:         append([]byte(protocol.TopicMsgRespTag), serializedMsg...),
+		data:         wp.compressMessage(append([]byte(protocol.TopicMsgRespTag), serializedMsg...)),
 		enqueued:     time.Now(),
 		peerEnqueued: time.Now()}:
 	case <-wp.closing:
@@ -337,6 +384,15 @@ func (wp *wsPeer) init(config config.Local, sendBufferLength int) {
 		wp.outgoingMsgFilter = makeMessageFilter(config.OutgoingMessageFilterBucketCount, config.OutgoingMessageFilterBucketSize)
 	}
 
+	if !wp.outgoing {
+		wp.quotas = makePeerQuotas(config, time.Now())
+	}
+
+	if wp.identityPending() {
+		wp.wg.Add(1)
+		go wp.identityDeadline()
+	}
+
 	wp.wg.Add(2)
 	go wp.readLoop()
 	go wp.writeLoop()
@@ -411,7 +467,21 @@ func (wp *wsPeer) readLoop() {
 		atomic.StoreInt64(&wp.lastPacketTime, msg.Received)
 		networkReceivedBytesTotal.AddUint64(uint64(len(msg.Data)+2), nil)
 		networkMessageReceivedTotal.AddUint64(1, nil)
+		atomic.AddUint64(&wp.messagesReceived, 1)
 		msg.Sender = wp
+		if wp.quotas != nil && !wp.throttle(msg.Tag, len(msg.Data)+2) {
+			cleanupCloseError = disconnectQuotaExceeded
+			return
+		}
+		if wp.compression != "" && compressedMessageTags[msg.Tag] {
+			msg.Data, err = decodeMessagePayload(msg.Data, maxMessageLength)
+			if err != nil {
+				wp.net.log.Warnf("wsPeer readLoop: could not decode the %s message from %s: %v", msg.Tag, wp.conn.RemoteAddr().String(), err)
+				networkConnectionsDroppedTotal.Inc(map[string]string{"reason": "bad compressed message"})
+				cleanupCloseError = disconnectBadData
+				return
+			}
+		}
 
 		// for outgoing connections, we want to notify the connection monitor that we've received
 		// a message. The connection monitor would update it's statistics accordingly.
@@ -419,7 +489,19 @@ func (wp *wsPeer) readLoop() {
 			wp.connMonitor.Notify(&msg)
 		}
 
+		if wp.identityPending() && msg.Tag != protocol.NetIDVerifyTag {
+			// drop messages until the peer proves that it owns its identity
+			continue
+		}
+
 		switch msg.Tag {
+		case protocol.NetIDVerifyTag:
+			if !wp.handleIdentityVerification(msg) {
+				networkConnectionsDroppedTotal.Inc(map[string]string{"reason": "bad identity"})
+				cleanupCloseError = disconnectBadIdentity
+				return
+			}
+			continue
 		case protocol.MsgOfInterestTag:
 			// try to decode the message-of-interest
 			if wp.handleMessageOfInterest(msg) {
@@ -580,6 +662,7 @@ func (wp *wsPeer) writeLoopSend(msg sendMessage) (exit bool) {
 	atomic.StoreInt64(&wp.lastPacketTime, time.Now().UnixNano())
 	networkSentBytesTotal.AddUint64(uint64(len(msg.data)), nil)
 	networkMessageSentTotal.AddUint64(1, nil)
+	atomic.AddUint64(&wp.messagesSent, 1)
 	networkMessageQueueMicrosTotal.AddUint64(uint64(time.Now().Sub(msg.peerEnqueued).Nanoseconds()/1000), nil)
 	return false
 }
@@ -741,7 +824,7 @@ func (wp *wsPeer) Request(ctx context.Context, tag Tag, topics Topics) (resp *Re
 	// Send serializedMsg
 	select {
 	case wp.sendBufferBulk <- sendMessage{
-		data:         append([]byte(tag), serializedMsg...),
+		data:         wp.compressMessage(append([]byte(tag), serializedMsg...)),
 		enqueued:     time.Now(),
 		peerEnqueued: time.Now()}:
 	case <-wp.closing:
@@ -19,6 +19,7 @@ package node
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -149,6 +150,34 @@ type TxnWithStatus struct {
 // MakeFull sets up an Algorand full node
 // (i.e., it returns a node that participates in consensus)
 func MakeFull(log logging.Logger, rootDir string, cfg config.Local, phonebookAddresses []string, genesis bookkeeping.Genesis) (*AlgorandFullNode, error) {
+	return makeFull(log, rootDir, cfg, genesis, func(node *AlgorandFullNode) (network.GossipNode, error) {
+		p2pNode, err := network.NewWebsocketNetwork(node.log, node.config, phonebookAddresses, genesis.ID(), genesis.Network)
+		if err != nil {
+			log.Errorf("could not create websocket node: %v", err)
+			return nil, err
+		}
+		p2pNode.SetPrioScheme(node)
+		if cfg.EnablePeerIdentity || cfg.PeerIdentityAllowlist != "" {
+			identity, err := network.LoadPeerIdentity(filepath.Join(rootDir, config.PeerIdentityKeyFilename))
+			if err != nil {
+				log.Errorf("could not load peer identity: %v", err)
+				return nil, err
+			}
+			p2pNode.SetIdentity(identity)
+		}
+		return p2pNode, nil
+	})
+}
+
+// MakeFullWithNetwork sets up an Algorand full node which uses the given network instead of creating a websocket
+// network, e.g. a node of an in-memory network running many nodes in a single process.
+func MakeFullWithNetwork(log logging.Logger, rootDir string, cfg config.Local, p2pNode network.GossipNode, genesis bookkeeping.Genesis) (*AlgorandFullNode, error) {
+	return makeFull(log, rootDir, cfg, genesis, func(*AlgorandFullNode) (network.GossipNode, error) {
+		return p2pNode, nil
+	})
+}
+
+func makeFull(log logging.Logger, rootDir string, cfg config.Local, genesis bookkeeping.Genesis, makeNetwork func(*AlgorandFullNode) (network.GossipNode, error)) (*AlgorandFullNode, error) {
 
 	node := new(AlgorandFullNode)
 	node.rootDir = rootDir
@@ -158,12 +187,10 @@ func MakeFull(log logging.Logger, rootDir string, cfg config.Local, phonebookAdd
 	node.genesisHash = crypto.HashObj(genesis)
 
 	// tie network, block fetcher, and agreement services together
-	p2pNode, err := network.NewWebsocketNetwork(node.log, node.config, phonebookAddresses, genesis.ID(), genesis.Network)
+	p2pNode, err := makeNetwork(node)
 	if err != nil {
-		log.Errorf("could not create websocket node: %v", err)
 		return nil, err
 	}
-	p2pNode.SetPrioScheme(node)
 	node.net = p2pNode
 	node.accountManager = data.MakeAccountManager(log)
 
@@ -872,6 +899,55 @@ func (node *AlgorandFullNode) AbortCatchup(catchpoint string) error {
 	return nil
 }
 
+// ErrPeerManagementUnsupported is returned by the peer management functions when the network of the node does not support them.
+var ErrPeerManagementUnsupported = errors.New("peer management is not supported by the network of the node")
+
+// websocketNetwork returns the network of the node, for the peer management functions.
+func (node *AlgorandFullNode) websocketNetwork() (*network.WebsocketNetwork, error) {
+	wn, ok := node.net.(*network.WebsocketNetwork)
+	if !ok {
+		return nil, ErrPeerManagementUnsupported
+	}
+	return wn, nil
+}
+
+// Peers returns a description of the peers the node is connected to.
+func (node *AlgorandFullNode) Peers() ([]network.PeerInfo, error) {
+	wn, err := node.websocketNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return wn.PeerInfos(), nil
+}
+
+// DisconnectPeer disconnects the peers whose address or remote host matches the given address, and returns
+// the number of peers which were disconnected.
+func (node *AlgorandFullNode) DisconnectPeer(address string) (int, error) {
+	wn, err := node.websocketNetwork()
+	if err != nil {
+		return 0, err
+	}
+	return wn.DisconnectPeerAddress(address), nil
+}
+
+// AddPhonebookAddress adds the address of a relay to the phonebook of the node.
+func (node *AlgorandFullNode) AddPhonebookAddress(address string) error {
+	wn, err := node.websocketNetwork()
+	if err != nil {
+		return err
+	}
+	return wn.AddPhonebookAddress(address)
+}
+
+// BanHost disconnects the peers with the given IP address, and rejects the connections with it for the given duration.
+func (node *AlgorandFullNode) BanHost(host string, duration time.Duration) error {
+	wn, err := node.websocketNetwork()
+	if err != nil {
+		return err
+	}
+	return wn.BanHost(host, duration)
+}
+
 // SetCatchpointCatchupMode change the node's operational mode from catchpoint catchup mode and back, it returns a
 // channel which contains the updated node context. This function need to work asyncronisly so that the caller could
 // detect and handle the usecase where the node is being shut down while we're switching to/from catchup mode without
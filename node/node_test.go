@@ -36,6 +36,7 @@ import (
 	"github.com/algorand/go-algorand/data/basics"
 	"github.com/algorand/go-algorand/data/bookkeeping"
 	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/network"
 	"github.com/algorand/go-algorand/protocol"
 	"github.com/algorand/go-algorand/util"
 	"github.com/algorand/go-algorand/util/db"
@@ -55,7 +56,9 @@ var defaultConfig = config.Local{
 	IncomingConnectionsLimit: -1,
 }
 
-func setupFullNodes(t *testing.T, proto protocol.ConsensusVersion, verificationPool execpool.BacklogPool, customConsensus config.ConsensusProtocols) ([]*AlgorandFullNode, []string, []string) {
+// setupFullNodes creates full nodes which are connected through real sockets, or through the given memory network
+// if it isn't nil.
+func setupFullNodes(t *testing.T, proto protocol.ConsensusVersion, verificationPool execpool.BacklogPool, customConsensus config.ConsensusProtocols, memoryNet *network.MemoryNetwork) ([]*AlgorandFullNode, []string, []string) {
 	util.RaiseRlimit(1000)
 	f, _ := os.Create(t.Name() + ".log")
 	logging.Base().SetJSONFormatter()
@@ -168,7 +171,23 @@ func setupFullNodes(t *testing.T, proto protocol.ConsensusVersion, verificationP
 		cfg, err := config.LoadConfigFromDisk(rootDirectory)
 		require.NoError(t, err)
 
-		node, err := MakeFull(logging.Base().With("source", t.Name()+strconv.Itoa(i)), rootDirectory, cfg, []string{}, g)
+		log := logging.Base().With("source", t.Name()+strconv.Itoa(i))
+		var node *AlgorandFullNode
+		if memoryNet != nil {
+			var phonebook []string
+			for j := range nodes {
+				if j != i {
+					phonebook = append(phonebook, "node"+strconv.Itoa(j))
+				}
+			}
+			cfg.NetAddress = "node" + strconv.Itoa(i)
+			var p2pNode *network.MemoryNode
+			p2pNode, err = memoryNet.NewNode(log, cfg, cfg.NetAddress, phonebook, g.ID())
+			require.NoError(t, err)
+			node, err = MakeFullWithNetwork(log, rootDirectory, cfg, p2pNode, g)
+		} else {
+			node, err = MakeFull(log, rootDirectory, cfg, []string{}, g)
+		}
 		nodes[i] = node
 		require.NoError(t, err)
 	}
@@ -182,7 +201,7 @@ func TestSyncingFullNode(t *testing.T) {
 	backlogPool := execpool.MakeBacklog(nil, 0, execpool.LowPriority, nil)
 	defer backlogPool.Shutdown()
 
-	nodes, wallets, rootDirs := setupFullNodes(t, protocol.ConsensusCurrentVersion, backlogPool, nil)
+	nodes, wallets, rootDirs := setupFullNodes(t, protocol.ConsensusCurrentVersion, backlogPool, nil, nil)
 	for i := 0; i < len(nodes); i++ {
 		defer os.Remove(wallets[i])
 		defer os.RemoveAll(rootDirs[i])
@@ -233,13 +252,57 @@ func TestSyncingFullNode(t *testing.T) {
 	}
 }
 
+// TestMemoryNetworkFullNodes runs full nodes connected through an in-memory network, and checks that they agree on
+// the same blocks.
+func TestMemoryNetworkFullNodes(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	backlogPool := execpool.MakeBacklog(nil, 0, execpool.LowPriority, nil)
+	defer backlogPool.Shutdown()
+
+	memoryNet := network.MakeMemoryNetwork(network.MemoryNetworkConfig{Latency: 10 * time.Millisecond, Jitter: 5 * time.Millisecond, Seed: 1})
+	nodes, wallets, rootDirs := setupFullNodes(t, protocol.ConsensusCurrentVersion, backlogPool, nil, memoryNet)
+	for i := 0; i < len(nodes); i++ {
+		defer os.Remove(wallets[i])
+		defer os.RemoveAll(rootDirs[i])
+		defer nodes[i].Stop()
+	}
+
+	initialRound := nodes[0].ledger.NextRound()
+	startAndConnectNodes(nodes, false)
+
+	const rounds = 3
+	timer := time.NewTimer(rounds * 2 * expectedAgreementTime)
+	defer timer.Stop()
+	for i := range nodes {
+		select {
+		case <-nodes[i].ledger.Wait(initialRound + rounds):
+		case <-timer.C:
+			require.Fail(t, fmt.Sprintf("no block notification for account: %d - %v", i, wallets[i]))
+			return
+		}
+	}
+
+	for r := basics.Round(0); r <= initialRound+rounds; r++ {
+		b0, err := nodes[0].ledger.Block(r)
+		require.NoError(t, err)
+		for i := 1; i < len(nodes); i++ {
+			bi, err := nodes[i].ledger.Block(r)
+			require.NoError(t, err)
+			require.Equal(t, b0.Hash(), bi.Hash())
+		}
+	}
+}
+
 func TestInitialSync(t *testing.T) {
 	t.Skip("flaky TestInitialSync ")
 
 	backlogPool := execpool.MakeBacklog(nil, 0, execpool.LowPriority, nil)
 	defer backlogPool.Shutdown()
 
-	nodes, wallets, rootdirs := setupFullNodes(t, protocol.ConsensusCurrentVersion, backlogPool, nil)
+	nodes, wallets, rootdirs := setupFullNodes(t, protocol.ConsensusCurrentVersion, backlogPool, nil, nil)
 	for i := 0; i < len(nodes); i++ {
 		defer os.Remove(wallets[i])
 		defer os.RemoveAll(rootdirs[i])
@@ -306,7 +369,7 @@ func TestSimpleUpgrade(t *testing.T) {
 	testParams1.ApprovedUpgrades = map[protocol.ConsensusVersion]uint64{}
 	configurableConsensus[consensusTest1] = testParams1
 
-	nodes, wallets, rootDirs := setupFullNodes(t, consensusTest0, backlogPool, configurableConsensus)
+	nodes, wallets, rootDirs := setupFullNodes(t, consensusTest0, backlogPool, configurableConsensus, nil)
 	for i := 0; i < len(nodes); i++ {
 		defer os.Remove(wallets[i])
 		defer os.RemoveAll(rootDirs[i])
@@ -27,6 +27,7 @@ const (
 	AuctionOutcomes   HashID = "aO"
 	AuctionParams     HashID = "aP"
 	AuctionSettlement HashID = "aS"
+	AppIndex          HashID = "appID"
 
 	CompactCertCoin HashID = "ccc"
 	CompactCertPart HashID = "ccp"
@@ -39,6 +40,9 @@ const (
 	Genesis           HashID = "GE"
 	MerkleArrayNode   HashID = "MA"
 	Message           HashID = "MX"
+	NetIDChallenge    HashID = "NIC"
+	NetIDResponse     HashID = "NIR"
+	NetIDVerify       HashID = "NIV"
 	NetPrioResponse   HashID = "NPR"
 	OneTimeSigKey1    HashID = "OT1"
 	OneTimeSigKey2    HashID = "OT2"
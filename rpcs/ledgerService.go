@@ -17,9 +17,11 @@
 package rpcs
 
 import (
+	"archive/tar"
 	"compress/gzip"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"strconv"
 	"strings"
@@ -47,6 +49,14 @@ const (
 	// e.g. .Handle(LedgerServiceLedgerPath, &ls)
 	LedgerServiceLedgerPath = "/v{version:[0-9.]+}/{genesisID}/ledger/{round:[0-9a-z]+}"
 
+	// LedgerSectionsHeader is the HTTP header with which the ledger service acknowledges that it serves only the range
+	// of sections of the catchpoint file requested with the "from" and "to" query arguments, as a tar archive of the
+	// sections whose index is in the [from, to) range. Older services ignore these arguments and serve the whole file.
+	LedgerSectionsHeader = "X-Algorand-Ledger-Sections"
+
+	// LedgerSectionsEnd is the end of a range of sections extending to the end of the catchpoint file.
+	LedgerSectionsEnd = math.MaxUint64
+
 	// maxCatchpointFileSize is a rough estimate for the worst-case scenario we're going to have of all the accounts data per a single catchpoint file chunk.
 	maxCatchpointFileSize = 512 * 1024 * 1024 // 512MB
 
@@ -201,6 +211,11 @@ func (ls *LedgerService) ServeHTTP(response http.ResponseWriter, request *http.R
 	defer cs.Close()
 	response.Header().Set("Content-Type", LedgerResponseContentType)
 	requestedCompressedResponse := strings.Contains(request.Header.Get("Accept-Encoding"), "gzip")
+	query := request.URL.Query()
+	if query.Get("from") != "" {
+		ls.serveSections(response, cs, round, query.Get("from"), query.Get("to"), requestedCompressedResponse)
+		return
+	}
 	if requestedCompressedResponse {
 		response.Header().Set("Content-Encoding", "gzip")
 		written, err := io.Copy(response, cs)
@@ -222,3 +237,71 @@ func (ls *LedgerService) ServeHTTP(response http.ResponseWriter, request *http.R
 		logging.Base().Infof("LedgerService.ServeHTTP : unable to write decompressed catchpoint file for round %d, written bytes %d : %v", round, written, err)
 	}
 }
+
+// serveSections writes the requested range of sections of a catchpoint file, given as the "from" and "to" query
+// arguments; a missing "to" argument extends the range to the end of the file.
+func (ls *LedgerService) serveSections(response http.ResponseWriter, cs io.Reader, round uint64, fromStr, toStr string, compress bool) {
+	from, err := strconv.ParseUint(fromStr, 10, 64)
+	if err != nil {
+		response.WriteHeader(http.StatusBadRequest)
+		response.Write([]byte(fmt.Sprintf("invalid first section '%s' : %v", fromStr, err)))
+		return
+	}
+	to := uint64(LedgerSectionsEnd)
+	if toStr != "" {
+		to, err = strconv.ParseUint(toStr, 10, 64)
+		if err != nil || to <= from {
+			response.WriteHeader(http.StatusBadRequest)
+			response.Write([]byte(fmt.Sprintf("invalid last section '%s'", toStr)))
+			return
+		}
+	}
+	decompressedGzip, err := gzip.NewReader(cs)
+	if err != nil {
+		logging.Base().Warnf("LedgerService.ServeHTTP : failed to decompress catchpoint %d %v", round, err)
+		response.WriteHeader(http.StatusInternalServerError)
+		response.Write([]byte(fmt.Sprintf("catchpoint file for round %d could not be decompressed due to internal error : %v", round, err)))
+		return
+	}
+	defer decompressedGzip.Close()
+	response.Header().Set(LedgerSectionsHeader, fromStr+"-"+toStr)
+	var out io.Writer = response
+	if compress {
+		response.Header().Set("Content-Encoding", "gzip")
+		compressedGzip := gzip.NewWriter(response)
+		defer compressedGzip.Close()
+		out = compressedGzip
+	}
+	err = copyCatchpointSections(out, decompressedGzip, from, to)
+	if err != nil {
+		logging.Base().Infof("LedgerService.ServeHTTP : unable to write sections %d-%d of catchpoint file for round %d : %v", from, to, round, err)
+	}
+}
+
+// copyCatchpointSections writes the sections of the catchpoint file tar archive whose index is in the [from, to)
+// range as a tar archive of their own.
+func copyCatchpointSections(out io.Writer, in io.Reader, from, to uint64) error {
+	tarReader := tar.NewReader(in)
+	tarWriter := tar.NewWriter(out)
+	for index := uint64(0); index < to; index++ {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if index < from {
+			continue
+		}
+		err = tarWriter.WriteHeader(header)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(tarWriter, tarReader)
+		if err != nil {
+			return err
+		}
+	}
+	return tarWriter.Close()
+}
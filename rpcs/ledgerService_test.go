@@ -0,0 +1,80 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package rpcs
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func makeTestCatchpointTar(t *testing.T, names []string) []byte {
+	var buf bytes.Buffer
+	tarWriter := tar.NewWriter(&buf)
+	for _, name := range names {
+		require.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(name))}))
+		_, err := tarWriter.Write([]byte(name))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tarWriter.Close())
+	return buf.Bytes()
+}
+
+func readTestCatchpointTar(t *testing.T, data []byte) []string {
+	var names []string
+	tarReader := tar.NewReader(bytes.NewReader(data))
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return names
+		}
+		require.NoError(t, err)
+		content, err := ioutil.ReadAll(tarReader)
+		require.NoError(t, err)
+		require.Equal(t, header.Name, string(content))
+		names = append(names, header.Name)
+	}
+}
+
+func TestCopyCatchpointSections(t *testing.T) {
+	names := []string{"content.msgpack"}
+	for i := 1; i <= 5; i++ {
+		names = append(names, fmt.Sprintf("balances.%d.5.msgpack", i))
+	}
+	names = append(names, "kvs.1.msgpack")
+	catchpoint := makeTestCatchpointTar(t, names)
+
+	for _, test := range []struct {
+		from, to uint64
+		expected []string
+	}{
+		{0, 1, names[:1]},
+		{2, 4, names[2:4]},
+		{5, LedgerSectionsEnd, names[5:]},
+		{0, LedgerSectionsEnd, names},
+		{10, 20, nil},
+	} {
+		var out bytes.Buffer
+		require.NoError(t, copyCatchpointSections(&out, bytes.NewReader(catchpoint), test.from, test.to))
+		require.Equal(t, test.expected, readTestCatchpointTar(t, out.Bytes()))
+	}
+}
@@ -29,6 +29,7 @@ import (
 
 	"github.com/algorand/go-algorand/data/transactions"
 	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/network"
 	"github.com/algorand/go-algorand/protocol"
 	"github.com/algorand/go-algorand/util/bloom"
 )
@@ -50,6 +51,14 @@ type TxService struct {
 	// and prevent sending huge responses. The client could make several
 	// request to retrieve the remaining trasactions.
 	responseSizeLimit int
+
+	// sketchesMu protects the cached sketches of the pending transactions, which are built from the pending transactions
+	// cache at the time sketchesUpdate, as well as the index of the group of each pending transaction.
+	sketchesMu     deadlock.Mutex
+	sketchesUpdate int64
+	sketches       map[int]*txSyncSketch
+	txGroupIndex   map[transactions.Txid]int
+	sketchedGroups [][]transactions.SignedTxn
 }
 
 const updateInterval = int64(30)
@@ -200,6 +209,106 @@ func (txs *TxService) updateTxCache() (pendingTxGroups [][]transactions.SignedTx
 	return txs.pendingTxGroups
 }
 
+// getSketch returns the sketch of the pending transactions with the given partition size, along with the pending
+// transaction groups it was built from and the index of the group of each pending transaction.
+func (txs *TxService) getSketch(partitionSize int) (sketch *txSyncSketch, pendingTxGroups [][]transactions.SignedTxn, txGroupIndex map[transactions.Txid]int) {
+	txs.updateTxCache()
+	txs.mu.RLock()
+	pendingTxGroups = txs.pendingTxGroups
+	lastUpdate := txs.lastUpdate
+	txs.mu.RUnlock()
+
+	txs.sketchesMu.Lock()
+	defer txs.sketchesMu.Unlock()
+	if txs.sketches == nil || txs.sketchesUpdate != lastUpdate {
+		txs.sketches = make(map[int]*txSyncSketch)
+		txs.txGroupIndex = make(map[transactions.Txid]int)
+		for i, txgroup := range pendingTxGroups {
+			for _, tx := range txgroup {
+				txs.txGroupIndex[tx.ID()] = i
+			}
+		}
+		txs.sketchedGroups = pendingTxGroups
+		txs.sketchesUpdate = lastUpdate
+	}
+	sketch = txs.sketches[partitionSize]
+	if sketch == nil {
+		sketch = makeTxSyncSketch(partitionSize)
+		for txid := range txs.txGroupIndex {
+			sketch.insert(txid)
+		}
+		txs.sketches[partitionSize] = sketch
+	}
+	return sketch, txs.sketchedGroups, txs.txGroupIndex
+}
+
+// handleTxSyncRequest answers a websocket transaction-sync request with the pending transaction groups the requester
+// is missing, found by subtracting the sketch of its pending transactions from ours.
+func (txs *TxService) handleTxSyncRequest(message network.IncomingMessage) network.OutgoingMessage {
+	return network.OutgoingMessage{
+		Action: network.Respond,
+		Topics: txs.reconcile(message.Data),
+	}
+}
+
+// reconcile returns the response topics to a serialized transaction-sync request.
+func (txs *TxService) reconcile(request []byte) network.Topics {
+	topics, err := network.UnmarshallTopics(request)
+	if err != nil {
+		txs.log.Infof("TxService reconcile: %v", err)
+		return network.Topics{network.MakeTopic(network.ErrorKey, []byte(err.Error()))}
+	}
+	sketchBytes, found := topics.GetValue(txSyncSketchKey)
+	if !found {
+		txs.log.Infof("TxService reconcile: %s", noTxSyncSketchErrMsg)
+		return network.Topics{network.MakeTopic(network.ErrorKey, []byte(noTxSyncSketchErrMsg))}
+	}
+	remoteSketch, err := unmarshalTxSyncSketch(sketchBytes)
+	if err != nil {
+		txs.log.Infof("TxService reconcile: %v", err)
+		return network.Topics{network.MakeTopic(network.ErrorKey, []byte(err.Error()))}
+	}
+	localSketch, pendingTxGroups, txGroupIndex := txs.getSketch(remoteSketch.partitionSize)
+	difference := localSketch.copy()
+	difference.subtract(remoteSketch)
+	missing, extra, ok := difference.decode()
+	if !ok {
+		// let the requester know how many transactions we have, so that it could pick a large enough sketch.
+		return network.Topics{
+			network.MakeTopic(network.ErrorKey, []byte(txSyncSketchUndecodableErrMsg)),
+			network.MakeTopic(txSyncPendingCountKey, uvarintBytes(uint64(len(txGroupIndex)))),
+		}
+	}
+
+	missingGroups := make(map[int]bool, len(missing))
+	for _, txid := range missing {
+		if i, has := txGroupIndex[txid]; has {
+			missingGroups[i] = true
+		}
+	}
+	missingTxns := make([]transactions.SignedTxn, 0)
+	encodedLength := 0
+	for i, txgroup := range pendingTxGroups {
+		if !missingGroups[i] {
+			continue
+		}
+		txGroupLength := 0
+		for _, tx := range txgroup {
+			txGroupLength += tx.GetEncodedLength()
+		}
+		if encodedLength+txGroupLength > txs.responseSizeLimit {
+			break
+		}
+		missingTxns = append(missingTxns, txgroup...)
+		encodedLength += txGroupLength
+	}
+	txs.log.Debugf("reconciled %d missing and %d extra txns, sending %d txns", len(missing), len(extra), len(missingTxns))
+	return network.Topics{
+		network.MakeTopic(txSyncTxnsKey, protocol.EncodeReflect(missingTxns)),
+		network.MakeTopic(txSyncDifferenceKey, uvarintBytes(uint64(len(missing)+len(extra)))),
+	}
+}
+
 // TxServiceHTTPPath is the URL path to sync pending transactions from
 const TxServiceHTTPPath = "/v1/{genesisID}/txsync"
 
@@ -207,4 +316,7 @@ const TxServiceHTTPPath = "/v1/{genesisID}/txsync"
 func RegisterTxService(pool PendingTxAggregate, registrar Registrar, genesisID string, txPoolSize int, responseSizeLimit int) {
 	service := makeTxService(pool, genesisID, txPoolSize, responseSizeLimit)
 	registrar.RegisterHTTPHandler(TxServiceHTTPPath, service)
+	registrar.RegisterHandlers([]network.TaggedMessageHandler{
+		{Tag: protocol.TxSyncRequestTag, MessageHandler: network.HandlerFunc(service.handleTxSyncRequest)},
+	})
 }
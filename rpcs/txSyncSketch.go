@@ -0,0 +1,255 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package rpcs
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/algorand/go-algorand/data/transactions"
+)
+
+// txSyncSketch is an invertible bloom lookup table of transaction ids. Subtracting the sketch of one set of transaction
+// ids from the sketch of another set yields the sketch of their symmetric difference, which can be decoded as long as the
+// difference is small compared to the number of cells, regardless of the size of the sets themselves. This allows two
+// nodes to find which pending transactions one has and the other lacks by exchanging a sketch which size is proportional
+// to the difference of their pools, rather than a bloom filter which size is proportional to the pool.
+//
+// The cells are divided in txSyncSketchHashes partitions of equal sizes, and every transaction id is added to a single
+// cell of each partition.
+type txSyncSketch struct {
+	partitionSize int
+	cells         []txSyncSketchCell
+}
+
+// txSyncSketchCell is a cell of an invertible bloom lookup table.
+type txSyncSketchCell struct {
+	// count is the number of transaction ids added to the cell, minus the number of the ones subtracted from it.
+	count int32
+	// idSum is the xor of the transaction ids added to or subtracted from the cell.
+	idSum transactions.Txid
+	// hashSum is the xor of the check hashes of the transaction ids added to or subtracted from the cell.
+	hashSum uint64
+}
+
+// txSyncSketchHashes is the number of cells each transaction id is added to.
+const txSyncSketchHashes = 3
+
+// txSyncSketchCellLength is the length of an encoded cell.
+const txSyncSketchCellLength = 4 + len(transactions.Txid{}) + 8
+
+// txSyncSketchMinPartitionSize is the smallest partition size of the sketches sent by the tx syncer.
+const txSyncSketchMinPartitionSize = 64
+
+// txSyncSketchMaxPartitionSize is the largest partition size of a sketch; it keeps the encoded sketch well below the
+// maximal websocket message length.
+const txSyncSketchMaxPartitionSize = 16384
+
+var errTxSyncSketchSizeMismatch = errors.New("sketches have different sizes")
+
+// makeTxSyncSketch creates an empty sketch with the given partition size.
+func makeTxSyncSketch(partitionSize int) *txSyncSketch {
+	return &txSyncSketch{
+		partitionSize: partitionSize,
+		cells:         make([]txSyncSketchCell, partitionSize*txSyncSketchHashes),
+	}
+}
+
+// txSyncSketchPartitionSize returns the partition size of a sketch which is expected to decode a difference of the given
+// number of transaction ids.
+func txSyncSketchPartitionSize(difference int) int {
+	// a sketch with 1.5 cells per element decodes with a high probability once the difference is large enough; small
+	// differences need some more room.
+	partitionSize := txSyncSketchMinPartitionSize
+	for partitionSize < txSyncSketchMaxPartitionSize && partitionSize*txSyncSketchHashes < 2*difference {
+		partitionSize *= 2
+	}
+	return partitionSize
+}
+
+// mix64 is the finalizer of the splitmix64 generator. Transaction ids are already uniformly distributed, so this is only
+// needed to derive cell indexes and check hashes which are not linear in the ids.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// txidWord returns the i-th 64 bits word of a transaction id.
+func txidWord(txid *transactions.Txid, i int) uint64 {
+	return binary.LittleEndian.Uint64(txid[i*8 : (i+1)*8])
+}
+
+// checkHash returns the check hash of a transaction id, used to find the cells holding a single transaction id.
+func checkHash(txid *transactions.Txid) uint64 {
+	return mix64(txidWord(txid, 3) ^ mix64(txidWord(txid, 2)^mix64(txidWord(txid, 1)^mix64(txidWord(txid, 0)))))
+}
+
+// cellIndex returns the index of the cell of the given partition which a transaction id is added to.
+func (s *txSyncSketch) cellIndex(txid *transactions.Txid, partition int) int {
+	return partition*s.partitionSize + int(mix64(txidWord(txid, partition))%uint64(s.partitionSize))
+}
+
+// toggle adds ( count = 1 ) or subtracts ( count = -1 ) a transaction id to the sketch.
+func (s *txSyncSketch) toggle(txid *transactions.Txid, hash uint64, count int32) {
+	for partition := 0; partition < txSyncSketchHashes; partition++ {
+		cell := &s.cells[s.cellIndex(txid, partition)]
+		cell.count += count
+		for i := range cell.idSum {
+			cell.idSum[i] ^= txid[i]
+		}
+		cell.hashSum ^= hash
+	}
+}
+
+// insert adds a transaction id to the sketch.
+func (s *txSyncSketch) insert(txid transactions.Txid) {
+	s.toggle(&txid, checkHash(&txid), 1)
+}
+
+// copy returns a copy of the sketch.
+func (s *txSyncSketch) copy() *txSyncSketch {
+	c := &txSyncSketch{
+		partitionSize: s.partitionSize,
+		cells:         make([]txSyncSketchCell, len(s.cells)),
+	}
+	copy(c.cells, s.cells)
+	return c
+}
+
+// subtract subtracts the content of another sketch of the same size from this one.
+func (s *txSyncSketch) subtract(other *txSyncSketch) error {
+	if s.partitionSize != other.partitionSize {
+		return errTxSyncSketchSizeMismatch
+	}
+	for i := range s.cells {
+		cell := &s.cells[i]
+		otherCell := &other.cells[i]
+		cell.count -= otherCell.count
+		for j := range cell.idSum {
+			cell.idSum[j] ^= otherCell.idSum[j]
+		}
+		cell.hashSum ^= otherCell.hashSum
+	}
+	return nil
+}
+
+// pure returns whether the cell holds a single transaction id.
+func (cell *txSyncSketchCell) pure() bool {
+	return (cell.count == 1 || cell.count == -1) && cell.hashSum == checkHash(&cell.idSum)
+}
+
+// empty returns whether the cell holds no transaction id.
+func (cell *txSyncSketchCell) empty() bool {
+	return cell.count == 0 && cell.hashSum == 0 && cell.idSum == transactions.Txid{}
+}
+
+// decode lists the transaction ids held by a sketch obtained by subtracting a sketch from another: added are the ones
+// which were only present in the sketch subtracted from, and removed the ones which were only present in the subtracted
+// sketch. It returns false if the sketch could not be fully decoded, in which case the lists are incomplete. The sketch
+// is emptied by a successful decoding.
+//
+// A sketch received from a peer may be crafted so that peeling never ends, for instance by holding a transaction id with
+// a count of -1 in a single cell: peeling it turns its other cells pure, and peeling those turns the first one pure
+// again. A genuine difference never holds the same transaction id twice, nor more transaction ids than cells, so the
+// decoding fails as soon as either happens.
+func (s *txSyncSketch) decode() (added []transactions.Txid, removed []transactions.Txid, ok bool) {
+	peeled := make(map[transactions.Txid]bool)
+	pending := make([]int, 0)
+	for i := range s.cells {
+		if s.cells[i].pure() {
+			pending = append(pending, i)
+		}
+	}
+	for len(pending) > 0 {
+		cell := &s.cells[pending[len(pending)-1]]
+		pending = pending[:len(pending)-1]
+		if !cell.pure() {
+			// the cell was peeled since it was found to be pure.
+			continue
+		}
+		txid := cell.idSum
+		if peeled[txid] || len(peeled) >= len(s.cells) {
+			return added, removed, false
+		}
+		peeled[txid] = true
+		count := cell.count
+		if count == 1 {
+			added = append(added, txid)
+		} else {
+			removed = append(removed, txid)
+		}
+		s.toggle(&txid, cell.hashSum, -count)
+		for partition := 0; partition < txSyncSketchHashes; partition++ {
+			if index := s.cellIndex(&txid, partition); s.cells[index].pure() {
+				pending = append(pending, index)
+			}
+		}
+	}
+	for i := range s.cells {
+		if !s.cells[i].empty() {
+			return added, removed, false
+		}
+	}
+	return added, removed, true
+}
+
+// MarshalBinary encodes the sketch.
+func (s *txSyncSketch) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, binary.MaxVarintLen64+len(s.cells)*txSyncSketchCellLength)
+	var buf [binary.MaxVarintLen64]byte
+	data = append(data, buf[:binary.PutUvarint(buf[:], uint64(s.partitionSize))]...)
+	for i := range s.cells {
+		cell := &s.cells[i]
+		data = append(data, buf[:4]...)
+		binary.LittleEndian.PutUint32(data[len(data)-4:], uint32(cell.count))
+		data = append(data, cell.idSum[:]...)
+		data = append(data, buf[:8]...)
+		binary.LittleEndian.PutUint64(data[len(data)-8:], cell.hashSum)
+	}
+	return data, nil
+}
+
+// unmarshalTxSyncSketch decodes a sketch encoded by MarshalBinary.
+func unmarshalTxSyncSketch(data []byte) (*txSyncSketch, error) {
+	partitionSize, n := binary.Uvarint(data)
+	if n <= 0 {
+		return nil, errors.New("unable to read the sketch partition size")
+	}
+	if partitionSize < txSyncSketchMinPartitionSize || partitionSize > txSyncSketchMaxPartitionSize || partitionSize&(partitionSize-1) != 0 {
+		return nil, fmt.Errorf("invalid sketch partition size %d", partitionSize)
+	}
+	s := makeTxSyncSketch(int(partitionSize))
+	data = data[n:]
+	if len(data) != len(s.cells)*txSyncSketchCellLength {
+		return nil, fmt.Errorf("invalid sketch length %d for partition size %d", len(data), partitionSize)
+	}
+	for i := range s.cells {
+		cell := &s.cells[i]
+		cell.count = int32(binary.LittleEndian.Uint32(data))
+		data = data[4:]
+		copy(cell.idSum[:], data)
+		data = data[len(cell.idSum):]
+		cell.hashSum = binary.LittleEndian.Uint64(data)
+		data = data[8:]
+	}
+	return s, nil
+}
@@ -0,0 +1,119 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package rpcs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/data/transactions"
+)
+
+func randomTxids(count int) []transactions.Txid {
+	txids := make([]transactions.Txid, count)
+	for i := range txids {
+		crypto.RandBytes(txids[i][:])
+	}
+	return txids
+}
+
+func makeTestSketch(partitionSize int, txids ...[]transactions.Txid) *txSyncSketch {
+	sketch := makeTxSyncSketch(partitionSize)
+	for _, set := range txids {
+		for _, txid := range set {
+			sketch.insert(txid)
+		}
+	}
+	return sketch
+}
+
+func TestTxSyncSketchDecode(t *testing.T) {
+	common := randomTxids(5000)
+	for _, difference := range []int{0, 1, 10, 100, 1000} {
+		onlyA := randomTxids(difference)
+		onlyB := randomTxids(difference / 2)
+		partitionSize := txSyncSketchPartitionSize(len(onlyA) + len(onlyB))
+		sketchA := makeTestSketch(partitionSize, common, onlyA)
+		sketchB := makeTestSketch(partitionSize, common, onlyB)
+
+		require.NoError(t, sketchA.subtract(sketchB))
+		added, removed, ok := sketchA.decode()
+		require.True(t, ok, "difference %d", difference)
+		require.ElementsMatch(t, onlyA, added)
+		require.ElementsMatch(t, onlyB, removed)
+	}
+}
+
+func TestTxSyncSketchUndecodable(t *testing.T) {
+	sketchA := makeTestSketch(txSyncSketchMinPartitionSize, randomTxids(10*txSyncSketchMinPartitionSize))
+	sketchB := makeTestSketch(txSyncSketchMinPartitionSize)
+
+	require.NoError(t, sketchA.subtract(sketchB))
+	_, _, ok := sketchA.decode()
+	require.False(t, ok)
+
+	require.Equal(t, errTxSyncSketchSizeMismatch, sketchA.subtract(makeTxSyncSketch(2*txSyncSketchMinPartitionSize)))
+}
+
+func TestTxSyncSketchCraftedLoop(t *testing.T) {
+	// a peer sketch holding a single transaction id, subtracted from a single cell, turns into a difference which cells
+	// keep becoming pure again as they are peeled.
+	txid := randomTxids(1)[0]
+	peerSketch := makeTxSyncSketch(txSyncSketchMinPartitionSize)
+	cell := &peerSketch.cells[peerSketch.cellIndex(&txid, 0)]
+	cell.count = -1
+	cell.idSum = txid
+	cell.hashSum = checkHash(&txid)
+
+	sketch := makeTxSyncSketch(txSyncSketchMinPartitionSize)
+	require.NoError(t, sketch.subtract(peerSketch))
+	_, _, ok := sketch.decode()
+	require.False(t, ok)
+}
+
+func TestTxSyncSketchPartitionSize(t *testing.T) {
+	require.Equal(t, txSyncSketchMinPartitionSize, txSyncSketchPartitionSize(0))
+	require.Equal(t, txSyncSketchMinPartitionSize, txSyncSketchPartitionSize(txSyncSketchMinPartitionSize))
+	require.Equal(t, 2*txSyncSketchMinPartitionSize, txSyncSketchPartitionSize(2*txSyncSketchMinPartitionSize))
+	require.Equal(t, txSyncSketchMaxPartitionSize, txSyncSketchPartitionSize(10*txSyncSketchMaxPartitionSize))
+}
+
+func TestTxSyncSketchMarshal(t *testing.T) {
+	sketch := makeTestSketch(128, randomTxids(100))
+	data, err := sketch.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, 2+len(sketch.cells)*txSyncSketchCellLength, len(data))
+
+	decoded, err := unmarshalTxSyncSketch(data)
+	require.NoError(t, err)
+	require.Equal(t, sketch, decoded)
+
+	_, err = unmarshalTxSyncSketch(data[:len(data)-1])
+	require.Error(t, err)
+	_, err = unmarshalTxSyncSketch(nil)
+	require.Error(t, err)
+
+	// partition sizes must be powers of two within the supported range
+	for _, partitionSize := range []int{txSyncSketchMinPartitionSize / 2, 100, 2 * txSyncSketchMaxPartitionSize} {
+		data, err := (&txSyncSketch{partitionSize: partitionSize, cells: make([]txSyncSketchCell, partitionSize*txSyncSketchHashes)}).MarshalBinary()
+		require.NoError(t, err)
+		_, err = unmarshalTxSyncSketch(data)
+		require.Error(t, err)
+	}
+}
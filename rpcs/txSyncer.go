@@ -55,6 +55,7 @@ type TxSyncer struct {
 	wg           sync.WaitGroup
 	log          logging.Logger
 	httpSync     *HTTPTxSync
+	wsSync       *wsTxSync
 }
 
 // MakeTxSyncer returns a TxSyncer
@@ -70,6 +71,7 @@ func MakeTxSyncer(pool PendingTxAggregate, clientSource network.GossipNode, txHa
 		syncTimeout:  syncTimeout,
 		log:          logging.Base(),
 		httpSync:     makeHTTPSync(clientSource, logging.Base(), uint64(serverResponseSize)),
+		wsSync:       makeWsTxSync(clientSource, logging.Base()),
 	}
 }
 
@@ -104,6 +106,13 @@ func (syncer *TxSyncer) Stop() {
 }
 
 func (syncer *TxSyncer) sync() error {
+	if peer := syncer.wsSync.selectPeer(); peer != nil {
+		reconciled, err := syncer.syncFromPeer(peer)
+		if reconciled {
+			return err
+		}
+		syncer.log.Infof("TxSyncer.Sync: falling back to http sync: %v", err)
+	}
 	return syncer.syncFromClient(syncer.httpSync)
 }
 
@@ -157,3 +166,42 @@ func (syncer *TxSyncer) syncFromClient(client TxSyncClient) error {
 
 	return nil
 }
+
+// syncFromPeer reconciles the pending transactions with the ones of a peer over websockets. It returns false if the
+// reconciliation failed, in which case the transactions should be synced using the http bloom filter protocol instead.
+func (syncer *TxSyncer) syncFromPeer(peer network.TxSyncPeer) (reconciled bool, err error) {
+	syncer.log.Infof("TxSyncer.Sync: reconciling pending transactions with peer %v", peer.GetAddress())
+
+	pending := syncer.pool.PendingTxIDs()
+	ctx, cf := context.WithTimeout(syncer.ctx, syncer.syncTimeout)
+	defer cf()
+	txgroups, err := syncer.wsSync.Sync(ctx, peer, pending)
+	if err != nil {
+		return false, fmt.Errorf("TxSyncer.Sync: peer '%v' error '%v'", peer.GetAddress(), err)
+	}
+
+	known := make(map[transactions.Txid]bool, len(pending))
+	for _, txid := range pending {
+		known[txid] = true
+	}
+	for _, txgroup := range txgroups {
+		var knownTxns int
+		for i := range txgroup {
+			if known[txgroup[i].ID()] {
+				knownTxns++
+			}
+		}
+
+		// unlike with a bloom filter, there are no false positives: the peer should only send groups we're missing.
+		if knownTxns == len(txgroup) {
+			return true, fmt.Errorf("TxSyncer.Sync: peer %v sent a transaction group that was entirely in our pool", peer.GetAddress())
+		}
+
+		// send the transaction to the trasaction pool
+		if syncer.handler.Handle(txgroup) != nil {
+			return true, fmt.Errorf("TxSyncer.Sync: peer %v sent invalid transaction", peer.GetAddress())
+		}
+	}
+
+	return true, nil
+}
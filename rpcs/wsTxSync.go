@@ -0,0 +1,147 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package rpcs
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/network"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+// Constant strings used as keys for the transaction-sync topics
+const (
+	txSyncSketchKey       = "sketch"     // Sketch of the requester pending transactions topic-key in the request
+	txSyncTxnsKey         = "txns"       // Missing transactions topic-key in the response
+	txSyncDifferenceKey   = "difference" // Size of the difference between the pending transactions topic-key in the response
+	txSyncPendingCountKey = "pending"    // Number of pending transactions topic-key in the response to an undecodable sketch
+)
+
+const noTxSyncSketchErrMsg = "can't find the sketch"
+const txSyncSketchUndecodableErrMsg = "sketch difference could not be decoded"
+
+var errTxSyncDifferenceTooLarge = errors.New("pending transactions difference is too large to be reconciled")
+
+// txSyncSketchCapacity is the largest difference which the largest sketch is expected to decode.
+const txSyncSketchCapacity = txSyncSketchMaxPartitionSize * txSyncSketchHashes / 2
+
+// uvarintBytes returns the varint encoding of an unsigned integer.
+func uvarintBytes(v uint64) []byte {
+	buf := make([]byte, binary.MaxVarintLen64)
+	return buf[:binary.PutUvarint(buf, v)]
+}
+
+// wsTxSync syncs pending transactions from peers over websockets, by reconciling the pending transactions with the
+// ones of the peer using sketches ( invertible bloom lookup tables ) instead of bloom filters. Only the peers which
+// negotiated the set reconciliation protocol during the handshake are synced from.
+type wsTxSync struct {
+	peers network.GossipNode
+
+	log logging.Logger
+
+	// difference is the size of the difference found by the last reconciliation, which the next sketch is sized after.
+	difference int
+}
+
+// create a new websocket sync object.
+func makeWsTxSync(peerSource network.GossipNode, log logging.Logger) *wsTxSync {
+	return &wsTxSync{
+		peers: peerSource,
+		log:   log,
+	}
+}
+
+// selectPeer returns a random outgoing peer supporting the set reconciliation protocol, or nil if there is none.
+func (wts *wsTxSync) selectPeer() network.TxSyncPeer {
+	candidates := make([]network.TxSyncPeer, 0)
+	for _, peer := range wts.peers.GetPeers(network.PeersConnectedOut) {
+		if txSyncPeer, ok := peer.(network.TxSyncPeer); ok && txSyncPeer.SupportsTxSyncReconciliation() {
+			candidates = append(candidates, txSyncPeer)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	return candidates[rand.Intn(len(candidates))]
+}
+
+// Sync returns the pending transaction groups of the peer which are missing from the given pending transactions. The
+// sketch sent to the peer is enlarged until the peer manages to decode the difference, or the difference is found to
+// be too large to be reconciled.
+func (wts *wsTxSync) Sync(ctx context.Context, peer network.TxSyncPeer, pending []transactions.Txid) ([][]transactions.SignedTxn, error) {
+	partitionSize := txSyncSketchPartitionSize(wts.difference)
+	for {
+		sketch := makeTxSyncSketch(partitionSize)
+		for _, txid := range pending {
+			sketch.insert(txid)
+		}
+		sketchBytes, err := sketch.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		wts.log.Debugf("ws sync from %s with a %d bytes sketch", peer.GetAddress(), len(sketchBytes))
+		resp, err := peer.Request(ctx, protocol.TxSyncRequestTag, network.Topics{network.MakeTopic(txSyncSketchKey, sketchBytes)})
+		if err != nil {
+			return nil, err
+		}
+
+		if errMsg, found := resp.Topics.GetValue(network.ErrorKey); found {
+			if string(errMsg) != txSyncSketchUndecodableErrMsg {
+				return nil, fmt.Errorf("txSync request error: %s", string(errMsg))
+			}
+			if partitionSize >= txSyncSketchMaxPartitionSize {
+				return nil, errTxSyncDifferenceTooLarge
+			}
+			// the difference is at least as large as the difference between the pools sizes.
+			difference := 2 * partitionSize * txSyncSketchHashes / 3
+			if countBytes, found := resp.Topics.GetValue(txSyncPendingCountKey); found {
+				if count, n := binary.Uvarint(countBytes); n > 0 && int(count) > len(pending)+difference {
+					difference = int(count) - len(pending)
+				}
+			}
+			if difference > txSyncSketchCapacity {
+				return nil, errTxSyncDifferenceTooLarge
+			}
+			partitionSize = txSyncSketchPartitionSize(difference)
+			continue
+		}
+
+		txnsBytes, found := resp.Topics.GetValue(txSyncTxnsKey)
+		if !found {
+			return nil, errors.New("txSync response is missing the transactions")
+		}
+		var txns []transactions.SignedTxn
+		err = protocol.DecodeReflect(txnsBytes, &txns)
+		if err != nil {
+			wts.log.Warn("txSync protocol decode: ", err)
+			return nil, err
+		}
+		if differenceBytes, found := resp.Topics.GetValue(txSyncDifferenceKey); found {
+			if difference, n := binary.Uvarint(differenceBytes); n > 0 {
+				wts.difference = int(difference)
+			}
+		}
+		return bookkeeping.SignedTxnsToGroups(txns), nil
+	}
+}
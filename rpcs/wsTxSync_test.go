@@ -0,0 +1,224 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package rpcs
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/components/mocks"
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/network"
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/algorand/go-algorand/util/bloom"
+)
+
+// implement network.TxSyncPeer, forwarding the requests to a TxService
+type txSyncTestPeer struct {
+	service        *TxService
+	reconciliation bool
+	requests       int
+	requestBytes   int
+}
+
+func (p *txSyncTestPeer) GetAddress() string {
+	return "txsync.test.peer"
+}
+func (p *txSyncTestPeer) Unicast(ctx context.Context, data []byte, tag protocol.Tag) error {
+	return nil
+}
+func (p *txSyncTestPeer) Version() string {
+	return "2.1"
+}
+func (p *txSyncTestPeer) Request(ctx context.Context, tag network.Tag, topics network.Topics) (resp *network.Response, e error) {
+	if p.service == nil {
+		return nil, errors.New("no service")
+	}
+	request := topics.MarshallTopics()
+	p.requests++
+	p.requestBytes += len(request)
+	return &network.Response{Topics: p.service.reconcile(request)}, nil
+}
+func (p *txSyncTestPeer) Respond(ctx context.Context, reqMsg network.IncomingMessage, topics network.Topics) (e error) {
+	return nil
+}
+func (p *txSyncTestPeer) SupportsTxSyncReconciliation() bool {
+	return p.reconciliation
+}
+
+type txSyncTestPeerSource struct {
+	outgoing  []network.Peer
+	phonebook []network.Peer
+	mocks.MockNetwork
+}
+
+func (s *txSyncTestPeerSource) GetPeers(options ...network.PeerOption) []network.Peer {
+	peers := make([]network.Peer, 0)
+	for _, option := range options {
+		switch option {
+		case network.PeersConnectedOut:
+			peers = append(peers, s.outgoing...)
+		case network.PeersPhonebook:
+			peers = append(peers, s.phonebook...)
+		}
+	}
+	return peers
+}
+
+func makeTestTxSyncService(pool PendingTxAggregate) *TxService {
+	return makeTxService(pool, "test genesisID", config.GetDefaultLocal().TxPoolSize, config.GetDefaultLocal().TxSyncServeResponseSize)
+}
+
+func TestWsTxSync(t *testing.T) {
+	pool := makeMockPendingTxAggregate(100)
+	peer := &txSyncTestPeer{service: makeTestTxSyncService(pool), reconciliation: true}
+	wsSync := makeWsTxSync(&txSyncTestPeerSource{}, logging.Base())
+
+	// the client is missing the first 10 transactions, and has 5 the server doesn't have
+	clientPending := append(pool.PendingTxIDs()[10:], makeMockPendingTxAggregate(5).PendingTxIDs()...)
+	txgroups, err := wsSync.Sync(context.Background(), peer, clientPending)
+	require.NoError(t, err)
+	require.Equal(t, 1, peer.requests)
+	require.Equal(t, 15, wsSync.difference)
+	var txns []transactions.SignedTxn
+	for _, txgroup := range txgroups {
+		txns = append(txns, txgroup...)
+	}
+	require.Equal(t, pool.txns[:10], txns)
+
+	// nothing is missing
+	txgroups, err = wsSync.Sync(context.Background(), peer, pool.PendingTxIDs())
+	require.NoError(t, err)
+	require.Empty(t, txgroups)
+	require.Equal(t, 0, wsSync.difference)
+}
+
+func TestWsTxSyncGrowSketch(t *testing.T) {
+	pool := makeMockPendingTxAggregate(2000)
+	peer := &txSyncTestPeer{service: makeTestTxSyncService(pool), reconciliation: true}
+	wsSync := makeWsTxSync(&txSyncTestPeerSource{}, logging.Base())
+
+	// the difference doesn't fit the smallest sketch, but the server pool size tells how large it should be.
+	txgroups, err := wsSync.Sync(context.Background(), peer, nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, peer.requests)
+	require.Equal(t, len(pool.txns), len(txgroups))
+	require.Equal(t, len(pool.txns), wsSync.difference)
+}
+
+func TestWsTxSyncDifferenceTooLarge(t *testing.T) {
+	pool := makeMockPendingTxAggregate(txSyncSketchCapacity + 1)
+	peer := &txSyncTestPeer{service: makeTestTxSyncService(pool), reconciliation: true}
+	wsSync := makeWsTxSync(&txSyncTestPeerSource{}, logging.Base())
+
+	_, err := wsSync.Sync(context.Background(), peer, nil)
+	require.Equal(t, errTxSyncDifferenceTooLarge, err)
+	require.Equal(t, 1, peer.requests)
+}
+
+func TestWsTxSyncSelectPeer(t *testing.T) {
+	unsupported := &txSyncTestPeer{}
+	supported := &txSyncTestPeer{reconciliation: true}
+	source := &txSyncTestPeerSource{outgoing: []network.Peer{unsupported}}
+	wsSync := makeWsTxSync(source, logging.Base())
+	require.Nil(t, wsSync.selectPeer())
+
+	source.outgoing = append(source.outgoing, supported)
+	require.Equal(t, supported, wsSync.selectPeer())
+}
+
+func TestTxSyncerReconciliation(t *testing.T) {
+	pool := makeMockPendingTxAggregate(3)
+	peer := &txSyncTestPeer{service: makeTestTxSyncService(pool), reconciliation: true}
+	source := &txSyncTestPeerSource{outgoing: []network.Peer{peer}}
+
+	handler := mockHandler{}
+	syncer := MakeTxSyncer(makeMockPendingTxAggregate(0), source, &handler, time.Second, time.Second, config.GetDefaultLocal().TxSyncServeResponseSize)
+	require.NoError(t, syncer.sync())
+	require.Equal(t, 1, peer.requests)
+	require.Equal(t, int32(3), atomic.LoadInt32(&handler.messageCounter))
+}
+
+func TestTxSyncerReconciliationFallback(t *testing.T) {
+	nodeA, nodeB := nodePair()
+	defer nodeA.stop()
+	defer nodeB.stop()
+
+	pool := makeMockPendingTxAggregate(3)
+	RegisterTxService(pool, nodeA, "test genesisID", config.GetDefaultLocal().TxPoolSize, config.GetDefaultLocal().TxSyncServeResponseSize)
+
+	// the peer supports the reconciliation but fails the request, so the syncer falls back to the http sync.
+	peer := &txSyncTestPeer{reconciliation: true}
+	source := &txSyncTestPeerSource{outgoing: []network.Peer{peer}, phonebook: nodeB.peers}
+
+	handler := mockHandler{}
+	syncer := MakeTxSyncer(makeMockPendingTxAggregate(0), source, &handler, time.Second, time.Second, config.GetDefaultLocal().TxSyncServeResponseSize)
+	require.NoError(t, syncer.sync())
+	require.Equal(t, int32(3), atomic.LoadInt32(&handler.messageCounter))
+}
+
+// BenchmarkTxSyncBandwidth compares the size of the requests of the http bloom filter protocol and of the websocket
+// set reconciliation protocol, along with the number of missing transactions each fails to retrieve.
+func BenchmarkTxSyncBandwidth(b *testing.B) {
+	for _, poolSize := range []int{1000, 10000} {
+		pool := makeMockPendingTxAggregate(poolSize)
+		service := makeTestTxSyncService(pool)
+		for _, missing := range []int{10, 100, 1000} {
+			clientPending := pool.PendingTxIDs()[missing:]
+
+			b.Run(fmt.Sprintf("bloom/pool=%d/missing=%d", poolSize, missing), func(b *testing.B) {
+				var requestBytes, missed int
+				for i := 0; i < b.N; i++ {
+					sizeBits, numHashes := bloom.Optimal(len(clientPending), bloomFilterFalsePositiveRate)
+					filter := bloom.New(sizeBits, numHashes, uint32(i))
+					for _, txid := range clientPending {
+						filter.Set(txid[:])
+					}
+					bloomBytes, err := filter.MarshalBinary()
+					require.NoError(b, err)
+					requestBytes += len(base64.URLEncoding.EncodeToString(bloomBytes))
+					missed += missing - len(service.getFilteredTxns(filter))
+				}
+				b.ReportMetric(float64(requestBytes)/float64(b.N), "request-bytes/op")
+				b.ReportMetric(float64(missed)/float64(b.N), "missed-txns/op")
+			})
+
+			b.Run(fmt.Sprintf("sketch/pool=%d/missing=%d", poolSize, missing), func(b *testing.B) {
+				var received int
+				peer := &txSyncTestPeer{service: service, reconciliation: true}
+				for i := 0; i < b.N; i++ {
+					// each sync starts without any knowledge of the expected difference.
+					wsSync := makeWsTxSync(&txSyncTestPeerSource{}, logging.Base())
+					txgroups, err := wsSync.Sync(context.Background(), peer, clientPending)
+					require.NoError(b, err)
+					received += len(txgroups)
+				}
+				b.ReportMetric(float64(peer.requestBytes)/float64(b.N), "request-bytes/op")
+				b.ReportMetric(float64(missing*b.N-received)/float64(b.N), "missed-txns/op")
+			})
+		}
+	}
+}
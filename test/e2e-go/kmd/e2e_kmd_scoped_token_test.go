@@ -0,0 +1,80 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package kmdtest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/test/framework/fixtures"
+	"github.com/algorand/go-algorand/util/tokens"
+)
+
+func TestScopedAPITokens(t *testing.T) {
+	t.Parallel()
+	var f fixtures.KMDFixture
+	walletHandleToken := f.SetupWithWallet(t)
+	defer f.Shutdown()
+
+	resp0, err := f.Client.ListWallets()
+	require.NoError(t, err)
+	require.Len(t, resp0.Wallets, 1)
+	walletID := resp0.Wallets[0].ID
+
+	// Make a second wallet with the static API token
+	resp1, err := f.Client.CreateWallet([]byte("other"), "sqlite", []byte(f.WalletPassword), crypto.MasterDerivationKey{})
+	require.NoError(t, err)
+	otherID := resp1.Wallet.ID
+	resp2, err := f.Client.InitWallet([]byte(otherID), []byte(f.WalletPassword))
+	require.NoError(t, err)
+	otherHandleToken := resp2.WalletHandleToken
+
+	// A wallet scoped token only sees and uses its wallet
+	walletClient := f.MakeScopedClient("wallet", tokens.ScopeWallet, walletID)
+
+	resp3, err := walletClient.ListWallets()
+	require.NoError(t, err)
+	require.Len(t, resp3.Wallets, 1)
+	require.Equal(t, walletID, resp3.Wallets[0].ID)
+
+	_, err = walletClient.InitWallet([]byte(walletID), []byte(f.WalletPassword))
+	require.NoError(t, err)
+	_, err = walletClient.GenerateKey([]byte(walletHandleToken))
+	require.NoError(t, err)
+
+	_, err = walletClient.InitWallet([]byte(otherID), []byte(f.WalletPassword))
+	require.Error(t, err)
+	_, err = walletClient.GenerateKey([]byte(otherHandleToken))
+	require.Error(t, err)
+	_, err = walletClient.CreateWallet([]byte("another"), "sqlite", []byte(f.WalletPassword), crypto.MasterDerivationKey{})
+	require.Error(t, err)
+
+	// An admin token may use every wallet
+	adminClient := f.MakeScopedClient("admin", tokens.ScopeAdmin, "")
+	resp4, err := adminClient.ListWallets()
+	require.NoError(t, err)
+	require.Len(t, resp4.Wallets, 2)
+	_, err = adminClient.GenerateKey([]byte(otherHandleToken))
+	require.NoError(t, err)
+
+	// Algod scopes mean nothing to kmd
+	readClient := f.MakeScopedClient("read", tokens.ScopeRead, "")
+	_, err = readClient.ListWallets()
+	require.Error(t, err)
+}
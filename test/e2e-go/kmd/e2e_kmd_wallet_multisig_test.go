@@ -418,3 +418,101 @@ func TestMultisigSignProgram(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, ok)
 }
+
+func TestPendingMultisig(t *testing.T) {
+	t.Parallel()
+	var f fixtures.KMDFixture
+	walletHandleToken := f.SetupWithWallet(t)
+	defer f.Shutdown()
+
+	// The coordinating wallet holds one key, a co-signer's wallet another
+	resp, err := f.Client.GenerateKey([]byte(walletHandleToken))
+	require.NoError(t, err)
+	pk1 := addrToPK(t, resp.Address)
+
+	resp0, err := f.Client.CreateWallet([]byte("cosigner"), "sqlite", []byte(f.WalletPassword), crypto.MasterDerivationKey{})
+	require.NoError(t, err)
+	resp1, err := f.Client.InitWallet([]byte(resp0.Wallet.ID), []byte(f.WalletPassword))
+	require.NoError(t, err)
+	cosignerHandleToken := resp1.WalletHandleToken
+	resp, err = f.Client.GenerateKey([]byte(cosignerHandleToken))
+	require.NoError(t, err)
+	pk2 := addrToPK(t, resp.Address)
+	pk3 := crypto.PublicKey{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1} // some public key we haven't imported
+	pks := []crypto.PublicKey{pk1, pk2, pk3}
+
+	// Create a 2-of-3 multisig account from the three public keys
+	resp2, err := f.Client.ImportMultisigAddr([]byte(walletHandleToken), 1, 2, pks)
+	require.NoError(t, err)
+	msigAddr := addrToPK(t, resp2.Address)
+
+	// Make a transaction spending from the multisig address
+	tx := transactions.Transaction{
+		Type: protocol.PaymentTx,
+		Header: transactions.Header{
+			Sender:     basics.Address(msigAddr),
+			Fee:        basics.MicroAlgos{Raw: config.Consensus[protocol.ConsensusCurrentVersion].MinTxnFee},
+			FirstValid: basics.Round(1),
+			LastValid:  basics.Round(1),
+		},
+		PaymentTxnFields: transactions.PaymentTxnFields{
+			Receiver: basics.Address{},
+			Amount:   basics.MicroAlgos{},
+		},
+	}
+
+	resp3, err := f.Client.CreatePendingMultisig([]byte(walletHandleToken), protocol.Encode(&tx), "")
+	require.NoError(t, err)
+	txid := resp3.Pending.TxID
+	require.Equal(t, tx.ID().String(), txid)
+	require.Equal(t, uint8(2), resp3.Pending.Threshold)
+	require.Empty(t, resp3.Pending.Signed)
+	require.Equal(t, pks, resp3.Pending.Missing)
+	require.False(t, resp3.Pending.Complete)
+
+	// The same transaction can't be pending twice
+	_, err = f.Client.CreatePendingMultisig([]byte(walletHandleToken), protocol.Encode(&tx), "")
+	require.Error(t, err)
+
+	// Sign with the coordinating wallet's key
+	resp4, err := f.Client.SignPendingMultisig([]byte(walletHandleToken), []byte(f.WalletPassword), txid, pk1)
+	require.NoError(t, err)
+	require.Equal(t, []crypto.PublicKey{pk1}, resp4.Pending.Signed)
+	require.False(t, resp4.Pending.Complete)
+	require.Empty(t, resp4.Pending.SignedTransaction)
+
+	// The co-signer signs in its own wallet, and hands over its subsignature
+	preimage := crypto.MultisigPreimageFromPKs(1, 2, pks)
+	resp5, err := f.Client.MultisigSignTransaction([]byte(cosignerHandleToken), []byte(f.WalletPassword), protocol.Encode(&tx), pk2, preimage, crypto.Digest{})
+	require.NoError(t, err)
+	resp6, err := f.Client.AddPendingMultisigSubsig([]byte(walletHandleToken), txid, resp5.Multisig)
+	require.NoError(t, err)
+	require.True(t, resp6.Pending.Complete)
+	require.Equal(t, []crypto.PublicKey{pk3}, resp6.Pending.Missing)
+
+	// The assembled transaction verifies
+	var stxn transactions.SignedTxn
+	err = protocol.Decode(resp6.Pending.SignedTransaction, &stxn)
+	require.NoError(t, err)
+	require.Equal(t, tx, stxn.Txn)
+	verified, err := crypto.MultisigVerify(tx, crypto.Digest(msigAddr), stxn.Msig)
+	require.NoError(t, err)
+	require.True(t, verified)
+
+	resp7, err := f.Client.ListPendingMultisigs([]byte(walletHandleToken))
+	require.NoError(t, err)
+	require.Len(t, resp7.Pending, 1)
+	require.Equal(t, resp6.Pending, resp7.Pending[0])
+
+	resp8, err := f.Client.ExportPendingMultisig([]byte(walletHandleToken), txid)
+	require.NoError(t, err)
+	require.Equal(t, resp6.Pending, resp8.Pending)
+
+	// Deleting needs the wallet password
+	_, err = f.Client.DeletePendingMultisig([]byte(walletHandleToken), []byte("wrong"), txid)
+	require.Error(t, err)
+	_, err = f.Client.DeletePendingMultisig([]byte(walletHandleToken), []byte(f.WalletPassword), txid)
+	require.NoError(t, err)
+	_, err = f.Client.ExportPendingMultisig([]byte(walletHandleToken), txid)
+	require.Error(t, err)
+}
@@ -0,0 +1,150 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package kmdtest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/daemon/kmd/lib/kmdapi"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/algorand/go-algorand/test/framework/fixtures"
+)
+
+func TestKeyPolicy(t *testing.T) {
+	t.Parallel()
+	var f fixtures.KMDFixture
+	walletHandleToken := f.SetupWithWallet(t)
+	defer f.Shutdown()
+
+	// Generate a key
+	resp0, err := f.Client.GenerateKey([]byte(walletHandleToken))
+	require.NoError(t, err)
+	addr, err := basics.UnmarshalChecksumAddress(resp0.Address)
+	require.NoError(t, err)
+
+	// The amount limits include the fee
+	var receiver basics.Address
+	crypto.RandBytes(receiver[:])
+	fee := config.Consensus[protocol.ConsensusCurrentVersion].MinTxnFee
+	policy := kmdapi.APIV1KeyPolicy{
+		TxTypes:        []protocol.TxType{protocol.PaymentTx, protocol.AssetTransferTx},
+		MaxAmount:      1000 + fee,
+		MaxDailyAmount: 1500 + 3*fee,
+		Receivers:      []string{receiver.String()},
+	}
+
+	// Setting a policy requires the wallet password, and the first policy
+	// picks the policy password
+	policyPassword := []byte("policy password")
+	_, err = f.Client.SetKeyPolicy([]byte(walletHandleToken), []byte("wrong"), policyPassword, addr.String(), policy)
+	require.Error(t, err)
+	_, err = f.Client.SetKeyPolicy([]byte(walletHandleToken), []byte(f.WalletPassword), policyPassword, addr.String(), policy)
+	require.NoError(t, err)
+
+	// Without the policy password, the policy can't be lifted
+	_, err = f.Client.SetKeyPolicy([]byte(walletHandleToken), []byte(f.WalletPassword), []byte(f.WalletPassword), addr.String(), kmdapi.APIV1KeyPolicy{})
+	require.Error(t, err)
+	_, err = f.Client.DeleteKeyPolicy([]byte(walletHandleToken), []byte(f.WalletPassword), []byte(f.WalletPassword), addr.String())
+	require.Error(t, err)
+
+	resp1, err := f.Client.ExportKeyPolicy([]byte(walletHandleToken), addr.String())
+	require.NoError(t, err)
+	require.Equal(t, policy, resp1.Policy)
+
+	sign := func(tx transactions.Transaction) error {
+		_, err := f.Client.SignTransaction([]byte(walletHandleToken), []byte(f.WalletPassword), crypto.PublicKey{}, tx)
+		return err
+	}
+	payment := func(amount uint64) transactions.Transaction {
+		return transactions.Transaction{
+			Type: protocol.PaymentTx,
+			Header: transactions.Header{
+				Sender:     addr,
+				Fee:        basics.MicroAlgos{Raw: fee},
+				FirstValid: basics.Round(1),
+				LastValid:  basics.Round(1),
+			},
+			PaymentTxnFields: transactions.PaymentTxnFields{
+				Receiver: receiver,
+				Amount:   basics.MicroAlgos{Raw: amount},
+			},
+		}
+	}
+
+	// Transactions within the policy are signed
+	require.NoError(t, sign(payment(1000)))
+
+	// The per transaction limit
+	require.Error(t, sign(payment(1001)))
+
+	// Receivers outside of the policy
+	tx := payment(10)
+	tx.Receiver = basics.Address{}
+	crypto.RandBytes(tx.Receiver[:])
+	require.Error(t, sign(tx))
+
+	// Rekeying and closing out aren't allowed by the policy
+	tx = payment(10)
+	tx.RekeyTo = receiver
+	require.Error(t, sign(tx))
+	tx = payment(10)
+	tx.CloseRemainderTo = receiver
+	require.Error(t, sign(tx))
+
+	// Assets can't be transferred under amount limits, but opting in is fine
+	axfer := func(amount uint64) transactions.Transaction {
+		tx := payment(0)
+		tx.Type = protocol.AssetTransferTx
+		tx.PaymentTxnFields = transactions.PaymentTxnFields{}
+		tx.AssetTransferTxnFields = transactions.AssetTransferTxnFields{
+			XferAsset:     basics.AssetIndex(1),
+			AssetAmount:   amount,
+			AssetReceiver: receiver,
+		}
+		return tx
+	}
+	require.Error(t, sign(axfer(1)))
+	require.NoError(t, sign(axfer(0)))
+
+	// Transaction types outside of the policy
+	tx = payment(0)
+	tx.Type = protocol.KeyRegistrationTx
+	tx.PaymentTxnFields = transactions.PaymentTxnFields{}
+	require.Error(t, sign(tx))
+
+	// Program signing isn't allowed by the policy
+	_, err = f.Client.SignProgram([]byte(walletHandleToken), []byte(f.WalletPassword), addr.String(), []byte("program"))
+	require.Error(t, err)
+
+	// The daily limit: 1000 and three fees were signed for already, and rejected
+	// transactions don't count
+	require.NoError(t, sign(payment(500)))
+	require.Error(t, sign(payment(1)))
+
+	// Without the policy, everything is signed again
+	_, err = f.Client.DeleteKeyPolicy([]byte(walletHandleToken), []byte(f.WalletPassword), policyPassword, addr.String())
+	require.NoError(t, err)
+	_, err = f.Client.ExportKeyPolicy([]byte(walletHandleToken), addr.String())
+	require.Error(t, err)
+	require.NoError(t, sign(payment(5000)))
+}
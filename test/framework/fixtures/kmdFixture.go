@@ -30,6 +30,7 @@ import (
 	"github.com/algorand/go-algorand/daemon/kmd/lib/kmdapi"
 	"github.com/algorand/go-algorand/nodecontrol"
 	"github.com/algorand/go-algorand/util"
+	"github.com/algorand/go-algorand/util/tokens"
 )
 
 // defaultConfig lowers scrypt params to make tests faster
@@ -193,6 +194,16 @@ func (f *KMDFixture) MakeWalletAndHandleToken() (handleToken string, err error)
 	return resp1.WalletHandleToken, nil
 }
 
+// MakeScopedClient adds a named API token with the given scope to kmd and
+// returns a client using it
+func (f *KMDFixture) MakeScopedClient(name string, scope tokens.Scope, walletID string) client.KMDClient {
+	apiToken, err := tokens.GenerateScopedToken(f.kmdDir, tokens.KmdScopedTokensFilename, name, scope, walletID, 0)
+	require.NoError(f.t, err)
+	scopedClient, err := client.MakeKMDClient(f.Sock, apiToken)
+	require.NoError(f.t, err)
+	return scopedClient
+}
+
 // TestConfig checks whether or not the passed config would be considered valid
 func (f *KMDFixture) TestConfig(cfg []byte) error {
 	// Write the passed config
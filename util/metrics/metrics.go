@@ -45,6 +45,22 @@ var (
 	NetworkSentDecompressedBytesTotal = MetricName{Name: "algod_network_sent_decompressed_bytes_total", Description: "Total number of bytes that were sent over the network prior of being compressed"}
 	// NetworkReceivedDecompressedBytesTotal Total number of bytes that were received from the network after of being decompressed
 	NetworkReceivedDecompressedBytesTotal = MetricName{Name: "algod_network_received_decompressed_bytes_total", Description: "Total number of bytes that were received from the network after being decompressed"}
+	// NetworkCompressionSavedBytesTotal Total number of bytes saved by compressing the messages sent over the network
+	NetworkCompressionSavedBytesTotal = MetricName{Name: "algod_network_compression_saved_bytes_total", Description: "Total number of bytes saved by compressing the messages sent over the network"}
+	// NetworkPeerThrottledMessagesTotal Number of messages received from an incoming peer which were throttled for exceeding the peer quotas
+	NetworkPeerThrottledMessagesTotal = MetricName{Name: "algod_network_peer_throttled_messages_total", Description: "Number of messages received from an incoming peer which were throttled for exceeding the peer quotas"}
+	// NetworkPeerThrottledSecondsTotal Time during which an incoming peer was throttled for exceeding its quotas
+	NetworkPeerThrottledSecondsTotal = MetricName{Name: "algod_network_peer_throttled_seconds_total", Description: "Time during which an incoming peer was throttled for exceeding its quotas"}
+	// CatchupPeerScore The catchup ranking score of a peer, higher is better
+	CatchupPeerScore = MetricName{Name: "algod_catchup_peer_score", Description: "The catchup ranking score of a peer, higher is better"}
+	// CatchupPeerLatencySeconds The average time it took a peer to serve a block during catchup
+	CatchupPeerLatencySeconds = MetricName{Name: "algod_catchup_peer_latency_seconds", Description: "The average time it took a peer to serve a block during catchup"}
+	// CatchupPeerThroughputBytes The average rate at which a peer served block bytes during catchup
+	CatchupPeerThroughputBytes = MetricName{Name: "algod_catchup_peer_throughput_bytes", Description: "The average rate at which a peer served block bytes during catchup"}
+	// CatchupPeerFailureRate The average rate of failed block requests to a peer during catchup
+	CatchupPeerFailureRate = MetricName{Name: "algod_catchup_peer_failure_rate", Description: "The average rate of failed block requests to a peer during catchup"}
+	// CatchupPeerPenaltiesTotal Number of bad blocks or certificates returned by a peer during catchup
+	CatchupPeerPenaltiesTotal = MetricName{Name: "algod_catchup_peer_penalties_total", Description: "Number of bad blocks or certificates returned by a peer during catchup"}
 	// DuplicateNetworkMessageReceivedTotal Total number of duplicate messages that were received from the network
 	DuplicateNetworkMessageReceivedTotal = MetricName{Name: "algod_network_duplicate_message_received_total", Description: "Total number of duplicate messages that were received from the network"}
 	// DuplicateNetworkMessageReceivedBytesTotal The total number ,in bytes, of the duplicate messages that were received from the network
@@ -0,0 +1,256 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package tokens
+
+import (
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/algorand/go-deadlock"
+)
+
+// Named API tokens that live in the datadirs of their respective daemons.
+// Only the hashes of the tokens are stored, so the token itself is only
+// known to whoever generated it.
+const (
+	AlgodScopedTokensFilename = "algod.tokens.json"
+	KmdScopedTokensFilename   = "kmd.tokens.json"
+)
+
+// Scope limits what a named API token may be used for
+type Scope string
+
+const (
+	// ScopeRead tokens may only read from the algod public API
+	ScopeRead Scope = "read"
+	// ScopeSubmit tokens may read from the algod public API and post transactions to it
+	ScopeSubmit Scope = "submit"
+	// ScopeAdmin tokens may use every endpoint, including the algod private API
+	ScopeAdmin Scope = "admin"
+	// ScopeWallet tokens may only use a single kmd wallet
+	ScopeWallet Scope = "wallet"
+)
+
+// AlgodScopes are the scopes that algod tokens may have
+var AlgodScopes = []Scope{ScopeRead, ScopeSubmit, ScopeAdmin}
+
+// KmdScopes are the scopes that kmd tokens may have
+var KmdScopes = []Scope{ScopeWallet, ScopeAdmin}
+
+// ScopedToken is a named API token as stored on disk
+type ScopedToken struct {
+	Name  string `json:"name"`
+	Hash  string `json:"hash"`
+	Scope Scope  `json:"scope"`
+	// Wallet is the ID of the only kmd wallet a ScopeWallet token may use
+	Wallet string `json:"wallet,omitempty"`
+	// Created and Expires are unix timestamps. A token which never expires
+	// has an Expires of zero.
+	Created int64 `json:"created"`
+	Expires int64 `json:"expires,omitempty"`
+}
+
+// Expired returns true if the token may no longer be used at time now
+func (t ScopedToken) Expired(now time.Time) bool {
+	return t.Expires != 0 && now.Unix() >= t.Expires
+}
+
+type scopedTokensFile struct {
+	Tokens []ScopedToken `json:"tokens"`
+}
+
+func hashAPIToken(apiToken string) string {
+	hash := sha512.Sum512_256([]byte(apiToken))
+	return hex.EncodeToString(hash[:])
+}
+
+// LoadScopedTokens reads the named tokens from the datadir. A missing file
+// holds no tokens.
+func LoadScopedTokens(dataDir, tokensFilename string) ([]ScopedToken, error) {
+	data, err := ioutil.ReadFile(tokenFilepath(dataDir, tokensFilename))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return parseScopedTokens(data, tokensFilename)
+}
+
+func parseScopedTokens(data []byte, tokensFilename string) ([]ScopedToken, error) {
+	var file scopedTokensFile
+	err := json.Unmarshal(data, &file)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse %s: %v", tokensFilename, err)
+	}
+	return file.Tokens, nil
+}
+
+// writeScopedTokensToDisk replaces the named tokens in the datadir. The new
+// file is renamed into place so that a daemon never reads a partial file.
+func writeScopedTokensToDisk(dataDir, tokensFilename string, scopedTokens []ScopedToken) error {
+	data, err := json.MarshalIndent(scopedTokensFile{Tokens: scopedTokens}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	filepath := tokenFilepath(dataDir, tokensFilename)
+	tmpFilepath := filepath + ".tmp"
+	err = ioutil.WriteFile(tmpFilepath, data, 0600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmpFilepath, filepath)
+}
+
+// GenerateScopedToken adds a named token with the given scope to the datadir
+// and returns the token. A zero lifetime generates a token which never expires.
+func GenerateScopedToken(dataDir, tokensFilename, name string, scope Scope, wallet string, lifetime time.Duration) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("token name must not be empty")
+	}
+	if lifetime < 0 {
+		return "", fmt.Errorf("token lifetime must not be negative")
+	}
+	if (scope == ScopeWallet) != (wallet != "") {
+		return "", fmt.Errorf("a wallet must be given for, and only for, %s scoped tokens", ScopeWallet)
+	}
+
+	scopedTokens, err := LoadScopedTokens(dataDir, tokensFilename)
+	if err != nil {
+		return "", err
+	}
+	for _, token := range scopedTokens {
+		if token.Name == name {
+			return "", fmt.Errorf("a token named %s already exists", name)
+		}
+	}
+
+	apiToken, err := generateAPITokenString()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	token := ScopedToken{
+		Name:    name,
+		Hash:    hashAPIToken(apiToken),
+		Scope:   scope,
+		Wallet:  wallet,
+		Created: now.Unix(),
+	}
+	if lifetime != 0 {
+		token.Expires = now.Add(lifetime).Unix()
+	}
+
+	return apiToken, writeScopedTokensToDisk(dataDir, tokensFilename, append(scopedTokens, token))
+}
+
+// RevokeScopedToken removes the named token from the datadir
+func RevokeScopedToken(dataDir, tokensFilename, name string) error {
+	scopedTokens, err := LoadScopedTokens(dataDir, tokensFilename)
+	if err != nil {
+		return err
+	}
+
+	for i, token := range scopedTokens {
+		if token.Name == name {
+			scopedTokens = append(scopedTokens[:i], scopedTokens[i+1:]...)
+			return writeScopedTokensToDisk(dataDir, tokensFilename, scopedTokens)
+		}
+	}
+	return fmt.Errorf("no token named %s", name)
+}
+
+// ScopedTokenStore looks up the named tokens of a daemon. It rereads the
+// tokens file whenever it changes, so tokens may be generated and revoked
+// while the daemon is running.
+type ScopedTokenStore struct {
+	dataDir        string
+	tokensFilename string
+
+	mu     deadlock.Mutex
+	digest [sha512.Size256]byte
+	tokens []ScopedToken
+}
+
+// MakeScopedTokenStore creates a ScopedTokenStore for the tokens file in the datadir
+func MakeScopedTokenStore(dataDir, tokensFilename string) *ScopedTokenStore {
+	return &ScopedTokenStore{
+		dataDir:        dataDir,
+		tokensFilename: tokensFilename,
+	}
+}
+
+// refreshLocked rereads the tokens file, and parses it again if its content
+// changed since it was last parsed. The content is compared rather than the
+// file modification time, which may not change when a token is revoked right
+// after it was generated. If the file can't be read no token is accepted
+// until it is fixed.
+func (s *ScopedTokenStore) refreshLocked() {
+	data, err := ioutil.ReadFile(tokenFilepath(s.dataDir, s.tokensFilename))
+	if err != nil {
+		s.digest = [sha512.Size256]byte{}
+		s.tokens = nil
+		return
+	}
+	digest := sha512.Sum512_256(data)
+	if digest == s.digest {
+		return
+	}
+
+	s.digest = digest
+	s.tokens, err = parseScopedTokens(data, s.tokensFilename)
+	if err != nil {
+		s.tokens = nil
+	}
+}
+
+// Lookup returns the named token matching apiToken, if there is one and it
+// has not expired
+func (s *ScopedTokenStore) Lookup(apiToken string) (ScopedToken, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.refreshLocked()
+
+	if len(s.tokens) == 0 {
+		return ScopedToken{}, false
+	}
+
+	hash := []byte(hashAPIToken(apiToken))
+	now := time.Now()
+	var found ScopedToken
+	ok := false
+	// Compare against every token in constant time
+	for _, token := range s.tokens {
+		if subtle.ConstantTimeCompare(hash, []byte(token.Hash)) == 1 {
+			found = token
+			ok = true
+		}
+	}
+	if !ok || found.Expired(now) {
+		return ScopedToken{}, false
+	}
+	return found, true
+}
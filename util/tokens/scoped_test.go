@@ -0,0 +1,110 @@
+// Copyright (C) 2019-2020 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package tokens
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScopedTokens(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "scoped-tokens")
+	require.NoError(t, err)
+	defer os.RemoveAll(dataDir)
+
+	store := MakeScopedTokenStore(dataDir, AlgodScopedTokensFilename)
+	_, ok := store.Lookup("no tokens yet")
+	require.False(t, ok)
+
+	readToken, err := GenerateScopedToken(dataDir, AlgodScopedTokensFilename, "reader", ScopeRead, "", 0)
+	require.NoError(t, err)
+	require.NoError(t, ValidateAPIToken(readToken))
+
+	_, err = GenerateScopedToken(dataDir, AlgodScopedTokensFilename, "reader", ScopeAdmin, "", 0)
+	require.Error(t, err)
+	_, err = GenerateScopedToken(dataDir, AlgodScopedTokensFilename, "nowallet", ScopeWallet, "", 0)
+	require.Error(t, err)
+
+	adminToken, err := GenerateScopedToken(dataDir, AlgodScopedTokensFilename, "admin", ScopeAdmin, "", time.Hour)
+	require.NoError(t, err)
+
+	// Only the hashes are stored on disk
+	data, err := ioutil.ReadFile(tokenFilepath(dataDir, AlgodScopedTokensFilename))
+	require.NoError(t, err)
+	require.NotContains(t, string(data), readToken)
+	require.NotContains(t, string(data), adminToken)
+
+	scopedTokens, err := LoadScopedTokens(dataDir, AlgodScopedTokensFilename)
+	require.NoError(t, err)
+	require.Len(t, scopedTokens, 2)
+	require.Zero(t, scopedTokens[0].Expires)
+	require.NotZero(t, scopedTokens[1].Expires)
+
+	token, ok := store.Lookup(readToken)
+	require.True(t, ok)
+	require.Equal(t, "reader", token.Name)
+	require.Equal(t, ScopeRead, token.Scope)
+
+	token, ok = store.Lookup(adminToken)
+	require.True(t, ok)
+	require.Equal(t, ScopeAdmin, token.Scope)
+
+	_, ok = store.Lookup(readToken + "0")
+	require.False(t, ok)
+
+	// Revoked tokens are no longer accepted by a running store
+	require.NoError(t, RevokeScopedToken(dataDir, AlgodScopedTokensFilename, "reader"))
+	require.Error(t, RevokeScopedToken(dataDir, AlgodScopedTokensFilename, "reader"))
+	_, ok = store.Lookup(readToken)
+	require.False(t, ok)
+	_, ok = store.Lookup(adminToken)
+	require.True(t, ok)
+
+	// Rewriting the file with the same size and modification time still
+	// revokes the token
+	filename := tokenFilepath(dataDir, AlgodScopedTokensFilename)
+	info, err := os.Stat(filename)
+	require.NoError(t, err)
+	scopedTokens, err = LoadScopedTokens(dataDir, AlgodScopedTokensFilename)
+	require.NoError(t, err)
+	adminHash := scopedTokens[0].Hash
+	scopedTokens[0].Hash = hashAPIToken(readToken)
+	require.NoError(t, writeScopedTokensToDisk(dataDir, AlgodScopedTokensFilename, scopedTokens))
+	require.NoError(t, os.Chtimes(filename, info.ModTime(), info.ModTime()))
+	rewritten, err := os.Stat(filename)
+	require.NoError(t, err)
+	require.Equal(t, info.Size(), rewritten.Size())
+	require.Equal(t, info.ModTime(), rewritten.ModTime())
+	_, ok = store.Lookup(adminToken)
+	require.False(t, ok)
+	scopedTokens[0].Hash = adminHash
+	require.NoError(t, writeScopedTokensToDisk(dataDir, AlgodScopedTokensFilename, scopedTokens))
+	_, ok = store.Lookup(adminToken)
+	require.True(t, ok)
+
+	// Expired tokens are rejected
+	scopedTokens, err = LoadScopedTokens(dataDir, AlgodScopedTokensFilename)
+	require.NoError(t, err)
+	scopedTokens[0].Expires = time.Now().Add(-time.Minute).Unix()
+	require.NoError(t, writeScopedTokensToDisk(dataDir, AlgodScopedTokensFilename, scopedTokens))
+	_, ok = MakeScopedTokenStore(dataDir, AlgodScopedTokensFilename).Lookup(adminToken)
+	require.False(t, ok)
+}
@@ -62,8 +62,8 @@ func writeAPITokenToDisk(dataDir, tokenFilename, apiToken string) error {
 	return ioutil.WriteFile(filepath, []byte(apiToken), 0644)
 }
 
-// GenerateAPIToken writes a cryptographically secure APIToken to disk
-func GenerateAPIToken(dataDir, tokenFilename string) (string, error) {
+// generateAPITokenString returns a cryptographically secure APIToken
+func generateAPITokenString() (string, error) {
 	// Random bytes will be converted to hex to make token
 	var entropyLen = (minimumAPITokenLength + 1) / 2
 	tokenBytes := make([]byte, entropyLen)
@@ -83,6 +83,16 @@ func GenerateAPIToken(dataDir, tokenFilename string) (string, error) {
 		return "", fmt.Errorf("generated invalid token: %v", err)
 	}
 
+	return hexToken, nil
+}
+
+// GenerateAPIToken writes a cryptographically secure APIToken to disk
+func GenerateAPIToken(dataDir, tokenFilename string) (string, error) {
+	hexToken, err := generateAPITokenString()
+	if err != nil {
+		return "", err
+	}
+
 	// Persist the token to disk
 	return hexToken, writeAPITokenToDisk(dataDir, tokenFilename, hexToken)
 }